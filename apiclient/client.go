@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	clienthttp "github.com/AlaudaDevops/pkg/client/http"
+	"github.com/AlaudaDevops/pkg/credentials"
+	kerrors "github.com/AlaudaDevops/pkg/errors"
+	"github.com/AlaudaDevops/pkg/retry"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxErrorBodySize limits the body read from failed responses
+const maxErrorBodySize = 1 << 20
+
+// Option configures the client created by New
+type Option func(*options)
+
+type options struct {
+	httpOptions []clienthttp.Option
+	retryPolicy *retry.Policy
+	header      http.Header
+	resource    schema.GroupResource
+}
+
+// WithAuthenticator authenticates all requests, e.g. with credentials resolved from a secret
+func WithAuthenticator(auth credentials.HTTPAuthenticator) Option {
+	return func(o *options) {
+		o.httpOptions = append(o.httpOptions, clienthttp.WithAuthenticator(auth))
+	}
+}
+
+// WithRetry sets the policy retrying idempotent requests failing with network errors
+// or 429 and 5xx status codes, defaults to retry.DefaultPolicy.
+// Use a policy with MaxAttempts 1 to disable retries
+func WithRetry(policy retry.Policy) Option {
+	return func(o *options) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithHTTPOptions configures the underlying http client, e.g. timeouts, CAs or proxies
+func WithHTTPOptions(opts ...clienthttp.Option) Option {
+	return func(o *options) {
+		o.httpOptions = append(o.httpOptions, opts...)
+	}
+}
+
+// WithHeader adds a header to all requests
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithResource sets the resource used in errors of responses without a status envelope,
+// defaults to errors.RESTClientGroupResource
+func WithResource(resource schema.GroupResource) Option {
+	return func(o *options) {
+		o.resource = resource
+	}
+}
+
+// Client sends JSON requests relative to a base url
+type Client struct {
+	baseURL  *url.URL
+	client   *http.Client
+	header   http.Header
+	resource schema.GroupResource
+}
+
+// New returns a client for the API at baseURL configured by the options
+func New(baseURL string, opts ...Option) (*Client, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url %q: %w", baseURL, err)
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base url %q: scheme and host are required", baseURL)
+	}
+
+	o := &options{
+		header:   http.Header{},
+		resource: kerrors.RESTClientGroupResource,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	policy := retry.DefaultPolicy()
+	if o.retryPolicy != nil {
+		policy = *o.retryPolicy
+	}
+	// a retry option passed in WithHTTPOptions takes precedence
+	httpOptions := append([]clienthttp.Option{clienthttp.WithRetry(policy)}, o.httpOptions...)
+	clt, err := clienthttp.NewClient(httpOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{baseURL: base, client: clt, header: o.header, resource: o.resource}, nil
+}
+
+// RequestOption configures a single request
+type RequestOption func(*http.Request)
+
+// WithQuery adds the values to the query of the request
+func WithQuery(values url.Values) RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		for key, vals := range values {
+			for _, val := range vals {
+				query.Add(key, val)
+			}
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// WithRequestHeader sets a header of the request, e.g. clienthttp.IdempotencyKeyHeader
+// to retry a create request
+func WithRequestHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// Do sends a request with method to path, relative to the base url, encoding body as JSON if not nil
+// and decoding the response into out if not nil. Failed responses return an *errors.Error
+// decoded from the status envelope of the body or from the status code
+func (c *Client) Do(ctx context.Context, method, path string, body, out any, opts ...RequestOption) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.JoinPath(path).String(), reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return c.decodeError(req, resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("decode response of %s %s: %w", method, req.URL.Path, err)
+	}
+	return nil
+}
+
+// decodeError returns the error in the status envelope of the response,
+// or a generic error for the status code when the body is not a status
+func (c *Client) decodeError(req *http.Request, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+
+	var status metav1.Status
+	if json.Unmarshal(body, &status) == nil && (status.Reason != "" || status.Message != "") {
+		if status.Code == 0 {
+			status.Code = int32(resp.StatusCode)
+		}
+		if retryAfter > 0 {
+			if status.Details == nil {
+				status.Details = &metav1.StatusDetails{}
+			}
+			if status.Details.RetryAfterSeconds == 0 {
+				status.Details.RetryAfterSeconds = int32(retryAfter)
+			}
+		}
+		return kerrors.FromStatus(status)
+	}
+	statusErr := apierrors.NewGenericServerResponse(resp.StatusCode, req.Method, c.resource, "", string(body), retryAfter, true)
+	return kerrors.FromStatus(statusErr.Status())
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	clienthttp "github.com/AlaudaDevops/pkg/client/http"
+	"github.com/AlaudaDevops/pkg/credentials"
+	kerrors "github.com/AlaudaDevops/pkg/errors"
+	"github.com/AlaudaDevops/pkg/retry"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type repository struct {
+	Name string `json:"name"`
+}
+
+var repositories = schema.GroupResource{Group: "tools.alauda.io", Resource: "repositories"}
+
+func writeJSON(w http.ResponseWriter, code int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func newServer(t *testing.T) (*httptest.Server, *int32) {
+	var flaky int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repositories/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if name := r.PathValue("name"); name != "demo" {
+			writeJSON(w, http.StatusNotFound, kerrors.ToStatus(kerrors.NewNotFound(repositories, name)))
+			return
+		}
+		writeJSON(w, http.StatusOK, repository{Name: "demo"})
+	})
+	mux.HandleFunc("GET /api/v1/repositories", func(w http.ResponseWriter, r *http.Request) {
+		opts, err := metav1alpha1.ParseListOptions(r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, kerrors.ToStatus(apierrors.NewBadRequest(err.Error())))
+			return
+		}
+		list := ListResult[repository]{Items: []repository{{Name: "a"}, {Name: "b"}}}
+		list.TotalItems = 10
+		list.Page = &opts.Page
+		writeJSON(w, http.StatusOK, list)
+	})
+	mux.HandleFunc("POST /api/v1/repositories", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			writeJSON(w, http.StatusUnauthorized, kerrors.ToStatus(kerrors.NewUnauthorized("")))
+			return
+		}
+		var repo repository
+		_ = json.NewDecoder(r.Body).Decode(&repo)
+		if repo.Name == "" {
+			writeJSON(w, http.StatusUnprocessableEntity, kerrors.ToStatus(kerrors.NewValidation(repositories, "", field.ErrorList{
+				field.Required(field.NewPath("name"), ""),
+			})))
+			return
+		}
+		writeJSON(w, http.StatusCreated, repo)
+	})
+	mux.HandleFunc("GET /api/v1/flaky", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&flaky, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, repository{Name: "flaky"})
+	})
+	mux.HandleFunc("GET /api/v1/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream exploded", http.StatusBadGateway)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &flaky
+}
+
+func newClient(t *testing.T, baseURL string, opts ...Option) *Client {
+	opts = append([]Option{
+		WithRetry(retry.Policy{InitialInterval: time.Millisecond, Multiplier: 1, MaxAttempts: 3}),
+		WithHTTPOptions(clienthttp.WithTracing(false), clienthttp.WithMetrics(false)),
+	}, opts...)
+	clt, err := New(baseURL, opts...)
+	NewGomegaWithT(t).Expect(err).To(BeNil())
+	return clt
+}
+
+func TestGet(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	server, flaky := newServer(t)
+	clt := newClient(t, server.URL+"/api/v1")
+
+	repo, err := Get[repository](ctx, clt, "repositories/demo")
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Name).To(Equal("demo"))
+
+	_, err = Get[repository](ctx, clt, "repositories/missing")
+	g.Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(err.Error()).To(Equal(`repositories.tools.alauda.io "missing" not found`))
+
+	repo, err = Get[repository](ctx, clt, "flaky")
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Name).To(Equal("flaky"))
+	g.Expect(atomic.LoadInt32(flaky)).To(Equal(int32(3)))
+
+	_, err = Get[repository](ctx, clt, "broken")
+	g.Expect(kerrors.HTTPStatusCode(err)).To(Equal(http.StatusBadGateway))
+	g.Expect(retry.IsTransient(err)).To(BeTrue())
+}
+
+func TestList(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server, _ := newServer(t)
+	clt := newClient(t, server.URL+"/api/v1")
+
+	list, err := List[repository](context.Background(), clt, "repositories", metav1alpha1.ListOptions{Page: 2, ItemsPerPage: 2})
+	g.Expect(err).To(BeNil())
+	g.Expect(list.Items).To(HaveLen(2))
+	g.Expect(list.TotalItems).To(Equal(10))
+	g.Expect(*list.Page).To(Equal(2))
+}
+
+func TestCreate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	server, _ := newServer(t)
+
+	_, err := Create(ctx, newClient(t, server.URL+"/api/v1"), "repositories", &repository{Name: "new"})
+	g.Expect(kerrors.IsUnauthorized(err)).To(BeTrue())
+
+	clt := newClient(t, server.URL+"/api/v1", WithAuthenticator(&credentials.BearerToken{Token: "secret"}))
+	repo, err := Create(ctx, clt, "repositories", &repository{Name: "new"})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Name).To(Equal("new"))
+
+	_, err = Create(ctx, clt, "repositories", &repository{})
+	g.Expect(kerrors.IsValidation(err)).To(BeTrue())
+	var typed *kerrors.Error
+	g.Expect(err).To(BeAssignableToTypeOf(typed))
+	g.Expect(err.(*kerrors.Error).FieldErrors()).To(HaveLen(1))
+}
+
+func TestNew(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := New("api/v1")
+	g.Expect(err).NotTo(BeNil())
+	_, err = New("://bad")
+	g.Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apiclient is a small REST client for platform APIs returning JSON objects
+// and the standard metav1.Status error envelope. Requests are authenticated with
+// credentials, idempotent requests are retried and failed responses are decoded
+// into the typed errors of the errors package.
+//
+//	clt, err := apiclient.New("https://api.example.com/v1",
+//		apiclient.WithAuthenticator(cred),
+//		apiclient.WithRetry(retry.DefaultPolicy()),
+//	)
+//	repo, err := apiclient.Get[Repository](ctx, clt, "repositories/"+name)
+//	if errors.IsNotFound(err) {
+//		repo, err = apiclient.Create(ctx, clt, "repositories", &Repository{Name: name})
+//	}
+package apiclient
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiclient
+
+import (
+	"context"
+	"net/http"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+// ListResult is a page of items returned by List
+type ListResult[T any] struct {
+	metav1alpha1.ListMeta `json:"metadata,omitempty"`
+
+	Items []T `json:"items"`
+}
+
+// Get returns the object at path
+func Get[T any](ctx context.Context, c *Client, path string, opts ...RequestOption) (*T, error) {
+	obj := new(T)
+	if err := c.Do(ctx, http.MethodGet, path, nil, obj, opts...); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// List returns the page of items at path selected by the list options
+func List[T any](ctx context.Context, c *Client, path string, listOpts metav1alpha1.ListOptions, opts ...RequestOption) (*ListResult[T], error) {
+	list := &ListResult[T]{}
+	opts = append([]RequestOption{WithQuery(listOpts.Encode())}, opts...)
+	if err := c.Do(ctx, http.MethodGet, path, nil, list, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Create posts obj to path and returns the created object.
+// Create requests are only retried with an idempotency key header
+func Create[T any](ctx context.Context, c *Client, path string, obj *T, opts ...RequestOption) (*T, error) {
+	created := new(T)
+	if err := c.Do(ctx, http.MethodPost, path, obj, created, opts...); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
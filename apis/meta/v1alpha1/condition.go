@@ -25,6 +25,10 @@ import (
 
 const (
 	ConditionReasonNotSet = "NotSet"
+	// ConditionReasonPaused reason of the Paused condition when reconciliation is paused
+	ConditionReasonPaused = "Paused"
+	// ConditionReasonResumed reason of the Paused condition when reconciliation is resumed
+	ConditionReasonResumed = "Resumed"
 )
 
 // ReasonForError returns a string for Reason from an error. If the error is a apimachinery.StatusError
@@ -138,4 +142,7 @@ const (
 	// ConditionCanceled specifies that the resource is canceled.
 	// For resource which run to canceled.
 	ConditionCanceled ConditionType = "Canceled"
+	// ConditionPaused specifies that the reconciliation of the resource is paused.
+	// For resource with the paused annotation.
+	ConditionPaused ConditionType = "Paused"
 )
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionSeverity indicates how a dependent condition affects the aggregated condition
+type ConditionSeverity string
+
+const (
+	// ConditionSeverityError dependent conditions must be True for the aggregated condition to be True
+	ConditionSeverityError ConditionSeverity = "Error"
+	// ConditionSeverityWarning dependent conditions are reported in the message but do not
+	// change the status of the aggregated condition
+	ConditionSeverityWarning ConditionSeverity = "Warning"
+	// ConditionSeverityInfo dependent conditions are ignored by the aggregation
+	ConditionSeverityInfo ConditionSeverity = "Info"
+)
+
+const (
+	// ConditionReasonDependenciesReady reason of the aggregated condition when all dependents are True
+	ConditionReasonDependenciesReady = "DependenciesReady"
+	// ConditionReasonDependenciesNotReady reason of the aggregated condition
+	// when a dependent is not True and has no reason
+	ConditionReasonDependenciesNotReady = "DependenciesNotReady"
+)
+
+// DependentCondition a condition aggregated by a ConditionAggregator
+// +k8s:deepcopy-gen=false
+type DependentCondition struct {
+	// Type of the condition
+	Type ConditionType
+	// Severity of the condition, defaults to Error
+	Severity ConditionSeverity
+	// Weight orders dependents when reporting the not ready ones,
+	// the reason of the highest weight dependent is used for the aggregated condition
+	Weight int
+}
+
+// ConditionAggregator computes a top level condition from dependent conditions.
+// The aggregated condition is:
+//   - False when any Error dependent is False, using its reason
+//   - Unknown when any Error dependent is Unknown or missing
+//   - True otherwise
+//
+// with a message summarizing the dependents, e.g. "2/3 components ready"
+// followed by the messages of the not ready ones.
+// +k8s:deepcopy-gen=false
+type ConditionAggregator struct {
+	// Type of the aggregated condition
+	Type ConditionType
+	// Dependents conditions
+	Dependents []DependentCondition
+}
+
+// NewConditionAggregator returns a ConditionAggregator for conditionType
+// with dependents of Error severity and the same weight
+func NewConditionAggregator(conditionType ConditionType, dependents ...ConditionType) *ConditionAggregator {
+	a := &ConditionAggregator{Type: conditionType}
+	for _, dependent := range dependents {
+		a.WithDependent(dependent, ConditionSeverityError, 0)
+	}
+	return a
+}
+
+// WithDependent adds a dependent condition, replacing the one with the same type
+func (a *ConditionAggregator) WithDependent(conditionType ConditionType, severity ConditionSeverity, weight int) *ConditionAggregator {
+	dependent := DependentCondition{Type: conditionType, Severity: severity, Weight: weight}
+	for i := range a.Dependents {
+		if a.Dependents[i].Type == conditionType {
+			a.Dependents[i] = dependent
+			return a
+		}
+	}
+	a.Dependents = append(a.Dependents, dependent)
+	return a
+}
+
+// IsDependent returns true if conditionType is a dependent of the aggregator
+func (a *ConditionAggregator) IsDependent(conditionType ConditionType) bool {
+	for _, dependent := range a.Dependents {
+		if dependent.Type == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// Aggregate returns the aggregated condition of the conditions
+func (a *ConditionAggregator) Aggregate(conditions []metav1.Condition) metav1.Condition {
+	type notReady struct {
+		DependentCondition
+		condition *metav1.Condition
+	}
+	var (
+		total, ready      int
+		failing, warnings []notReady
+		status            = metav1.ConditionTrue
+	)
+	for _, dependent := range a.Dependents {
+		if dependent.Severity == ConditionSeverityInfo {
+			continue
+		}
+		total++
+		condition := findCondition(conditions, dependent.Type)
+		if condition != nil && condition.Status == metav1.ConditionTrue {
+			ready++
+			continue
+		}
+		if dependent.Severity == ConditionSeverityWarning {
+			warnings = append(warnings, notReady{dependent, condition})
+			continue
+		}
+		failing = append(failing, notReady{dependent, condition})
+		if condition != nil && condition.Status == metav1.ConditionFalse {
+			status = metav1.ConditionFalse
+		} else if status != metav1.ConditionFalse {
+			status = metav1.ConditionUnknown
+		}
+	}
+
+	// False dependents first, then by weight keeping declaration order
+	sort.SliceStable(failing, func(i, j int) bool {
+		iFalse := failing[i].condition != nil && failing[i].condition.Status == metav1.ConditionFalse
+		jFalse := failing[j].condition != nil && failing[j].condition.Status == metav1.ConditionFalse
+		if iFalse != jFalse {
+			return iFalse
+		}
+		return failing[i].Weight > failing[j].Weight
+	})
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Weight > warnings[j].Weight
+	})
+
+	aggregated := metav1.Condition{
+		Type:   string(a.Type),
+		Status: status,
+		Reason: ConditionReasonDependenciesReady,
+	}
+	if len(failing) > 0 {
+		aggregated.Reason = ConditionReasonDependenciesNotReady
+		if failing[0].condition != nil && failing[0].condition.Reason != "" {
+			aggregated.Reason = failing[0].condition.Reason
+		}
+	}
+
+	messages := []string{fmt.Sprintf("%d/%d components ready", ready, total)}
+	for _, item := range append(failing, warnings...) {
+		message := "condition is not set"
+		if item.condition != nil {
+			message = item.condition.Message
+		}
+		if message == "" {
+			message = "condition is " + string(item.condition.Status)
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", item.Type, message))
+	}
+	aggregated.Message = strings.Join(messages, "; ")
+	return aggregated
+}
+
+// Apply sets the aggregated condition in the ConditionManager
+func (a *ConditionAggregator) Apply(m *ConditionManager) {
+	if m.conditions == nil {
+		return
+	}
+	m.SetCondition(a.Aggregate(*m.conditions))
+}
+
+func findCondition(conditions []metav1.Condition, conditionType ConditionType) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == string(conditionType) {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionAggregator_Aggregate(t *testing.T) {
+	aggregator := NewConditionAggregator(ConditionReady, "Database", "Cache").
+		WithDependent("Storage", ConditionSeverityError, 10).
+		WithDependent("Metrics", ConditionSeverityWarning, 0).
+		WithDependent("Docs", ConditionSeverityInfo, 0)
+
+	var data = []struct {
+		desc       string
+		conditions []metav1.Condition
+		expected   metav1.Condition
+	}{
+		{
+			desc: "all ready",
+			conditions: []metav1.Condition{
+				{Type: "Database", Status: metav1.ConditionTrue},
+				{Type: "Cache", Status: metav1.ConditionTrue},
+				{Type: "Storage", Status: metav1.ConditionTrue},
+				{Type: "Metrics", Status: metav1.ConditionTrue},
+			},
+			expected: metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: ConditionReasonDependenciesReady, Message: "4/4 components ready"},
+		},
+		{
+			desc: "warning does not change the status",
+			conditions: []metav1.Condition{
+				{Type: "Database", Status: metav1.ConditionTrue},
+				{Type: "Cache", Status: metav1.ConditionTrue},
+				{Type: "Storage", Status: metav1.ConditionTrue},
+				{Type: "Metrics", Status: metav1.ConditionFalse, Reason: "ScrapeFailed", Message: "scrape failed"},
+				{Type: "Docs", Status: metav1.ConditionFalse},
+			},
+			expected: metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: ConditionReasonDependenciesReady, Message: "3/4 components ready; Metrics: scrape failed"},
+		},
+		{
+			desc: "missing dependents are unknown",
+			conditions: []metav1.Condition{
+				{Type: "Database", Status: metav1.ConditionTrue},
+				{Type: "Storage", Status: metav1.ConditionUnknown, Reason: "Provisioning"},
+				{Type: "Metrics", Status: metav1.ConditionTrue},
+			},
+			expected: metav1.Condition{Type: "Ready", Status: metav1.ConditionUnknown, Reason: "Provisioning", Message: "2/4 components ready; Storage: condition is Unknown; Cache: condition is not set"},
+		},
+		{
+			desc: "false dependents are reported first",
+			conditions: []metav1.Condition{
+				{Type: "Database", Status: metav1.ConditionFalse, Reason: "ConnectionRefused", Message: "connection refused"},
+				{Type: "Cache", Status: metav1.ConditionTrue},
+				{Type: "Storage", Status: metav1.ConditionUnknown, Reason: "Provisioning", Message: "provisioning volume"},
+			},
+			expected: metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "ConnectionRefused", Message: "1/4 components ready; Database: connection refused; Storage: provisioning volume; Metrics: condition is not set"},
+		},
+		{
+			desc: "higher weight reason is used",
+			conditions: []metav1.Condition{
+				{Type: "Database", Status: metav1.ConditionFalse, Reason: "ConnectionRefused"},
+				{Type: "Cache", Status: metav1.ConditionTrue},
+				{Type: "Storage", Status: metav1.ConditionFalse, Message: "volume lost"},
+				{Type: "Metrics", Status: metav1.ConditionTrue},
+			},
+			expected: metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: ConditionReasonDependenciesNotReady, Message: "2/4 components ready; Storage: volume lost; Database: condition is False"},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(aggregator.Aggregate(item.conditions)).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestConditionAggregator_Apply(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	conditions := []metav1.Condition{}
+	m := NewConditionManager(&conditions, 2)
+	aggregator := NewConditionAggregator(ConditionReady, "Database").WithDependent("Database", ConditionSeverityWarning, 1)
+	g.Expect(aggregator.Dependents).To(HaveLen(1))
+	g.Expect(aggregator.IsDependent("Database")).To(BeTrue())
+	g.Expect(aggregator.IsDependent("Cache")).To(BeFalse())
+
+	aggregator.Apply(m)
+	ready := m.GetCondition(ConditionReady)
+	g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(ready.ObservedGeneration).To(Equal(int64(2)))
+	g.Expect(ready.Message).To(Equal("0/1 components ready; Database: condition is not set"))
+}
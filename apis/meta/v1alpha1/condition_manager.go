@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings"
+)
+
+// ConditionTransitionFunc is called when a condition is added or its status or reason changes,
+// previous is nil when the condition is added
+type ConditionTransitionFunc func(previous *metav1.Condition, current metav1.Condition)
+
+// ConditionManager manages a list of metav1.Condition, keeping conditions
+// sorted by type and only updating LastTransitionTime when the status changes.
+// Useful for CRDs using metav1.Condition in their status.
+// +k8s:deepcopy-gen=false
+type ConditionManager struct {
+	conditions   *[]metav1.Condition
+	generation   int64
+	now          func() time.Time
+	onTransition []ConditionTransitionFunc
+}
+
+// NewConditionManager returns a ConditionManager for the conditions.
+// generation is set as the ObservedGeneration of the conditions that are set
+func NewConditionManager(conditions *[]metav1.Condition, generation int64) *ConditionManager {
+	return &ConditionManager{conditions: conditions, generation: generation, now: time.Now}
+}
+
+// WithClock sets the function used to get the current time
+func (m *ConditionManager) WithClock(now func() time.Time) *ConditionManager {
+	m.now = now
+	return m
+}
+
+// OnTransition adds a function called on each transition of a condition,
+// e.g. to record events of the transitions
+func (m *ConditionManager) OnTransition(fn ConditionTransitionFunc) *ConditionManager {
+	m.onTransition = append(m.onTransition, fn)
+	return m
+}
+
+// IsConditionTransition returns true if current is a new condition or changes the status or reason of previous
+func IsConditionTransition(previous *metav1.Condition, current metav1.Condition) bool {
+	return previous == nil || previous.Status != current.Status || previous.Reason != current.Reason
+}
+
+// GetCondition returns the condition by type or nil if not found
+func (m *ConditionManager) GetCondition(conditionType ConditionType) *metav1.Condition {
+	if m.conditions == nil {
+		return nil
+	}
+	for i := range *m.conditions {
+		if (*m.conditions)[i].Type == string(conditionType) {
+			return &(*m.conditions)[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates a condition.
+// LastTransitionTime is only updated when the status changes or is not set,
+// ObservedGeneration defaults to the generation of the manager and
+// Reason defaults to NotSet as required by metav1.Condition validation
+func (m *ConditionManager) SetCondition(condition metav1.Condition) {
+	if m.conditions == nil {
+		return
+	}
+	if condition.Reason == "" {
+		condition.Reason = ConditionReasonNotSet
+	}
+	if condition.ObservedGeneration == 0 {
+		condition.ObservedGeneration = m.generation
+	}
+	condition.Message = strings.ShortenString(condition.Message, MaxConditionMessageLength)
+
+	if existing := m.GetCondition(ConditionType(condition.Type)); existing != nil {
+		if existing.Status == condition.Status && !existing.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		} else if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.NewTime(m.now())
+		}
+		previous := *existing
+		*existing = condition
+		m.transition(&previous, condition)
+		return
+	}
+
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.NewTime(m.now())
+	}
+	*m.conditions = append(*m.conditions, condition)
+	SortConditions(*m.conditions)
+	m.transition(nil, condition)
+}
+
+func (m *ConditionManager) transition(previous *metav1.Condition, current metav1.Condition) {
+	if !IsConditionTransition(previous, current) {
+		return
+	}
+	for _, fn := range m.onTransition {
+		fn(previous, current)
+	}
+}
+
+// RemoveCondition removes a condition by type, returns true if it was removed
+func (m *ConditionManager) RemoveCondition(conditionType ConditionType) bool {
+	if m.conditions == nil {
+		return false
+	}
+	for i := range *m.conditions {
+		if (*m.conditions)[i].Type == string(conditionType) {
+			*m.conditions = append((*m.conditions)[:i], (*m.conditions)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// MarkTrue sets the condition status to True
+func (m *ConditionManager) MarkTrue(conditionType ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.mark(conditionType, metav1.ConditionTrue, reason, messageFormat, messageA...)
+}
+
+// MarkFalse sets the condition status to False
+func (m *ConditionManager) MarkFalse(conditionType ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.mark(conditionType, metav1.ConditionFalse, reason, messageFormat, messageA...)
+}
+
+// MarkUnknown sets the condition status to Unknown
+func (m *ConditionManager) MarkUnknown(conditionType ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.mark(conditionType, metav1.ConditionUnknown, reason, messageFormat, messageA...)
+}
+
+func (m *ConditionManager) mark(conditionType ConditionType, status metav1.ConditionStatus, reason, messageFormat string, messageA ...interface{}) {
+	message := messageFormat
+	if len(messageA) > 0 {
+		message = fmt.Sprintf(messageFormat, messageA...)
+	}
+	m.SetCondition(metav1.Condition{
+		Type:    string(conditionType),
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// IsTrue returns true if the condition exists and its status is True
+func (m *ConditionManager) IsTrue(conditionType ConditionType) bool {
+	condition := m.GetCondition(conditionType)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// IsFalse returns true if the condition exists and its status is False
+func (m *ConditionManager) IsFalse(conditionType ConditionType) bool {
+	condition := m.GetCondition(conditionType)
+	return condition != nil && condition.Status == metav1.ConditionFalse
+}
+
+// IsUnknown returns true if the condition does not exist or its status is Unknown
+func (m *ConditionManager) IsUnknown(conditionType ConditionType) bool {
+	condition := m.GetCondition(conditionType)
+	return condition == nil || condition.Status == metav1.ConditionUnknown
+}
+
+// SortConditions sorts conditions by type
+func SortConditions(conditions []metav1.Condition) {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].Type < conditions[j].Type
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionManager(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := first
+	conditions := []metav1.Condition{}
+	m := NewConditionManager(&conditions, 3).WithClock(func() time.Time { return now })
+
+	g.Expect(m.GetCondition(ConditionReady)).To(BeNil())
+	g.Expect(m.IsUnknown(ConditionReady)).To(BeTrue())
+
+	m.MarkFalse(ConditionReady, "Reconciling", "waiting for %d replicas", 2)
+	m.MarkTrue(ConditionPending, "", "")
+	g.Expect(conditions).To(HaveLen(2))
+	g.Expect(conditions[0].Type).To(Equal(string(ConditionPending)), "conditions should be sorted by type")
+	g.Expect(conditions[0].Reason).To(Equal(ConditionReasonNotSet))
+
+	ready := m.GetCondition(ConditionReady)
+	g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(ready.Message).To(Equal("waiting for 2 replicas"))
+	g.Expect(ready.ObservedGeneration).To(Equal(int64(3)))
+	g.Expect(ready.LastTransitionTime.Time).To(Equal(first))
+	g.Expect(m.IsFalse(ConditionReady)).To(BeTrue())
+
+	// same status keeps the transition time
+	now = first.Add(time.Minute)
+	m.MarkFalse(ConditionReady, "StillReconciling", "")
+	ready = m.GetCondition(ConditionReady)
+	g.Expect(ready.Reason).To(Equal("StillReconciling"))
+	g.Expect(ready.LastTransitionTime.Time).To(Equal(first))
+
+	// status change updates the transition time
+	now = first.Add(2 * time.Minute)
+	m.MarkTrue(ConditionReady, "Ready", "")
+	ready = m.GetCondition(ConditionReady)
+	g.Expect(m.IsTrue(ConditionReady)).To(BeTrue())
+	g.Expect(ready.LastTransitionTime.Time).To(Equal(now))
+
+	m.MarkUnknown(ConditionReady, "Unknown", strings.Repeat("a", MaxConditionMessageLength+10))
+	g.Expect(m.GetCondition(ConditionReady).Message).To(HaveLen(MaxConditionMessageLength))
+
+	g.Expect(m.RemoveCondition(ConditionPending)).To(BeTrue())
+	g.Expect(m.RemoveCondition(ConditionPending)).To(BeFalse())
+	g.Expect(conditions).To(HaveLen(1))
+}
+
+func TestConditionManager_OnTransition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var transitions []string
+	conditions := []metav1.Condition{}
+	m := NewConditionManager(&conditions, 1).OnTransition(func(previous *metav1.Condition, current metav1.Condition) {
+		from := "none"
+		if previous != nil {
+			from = string(previous.Status) + "/" + previous.Reason
+		}
+		transitions = append(transitions, from+" -> "+string(current.Status)+"/"+current.Reason)
+	})
+
+	m.MarkUnknown(ConditionReady, "Reconciling", "")
+	m.MarkUnknown(ConditionReady, "Reconciling", "new message")
+	m.MarkFalse(ConditionReady, "Failed", "boom")
+	m.MarkFalse(ConditionReady, "Timeout", "boom")
+	m.MarkTrue(ConditionReady, "Ready", "")
+	m.MarkTrue(ConditionReady, "Ready", "")
+	g.Expect(transitions).To(Equal([]string{
+		"none -> Unknown/Reconciling",
+		"Unknown/Reconciling -> False/Failed",
+		"False/Failed -> False/Timeout",
+		"False/Timeout -> True/Ready",
+	}))
+}
+
+func TestSortConditions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	conditions := []metav1.Condition{{Type: "c"}, {Type: "a"}, {Type: "b"}}
+	SortConditions(conditions)
+	g.Expect(conditions).To(Equal([]metav1.Condition{{Type: "a"}, {Type: "b"}, {Type: "c"}}))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionSet declares the happy condition of a resource and the dependent
+// conditions it is computed from, as knative.dev/pkg/apis.ConditionSet does for
+// apis.Conditions. It is declared once per type:
+//
+//	var widgetConditions = NewLivingConditionSet("DeploymentReady", "ServiceReady")
+//
+//	func (s *WidgetStatus) MarkDeploymentReady() {
+//		widgetConditions.Manage(&s.Conditions, s.ObservedGeneration).MarkTrue("DeploymentReady", "", "")
+//	}
+//
+// +k8s:deepcopy-gen=false
+type ConditionSet struct {
+	aggregator *ConditionAggregator
+}
+
+// NewConditionSet returns a ConditionSet with happy condition computed from the dependents
+func NewConditionSet(happy ConditionType, dependents ...ConditionType) ConditionSet {
+	return ConditionSet{aggregator: NewConditionAggregator(happy, dependents...)}
+}
+
+// NewLivingConditionSet returns a ConditionSet with Ready as the happy condition,
+// used for long-running resources
+func NewLivingConditionSet(dependents ...ConditionType) ConditionSet {
+	return NewConditionSet(ConditionReady, dependents...)
+}
+
+// NewBatchConditionSet returns a ConditionSet with Succeeded as the happy condition,
+// used for resources which run to completion
+func NewBatchConditionSet(dependents ...ConditionType) ConditionSet {
+	return NewConditionSet(ConditionSucceeded, dependents...)
+}
+
+// GetTopLevelConditionType returns the type of the happy condition
+func (s ConditionSet) GetTopLevelConditionType() ConditionType {
+	return s.aggregator.Type
+}
+
+// Dependents returns the types of the dependent conditions
+func (s ConditionSet) Dependents() []ConditionType {
+	dependents := make([]ConditionType, 0, len(s.aggregator.Dependents))
+	for _, dependent := range s.aggregator.Dependents {
+		dependents = append(dependents, dependent.Type)
+	}
+	return dependents
+}
+
+// Manage returns a ConditionSetManager for the conditions
+func (s ConditionSet) Manage(conditions *[]metav1.Condition, generation int64) *ConditionSetManager {
+	return &ConditionSetManager{
+		ConditionManager: NewConditionManager(conditions, generation),
+		set:              s,
+	}
+}
+
+// ConditionSetManager manages the conditions of a ConditionSet,
+// propagating changes of dependent conditions into the happy condition
+// +k8s:deepcopy-gen=false
+type ConditionSetManager struct {
+	*ConditionManager
+	set ConditionSet
+}
+
+// InitializeConditions sets missing conditions of the set to Unknown,
+// or to True for dependents when the happy condition is already True
+func (m *ConditionSetManager) InitializeConditions() {
+	happyType := m.set.GetTopLevelConditionType()
+	happy := m.GetCondition(happyType)
+	if happy == nil {
+		m.ConditionManager.MarkUnknown(happyType, ConditionReasonNotSet, "")
+		happy = m.GetCondition(happyType)
+	}
+	status := metav1.ConditionUnknown
+	if happy != nil && happy.Status == metav1.ConditionTrue {
+		status = metav1.ConditionTrue
+	}
+	for _, dependent := range m.set.Dependents() {
+		if m.GetCondition(dependent) == nil {
+			m.SetCondition(metav1.Condition{Type: string(dependent), Status: status, Reason: ConditionReasonNotSet})
+		}
+	}
+}
+
+// GetTopLevelCondition returns the happy condition
+func (m *ConditionSetManager) GetTopLevelCondition() *metav1.Condition {
+	return m.GetCondition(m.set.GetTopLevelConditionType())
+}
+
+// IsHappy returns true if the happy condition is True
+func (m *ConditionSetManager) IsHappy() bool {
+	return m.IsTrue(m.set.GetTopLevelConditionType())
+}
+
+// MarkTrue sets the condition status to True, the happy condition
+// becomes True once all dependents are True
+func (m *ConditionSetManager) MarkTrue(conditionType ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.ConditionManager.MarkTrue(conditionType, reason, messageFormat, messageA...)
+	m.propagate(conditionType)
+}
+
+// MarkFalse sets the condition status to False, a False dependent
+// sets the happy condition to False with its reason
+func (m *ConditionSetManager) MarkFalse(conditionType ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.ConditionManager.MarkFalse(conditionType, reason, messageFormat, messageA...)
+	m.propagate(conditionType)
+}
+
+// MarkUnknown sets the condition status to Unknown, an Unknown dependent
+// sets the happy condition to Unknown unless another dependent is False
+func (m *ConditionSetManager) MarkUnknown(conditionType ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.ConditionManager.MarkUnknown(conditionType, reason, messageFormat, messageA...)
+	m.propagate(conditionType)
+}
+
+// propagate recomputes the happy condition when conditionType is a dependent
+func (m *ConditionSetManager) propagate(conditionType ConditionType) {
+	if m.set.aggregator.IsDependent(conditionType) {
+		m.set.aggregator.Apply(m.ConditionManager)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	set := NewLivingConditionSet("DeploymentReady", "ServiceReady")
+	g.Expect(set.GetTopLevelConditionType()).To(Equal(ConditionReady))
+	g.Expect(set.Dependents()).To(Equal([]ConditionType{"DeploymentReady", "ServiceReady"}))
+	g.Expect(NewBatchConditionSet().GetTopLevelConditionType()).To(Equal(ConditionSucceeded))
+
+	conditions := []metav1.Condition{}
+	m := set.Manage(&conditions, 1)
+	m.InitializeConditions()
+	g.Expect(conditions).To(HaveLen(3))
+	g.Expect(m.IsUnknown("DeploymentReady")).To(BeTrue())
+	g.Expect(m.IsUnknown("ServiceReady")).To(BeTrue())
+	g.Expect(m.GetTopLevelCondition().Status).To(Equal(metav1.ConditionUnknown))
+
+	m.MarkTrue("DeploymentReady", "Available", "")
+	g.Expect(m.IsHappy()).To(BeFalse())
+	g.Expect(m.GetTopLevelCondition().Status).To(Equal(metav1.ConditionUnknown))
+
+	m.MarkFalse("ServiceReady", "NoEndpoints", "no endpoints for %s", "widget")
+	g.Expect(m.GetTopLevelCondition().Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(m.GetTopLevelCondition().Reason).To(Equal("NoEndpoints"))
+	g.Expect(m.GetTopLevelCondition().Message).To(Equal("1/2 components ready; ServiceReady: no endpoints for widget"))
+
+	m.MarkTrue("ServiceReady", "", "")
+	g.Expect(m.IsHappy()).To(BeTrue())
+
+	// conditions out of the set do not change the happy condition
+	m.MarkFalse("Other", "Failed", "")
+	g.Expect(m.IsHappy()).To(BeTrue())
+
+	// dependents of a happy resource are initialized as True
+	conditions = []metav1.Condition{{Type: string(ConditionReady), Status: metav1.ConditionTrue, Reason: "Ready"}}
+	m = NewLivingConditionSet("DeploymentReady").Manage(&conditions, 1)
+	m.InitializeConditions()
+	g.Expect(m.IsTrue("DeploymentReady")).To(BeTrue())
+	g.Expect(m.IsHappy()).To(BeTrue())
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Duration is a duration serialized as a string like 1m30s, compatible with metav1.Duration
+// to be used in specs instead of string fields parsed by each controller
+// +kubebuilder:validation:Type=string
+// +kubebuilder:validation:Format=duration
+type Duration struct {
+	time.Duration
+}
+
+// NewDuration returns a Duration of d
+func NewDuration(d time.Duration) *Duration {
+	return &Duration{Duration: d}
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// ToUnstructured implements the value.UnstructuredConverter interface
+func (d Duration) ToUnstructured() interface{} {
+	return d.Duration.String()
+}
+
+// OpenAPISchemaType is used by the kube-openapi generator when constructing
+// the OpenAPI spec of this type
+func (Duration) OpenAPISchemaType() []string { return []string{"string"} }
+
+// OpenAPISchemaFormat is used by the kube-openapi generator when constructing
+// the OpenAPI spec of this type
+func (Duration) OpenAPISchemaFormat() string { return "duration" }
+
+// MetaV1 returns the duration as a metav1.Duration
+func (d Duration) MetaV1() metav1.Duration {
+	return metav1.Duration{Duration: d.Duration}
+}
+
+// Get returns the duration, or defaultValue when d is nil or zero
+func (d *Duration) Get(defaultValue time.Duration) time.Duration {
+	if d == nil || d.Duration == 0 {
+		return defaultValue
+	}
+	return d.Duration
+}
+
+// SetDefaults sets the duration to defaultValue when it is zero
+func (d *Duration) SetDefaults(defaultValue time.Duration) {
+	if d.Duration == 0 {
+		d.Duration = defaultValue
+	}
+}
+
+// Validate returns an error if the duration is negative or out of the min and max range,
+// a zero min or max means no bound
+func (d *Duration) Validate(fld *field.Path, min, max time.Duration) field.ErrorList {
+	if d == nil {
+		return nil
+	}
+	switch {
+	case d.Duration < 0:
+		return field.ErrorList{field.Invalid(fld, d.Duration.String(), "must not be negative")}
+	case min != 0 && d.Duration < min:
+		return field.ErrorList{field.Invalid(fld, d.Duration.String(), fmt.Sprintf("must be greater than or equal to %s", min))}
+	case max != 0 && d.Duration > max:
+		return field.ErrorList{field.Invalid(fld, d.Duration.String(), fmt.Sprintf("must be less than or equal to %s", max))}
+	}
+	return nil
+}
+
+// Percent is a percentage between 0 and 100 serialized as a string like 50%
+// +kubebuilder:validation:Type=string
+// +kubebuilder:validation:Pattern=`^[0-9]+%$`
+type Percent struct {
+	// a struct is used so the unstructured converter uses ToUnstructured instead of the integer value
+	value int32
+}
+
+// NewPercent returns a Percent of value
+func NewPercent(value int32) Percent {
+	return Percent{value: value}
+}
+
+// ParsePercent parses a percentage like 50%, the % suffix is optional
+func ParsePercent(str string) (Percent, error) {
+	value, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(str), "%"), 10, 32)
+	if err != nil {
+		return Percent{}, fmt.Errorf("invalid percent %q: %w", str, err)
+	}
+	return NewPercent(int32(value)), nil
+}
+
+// Value returns the percentage as a number, e.g. 50 for 50%
+func (p Percent) Value() int32 {
+	return p.value
+}
+
+// String returns the percentage like 50%
+func (p Percent) String() string {
+	return strconv.Itoa(int(p.value)) + "%"
+}
+
+// Of returns the percentage of total rounding up, like the percentages of rolling updates
+func (p Percent) Of(total int) int {
+	return int(math.Ceil(float64(total) * float64(p.value) / 100))
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	parsed, err := ParsePercent(str)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// ToUnstructured implements the value.UnstructuredConverter interface
+func (p Percent) ToUnstructured() interface{} {
+	return p.String()
+}
+
+// OpenAPISchemaType is used by the kube-openapi generator when constructing
+// the OpenAPI spec of this type
+func (Percent) OpenAPISchemaType() []string { return []string{"string"} }
+
+// OpenAPISchemaFormat is used by the kube-openapi generator when constructing
+// the OpenAPI spec of this type
+func (Percent) OpenAPISchemaFormat() string { return "" }
+
+// Validate returns an error if the percentage is not between 0 and 100
+func (p Percent) Validate(fld *field.Path) field.ErrorList {
+	if p.value < 0 || p.value > 100 {
+		return field.ErrorList{field.Invalid(fld, p.String(), "must be between 0% and 100%")}
+	}
+	return nil
+}
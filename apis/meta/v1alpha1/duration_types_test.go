@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type durationSpec struct {
+	Timeout  *Duration `json:"timeout,omitempty"`
+	Interval Duration  `json:"interval"`
+	Percent  Percent   `json:"percent"`
+}
+
+func TestDuration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	spec := durationSpec{}
+	g.Expect(json.Unmarshal([]byte(`{"timeout":"1m30s","interval":"10s","percent":"25%"}`), &spec)).To(Succeed())
+	g.Expect(spec.Timeout.Duration).To(Equal(90 * time.Second))
+	g.Expect(spec.Interval.Duration).To(Equal(10 * time.Second))
+
+	data, err := json.Marshal(spec)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(Equal(`{"timeout":"1m30s","interval":"10s","percent":"25%"}`))
+
+	// compatible with metav1.Duration
+	metaDuration := metav1.Duration{}
+	g.Expect(json.Unmarshal([]byte(`"1m30s"`), &metaDuration)).To(Succeed())
+	g.Expect(spec.Timeout.MetaV1()).To(Equal(metaDuration))
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	g.Expect(err).To(BeNil())
+	g.Expect(content).To(HaveKeyWithValue("interval", "10s"))
+	g.Expect(content).To(HaveKeyWithValue("percent", "25%"))
+	converted := durationSpec{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(content, &converted)).To(Succeed())
+	g.Expect(converted).To(Equal(spec))
+
+	g.Expect(json.Unmarshal([]byte(`{"timeout":"90"}`), &spec)).NotTo(Succeed())
+	g.Expect(json.Unmarshal([]byte(`{"timeout":90}`), &spec)).NotTo(Succeed())
+}
+
+func TestDuration_defaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var nilDuration *Duration
+	g.Expect(nilDuration.Get(time.Minute)).To(Equal(time.Minute))
+	g.Expect(NewDuration(0).Get(time.Minute)).To(Equal(time.Minute))
+	g.Expect(NewDuration(time.Second).Get(time.Minute)).To(Equal(time.Second))
+
+	d := Duration{}
+	d.SetDefaults(time.Hour)
+	g.Expect(d.Duration).To(Equal(time.Hour))
+	d.SetDefaults(time.Minute)
+	g.Expect(d.Duration).To(Equal(time.Hour))
+}
+
+func TestDuration_Validate(t *testing.T) {
+	var data = []struct {
+		desc     string
+		duration *Duration
+		min, max time.Duration
+		err      string
+	}{
+		{"nil", nil, time.Second, 0, ""},
+		{"in range", NewDuration(time.Minute), time.Second, time.Hour, ""},
+		{"no bounds", NewDuration(48 * time.Hour), 0, 0, ""},
+		{"negative", NewDuration(-time.Second), 0, 0, "must not be negative"},
+		{"below min", NewDuration(time.Millisecond), time.Second, 0, "must be greater than or equal to 1s"},
+		{"above max", NewDuration(2 * time.Hour), 0, time.Hour, "must be less than or equal to 1h0m0s"},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			errs := item.duration.Validate(field.NewPath("timeout"), item.min, item.max)
+			if item.err == "" {
+				g.Expect(errs).To(BeEmpty())
+				return
+			}
+			g.Expect(errs).To(HaveLen(1))
+			g.Expect(errs[0].Error()).To(ContainSubstring(item.err))
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	var data = []struct {
+		desc  string
+		json  string
+		value Percent
+		err   bool
+		valid bool
+	}{
+		{"with percent sign", `"50%"`, NewPercent(50), false, true},
+		{"without percent sign", `"5"`, NewPercent(5), false, true},
+		{"out of range", `"150%"`, NewPercent(150), false, false},
+		{"negative", `"-1%"`, NewPercent(-1), false, false},
+		{"not a number", `"half"`, Percent{}, true, false},
+		{"number", `50`, Percent{}, true, false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			var percent Percent
+			err := json.Unmarshal([]byte(item.json), &percent)
+			if item.err {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(percent).To(Equal(item.value))
+			g.Expect(percent.Validate(field.NewPath("percent"))).To(HaveLen(map[bool]int{true: 0, false: 1}[item.valid]))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	g.Expect(NewPercent(25).String()).To(Equal("25%"))
+	g.Expect(NewPercent(25).Value()).To(Equal(int32(25)))
+	g.Expect(NewPercent(25).Of(10)).To(Equal(3))
+	g.Expect(NewPercent(100).Of(10)).To(Equal(10))
+	g.Expect(NewPercent(0).Of(10)).To(Equal(0))
+}
@@ -40,6 +40,18 @@ const (
 	// TriggeredByAnnotationKey annotation key to store resource update username
 	TriggeredByAnnotationKey = "cpaas.io/triggeredBy"
 
+	// SpecHashAnnotationKey annotation key to store the hash of the desired spec of a resource
+	// used to detect changes made outside of its controller
+	SpecHashAnnotationKey = "cpaas.io/specHash"
+
+	// PausedAnnotationKey annotation key to pause the reconciliation of a resource,
+	// any value other than false pauses it
+	PausedAnnotationKey = "cpaas.io/paused"
+
+	// ExpiresAtAnnotationKey annotation key to store the time in RFC3339 format after which
+	// a temporary resource, e.g. a preview environment, is deleted by the expiry controller
+	ExpiresAtAnnotationKey = "cpaas.io/expiresAt"
+
 	// UIDescriptorsAnnotationKey annotation for storing ui descriptors in resources
 	UIDescriptorsAnnotationKey = "ui.cpaas.io/descriptors"
 )
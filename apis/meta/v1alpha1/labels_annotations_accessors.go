@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDisplayName returns the display name annotation of the object
+func GetDisplayName(obj metav1.Object) string {
+	return getAnnotation(obj, DisplayNameAnnotationKey)
+}
+
+// SetDisplayName sets the display name annotation of the object
+// an empty name removes the annotation
+func SetDisplayName(obj metav1.Object, name string) {
+	setAnnotation(obj, DisplayNameAnnotationKey, name)
+}
+
+// GetSpecHash returns the spec hash annotation of the object
+func GetSpecHash(obj metav1.Object) string {
+	return getAnnotation(obj, SpecHashAnnotationKey)
+}
+
+// SetSpecHash sets the spec hash annotation of the object
+// an empty hash removes the annotation
+func SetSpecHash(obj metav1.Object, hash string) {
+	setAnnotation(obj, SpecHashAnnotationKey, hash)
+}
+
+// IsPaused returns true if the object has the paused annotation with a value other than false
+func IsPaused(obj metav1.Object) bool {
+	value, ok := obj.GetAnnotations()[PausedAnnotationKey]
+	if !ok {
+		return false
+	}
+	paused, err := strconv.ParseBool(strings.TrimSpace(value))
+	return err != nil || paused
+}
+
+// SetPaused sets the paused annotation of the object to true
+// or removes it when paused is false
+func SetPaused(obj metav1.Object, paused bool) {
+	if !paused {
+		setAnnotation(obj, PausedAnnotationKey, "")
+		return
+	}
+	setAnnotation(obj, PausedAnnotationKey, strconv.FormatBool(paused))
+}
+
+// GetCreatedTime returns the creation time annotation of the object
+// returns a zero time and nil error if the annotation is not set or empty
+func GetCreatedTime(obj metav1.Object) (time.Time, error) {
+	return getTimeAnnotation(obj, CreatedTimeAnnotationKey)
+}
+
+// SetCreatedTime sets the creation time annotation of the object in RFC3339 format
+// a zero time removes the annotation
+func SetCreatedTime(obj metav1.Object, t time.Time) {
+	setTimeAnnotation(obj, CreatedTimeAnnotationKey, t)
+}
+
+// GetUpdatedTime returns the update time annotation of the object
+// returns a zero time and nil error if the annotation is not set or empty
+func GetUpdatedTime(obj metav1.Object) (time.Time, error) {
+	return getTimeAnnotation(obj, UpdatedTimeAnnotationKey)
+}
+
+// SetUpdatedTime sets the update time annotation of the object in RFC3339 format
+// a zero time removes the annotation
+func SetUpdatedTime(obj metav1.Object, t time.Time) {
+	setTimeAnnotation(obj, UpdatedTimeAnnotationKey, t)
+}
+
+// GetDeletedTime returns the deletion time annotation of the object
+// returns a zero time and nil error if the annotation is not set or empty
+func GetDeletedTime(obj metav1.Object) (time.Time, error) {
+	return getTimeAnnotation(obj, DeletedTimeAnnotationKey)
+}
+
+// SetDeletedTime sets the deletion time annotation of the object in RFC3339 format
+// a zero time removes the annotation
+func SetDeletedTime(obj metav1.Object, t time.Time) {
+	setTimeAnnotation(obj, DeletedTimeAnnotationKey, t)
+}
+
+// GetExpiresAt returns the expiry time annotation of the object
+// returns a zero time and nil error if the annotation is not set or empty
+func GetExpiresAt(obj metav1.Object) (time.Time, error) {
+	return getTimeAnnotation(obj, ExpiresAtAnnotationKey)
+}
+
+// SetExpiresAt sets the expiry time annotation of the object in RFC3339 format
+// a zero time removes the annotation
+func SetExpiresAt(obj metav1.Object, t time.Time) {
+	setTimeAnnotation(obj, ExpiresAtAnnotationKey, t)
+}
+
+// IsExpired returns true if the object has an expiry time annotation not after now
+func IsExpired(obj metav1.Object, now time.Time) (bool, error) {
+	expiresAt, err := GetExpiresAt(obj)
+	if err != nil || expiresAt.IsZero() {
+		return false, err
+	}
+	return !expiresAt.After(now), nil
+}
+
+// GetCreatedBy returns the creator stored in the annotations of the object
+// returns nil and nil error if the annotation is not set or empty
+func GetCreatedBy(obj metav1.Object) (*CreatedBy, error) {
+	if getAnnotation(obj, CreatedByAnnotationKey) == "" {
+		return nil, nil
+	}
+	return (&CreatedBy{}).FromAnnotation(obj.GetAnnotations())
+}
+
+// SetCreatedBy stores the creator into the annotations of the object
+// a zero value removes the annotation
+func SetCreatedBy(obj metav1.Object, by *CreatedBy) {
+	if by.IsZero() {
+		setAnnotation(obj, CreatedByAnnotationKey, "")
+		return
+	}
+	annotations, _ := by.SetIntoAnnotation(obj.GetAnnotations())
+	obj.SetAnnotations(annotations)
+}
+
+// GetUpdatedBy returns the updater stored in the annotations of the object
+// returns nil and nil error if the annotation is not set or empty
+func GetUpdatedBy(obj metav1.Object) (*UpdatedBy, error) {
+	if getAnnotation(obj, UpdatedByAnnotationKey) == "" {
+		return nil, nil
+	}
+	return (&UpdatedBy{}).FromAnnotation(obj.GetAnnotations())
+}
+
+// SetUpdatedBy stores the updater into the annotations of the object
+// a zero value removes the annotation
+func SetUpdatedBy(obj metav1.Object, by *UpdatedBy) {
+	if by.IsZero() {
+		setAnnotation(obj, UpdatedByAnnotationKey, "")
+		return
+	}
+	annotations, _ := by.SetIntoAnnotation(obj.GetAnnotations())
+	obj.SetAnnotations(annotations)
+}
+
+// GetDeletedBy returns the deleter stored in the annotations of the object
+// returns nil and nil error if the annotation is not set or empty
+func GetDeletedBy(obj metav1.Object) (*DeletedBy, error) {
+	if getAnnotation(obj, DeletedByAnnotationKey) == "" {
+		return nil, nil
+	}
+	return (&DeletedBy{}).FromAnnotation(obj.GetAnnotations())
+}
+
+// SetDeletedBy stores the deleter into the annotations of the object
+// a zero value removes the annotation
+func SetDeletedBy(obj metav1.Object, by *DeletedBy) {
+	if by.IsZero() {
+		setAnnotation(obj, DeletedByAnnotationKey, "")
+		return
+	}
+	annotations, _ := by.SetIntoAnnotation(obj.GetAnnotations())
+	obj.SetAnnotations(annotations)
+}
+
+func getAnnotation(obj metav1.Object, key string) string {
+	return strings.TrimSpace(obj.GetAnnotations()[key])
+}
+
+func setAnnotation(obj metav1.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if value == "" {
+		if _, ok := annotations[key]; ok {
+			delete(annotations, key)
+			obj.SetAnnotations(annotations)
+		}
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
+
+func getTimeAnnotation(obj metav1.Object, key string) (time.Time, error) {
+	value := getAnnotation(obj, key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s annotation %q: %w", key, value, err)
+	}
+	return t, nil
+}
+
+func setTimeAnnotation(obj metav1.Object, key string, t time.Time) {
+	if t.IsZero() {
+		setAnnotation(obj, key, "")
+		return
+	}
+	setAnnotation(obj, key, t.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDisplayNameAccessors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj := &corev1.ConfigMap{}
+	g.Expect(GetDisplayName(obj)).To(BeEmpty())
+
+	SetDisplayName(obj, "My ConfigMap")
+	g.Expect(GetDisplayName(obj)).To(Equal("My ConfigMap"))
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(DisplayNameAnnotationKey, "My ConfigMap"))
+
+	SetDisplayName(obj, "")
+	g.Expect(obj.Annotations).NotTo(HaveKey(DisplayNameAnnotationKey))
+}
+
+func TestSpecHashAccessors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj := &corev1.ConfigMap{}
+	g.Expect(GetSpecHash(obj)).To(BeEmpty())
+
+	SetSpecHash(obj, "abc")
+	g.Expect(GetSpecHash(obj)).To(Equal("abc"))
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(SpecHashAnnotationKey, "abc"))
+
+	SetSpecHash(obj, "")
+	g.Expect(obj.Annotations).NotTo(HaveKey(SpecHashAnnotationKey))
+}
+
+func TestPausedAccessors(t *testing.T) {
+	var data = []struct {
+		desc        string
+		annotations map[string]string
+
+		expected bool
+	}{
+		{desc: "nil annotations", annotations: nil, expected: false},
+		{desc: "true", annotations: map[string]string{PausedAnnotationKey: "true"}, expected: true},
+		{desc: "empty value", annotations: map[string]string{PausedAnnotationKey: ""}, expected: true},
+		{desc: "any other value", annotations: map[string]string{PausedAnnotationKey: "incident-42"}, expected: true},
+		{desc: "false", annotations: map[string]string{PausedAnnotationKey: " false "}, expected: false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			obj := &corev1.ConfigMap{}
+			obj.Annotations = item.annotations
+			g.Expect(IsPaused(obj)).To(Equal(item.expected))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	obj := &corev1.ConfigMap{}
+	SetPaused(obj, true)
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(PausedAnnotationKey, "true"))
+	g.Expect(IsPaused(obj)).To(BeTrue())
+	SetPaused(obj, false)
+	g.Expect(obj.Annotations).NotTo(HaveKey(PausedAnnotationKey))
+}
+
+func TestTimeAccessors(t *testing.T) {
+	var data = []struct {
+		desc        string
+		annotations map[string]string
+
+		expected time.Time
+		hasError bool
+	}{
+		{
+			desc:     "nil annotations",
+			expected: time.Time{},
+		},
+		{
+			desc:        "empty value",
+			annotations: map[string]string{CreatedTimeAnnotationKey: " "},
+			expected:    time.Time{},
+		},
+		{
+			desc:        "rfc3339 value",
+			annotations: map[string]string{CreatedTimeAnnotationKey: "2024-01-02T03:04:05Z"},
+			expected:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			desc:        "invalid value",
+			annotations: map[string]string{CreatedTimeAnnotationKey: "yesterday"},
+			hasError:    true,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: item.annotations}}
+
+			actual, err := GetCreatedTime(obj)
+			if item.hasError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(actual.Equal(item.expected)).To(BeTrue())
+		})
+	}
+
+	t.Run("set and get", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		obj := &corev1.ConfigMap{}
+		now := time.Date(2024, 1, 2, 11, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+
+		SetUpdatedTime(obj, now)
+		g.Expect(obj.Annotations).To(HaveKeyWithValue(UpdatedTimeAnnotationKey, "2024-01-02T03:04:05Z"))
+		actual, err := GetUpdatedTime(obj)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(actual.Equal(now)).To(BeTrue())
+
+		SetUpdatedTime(obj, time.Time{})
+		g.Expect(obj.Annotations).NotTo(HaveKey(UpdatedTimeAnnotationKey))
+	})
+}
+
+func TestExpiresAtAccessors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	obj := &corev1.ConfigMap{}
+	expired, err := IsExpired(obj, now)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(expired).To(BeFalse())
+
+	SetExpiresAt(obj, now.Add(time.Hour))
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(ExpiresAtAnnotationKey, "2024-01-02T04:04:05Z"))
+	expired, _ = IsExpired(obj, now)
+	g.Expect(expired).To(BeFalse())
+	expired, _ = IsExpired(obj, now.Add(time.Hour))
+	g.Expect(expired).To(BeTrue())
+
+	obj.Annotations[ExpiresAtAnnotationKey] = "tomorrow"
+	_, err = IsExpired(obj, now)
+	g.Expect(err).To(HaveOccurred())
+
+	SetExpiresAt(obj, time.Time{})
+	g.Expect(obj.Annotations).NotTo(HaveKey(ExpiresAtAnnotationKey))
+}
+
+func TestCreatedByAccessors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj := &corev1.ConfigMap{}
+	by, err := GetCreatedBy(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(by).To(BeNil())
+
+	SetCreatedBy(obj, &CreatedBy{User: &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "admin"}})
+	by, err = GetCreatedBy(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(by.User.Name).To(Equal("admin"))
+
+	obj.Annotations[CreatedByAnnotationKey] = "{"
+	_, err = GetCreatedBy(obj)
+	g.Expect(err).To(HaveOccurred())
+
+	SetCreatedBy(obj, nil)
+	g.Expect(obj.Annotations).NotTo(HaveKey(CreatedByAnnotationKey))
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query parameter names used to encode list options
+const (
+	QueryItemsPerPage = "itemsPerPage"
+	QueryPage         = "page"
+	QueryContinue     = "continue"
+	QuerySortBy       = "sortBy"
+	QueryFilterBy     = "filterBy"
+)
+
+// ParsePager parses a Pager from query parameters.
+// Missing parameters are left empty so the defaults of Pager apply.
+func ParsePager(values url.Values) (pager Pager, err error) {
+	if pager.ItemsPerPage, err = parseNonNegativeInt(values, QueryItemsPerPage); err != nil {
+		return
+	}
+	if pager.Page, err = parseNonNegativeInt(values, QueryPage); err != nil {
+		return
+	}
+	pager.Continue = values.Get(QueryContinue)
+	return
+}
+
+// Encode adds the non empty pager fields to query parameters
+func (p Pager) Encode(values url.Values) {
+	if p.ItemsPerPage > 0 {
+		values.Set(QueryItemsPerPage, strconv.Itoa(p.ItemsPerPage))
+	}
+	if p.Page > 0 {
+		values.Set(QueryPage, strconv.Itoa(p.Page))
+	}
+	if p.Continue != "" {
+		values.Set(QueryContinue, p.Continue)
+	}
+}
+
+// ParseSortOptions parses SortOptions from the sortBy query parameter.
+// Fields are comma separated and prefixed with - for descending order,
+// e.g. sortBy=name,-creationTimestamp. The parameter may also be repeated.
+func ParseSortOptions(values url.Values) (opts SortOptions, err error) {
+	for _, value := range values[QuerySortBy] {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			field := SortField{Field: item, Order: SortOrderAsc}
+			if strings.HasPrefix(item, "-") {
+				field = SortField{Field: strings.TrimPrefix(item, "-"), Order: SortOrderDesc}
+			}
+			if field.Field == "" {
+				return opts, fmt.Errorf("invalid %s value %q", QuerySortBy, value)
+			}
+			opts.SortBy = append(opts.SortBy, field)
+		}
+	}
+	return
+}
+
+// String returns the query representation of the sort field
+func (f SortField) String() string {
+	if f.Order == SortOrderDesc {
+		return "-" + f.Field
+	}
+	return f.Field
+}
+
+// Encode adds the sort fields to query parameters
+func (o SortOptions) Encode(values url.Values) {
+	if len(o.SortBy) == 0 {
+		return
+	}
+	fields := make([]string, 0, len(o.SortBy))
+	for _, field := range o.SortBy {
+		fields = append(fields, field.String())
+	}
+	values.Set(QuerySortBy, strings.Join(fields, ","))
+}
+
+// filterOperators in parsing order, longer operators first
+var filterOperators = []FilterOperator{FilterOperatorNotEqual, FilterOperatorEqual, FilterOperatorContains}
+
+// ParseFilterOptions parses FilterOptions from repeated filterBy query parameters.
+// Each value is a field, an operator and a value, e.g. filterBy=name~demo&filterBy=phase!=Failed.
+func ParseFilterOptions(values url.Values) (opts FilterOptions, err error) {
+	for _, value := range values[QueryFilterBy] {
+		var filter Filter
+		if filter, err = ParseFilter(value); err != nil {
+			return
+		}
+		opts.FilterBy = append(opts.FilterBy, filter)
+	}
+	return
+}
+
+// ParseFilter parses a single filter expression such as name=demo
+func ParseFilter(expr string) (filter Filter, err error) {
+	index, operator := -1, FilterOperator("")
+	for _, op := range filterOperators {
+		if i := strings.Index(expr, string(op)); i >= 0 && (index < 0 || i < index) {
+			index, operator = i, op
+		}
+	}
+	if index <= 0 {
+		return filter, fmt.Errorf("invalid %s value %q", QueryFilterBy, expr)
+	}
+	filter = Filter{
+		Field:    strings.TrimSpace(expr[:index]),
+		Operator: operator,
+		Value:    expr[index+len(operator):],
+	}
+	return
+}
+
+// String returns the query representation of the filter
+func (f Filter) String() string {
+	operator := f.Operator
+	if operator == "" {
+		operator = FilterOperatorEqual
+	}
+	return f.Field + string(operator) + f.Value
+}
+
+// Encode adds the filters to query parameters
+func (o FilterOptions) Encode(values url.Values) {
+	for _, filter := range o.FilterBy {
+		values.Add(QueryFilterBy, filter.String())
+	}
+}
+
+// ParseListOptions parses ListOptions from query parameters
+func ParseListOptions(values url.Values) (opts ListOptions, err error) {
+	var pager Pager
+	if pager, err = ParsePager(values); err != nil {
+		return
+	}
+	opts.ItemsPerPage, opts.Page, opts.Continue = pager.ItemsPerPage, pager.Page, pager.Continue
+	if opts.Sort, err = ParseSortOptions(values); err != nil {
+		return
+	}
+	opts.Filter, err = ParseFilterOptions(values)
+	return
+}
+
+// Pager returns the paging params of the list options
+func (o ListOptions) Pager() Pager {
+	return Pager{ItemsPerPage: o.ItemsPerPage, Page: o.Page, Continue: o.Continue}
+}
+
+// Encode returns the list options as query parameters
+func (o ListOptions) Encode() url.Values {
+	values := url.Values{}
+	o.Pager().Encode(values)
+	o.Sort.Encode(values)
+	o.Filter.Encode(values)
+	return values
+}
+
+func parseNonNegativeInt(values url.Values, key string) (int, error) {
+	value := values.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid %s value %q", key, value)
+	}
+	return i, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParseListOptions(t *testing.T) {
+	tests := map[string]struct {
+		query   string
+		want    ListOptions
+		wantErr bool
+	}{
+		"empty query": {
+			query: "",
+			want:  ListOptions{},
+		},
+		"pager with continue": {
+			query: "itemsPerPage=10&page=2&continue=abc",
+			want:  ListOptions{ItemsPerPage: 10, Page: 2, Continue: "abc"},
+		},
+		"sort and filter": {
+			query: "sortBy=name,-creationTimestamp&filterBy=name~demo&filterBy=phase!=Failed&filterBy=namespace=default",
+			want: ListOptions{
+				Sort: SortOptions{SortBy: []SortField{
+					{Field: "name", Order: SortOrderAsc},
+					{Field: "creationTimestamp", Order: SortOrderDesc},
+				}},
+				Filter: FilterOptions{FilterBy: []Filter{
+					{Field: "name", Operator: FilterOperatorContains, Value: "demo"},
+					{Field: "phase", Operator: FilterOperatorNotEqual, Value: "Failed"},
+					{Field: "namespace", Operator: FilterOperatorEqual, Value: "default"},
+				}},
+			},
+		},
+		"invalid page": {
+			query:   "page=-1",
+			wantErr: true,
+		},
+		"invalid items per page": {
+			query:   "itemsPerPage=abc",
+			wantErr: true,
+		},
+		"invalid sort": {
+			query:   "sortBy=-",
+			wantErr: true,
+		},
+		"invalid filter": {
+			query:   "filterBy=name",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			values, err := url.ParseQuery(tt.query)
+			g.Expect(err).To(gomega.BeNil())
+
+			got, err := ParseListOptions(values)
+			if tt.wantErr {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestListOptions_Encode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	opts := ListOptions{
+		ItemsPerPage: 10,
+		Page:         2,
+		Continue:     "abc",
+		Sort:         SortOptions{SortBy: []SortField{{Field: "name"}, {Field: "age", Order: SortOrderDesc}}},
+		Filter:       FilterOptions{FilterBy: []Filter{{Field: "name", Value: "demo"}, {Field: "phase", Operator: FilterOperatorNotEqual, Value: "Failed"}}},
+	}
+
+	values := opts.Encode()
+	g.Expect(values.Encode()).To(gomega.Equal("continue=abc&filterBy=name%3Ddemo&filterBy=phase%21%3DFailed&itemsPerPage=10&page=2&sortBy=name%2C-age"))
+
+	parsed, err := ParseListOptions(values)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parsed.Pager()).To(gomega.Equal(opts.Pager()))
+	g.Expect(parsed.Sort.SortBy).To(gomega.Equal([]SortField{{Field: "name", Order: SortOrderAsc}, {Field: "age", Order: SortOrderDesc}}))
+	g.Expect(parsed.Filter.FilterBy[0].Operator).To(gomega.Equal(FilterOperatorEqual))
+	g.Expect(parsed.Filter.FilterBy[1]).To(gomega.Equal(opts.Filter.FilterBy[1]))
+}
+
+func TestListOptions_DeepCopy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	opts := &ListOptions{Sort: SortOptions{SortBy: []SortField{{Field: "name"}}}}
+
+	copied := opts.DeepCopy()
+	copied.Sort.SortBy[0].Field = "changed"
+	g.Expect(opts.Sort.SortBy[0].Field).To(gomega.Equal("name"))
+}
@@ -48,4 +48,75 @@ type ListOptions struct {
 
 	// Page desired to be returned
 	Page int `json:"page"`
+
+	// Continue token returned by a previous list to fetch the next chunk of items
+	// +optional
+	Continue string `json:"continue,omitempty"`
+
+	// Sort options of the list
+	// +optional
+	Sort SortOptions `json:"sort,omitempty"`
+
+	// Filter options of the list
+	// +optional
+	Filter FilterOptions `json:"filter,omitempty"`
+}
+
+// SortOrder order of a sorted field
+type SortOrder string
+
+const (
+	// SortOrderAsc sorts in ascending order
+	SortOrderAsc SortOrder = "asc"
+	// SortOrderDesc sorts in descending order
+	SortOrderDesc SortOrder = "desc"
+)
+
+// SortField describes a field used to sort a list
+type SortField struct {
+	// Field name to sort by
+	Field string `json:"field"`
+
+	// Order of the sort, defaults to asc
+	// +optional
+	Order SortOrder `json:"order,omitempty"`
+}
+
+// SortOptions options to sort a list, fields are applied in order
+type SortOptions struct {
+	// SortBy fields to sort by
+	// +optional
+	SortBy []SortField `json:"sortBy,omitempty"`
+}
+
+// FilterOperator operator used to compare a field with a value
+type FilterOperator string
+
+const (
+	// FilterOperatorEqual field value is equal to the value
+	FilterOperatorEqual FilterOperator = "="
+	// FilterOperatorNotEqual field value is not equal to the value
+	FilterOperatorNotEqual FilterOperator = "!="
+	// FilterOperatorContains field value contains the value
+	FilterOperatorContains FilterOperator = "~"
+)
+
+// Filter describes a condition on a field used to filter a list
+type Filter struct {
+	// Field name to filter by
+	Field string `json:"field"`
+
+	// Operator used to compare, defaults to =
+	// +optional
+	Operator FilterOperator `json:"operator,omitempty"`
+
+	// Value to compare with
+	Value string `json:"value"`
+}
+
+// FilterOptions options to filter a list, all filters must match
+type FilterOptions struct {
+	// FilterBy filters to apply
+	// +optional
+	FilterBy []Filter `json:"filterBy,omitempty"`
 }
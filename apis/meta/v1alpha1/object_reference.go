@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ObjectReferenceFromObject returns a reference to obj including its uid and resource version.
+// The kind is taken from the object or looked up in the scheme when not set
+func ObjectReferenceFromObject(obj runtime.Object, scheme *runtime.Scheme) (*corev1.ObjectReference, error) {
+	gvk, err := gvkForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return &corev1.ObjectReference{
+		APIVersion:      apiVersion,
+		Kind:            kind,
+		Namespace:       accessor.GetNamespace(),
+		Name:            accessor.GetName(),
+		UID:             accessor.GetUID(),
+		ResourceVersion: accessor.GetResourceVersion(),
+	}, nil
+}
+
+// TypedLocalObjectReferenceFromObject returns a local reference to obj.
+// The kind is taken from the object or looked up in the scheme when not set
+func TypedLocalObjectReferenceFromObject(obj runtime.Object, scheme *runtime.Scheme) (*corev1.TypedLocalObjectReference, error) {
+	gvk, err := gvkForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	ref := &corev1.TypedLocalObjectReference{Kind: gvk.Kind, Name: accessor.GetName()}
+	if gvk.Group != "" {
+		ref.APIGroup = &gvk.Group
+	}
+	return ref, nil
+}
+
+func gvkForObject(obj runtime.Object, scheme *runtime.Scheme) (schema.GroupVersionKind, error) {
+	if obj == nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("object is nil")
+	}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Kind != "" {
+		return gvk, nil
+	}
+	if scheme == nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("kind of %T is not set and scheme is nil", obj)
+	}
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gvks[0], nil
+}
+
+// ParseObjectReference parses a reference from a "kind/namespace/name" or "kind/name" string.
+// The api version is not part of the string and needs to be set when required
+func ParseObjectReference(str string) (*corev1.ObjectReference, error) {
+	parts := strings.Split(str, "/")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid object reference %q: expected kind/namespace/name or kind/name", str)
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return &corev1.ObjectReference{Kind: parts[0], Name: parts[1]}, nil
+	case 3:
+		return &corev1.ObjectReference{Kind: parts[0], Namespace: parts[1], Name: parts[2]}, nil
+	default:
+		return nil, fmt.Errorf("invalid object reference %q: expected kind/namespace/name or kind/name", str)
+	}
+}
+
+// ObjectReferenceString returns the reference as "kind/namespace/name",
+// or "kind/name" for cluster scoped objects, the format used by ParseObjectReference
+func ObjectReferenceString(ref *corev1.ObjectReference) string {
+	if ref == nil {
+		return ""
+	}
+	if ref.Namespace == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+// TypedLocalObjectReferenceString returns the reference as "kind.group/name", or "kind/name" for the core group
+func TypedLocalObjectReferenceString(ref *corev1.TypedLocalObjectReference) string {
+	if ref == nil {
+		return ""
+	}
+	if ref.APIGroup == nil || *ref.APIGroup == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+	return ref.Kind + "." + *ref.APIGroup + "/" + ref.Name
+}
+
+// ObjectReferenceEqual returns true if both references point to the same object.
+// Versions of the same group are considered equal and uids are only compared when both are set
+func ObjectReferenceEqual(a, b *corev1.ObjectReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.UID != "" && b.UID != "" && a.UID != b.UID {
+		return false
+	}
+	return a.GroupVersionKind().GroupKind() == b.GroupVersionKind().GroupKind() &&
+		a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// TypedLocalObjectReferenceEqual returns true if both references point to the same object,
+// a nil api group is the same as the core group
+func TypedLocalObjectReferenceEqual(a, b *corev1.TypedLocalObjectReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	var aGroup, bGroup string
+	if a.APIGroup != nil {
+		aGroup = *a.APIGroup
+	}
+	if b.APIGroup != nil {
+		bGroup = *b.APIGroup
+	}
+	return aGroup == bGroup && a.Kind == b.Kind && a.Name == b.Name
+}
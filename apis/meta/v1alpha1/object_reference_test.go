@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestObjectReferenceFromObject(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "uid", ResourceVersion: "1"}}
+	ref, err := ObjectReferenceFromObject(deploy, clientgoscheme.Scheme)
+	g.Expect(err).To(BeNil())
+	g.Expect(ref).To(Equal(&corev1.ObjectReference{
+		APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", UID: "uid", ResourceVersion: "1",
+	}))
+
+	local, err := TypedLocalObjectReferenceFromObject(deploy, clientgoscheme.Scheme)
+	g.Expect(err).To(BeNil())
+	g.Expect(TypedLocalObjectReferenceString(local)).To(Equal("Deployment.apps/web"))
+
+	// kind set in the object does not need the scheme
+	cm := &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, ObjectMeta: metav1.ObjectMeta{Name: "cm"}}
+	local, err = TypedLocalObjectReferenceFromObject(cm, nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(local.APIGroup).To(BeNil())
+	g.Expect(TypedLocalObjectReferenceString(local)).To(Equal("ConfigMap/cm"))
+
+	_, err = ObjectReferenceFromObject(deploy, nil)
+	g.Expect(err).NotTo(BeNil())
+	_, err = ObjectReferenceFromObject(deploy, runtime.NewScheme())
+	g.Expect(err).NotTo(BeNil())
+	_, err = ObjectReferenceFromObject(nil, clientgoscheme.Scheme)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestParseObjectReference(t *testing.T) {
+	var data = []struct {
+		desc     string
+		str      string
+		expected *corev1.ObjectReference
+	}{
+		{"namespaced", "Deployment/default/web", &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "web"}},
+		{"cluster scoped", "Namespace/default", &corev1.ObjectReference{Kind: "Namespace", Name: "default"}},
+		{"missing name", "Deployment", nil},
+		{"empty part", "Deployment//web", nil},
+		{"too many parts", "apps/v1/Deployment/default/web", nil},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			ref, err := ParseObjectReference(item.str)
+			if item.expected == nil {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(ref).To(Equal(item.expected))
+			g.Expect(ObjectReferenceString(ref)).To(Equal(item.str))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	g.Expect(ObjectReferenceString(nil)).To(BeEmpty())
+	g.Expect(TypedLocalObjectReferenceString(nil)).To(BeEmpty())
+}
+
+func TestObjectReferenceEqual(t *testing.T) {
+	base := &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}
+	var data = []struct {
+		desc     string
+		a, b     *corev1.ObjectReference
+		expected bool
+	}{
+		{"same reference", base, base.DeepCopy(), true},
+		{"other version of the group", base, &corev1.ObjectReference{APIVersion: "apps/v1beta1", Kind: "Deployment", Namespace: "default", Name: "web"}, true},
+		{"other group", base, &corev1.ObjectReference{APIVersion: "extensions/v1beta1", Kind: "Deployment", Namespace: "default", Name: "web"}, false},
+		{"other namespace", base, &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "other", Name: "web"}, false},
+		{"uid only in one", base, &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", UID: "a"}, true},
+		{"different uids", &corev1.ObjectReference{Kind: "Pod", Name: "a", UID: "a"}, &corev1.ObjectReference{Kind: "Pod", Name: "a", UID: "b"}, false},
+		{"both nil", nil, nil, true},
+		{"one nil", base, nil, false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(ObjectReferenceEqual(item.a, item.b)).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestTypedLocalObjectReferenceEqual(t *testing.T) {
+	g := NewGomegaWithT(t)
+	core, apps := "", "apps"
+
+	g.Expect(TypedLocalObjectReferenceEqual(
+		&corev1.TypedLocalObjectReference{Kind: "ConfigMap", Name: "a"},
+		&corev1.TypedLocalObjectReference{APIGroup: &core, Kind: "ConfigMap", Name: "a"},
+	)).To(BeTrue())
+	g.Expect(TypedLocalObjectReferenceEqual(
+		&corev1.TypedLocalObjectReference{Kind: "Deployment", Name: "a"},
+		&corev1.TypedLocalObjectReference{APIGroup: &apps, Kind: "Deployment", Name: "a"},
+	)).To(BeFalse())
+	g.Expect(TypedLocalObjectReferenceEqual(nil, nil)).To(BeTrue())
+	g.Expect(TypedLocalObjectReferenceEqual(&corev1.TypedLocalObjectReference{}, nil)).To(BeFalse())
+}
@@ -23,6 +23,11 @@ type Pager struct {
 
 	// Page desired to be returned
 	Page int `json:"page"`
+
+	// Continue token returned by a previous list to fetch the next chunk
+	// of items, used instead of Page by token based paginations
+	// +optional
+	Continue string `json:"continue,omitempty"`
 }
 
 // GetPageLimit get the limit returned by a single page
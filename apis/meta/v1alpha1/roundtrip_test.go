@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// widget is a custom resource embedding the shared types like downstream CRDs do
+type widget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   widgetSpec   `json:"spec,omitempty"`
+	Status widgetStatus `json:"status,omitempty"`
+}
+
+type widgetSpec struct {
+	Params      Params        `json:"params,omitempty"`
+	ParamSpecs  ParamSpecs    `json:"paramSpecs,omitempty"`
+	Timeout     *Duration     `json:"timeout,omitempty"`
+	MaxSurge    Percent       `json:"maxSurge,omitempty"`
+	Descriptors UIDescriptors `json:"descriptors,omitempty"`
+	Options     DataMap       `json:"options,omitempty"`
+	List        ListOptions   `json:"list,omitempty"`
+	CreatedBy   *CreatedBy    `json:"createdBy,omitempty"`
+}
+
+type widgetStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	Health     *HealthStatus      `json:"health,omitempty"`
+	Sync       *SyncStatus        `json:"sync,omitempty"`
+}
+
+func (in *widget) DeepCopyObject() runtime.Object {
+	out := new(widget)
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Params = in.Spec.Params.DeepCopy()
+	out.Spec.ParamSpecs = in.Spec.ParamSpecs.DeepCopy()
+	out.Spec.Timeout = in.Spec.Timeout.DeepCopy()
+	out.Spec.Descriptors = in.Spec.Descriptors.DeepCopy()
+	out.Spec.Options = in.Spec.Options.DeepCopy()
+	in.Spec.List.DeepCopyInto(&out.Spec.List)
+	out.Spec.CreatedBy = in.Spec.CreatedBy.DeepCopy()
+	if in.Status.Conditions != nil {
+		out.Status.Conditions = make([]metav1.Condition, len(in.Status.Conditions))
+		for i := range in.Status.Conditions {
+			in.Status.Conditions[i].DeepCopyInto(&out.Status.Conditions[i])
+		}
+	}
+	out.Status.Health = in.Status.Health.DeepCopy()
+	out.Status.Sync = in.Status.Sync.DeepCopy()
+	return out
+}
+
+func newWidget() *widget {
+	now := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	return &widget{
+		ObjectMeta: metav1.ObjectMeta{Name: "widget", Namespace: "default"},
+		Spec: widgetSpec{
+			Params: Params{
+				{Name: "image", Value: *NewStructuredValues("nginx")},
+				{Name: "args", Value: *NewStructuredValues("-v", "-d")},
+				{Name: "labels", Value: *NewObject(map[string]string{"app": "widget"})},
+			},
+			ParamSpecs:  ParamSpecs{{Name: "image", Type: ParamTypeString, Enum: []string{"nginx"}, Default: NewStructuredValues("nginx")}},
+			Timeout:     NewDuration(90 * time.Second),
+			MaxSurge:    NewPercent(25),
+			Descriptors: UIDescriptors{{Path: "spec.image", DisplayName: "Image", XDescriptors: []string{"urn:alm:descriptor:label"}}},
+			Options:     DataMap{"retries": "3"},
+			List: ListOptions{
+				ItemsPerPage: 10,
+				Sort:         SortOptions{SortBy: []SortField{{Field: "name", Order: SortOrderAsc}}},
+				Filter:       FilterOptions{FilterBy: []Filter{{Field: "phase", Value: "Ready"}}},
+			},
+			CreatedBy: &CreatedBy{User: &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "admin"}},
+		},
+		Status: widgetStatus{
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready", LastTransitionTime: now}},
+			Health:     &HealthStatus{Status: HealthStatusHealthy, LastTransitionTime: &now},
+			Sync:       &SyncStatus{Status: SyncStatusSynced, Revision: "abc", SyncedRevision: "abc", LastSyncTime: &now},
+		},
+	}
+}
+
+func newWidgetScheme(t *testing.T) *runtime.Scheme {
+	builder := &scheme.Builder{GroupVersion: schema.GroupVersion{Group: "test.alauda.io", Version: "v1"}}
+	builder.Register(&widget{})
+	s := runtime.NewScheme()
+	NewGomegaWithT(t).Expect(builder.AddToScheme(s)).To(Succeed())
+	return s
+}
+
+func TestDeepCopy_fuzz(t *testing.T) {
+	g := NewGomegaWithT(t)
+	f := fuzz.NewWithSeed(1).NilChance(0.2).NumElements(0, 3)
+
+	objs := map[string]func() (interface{}, interface{}){
+		"CreatedBy":     func() (interface{}, interface{}) { in := &CreatedBy{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"UpdatedBy":     func() (interface{}, interface{}) { in := &UpdatedBy{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"DeletedBy":     func() (interface{}, interface{}) { in := &DeletedBy{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"Duration":      func() (interface{}, interface{}) { in := &Duration{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"ListMeta":      func() (interface{}, interface{}) { in := &ListMeta{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"ListOptions":   func() (interface{}, interface{}) { in := &ListOptions{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"Pager":         func() (interface{}, interface{}) { in := &Pager{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"ParamSpecs":    func() (interface{}, interface{}) { in := ParamSpecs{}; f.Fuzz(&in); return in, in.DeepCopy() },
+		"Params":        func() (interface{}, interface{}) { in := Params{}; f.Fuzz(&in); return in, in.DeepCopy() },
+		"HealthStatus":  func() (interface{}, interface{}) { in := &HealthStatus{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"SyncStatus":    func() (interface{}, interface{}) { in := &SyncStatus{}; f.Fuzz(in); return in, in.DeepCopy() },
+		"UIDescriptors": func() (interface{}, interface{}) { in := UIDescriptors{}; f.Fuzz(&in); return in, in.DeepCopy() },
+		"DataMap":       func() (interface{}, interface{}) { in := DataMap{}; f.Fuzz(&in); return in, in.DeepCopy() },
+	}
+	for name, deepCopy := range objs {
+		for i := 0; i < 20; i++ {
+			in, out := deepCopy()
+			g.Expect(apiequality.Semantic.DeepEqual(in, out)).To(BeTrue(), name)
+		}
+	}
+}
+
+func TestDeepCopy_independent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	in := newWidget()
+	out := in.DeepCopyObject().(*widget)
+
+	out.Spec.Params[2].Value.ObjectVal["app"] = "changed"
+	out.Spec.ParamSpecs[0].Default.StringVal = "changed"
+	out.Spec.Timeout.Duration = time.Second
+	out.Spec.Descriptors[0].XDescriptors[0] = "changed"
+	out.Spec.Options["retries"] = "0"
+	out.Spec.List.Sort.SortBy[0].Field = "changed"
+	out.Spec.CreatedBy.User.Name = "changed"
+	out.Status.Health.LastTransitionTime.Time = time.Time{}
+
+	g.Expect(in).To(Equal(newWidget()))
+}
+
+func TestScheme_roundTrip(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := newWidgetScheme(t)
+	codecs := serializer.NewCodecFactory(s)
+	gv := schema.GroupVersion{Group: "test.alauda.io", Version: "v1"}
+
+	in := newWidget()
+	data, err := runtime.Encode(codecs.LegacyCodec(gv), in)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(ContainSubstring(`"kind":"widget"`))
+	g.Expect(string(data)).To(ContainSubstring(`"timeout":"1m30s"`))
+	g.Expect(string(data)).To(ContainSubstring(`"maxSurge":"25%"`))
+
+	obj, err := runtime.Decode(codecs.UniversalDecoder(gv), data)
+	g.Expect(err).To(BeNil())
+	out, ok := obj.(*widget)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(out.Spec.MaxSurge).To(Equal(in.Spec.MaxSurge))
+	g.Expect(out.Status.Sync.LastSyncTime.Equal(in.Status.Sync.LastSyncTime)).To(BeTrue())
+
+	reencoded, err := runtime.Encode(codecs.LegacyCodec(gv), out)
+	g.Expect(err).To(BeNil())
+	g.Expect(reencoded).To(MatchJSON(data))
+
+	gvks, _, err := s.ObjectKinds(out)
+	g.Expect(err).To(BeNil())
+	g.Expect(gvks).To(ConsistOf(gv.WithKind("widget")))
+	g.Expect(out.DeepCopyObject()).To(Equal(out))
+}
+
+func TestScheme_unstructuredRoundTrip(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	in := newWidget()
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(in)
+	g.Expect(err).To(BeNil())
+	g.Expect(u).To(HaveKeyWithValue("spec", HaveKeyWithValue("maxSurge", "25%")))
+
+	out := &widget{}
+	g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u, out)).To(Succeed())
+	g.Expect(out.Spec.MaxSurge).To(Equal(in.Spec.MaxSurge))
+	g.Expect(out.Spec.Params).To(Equal(in.Spec.Params))
+
+	again, err := runtime.DefaultUnstructuredConverter.ToUnstructured(out)
+	g.Expect(err).To(BeNil())
+	g.Expect(again).To(Equal(u))
+}
+
+func TestGroupVersion_helpers(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(Kind("Widget")).To(Equal(schema.GroupKind{Group: "meta.alauda.io", Kind: "Widget"}))
+	g.Expect(Resource("widgets")).To(Equal(schema.GroupResource{Group: "meta.alauda.io", Resource: "widgets"}))
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Phase is a high level summary of where a resource is in its lifecycle
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Unknown
+type Phase string
+
+const (
+	// PhasePending the resource is accepted but not running yet
+	PhasePending Phase = "Pending"
+	// PhaseRunning the resource is running
+	PhaseRunning Phase = "Running"
+	// PhaseSucceeded the resource finished successfully
+	PhaseSucceeded Phase = "Succeeded"
+	// PhaseFailed the resource finished with a failure
+	PhaseFailed Phase = "Failed"
+	// PhaseUnknown the phase of the resource could not be determined
+	PhaseUnknown Phase = "Unknown"
+)
+
+// Phases returns all the supported phases
+func Phases() []Phase {
+	return []Phase{PhasePending, PhaseRunning, PhaseSucceeded, PhaseFailed, PhaseUnknown}
+}
+
+// IsFinished returns true if the phase is Succeeded or Failed
+func (p Phase) IsFinished() bool {
+	return p == PhaseSucceeded || p == PhaseFailed
+}
+
+// Validate returns an error if the phase is not supported
+func (p Phase) Validate(fld *field.Path) field.ErrorList {
+	return validateEnum(p, Phases(), fld)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface, matching supported values case insensitively
+func (p *Phase) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, p, Phases())
+}
+
+// HealthStatusCode is the health of a resource
+// +kubebuilder:validation:Enum=Healthy;Degraded;Unknown
+type HealthStatusCode string
+
+const (
+	// HealthStatusHealthy the resource is working as expected
+	HealthStatusHealthy HealthStatusCode = "Healthy"
+	// HealthStatusDegraded the resource is working with failures or reduced capacity
+	HealthStatusDegraded HealthStatusCode = "Degraded"
+	// HealthStatusUnknown the health of the resource could not be determined
+	HealthStatusUnknown HealthStatusCode = "Unknown"
+)
+
+// HealthStatusCodes returns all the supported health status codes
+func HealthStatusCodes() []HealthStatusCode {
+	return []HealthStatusCode{HealthStatusHealthy, HealthStatusDegraded, HealthStatusUnknown}
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface, matching supported values case insensitively
+func (c *HealthStatusCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, c, HealthStatusCodes())
+}
+
+// HealthStatus health of a resource
+type HealthStatus struct {
+	// Status of the health
+	Status HealthStatusCode `json:"status"`
+	// Message human readable details of the health
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime last time the status changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IsHealthy returns true if the status is Healthy
+func (h *HealthStatus) IsHealthy() bool {
+	return h != nil && h.Status == HealthStatusHealthy
+}
+
+// Set sets the status and message, LastTransitionTime is only updated when the status changes
+func (h *HealthStatus) Set(status HealthStatusCode, message string, now time.Time) {
+	if h.Status != status || h.LastTransitionTime == nil {
+		h.LastTransitionTime = &metav1.Time{Time: now}
+	}
+	h.Status = status
+	h.Message = message
+}
+
+// Validate returns errors if the status is not supported
+func (h *HealthStatus) Validate(fld *field.Path) field.ErrorList {
+	if h == nil {
+		return nil
+	}
+	return validateEnum(h.Status, HealthStatusCodes(), fld.Child("status"))
+}
+
+// SyncStatusCode indicates if a resource is synced with its desired revision
+// +kubebuilder:validation:Enum=Synced;OutOfSync;Unknown
+type SyncStatusCode string
+
+const (
+	// SyncStatusSynced the resource is synced with the desired revision
+	SyncStatusSynced SyncStatusCode = "Synced"
+	// SyncStatusOutOfSync the resource is not synced with the desired revision
+	SyncStatusOutOfSync SyncStatusCode = "OutOfSync"
+	// SyncStatusUnknown the desired revision is unknown
+	SyncStatusUnknown SyncStatusCode = "Unknown"
+)
+
+// SyncStatusCodes returns all the supported sync status codes
+func SyncStatusCodes() []SyncStatusCode {
+	return []SyncStatusCode{SyncStatusSynced, SyncStatusOutOfSync, SyncStatusUnknown}
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface, matching supported values case insensitively
+func (c *SyncStatusCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, c, SyncStatusCodes())
+}
+
+// SyncStatus tracks the revision a resource is synced to
+type SyncStatus struct {
+	// Status of the sync, computed comparing Revision and SyncedRevision
+	Status SyncStatusCode `json:"status"`
+	// Revision desired revision of the resource
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// SyncedRevision last revision successfully synced
+	// +optional
+	SyncedRevision string `json:"syncedRevision,omitempty"`
+	// LastSyncTime last time the resource was synced
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Message human readable details of the sync
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsSynced returns true if the status is Synced
+func (s *SyncStatus) IsSynced() bool {
+	return s != nil && s.Status == SyncStatusSynced
+}
+
+// SetRevision sets the desired revision and updates the status
+func (s *SyncStatus) SetRevision(revision string) {
+	s.Revision = revision
+	s.updateStatus()
+}
+
+// MarkSynced records revision as synced at now and updates the status
+func (s *SyncStatus) MarkSynced(revision string, now time.Time) {
+	s.SyncedRevision = revision
+	s.LastSyncTime = &metav1.Time{Time: now}
+	s.Message = ""
+	s.updateStatus()
+}
+
+// MarkSyncFailed keeps the last synced revision and sets the message of the failure
+func (s *SyncStatus) MarkSyncFailed(message string) {
+	s.Message = message
+	s.updateStatus()
+}
+
+func (s *SyncStatus) updateStatus() {
+	switch {
+	case s.Revision == "":
+		s.Status = SyncStatusUnknown
+	case s.Revision == s.SyncedRevision:
+		s.Status = SyncStatusSynced
+	default:
+		s.Status = SyncStatusOutOfSync
+	}
+}
+
+// Validate returns errors if the status is not supported or does not match the revisions
+func (s *SyncStatus) Validate(fld *field.Path) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+	errs := validateEnum(s.Status, SyncStatusCodes(), fld.Child("status"))
+	if s.Status == SyncStatusSynced && s.Revision != s.SyncedRevision {
+		errs = append(errs, field.Invalid(fld.Child("status"), s.Status, "revision and syncedRevision must be the same when synced"))
+	}
+	return errs
+}
+
+// validateEnum returns an error if value is not one of the supported values
+func validateEnum[T ~string](value T, supported []T, fld *field.Path) field.ErrorList {
+	for _, item := range supported {
+		if value == item {
+			return nil
+		}
+	}
+	values := make([]string, 0, len(supported))
+	for _, item := range supported {
+		values = append(values, string(item))
+	}
+	return field.ErrorList{field.NotSupported(fld, value, values)}
+}
+
+// unmarshalEnum decodes a string into value, using the supported value matching case insensitively.
+// Values not supported are kept as is to be reported by validation
+func unmarshalEnum[T ~string](data []byte, value *T, supported []T) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	*value = T(str)
+	for _, item := range supported {
+		if strings.EqualFold(str, string(item)) {
+			*value = item
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestPhase(t *testing.T) {
+	var data = []struct {
+		desc     string
+		json     string
+		expected Phase
+		valid    bool
+		finished bool
+	}{
+		{"supported phase", `"Running"`, PhaseRunning, true, false},
+		{"case insensitive", `"succeeded"`, PhaseSucceeded, true, true},
+		{"failed is finished", `"FAILED"`, PhaseFailed, true, true},
+		{"not supported phase is kept", `"Paused"`, Phase("Paused"), false, false},
+		{"empty phase", `""`, Phase(""), false, false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			var phase Phase
+			g.Expect(json.Unmarshal([]byte(item.json), &phase)).To(Succeed())
+			g.Expect(phase).To(Equal(item.expected))
+			g.Expect(phase.IsFinished()).To(Equal(item.finished))
+			if item.valid {
+				g.Expect(phase.Validate(field.NewPath("phase"))).To(BeEmpty())
+			} else {
+				g.Expect(phase.Validate(field.NewPath("phase"))).To(HaveLen(1))
+			}
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	var phase Phase
+	g.Expect(json.Unmarshal([]byte(`1`), &phase)).NotTo(Succeed())
+}
+
+func TestHealthStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var health *HealthStatus
+	g.Expect(health.IsHealthy()).To(BeFalse())
+	g.Expect(health.Validate(field.NewPath("health"))).To(BeEmpty())
+
+	health = &HealthStatus{}
+	g.Expect(json.Unmarshal([]byte(`{"status":"healthy","message":"ok"}`), health)).To(Succeed())
+	g.Expect(health.IsHealthy()).To(BeTrue())
+	g.Expect(health.Validate(field.NewPath("health"))).To(BeEmpty())
+
+	health.Set(HealthStatusHealthy, "still ok", now)
+	g.Expect(health.LastTransitionTime.Time).To(Equal(now))
+	health.Set(HealthStatusHealthy, "", now.Add(time.Minute))
+	g.Expect(health.LastTransitionTime.Time).To(Equal(now))
+	health.Set(HealthStatusDegraded, "1/3 replicas unavailable", now.Add(2*time.Minute))
+	g.Expect(health.LastTransitionTime.Time).To(Equal(now.Add(2 * time.Minute)))
+	g.Expect(health.Message).To(Equal("1/3 replicas unavailable"))
+
+	copied := health.DeepCopy()
+	copied.LastTransitionTime.Time = now
+	g.Expect(health.LastTransitionTime.Time).To(Equal(now.Add(2 * time.Minute)))
+
+	data, err := json.Marshal(HealthStatus{Status: HealthStatusUnknown})
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(Equal(`{"status":"Unknown"}`))
+
+	errs := (&HealthStatus{Status: "Broken"}).Validate(field.NewPath("status", "health"))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("status.health.status"))
+}
+
+func TestSyncStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fld := field.NewPath("sync")
+
+	var sync *SyncStatus
+	g.Expect(sync.IsSynced()).To(BeFalse())
+	g.Expect(sync.Validate(fld)).To(BeEmpty())
+
+	sync = &SyncStatus{}
+	sync.SetRevision("")
+	g.Expect(sync.Status).To(Equal(SyncStatusUnknown))
+
+	sync.SetRevision("abc")
+	g.Expect(sync.Status).To(Equal(SyncStatusOutOfSync))
+
+	sync.MarkSyncFailed("clone failed")
+	g.Expect(sync.Status).To(Equal(SyncStatusOutOfSync))
+	g.Expect(sync.Message).To(Equal("clone failed"))
+
+	sync.MarkSynced("abc", now)
+	g.Expect(sync.IsSynced()).To(BeTrue())
+	g.Expect(sync.Message).To(BeEmpty())
+	g.Expect(sync.LastSyncTime.Time).To(Equal(now))
+	g.Expect(sync.Validate(fld)).To(BeEmpty())
+
+	sync.SetRevision("def")
+	g.Expect(sync.Status).To(Equal(SyncStatusOutOfSync))
+	g.Expect(sync.SyncedRevision).To(Equal("abc"))
+
+	g.Expect(json.Unmarshal([]byte(`{"status":"outofsync","revision":"def"}`), sync)).To(Succeed())
+	g.Expect(sync.Status).To(Equal(SyncStatusOutOfSync))
+
+	g.Expect((&SyncStatus{Status: SyncStatusSynced, Revision: "a", SyncedRevision: "b"}).Validate(fld)).To(HaveLen(1))
+	g.Expect((&SyncStatus{Status: "Syncing"}).Validate(fld)).To(HaveLen(1))
+}
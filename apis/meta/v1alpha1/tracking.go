@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrackCreation sets the createdBy and creation time annotations of the object when missing,
+// a nil subject only sets the creation time
+func TrackCreation(obj metav1.Object, subject *rbacv1.Subject, t time.Time) {
+	if by, _ := GetCreatedBy(obj); by.IsZero() && subject != nil {
+		SetCreatedBy(obj, &CreatedBy{User: subject})
+	}
+	if created, _ := GetCreatedTime(obj); created.IsZero() {
+		SetCreatedTime(obj, t)
+	}
+}
+
+// TrackUpdate sets the updatedBy and update time annotations of the object,
+// a nil subject only sets the update time
+func TrackUpdate(obj metav1.Object, subject *rbacv1.Subject, t time.Time) {
+	if subject != nil {
+		SetUpdatedBy(obj, &UpdatedBy{User: subject})
+	}
+	SetUpdatedTime(obj, t)
+}
+
+// TrackDeletion sets the deletedBy and deletion time annotations of the object when missing,
+// a nil subject only sets the deletion time, which defaults to the deletion timestamp of the object.
+// Returns true if any annotation was set
+func TrackDeletion(obj metav1.Object, subject *rbacv1.Subject, t time.Time) (changed bool) {
+	if by, _ := GetDeletedBy(obj); by.IsZero() && subject != nil {
+		SetDeletedBy(obj, &DeletedBy{User: subject})
+		changed = true
+	}
+	if t.IsZero() && obj.GetDeletionTimestamp() != nil {
+		t = obj.GetDeletionTimestamp().Time
+	}
+	if deleted, _ := GetDeletedTime(obj); deleted.IsZero() && !t.IsZero() {
+		SetDeletedTime(obj, t)
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTracking(t *testing.T) {
+	g := NewGomegaWithT(t)
+	admin := &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "admin"}
+	dev := &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "dev"}
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	obj := &corev1.ConfigMap{}
+	TrackCreation(obj, nil, first)
+	g.Expect(obj.Annotations).To(Equal(map[string]string{CreatedTimeAnnotationKey: "2024-01-02T03:04:05Z"}))
+
+	// existing values are kept
+	TrackCreation(obj, admin, second)
+	TrackCreation(obj, dev, second)
+	created, _ := GetCreatedTime(obj)
+	g.Expect(created).To(Equal(first))
+	by, _ := GetCreatedBy(obj)
+	g.Expect(by.User.Name).To(Equal("admin"))
+
+	TrackUpdate(obj, dev, first)
+	TrackUpdate(obj, nil, second)
+	updated, _ := GetUpdatedTime(obj)
+	g.Expect(updated).To(Equal(second))
+	updatedBy, _ := GetUpdatedBy(obj)
+	g.Expect(updatedBy.User.Name).To(Equal("dev"))
+
+	g.Expect(TrackDeletion(obj, nil, time.Time{})).To(BeFalse(), "no deletion timestamp")
+	obj.DeletionTimestamp = &metav1.Time{Time: second}
+	g.Expect(TrackDeletion(obj, admin, time.Time{})).To(BeTrue())
+	g.Expect(TrackDeletion(obj, dev, first)).To(BeFalse())
+	deleted, _ := GetDeletedTime(obj)
+	g.Expect(deleted).To(Equal(second))
+	deletedBy, _ := GetDeletedBy(obj)
+	g.Expect(deletedBy.User.Name).To(Equal("admin"))
+}
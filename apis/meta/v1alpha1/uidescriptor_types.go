@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// XDescriptorPrefix prefix of the x-descriptors understood by the ui
+const XDescriptorPrefix = "urn:alm:descriptor:com.tectonic.ui:"
+
+const (
+	// XDescriptorText displays the field as a text input
+	XDescriptorText = XDescriptorPrefix + "text"
+	// XDescriptorPassword displays the field as a masked input
+	XDescriptorPassword = XDescriptorPrefix + "password"
+	// XDescriptorNumber displays the field as a number input
+	XDescriptorNumber = XDescriptorPrefix + "number"
+	// XDescriptorBooleanSwitch displays the field as a switch
+	XDescriptorBooleanSwitch = XDescriptorPrefix + "booleanSwitch"
+	// XDescriptorSelect displays the field as a select, options are appended as select:<option>
+	XDescriptorSelect = XDescriptorPrefix + "select"
+	// XDescriptorAdvanced displays the field in the advanced section
+	XDescriptorAdvanced = XDescriptorPrefix + "advanced"
+	// XDescriptorHidden hides the field
+	XDescriptorHidden = XDescriptorPrefix + "hidden"
+)
+
+// UIDescriptor describes how a field of a resource is displayed in the ui
+type UIDescriptor struct {
+	// Path of the field as dot separated names, e.g. spec.git.url
+	Path string `json:"path"`
+	// DisplayName of the field, defaults to the last name of the path
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Description of the field
+	// +optional
+	Description string `json:"description,omitempty"`
+	// XDescriptors display hints of the field, e.g. urn:alm:descriptor:com.tectonic.ui:password
+	// +optional
+	XDescriptors []string `json:"x-descriptors,omitempty"`
+	// Placeholder shown in empty inputs
+	// +optional
+	Placeholder string `json:"placeholder,omitempty"`
+	// Order of the field in forms, fields with lower order are displayed first
+	// +optional
+	Order int `json:"order,omitempty"`
+}
+
+// HasXDescriptor returns true if the descriptor has the x-descriptor
+func (d *UIDescriptor) HasXDescriptor(xDescriptor string) bool {
+	for _, item := range d.XDescriptors {
+		if item == xDescriptor {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDefaults trims the path, defaults the display name to the last name of the path
+// and removes duplicated x-descriptors
+func (d *UIDescriptor) SetDefaults() {
+	d.Path = strings.TrimSpace(d.Path)
+	if d.DisplayName == "" {
+		d.DisplayName = d.Path[strings.LastIndex(d.Path, ".")+1:]
+	}
+	if len(d.XDescriptors) > 0 {
+		seen := sets.New[string]()
+		xDescriptors := make([]string, 0, len(d.XDescriptors))
+		for _, item := range d.XDescriptors {
+			if !seen.Has(item) {
+				seen.Insert(item)
+				xDescriptors = append(xDescriptors, item)
+			}
+		}
+		d.XDescriptors = xDescriptors
+	}
+}
+
+// Validate returns errors if the path is empty or malformed or an x-descriptor is not an urn
+func (d *UIDescriptor) Validate(fld *field.Path) (errs field.ErrorList) {
+	if d.Path == "" {
+		errs = append(errs, field.Required(fld.Child("path"), "path is required"))
+	} else {
+		for _, name := range strings.Split(d.Path, ".") {
+			if strings.TrimSpace(name) == "" {
+				errs = append(errs, field.Invalid(fld.Child("path"), d.Path, "path must be dot separated field names"))
+				break
+			}
+		}
+	}
+	for i, item := range d.XDescriptors {
+		if !strings.HasPrefix(item, "urn:") {
+			errs = append(errs, field.Invalid(fld.Child("x-descriptors").Index(i), item, "x-descriptor must be an urn"))
+		}
+	}
+	return errs
+}
+
+// UIDescriptors list of ui descriptors stored as JSON in the UIDescriptorsAnnotationKey annotation
+type UIDescriptors []UIDescriptor
+
+// Get returns the descriptor of the path, or nil
+func (d UIDescriptors) Get(path string) *UIDescriptor {
+	for i := range d {
+		if d[i].Path == path {
+			return &d[i]
+		}
+	}
+	return nil
+}
+
+// SetDefaults sets the defaults of each descriptor
+func (d UIDescriptors) SetDefaults() {
+	for i := range d {
+		d[i].SetDefaults()
+	}
+}
+
+// Validate returns errors of each descriptor and for duplicated paths
+func (d UIDescriptors) Validate(fld *field.Path) (errs field.ErrorList) {
+	paths := sets.New[string]()
+	for i := range d {
+		errs = append(errs, d[i].Validate(fld.Index(i))...)
+		if d[i].Path != "" && paths.Has(d[i].Path) {
+			errs = append(errs, field.Duplicate(fld.Index(i).Child("path"), d[i].Path))
+		}
+		paths.Insert(d[i].Path)
+	}
+	return errs
+}
+
+// GetUIDescriptors returns the ui descriptors stored in the annotations of the object
+// returns nil and nil error if the annotation is not set or empty,
+// and an error if the annotation is not valid JSON or the descriptors are invalid
+func GetUIDescriptors(obj metav1.Object) (UIDescriptors, error) {
+	value := getAnnotation(obj, UIDescriptorsAnnotationKey)
+	if value == "" {
+		return nil, nil
+	}
+	descriptors := UIDescriptors{}
+	if err := json.Unmarshal([]byte(value), &descriptors); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", UIDescriptorsAnnotationKey, err)
+	}
+	if errs := descriptors.Validate(field.NewPath("metadata", "annotations").Key(UIDescriptorsAnnotationKey)); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return descriptors, nil
+}
+
+// SetUIDescriptors stores the ui descriptors into the annotations of the object
+// after setting their defaults, empty descriptors remove the annotation.
+// Returns an error without changing the object if the descriptors are invalid
+func SetUIDescriptors(obj metav1.Object, descriptors UIDescriptors) error {
+	if len(descriptors) == 0 {
+		setAnnotation(obj, UIDescriptorsAnnotationKey, "")
+		return nil
+	}
+	descriptors = descriptors.DeepCopy()
+	descriptors.SetDefaults()
+	if errs := descriptors.Validate(field.NewPath("metadata", "annotations").Key(UIDescriptorsAnnotationKey)); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	data, err := json.Marshal(descriptors)
+	if err != nil {
+		return err
+	}
+	setAnnotation(obj, UIDescriptorsAnnotationKey, string(data))
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestUIDescriptors_Validate(t *testing.T) {
+	var data = []struct {
+		desc        string
+		descriptors UIDescriptors
+		errs        int
+	}{
+		{"valid", UIDescriptors{{Path: "spec.url", XDescriptors: []string{XDescriptorText}}, {Path: "spec.token"}}, 0},
+		{"empty path", UIDescriptors{{Path: ""}}, 1},
+		{"malformed path", UIDescriptors{{Path: "spec..url"}}, 1},
+		{"duplicated path", UIDescriptors{{Path: "spec.url"}, {Path: "spec.url"}}, 1},
+		{"invalid x-descriptor", UIDescriptors{{Path: "spec.url", XDescriptors: []string{"text", XDescriptorText, "password"}}}, 2},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(item.descriptors.Validate(field.NewPath("descriptors"))).To(HaveLen(item.errs))
+		})
+	}
+}
+
+func TestUIDescriptors_SetDefaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+	descriptors := UIDescriptors{
+		{Path: " spec.git.url ", XDescriptors: []string{XDescriptorText, XDescriptorAdvanced, XDescriptorText}},
+		{Path: "replicas", DisplayName: "Replicas count"},
+	}
+	descriptors.SetDefaults()
+	g.Expect(descriptors).To(Equal(UIDescriptors{
+		{Path: "spec.git.url", DisplayName: "url", XDescriptors: []string{XDescriptorText, XDescriptorAdvanced}},
+		{Path: "replicas", DisplayName: "Replicas count"},
+	}))
+	g.Expect(descriptors.Get("spec.git.url").HasXDescriptor(XDescriptorAdvanced)).To(BeTrue())
+	g.Expect(descriptors.Get("replicas").HasXDescriptor(XDescriptorAdvanced)).To(BeFalse())
+	g.Expect(descriptors.Get("spec.missing")).To(BeNil())
+}
+
+func TestUIDescriptorsAccessors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	obj := &corev1.ConfigMap{}
+
+	descriptors, err := GetUIDescriptors(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(descriptors).To(BeNil())
+
+	original := UIDescriptors{{Path: "spec.token", XDescriptors: []string{XDescriptorPassword}, Order: 1}}
+	g.Expect(SetUIDescriptors(obj, original)).To(Succeed())
+	g.Expect(original[0].DisplayName).To(BeEmpty())
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(UIDescriptorsAnnotationKey,
+		`[{"path":"spec.token","displayName":"token","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:password"],"order":1}]`))
+
+	descriptors, err = GetUIDescriptors(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(descriptors).To(Equal(UIDescriptors{
+		{Path: "spec.token", DisplayName: "token", XDescriptors: []string{XDescriptorPassword}, Order: 1},
+	}))
+
+	g.Expect(SetUIDescriptors(obj, UIDescriptors{{Path: ""}})).To(MatchError(ContainSubstring("path is required")))
+	g.Expect(obj.Annotations).To(HaveKey(UIDescriptorsAnnotationKey))
+
+	g.Expect(SetUIDescriptors(obj, nil)).To(Succeed())
+	g.Expect(obj.Annotations).NotTo(HaveKey(UIDescriptorsAnnotationKey))
+
+	obj.Annotations = map[string]string{UIDescriptorsAnnotationKey: `{"path":"spec"}`}
+	_, err = GetUIDescriptors(obj)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid " + UIDescriptorsAnnotationKey + " annotation")))
+
+	obj.Annotations = map[string]string{UIDescriptorsAnnotationKey: `[{"path":"spec"},{"path":"spec"}]`}
+	_, err = GetUIDescriptors(obj)
+	g.Expect(err).To(MatchError(ContainSubstring("Duplicate value")))
+}
@@ -85,6 +85,75 @@ func (in *DeletedBy) DeepCopy() *DeletedBy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Duration) DeepCopyInto(out *Duration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Duration.
+func (in *Duration) DeepCopy() *Duration {
+	if in == nil {
+		return nil
+	}
+	out := new(Duration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Filter) DeepCopyInto(out *Filter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
+func (in *Filter) DeepCopy() *Filter {
+	if in == nil {
+		return nil
+	}
+	out := new(Filter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterOptions) DeepCopyInto(out *FilterOptions) {
+	*out = *in
+	if in.FilterBy != nil {
+		in, out := &in.FilterBy, &out.FilterBy
+		*out = make([]Filter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterOptions.
+func (in *FilterOptions) DeepCopy() *FilterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthStatus) DeepCopyInto(out *HealthStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthStatus.
+func (in *HealthStatus) DeepCopy() *HealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ListMeta) DeepCopyInto(out *ListMeta) {
 	*out = *in
@@ -119,6 +188,8 @@ func (in *ListMeta) DeepCopy() *ListMeta {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ListOptions) DeepCopyInto(out *ListOptions) {
 	*out = *in
+	in.Sort.DeepCopyInto(&out.Sort)
+	in.Filter.DeepCopyInto(&out.Filter)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListOptions.
@@ -263,6 +334,21 @@ func (in Params) DeepCopy() Params {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Percent) DeepCopyInto(out *Percent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Percent.
+func (in *Percent) DeepCopy() *Percent {
+	if in == nil {
+		return nil
+	}
+	out := new(Percent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PropertySpec) DeepCopyInto(out *PropertySpec) {
 	*out = *in
@@ -278,6 +364,101 @@ func (in *PropertySpec) DeepCopy() *PropertySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SortField) DeepCopyInto(out *SortField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SortField.
+func (in *SortField) DeepCopy() *SortField {
+	if in == nil {
+		return nil
+	}
+	out := new(SortField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SortOptions) DeepCopyInto(out *SortOptions) {
+	*out = *in
+	if in.SortBy != nil {
+		in, out := &in.SortBy, &out.SortBy
+		*out = make([]SortField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SortOptions.
+func (in *SortOptions) DeepCopy() *SortOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SortOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncStatus.
+func (in *SyncStatus) DeepCopy() *SyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UIDescriptor) DeepCopyInto(out *UIDescriptor) {
+	*out = *in
+	if in.XDescriptors != nil {
+		in, out := &in.XDescriptors, &out.XDescriptors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UIDescriptor.
+func (in *UIDescriptor) DeepCopy() *UIDescriptor {
+	if in == nil {
+		return nil
+	}
+	out := new(UIDescriptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in UIDescriptors) DeepCopyInto(out *UIDescriptors) {
+	{
+		in := &in
+		*out = make(UIDescriptors, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UIDescriptors.
+func (in UIDescriptors) DeepCopy() UIDescriptors {
+	if in == nil {
+		return nil
+	}
+	out := new(UIDescriptors)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdatedBy) DeepCopyInto(out *UpdatedBy) {
 	*out = *in
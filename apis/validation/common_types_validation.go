@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	v1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -57,6 +58,29 @@ func ValidateObjectReference(objref *corev1.ObjectReference, optional, needsReso
 	return errs
 }
 
+// ValidateTypedLocalObjectReference validates a typed local object reference
+func ValidateTypedLocalObjectReference(ref *corev1.TypedLocalObjectReference, optional bool, fld *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if ref == nil {
+		if !optional {
+			errs = append(errs, field.Required(fld, "a valid reference is required"))
+		}
+		return errs
+	}
+	if ref.Kind == "" {
+		errs = append(errs, field.Required(fld.Child("kind"), "needs to specify a specific kind"))
+	}
+	if ref.APIGroup != nil && *ref.APIGroup != "" {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(*ref.APIGroup) {
+			errs = append(errs, field.Invalid(fld.Child("apiGroup"), *ref.APIGroup, msg))
+		}
+	}
+	if ref.Name == "" {
+		errs = append(errs, field.Required(fld.Child("name"), "needs to specify a resource name"))
+	}
+	return errs
+}
+
 // ValidateCommonObject common validations for objects in katanomi,
 // includes, name, annotations etc.
 func ValidateCommonObject(obj metav1.Object) field.ErrorList {
@@ -125,6 +125,31 @@ func TestValidateObjectReference(t *testing.T) {
 
 }
 
+func TestValidateTypedLocalObjectReference(t *testing.T) {
+	apps, invalidGroup := "apps", "Apps_v1"
+
+	table := map[string]struct {
+		Object   *corev1.TypedLocalObjectReference
+		optional bool
+		errs     int
+	}{
+		"Nil non optional, should error":       {nil, false, 1},
+		"Nil optional, should succeed":         {nil, true, 0},
+		"Empty reference, should error twice":  {&corev1.TypedLocalObjectReference{}, false, 2},
+		"Invalid api group, should error":      {&corev1.TypedLocalObjectReference{APIGroup: &invalidGroup, Kind: "Deployment", Name: "abc"}, false, 1},
+		"Core group reference, should succeed": {&corev1.TypedLocalObjectReference{Kind: "ConfigMap", Name: "abc"}, false, 0},
+		"Grouped reference, should succeed":    {&corev1.TypedLocalObjectReference{APIGroup: &apps, Kind: "Deployment", Name: "abc"}, false, 0},
+	}
+
+	for i, test := range table {
+		t.Run(i, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			errs := ValidateTypedLocalObjectReference(test.Object, test.optional, field.NewPath("ref"))
+			g.Expect(errs).To(HaveLen(test.errs))
+		})
+	}
+}
+
 func TestReturnInvalidError(t *testing.T) {
 	g := NewGomegaWithT(t)
 	gk := schema.GroupKind{Group: "abc", Kind: "FooBar"}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Func validates obj returning the errors found
+type Func[T any] func(obj T) field.ErrorList
+
+// UpdateFunc validates an update from oldObj to newObj returning the errors found
+type UpdateFunc[T any] func(oldObj, newObj T) field.ErrorList
+
+// Validate runs all funcs against obj and returns all the errors found
+func Validate[T any](obj T, funcs ...Func[T]) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, fn := range funcs {
+		errs = append(errs, fn(obj)...)
+	}
+	return errs
+}
+
+// ValidateUpdate runs all funcs against the update from oldObj to newObj and returns all the errors found
+func ValidateUpdate[T any](oldObj, newObj T, funcs ...UpdateFunc[T]) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, fn := range funcs {
+		errs = append(errs, fn(oldObj, newObj)...)
+	}
+	return errs
+}
+
+// Field returns a Func validating the value returned by get at fld with validators, e.g.
+//
+//	validation.Field(field.NewPath("spec", "url"), func(t *Tool) string { return t.Spec.URL }, validation.ValidateURLString)
+func Field[T any, V any](fld *field.Path, get func(T) V, validators ...func(V, *field.Path) field.ErrorList) Func[T] {
+	return func(obj T) field.ErrorList {
+		value := get(obj)
+		errs := field.ErrorList{}
+		for _, validate := range validators {
+			errs = append(errs, validate(value, fld)...)
+		}
+		return errs
+	}
+}
+
+// Immutable returns an UpdateFunc validating the value returned by get at fld does not change
+func Immutable[T any, V any](fld *field.Path, get func(T) V) UpdateFunc[T] {
+	return func(oldObj, newObj T) field.ErrorList {
+		return ValidateImmutableField(get(newObj), get(oldObj), fld)
+	}
+}
+
+// OnUpdate returns an UpdateFunc running funcs against the new object
+func OnUpdate[T any](funcs ...Func[T]) UpdateFunc[T] {
+	return func(_, newObj T) field.ErrorList {
+		return Validate(newObj, funcs...)
+	}
+}
+
+// Rules composes validations of objects of type T and implements the validator of
+// the webhook package, validation errors are returned as field error aggregates
+// which are denied with an Invalid status and one cause per field
+type Rules[T any] struct {
+	// Create validations run on creation
+	Create []Func[T]
+	// Update validations run on update
+	Update []UpdateFunc[T]
+}
+
+// ValidateCreate runs the Create validations
+func (r Rules[T]) ValidateCreate(_ context.Context, obj T) (admission.Warnings, error) {
+	return nil, Validate(obj, r.Create...).ToAggregate()
+}
+
+// ValidateUpdate runs the Update validations
+func (r Rules[T]) ValidateUpdate(_ context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	return nil, ValidateUpdate(oldObj, newObj, r.Update...).ToAggregate()
+}
+
+// ValidateDelete allows all deletions
+func (r Rules[T]) ValidateDelete(_ context.Context, _ T) (admission.Warnings, error) {
+	return nil, nil
+}
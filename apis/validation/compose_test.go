@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/webhook"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var configMapRules = Rules[*corev1.ConfigMap]{
+	Create: []Func[*corev1.ConfigMap]{
+		Field(field.NewPath("metadata", "name"), func(cm *corev1.ConfigMap) string { return cm.Name }, ValidateDNS1123Label),
+		Field(field.NewPath("data", "url"), func(cm *corev1.ConfigMap) string { return cm.Data["url"] },
+			func(value string, fld *field.Path) field.ErrorList { return ValidateURLString(value, fld, "https") }),
+	},
+	Update: []UpdateFunc[*corev1.ConfigMap]{
+		Immutable(field.NewPath("data", "url"), func(cm *corev1.ConfigMap) string { return cm.Data["url"] }),
+		OnUpdate(Field(field.NewPath("data", "timeout"), func(cm *corev1.ConfigMap) string { return cm.Data["timeout"] }, ValidateDuration)),
+	},
+}
+
+var _ webhook.Validator[*corev1.ConfigMap] = configMapRules
+
+func newConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name}, Data: data}
+}
+
+func TestValidate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	errs := Validate(newConfigMap("my.name", map[string]string{"url": "http://github.com"}), configMapRules.Create...)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("metadata.name"))
+	g.Expect(errs[1].Field).To(Equal("data.url"))
+
+	errs = Validate(newConfigMap("my-name", map[string]string{"url": "https://github.com"}), configMapRules.Create...)
+	g.Expect(errs).To(BeEmpty())
+}
+
+func TestRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	warnings, err := configMapRules.ValidateCreate(ctx, newConfigMap("my-name", map[string]string{"url": "https://github.com"}))
+	g.Expect(warnings).To(BeNil())
+	g.Expect(err).To(BeNil())
+
+	_, err = configMapRules.ValidateCreate(ctx, newConfigMap("my.name", map[string]string{"url": "https://github.com"}))
+	var aggregate utilerrors.Aggregate
+	g.Expect(errors.As(err, &aggregate)).To(BeTrue())
+	g.Expect(aggregate.Errors()).To(HaveLen(1))
+
+	old := newConfigMap("my-name", map[string]string{"url": "https://github.com", "timeout": "1m"})
+	_, err = configMapRules.ValidateUpdate(ctx, old, newConfigMap("my-name", map[string]string{"url": "https://gitlab.com", "timeout": "1 minute"}))
+	g.Expect(err).To(MatchError(And(ContainSubstring("data.url: Invalid value"), ContainSubstring("data.timeout: Invalid value"))))
+
+	_, err = configMapRules.ValidateUpdate(ctx, old, old.DeepCopy())
+	g.Expect(err).To(BeNil())
+
+	_, err = configMapRules.ValidateDelete(ctx, old)
+	g.Expect(err).To(BeNil())
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateDNS1123Label validates value is a DNS (RFC 1123) label, e.g. a namespace name
+func ValidateDNS1123Label(value string, fld *field.Path) field.ErrorList {
+	return toFieldErrors(value, utilvalidation.IsDNS1123Label(value), fld)
+}
+
+// ValidateDNS1123Subdomain validates value is a DNS (RFC 1123) subdomain, e.g. most object names
+func ValidateDNS1123Subdomain(value string, fld *field.Path) field.ErrorList {
+	return toFieldErrors(value, utilvalidation.IsDNS1123Subdomain(value), fld)
+}
+
+// ValidateDuration validates value is a duration like 1m30s
+func ValidateDuration(value string, fld *field.Path) field.ErrorList {
+	if _, err := time.ParseDuration(value); err != nil {
+		return field.ErrorList{field.Invalid(fld, value, "must be a duration like 1m30s")}
+	}
+	return nil
+}
+
+// ValidateDurationRange validates duration is between min and max, both included.
+// A zero min or max means no bound
+func ValidateDurationRange(duration, min, max time.Duration, fld *field.Path) field.ErrorList {
+	if (min != 0 && duration < min) || (max != 0 && duration > max) {
+		return field.ErrorList{field.Invalid(fld, duration.String(), rangeMessage(min, max, time.Duration(0)))}
+	}
+	return nil
+}
+
+// ValidateURLString validates value is an absolute url using one of schemes,
+// any scheme is accepted when schemes is empty
+func ValidateURLString(value string, fld *field.Path, schemes ...string) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(fld, "value is required")}
+	}
+	uri, err := url.ParseRequestURI(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fld, value, err.Error())}
+	}
+	if uri.Scheme == "" || uri.Host == "" {
+		return field.ErrorList{field.Invalid(fld, value, "must be an absolute url with scheme and host")}
+	}
+	if len(schemes) > 0 && !containsFold(schemes, uri.Scheme) {
+		return field.ErrorList{field.NotSupported(fld, uri.Scheme, schemes)}
+	}
+	return nil
+}
+
+// ValidateQuantityRange validates quantity is between min and max, both included.
+// A nil min or max means no bound
+func ValidateQuantityRange(quantity resource.Quantity, min, max *resource.Quantity, fld *field.Path) field.ErrorList {
+	if (min != nil && quantity.Cmp(*min) < 0) || (max != nil && quantity.Cmp(*max) > 0) {
+		var minValue, maxValue interface{}
+		if min != nil {
+			minValue = min.String()
+		}
+		if max != nil {
+			maxValue = max.String()
+		}
+		return field.ErrorList{field.Invalid(fld, quantity.String(), rangeMessage(minValue, maxValue, nil))}
+	}
+	return nil
+}
+
+// ValidateImmutableField validates newVal did not change from oldVal on updates
+var ValidateImmutableField = apimachineryvalidation.ValidateImmutableField
+
+// rangeMessage describes a range where bounds equal to unbounded are omitted
+func rangeMessage(min, max, unbounded interface{}) string {
+	switch {
+	case min == unbounded:
+		return fmt.Sprintf("must be less than or equal to %v", max)
+	case max == unbounded:
+		return fmt.Sprintf("must be greater than or equal to %v", min)
+	default:
+		return fmt.Sprintf("must be between %v and %v", min, max)
+	}
+}
+
+func toFieldErrors(value string, messages []string, fld *field.Path) (errs field.ErrorList) {
+	for _, msg := range messages {
+		errs = append(errs, field.Invalid(fld, value, msg))
+	}
+	return
+}
+
+func containsFold(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestFieldValidators(t *testing.T) {
+	fld := field.NewPath("spec", "value")
+	min, max := resource.MustParse("100m"), resource.MustParse("2")
+
+	table := map[string]struct {
+		errs   field.ErrorList
+		detail string
+	}{
+		"valid dns1123 label":                {ValidateDNS1123Label("my-name", fld), ""},
+		"dns1123 label with dots":            {ValidateDNS1123Label("my.name", fld), "must not contain dots"},
+		"valid dns1123 subdomain":            {ValidateDNS1123Subdomain("my.name", fld), ""},
+		"dns1123 subdomain with underscores": {ValidateDNS1123Subdomain("my_name", fld), "RFC 1123 subdomain"},
+		"valid duration":                     {ValidateDuration("1m30s", fld), ""},
+		"invalid duration":                   {ValidateDuration("1 minute", fld), "must be a duration like 1m30s"},
+		"duration in range":                  {ValidateDurationRange(time.Minute, time.Second, time.Hour, fld), ""},
+		"duration without max":               {ValidateDurationRange(48*time.Hour, time.Second, 0, fld), ""},
+		"duration below min":                 {ValidateDurationRange(time.Millisecond, time.Second, 0, fld), "must be greater than or equal to 1s"},
+		"duration above max":                 {ValidateDurationRange(2*time.Hour, 0, time.Hour, fld), "must be less than or equal to 1h0m0s"},
+		"duration out of range":              {ValidateDurationRange(2*time.Hour, time.Second, time.Hour, fld), "must be between 1s and 1h0m0s"},
+		"valid url":                          {ValidateURLString("https://github.com/org", fld), ""},
+		"valid url with scheme":              {ValidateURLString("HTTPS://github.com", fld, "http", "https"), ""},
+		"empty url":                          {ValidateURLString("", fld), "value is required"},
+		"relative url":                       {ValidateURLString("/path", fld), "must be an absolute url"},
+		"url with unsupported scheme":        {ValidateURLString("ftp://github.com", fld, "http", "https"), `supported values: "http", "https"`},
+		"quantity in range":                  {ValidateQuantityRange(resource.MustParse("1"), &min, &max, fld), ""},
+		"quantity without bounds":            {ValidateQuantityRange(resource.MustParse("10"), nil, nil, fld), ""},
+		"quantity below min":                 {ValidateQuantityRange(resource.MustParse("10m"), &min, nil, fld), "must be greater than or equal to 100m"},
+		"quantity above max":                 {ValidateQuantityRange(resource.MustParse("3"), &min, &max, fld), "must be between 100m and 2"},
+		"immutable field unchanged":          {ValidateImmutableField("a", "a", fld), ""},
+		"immutable field changed":            {ValidateImmutableField("b", "a", fld), "field is immutable"},
+	}
+
+	for name, test := range table {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			if test.detail == "" {
+				g.Expect(test.errs).To(BeEmpty())
+				return
+			}
+			g.Expect(test.errs).To(HaveLen(1))
+			g.Expect(test.errs[0].Field).To(Equal("spec.value"))
+			g.Expect(test.errs[0].Error()).To(ContainSubstring(test.detail))
+		})
+	}
+}
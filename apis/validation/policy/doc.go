@@ -0,0 +1,32 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy has declarative rules validating updates of fields selected by path,
+// so webhooks report immutable, append-only and non-decreasing fields with the same messages:
+//
+//	var widgetUpdateRules = []policy.Rule{
+//		policy.Immutable(".spec.storageClass"),
+//		policy.AppendOnly(".spec.targets"),
+//		policy.DecreasingForbidden(".spec.replicas"),
+//	}
+//
+//	rules := validation.Rules[*v1alpha1.Widget]{
+//		Update: []validation.UpdateFunc[*v1alpha1.Widget]{policy.Update[*v1alpha1.Widget](widgetUpdateRules...)},
+//	}
+//
+// Paths select fields by their json names separated by dots. Objects are converted to
+// unstructured content before the rules are evaluated.
+package policy
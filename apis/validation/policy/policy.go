@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/AlaudaDevops/pkg/apis/validation"
+)
+
+// Rule validates the update of the field at its path
+type Rule struct {
+	// Path of the field, e.g. .spec.replicas
+	Path string
+
+	fields []string
+	check  func(fld *field.Path, oldValue, newValue interface{}, oldFound, newFound bool) field.ErrorList
+}
+
+func newRule(path string, check func(fld *field.Path, oldValue, newValue interface{}, oldFound, newFound bool) field.ErrorList) Rule {
+	if !strings.HasPrefix(path, ".") || len(path) == 1 {
+		panic(fmt.Sprintf("invalid path %q, it must start with a dot, e.g. .spec.replicas", path))
+	}
+	fields := strings.Split(path[1:], ".")
+	for _, name := range fields {
+		if name == "" {
+			panic(fmt.Sprintf("invalid path %q, it has an empty field name", path))
+		}
+	}
+	return Rule{Path: path, fields: fields, check: check}
+}
+
+// Immutable forbids any change of the field, including setting or removing it.
+// It panics when path is invalid
+func Immutable(path string) Rule {
+	return newRule(path, func(fld *field.Path, oldValue, newValue interface{}, oldFound, newFound bool) field.ErrorList {
+		if oldFound == newFound && equality.Semantic.DeepEqual(oldValue, newValue) {
+			return nil
+		}
+		return field.ErrorList{field.Invalid(fld, newValue, "field is immutable")}
+	})
+}
+
+// AppendOnly only allows adding items at the end of the list field,
+// existing items cannot be changed, reordered or removed. It panics when path is invalid
+func AppendOnly(path string) Rule {
+	return newRule(path, func(fld *field.Path, oldValue, newValue interface{}, oldFound, _ bool) field.ErrorList {
+		if !oldFound {
+			return nil
+		}
+		oldItems, ok := oldValue.([]interface{})
+		if !ok {
+			return field.ErrorList{field.InternalError(fld, fmt.Errorf("%T is not a list", oldValue))}
+		}
+		newItems, ok := newValue.([]interface{})
+		if !ok && newValue != nil {
+			return field.ErrorList{field.InternalError(fld, fmt.Errorf("%T is not a list", newValue))}
+		}
+		for i, item := range oldItems {
+			if i >= len(newItems) {
+				return field.ErrorList{field.Forbidden(fld.Index(i), "items cannot be removed, field is append-only")}
+			}
+			if !equality.Semantic.DeepEqual(item, newItems[i]) {
+				return field.ErrorList{field.Forbidden(fld.Index(i), "items cannot be changed, field is append-only")}
+			}
+		}
+		return nil
+	})
+}
+
+// DecreasingForbidden forbids decreasing the number or quantity of the field, e.g. replicas or
+// a storage request. Setting or removing the field is not validated. It panics when path is invalid
+func DecreasingForbidden(path string) Rule {
+	return newRule(path, func(fld *field.Path, oldValue, newValue interface{}, oldFound, newFound bool) field.ErrorList {
+		if !oldFound || !newFound {
+			return nil
+		}
+		cmp, err := compare(oldValue, newValue)
+		if err != nil {
+			return field.ErrorList{field.InternalError(fld, err)}
+		}
+		if cmp > 0 {
+			return field.ErrorList{field.Invalid(fld, newValue, fmt.Sprintf("must not be decreased from %v", oldValue))}
+		}
+		return nil
+	})
+}
+
+// compare returns 1 when oldValue is greater than newValue, -1 when it is lower, 0 otherwise.
+// Values are numbers or quantities
+func compare(oldValue, newValue interface{}) (int, error) {
+	oldQuantity, err := toQuantity(oldValue)
+	if err != nil {
+		return 0, err
+	}
+	newQuantity, err := toQuantity(newValue)
+	if err != nil {
+		return 0, err
+	}
+	return oldQuantity.Cmp(newQuantity), nil
+}
+
+func toQuantity(value interface{}) (resource.Quantity, error) {
+	switch v := value.(type) {
+	case int64:
+		return *resource.NewQuantity(v, resource.DecimalSI), nil
+	case float64:
+		return resource.ParseQuantity(fmt.Sprint(v))
+	case string:
+		return resource.ParseQuantity(v)
+	}
+	return resource.Quantity{}, fmt.Errorf("%T is not a number or quantity", value)
+}
+
+// Validate evaluates the rule against the update from oldContent to newContent
+func (r Rule) Validate(oldContent, newContent map[string]interface{}) field.ErrorList {
+	oldValue, oldFound, _ := unstructured.NestedFieldNoCopy(oldContent, r.fields...)
+	newValue, newFound, _ := unstructured.NestedFieldNoCopy(newContent, r.fields...)
+	return r.check(field.NewPath(r.fields[0], r.fields[1:]...), oldValue, newValue, oldFound, newFound)
+}
+
+// Evaluate evaluates rules against the update from oldObj to newObj and returns all the errors found
+func Evaluate(oldObj, newObj runtime.Object, rules ...Rule) field.ErrorList {
+	oldContent, err := toUnstructured(oldObj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(nil, err)}
+	}
+	newContent, err := toUnstructured(newObj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(nil, err)}
+	}
+	errs := field.ErrorList{}
+	for _, rule := range rules {
+		errs = append(errs, rule.Validate(oldContent, newContent)...)
+	}
+	return errs
+}
+
+// Update returns a validation.UpdateFunc evaluating rules
+func Update[T runtime.Object](rules ...Rule) validation.UpdateFunc[T] {
+	return func(oldObj, newObj T) field.ErrorList {
+		return Evaluate(oldObj, newObj, rules...)
+	}
+}
+
+func toUnstructured(obj runtime.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/AlaudaDevops/pkg/apis/validation"
+)
+
+func newPVC(storageClass *string, storage string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{
+		StorageClassName: storageClass,
+		Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(storage),
+		}},
+	}}
+}
+
+func TestImmutable(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rule := Immutable(".spec.storageClassName")
+
+	g.Expect(Evaluate(newPVC(ptr.To("fast"), "1Gi"), newPVC(ptr.To("fast"), "2Gi"), rule)).To(BeEmpty())
+
+	errs := Evaluate(newPVC(ptr.To("fast"), "1Gi"), newPVC(ptr.To("slow"), "1Gi"), rule)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+	g.Expect(errs[0].Error()).To(Equal(`spec.storageClassName: Invalid value: "slow": field is immutable`))
+
+	// setting and removing are changes too
+	g.Expect(Evaluate(newPVC(nil, "1Gi"), newPVC(ptr.To("fast"), "1Gi"), rule)).To(HaveLen(1))
+	g.Expect(Evaluate(newPVC(ptr.To("fast"), "1Gi"), newPVC(nil, "1Gi"), rule)).To(HaveLen(1))
+	g.Expect(Evaluate(newPVC(nil, "1Gi"), newPVC(nil, "1Gi"), rule)).To(BeEmpty())
+}
+
+func TestAppendOnly(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rule := AppendOnly(".spec.targets")
+	newObj := func(targets ...interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+		if targets != nil {
+			_ = unstructured.SetNestedSlice(obj.Object, targets, "spec", "targets")
+		}
+		return obj
+	}
+
+	g.Expect(Evaluate(newObj("a"), newObj("a", "b"), rule)).To(BeEmpty())
+	g.Expect(Evaluate(newObj(), newObj("a"), rule)).To(BeEmpty())
+
+	errs := Evaluate(newObj("a", "b"), newObj("b", "a"), rule)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Error()).To(Equal("spec.targets[0]: Forbidden: items cannot be changed, field is append-only"))
+
+	errs = Evaluate(newObj("a", "b"), newObj("a"), rule)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Error()).To(Equal("spec.targets[1]: Forbidden: items cannot be removed, field is append-only"))
+
+	g.Expect(Evaluate(newObj("a"), newObj(), rule)).To(HaveLen(1))
+}
+
+func TestDecreasingForbidden(t *testing.T) {
+	g := NewGomegaWithT(t)
+	newDeployment := func(replicas *int32) *appsv1.Deployment {
+		return &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: replicas}}
+	}
+	rule := DecreasingForbidden(".spec.replicas")
+
+	g.Expect(Evaluate(newDeployment(ptr.To[int32](2)), newDeployment(ptr.To[int32](3)), rule)).To(BeEmpty())
+	g.Expect(Evaluate(newDeployment(nil), newDeployment(ptr.To[int32](1)), rule)).To(BeEmpty())
+	errs := Evaluate(newDeployment(ptr.To[int32](3)), newDeployment(ptr.To[int32](2)), rule)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Error()).To(Equal("spec.replicas: Invalid value: 2: must not be decreased from 3"))
+
+	// quantities are compared by value
+	rule = DecreasingForbidden(".spec.resources.requests.storage")
+	g.Expect(Evaluate(newPVC(nil, "1Gi"), newPVC(nil, "1024Mi"), rule)).To(BeEmpty())
+	errs = Evaluate(newPVC(nil, "1Gi"), newPVC(nil, "500Mi"), rule)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Error()).To(ContainSubstring("must not be decreased from 1Gi"))
+
+	errs = Evaluate(newPVC(ptr.To("fast"), "1Gi"), newPVC(ptr.To("slow"), "1Gi"), DecreasingForbidden(".spec.storageClassName"))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInternal))
+}
+
+func TestUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rules := validation.Rules[*corev1.PersistentVolumeClaim]{
+		Update: []validation.UpdateFunc[*corev1.PersistentVolumeClaim]{
+			Update[*corev1.PersistentVolumeClaim](Immutable(".spec.storageClassName"), DecreasingForbidden(".spec.resources.requests.storage")),
+		},
+	}
+
+	_, err := rules.ValidateUpdate(context.Background(), newPVC(ptr.To("fast"), "2Gi"), newPVC(ptr.To("slow"), "1Gi"))
+	g.Expect(err).To(MatchError(And(ContainSubstring("spec.storageClassName"), ContainSubstring("spec.resources.requests.storage"))))
+}
+
+func TestRule_invalidPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(func() { Immutable("spec.replicas") }).To(Panic())
+	g.Expect(func() { Immutable(".spec..replicas") }).To(Panic())
+	g.Expect(func() { Immutable(".") }).To(Panic())
+}
@@ -0,0 +1,367 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	kclient "github.com/AlaudaDevops/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PartOfLabel label set on every applied object with the apply set id as value
+	PartOfLabel = "applyset.kubernetes.io/part-of"
+	// IDLabel label set on the parent object with the apply set id as value
+	IDLabel = "applyset.kubernetes.io/id"
+	// ToolingAnnotation annotation on the parent object with the tool managing the apply set
+	ToolingAnnotation = "applyset.kubernetes.io/tooling"
+	// ContainsGroupKindsAnnotation annotation on the parent object listing the group kinds of the applied objects
+	ContainsGroupKindsAnnotation = "applyset.kubernetes.io/contains-group-kinds"
+	// AdditionalNamespacesAnnotation annotation on the parent object listing the namespaces
+	// of the applied objects other than the parent namespace
+	AdditionalNamespacesAnnotation = "applyset.kubernetes.io/additional-namespaces"
+
+	// DefaultFieldManager field manager used when none is given
+	DefaultFieldManager = "applyset"
+	// DefaultTooling tooling recorded on the parent when none is given
+	DefaultTooling = "applyset/v1"
+)
+
+// Options of an ApplySet
+type Options struct {
+	// FieldManager used for server-side apply
+	FieldManager string
+	// Tooling recorded in the parent ToolingAnnotation
+	Tooling string
+	// DryRun applies and prunes using server dry run
+	DryRun bool
+	// Prune deletes the objects of previous runs which are no longer part of the set
+	Prune bool
+	// Force takes ownership of conflicting fields
+	Force bool
+	// Rollback deletes the objects created and restores the objects updated by Apply
+	// when a later object fails to apply, see Transaction
+	Rollback bool
+}
+
+// Option configures Options
+type Option func(*Options)
+
+// WithFieldManager sets the field manager used for server-side apply
+func WithFieldManager(fieldManager string) Option {
+	return func(opts *Options) {
+		opts.FieldManager = fieldManager
+	}
+}
+
+// WithTooling sets the tooling recorded on the parent
+func WithTooling(tooling string) Option {
+	return func(opts *Options) {
+		opts.Tooling = tooling
+	}
+}
+
+// WithDryRun applies and prunes using server dry run
+func WithDryRun() Option {
+	return func(opts *Options) {
+		opts.DryRun = true
+	}
+}
+
+// WithPrune deletes the objects of previous runs which are no longer part of the set
+func WithPrune() Option {
+	return func(opts *Options) {
+		opts.Prune = true
+	}
+}
+
+// WithForce takes ownership of conflicting fields
+func WithForce() Option {
+	return func(opts *Options) {
+		opts.Force = true
+	}
+}
+
+// WithRollback rolls back the objects applied by Apply when a later object fails
+func WithRollback() Option {
+	return func(opts *Options) {
+		opts.Rollback = true
+	}
+}
+
+// ApplySet applies a set of objects tracked by a parent ConfigMap
+type ApplySet struct {
+	client client.Client
+	parent client.ObjectKey
+	Options
+}
+
+// New returns an ApplySet using a parent ConfigMap named name in namespace.
+// The client must be able to map group kinds using its RESTMapper.
+func New(cli client.Client, name, namespace string, opts ...Option) *ApplySet {
+	set := &ApplySet{
+		client: cli,
+		parent: client.ObjectKey{Name: name, Namespace: namespace},
+		Options: Options{
+			FieldManager: DefaultFieldManager,
+			Tooling:      DefaultTooling,
+		},
+	}
+	for _, opt := range opts {
+		opt(&set.Options)
+	}
+	return set
+}
+
+// ID returns the apply set id as defined by the kubectl ApplySet design
+func (s *ApplySet) ID() string {
+	return ID(s.parent.Name, s.parent.Namespace)
+}
+
+// ID returns the id of an apply set using the ConfigMap name in namespace as parent
+func ID(name, namespace string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s.%s.ConfigMap.", name, namespace)))
+	return fmt.Sprintf("applyset-%s-v1", base64.RawURLEncoding.EncodeToString(sum[:]))
+}
+
+// Apply applies objs with server-side apply and, when pruning is enabled,
+// deletes the objects of previous runs which are no longer part of objs.
+// objs are updated with the objects returned by the server.
+func (s *ApplySet) Apply(ctx context.Context, objs []*unstructured.Unstructured) (*Result, error) {
+	parent, err := s.getParent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	previousKinds, previousNamespaces := parentGroupKinds(parent), parentNamespaces(parent)
+
+	kinds, namespaces := sets.New[schema.GroupKind](), sets.New[string]()
+	for _, obj := range objs {
+		if err = s.prepare(obj); err != nil {
+			return nil, err
+		}
+		kinds.Insert(obj.GroupVersionKind().GroupKind())
+		if ns := obj.GetNamespace(); ns != "" {
+			namespaces.Insert(ns)
+		}
+	}
+
+	// record the union before applying so that an interrupted run can still prune later
+	if err = s.updateParent(ctx, parent, kinds.Union(previousKinds), namespaces.Union(previousNamespaces)); err != nil {
+		return nil, err
+	}
+
+	result := &Result{DryRun: s.DryRun}
+	applied := sets.New[string]()
+	tx := s.Begin()
+	for _, obj := range objs {
+		change, err := tx.Apply(ctx, obj)
+		if err != nil {
+			if !s.Rollback {
+				return result, err
+			}
+			rolledBack, rollbackErr := tx.Rollback(ctx)
+			result.Changes = append(result.Changes, rolledBack...)
+			if rollbackErr != nil {
+				return result, fmt.Errorf("%w, rollback failed: %w", err, rollbackErr)
+			}
+			return result, err
+		}
+		result.Changes = append(result.Changes, change)
+		applied.Insert(objectID(obj.GroupVersionKind().GroupKind(), client.ObjectKeyFromObject(obj)))
+	}
+
+	if !s.Prune {
+		return result, nil
+	}
+	pruned, err := s.prune(ctx, kinds.Union(previousKinds), namespaces.Union(previousNamespaces), applied)
+	result.Changes = append(result.Changes, pruned...)
+	if err != nil {
+		return result, err
+	}
+	return result, s.updateParent(ctx, parent, kinds, namespaces)
+}
+
+// prepare defaults the namespace of obj and labels it with the apply set id
+func (s *ApplySet) prepare(obj *unstructured.Unstructured) error {
+	if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+		return fmt.Errorf("object %q has no apiVersion or kind", obj.GetName())
+	}
+	namespaced, err := s.client.IsObjectNamespaced(obj)
+	if err != nil {
+		return fmt.Errorf("resolve scope of %s %q failed: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	switch {
+	case namespaced && obj.GetNamespace() == "":
+		obj.SetNamespace(s.parent.Namespace)
+	case !namespaced:
+		obj.SetNamespace("")
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[PartOfLabel] = s.ID()
+	obj.SetLabels(labels)
+	return nil
+}
+
+// apply applies obj returning the change and the object before the change, nil when it was created
+func (s *ApplySet) apply(ctx context.Context, obj *unstructured.Unstructured) (change Change, live *unstructured.Unstructured, err error) {
+	gvk := obj.GroupVersionKind()
+	change = Change{GroupVersionKind: gvk, Key: client.ObjectKeyFromObject(obj)}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	err = s.client.Get(ctx, change.Key, current)
+	switch {
+	case err == nil:
+		live = current
+	case !apierrors.IsNotFound(err):
+		return change, nil, err
+	}
+
+	cli := s.client
+	if s.DryRun {
+		cli = client.NewDryRunClient(cli)
+	}
+	var applyOpts []kclient.ApplyOption
+	if s.Force {
+		applyOpts = append(applyOpts, kclient.ForceApply())
+	}
+	if err = kclient.Apply(ctx, cli, obj, s.FieldManager, applyOpts...); err != nil {
+		return change, nil, fmt.Errorf("apply %s %s failed: %w", gvk.Kind, change.Key, err)
+	}
+	// the dry run client may not return the object kind
+	obj.SetGroupVersionKind(gvk)
+
+	name := strings.ToLower(gvk.Kind) + "/" + change.Key.String()
+	if change.Diff, err = Diff(name, live, obj); err != nil {
+		return change, nil, err
+	}
+	switch {
+	case live == nil:
+		change.Action = CreatedAction
+	case change.Diff != "":
+		change.Action = ConfiguredAction
+	default:
+		change.Action = UnchangedAction
+	}
+	return change, live, nil
+}
+
+func (s *ApplySet) getParent(ctx context.Context) (*corev1.ConfigMap, error) {
+	parent := &corev1.ConfigMap{}
+	err := s.client.Get(ctx, s.parent, parent)
+	if apierrors.IsNotFound(err) {
+		parent.Name, parent.Namespace = s.parent.Name, s.parent.Namespace
+		return parent, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get apply set parent %s failed: %w", s.parent, err)
+	}
+	if id := parent.Labels[IDLabel]; id != s.ID() {
+		return nil, fmt.Errorf("configmap %s is not the parent of apply set %s: %s label is %q", s.parent, s.ID(), IDLabel, id)
+	}
+	return parent, nil
+}
+
+// updateParent records kinds and namespaces in the parent, creating it if needed.
+// It does nothing on dry run.
+func (s *ApplySet) updateParent(ctx context.Context, parent *corev1.ConfigMap, kinds sets.Set[schema.GroupKind], namespaces sets.Set[string]) error {
+	if s.DryRun {
+		return nil
+	}
+	if parent.Labels == nil {
+		parent.Labels = map[string]string{}
+	}
+	if parent.Annotations == nil {
+		parent.Annotations = map[string]string{}
+	}
+	parent.Labels[IDLabel] = s.ID()
+	parent.Annotations[ToolingAnnotation] = s.Tooling
+	parent.Annotations[ContainsGroupKindsAnnotation] = formatGroupKinds(kinds)
+	additional := namespaces.Clone().Delete(s.parent.Namespace)
+	parent.Annotations[AdditionalNamespacesAnnotation] = strings.Join(sets.List(additional), ",")
+
+	var err error
+	if parent.ResourceVersion == "" {
+		err = s.client.Create(ctx, parent, client.FieldOwner(s.FieldManager))
+	} else {
+		err = s.client.Update(ctx, parent, client.FieldOwner(s.FieldManager))
+	}
+	if err != nil {
+		return fmt.Errorf("update apply set parent %s failed: %w", s.parent, err)
+	}
+	return nil
+}
+
+func parentGroupKinds(parent *corev1.ConfigMap) sets.Set[schema.GroupKind] {
+	kinds := sets.New[schema.GroupKind]()
+	for _, value := range splitList(parent.Annotations[ContainsGroupKindsAnnotation]) {
+		kinds.Insert(schema.ParseGroupKind(value))
+	}
+	return kinds
+}
+
+func parentNamespaces(parent *corev1.ConfigMap) sets.Set[string] {
+	namespaces := sets.New(splitList(parent.Annotations[AdditionalNamespacesAnnotation])...)
+	if parent.Namespace != "" {
+		namespaces.Insert(parent.Namespace)
+	}
+	return namespaces
+}
+
+func formatGroupKinds(kinds sets.Set[schema.GroupKind]) string {
+	values := make([]string, 0, kinds.Len())
+	for _, kind := range sortedGroupKinds(kinds) {
+		values = append(values, kind.String())
+	}
+	return strings.Join(values, ",")
+}
+
+func sortedGroupKinds(kinds sets.Set[schema.GroupKind]) []schema.GroupKind {
+	list := kinds.UnsortedList()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+	return list
+}
+
+func splitList(value string) (values []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return
+}
+
+func objectID(kind schema.GroupKind, key client.ObjectKey) string {
+	return kind.String() + "/" + key.String()
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ktesting "github.com/AlaudaDevops/pkg/testing"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newFakeClient(objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}, {Group: "apps", Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(mapper).WithObjects(objs...).
+		WithInterceptorFuncs(ktesting.WithoutServerSideApply(interceptor.Funcs{})).Build()
+}
+
+func newConfigMap(name, namespace string, data map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name},
+		"data":       data,
+	}}
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+func TestID(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// base64url encoded sha256 of the parent name, namespace and kind
+	g.Expect(ID("my-app", "default")).To(MatchRegexp(`^applyset-[A-Za-z0-9_-]{43}-v1$`))
+	g.Expect(ID("my-app", "default")).To(Equal(New(nil, "my-app", "default").ID()))
+	g.Expect(ID("my-app", "default")).NotTo(Equal(ID("my-app", "other")))
+}
+
+func TestApplySet_Apply(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := newFakeClient()
+	set := New(cli, "my-app", "default", WithPrune())
+
+	result, err := set.Apply(ctx, []*unstructured.Unstructured{
+		newConfigMap("a", "", map[string]interface{}{"key": "1"}),
+		newConfigMap("b", "other", nil),
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Changes).To(HaveLen(2))
+	g.Expect(result.Changes[0].Action).To(Equal(CreatedAction))
+	g.Expect(result.Changes[0].Key).To(Equal(client.ObjectKey{Namespace: "default", Name: "a"}))
+	g.Expect(result.Changes[0].Diff).To(ContainSubstring("+  key: \"1\""))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, cm)).To(Succeed())
+	g.Expect(cm.Labels).To(HaveKeyWithValue(PartOfLabel, set.ID()))
+
+	parent := &corev1.ConfigMap{}
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "my-app"}, parent)).To(Succeed())
+	g.Expect(parent.Labels).To(HaveKeyWithValue(IDLabel, set.ID()))
+	g.Expect(parent.Annotations).To(HaveKeyWithValue(ToolingAnnotation, DefaultTooling))
+	g.Expect(parent.Annotations).To(HaveKeyWithValue(ContainsGroupKindsAnnotation, "ConfigMap"))
+	g.Expect(parent.Annotations).To(HaveKeyWithValue(AdditionalNamespacesAnnotation, "other"))
+
+	// second run changes a, keeps nothing else and prunes b
+	result, err = set.Apply(ctx, []*unstructured.Unstructured{
+		newConfigMap("a", "", map[string]interface{}{"key": "2"}),
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Changes).To(HaveLen(2))
+	g.Expect(result.Changes[0].Action).To(Equal(ConfiguredAction))
+	g.Expect(result.Changes[1].Action).To(Equal(PrunedAction))
+	g.Expect(result.Changes[1].Key).To(Equal(client.ObjectKey{Namespace: "other", Name: "b"}))
+
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "other", Name: "b"}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "my-app"}, parent)).To(Succeed())
+	g.Expect(parent.Annotations).To(HaveKeyWithValue(AdditionalNamespacesAnnotation, ""))
+
+	// third run has no change
+	result, err = set.Apply(ctx, []*unstructured.Unstructured{
+		newConfigMap("a", "", map[string]interface{}{"key": "2"}),
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Changes).To(HaveLen(1))
+	g.Expect(result.Changes[0].Action).To(Equal(UnchangedAction))
+	g.Expect(result.Changed()).To(BeEmpty())
+}
+
+func TestApplySet_Apply_keepsUnlabeledObjects(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	other := newConfigMap("unmanaged", "default", nil)
+	cli := newFakeClient(other)
+	set := New(cli, "my-app", "default", WithPrune())
+
+	_, err := set.Apply(ctx, []*unstructured.Unstructured{newConfigMap("a", "", nil)})
+	g.Expect(err).To(BeNil())
+	_, err = set.Apply(ctx, nil)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "unmanaged"}, &corev1.ConfigMap{})).To(Succeed())
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestApplySet_Apply_dryRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := newFakeClient()
+
+	result, err := New(cli, "my-app", "default", WithDryRun(), WithPrune()).Apply(ctx, []*unstructured.Unstructured{
+		newConfigMap("a", "", map[string]interface{}{"key": "1"}),
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.DryRun).To(BeTrue())
+	g.Expect(result.Changes).To(HaveLen(1))
+	g.Expect(result.Changes[0].Action).To(Equal(CreatedAction))
+
+	buf := &bytes.Buffer{}
+	g.Expect(result.Print(buf)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("configmap/a created (dry run)\n"))
+
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "my-app"}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestApplySet_Apply_foreignParent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cli := newFakeClient(newConfigMap("my-app", "default", nil))
+
+	_, err := New(cli, "my-app", "default").Apply(context.Background(), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("is not the parent of apply set")))
+}
+
+func TestApplySet_Apply_clusterScoped(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := newFakeClient()
+
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName("other")
+	ns.SetNamespace("ignored")
+
+	result, err := New(cli, "my-app", "default").Apply(ctx, []*unstructured.Unstructured{ns})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Changes[0].Key).To(Equal(client.ObjectKey{Name: "other"}))
+	g.Expect(result.Changes[0].String()).To(Equal("namespace/other created"))
+}
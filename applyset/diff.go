@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/AlaudaDevops/pkg/normalize"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// NormalizedFields fields removed by Normalize as they are set by the server
+// and do not describe the desired state of objects
+var NormalizedFields = normalize.ServerFields
+
+// Normalize returns the content of a copy of obj without NormalizedFields,
+// empty annotations and labels and the defaults set by the server, see normalize.Normalize
+func Normalize(obj client.Object) (map[string]interface{}, error) {
+	content, err := normalize.Normalize(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range NormalizedFields {
+		unstructured.RemoveNestedField(content, field...)
+	}
+	return content, nil
+}
+
+// Diff returns a unified diff between the normalized yaml of live and desired,
+// or an empty string if they are equivalent. A nil live or desired object
+// is shown as an object being created or deleted.
+// name is used in the diff header, usually the kind and key of the object.
+func Diff(name string, live, desired client.Object) (string, error) {
+	before, err := normalizedYAML(live)
+	if err != nil {
+		return "", err
+	}
+	after, err := normalizedYAML(desired)
+	if err != nil {
+		return "", err
+	}
+	if before == after {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: "live/" + name,
+		ToFile:   "merged/" + name,
+		Context:  3,
+	})
+}
+
+func normalizedYAML(obj client.Object) (string, error) {
+	if obj == nil || isNil(obj) {
+		return "", nil
+	}
+	content, err := Normalize(obj)
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(content)
+	return string(data), err
+}
+
+// splitLines splits content keeping line endings, returning no line for empty content
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// isNil returns true for typed nil pointers like (*unstructured.Unstructured)(nil)
+func isNil(obj client.Object) bool {
+	value := reflect.ValueOf(obj)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
+
+// ColorizeDiff colors removed lines in red and added lines in green
+func ColorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			lines[i] = colorize(line, "1")
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorize(line, "31")
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorize(line, "32")
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = colorize(line, "36")
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func colorize(line, code string) string {
+	content := strings.TrimSuffix(line, "\n")
+	return "\033[" + code + "m" + content + "\033[0m" + line[len(content):]
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	live := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cm", Namespace: "default", ResourceVersion: "10", UID: "uid",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "test"}},
+		},
+		Data: map[string]string{"a": "1"},
+	}
+	desired := live.DeepCopy()
+	desired.ResourceVersion = "11"
+
+	diff, err := Diff("cm", live, desired)
+	g.Expect(err).To(BeNil())
+	g.Expect(diff).To(BeEmpty())
+
+	desired.Data["a"] = "2"
+	diff, err = Diff("cm", live, desired)
+	g.Expect(err).To(BeNil())
+	g.Expect(diff).To(ContainSubstring("--- live/cm"))
+	g.Expect(diff).To(ContainSubstring("+++ merged/cm"))
+	g.Expect(diff).To(ContainSubstring("-  a: \"1\""))
+	g.Expect(diff).To(ContainSubstring("+  a: \"2\""))
+
+	diff, err = Diff("cm", nil, desired)
+	g.Expect(err).To(BeNil())
+	g.Expect(diff).To(ContainSubstring("+kind: ConfigMap"))
+	g.Expect(diff).NotTo(ContainSubstring("resourceVersion"))
+}
+
+func TestColorizeDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	colored := ColorizeDiff("--- live/cm\n+++ merged/cm\n@@ -1 +1 @@\n-a\n+b\n c\n")
+	g.Expect(colored).To(ContainSubstring("\x1b[31m-a\x1b[0m"))
+	g.Expect(colored).To(ContainSubstring("\x1b[32m+b\x1b[0m"))
+	g.Expect(colored).To(ContainSubstring("\n c\n"))
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package applyset applies a set of manifests to a cluster using server-side apply
+// and prunes the objects applied by previous runs that are no longer part of the set,
+// following the kubectl ApplySet design: applied objects are labeled with the apply set id
+// and a parent ConfigMap records the group kinds and namespaces they belong to.
+// Dry runs and diffs of every change are supported, it is the backbone of apply subcommands.
+// With WithRollback, or using a Transaction, the objects created are deleted and the objects
+// updated are restored when a later object fails, so a failed install does not stay half applied.
+//
+//	objs, err := applyset.LoadManifests("deploy/")
+//	set := applyset.New(cli, "my-app", "default", applyset.WithFieldManager("my-cli"), applyset.WithPrune())
+//	result, err := set.Apply(ctx, objs)
+//	result.Print(os.Stdout)
+package applyset
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestExtensions file extensions considered manifests when loading directories
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// LoadManifests loads the objects of yaml or json files, supporting multiple documents per file.
+// Directories are walked recursively loading files with yaml, yml or json extensions
+// in lexical order, and - reads from stdin.
+func LoadManifests(paths ...string) (objs []*unstructured.Unstructured, err error) {
+	for _, path := range paths {
+		var loaded []*unstructured.Unstructured
+		if path == "-" {
+			loaded, err = ReadManifests(os.Stdin)
+		} else {
+			loaded, err = loadPath(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, loaded...)
+	}
+	return objs, nil
+}
+
+func loadPath(path string) (objs []*unstructured.Unstructured, err error) {
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		// explicitly given files are loaded whatever their extension
+		if d.IsDir() || (file != path && !manifestExtensions[strings.ToLower(filepath.Ext(file))]) {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		loaded, err := ReadManifests(f)
+		if err != nil {
+			return fmt.Errorf("load manifest file %s failed: %w", file, err)
+		}
+		objs = append(objs, loaded...)
+		return nil
+	})
+	return
+}
+
+// ReadManifests reads the objects of yaml or json documents separated by --- from r.
+// Empty documents are skipped and List kinds are expanded into their items.
+func ReadManifests(r io.Reader) (objs []*unstructured.Unstructured, err error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		data, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if string(data) == "null" || string(data) == "{}" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		// unmarshal json so numbers are decoded as int64 instead of float64
+		if err = obj.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if obj.IsList() {
+			err = obj.EachListItem(func(item runtime.Object) error {
+				objs = append(objs, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+			continue
+		}
+		objs = append(objs, obj)
+	}
+}
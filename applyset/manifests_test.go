@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLoadManifests(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objs, err := LoadManifests("testdata/manifests")
+	g.Expect(err).To(BeNil())
+
+	names := []string{}
+	for _, obj := range objs {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	g.Expect(names).To(Equal([]string{"ConfigMap/settings", "ConfigMap/listed", "Namespace/other", "Deployment/app"}))
+	g.Expect(objs[1].GetNamespace()).To(Equal("other"))
+
+	replicas := objs[3].Object["spec"].(map[string]interface{})["replicas"]
+	g.Expect(replicas).To(Equal(int64(2)))
+}
+
+func TestLoadManifests_file(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objs, err := LoadManifests("testdata/manifests/namespace.json", "testdata/manifests/nested/deployment.yml")
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(2))
+
+	_, err = LoadManifests("testdata/manifests/missing.yaml")
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestReadManifests(t *testing.T) {
+	var data = []struct {
+		desc    string
+		content string
+		count   int
+		err     string
+	}{
+		{
+			desc:    "multiple documents",
+			content: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n",
+			count:   2,
+		},
+		{
+			desc:    "empty",
+			content: "",
+		},
+		{
+			desc:    "missing kind",
+			content: "apiVersion: v1\nmetadata:\n  name: a\n",
+			err:     "document 0",
+		},
+		{
+			desc:    "invalid yaml",
+			content: "apiVersion: v1\nkind: [\n",
+			err:     "document 0",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			objs, err := ReadManifests(strings.NewReader(item.content))
+			if item.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(item.err)))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(objs).To(HaveLen(item.count))
+		})
+	}
+}
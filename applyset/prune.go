@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// prune deletes the objects labeled with the apply set id in kinds and namespaces
+// which are not in applied. Group kinds no longer served by the cluster are skipped.
+func (s *ApplySet) prune(ctx context.Context, kinds sets.Set[schema.GroupKind], namespaces sets.Set[string], applied sets.Set[string]) (changes []Change, err error) {
+	var errs []error
+	for _, kind := range sortedGroupKinds(kinds) {
+		mapping, err := s.client.RESTMapper().RESTMapping(kind)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		listNamespaces := []string{""}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			listNamespaces = sets.List(namespaces)
+		}
+		for _, namespace := range listNamespaces {
+			pruned, err := s.pruneKind(ctx, mapping.GroupVersionKind, namespace, applied)
+			changes = append(changes, pruned...)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return changes, utilerrors.NewAggregate(errs)
+}
+
+func (s *ApplySet) pruneKind(ctx context.Context, gvk schema.GroupVersionKind, namespace string, applied sets.Set[string]) (changes []Change, err error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	listOpts := []client.ListOption{client.MatchingLabels{PartOfLabel: s.ID()}}
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	if err = s.client.List(ctx, list, listOpts...); err != nil {
+		return nil, fmt.Errorf("list %s to prune failed: %w", gvk.Kind, err)
+	}
+
+	deleteOpts := []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationBackground)}
+	if s.DryRun {
+		deleteOpts = append(deleteOpts, client.DryRunAll)
+	}
+	var errs []error
+	for i := range list.Items {
+		item := &list.Items[i]
+		key := client.ObjectKeyFromObject(item)
+		if applied.Has(objectID(gvk.GroupKind(), key)) {
+			continue
+		}
+		item.SetGroupVersionKind(gvk)
+		if err = s.client.Delete(ctx, item, deleteOpts...); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("prune %s %s failed: %w", gvk.Kind, key, err))
+			continue
+		}
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		changes = append(changes, Change{GroupVersionKind: gvk, Key: key, Action: PrunedAction})
+	}
+	return changes, utilerrors.NewAggregate(errs)
+}
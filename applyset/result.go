@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Action done on an object
+type Action string
+
+const (
+	// CreatedAction the object did not exist and was created
+	CreatedAction Action = "created"
+	// ConfiguredAction the object existed and was changed
+	ConfiguredAction Action = "configured"
+	// UnchangedAction the object existed and was not changed
+	UnchangedAction Action = "unchanged"
+	// PrunedAction the object was deleted as it is no longer part of the apply set
+	PrunedAction Action = "pruned"
+	// RolledBackAction the object created was deleted or the object configured was restored
+	// because another object failed to apply
+	RolledBackAction Action = "rolled back"
+)
+
+// Change is the result of applying or pruning an object
+type Change struct {
+	// GroupVersionKind of the object
+	GroupVersionKind schema.GroupVersionKind
+	// Key of the object
+	Key client.ObjectKey
+	// Action done on the object
+	Action Action
+	// Diff is the unified diff between the live object and the applied object
+	Diff string
+}
+
+// String returns the change like kubectl, e.g. deployment.apps/name created
+func (c Change) String() string {
+	kind := strings.ToLower(c.GroupVersionKind.Kind)
+	if c.GroupVersionKind.Group != "" {
+		kind += "." + c.GroupVersionKind.Group
+	}
+	return fmt.Sprintf("%s/%s %s", kind, c.Key.Name, c.Action)
+}
+
+// Result of applying an apply set
+type Result struct {
+	// DryRun is true when no change was persisted
+	DryRun bool
+	// Changes done on applied and pruned objects in order
+	Changes []Change
+}
+
+// Changed returns the changes other than UnchangedAction
+func (r *Result) Changed() (changes []Change) {
+	for _, change := range r.Changes {
+		if change.Action != UnchangedAction {
+			changes = append(changes, change)
+		}
+	}
+	return
+}
+
+// Print writes one line per change into w
+func (r *Result) Print(w io.Writer) error {
+	suffix := ""
+	if r.DryRun {
+		suffix = " (dry run)"
+	}
+	for _, change := range r.Changes {
+		if _, err := fmt.Fprintf(w, "%s%s\n", change, suffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintDiff writes the diff of every change into w, colorized when color is true
+func (r *Result) PrintDiff(w io.Writer, color bool) error {
+	for _, change := range r.Changes {
+		diff := change.Diff
+		if diff == "" {
+			continue
+		}
+		if color {
+			diff = ColorizeDiff(diff)
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Transaction applies a group of objects of an apply set recording what was created
+// and updated, so the group can be rolled back when a later object fails to apply
+//
+//	tx := set.Begin()
+//	for _, obj := range objs {
+//		if _, err := tx.Apply(ctx, obj); err != nil {
+//			rolledBack, rollbackErr := tx.Rollback(ctx)
+//			...
+//		}
+//	}
+type Transaction struct {
+	set     *ApplySet
+	applied []transactionObject
+}
+
+// transactionObject is an object applied by a Transaction
+type transactionObject struct {
+	change Change
+	// applied is the object returned by the server
+	applied *unstructured.Unstructured
+	// previous is the object before it was applied, nil when it was created
+	previous *unstructured.Unstructured
+}
+
+// Begin starts a Transaction applying objects with the options of the apply set.
+// The parent of the apply set is not updated, use Apply with WithRollback to do so.
+func (s *ApplySet) Begin() *Transaction {
+	return &Transaction{set: s}
+}
+
+// Apply applies obj with server-side apply, labeling it with the apply set id,
+// and records its previous state. obj is updated with the object returned by the server.
+func (t *Transaction) Apply(ctx context.Context, obj *unstructured.Unstructured) (Change, error) {
+	if err := t.set.prepare(obj); err != nil {
+		return Change{}, err
+	}
+	change, live, err := t.set.apply(ctx, obj)
+	if err != nil {
+		return change, err
+	}
+	if change.Action != UnchangedAction {
+		t.applied = append(t.applied, transactionObject{change: change, applied: obj.DeepCopy(), previous: live})
+	}
+	return change, nil
+}
+
+// Changes returns the changes done by the transaction in order, unchanged objects excluded
+func (t *Transaction) Changes() []Change {
+	changes := make([]Change, 0, len(t.applied))
+	for _, applied := range t.applied {
+		changes = append(changes, applied.change)
+	}
+	return changes
+}
+
+// Rollback undoes the changes of the transaction in reverse order: created objects are deleted
+// and updated objects are restored to their previous version. To be safe a created object is only
+// deleted if it was not recreated and an updated object is only restored if it was not changed by
+// others since it was applied, otherwise an error is returned for it and the rollback goes on.
+// Nothing is done on dry run.
+// The returned changes use RolledBackAction for the objects rolled back.
+func (t *Transaction) Rollback(ctx context.Context) (changes []Change, err error) {
+	if t.set.DryRun {
+		t.applied = nil
+		return nil, nil
+	}
+	var errs []error
+	for i := len(t.applied) - 1; i >= 0; i-- {
+		applied := t.applied[i]
+		if err = t.rollback(ctx, applied); err != nil {
+			errs = append(errs, fmt.Errorf("rollback %s %s failed: %w", applied.change.GroupVersionKind.Kind, applied.change.Key, err))
+			continue
+		}
+		change := applied.change
+		change.Action, change.Diff = RolledBackAction, ""
+		changes = append(changes, change)
+	}
+	t.applied = nil
+	return changes, utilerrors.NewAggregate(errs)
+}
+
+func (t *Transaction) rollback(ctx context.Context, applied transactionObject) error {
+	if applied.previous == nil {
+		// the uid precondition makes sure the object was not recreated since it was applied
+		uid := applied.applied.GetUID()
+		err := t.set.client.Delete(ctx, applied.applied, client.Preconditions{UID: &uid},
+			client.PropagationPolicy(metav1.DeletePropagationBackground))
+		return client.IgnoreNotFound(err)
+	}
+	// the resource version of the applied object makes the update fail with a conflict
+	// when the object was changed since it was applied
+	restored := applied.previous.DeepCopy()
+	restored.SetResourceVersion(applied.applied.GetResourceVersion())
+	err := t.set.client.Update(ctx, restored, client.FieldOwner(t.set.FieldManager))
+	if apierrors.IsConflict(err) {
+		return fmt.Errorf("changed since it was applied, not restored: %w", err)
+	}
+	return err
+}
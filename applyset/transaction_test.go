@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ktesting "github.com/AlaudaDevops/pkg/testing"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newFailingClient returns a fake client failing to write objects named name
+func newFailingClient(name string, objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	failure := errors.New("admission denied")
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(mapper).WithObjects(objs...).
+		WithInterceptorFuncs(ktesting.WithoutServerSideApply(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetName() == name {
+					return failure
+				}
+				return cli.Create(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if obj.GetName() == name {
+					return failure
+				}
+				return cli.Patch(ctx, obj, patch, opts...)
+			},
+		})).Build()
+}
+
+func TestApplySet_Apply_rollback(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := newFailingClient("bad", newConfigMap("a", "default", map[string]interface{}{"key": "1"}))
+
+	result, err := New(cli, "my-app", "default", WithRollback()).Apply(ctx, []*unstructured.Unstructured{
+		newConfigMap("a", "", map[string]interface{}{"key": "2"}),
+		newConfigMap("b", "", nil),
+		newConfigMap("bad", "", nil),
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("apply ConfigMap default/bad failed: admission denied")))
+	g.Expect(result.Changes).To(HaveLen(4))
+	g.Expect(result.Changes[0].Action).To(Equal(ConfiguredAction))
+	g.Expect(result.Changes[1].Action).To(Equal(CreatedAction))
+	g.Expect(result.Changes[2].Action).To(Equal(RolledBackAction))
+	g.Expect(result.Changes[2].Key.Name).To(Equal("b"))
+	g.Expect(result.Changes[3].String()).To(Equal("configmap/a rolled back"))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "1"}))
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, cm)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestApplySet_Apply_withoutRollback(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := newFailingClient("bad")
+
+	result, err := New(cli, "my-app", "default").Apply(ctx, []*unstructured.Unstructured{
+		newConfigMap("b", "", nil),
+		newConfigMap("bad", "", nil),
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(result.Changes).To(HaveLen(1))
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.ConfigMap{})).To(Succeed())
+}
+
+func TestTransaction_Rollback_changedObject(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := newFakeClient(newConfigMap("a", "default", map[string]interface{}{"key": "1"}))
+	tx := New(cli, "my-app", "default").Begin()
+
+	change, err := tx.Apply(ctx, newConfigMap("a", "", map[string]interface{}{"key": "2"}))
+	g.Expect(err).To(BeNil())
+	g.Expect(change.Action).To(Equal(ConfiguredAction))
+	_, err = tx.Apply(ctx, newConfigMap("b", "", nil))
+	g.Expect(err).To(BeNil())
+	g.Expect(tx.Changes()).To(HaveLen(2))
+
+	// a is changed by someone else after it was applied
+	cm := &corev1.ConfigMap{}
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, cm)).To(Succeed())
+	cm.Data["key"] = "3"
+	g.Expect(cli.Update(ctx, cm)).To(Succeed())
+
+	changes, err := tx.Rollback(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("rollback ConfigMap default/a failed: changed since it was applied")))
+	g.Expect(changes).To(HaveLen(1))
+	g.Expect(changes[0].Key.Name).To(Equal("b"))
+
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "3"}))
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, cm)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestTransaction_Rollback_dryRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	tx := New(newFakeClient(), "my-app", "default", WithDryRun()).Begin()
+
+	_, err := tx.Apply(ctx, newConfigMap("a", "", nil))
+	g.Expect(err).To(BeNil())
+	changes, err := tx.Rollback(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(changes).To(BeEmpty())
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	kclient "github.com/AlaudaDevops/pkg/client"
+	"go.uber.org/zap"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithLogger sets the logger used to report records the sink failed to write
+func WithLogger(logger *zap.SugaredLogger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDiff toggles the diff summary of updates, which reads the current object before each update
+func WithDiff(diff bool) ClientOption {
+	return func(c *Client) {
+		c.diff = diff
+	}
+}
+
+// WithClock sets the clock used for record times
+func WithClock(clock clock.PassiveClock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// Client wraps a client.Client writing an audit record to the sink for every
+// create, update, patch, delete and deletecollection request, including status writes.
+// The user is taken from the createdBy, updatedBy or deletedBy annotation of the object,
+// falling back to the impersonated user or the user in the context.
+// Sink errors are logged and never fail the request
+type Client struct {
+	client.Client
+
+	sink   Sink
+	logger *zap.SugaredLogger
+	diff   bool
+	clock  clock.PassiveClock
+}
+
+var _ client.Client = &Client{}
+
+// NewClient wraps clt writing records to sink, update diffs are enabled by default
+func NewClient(clt client.Client, sink Sink, opts ...ClientOption) *Client {
+	c := &Client{
+		Client: clt,
+		sink:   sink,
+		logger: zap.NewNop().Sugar(),
+		diff:   true,
+		clock:  clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Create implements client.Client
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	createOpts := (&client.CreateOptions{}).ApplyOptions(opts)
+	record := c.newRecord(ctx, VerbCreate, obj, err, len(createOpts.DryRun) > 0)
+	if by, _ := metav1alpha1.GetCreatedBy(obj); !by.IsZero() {
+		record.User = userOf(by.User)
+	}
+	record.Diff, _ = DiffSummary(nil, obj)
+	c.write(ctx, record)
+	return err
+}
+
+// Update implements client.Client
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	var diff []string
+	if c.diff {
+		old := obj.DeepCopyObject().(client.Object)
+		if c.Client.Get(ctx, client.ObjectKeyFromObject(obj), old) == nil {
+			diff, _ = DiffSummary(old, obj)
+		}
+	}
+	err := c.Client.Update(ctx, obj, opts...)
+	updateOpts := (&client.UpdateOptions{}).ApplyOptions(opts)
+	record := c.newRecord(ctx, VerbUpdate, obj, err, len(updateOpts.DryRun) > 0)
+	c.setUpdatedBy(&record, obj)
+	record.Diff = diff
+	c.write(ctx, record)
+	return err
+}
+
+// Patch implements client.Client
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	// computed before the patch because obj is replaced by the response
+	var diff []string
+	if data, err := patch.Data(obj); err == nil {
+		diff, _ = PatchSummary(data)
+	}
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	patchOpts := (&client.PatchOptions{}).ApplyOptions(opts)
+	record := c.newRecord(ctx, VerbPatch, obj, err, len(patchOpts.DryRun) > 0)
+	c.setUpdatedBy(&record, obj)
+	record.Diff = diff
+	c.write(ctx, record)
+	return err
+}
+
+// Delete implements client.Client
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	deleteOpts := (&client.DeleteOptions{}).ApplyOptions(opts)
+	record := c.newRecord(ctx, VerbDelete, obj, err, len(deleteOpts.DryRun) > 0)
+	if by, _ := metav1alpha1.GetDeletedBy(obj); !by.IsZero() {
+		record.User = userOf(by.User)
+	}
+	c.write(ctx, record)
+	return err
+}
+
+// DeleteAllOf implements client.Client
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	deleteOpts := (&client.DeleteAllOfOptions{}).ApplyOptions(opts)
+	record := c.newRecord(ctx, VerbDeleteCollection, obj, err, len(deleteOpts.DryRun) > 0)
+	record.Name = ""
+	record.Namespace = deleteOpts.Namespace
+	c.write(ctx, record)
+	return err
+}
+
+// Status implements client.Client
+func (c *Client) Status() client.SubResourceWriter {
+	return &subResourceWriter{SubResourceWriter: c.Client.Status(), client: c, subResource: "status"}
+}
+
+// SubResource implements client.Client
+func (c *Client) SubResource(subResource string) client.SubResourceClient {
+	clt := c.Client.SubResource(subResource)
+	return &subResourceClient{
+		SubResourceClient: clt,
+		writer:            &subResourceWriter{SubResourceWriter: clt, client: c, subResource: subResource},
+	}
+}
+
+// newRecord returns a record of the operation on obj by the user in the context
+func (c *Client) newRecord(ctx context.Context, verb Verb, obj client.Object, err error, dryRun bool) Record {
+	record := Record{
+		Time:      c.clock.Now(),
+		Source:    SourceClient,
+		Verb:      verb,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		DryRun:    dryRun,
+	}
+	if dryRunner, ok := c.Client.(interface{ IsDryRun() bool }); ok && dryRunner.IsDryRun() {
+		record.DryRun = true
+	}
+	if gvk, gvkErr := apiutil.GVKForObject(obj, c.Scheme()); gvkErr == nil {
+		record.SetGVK(gvk)
+	}
+	if u := kclient.ImpersonatedUser(ctx); u != nil && u.GetName() != "" {
+		record.User = u.GetName()
+	} else if u := kclient.User(ctx); u != nil {
+		record.User = u.GetName()
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+func (c *Client) setUpdatedBy(record *Record, obj client.Object) {
+	if by, _ := metav1alpha1.GetUpdatedBy(obj); !by.IsZero() {
+		record.User = userOf(by.User)
+	}
+}
+
+func (c *Client) write(ctx context.Context, record Record) {
+	writeRecord(ctx, c.sink, c.logger, record)
+}
+
+// writeRecord writes the record to the sink logging failures
+func writeRecord(ctx context.Context, sink Sink, logger *zap.SugaredLogger, record Record) {
+	if err := sink.Write(ctx, record); err != nil {
+		logger.Errorw("failed to write audit record", "verb", record.Verb, "kind", record.Kind,
+			"namespace", record.Namespace, "name", record.Name, "err", err)
+	}
+}
+
+type subResourceWriter struct {
+	client.SubResourceWriter
+
+	client      *Client
+	subResource string
+}
+
+// Create implements client.SubResourceWriter
+func (w *subResourceWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	err := w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+	createOpts := (&client.SubResourceCreateOptions{}).ApplyOptions(opts)
+	w.write(ctx, VerbCreate, obj, err, len(createOpts.DryRun) > 0, nil)
+	return err
+}
+
+// Update implements client.SubResourceWriter
+func (w *subResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	err := w.SubResourceWriter.Update(ctx, obj, opts...)
+	updateOpts := (&client.SubResourceUpdateOptions{}).ApplyOptions(opts)
+	w.write(ctx, VerbUpdate, obj, err, len(updateOpts.DryRun) > 0, nil)
+	return err
+}
+
+// Patch implements client.SubResourceWriter
+func (w *subResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	var diff []string
+	if data, err := patch.Data(obj); err == nil {
+		diff, _ = PatchSummary(data)
+	}
+	err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	patchOpts := (&client.SubResourcePatchOptions{}).ApplyOptions(opts)
+	w.write(ctx, VerbPatch, obj, err, len(patchOpts.DryRun) > 0, diff)
+	return err
+}
+
+func (w *subResourceWriter) write(ctx context.Context, verb Verb, obj client.Object, err error, dryRun bool, diff []string) {
+	record := w.client.newRecord(ctx, verb, obj, err, dryRun)
+	record.SubResource = w.subResource
+	record.Diff = diff
+	w.client.write(ctx, record)
+}
+
+type subResourceClient struct {
+	client.SubResourceClient
+
+	writer *subResourceWriter
+}
+
+// Create implements client.SubResourceClient
+func (c *subResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return c.writer.Create(ctx, obj, subResource, opts...)
+}
+
+// Update implements client.SubResourceClient
+func (c *subResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.writer.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.SubResourceClient
+func (c *subResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.writer.Patch(ctx, obj, patch, opts...)
+}
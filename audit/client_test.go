@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kclient "github.com/AlaudaDevops/pkg/client"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type recordingSink struct {
+	records []Record
+	err     error
+}
+
+func (s *recordingSink) Write(_ context.Context, record Record) error {
+	s.records = append(s.records, record)
+	return s.err
+}
+
+func newTestClient(g *WithT, sink Sink, wrapperOpts ...kclient.WrappedClientOption) *Client {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	base := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).
+		WithStatusSubresource(&corev1.Namespace{}).Build()
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return NewClient(kclient.NewWrappedClient(base, wrapperOpts...), sink, WithClock(clocktesting.NewFakePassiveClock(now)))
+}
+
+func TestClient(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sink := &recordingSink{}
+	clt := newTestClient(g, sink)
+	ctx := kclient.WithUser(context.Background(), &user.DefaultInfo{Name: "admin"})
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}, Data: map[string]string{"a": "1"}}
+	g.Expect(clt.Create(ctx, cm)).To(Succeed())
+
+	ctx = kclient.WithImpersonatedUser(ctx, &user.DefaultInfo{Name: "system:serviceaccount:default:builder"})
+	cm.Data["a"] = "2"
+	g.Expect(clt.Update(ctx, cm)).To(Succeed())
+
+	base := cm.DeepCopy()
+	cm.Data["b"] = "3"
+	g.Expect(clt.Patch(ctx, cm, client.MergeFrom(base), client.DryRunAll)).To(Succeed())
+
+	g.Expect(clt.Delete(context.Background(), cm)).To(Succeed())
+	g.Expect(clt.Delete(context.Background(), cm)).NotTo(Succeed())
+	g.Expect(clt.DeleteAllOf(context.Background(), &corev1.ConfigMap{}, client.InNamespace("default"))).To(Succeed())
+
+	g.Expect(sink.records).To(HaveLen(6))
+
+	created := sink.records[0]
+	g.Expect(created.Time).To(Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+	g.Expect(created.Source).To(Equal(SourceClient))
+	g.Expect(created.User).To(Equal("admin"))
+	g.Expect(created.Verb).To(Equal(VerbCreate))
+	g.Expect(created.GVK()).To(Equal(corev1.SchemeGroupVersion.WithKind("ConfigMap")))
+	g.Expect(created.Namespace).To(Equal("default"))
+	g.Expect(created.Name).To(Equal("cm"))
+	g.Expect(created.Diff).To(ContainElement("data.a"))
+
+	updated := sink.records[1]
+	g.Expect(updated.Verb).To(Equal(VerbUpdate))
+	g.Expect(updated.User).To(Equal("system:serviceaccount:default:builder"))
+	g.Expect(updated.Diff).To(ContainElement("data.a"))
+	g.Expect(updated.Diff).NotTo(ContainElement("data.b"))
+
+	patched := sink.records[2]
+	g.Expect(patched.Verb).To(Equal(VerbPatch))
+	g.Expect(patched.DryRun).To(BeTrue())
+	g.Expect(patched.Diff).To(ContainElement("data.b"))
+
+	g.Expect(sink.records[3].Verb).To(Equal(VerbDelete))
+	g.Expect(sink.records[3].Error).To(BeEmpty())
+	g.Expect(sink.records[4].Error).NotTo(BeEmpty())
+
+	deleted := sink.records[5]
+	g.Expect(deleted.Verb).To(Equal(VerbDeleteCollection))
+	g.Expect(deleted.Namespace).To(Equal("default"))
+	g.Expect(deleted.Name).To(BeEmpty())
+}
+
+func TestClient_Status(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sink := &recordingSink{}
+	clt := newTestClient(g, sink)
+	ctx := context.Background()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	g.Expect(clt.Client.Create(ctx, ns)).To(Succeed())
+	ns.Status.Phase = corev1.NamespaceActive
+	g.Expect(clt.Status().Update(ctx, ns)).To(Succeed())
+	dryRun := NewClient(clt.Client.(*kclient.WrappedClient).WithOptions(kclient.WithDryRun(true)), sink)
+	g.Expect(dryRun.Status().Update(ctx, ns)).To(Succeed())
+
+	g.Expect(sink.records).To(HaveLen(2))
+	g.Expect(sink.records[0].Verb).To(Equal(VerbUpdate))
+	g.Expect(sink.records[0].SubResource).To(Equal("status"))
+	g.Expect(sink.records[0].DryRun).To(BeFalse())
+	g.Expect(sink.records[1].DryRun).To(BeTrue())
+}
+
+func TestClient_SinkErrorsDoNotFail(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sink := &recordingSink{err: errors.New("unavailable")}
+	clt := newTestClient(g, sink)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}}
+	g.Expect(clt.Create(context.Background(), cm)).To(Succeed())
+	g.Expect(sink.records).To(HaveLen(1))
+}
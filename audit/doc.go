@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit emits structured records of mutating operations: who did it,
+// the verb, the kind, namespace and name of the object and a summary of the
+// changed fields. Records are produced by a client wrapper, which takes the user
+// from the createdBy, updatedBy and deletedBy annotations set by
+// client.WrappedClient, and by an admission webhook middleware, and are written
+// to pluggable sinks: a logger, a file or an HTTP endpoint.
+//
+//	sink := audit.MultiSink(audit.LogSink(logger), audit.HTTPSink(url, nil))
+//	clt := audit.NewClient(kclient.NewWrappedClient(mgr.GetClient()), sink)
+//
+//	handler = webhook.Chain(handler, audit.Middleware(sink))
+package audit
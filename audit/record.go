@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Verb of an audited operation, using the kubernetes api verbs
+type Verb string
+
+const (
+	// VerbCreate creates an object
+	VerbCreate Verb = "create"
+	// VerbUpdate replaces an object
+	VerbUpdate Verb = "update"
+	// VerbPatch patches an object
+	VerbPatch Verb = "patch"
+	// VerbDelete deletes an object
+	VerbDelete Verb = "delete"
+	// VerbDeleteCollection deletes all objects matching options
+	VerbDeleteCollection Verb = "deletecollection"
+)
+
+const (
+	// SourceClient records produced by the audit client
+	SourceClient = "client"
+	// SourceWebhook records produced by the admission webhook middleware
+	SourceWebhook = "webhook"
+)
+
+// MaxDiffDepth is the maximum depth of the field paths of a diff summary,
+// changes in deeper fields are reported on their ancestor at this depth
+const MaxDiffDepth = 3
+
+// Record is a structured audit record of a mutating operation
+type Record struct {
+	// Time the operation finished
+	Time time.Time `json:"time"`
+	// Source of the record, SourceClient or SourceWebhook
+	Source string `json:"source"`
+	// User performing the operation, service accounts as system:serviceaccount:<namespace>:<name>
+	User string `json:"user,omitempty"`
+	// Verb of the operation
+	Verb Verb `json:"verb"`
+	// Group of the object kind
+	Group string `json:"group,omitempty"`
+	// Version of the object kind
+	Version string `json:"version,omitempty"`
+	// Kind of the object
+	Kind string `json:"kind"`
+	// Namespace of the object
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the object, empty for deletecollection
+	Name string `json:"name,omitempty"`
+	// SubResource written, e.g. status
+	SubResource string `json:"subResource,omitempty"`
+	// DryRun is true if the operation was not persisted
+	DryRun bool `json:"dryRun,omitempty"`
+	// Diff summary as the sorted paths of the changed fields
+	Diff []string `json:"diff,omitempty"`
+	// Error of a failed operation or the reason of a denied admission request
+	Error string `json:"error,omitempty"`
+}
+
+// SetGVK sets the group, version and kind of the record
+func (r *Record) SetGVK(gvk schema.GroupVersionKind) {
+	r.Group, r.Version, r.Kind = gvk.Group, gvk.Version, gvk.Kind
+}
+
+// GVK returns the group, version and kind of the record
+func (r *Record) GVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: r.Group, Version: r.Version, Kind: r.Kind}
+}
+
+// userOf returns the username of a rbac subject
+func userOf(subject *rbacv1.Subject) string {
+	if subject == nil {
+		return ""
+	}
+	if subject.Kind == rbacv1.ServiceAccountKind {
+		return fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name)
+	}
+	return subject.Name
+}
+
+// ignoredPaths are set by the api server on writes and left out of diff summaries
+var ignoredPaths = map[string]bool{
+	"metadata.resourceVersion":   true,
+	"metadata.managedFields":     true,
+	"metadata.generation":        true,
+	"metadata.uid":               true,
+	"metadata.creationTimestamp": true,
+}
+
+// DiffSummary returns the sorted paths of the fields changed between oldObj and newObj,
+// up to MaxDiffDepth. A nil oldObj reports every field of newObj and a nil newObj none
+func DiffSummary(oldObj, newObj runtime.Object) ([]string, error) {
+	oldMap, err := toMap(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newMap, err := toMap(newObj)
+	if err != nil {
+		return nil, err
+	}
+	paths := map[string]bool{}
+	diffPaths(oldMap, newMap, "", 1, paths)
+	return sortedKeys(paths), nil
+}
+
+// DiffSummaryJSON is like DiffSummary for JSON documents
+func DiffSummaryJSON(oldRaw, newRaw []byte) ([]string, error) {
+	oldMap, newMap := map[string]interface{}{}, map[string]interface{}{}
+	if len(oldRaw) > 0 {
+		if err := json.Unmarshal(oldRaw, &oldMap); err != nil {
+			return nil, err
+		}
+	}
+	if len(newRaw) > 0 {
+		if err := json.Unmarshal(newRaw, &newMap); err != nil {
+			return nil, err
+		}
+	}
+	paths := map[string]bool{}
+	diffPaths(oldMap, newMap, "", 1, paths)
+	return sortedKeys(paths), nil
+}
+
+// PatchSummary returns the sorted paths of the fields set by a patch:
+// the operation paths of a JSON patch or the fields of a merge or apply patch, up to MaxDiffDepth
+func PatchSummary(data []byte) ([]string, error) {
+	paths := map[string]bool{}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		var operations []struct {
+			Path string `json:"path"`
+		}
+		if err := json.Unmarshal(data, &operations); err != nil {
+			return nil, err
+		}
+		for _, op := range operations {
+			segments := strings.Split(strings.Trim(op.Path, "/"), "/")
+			if len(segments) > MaxDiffDepth {
+				segments = segments[:MaxDiffDepth]
+			}
+			paths[strings.Join(segments, ".")] = true
+		}
+		return sortedKeys(paths), nil
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fieldPaths(fields, "", 1, paths)
+	return sortedKeys(paths), nil
+}
+
+// fieldPaths adds the paths of all fields of m up to MaxDiffDepth, including null ones
+func fieldPaths(m map[string]interface{}, prefix string, depth int, paths map[string]bool) {
+	for key, value := range m {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if child, ok := value.(map[string]interface{}); ok && depth < MaxDiffDepth && len(child) > 0 {
+			fieldPaths(child, path, depth+1, paths)
+			continue
+		}
+		paths[path] = true
+	}
+}
+
+func toMap(obj runtime.Object) (map[string]interface{}, error) {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return map[string]interface{}{}, nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+func diffPaths(oldMap, newMap map[string]interface{}, prefix string, depth int, paths map[string]bool) {
+	keys := map[string]bool{}
+	for key := range oldMap {
+		keys[key] = true
+	}
+	for key := range newMap {
+		keys[key] = true
+	}
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if ignoredPaths[path] {
+			continue
+		}
+		oldValue, newValue := oldMap[key], newMap[key]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		oldChild, oldIsMap := oldValue.(map[string]interface{})
+		newChild, newIsMap := newValue.(map[string]interface{})
+		if depth < MaxDiffDepth && (oldIsMap || oldValue == nil) && (newIsMap || newValue == nil) {
+			diffPaths(oldChild, newChild, path, depth+1, paths)
+			continue
+		}
+		paths[path] = true
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffSummary(t *testing.T) {
+	oldCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", ResourceVersion: "1", Labels: map[string]string{"a": "b"}},
+		Data:       map[string]string{"keep": "x", "change": "1", "remove": "y"},
+	}
+	newCm := oldCm.DeepCopy()
+	newCm.ResourceVersion = "2"
+	newCm.Labels["c"] = "d"
+	newCm.Data["change"] = "2"
+	delete(newCm.Data, "remove")
+	newCm.BinaryData = map[string][]byte{"bin": []byte("z")}
+
+	var data = []struct {
+		desc   string
+		oldObj *corev1.ConfigMap
+		newObj *corev1.ConfigMap
+		want   []string
+	}{
+		{"changed fields", oldCm, newCm, []string{"binaryData.bin", "data.change", "data.remove", "metadata.labels.c"}},
+		{"no changes", oldCm, oldCm.DeepCopy(), nil},
+		{"created", nil, oldCm, []string{"data.change", "data.keep", "data.remove", "metadata.labels.a", "metadata.name"}},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			diff, err := DiffSummary(item.oldObj, item.newObj)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(diff).To(Equal(item.want))
+		})
+	}
+}
+
+func TestDiffSummaryJSON(t *testing.T) {
+	g := NewGomegaWithT(t)
+	diff, err := DiffSummaryJSON(
+		[]byte(`{"spec":{"replicas":1,"template":{"spec":{"containers":[{"image":"a"}]}}}}`),
+		[]byte(`{"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"b"}]}}}}`),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal([]string{"spec.replicas", "spec.template.spec"}))
+
+	_, err = DiffSummaryJSON([]byte(`{`), nil)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestPatchSummary(t *testing.T) {
+	var data = []struct {
+		desc    string
+		patch   string
+		want    []string
+		wantErr bool
+	}{
+		{"merge patch", `{"metadata":{"labels":{"a":null}},"data":{"b":"c"}}`, []string{"data.b", "metadata.labels.a"}, false},
+		{"json patch", `[{"op":"replace","path":"/spec/template/spec/containers/0/image","value":"b"},{"op":"remove","path":"/data"}]`,
+			[]string{"data", "spec.template.spec"}, false},
+		{"empty patch", `{}`, nil, false},
+		{"invalid patch", `{`, nil, true},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			diff, err := PatchSummary([]byte(item.patch))
+			if item.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(diff).To(Equal(item.want))
+		})
+	}
+}
+
+func TestUserOf(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(userOf(nil)).To(BeEmpty())
+	g.Expect(userOf(&rbacv1.Subject{Kind: rbacv1.UserKind, Name: "admin"})).To(Equal("admin"))
+	g.Expect(userOf(&rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "default", Name: "builder"})).
+		To(Equal("system:serviceaccount:default:builder"))
+}
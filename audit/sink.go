@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Sink writes audit records
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+// SinkFunc is a function implementing Sink
+type SinkFunc func(ctx context.Context, record Record) error
+
+// Write implements Sink
+func (f SinkFunc) Write(ctx context.Context, record Record) error {
+	return f(ctx, record)
+}
+
+// LogSink returns a sink logging records at info level
+func LogSink(logger *zap.SugaredLogger) Sink {
+	return SinkFunc(func(_ context.Context, record Record) error {
+		logger.Infow("audit",
+			"source", record.Source, "user", record.User, "verb", record.Verb,
+			"gvk", record.GVK().String(), "namespace", record.Namespace, "name", record.Name,
+			"subResource", record.SubResource, "dryRun", record.DryRun, "diff", record.Diff, "error", record.Error)
+		return nil
+	})
+}
+
+// WriterSink returns a sink writing records as JSON lines to w, safe for concurrent use
+func WriterSink(w io.Writer) Sink {
+	lock := sync.Mutex{}
+	return SinkFunc(func(_ context.Context, record Record) error {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		_, err = w.Write(append(data, '\n'))
+		return err
+	})
+}
+
+// FileSink returns a sink appending records as JSON lines to the file at path,
+// created if missing, and a function closing the file
+func FileSink(path string) (Sink, func() error, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open audit file %q: %w", path, err)
+	}
+	return WriterSink(file), file.Close, nil
+}
+
+// HTTPSink returns a sink posting each record as JSON to url using clt,
+// http.DefaultClient is used if clt is nil
+func HTTPSink(url string, clt *http.Client) Sink {
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+	return SinkFunc(func(ctx context.Context, record Record) error {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := clt.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("audit endpoint %s returned status %d", url, resp.StatusCode)
+		}
+		return nil
+	})
+}
+
+// MultiSink returns a sink writing records to all sinks, joining their errors
+func MultiSink(sinks ...Sink) Sink {
+	return SinkFunc(func(ctx context.Context, record Record) error {
+		errs := make([]error, 0, len(sinks))
+		for _, sink := range sinks {
+			errs = append(errs, sink.Write(ctx, record))
+		}
+		return errors.Join(errs...)
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWriterSink(t *testing.T) {
+	g := NewGomegaWithT(t)
+	buf := &bytes.Buffer{}
+	sink := WriterSink(buf)
+	g.Expect(sink.Write(context.Background(), Record{Verb: VerbCreate, Kind: "ConfigMap", Name: "a"})).To(Succeed())
+	g.Expect(sink.Write(context.Background(), Record{Verb: VerbDelete, Kind: "ConfigMap", Name: "b"})).To(Succeed())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	g.Expect(lines).To(HaveLen(2))
+	record := Record{}
+	g.Expect(json.Unmarshal([]byte(lines[1]), &record)).To(Succeed())
+	g.Expect(record.Verb).To(Equal(VerbDelete))
+	g.Expect(record.Name).To(Equal("b"))
+}
+
+func TestFileSink(t *testing.T) {
+	g := NewGomegaWithT(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, closeFile, err := FileSink(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sink.Write(context.Background(), Record{Verb: VerbPatch, Kind: "Secret"})).To(Succeed())
+	g.Expect(closeFile()).To(Succeed())
+
+	content, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring(`"verb":"patch"`))
+
+	_, _, err = FileSink(filepath.Join(t.TempDir(), "missing", "audit.log"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestHTTPSink(t *testing.T) {
+	g := NewGomegaWithT(t)
+	received := []Record{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record := Record{}
+		_ = json.NewDecoder(r.Body).Decode(&record)
+		received = append(received, record)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := HTTPSink(server.URL, server.Client())
+	g.Expect(sink.Write(context.Background(), Record{Verb: VerbUpdate, Kind: "Deployment"})).To(Succeed())
+	g.Expect(received).To(HaveLen(1))
+	g.Expect(received[0].Kind).To(Equal("Deployment"))
+
+	status = http.StatusInternalServerError
+	g.Expect(sink.Write(context.Background(), Record{})).To(MatchError(ContainSubstring("returned status 500")))
+}
+
+func TestLogSinkAndMultiSink(t *testing.T) {
+	g := NewGomegaWithT(t)
+	core, logs := observer.New(zap.InfoLevel)
+	failing := SinkFunc(func(context.Context, Record) error { return errors.New("unavailable") })
+	sink := MultiSink(LogSink(zap.New(core).Sugar()), failing)
+
+	err := sink.Write(context.Background(), Record{User: "admin", Verb: VerbCreate, Version: "v1", Kind: "ConfigMap"})
+	g.Expect(err).To(MatchError("unavailable"))
+	g.Expect(logs.Len()).To(Equal(1))
+	fields := logs.All()[0].ContextMap()
+	g.Expect(fields).To(HaveKeyWithValue("user", "admin"))
+	g.Expect(fields).To(HaveKeyWithValue("gvk", "/v1, Kind=ConfigMap"))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AlaudaDevops/pkg/webhook"
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Middleware returns a webhook.Middleware writing an audit record to the sink for each
+// create, update and delete admission request, with the user of the request and the
+// diff between the old and new objects. Denied requests are recorded with their reason.
+// Sink errors are logged using the logger in the context and never change the admission response
+func Middleware(sink Sink) webhook.Middleware {
+	return func(next admission.Handler) admission.Handler {
+		return admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+			resp := next.Handle(ctx, req)
+			if verb, ok := webhookVerbs[req.Operation]; ok {
+				logger := logging.FromContext(ctx)
+				writeRecord(ctx, sink, logger, admissionRecord(clock.RealClock{}, verb, req, resp, logger))
+			}
+			return resp
+		})
+	}
+}
+
+// webhookVerbs maps the audited admission operations to verbs
+var webhookVerbs = map[admissionv1.Operation]Verb{
+	admissionv1.Create: VerbCreate,
+	admissionv1.Update: VerbUpdate,
+	admissionv1.Delete: VerbDelete,
+}
+
+func admissionRecord(clock clock.PassiveClock, verb Verb, req admission.Request, resp admission.Response, logger *zap.SugaredLogger) Record {
+	record := Record{
+		Time:        clock.Now(),
+		Source:      SourceWebhook,
+		User:        req.UserInfo.Username,
+		Verb:        verb,
+		Namespace:   req.Namespace,
+		Name:        req.Name,
+		SubResource: req.SubResource,
+		DryRun:      req.DryRun != nil && *req.DryRun,
+	}
+	record.SetGVK(schema.GroupVersionKind(req.Kind))
+	if verb != VerbDelete {
+		diff, err := DiffSummaryJSON(req.OldObject.Raw, req.Object.Raw)
+		if err != nil {
+			logger.Debugw("failed to compute audit diff", "uid", req.UID, "err", err)
+		}
+		record.Diff = diff
+	}
+	if !resp.Allowed {
+		reason := "denied"
+		if resp.Result != nil {
+			reason = strings.TrimSpace(strings.Join([]string{string(resp.Result.Reason), resp.Result.Message}, " "))
+		}
+		record.Error = reason
+	}
+	return record
+}
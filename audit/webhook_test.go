@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestMiddleware(t *testing.T) {
+	allowed := admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+		return admission.Allowed("")
+	})
+	denied := admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+		return admission.Denied("replicas must be positive")
+	})
+	newRequest := func(operation admissionv1.Operation) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Namespace: "default",
+			Name:      "app",
+			UserInfo:  authenticationv1.UserInfo{Username: "admin"},
+			DryRun:    ptr.To(true),
+			OldObject: runtime.RawExtension{Raw: []byte(`{"spec":{"replicas":1}}`)},
+			Object:    runtime.RawExtension{Raw: []byte(`{"spec":{"replicas":2}}`)},
+		}}
+	}
+
+	var data = []struct {
+		desc      string
+		handler   admission.Handler
+		operation admissionv1.Operation
+		want      *Record
+	}{
+		{"update", allowed, admissionv1.Update, &Record{
+			Source: SourceWebhook, User: "admin", Verb: VerbUpdate, Group: "apps", Version: "v1", Kind: "Deployment",
+			Namespace: "default", Name: "app", DryRun: true, Diff: []string{"spec.replicas"},
+		}},
+		{"denied", denied, admissionv1.Create, &Record{
+			Source: SourceWebhook, User: "admin", Verb: VerbCreate, Group: "apps", Version: "v1", Kind: "Deployment",
+			Namespace: "default", Name: "app", DryRun: true, Diff: []string{"spec.replicas"},
+			Error: "Forbidden replicas must be positive",
+		}},
+		{"delete", allowed, admissionv1.Delete, &Record{
+			Source: SourceWebhook, User: "admin", Verb: VerbDelete, Group: "apps", Version: "v1", Kind: "Deployment",
+			Namespace: "default", Name: "app", DryRun: true,
+		}},
+		{"connect is not audited", allowed, admissionv1.Connect, nil},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			sink := &recordingSink{}
+			handler := Middleware(sink)(item.handler)
+			resp := handler.Handle(context.Background(), newRequest(item.operation))
+			g.Expect(resp).To(Equal(item.handler.Handle(context.Background(), newRequest(item.operation))))
+			if item.want == nil {
+				g.Expect(sink.records).To(BeEmpty())
+				return
+			}
+			g.Expect(sink.records).To(HaveLen(1))
+			record := sink.records[0]
+			g.Expect(record.Time).NotTo(BeZero())
+			record.Time = item.want.Time
+			g.Expect(record).To(Equal(*item.want))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Option configures the metadata of built objects
+type Option func(*options)
+
+type options struct {
+	labels      map[string]string
+	annotations map[string]string
+	displayName string
+	createdBy   *metav1alpha1.CreatedBy
+	owner       metav1.Object
+	ownerGVK    schema.GroupVersionKind
+}
+
+// WithLabels adds labels to the object, and to the pod template of workloads
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.labels = merge(o.labels, labels)
+	}
+}
+
+// WithAnnotations adds annotations to the object
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *options) {
+		o.annotations = merge(o.annotations, annotations)
+	}
+}
+
+// WithDisplayName sets the display name annotation of the object
+func WithDisplayName(name string) Option {
+	return func(o *options) {
+		o.displayName = name
+	}
+}
+
+// WithCreatedBy sets the created by annotation of the object,
+// overriding the creator inherited from the owner
+func WithCreatedBy(by *metav1alpha1.CreatedBy) Option {
+	return func(o *options) {
+		o.createdBy = by
+	}
+}
+
+// WithOwner sets owner as the controller of the object, the object
+// inherits the created by annotation of its owner
+func WithOwner(owner metav1.Object, gvk schema.GroupVersionKind) Option {
+	return func(o *options) {
+		o.owner = owner
+		o.ownerGVK = gvk
+	}
+}
+
+// Object sets the name, namespace and standard metadata of obj and returns it
+func Object[T client.Object](obj T, name, namespace string, opts ...Option) T {
+	o := newOptions(opts)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	o.apply(obj)
+	return obj
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) apply(obj metav1.Object) {
+	if len(o.labels) > 0 {
+		obj.SetLabels(merge(obj.GetLabels(), o.labels))
+	}
+	if len(o.annotations) > 0 {
+		obj.SetAnnotations(merge(obj.GetAnnotations(), o.annotations))
+	}
+	if o.displayName != "" {
+		metav1alpha1.SetDisplayName(obj, o.displayName)
+	}
+	if o.owner != nil {
+		obj.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(o.owner, o.ownerGVK)})
+		if by, ok := o.owner.GetAnnotations()[metav1alpha1.CreatedByAnnotationKey]; ok && o.createdBy.IsZero() {
+			obj.SetAnnotations(merge(obj.GetAnnotations(), map[string]string{metav1alpha1.CreatedByAnnotationKey: by}))
+		}
+	}
+	if !o.createdBy.IsZero() {
+		metav1alpha1.SetCreatedBy(obj, o.createdBy)
+	}
+}
+
+// merge returns a copy of dst with the values of src
+func merge(dst, src map[string]string) map[string]string {
+	if len(dst) == 0 && len(src) == 0 {
+		return dst
+	}
+	merged := make(map[string]string, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		merged[k] = v
+	}
+	return merged
+}
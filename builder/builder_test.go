@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	kclient "github.com/AlaudaDevops/pkg/client"
+	ktesting "github.com/AlaudaDevops/pkg/testing"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newOwner() *corev1.ConfigMap {
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid"}}
+	metav1alpha1.SetCreatedBy(owner, &metav1alpha1.CreatedBy{User: &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "admin"}})
+	return owner
+}
+
+func TestObject(t *testing.T) {
+	g := NewGomegaWithT(t)
+	owner := newOwner()
+
+	cm := ConfigMap("config", "default", map[string]string{"key": "value"},
+		WithOwner(owner, corev1.SchemeGroupVersion.WithKind("ConfigMap")),
+		WithLabels(map[string]string{"app": "demo"}),
+		WithAnnotations(map[string]string{"note": "generated"}),
+		WithDisplayName("Config"),
+	)
+	g.Expect(cm.Kind).To(Equal("ConfigMap"))
+	g.Expect(cm.Name).To(Equal("config"))
+	g.Expect(cm.Namespace).To(Equal("default"))
+	g.Expect(cm.Labels).To(Equal(map[string]string{"app": "demo"}))
+	g.Expect(cm.Annotations).To(HaveKeyWithValue("note", "generated"))
+	g.Expect(metav1alpha1.GetDisplayName(cm)).To(Equal("Config"))
+	g.Expect(cm.OwnerReferences).To(HaveLen(1))
+	g.Expect(cm.OwnerReferences[0].Controller).To(Equal(ptr.To(true)))
+	g.Expect(cm.OwnerReferences[0].UID).To(BeEquivalentTo("uid"))
+
+	by, err := metav1alpha1.GetCreatedBy(cm)
+	g.Expect(err).To(BeNil())
+	g.Expect(by.User.Name).To(Equal("admin"))
+
+	// explicit creator overrides the owner
+	cm = ConfigMap("config", "default", nil,
+		WithOwner(owner, corev1.SchemeGroupVersion.WithKind("ConfigMap")),
+		WithCreatedBy(&metav1alpha1.CreatedBy{User: &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "dev"}}),
+	)
+	by, _ = metav1alpha1.GetCreatedBy(cm)
+	g.Expect(by.User.Name).To(Equal("dev"))
+
+	// no options leave the metadata empty
+	sa := ServiceAccount("sa", "default")
+	g.Expect(sa.Labels).To(BeNil())
+	g.Expect(sa.Annotations).To(BeNil())
+	g.Expect(sa.OwnerReferences).To(BeNil())
+}
+
+func TestWorkloads(t *testing.T) {
+	g := NewGomegaWithT(t)
+	selector := map[string]string{"app": "demo"}
+
+	deploy := Deployment("demo", "default", selector, corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "server", Image: "demo:latest"}},
+	}, WithLabels(map[string]string{"tier": "backend"}))
+	g.Expect(deploy.APIVersion).To(Equal("apps/v1"))
+	g.Expect(deploy.Spec.Selector.MatchLabels).To(Equal(selector))
+	g.Expect(deploy.Spec.Template.Labels).To(Equal(map[string]string{"app": "demo", "tier": "backend"}))
+	g.Expect(deploy.Labels).To(Equal(map[string]string{"tier": "backend"}))
+	g.Expect(deploy.Spec.Template.Spec.Containers[0].Image).To(Equal("demo:latest"))
+
+	svc := Service("demo", "default", selector, []corev1.ServicePort{ServicePort("http", 8080)})
+	g.Expect(svc.Spec.Selector).To(Equal(selector))
+	g.Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(8080))
+	g.Expect(svc.Spec.Ports[0].Protocol).To(Equal(corev1.ProtocolTCP))
+
+	role := Role("demo", "default", []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}})
+	g.Expect(role.Rules).To(HaveLen(1))
+	binding := RoleBinding("demo", "default", RoleRef(role.Name), []rbacv1.Subject{ServiceAccountSubject("demo", "default")})
+	g.Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "demo"}))
+	g.Expect(binding.Subjects[0].Kind).To(Equal("ServiceAccount"))
+	g.Expect(ClusterRoleRef("view").Kind).To(Equal("ClusterRole"))
+
+	secret := Secret("demo", "default", "", map[string][]byte{"token": []byte("x")})
+	g.Expect(secret.Type).To(Equal(corev1.SecretTypeOpaque))
+}
+
+func TestApply(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := fake.NewClientBuilder().WithInterceptorFuncs(ktesting.WithoutServerSideApply(interceptor.Funcs{})).Build()
+
+	objs := []client.Object{
+		ServiceAccount("demo", "default"),
+		Deployment("demo", "default", map[string]string{"app": "demo"}, corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "server", Image: "demo:latest"}},
+		}),
+	}
+	for _, obj := range objs {
+		g.Expect(kclient.Apply(ctx, clt, obj, "builder-test")).To(Succeed())
+	}
+	deploy := &appsv1.Deployment{}
+	g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "demo"}, deploy)).To(Succeed())
+	g.Expect(deploy.Spec.Template.Labels).To(HaveKeyWithValue("app", "demo"))
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builder constructs the desired state of child objects created by operators,
+// like Deployments, Services, ServiceAccounts, Roles, RoleBindings, ConfigMaps and Secrets,
+// with the standard labels and annotations applied. Objects have their TypeMeta set
+// so they can be applied with server-side apply as they are.
+//
+//	opts := []builder.Option{
+//		builder.WithOwner(app, v1alpha1.GroupVersion.WithKind("App")),
+//		builder.WithLabels(map[string]string{"app.kubernetes.io/part-of": app.Name}),
+//		builder.WithDisplayName("API server"),
+//	}
+//	sa := builder.ServiceAccount(app.Name, app.Namespace, opts...)
+//	deploy := builder.Deployment(app.Name, app.Namespace, selector, corev1.PodSpec{
+//		ServiceAccountName: sa.Name,
+//		Containers:         []corev1.Container{{Name: "server", Image: app.Spec.Image}},
+//	}, opts...)
+//	svc := builder.Service(app.Name, app.Namespace, selector, []corev1.ServicePort{builder.ServicePort("http", 8080)}, opts...)
+//	for _, obj := range []client.Object{sa, deploy, svc} {
+//		err = kclient.Apply(ctx, clt, obj, "app-controller")
+//	}
+package builder
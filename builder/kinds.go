@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Deployment returns a deployment selecting pods with the selector labels and running pod,
+// the pod template is labeled with the selector and the labels of the options
+func Deployment(name, namespace string, selector map[string]string, pod corev1.PodSpec, opts ...Option) *appsv1.Deployment {
+	o := newOptions(opts)
+	deploy := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: merge(o.labels, selector)},
+				Spec:       pod,
+			},
+		},
+	}
+	o.apply(deploy)
+	return deploy
+}
+
+// Service returns a ClusterIP service exposing ports of the pods matching selector
+func Service(name, namespace string, selector map[string]string, ports []corev1.ServicePort, opts ...Option) *corev1.Service {
+	svc := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Service"},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+	return Object(svc, name, namespace, opts...)
+}
+
+// ServicePort returns a TCP service port forwarding port to the same container port
+func ServicePort(name string, port int32) corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       name,
+		Protocol:   corev1.ProtocolTCP,
+		Port:       port,
+		TargetPort: intstr.FromInt32(port),
+	}
+}
+
+// ServiceAccount returns a service account
+func ServiceAccount(name, namespace string, opts ...Option) *corev1.ServiceAccount {
+	sa := &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ServiceAccount"},
+	}
+	return Object(sa, name, namespace, opts...)
+}
+
+// Role returns a role granting rules
+func Role(name, namespace string, rules []rbacv1.PolicyRule, opts ...Option) *rbacv1.Role {
+	role := &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		Rules:    rules,
+	}
+	return Object(role, name, namespace, opts...)
+}
+
+// RoleBinding returns a role binding granting the role referenced by roleRef to subjects
+func RoleBinding(name, namespace string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject, opts ...Option) *rbacv1.RoleBinding {
+	binding := &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		RoleRef:  roleRef,
+		Subjects: subjects,
+	}
+	return Object(binding, name, namespace, opts...)
+}
+
+// RoleRef returns a reference to the role name
+func RoleRef(name string) rbacv1.RoleRef {
+	return rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}
+}
+
+// ClusterRoleRef returns a reference to the cluster role name
+func ClusterRoleRef(name string) rbacv1.RoleRef {
+	return rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name}
+}
+
+// ServiceAccountSubject returns a subject for the service account name in namespace
+func ServiceAccountSubject(name, namespace string) rbacv1.Subject {
+	return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}
+}
+
+// ConfigMap returns a config map with data
+func ConfigMap(name, namespace string, data map[string]string, opts ...Option) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ConfigMap"},
+		Data:     data,
+	}
+	return Object(cm, name, namespace, opts...)
+}
+
+// Secret returns a secret of secretType with data, an empty type is opaque
+func Secret(name, namespace string, secretType corev1.SecretType, data map[string][]byte, opts ...Option) *corev1.Secret {
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Secret"},
+		Type:     secretType,
+		Data:     data,
+	}
+	return Object(secret, name, namespace, opts...)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+// DefaultBufferSize is the default number of changes waiting to be published
+const DefaultBufferSize = 1024
+
+// Broadcaster is a manager runnable publishing the changes of watched kinds as CloudEvents.
+// Changes happening while the buffer is full are dropped and logged.
+// Objects existing when the broadcaster starts are not published
+type Broadcaster struct {
+	informers cache.Informers
+	scheme    *runtime.Scheme
+	publisher Publisher
+	source    string
+	kinds     []client.Object
+	size      int
+	clock     clock.Clock
+	logger    *zap.SugaredLogger
+
+	messages chan *Message
+}
+
+var _ manager.LeaderElectionRunnable = &Broadcaster{}
+
+// BroadcasterOption configures a Broadcaster
+type BroadcasterOption func(*Broadcaster)
+
+// WithKinds adds kinds whose changes are published
+func WithKinds(objs ...client.Object) BroadcasterOption {
+	return func(b *Broadcaster) {
+		b.kinds = append(b.kinds, objs...)
+	}
+}
+
+// WithBufferSize sets the number of changes waiting to be published, defaults to DefaultBufferSize
+func WithBufferSize(size int) BroadcasterOption {
+	return func(b *Broadcaster) {
+		b.size = size
+	}
+}
+
+// WithClock sets the clock of event times
+func WithClock(clock clock.Clock) BroadcasterOption {
+	return func(b *Broadcaster) {
+		b.clock = clock
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(logger *zap.SugaredLogger) BroadcasterOption {
+	return func(b *Broadcaster) {
+		b.logger = logger
+	}
+}
+
+// NewBroadcaster returns a Broadcaster watching kinds with informers, usually the manager cache,
+// and publishing their changes with publisher using source as the CloudEvents source
+func NewBroadcaster(informers cache.Informers, scheme *runtime.Scheme, publisher Publisher, source string, opts ...BroadcasterOption) *Broadcaster {
+	b := &Broadcaster{
+		informers: informers,
+		scheme:    scheme,
+		publisher: publisher,
+		source:    source,
+		size:      DefaultBufferSize,
+		clock:     clock.RealClock{},
+		logger:    zap.NewNop().Sugar(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader publishes changes
+func (b *Broadcaster) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable, publishing changes until ctx is done
+func (b *Broadcaster) Start(ctx context.Context) error {
+	b.messages = make(chan *Message, b.size)
+	for _, obj := range b.kinds {
+		gvk, err := apiutil.GVKForObject(obj, b.scheme)
+		if err != nil {
+			return err
+		}
+		informer, err := b.informers.GetInformer(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("get informer of %s failed: %w", gvk, err)
+		}
+		registration, err := informer.AddEventHandler(b.handler(gvk))
+		if err != nil {
+			return fmt.Errorf("watch %s failed: %w", gvk, err)
+		}
+		defer func() {
+			_ = informer.RemoveEventHandler(registration)
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-b.messages:
+			if err := b.publisher.Publish(ctx, msg); err != nil {
+				b.logger.Errorw("cannot publish resource event", "topic", msg.Topic, "id", msg.ID, "err", err)
+			}
+		}
+	}
+}
+
+func (b *Broadcaster) handler(gvk schema.GroupVersionKind) toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				b.enqueue(gvk, ResourceCreatedType, obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldOK := oldObj.(client.Object)
+			newMeta, newOK := newObj.(client.Object)
+			// resyncs notify updates without changes
+			if oldOK && newOK && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			b.enqueue(gvk, ResourceUpdatedType, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			b.enqueue(gvk, ResourceDeletedType, obj)
+		},
+	}
+}
+
+func (b *Broadcaster) enqueue(gvk schema.GroupVersionKind, eventType string, obj interface{}) {
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+	event, err := NewResourceEvent(b.source, eventType, gvk, clientObj, b.clock.Now())
+	if err != nil {
+		b.logger.Errorw("cannot create resource event", "kind", gvk.Kind, "object", client.ObjectKeyFromObject(clientObj), "err", err)
+		return
+	}
+	msg, err := EncodeEvent(Topic(gvk), event)
+	if err != nil {
+		b.logger.Errorw("cannot encode resource event", "kind", gvk.Kind, "object", client.ObjectKeyFromObject(clientObj), "err", err)
+		return
+	}
+	select {
+	case b.messages <- msg:
+	default:
+		b.logger.Warnw("dropped resource event, buffer is full", "kind", gvk.Kind, "object", client.ObjectKeyFromObject(clientObj), "type", eventType)
+	}
+}
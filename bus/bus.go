@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed is returned when publishing into or subscribing to a closed bus
+var ErrClosed = errors.New("bus is closed")
+
+// Message published on a topic
+type Message struct {
+	// Topic of the message, mapped to a subject by SubjectBus and a record topic by RecordBus
+	Topic string
+	// ID of the message, used for deduplication when supported
+	ID string
+	// Key orders messages with the same key, e.g. the namespace and name of an object
+	Key string
+	// Headers of the message
+	Headers map[string]string
+	// Data is the payload
+	Data []byte
+}
+
+// Handler handles messages received from a subscription,
+// returning an error asks the bus to deliver the message again when supported
+type Handler func(ctx context.Context, msg *Message) error
+
+// Publisher publishes messages
+type Publisher interface {
+	// Publish publishes the messages in order
+	Publish(ctx context.Context, msgs ...*Message) error
+}
+
+// Subscriber subscribes to messages
+type Subscriber interface {
+	// Subscribe calls handler for each message of topic until ctx is done
+	Subscribe(ctx context.Context, topic string, handler Handler) error
+}
+
+// Bus publishes and subscribes messages
+type Bus interface {
+	Publisher
+	Subscriber
+}
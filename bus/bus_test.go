@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var now = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func configMap(resourceVersion string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm", UID: "uid", ResourceVersion: resourceVersion},
+		Data:       map[string]string{"key": "value"},
+	}
+}
+
+// subscribe runs Subscribe in the background collecting the messages received
+func subscribe(ctx context.Context, b Bus, topic string) func() []*Message {
+	var (
+		lock     sync.Mutex
+		received []*Message
+	)
+	go func() {
+		_ = b.Subscribe(ctx, topic, func(ctx context.Context, msg *Message) error {
+			lock.Lock()
+			defer lock.Unlock()
+			received = append(received, msg)
+			return nil
+		})
+	}()
+	return func() []*Message {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]*Message{}, received...)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := NewMemory()
+
+	received := subscribe(ctx, b, "a")
+	g.Eventually(func() int {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+		return len(b.subscribers["a"])
+	}).Should(Equal(1))
+
+	g.Expect(b.Publish(ctx, &Message{Topic: "a", Data: []byte("1")}, &Message{Topic: "b", Data: []byte("2")})).To(Succeed())
+	g.Eventually(received).Should(HaveLen(1))
+	g.Expect(string(received()[0].Data)).To(Equal("1"))
+	g.Expect(b.Published()).To(HaveLen(2))
+
+	b.Close()
+	g.Expect(b.Publish(ctx, &Message{Topic: "a"})).To(MatchError(ErrClosed))
+	g.Expect(b.Subscribe(ctx, "a", nil)).To(MatchError(ErrClosed))
+}
+
+func TestResourceEvent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	g.Expect(Topic(gvk)).To(Equal("resources.core.configmap"))
+
+	event, err := NewResourceEvent("my-operator", ResourceDeletedType, gvk, configMap("10"), now)
+	g.Expect(err).To(BeNil())
+	msg, err := EncodeEvent(Topic(gvk), event)
+	g.Expect(err).To(BeNil())
+	g.Expect(msg.ID).To(Equal("uid-10-deleted"))
+	g.Expect(msg.Key).To(Equal("default/cm"))
+	g.Expect(msg.Headers).To(HaveKeyWithValue(ContentTypeHeader, "application/cloudevents+json"))
+
+	change, err := DecodeResourceEvent(msg)
+	g.Expect(err).To(BeNil())
+	g.Expect(change.Type).To(Equal(ResourceDeletedType))
+	g.Expect(change.Source).To(Equal("my-operator"))
+	g.Expect(change.Time.Equal(now)).To(BeTrue())
+	g.Expect(change.Object.GetKind()).To(Equal("ConfigMap"))
+	g.Expect(change.Object.GetName()).To(Equal("cm"))
+	g.Expect(change.Object.Object["data"]).To(Equal(map[string]interface{}{"key": "value"}))
+
+	_, err = DecodeResourceEvent(&Message{Topic: "x", Data: []byte("{")})
+	g.Expect(err).To(MatchError(ContainSubstring("decode cloudevent of topic x failed")))
+	event.SetType("other")
+	msg, _ = EncodeEvent("x", event)
+	_, err = DecodeResourceEvent(msg)
+	g.Expect(err).To(MatchError("cloudevent uid-10-deleted of type other is not a resource event"))
+}
+
+// notifyingInformers closes registered when an event handler is added
+type notifyingInformers struct {
+	*informertest.FakeInformers
+	registered chan struct{}
+}
+
+func (n *notifyingInformers) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	informer, err := n.FakeInformers.GetInformer(ctx, obj, opts...)
+	return &notifyingInformer{Informer: informer, registered: n.registered}, err
+}
+
+type notifyingInformer struct {
+	cache.Informer
+	registered chan struct{}
+}
+
+func (n *notifyingInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	registration, err := n.Informer.AddEventHandler(handler)
+	close(n.registered)
+	return registration, err
+}
+
+func TestBroadcaster(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informers := &notifyingInformers{
+		FakeInformers: &informertest.FakeInformers{Scheme: clientgoscheme.Scheme},
+		registered:    make(chan struct{}),
+	}
+	b := NewMemory()
+	broadcaster := NewBroadcaster(informers, clientgoscheme.Scheme, b, "my-operator",
+		WithKinds(&corev1.ConfigMap{}), WithClock(clocktesting.NewFakeClock(now)))
+	g.Expect(broadcaster.NeedLeaderElection()).To(BeTrue())
+
+	done := make(chan error)
+	go func() { done <- broadcaster.Start(ctx) }()
+	g.Eventually(informers.registered).Should(BeClosed())
+	informer, err := informers.FakeInformerFor(ctx, &corev1.ConfigMap{})
+	g.Expect(err).To(BeNil())
+
+	informer.Add(configMap("1"))
+	informer.Update(configMap("1"), configMap("1"))
+	informer.Update(configMap("1"), configMap("2"))
+	informer.Delete(configMap("2"))
+
+	g.Eventually(b.Published).Should(HaveLen(3))
+	ids := []string{}
+	for _, msg := range b.Published() {
+		g.Expect(msg.Topic).To(Equal("resources.core.configmap"))
+		ids = append(ids, msg.ID)
+	}
+	g.Expect(ids).To(Equal([]string{"uid-1", "uid-2", "uid-2-deleted"}))
+
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
+
+type loopback struct {
+	subjectMsgs []*SubjectMsg
+	records     []*Record
+}
+
+func (l *loopback) Publish(_ context.Context, msg *SubjectMsg) error {
+	l.subjectMsgs = append(l.subjectMsgs, msg)
+	return nil
+}
+
+func (l *loopback) Produce(_ context.Context, records ...*Record) error {
+	l.records = append(l.records, records...)
+	return nil
+}
+
+type loopbackSubject struct{ *loopback }
+
+func (l loopbackSubject) Consume(ctx context.Context, subject string, handler func(ctx context.Context, msg *SubjectMsg) error) error {
+	for _, msg := range l.subjectMsgs {
+		if msg.Subject == subject {
+			if err := handler(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type loopbackRecord struct{ *loopback }
+
+func (l loopbackRecord) Consume(ctx context.Context, topic string, handler func(ctx context.Context, record *Record) error) error {
+	for _, record := range l.records {
+		if record.Topic == topic {
+			if err := handler(ctx, record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestTransports(t *testing.T) {
+	msg := &Message{Topic: "resources.core.configmap", ID: "id", Key: "default/cm", Headers: map[string]string{"b": "2", "a": "1"}, Data: []byte("data")}
+
+	t.Run("subject", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		client := loopbackSubject{&loopback{}}
+		b := NewSubjectBus(client, WithSubjectPrefix("events"))
+		g.Expect(b.Publish(context.Background(), msg)).To(Succeed())
+		g.Expect(client.subjectMsgs[0].Subject).To(Equal("events.resources.core.configmap"))
+		g.Expect(client.subjectMsgs[0].Header).To(HaveKeyWithValue(NATSMsgIDHeader, []string{"id"}))
+
+		var received *Message
+		g.Expect(b.Subscribe(context.Background(), msg.Topic, func(_ context.Context, m *Message) error {
+			received = m
+			return nil
+		})).To(Succeed())
+		g.Expect(received).To(Equal(msg))
+	})
+
+	t.Run("record", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		client := loopbackRecord{&loopback{}}
+		b := NewRecordBus(client)
+		g.Expect(b.Publish(context.Background(), msg)).To(Succeed())
+		g.Expect(client.records[0].Key).To(Equal([]byte("default/cm")))
+		g.Expect(client.records[0].Headers).To(Equal([]RecordHeader{
+			{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}, {Key: IDHeader, Value: []byte("id")},
+		}))
+
+		var received *Message
+		g.Expect(b.Subscribe(context.Background(), msg.Topic, func(_ context.Context, m *Message) error {
+			received = m
+			return nil
+		})).To(Succeed())
+		g.Expect(received).To(Equal(msg))
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CloudEvent types of resource changes
+const (
+	// ResourceCreatedType type of events of created resources
+	ResourceCreatedType = "io.alauda.resource.created"
+	// ResourceUpdatedType type of events of updated resources
+	ResourceUpdatedType = "io.alauda.resource.updated"
+	// ResourceDeletedType type of events of deleted resources
+	ResourceDeletedType = "io.alauda.resource.deleted"
+)
+
+// ContentTypeHeader header of messages with the content type of the data
+const ContentTypeHeader = "content-type"
+
+// TopicPrefix prefixes the topics of resource change events
+const TopicPrefix = "resources"
+
+// Topic returns the topic of the change events of a kind,
+// e.g. resources.apps.deployment or resources.core.configmap
+func Topic(gvk schema.GroupVersionKind) string {
+	group := gvk.Group
+	if group == "" {
+		group = "core"
+	}
+	return strings.Join([]string{TopicPrefix, group, strings.ToLower(gvk.Kind)}, ".")
+}
+
+// ResourceEvent is a decoded resource change event
+type ResourceEvent struct {
+	// ID of the event
+	ID string
+	// Type of the event, e.g. ResourceCreatedType
+	Type string
+	// Source of the event, e.g. the name of the operator
+	Source string
+	// Time of the change
+	Time time.Time
+	// Object changed, or its last known state when deleted
+	Object *unstructured.Unstructured
+}
+
+// NewResourceEvent returns the CloudEvent of a change of obj with kind gvk. The id is derived
+// from the uid and resource version so replicas publishing the same change can be deduplicated
+func NewResourceEvent(source, eventType string, gvk schema.GroupVersionKind, obj client.Object, now time.Time) (cloudevents.Event, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return cloudevents.Event{}, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+
+	id := fmt.Sprintf("%s-%s", obj.GetUID(), obj.GetResourceVersion())
+	if eventType == ResourceDeletedType {
+		id += "-deleted"
+	}
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetType(eventType)
+	event.SetSource(source)
+	event.SetSubject(client.ObjectKeyFromObject(obj).String())
+	event.SetTime(now)
+	if err = event.SetData(cloudevents.ApplicationJSON, u.Object); err != nil {
+		return cloudevents.Event{}, err
+	}
+	return event, event.Validate()
+}
+
+// EncodeEvent returns a message of topic with the event in structured JSON mode,
+// keyed by the subject of the event
+func EncodeEvent(topic string, event cloudevents.Event) (*Message, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Topic:   topic,
+		ID:      event.ID(),
+		Key:     event.Subject(),
+		Headers: map[string]string{ContentTypeHeader: cloudevents.ApplicationCloudEventsJSON},
+		Data:    data,
+	}, nil
+}
+
+// DecodeEvent returns the CloudEvent of a message encoded by EncodeEvent
+func DecodeEvent(msg *Message) (event cloudevents.Event, err error) {
+	if err = json.Unmarshal(msg.Data, &event); err != nil {
+		return event, fmt.Errorf("decode cloudevent of topic %s failed: %w", msg.Topic, err)
+	}
+	return event, event.Validate()
+}
+
+// DecodeResourceEvent returns the resource change of a message
+func DecodeResourceEvent(msg *Message) (*ResourceEvent, error) {
+	event, err := DecodeEvent(msg)
+	if err != nil {
+		return nil, err
+	}
+	switch event.Type() {
+	case ResourceCreatedType, ResourceUpdatedType, ResourceDeletedType:
+	default:
+		return nil, fmt.Errorf("cloudevent %s of type %s is not a resource event", event.ID(), event.Type())
+	}
+	obj := &unstructured.Unstructured{}
+	if err = event.DataAs(&obj.Object); err != nil {
+		return nil, fmt.Errorf("decode object of cloudevent %s failed: %w", event.ID(), err)
+	}
+	return &ResourceEvent{
+		ID:     event.ID(),
+		Type:   event.Type(),
+		Source: event.Source(),
+		Time:   event.Time(),
+		Object: obj,
+	}, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bus publishes and subscribes messages through message queues with a
+// small interface implemented over subject based and record based transports,
+// e.g. NATS JetStream and Kafka, and by an in-memory bus for tests.
+//
+// Resource change notifications are CloudEvents in structured JSON mode, so every
+// consumer shares the same wire format. A Broadcaster attached to a manager
+// publishes the changes of watched kinds:
+//
+//	b := bus.NewRecordBus(kafkaTransport)
+//	broadcaster := bus.NewBroadcaster(mgr.GetCache(), mgr.GetScheme(), b, "my-operator",
+//		bus.WithKinds(&appsv1.Deployment{}, &v1alpha1.Repository{}))
+//	err := mgr.Add(broadcaster)
+//
+// and consumers decode them:
+//
+//	err := b.Subscribe(ctx, bus.Topic(appsv1.SchemeGroupVersion.WithKind("Deployment")), func(ctx context.Context, msg *bus.Message) error {
+//		change, err := bus.DecodeResourceEvent(msg)
+//		...
+//	})
+//
+// SubjectBus and RecordBus only map topics, keys and headers to messages of the
+// transport, they do not connect to any broker. This module does not depend on any
+// message queue client and ships no NATS or Kafka adapter: each service implements
+// SubjectTransport or RecordTransport with a thin wrapper of the client library it
+// chooses, e.g. for the github.com/nats-io/nats.go/jetstream package:
+//
+//	type jetStreamTransport struct{ js jetstream.JetStream }
+//
+//	func (c jetStreamTransport) Publish(ctx context.Context, msg *bus.SubjectMsg) error {
+//		_, err := c.js.PublishMsg(ctx, &nats.Msg{Subject: msg.Subject, Header: msg.Header, Data: msg.Data})
+//		return err
+//	}
+//
+// with Consume creating a consumer filtered on the subject, calling Ack when the handler
+// returns nil and Nak otherwise. Tests can use NewMemory instead of a broker.
+package bus
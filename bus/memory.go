@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory is an in-memory Bus delivering every message to all subscribers of its topic,
+// used in tests. Failed deliveries are not retried
+type Memory struct {
+	lock        sync.RWMutex
+	subscribers map[string][]*subscriber
+	published   []*Message
+	closed      bool
+}
+
+var _ Bus = &Memory{}
+
+type subscriber struct {
+	messages chan *Message
+	// done is closed when the subscription ends
+	done chan struct{}
+}
+
+// NewMemory returns an empty in-memory bus
+func NewMemory() *Memory {
+	return &Memory{subscribers: map[string][]*subscriber{}}
+}
+
+// Publish implements Publisher, waiting until every subscriber received the messages
+func (m *Memory) Publish(ctx context.Context, msgs ...*Message) error {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return ErrClosed
+	}
+	m.published = append(m.published, msgs...)
+	subscribers := make(map[string][]*subscriber, len(m.subscribers))
+	for topic, topicSubscribers := range m.subscribers {
+		subscribers[topic] = append([]*subscriber{}, topicSubscribers...)
+	}
+	m.lock.Unlock()
+
+	for _, msg := range msgs {
+		for _, sub := range subscribers[msg.Topic] {
+			select {
+			case sub.messages <- msg:
+			case <-sub.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe implements Subscriber, only messages published after subscribing are received
+func (m *Memory) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	sub := &subscriber{messages: make(chan *Message), done: make(chan struct{})}
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return ErrClosed
+	}
+	m.subscribers[topic] = append(m.subscribers[topic], sub)
+	m.lock.Unlock()
+	defer m.unsubscribe(topic, sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-sub.messages:
+			_ = handler(ctx, msg)
+		}
+	}
+}
+
+func (m *Memory) unsubscribe(topic string, sub *subscriber) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	close(sub.done)
+	subscribers := m.subscribers[topic]
+	for i := range subscribers {
+		if subscribers[i] == sub {
+			m.subscribers[topic] = append(subscribers[:i:i], subscribers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Published returns all the messages published
+func (m *Memory) Published() []*Message {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]*Message{}, m.published...)
+}
+
+// Close closes the bus
+func (m *Memory) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.closed = true
+}
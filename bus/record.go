@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"context"
+	"sort"
+)
+
+// IDHeader header of the id of messages in transports without ids
+const IDHeader = "Bus-Id"
+
+// Record is a keyed record with ordered headers, the shape of Kafka records
+type Record struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers []RecordHeader
+}
+
+// RecordHeader is a header of a Record
+type RecordHeader struct {
+	Key   string
+	Value []byte
+}
+
+// RecordTransport produces and consumes records of a message queue with keyed records,
+// e.g. Kafka. No implementation is provided, services implement it with a thin wrapper
+// of their client library, see the package documentation
+type RecordTransport interface {
+	// Produce produces records in order, waiting for their acknowledgement
+	Produce(ctx context.Context, records ...*Record) error
+	// Consume calls handler for the records of topic until ctx is done, committing the
+	// offset of records when handler returns nil
+	Consume(ctx context.Context, topic string, handler func(ctx context.Context, record *Record) error) error
+}
+
+// RecordBus is a Bus over a RecordTransport, message keys are record keys so changes
+// of the same object land in the same partition and keep their order
+type RecordBus struct {
+	client RecordTransport
+}
+
+var _ Bus = &RecordBus{}
+
+// NewRecordBus returns a Bus using the records of client
+func NewRecordBus(client RecordTransport) *RecordBus {
+	return &RecordBus{client: client}
+}
+
+// Publish implements Publisher
+func (r *RecordBus) Publish(ctx context.Context, msgs ...*Message) error {
+	records := make([]*Record, 0, len(msgs))
+	for _, msg := range msgs {
+		record := &Record{Topic: msg.Topic, Value: msg.Data}
+		if msg.Key != "" {
+			record.Key = []byte(msg.Key)
+		}
+		keys := make([]string, 0, len(msg.Headers))
+		for key := range msg.Headers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			record.Headers = append(record.Headers, RecordHeader{Key: key, Value: []byte(msg.Headers[key])})
+		}
+		if msg.ID != "" {
+			record.Headers = append(record.Headers, RecordHeader{Key: IDHeader, Value: []byte(msg.ID)})
+		}
+		records = append(records, record)
+	}
+	return r.client.Produce(ctx, records...)
+}
+
+// Subscribe implements Subscriber
+func (r *RecordBus) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	return r.client.Consume(ctx, topic, func(ctx context.Context, record *Record) error {
+		msg := &Message{Topic: record.Topic, Key: string(record.Key), Headers: map[string]string{}, Data: record.Value}
+		for _, header := range record.Headers {
+			if header.Key == IDHeader {
+				msg.ID = string(header.Value)
+				continue
+			}
+			msg.Headers[header.Key] = string(header.Value)
+		}
+		return handler(ctx, msg)
+	})
+}
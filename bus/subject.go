@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bus
+
+import (
+	"context"
+)
+
+const (
+	// NATSMsgIDHeader header used by JetStream to deduplicate messages
+	NATSMsgIDHeader = "Nats-Msg-Id"
+	// KeyHeader header of the key of messages in transports without keys
+	KeyHeader = "Bus-Key"
+)
+
+// SubjectMsg is a message published to a subject with multi-valued headers, the shape of NATS messages
+type SubjectMsg struct {
+	Subject string
+	Header  map[string][]string
+	Data    []byte
+}
+
+// SubjectTransport publishes and consumes messages of a message queue with subjects,
+// e.g. NATS JetStream. No implementation is provided, services implement it with a thin
+// wrapper of their client library, see the package documentation
+type SubjectTransport interface {
+	// Publish publishes msg into its stream, waiting for the acknowledgement
+	Publish(ctx context.Context, msg *SubjectMsg) error
+	// Consume calls handler for the messages of subject until ctx is done, acknowledging
+	// messages when handler returns nil and asking for a redelivery otherwise
+	Consume(ctx context.Context, subject string, handler func(ctx context.Context, msg *SubjectMsg) error) error
+}
+
+// SubjectBus is a Bus over a SubjectTransport, topics are mapped to subjects
+type SubjectBus struct {
+	client SubjectTransport
+	prefix string
+}
+
+var _ Bus = &SubjectBus{}
+
+// SubjectBusOption configures a SubjectBus
+type SubjectBusOption func(*SubjectBus)
+
+// WithSubjectPrefix prefixes the subjects of topics, e.g. the prefix "events" maps
+// the topic resources.core.configmap to the subject events.resources.core.configmap
+func WithSubjectPrefix(prefix string) SubjectBusOption {
+	return func(s *SubjectBus) {
+		s.prefix = prefix
+	}
+}
+
+// NewSubjectBus returns a Bus using the subjects of client
+func NewSubjectBus(client SubjectTransport, opts ...SubjectBusOption) *SubjectBus {
+	s := &SubjectBus{client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Subject returns the subject of a topic
+func (s *SubjectBus) Subject(topic string) string {
+	if s.prefix == "" {
+		return topic
+	}
+	return s.prefix + "." + topic
+}
+
+// Publish implements Publisher, the message id is used for JetStream deduplication
+func (s *SubjectBus) Publish(ctx context.Context, msgs ...*Message) error {
+	for _, msg := range msgs {
+		subjectMsg := &SubjectMsg{Subject: s.Subject(msg.Topic), Header: map[string][]string{}, Data: msg.Data}
+		for key, value := range msg.Headers {
+			subjectMsg.Header[key] = []string{value}
+		}
+		if msg.ID != "" {
+			subjectMsg.Header[NATSMsgIDHeader] = []string{msg.ID}
+		}
+		if msg.Key != "" {
+			subjectMsg.Header[KeyHeader] = []string{msg.Key}
+		}
+		if err := s.client.Publish(ctx, subjectMsg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe implements Subscriber
+func (s *SubjectBus) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	return s.client.Consume(ctx, s.Subject(topic), func(ctx context.Context, subjectMsg *SubjectMsg) error {
+		msg := &Message{Topic: topic, Headers: map[string]string{}, Data: subjectMsg.Data}
+		for key, values := range subjectMsg.Header {
+			if len(values) == 0 {
+				continue
+			}
+			switch key {
+			case NATSMsgIDHeader:
+				msg.ID = values[0]
+			case KeyHeader:
+				msg.Key = values[0]
+			default:
+				msg.Headers[key] = values[0]
+			}
+		}
+		return handler(ctx, msg)
+	})
+}
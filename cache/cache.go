@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+var errLoaderPanicked = errors.New("cache loader panicked")
+
+// Option configures a Cache
+type Option func(*options)
+
+type options struct {
+	ttl        time.Duration
+	maxEntries int
+	clock      clock.PassiveClock
+	metrics    Metrics
+}
+
+// WithTTL sets the default time to live of entries, zero means entries do not expire
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithMaxEntries sets the maximum number of entries, the least recently used
+// entry is evicted when full. Zero means no limit
+func WithMaxEntries(maxEntries int) Option {
+	return func(o *options) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithClock sets the clock used to expire entries
+func WithClock(clock clock.PassiveClock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithMetrics sets the metrics receiving the cache events
+func WithMetrics(metrics Metrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// LoaderFunc loads the value of a missing entry
+type LoaderFunc[V any] func(ctx context.Context) (V, error)
+
+// Cache is an in-memory cache safe for concurrent use
+type Cache[K comparable, V any] struct {
+	options
+
+	lock    sync.Mutex
+	entries map[K]*list.Element
+	// lru has the most recently used entries in the front
+	lru   *list.List
+	loads map[K]*load[V]
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// load is an in flight loader call shared by concurrent callers
+type load[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// New returns an empty Cache
+func New[K comparable, V any](opts ...Option) *Cache[K, V] {
+	c := &Cache[K, V]{
+		options: options{clock: clock.RealClock{}, metrics: NopMetrics{}},
+		entries: map[K]*list.Element{},
+		lru:     list.New(),
+		loads:   map[K]*load[V]{},
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return c
+}
+
+// Get returns the value of key if found and not expired
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.get(key)
+}
+
+func (c *Cache[K, V]) get(key K) (value V, ok bool) {
+	elem, found := c.entries[key]
+	if !found {
+		c.metrics.Miss()
+		return
+	}
+	e := elem.Value.(*entry[K, V])
+	if c.expired(e) {
+		c.remove(elem, EvictionReasonExpired)
+		c.metrics.Miss()
+		return
+	}
+	c.lru.MoveToFront(elem)
+	c.metrics.Hit()
+	return e.value, true
+}
+
+// Set adds or replaces the value of key using the default TTL
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL adds or replaces the value of key expiring after ttl,
+// zero means the entry does not expire
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set(key, value, ttl)
+}
+
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.clock.Now().Add(ttl)
+	}
+	if elem, found := c.entries[key]; found {
+		e := elem.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back(), EvictionReasonCapacity)
+	}
+}
+
+// Delete removes key from the cache
+func (c *Cache[K, V]) Delete(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, found := c.entries[key]; found {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// DeleteExpired removes all expired entries, entries are also removed when
+// accessed after expiring so calling it is only needed to release memory
+func (c *Cache[K, V]) DeleteExpired() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if c.expired(elem.Value.(*entry[K, V])) {
+			c.remove(elem, EvictionReasonExpired)
+		}
+		elem = next
+	}
+}
+
+// Purge removes all entries
+func (c *Cache[K, V]) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = map[K]*list.Element{}
+	c.lru.Init()
+}
+
+// Len returns the number of entries, including expired ones not removed yet
+func (c *Cache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// GetOrLoad returns the value of key, calling loader when it is missing or expired
+// and storing its value with the default TTL. Concurrent calls for the same key
+// wait for a single loader call. Errors are returned to all waiting callers and not cached.
+// The loader receives the context of the caller starting it
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader LoaderFunc[V]) (V, error) {
+	c.lock.Lock()
+	if value, ok := c.get(key); ok {
+		c.lock.Unlock()
+		return value, nil
+	}
+	if l, found := c.loads[key]; found {
+		c.lock.Unlock()
+		select {
+		case <-l.done:
+			return l.value, l.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	// the error is kept for waiting callers if the loader panics
+	l := &load[V]{done: make(chan struct{}), err: errLoaderPanicked}
+	c.loads[key] = l
+	c.lock.Unlock()
+
+	start := c.clock.Now()
+	defer func() {
+		c.lock.Lock()
+		delete(c.loads, key)
+		c.lock.Unlock()
+		close(l.done)
+	}()
+	l.value, l.err = loader(ctx)
+
+	c.lock.Lock()
+	c.metrics.Load(c.clock.Since(start), l.err)
+	if l.err == nil {
+		c.set(key, l.value, c.ttl)
+	}
+	c.lock.Unlock()
+	return l.value, l.err
+}
+
+func (c *Cache[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expiresAt.IsZero() && !c.clock.Now().Before(e.expiresAt)
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element, reason EvictionReason) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*entry[K, V]).key)
+	c.metrics.Evict(reason)
+}
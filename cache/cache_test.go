@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type countMetrics struct {
+	hits, misses, loads int
+	evictions           map[EvictionReason]int
+}
+
+func (m *countMetrics) Hit()  { m.hits++ }
+func (m *countMetrics) Miss() { m.misses++ }
+func (m *countMetrics) Evict(reason EvictionReason) {
+	if m.evictions == nil {
+		m.evictions = map[EvictionReason]int{}
+	}
+	m.evictions[reason]++
+}
+func (m *countMetrics) Load(time.Duration, error) { m.loads++ }
+
+func expectValue[V any](g *WithT, c *Cache[string, V], key string, expected V) {
+	value, ok := c.Get(key)
+	g.Expect(ok).To(BeTrue(), "key %s not found", key)
+	g.Expect(value).To(Equal(expected))
+}
+
+func TestCache_TTL(t *testing.T) {
+	g := NewGomegaWithT(t)
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	metrics := &countMetrics{}
+	c := New[string, int](WithTTL(time.Minute), WithClock(clock), WithMetrics(metrics))
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, 2*time.Minute)
+	c.SetWithTTL("c", 3, 0)
+	expectValue(g, c, "a", 1)
+	g.Expect(c.Len()).To(Equal(3))
+
+	clock.SetTime(clock.Now().Add(time.Minute))
+	_, ok := c.Get("a")
+	g.Expect(ok).To(BeFalse())
+	expectValue(g, c, "b", 2)
+	g.Expect(c.Len()).To(Equal(2))
+
+	clock.SetTime(clock.Now().Add(time.Hour))
+	c.DeleteExpired()
+	g.Expect(c.Len()).To(Equal(1))
+	expectValue(g, c, "c", 3)
+
+	c.Delete("c")
+	_, ok = c.Get("c")
+	g.Expect(ok).To(BeFalse())
+
+	g.Expect(metrics.hits).To(Equal(3))
+	g.Expect(metrics.misses).To(Equal(2))
+	g.Expect(metrics.evictions[EvictionReasonExpired]).To(Equal(2))
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	g := NewGomegaWithT(t)
+	metrics := &countMetrics{}
+	c := New[string, int](WithMaxEntries(2), WithMetrics(metrics))
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	// a becomes the most recently used
+	expectValue(g, c, "a", 1)
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	g.Expect(ok).To(BeFalse())
+	expectValue(g, c, "a", 1)
+	expectValue(g, c, "c", 3)
+	g.Expect(metrics.evictions[EvictionReasonCapacity]).To(Equal(1))
+
+	// replacing keeps the number of entries
+	c.Set("c", 4)
+	g.Expect(c.Len()).To(Equal(2))
+	expectValue(g, c, "c", 4)
+
+	c.Purge()
+	g.Expect(c.Len()).To(Equal(0))
+}
+
+func TestCache_GetOrLoad(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	metrics := &countMetrics{}
+	c := New[string, string](WithMetrics(metrics))
+
+	var calls int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	wg := sync.WaitGroup{}
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.GetOrLoad(ctx, "key", loader)
+		}(i)
+	}
+	g.Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(Equal(int32(1)))
+	close(release)
+	wg.Wait()
+
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	for _, result := range results {
+		g.Expect(result).To(Equal("value"))
+	}
+	expectValue(g, c, "key", "value")
+
+	// errors are not cached
+	failure := errors.New("failed")
+	_, err := c.GetOrLoad(ctx, "other", func(ctx context.Context) (string, error) { return "", failure })
+	g.Expect(err).To(Equal(failure))
+	_, ok := c.Get("other")
+	g.Expect(ok).To(BeFalse())
+
+	value, err := c.GetOrLoad(ctx, "other", func(ctx context.Context) (string, error) { return "loaded", nil })
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal("loaded"))
+}
+
+func TestCache_GetOrLoadContextDone(t *testing.T) {
+	g := NewGomegaWithT(t)
+	c := New[string, string]()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	go func() {
+		_, _ = c.GetOrLoad(context.Background(), "key", func(ctx context.Context) (string, error) {
+			close(started)
+			<-release
+			return "value", nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetOrLoad(ctx, "key", func(ctx context.Context) (string, error) { return "other", nil })
+	g.Expect(err).To(Equal(context.Canceled))
+	close(release)
+}
+
+func TestCache_GetOrLoadPanic(t *testing.T) {
+	g := NewGomegaWithT(t)
+	c := New[string, string]()
+
+	g.Expect(func() {
+		_, _ = c.GetOrLoad(context.Background(), "key", func(ctx context.Context) (string, error) { panic("boom") })
+	}).To(Panic())
+
+	value, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context) (string, error) { return "value", nil })
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal("value"))
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache implements a generic in-memory cache with expiration,
+// least recently used eviction and deduplicated loading of missing entries.
+//
+//	tags := cache.New[string, []string](cache.WithTTL(5*time.Minute), cache.WithMaxEntries(100))
+//	list, err := tags.GetOrLoad(ctx, image, func(ctx context.Context) ([]string, error) {
+//		return registry.ListTags(ctx, image)
+//	})
+package cache
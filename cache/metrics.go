@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import "time"
+
+// EvictionReason reason of an entry being removed from the cache
+type EvictionReason string
+
+const (
+	// EvictionReasonExpired the entry expired
+	EvictionReasonExpired EvictionReason = "Expired"
+	// EvictionReasonCapacity the entry was the least recently used when the cache was full
+	EvictionReasonCapacity EvictionReason = "Capacity"
+)
+
+// Metrics receives events of the cache, used to export metrics.
+// Methods are called holding the cache lock and should not block
+type Metrics interface {
+	// Hit is called when a valid entry is found
+	Hit()
+	// Miss is called when an entry is not found or expired
+	Miss()
+	// Evict is called when an entry is removed by the cache
+	Evict(reason EvictionReason)
+	// Load is called after a loader returns
+	Load(duration time.Duration, err error)
+}
+
+// NopMetrics implements Metrics ignoring all events
+type NopMetrics struct{}
+
+// Hit does nothing
+func (NopMetrics) Hit() {}
+
+// Miss does nothing
+func (NopMetrics) Miss() {}
+
+// Evict does nothing
+func (NopMetrics) Evict(EvictionReason) {}
+
+// Load does nothing
+func (NopMetrics) Load(time.Duration, error) {}
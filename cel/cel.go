@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+	apiservercel "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/apiserver/pkg/cel/environment"
+)
+
+const (
+	// ObjectVarName is the variable name of the current object in expressions
+	ObjectVarName = "object"
+	// OldObjectVarName is the variable name of the previous object in expressions
+	OldObjectVarName = "oldObject"
+
+	// DefaultCostLimit is the default runtime cost limit of a single evaluation,
+	// the same used by the Kubernetes API server per validation call
+	DefaultCostLimit uint64 = apiservercel.PerCallLimit
+)
+
+var (
+	envOnce sync.Once
+	env     *celgo.Env
+	envErr  error
+)
+
+// Env returns the CEL environment used to compile expressions.
+// It contains the Kubernetes CEL libraries and declares the
+// object and oldObject variables.
+func Env() (*celgo.Env, error) {
+	envOnce.Do(func() {
+		var envSet *environment.EnvSet
+		envSet, envErr = environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), true).Extend(
+			environment.VersionedOptions{
+				IntroducedVersion: version.MajorMinor(1, 0),
+				EnvOptions: []celgo.EnvOption{
+					celgo.Variable(ObjectVarName, celgo.DynType),
+					celgo.Variable(OldObjectVarName, celgo.DynType),
+				},
+			},
+		)
+		if envErr != nil {
+			return
+		}
+		env, envErr = envSet.Env(environment.StoredExpressions)
+	})
+	return env, envErr
+}
+
+// CompileOption customizes how an expression is compiled
+type CompileOption func(*compileOptions)
+
+type compileOptions struct {
+	costLimit uint64
+}
+
+// WithCostLimit sets the runtime cost limit of the program
+func WithCostLimit(limit uint64) CompileOption {
+	return func(o *compileOptions) {
+		o.costLimit = limit
+	}
+}
+
+// Program is a compiled CEL expression ready to be evaluated
+type Program struct {
+	expression string
+	program    celgo.Program
+}
+
+// Expression returns the source expression of the program
+func (p *Program) Expression() string {
+	return p.expression
+}
+
+// Compile compiles a CEL expression that must evaluate to a bool.
+// Programs are safe to be evaluated concurrently and should be
+// reused whenever the same expression is evaluated many times.
+func Compile(expression string, opts ...CompileOption) (*Program, error) {
+	options := compileOptions{costLimit: DefaultCostLimit}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	env, err := Env()
+	if err != nil {
+		return nil, fmt.Errorf("cel environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("compile expression %q: %w", expression, issues.Err())
+	}
+	if ast.OutputType() != celgo.BoolType && ast.OutputType() != celgo.DynType {
+		return nil, fmt.Errorf("expression %q must return bool, got %s", expression, ast.OutputType())
+	}
+
+	program, err := env.Program(ast,
+		celgo.CostLimit(options.costLimit),
+		celgo.InterruptCheckFrequency(100),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("program for expression %q: %w", expression, err)
+	}
+	return &Program{expression: expression, program: program}, nil
+}
+
+// EvalBool evaluates the program with obj as object and oldObj as oldObject.
+// Any of the objects can be nil which will be exposed as null.
+func (p *Program) EvalBool(ctx context.Context, obj, oldObj runtime.Object) (bool, error) {
+	object, err := toValue(obj)
+	if err != nil {
+		return false, err
+	}
+	oldObject, err := toValue(oldObj)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := p.program.ContextEval(ctx, map[string]interface{}{
+		ObjectVarName:    object,
+		OldObjectVarName: oldObject,
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluate expression %q: %w", p.expression, err)
+	}
+	result, ok := out.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q must return bool, got %s", p.expression, out.Type())
+	}
+	return bool(result), nil
+}
+
+// EvalBool compiles and evaluates a CEL expression with obj as object
+// and oldObj as oldObject. Prefer Compile when the expression is reused.
+func EvalBool(expression string, obj, oldObj runtime.Object) (bool, error) {
+	program, err := Compile(expression)
+	if err != nil {
+		return false, err
+	}
+	return program.EvalBool(context.Background(), obj, oldObj)
+}
+
+// toValue converts a runtime.Object into its unstructured representation
+func toValue(obj runtime.Object) (interface{}, error) {
+	if obj == nil || (reflect.ValueOf(obj).Kind() == reflect.Ptr && reflect.ValueOf(obj).IsNil()) {
+		return nil, nil
+	}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.Object, nil
+	}
+	value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("convert %T to unstructured: %w", obj, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestEvalBool(t *testing.T) {
+	deploy := func(replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"app": "web"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		}
+	}
+
+	var data = []struct {
+		desc       string
+		expression string
+		obj        runtime.Object
+		oldObj     runtime.Object
+
+		expected bool
+		errored  bool
+	}{
+		{
+			desc:       "field comparison",
+			expression: "object.spec.replicas > 1",
+			obj:        deploy(2),
+			expected:   true,
+		},
+		{
+			desc:       "old object is null when missing",
+			expression: "oldObject == null",
+			obj:        deploy(1),
+			oldObj:     (*appsv1.Deployment)(nil),
+			expected:   true,
+		},
+		{
+			desc:       "compare old and new",
+			expression: "object.spec.replicas != oldObject.spec.replicas",
+			obj:        deploy(2),
+			oldObj:     deploy(1),
+			expected:   true,
+		},
+		{
+			desc:       "kubernetes library functions",
+			expression: "quantity(object.data.size).isGreaterThan(quantity('1Gi'))",
+			obj: &corev1.ConfigMap{
+				Data: map[string]string{"size": "2Gi"},
+			},
+			expected: true,
+		},
+		{
+			desc:       "unstructured objects",
+			expression: "has(object.metadata.labels) && object.metadata.labels.app == 'web'",
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web"}},
+			}},
+			expected: true,
+		},
+		{
+			desc:       "non bool expression",
+			expression: "object.metadata.name",
+			obj:        deploy(1),
+			errored:    true,
+		},
+		{
+			desc:       "invalid expression",
+			expression: "object.",
+			errored:    true,
+		},
+		{
+			desc:       "missing field",
+			expression: "object.spec.missing == 1",
+			obj:        deploy(1),
+			errored:    true,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			result, err := EvalBool(item.expression, item.obj, item.oldObj)
+			if item.errored {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(result).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestCompileTypeCheck(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := Compile("1 + 1")
+	g.Expect(err).To(MatchError(ContainSubstring("must return bool")))
+}
+
+func TestCostLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	program, err := Compile("object.data.all(k, object.data.all(j, k != j || k == j))", WithCostLimit(10))
+	g.Expect(err).To(BeNil())
+	g.Expect(program.Expression()).To(HavePrefix("object.data.all"))
+
+	cm := &corev1.ConfigMap{Data: map[string]string{"a": "1", "b": "2", "c": "3"}}
+	_, err = program.EvalBool(context.Background(), cm, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("cost limit exceeded")))
+}
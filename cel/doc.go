@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cel compiles and evaluates CEL expressions against Kubernetes objects
+// using the same libraries and cost limits as the Kubernetes API server.
+//
+// Expressions can access the current object as `object` and the previous
+// version of the object as `oldObject`, which is null when not available:
+//
+//	ok, err := cel.EvalBool(`object.spec.replicas > 1 && oldObject != null`, obj, old)
+package cel
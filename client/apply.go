@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// LastAppliedConfigAnnotation annotation storing the last applied configuration
+// used to compute three-way merge patches when server-side apply is not available
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ApplyOptions options of Apply
+type ApplyOptions struct {
+	// Force takes ownership of conflicting fields in server-side apply.
+	// Like kubectl, the three-way merge fallback always overwrites conflicting changes.
+	Force bool
+
+	// DisableServerSideApply always uses the three-way merge patch
+	DisableServerSideApply bool
+}
+
+// ApplyOption configures ApplyOptions
+type ApplyOption func(*ApplyOptions)
+
+// ForceApply takes ownership of conflicting fields
+func ForceApply() ApplyOption {
+	return func(opts *ApplyOptions) {
+		opts.Force = true
+	}
+}
+
+// WithoutServerSideApply always uses the three-way merge patch instead of server-side apply
+func WithoutServerSideApply() ApplyOption {
+	return func(opts *ApplyOptions) {
+		opts.DisableServerSideApply = true
+	}
+}
+
+// Apply makes the object in the cluster match obj using server-side apply with fieldManager.
+// When the cluster does not support server-side apply it falls back to a client side
+// three-way merge patch based on the last applied configuration annotation, creating the
+// object if it does not exist. Built-in types use a strategic merge patch and other types
+// a json merge patch. On success obj is updated with the object returned by the server.
+func Apply(ctx context.Context, clt client.Client, obj client.Object, fieldManager string, opts ...ApplyOption) error {
+	options := &ApplyOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, clt.Scheme())
+	if err != nil {
+		return err
+	}
+
+	if !options.DisableServerSideApply {
+		err = serverSideApply(ctx, clt, obj, gvk, fieldManager, options.Force)
+		if !isServerSideApplyUnsupported(err) {
+			return err
+		}
+	}
+	return threeWayMergeApply(ctx, clt, obj, gvk, fieldManager)
+}
+
+func serverSideApply(ctx context.Context, clt client.Client, obj client.Object, gvk schema.GroupVersionKind, fieldManager string, force bool) error {
+	applied := obj.DeepCopyObject().(client.Object)
+	applied.GetObjectKind().SetGroupVersionKind(gvk)
+	applied.SetManagedFields(nil)
+	applied.SetResourceVersion("")
+
+	patchOpts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if force {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+	if err := clt.Patch(ctx, applied, client.Apply, patchOpts...); err != nil {
+		return err
+	}
+	return copyInto(applied, obj)
+}
+
+// isServerSideApplyUnsupported returns true if the error means apply patches are not supported
+func isServerSideApplyUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	return apierrors.IsUnsupportedMediaType(err) || apierrors.IsMethodNotSupported(err) ||
+		strings.Contains(err.Error(), "apply patches are not supported")
+}
+
+func threeWayMergeApply(ctx context.Context, clt client.Client, obj client.Object, gvk schema.GroupVersionKind, fieldManager string) error {
+	modified, err := setLastApplied(obj)
+	if err != nil {
+		return err
+	}
+
+	current, err := newObjectFor(clt.Scheme(), obj, gvk)
+	if err != nil {
+		return err
+	}
+	err = clt.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if apierrors.IsNotFound(err) {
+		return clt.Create(ctx, obj, client.FieldOwner(fieldManager))
+	}
+	if err != nil {
+		return err
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	original := []byte(current.GetAnnotations()[LastAppliedConfigAnnotation])
+	if len(original) == 0 {
+		original = []byte("{}")
+	}
+
+	var patch client.Patch
+	if _, isUnstructured := obj.(runtime.Unstructured); !isUnstructured && clientgoscheme.Scheme.Recognizes(gvk) {
+		meta, err := strategicpatch.NewPatchMetaFromStruct(current)
+		if err != nil {
+			return err
+		}
+		data, err := strategicpatch.CreateThreeWayMergePatch(original, modified, currentJSON, meta, true)
+		if err != nil {
+			return fmt.Errorf("create strategic merge patch for %s failed: %w", gvk.Kind, err)
+		}
+		patch = client.RawPatch(types.StrategicMergePatchType, data)
+	} else {
+		data, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, currentJSON)
+		if err != nil {
+			return fmt.Errorf("create json merge patch for %s failed: %w", gvk.Kind, err)
+		}
+		patch = client.RawPatch(types.MergePatchType, data)
+	}
+
+	if err = clt.Patch(ctx, current, patch, client.FieldOwner(fieldManager)); err != nil {
+		return err
+	}
+	return copyInto(current, obj)
+}
+
+// setLastApplied stores the configuration of obj in its last applied annotation
+// and returns the modified configuration used for the three-way merge
+func setLastApplied(obj client.Object) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	for _, field := range [][]string{
+		{"metadata", "creationTimestamp"}, {"metadata", "resourceVersion"}, {"metadata", "uid"},
+		{"metadata", "generation"}, {"metadata", "managedFields"}, {"status"},
+	} {
+		unstructured.RemoveNestedField(u.Object, field...)
+	}
+	annotations := u.GetAnnotations()
+	delete(annotations, LastAppliedConfigAnnotation)
+	u.SetAnnotations(annotations)
+
+	lastApplied, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	annotations = u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[LastAppliedConfigAnnotation] = string(lastApplied)
+	u.SetAnnotations(annotations)
+	obj.SetAnnotations(annotations)
+	return json.Marshal(u)
+}
+
+func newObjectFor(scheme *runtime.Scheme, obj client.Object, gvk schema.GroupVersionKind) (client.Object, error) {
+	if _, isUnstructured := obj.(runtime.Unstructured); isUnstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		return u, nil
+	}
+	newObj, err := scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := newObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", newObj)
+	}
+	return current, nil
+}
+
+// copyInto copies the content of from into to
+func copyInto(from, to client.Object) error {
+	if from == to {
+		return nil
+	}
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	if u, ok := to.(*unstructured.Unstructured); ok {
+		u.Object = nil
+		return u.UnmarshalJSON(data)
+	}
+	// reset the object so fields removed on the server are not kept
+	value := reflect.ValueOf(to).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	return json.Unmarshal(data, to)
+}
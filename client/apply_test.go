@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Apply", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		clt    client.Client
+		key    = client.ObjectKey{Namespace: "default", Name: "cm"}
+	)
+
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name, Labels: map[string]string{"app": "demo"}},
+			Data:       data,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		clt = fake.NewClientBuilder().WithScheme(scheme).Build()
+	})
+
+	When("server-side apply is not supported", func() {
+		BeforeEach(func() {
+			clt = interceptor.NewClient(clt.(client.WithWatch), interceptor.Funcs{
+				Patch: func(ctx context.Context, clt client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if patch.Type() == types.ApplyPatchType {
+						return apierrors.NewMethodNotSupported(schema.GroupResource{Resource: "configmaps"}, "patch")
+					}
+					return clt.Patch(ctx, obj, patch, opts...)
+				},
+			})
+		})
+
+		It("creates the object when missing", func() {
+			cm := newConfigMap(map[string]string{"a": "1"})
+			Expect(Apply(ctx, clt, cm, "test")).To(Succeed())
+
+			current := &corev1.ConfigMap{}
+			Expect(clt.Get(ctx, key, current)).To(Succeed())
+			Expect(current.Data).To(Equal(map[string]string{"a": "1"}))
+			Expect(current.Annotations).To(HaveKey(LastAppliedConfigAnnotation))
+			Expect(current.Annotations[LastAppliedConfigAnnotation]).NotTo(ContainSubstring(LastAppliedConfigAnnotation))
+		})
+
+		It("three-way merges with changes made by others", func() {
+			Expect(Apply(ctx, clt, newConfigMap(map[string]string{"a": "1", "b": "2"}), "test")).To(Succeed())
+
+			// someone else adds a key
+			current := &corev1.ConfigMap{}
+			Expect(clt.Get(ctx, key, current)).To(Succeed())
+			current.Data["other"] = "3"
+			Expect(clt.Update(ctx, current)).To(Succeed())
+
+			// apply removes b and changes a
+			cm := newConfigMap(map[string]string{"a": "10"})
+			Expect(Apply(ctx, clt, cm, "test")).To(Succeed())
+			Expect(cm.Data).To(Equal(map[string]string{"a": "10", "other": "3"}))
+			Expect(cm.ResourceVersion).NotTo(BeEmpty())
+
+			Expect(clt.Get(ctx, key, current)).To(Succeed())
+			Expect(current.Data).To(Equal(map[string]string{"a": "10", "other": "3"}))
+			Expect(current.Labels).To(Equal(map[string]string{"app": "demo"}))
+		})
+
+		It("uses a json merge patch for unstructured objects", func() {
+			Expect(Apply(ctx, clt, newConfigMap(map[string]string{"a": "1", "b": "2"}), "test")).To(Succeed())
+
+			u := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"namespace": key.Namespace, "name": key.Name},
+				"data":       map[string]interface{}{"b": "20"},
+			}}
+			Expect(Apply(ctx, clt, u, "test")).To(Succeed())
+			data, _, _ := unstructured.NestedStringMap(u.Object, "data")
+			Expect(data).To(Equal(map[string]string{"b": "20"}))
+		})
+	})
+
+	When("server-side apply is supported", func() {
+		var patches []client.Patch
+		var patchOpts []*client.PatchOptions
+
+		BeforeEach(func() {
+			patches, patchOpts = nil, nil
+			clt = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, clt client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					patches = append(patches, patch)
+					patchOpts = append(patchOpts, (&client.PatchOptions{}).ApplyOptions(opts))
+					obj.SetResourceVersion("1")
+					return nil
+				},
+			}).Build()
+		})
+
+		It("sends an apply patch with the field manager", func() {
+			cm := newConfigMap(map[string]string{"a": "1"})
+			Expect(Apply(ctx, clt, cm, "test", ForceApply())).To(Succeed())
+			Expect(patches).To(HaveLen(1))
+			Expect(patches[0].Type()).To(Equal(types.ApplyPatchType))
+			Expect(patchOpts[0].FieldManager).To(Equal("test"))
+			Expect(*patchOpts[0].Force).To(BeTrue())
+			Expect(cm.ResourceVersion).To(Equal("1"))
+			Expect(cm.Annotations).NotTo(HaveKey(LastAppliedConfigAnnotation))
+		})
+
+		It("returns not found errors unchanged", func() {
+			clt = interceptor.NewClient(clt.(client.WithWatch), interceptor.Funcs{
+				Patch: func(ctx context.Context, clt client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "missing")
+				},
+			})
+			err := Apply(ctx, clt, newConfigMap(nil), "test")
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(clt.Get(ctx, key, &corev1.ConfigMap{})).NotTo(Succeed())
+		})
+
+		It("skips server-side apply when disabled", func() {
+			Expect(Apply(ctx, clt, newConfigMap(nil), "test", WithoutServerSideApply())).To(Succeed())
+			Expect(patches).To(BeEmpty())
+		})
+	})
+})
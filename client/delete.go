@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultDeleteTimeout is how long DeleteAllOfAndWait waits for objects to disappear
+	DefaultDeleteTimeout = 5 * time.Minute
+	// DefaultDeletePollInterval is the interval between checks of DeleteAllOfAndWait
+	DefaultDeletePollInterval = time.Second
+)
+
+// DeleteProgress is the progress of DeleteAllOfAndWait
+type DeleteProgress struct {
+	// Total number of objects deleted
+	Total int
+	// Remaining objects, as kind namespace/name, which still exist
+	Remaining []string
+	// FinalizersRemoved objects, as kind namespace/name, whose finalizers were stripped
+	FinalizersRemoved []string
+}
+
+// DeleteWaitOptions options of DeleteAllOfAndWait
+type DeleteWaitOptions struct {
+	// PropagationPolicy of the deletion, defaults to background
+	PropagationPolicy metav1.DeletionPropagation
+	// Timeout waiting for the objects to disappear, defaults to DefaultDeleteTimeout
+	Timeout time.Duration
+	// PollInterval between checks, defaults to DefaultDeletePollInterval
+	PollInterval time.Duration
+	// RemoveFinalizersAfter strips the finalizers of objects still terminating after the
+	// duration. Zero never strips them, as their controllers may not have cleaned up yet
+	RemoveFinalizersAfter time.Duration
+	// Progress is called after each check
+	Progress func(DeleteProgress)
+	// lists selecting objects to delete in addition to the given ones
+	lists []deleteList
+}
+
+type deleteList struct {
+	list client.ObjectList
+	opts []client.ListOption
+}
+
+// DeleteWaitOption configures DeleteWaitOptions
+type DeleteWaitOption func(*DeleteWaitOptions)
+
+// MatchingList also deletes the objects listed in list with opts, e.g. a label selector
+func MatchingList(list client.ObjectList, opts ...client.ListOption) DeleteWaitOption {
+	return func(o *DeleteWaitOptions) {
+		o.lists = append(o.lists, deleteList{list: list, opts: opts})
+	}
+}
+
+// WithPropagationPolicy sets the propagation policy of the deletion
+func WithPropagationPolicy(policy metav1.DeletionPropagation) DeleteWaitOption {
+	return func(o *DeleteWaitOptions) {
+		o.PropagationPolicy = policy
+	}
+}
+
+// WithDeleteTimeout sets how long to wait for the objects to disappear
+func WithDeleteTimeout(timeout time.Duration) DeleteWaitOption {
+	return func(o *DeleteWaitOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithDeletePollInterval sets the interval between checks
+func WithDeletePollInterval(interval time.Duration) DeleteWaitOption {
+	return func(o *DeleteWaitOptions) {
+		o.PollInterval = interval
+	}
+}
+
+// RemoveFinalizersAfter strips the finalizers of objects still terminating after the duration
+func RemoveFinalizersAfter(after time.Duration) DeleteWaitOption {
+	return func(o *DeleteWaitOptions) {
+		o.RemoveFinalizersAfter = after
+	}
+}
+
+// WithDeleteProgress calls progress after each check
+func WithDeleteProgress(progress func(DeleteProgress)) DeleteWaitOption {
+	return func(o *DeleteWaitOptions) {
+		o.Progress = progress
+	}
+}
+
+// deleteTarget is an object being deleted
+type deleteTarget struct {
+	gvk schema.GroupVersionKind
+	key client.ObjectKey
+}
+
+func (t deleteTarget) String() string {
+	if t.key.Namespace == "" {
+		return t.gvk.Kind + " " + t.key.Name
+	}
+	return t.gvk.Kind + " " + t.key.String()
+}
+
+// DeleteAllOfAndWait deletes objs and the objects of the MatchingList options, then waits until
+// they disappear, stripping the finalizers of objects stuck terminating when RemoveFinalizersAfter is set.
+// Objects already gone are ignored. Returns an error listing the remaining objects on timeout
+func DeleteAllOfAndWait(ctx context.Context, cli client.Client, objs []client.Object, opts ...DeleteWaitOption) error {
+	options := DeleteWaitOptions{
+		PropagationPolicy: metav1.DeletePropagationBackground,
+		Timeout:           DefaultDeleteTimeout,
+		PollInterval:      DefaultDeletePollInterval,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	logger := logging.FromContext(ctx)
+
+	targets, err := deleteTargets(ctx, cli, objs, options.lists)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, target := range targets {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(target.gvk)
+		obj.SetNamespace(target.key.Namespace)
+		obj.SetName(target.key.Name)
+		if err = cli.Delete(ctx, obj, client.PropagationPolicy(options.PropagationPolicy)); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("delete %s failed: %w", target, err))
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	start := time.Now()
+	progress := DeleteProgress{Total: len(targets)}
+	finalizersRemoved := map[deleteTarget]bool{}
+	err = wait.PollUntilContextTimeout(ctx, options.PollInterval, options.Timeout, true, func(ctx context.Context) (bool, error) {
+		progress.Remaining = nil
+		stripFinalizers := options.RemoveFinalizersAfter > 0 && time.Since(start) >= options.RemoveFinalizersAfter
+		for _, target := range targets {
+			live := &metav1.PartialObjectMetadata{}
+			live.SetGroupVersionKind(target.gvk)
+			if err := cli.Get(ctx, target.key, live); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return false, err
+			}
+			progress.Remaining = append(progress.Remaining, target.String())
+			if !stripFinalizers || live.DeletionTimestamp == nil || len(live.Finalizers) == 0 || finalizersRemoved[target] {
+				continue
+			}
+			logger.Infow("removing finalizers of object stuck terminating", "object", target.String(), "finalizers", live.Finalizers)
+			live.SetGroupVersionKind(target.gvk)
+			patch := client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`))
+			if err := cli.Patch(ctx, live, patch); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("remove finalizers of %s failed: %w", target, err)
+			}
+			finalizersRemoved[target] = true
+			progress.FinalizersRemoved = append(progress.FinalizersRemoved, target.String())
+		}
+		if options.Progress != nil {
+			options.Progress(progress)
+		}
+		return len(progress.Remaining) == 0, nil
+	})
+	if err != nil && wait.Interrupted(err) && ctx.Err() == nil {
+		return fmt.Errorf("timed out waiting for %d objects to be deleted: %s", len(progress.Remaining), strings.Join(progress.Remaining, ", "))
+	}
+	return err
+}
+
+// deleteTargets returns the sorted and deduplicated objs and items of lists
+func deleteTargets(ctx context.Context, cli client.Client, objs []client.Object, lists []deleteList) ([]deleteTarget, error) {
+	for _, l := range lists {
+		if err := cli.List(ctx, l.list, l.opts...); err != nil {
+			return nil, fmt.Errorf("list objects to delete failed: %w", err)
+		}
+		items, err := meta.ExtractList(l.list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("%T is not a client.Object", item)
+			}
+			objs = append(objs, obj)
+		}
+	}
+
+	seen := map[deleteTarget]bool{}
+	targets := make([]deleteTarget, 0, len(objs))
+	for _, obj := range objs {
+		gvk, err := cli.GroupVersionKindFor(obj)
+		if err != nil {
+			return nil, err
+		}
+		target := deleteTarget{gvk: gvk, key: client.ObjectKeyFromObject(obj)}
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].String() < targets[j].String()
+	})
+	return targets, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DeleteAllOfAndWait", func() {
+	var (
+		ctx      context.Context
+		clt      client.Client
+		progress []DeleteProgress
+		opts     []DeleteWaitOption
+	)
+
+	newConfigMap := func(name string, labels map[string]string, finalizers ...string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: name, Labels: labels, Finalizers: finalizers,
+		}}
+	}
+	exists := func(name string) bool {
+		err := clt.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		return !apierrors.IsNotFound(err)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		progress = nil
+		clt = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			newConfigMap("a", nil),
+			newConfigMap("b", map[string]string{"app": "demo"}),
+			newConfigMap("c", map[string]string{"app": "demo"}, "example.com/cleanup"),
+			newConfigMap("other", map[string]string{"app": "other"}),
+		).Build()
+		opts = []DeleteWaitOption{
+			WithDeletePollInterval(time.Millisecond),
+			WithDeleteProgress(func(p DeleteProgress) { progress = append(progress, p) }),
+		}
+	})
+
+	It("deletes the objects and the listed objects", func() {
+		err := DeleteAllOfAndWait(ctx, clt, []client.Object{newConfigMap("a", nil), newConfigMap("missing", nil), newConfigMap("b", nil)},
+			append(opts, MatchingList(&corev1.ConfigMapList{}, client.InNamespace("default"), client.MatchingLabels{"app": "demo"}),
+				RemoveFinalizersAfter(time.Millisecond))...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists("a")).To(BeFalse())
+		Expect(exists("b")).To(BeFalse())
+		Expect(exists("c")).To(BeFalse())
+		Expect(exists("other")).To(BeTrue())
+
+		last := progress[len(progress)-1]
+		Expect(last.Total).To(Equal(4))
+		Expect(last.Remaining).To(BeEmpty())
+		Expect(last.FinalizersRemoved).To(Equal([]string{"ConfigMap default/c"}))
+	})
+
+	It("times out waiting for objects stuck terminating without removing finalizers", func() {
+		err := DeleteAllOfAndWait(ctx, clt, []client.Object{newConfigMap("c", nil)}, append(opts, WithDeleteTimeout(20*time.Millisecond))...)
+		Expect(err).To(MatchError("timed out waiting for 1 objects to be deleted: ConfigMap default/c"))
+		Expect(exists("c")).To(BeTrue())
+		Expect(progress).NotTo(BeEmpty())
+		Expect(progress[0].Remaining).To(Equal([]string{"ConfigMap default/c"}))
+		Expect(progress[0].FinalizersRemoved).To(BeEmpty())
+	})
+})
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+	"github.com/AlaudaDevops/pkg/metrics"
+	"github.com/AlaudaDevops/pkg/resilience"
+	"github.com/AlaudaDevops/pkg/tracing"
+)
+
+// NewClient returns a http client configured by the options.
+// By default requests timeout after DefaultTimeout, proxies are
+// loaded from the environment, tracing spans are propagated
+// and latencies are observed in the standard metrics
+func NewClient(opts ...Option) (*http.Client, error) {
+	o := &options{
+		timeout:             DefaultTimeout,
+		tlsConfig:           &tls.Config{MinVersion: tls.VersionTLS12},
+		proxy:               http.ProxyFromEnvironment,
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		tracing:             true,
+		metrics:             true,
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:                 o.proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       o.tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   o.maxIdleConnsPerHost,
+		MaxConnsPerHost:       o.maxConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	for _, wrap := range o.transportWrappers {
+		transport = wrap(transport)
+	}
+	// each attempt of a retried request is observed and traced
+	if o.metrics {
+		transport = metrics.InstrumentTransport(transport)
+	}
+	if o.tracing {
+		transport = tracing.WrapTransport(transport)
+	}
+	if o.hosts != nil {
+		transport = resilience.NewTransport(transport, o.hosts)
+	}
+	if o.retryPolicy != nil {
+		transport = &retryTransport{base: transport, policy: *o.retryPolicy}
+	}
+	if o.authenticator != nil {
+		transport = credentials.NewTransport(transport, o.authenticator)
+	}
+	return &http.Client{Transport: transport, Timeout: o.timeout}, nil
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+	"github.com/AlaudaDevops/pkg/metrics"
+	"github.com/AlaudaDevops/pkg/resilience"
+	"github.com/AlaudaDevops/pkg/retry"
+	"github.com/AlaudaDevops/pkg/testing/recorder"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func fastRetry() retry.Policy {
+	return retry.Policy{InitialInterval: time.Millisecond, Multiplier: 1, MaxAttempts: 3}
+}
+
+func TestNewClient_Retry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/unavailable"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case n < 3:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	clt, err := NewClient(WithRetry(fastRetry()), WithTracing(false))
+	NewGomegaWithT(t).Expect(err).To(BeNil())
+
+	t.Run("retries transient status codes", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		atomic.StoreInt32(&calls, 0)
+		resp, err := clt.Get(server.URL)
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+	})
+
+	t.Run("sends the body again", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		atomic.StoreInt32(&calls, 0)
+		req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("payload"))
+		resp, err := clt.Do(req)
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		g.Expect(string(body)).To(Equal("payload"))
+	})
+
+	t.Run("does not retry non idempotent requests", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		atomic.StoreInt32(&calls, 0)
+		resp, err := clt.Post(server.URL, "text/plain", strings.NewReader("payload"))
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	t.Run("retries requests with idempotency key", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		atomic.StoreInt32(&calls, 0)
+		req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+		req.Header.Set(IdempotencyKeyHeader, "key")
+		resp, err := clt.Do(req)
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	t.Run("returns the last response after all attempts", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		atomic.StoreInt32(&calls, 0)
+		resp, err := clt.Get(server.URL + "/unavailable")
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		g.Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+	})
+}
+
+func TestNewClient_TLS(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	clt, err := NewClient()
+	g.Expect(err).To(BeNil())
+	_, err = clt.Get(server.URL)
+	g.Expect(err).NotTo(BeNil())
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	clt, err = NewClient(WithCABundle(caBundle))
+	g.Expect(err).To(BeNil())
+	resp, err := clt.Get(server.URL)
+	g.Expect(err).To(BeNil())
+	resp.Body.Close()
+
+	clt, err = NewClient(WithInsecureSkipVerify(true))
+	g.Expect(err).To(BeNil())
+	resp, err = clt.Get(server.URL)
+	g.Expect(err).To(BeNil())
+	resp.Body.Close()
+
+	_, err = NewClient(WithCABundle([]byte("invalid")))
+	g.Expect(err).NotTo(BeNil())
+	_, err = NewClient(WithClientCertificate([]byte("invalid"), nil))
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestNewClient_Proxy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		_, _ = w.Write([]byte(r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	secret := &corev1.Secret{Data: map[string][]byte{
+		SecretKeyHTTPProxy: []byte(proxy.URL),
+		SecretKeyNoProxy:   []byte("excluded.example.com"),
+	}}
+	clt, err := NewClient(WithProxyFromSecret(secret))
+	g.Expect(err).To(BeNil())
+
+	resp, err := clt.Get("http://example.com/path")
+	g.Expect(err).To(BeNil())
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	g.Expect(string(body)).To(Equal("http://example.com/path"))
+	g.Expect(atomic.LoadInt32(&proxied)).To(Equal(int32(1)))
+
+	clt, err = NewClient(WithProxy(proxy.URL))
+	g.Expect(err).To(BeNil())
+	resp, err = clt.Get("http://excluded.example.com/")
+	g.Expect(err).To(BeNil())
+	resp.Body.Close()
+	g.Expect(atomic.LoadInt32(&proxied)).To(Equal(int32(2)))
+
+	_, err = NewClient(WithProxy("://invalid"))
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestNewClient_Timeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	clt, err := NewClient(WithTimeout(10*time.Millisecond), WithMaxConnsPerHost(1), WithMaxIdleConnsPerHost(1))
+	g.Expect(err).To(BeNil())
+	g.Expect(clt.Timeout).To(Equal(10 * time.Millisecond))
+	_, err = clt.Get(server.URL)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestNewClient_Authenticator(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	clt, err := NewClient(WithAuthenticator(&credentials.BearerToken{Token: "abc"}))
+	g.Expect(err).To(BeNil())
+	resp, err := clt.Get(server.URL)
+	g.Expect(err).To(BeNil())
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	g.Expect(string(body)).To(Equal("Bearer abc"))
+}
+
+func TestNewClient_Metrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	for _, enabled := range []bool{true, false} {
+		metrics.ExternalRequestDuration.Reset()
+		clt, err := NewClient(WithMetrics(enabled))
+		g.Expect(err).To(BeNil())
+		resp, err := clt.Get(server.URL)
+		g.Expect(err).To(BeNil())
+		resp.Body.Close()
+
+		expected := 0
+		if enabled {
+			expected = 1
+		}
+		g.Expect(testutil.CollectAndCount(metrics.ExternalRequestDuration)).To(Equal(expected))
+	}
+}
+
+func TestNewClient_Resilience(t *testing.T) {
+	g := NewGomegaWithT(t)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	breaker := resilience.BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Hour}
+	clt, err := NewClient(WithResilience(resilience.NewHosts(resilience.Policy{Breaker: &breaker})), WithRetry(fastRetry()))
+	g.Expect(err).To(BeNil())
+
+	// the breaker opens during the retries, which stop at the first rejected attempt
+	_, err = clt.Get(server.URL)
+	g.Expect(err).To(MatchError(resilience.ErrOpen))
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+}
+
+func TestNewClient_TransportWrapper(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("recorded"))
+	}))
+	defer server.Close()
+	cassette := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	for _, mode := range []recorder.Mode{recorder.ModeRecord, recorder.ModeReplay} {
+		rec, err := recorder.New(cassette, mode)
+		g.Expect(err).To(BeNil())
+		clt, err := NewClient(WithTransportWrapper(rec.Wrap), WithAuthenticator(&credentials.BearerToken{Token: "abc"}))
+		g.Expect(err).To(BeNil())
+		resp, err := clt.Get(server.URL)
+		g.Expect(err).To(BeNil())
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		g.Expect(string(body)).To(Equal("recorded"))
+		g.Expect(rec.Stop()).To(Succeed())
+		server.Config.Handler = http.NotFoundHandler()
+	}
+}
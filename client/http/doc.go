@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package http creates http clients for tool integrations with timeouts,
+// tracing propagation, metrics, retries of idempotent requests, custom CAs, proxies,
+// circuit breakers and client-side rate limits per host.
+//
+//	clt, err := http.NewClient(
+//		http.WithTimeout(time.Minute),
+//		http.WithCABundle(caBundle),
+//		http.WithProxyFromSecret(secret),
+//		http.WithRetry(retry.DefaultPolicy()),
+//	)
+package http
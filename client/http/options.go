@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+	"github.com/AlaudaDevops/pkg/resilience"
+	"github.com/AlaudaDevops/pkg/retry"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultTimeout timeout of requests including reading the response body
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxIdleConnsPerHost idle connections kept per host
+	DefaultMaxIdleConnsPerHost = 10
+
+	// SecretKeyHTTPProxy key of the proxy for http requests in a proxy secret
+	SecretKeyHTTPProxy = "httpProxy"
+	// SecretKeyHTTPSProxy key of the proxy for https requests in a proxy secret
+	SecretKeyHTTPSProxy = "httpsProxy"
+	// SecretKeyNoProxy key of the comma separated hosts excluded from proxying in a proxy secret
+	SecretKeyNoProxy = "noProxy"
+)
+
+// Option configures the client created by NewClient
+type Option func(*options) error
+
+type options struct {
+	timeout             time.Duration
+	tlsConfig           *tls.Config
+	proxy               func(*http.Request) (*url.URL, error)
+	maxIdleConnsPerHost int
+	maxConnsPerHost     int
+	retryPolicy         *retry.Policy
+	tracing             bool
+	metrics             bool
+	authenticator       credentials.HTTPAuthenticator
+	hosts               *resilience.Hosts
+	transportWrappers   []func(http.RoundTripper) http.RoundTripper
+}
+
+// WithTimeout sets the timeout of requests, zero means no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of server certificates
+func WithInsecureSkipVerify(insecure bool) Option {
+	return func(o *options) error {
+		o.tlsConfig.InsecureSkipVerify = insecure // nolint: gosec // G402: explicitly requested
+		return nil
+	}
+}
+
+// WithCABundle trusts the PEM encoded certificates in addition to the system ones
+func WithCABundle(caBundle []byte) Option {
+	return func(o *options) error {
+		if len(caBundle) == 0 {
+			return nil
+		}
+		if o.tlsConfig.RootCAs == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			o.tlsConfig.RootCAs = pool
+		}
+		if !o.tlsConfig.RootCAs.AppendCertsFromPEM(caBundle) {
+			return errors.New("no valid certificates found in the CA bundle")
+		}
+		return nil
+	}
+}
+
+// WithClientCertificate sets the certificate presented to servers requiring client authentication
+func WithClientCertificate(certPEM, keyPEM []byte) Option {
+	return func(o *options) error {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return err
+		}
+		o.tlsConfig.Certificates = append(o.tlsConfig.Certificates, cert)
+		return nil
+	}
+}
+
+// WithProxy sends all requests through the proxy url, an empty url disables proxies
+func WithProxy(proxyURL string) Option {
+	return func(o *options) error {
+		if proxyURL == "" {
+			o.proxy = nil
+			return nil
+		}
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+		o.proxy = http.ProxyURL(parsed)
+		return nil
+	}
+}
+
+// WithProxyConfig sets the proxies by scheme and the hosts excluded from proxying
+func WithProxyConfig(config httpproxy.Config) Option {
+	return func(o *options) error {
+		proxyFunc := config.ProxyFunc()
+		o.proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+		return nil
+	}
+}
+
+// WithProxyFromSecret sets the proxies from the httpProxy, httpsProxy and noProxy keys
+// of the secret. A nil secret keeps the proxies from the environment
+func WithProxyFromSecret(secret *corev1.Secret) Option {
+	if secret == nil {
+		return func(*options) error { return nil }
+	}
+	return WithProxyConfig(httpproxy.Config{
+		HTTPProxy:  string(secret.Data[SecretKeyHTTPProxy]),
+		HTTPSProxy: string(secret.Data[SecretKeyHTTPSProxy]),
+		NoProxy:    string(secret.Data[SecretKeyNoProxy]),
+	})
+}
+
+// WithMaxConnsPerHost limits the connections per host, zero means no limit
+func WithMaxConnsPerHost(maxConns int) Option {
+	return func(o *options) error {
+		o.maxConnsPerHost = maxConns
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the idle connections kept per host,
+// defaults to DefaultMaxIdleConnsPerHost
+func WithMaxIdleConnsPerHost(maxIdleConns int) Option {
+	return func(o *options) error {
+		o.maxIdleConnsPerHost = maxIdleConns
+		return nil
+	}
+}
+
+// WithRetry retries idempotent requests failing with network errors or
+// 429 and 5xx status codes using the policy backoff and attempts.
+// The RetryOn predicate of the policy is not used
+func WithRetry(policy retry.Policy) Option {
+	return func(o *options) error {
+		o.retryPolicy = &policy
+		return nil
+	}
+}
+
+// WithTracing enables the propagation of tracing spans in requests, enabled by default
+func WithTracing(enabled bool) Option {
+	return func(o *options) error {
+		o.tracing = enabled
+		return nil
+	}
+}
+
+// WithMetrics enables observing the latency of requests by host, method and status code
+// in the standard external request metrics, enabled by default
+func WithMetrics(enabled bool) Option {
+	return func(o *options) error {
+		o.metrics = enabled
+		return nil
+	}
+}
+
+// WithAuthenticator authenticates all requests, e.g. with credentials resolved from a secret
+func WithAuthenticator(auth credentials.HTTPAuthenticator) Option {
+	return func(o *options) error {
+		o.authenticator = auth
+		return nil
+	}
+}
+
+// WithResilience rate limits each attempt of requests and fails them fast while the
+// circuit breaker of their host is open, using the breakers and limiters of hosts.
+// Sharing hosts between clients shares their breakers and limits
+func WithResilience(hosts *resilience.Hosts) Option {
+	return func(o *options) error {
+		o.hosts = hosts
+		return nil
+	}
+}
+
+// WithTransportWrapper wraps the transport sending requests, inside metrics, tracing, retries
+// and authentication, e.g. to record and replay interactions in tests with testing/recorder
+func WithTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(o *options) error {
+		o.transportWrappers = append(o.transportWrappers, wrap)
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/AlaudaDevops/pkg/retry"
+)
+
+// IdempotencyKeyHeader marks non idempotent requests as safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// retryTransport retries idempotent requests with transient failures
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retry.Policy
+}
+
+type statusCodeError struct {
+	code int
+}
+
+func (e *statusCodeError) Error() string { return fmt.Sprintf("unexpected status code %d", e.code) }
+
+func (e *statusCodeError) StatusCode() int { return e.code }
+
+// IsIdempotent returns true if the request can be sent more than once:
+// the method is idempotent, or the request has an Idempotency-Key header,
+// and the body can be sent again
+func IsIdempotent(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// RoundTrip sends the request retrying network errors and 429 or 5xx responses.
+// The last response is returned when all attempts fail with a status code
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !IsIdempotent(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	policy := t.policy.WithRetryOn(retry.IsTransient)
+	attempt := 0
+	var last *http.Response
+	resp, err := retry.DoWithResult(req.Context(), policy, func(ctx context.Context) (*http.Response, error) {
+		attempt++
+		if last != nil {
+			// the previous response is discarded only once retrying
+			_, _ = io.Copy(io.Discard, last.Body)
+			_ = last.Body.Close()
+			last = nil
+		}
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, retry.Permanent(err)
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+		if retry.IsTransientStatusCode(resp.StatusCode) {
+			last = resp
+			return resp, &statusCodeError{code: resp.StatusCode}
+		}
+		return resp, nil
+	})
+	if err == nil {
+		return resp, nil
+	}
+	var statusErr *statusCodeError
+	if last != nil && errors.As(err, &statusErr) && req.Context().Err() == nil {
+		return last, nil
+	}
+	if last != nil {
+		_ = last.Body.Close()
+	}
+	return nil, err
+}
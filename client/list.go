@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPageSize is the number of objects requested per page by ForEachListItem
+const DefaultPageSize = 500
+
+// ListPageOptions options of ForEachListItem
+type ListPageOptions struct {
+	// PageSize is the number of objects requested per page, defaults to DefaultPageSize
+	PageSize int64
+	// ListOptions of every request, e.g. a namespace or a label selector
+	ListOptions []client.ListOption
+	// Parallelism is the number of pages processed concurrently while the next pages are fetched,
+	// fn must then be safe for concurrent use. Items of a page are always processed in order.
+	// Defaults to 1, processing pages one after the other
+	Parallelism int
+}
+
+// ListPageOption configures ListPageOptions
+type ListPageOption func(*ListPageOptions)
+
+// WithPageSize sets the number of objects requested per page
+func WithPageSize(size int64) ListPageOption {
+	return func(opts *ListPageOptions) {
+		opts.PageSize = size
+	}
+}
+
+// WithListOptions sets the options of every request, e.g. client.InNamespace
+func WithListOptions(listOpts ...client.ListOption) ListPageOption {
+	return func(opts *ListPageOptions) {
+		opts.ListOptions = append(opts.ListOptions, listOpts...)
+	}
+}
+
+// WithParallelism processes up to n pages concurrently, fn must be safe for concurrent use
+func WithParallelism(n int) ListPageOption {
+	return func(opts *ListPageOptions) {
+		opts.Parallelism = n
+	}
+}
+
+// ForEachListItem lists the objects of list page by page, following continue tokens,
+// and calls fn with each of them so the whole collection is never held in memory.
+// The iteration stops at the first error returned by fn or by a request.
+//
+// All the pages are served from the snapshot of the first one, whose resource version
+// is set on list when done so a watch can start from it. When the snapshot is compacted
+// before the iteration ends the request fails with an expired error, see
+// apierrors.IsResourceExpired, and the iteration should be restarted.
+// Pagination is only honored by the api server, use an uncached client like
+// manager.GetAPIReader, a cached client returns a single page.
+//
+//	err := client.ForEachListItem(ctx, reader, &corev1.PodList{}, func(obj ctrlclient.Object) error {
+//		pod := obj.(*corev1.Pod)
+//		...
+//	}, client.WithPageSize(500), client.WithListOptions(ctrlclient.InNamespace(ns)))
+func ForEachListItem(ctx context.Context, cli client.Reader, list client.ObjectList, fn func(obj client.Object) error, opts ...ListPageOption) error {
+	options := ListPageOptions{PageSize: DefaultPageSize, Parallelism: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Parallelism < 1 {
+		options.Parallelism = 1
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(options.Parallelism)
+	// list is kept empty to be copied for each page
+	template := list.DeepCopyObject().(client.ObjectList)
+	var resourceVersion, continueToken string
+	for {
+		page := template.DeepCopyObject().(client.ObjectList)
+		listOpts := append([]client.ListOption{}, options.ListOptions...)
+		listOpts = append(listOpts, client.Limit(options.PageSize), client.Continue(continueToken))
+		if err := cli.List(groupCtx, page, listOpts...); err != nil {
+			// the list fails when fn failed and canceled the context, its error is reported instead
+			if groupErr := group.Wait(); groupErr != nil {
+				return groupErr
+			}
+			return fmt.Errorf("list page failed: %w", err)
+		}
+		if resourceVersion == "" {
+			resourceVersion = page.GetResourceVersion()
+		}
+		items, err := meta.ExtractList(page)
+		if err != nil {
+			_ = group.Wait()
+			return err
+		}
+		// blocks while Parallelism pages are processed, so at most one more page is held in memory
+		group.Go(func() error {
+			for _, item := range items {
+				// a page processed concurrently failed
+				if groupCtx.Err() != nil {
+					return nil
+				}
+				obj, ok := item.(client.Object)
+				if !ok {
+					return fmt.Errorf("list item %T is not a client.Object", item)
+				}
+				if err := fn(obj); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if continueToken = page.GetContinue(); continueToken == "" || groupCtx.Err() != nil {
+			break
+		}
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	if continueToken != "" {
+		// stopped because ctx was canceled
+		return ctx.Err()
+	}
+	list.SetResourceVersion(resourceVersion)
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("ForEachListItem", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		requests []client.ListOptions
+		mu       sync.Mutex
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests = nil
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for i := 0; i < 5; i++ {
+			builder = builder.WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("cm-%d", i)}})
+		}
+		builder = builder.WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "cm"}})
+		// the fake client does not paginate, the continue token is the index of the next item
+		cli = builder.WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				requests = append(requests, listOpts)
+				all := &corev1.ConfigMapList{}
+				if err := cli.List(ctx, all, client.InNamespace(listOpts.Namespace)); err != nil {
+					return err
+				}
+				start, _ := strconv.Atoi(listOpts.Continue)
+				end := min(start+int(listOpts.Limit), len(all.Items))
+				page := list.(*corev1.ConfigMapList)
+				page.Items = all.Items[start:end]
+				page.ResourceVersion = strconv.Itoa(len(requests))
+				if end < len(all.Items) {
+					page.Continue = strconv.Itoa(end)
+				}
+				return nil
+			},
+		}).Build()
+	})
+
+	It("calls fn with every item page by page", func() {
+		var names []string
+		list := &corev1.ConfigMapList{}
+		err := ForEachListItem(ctx, cli, list, func(obj client.Object) error {
+			names = append(names, obj.(*corev1.ConfigMap).Name)
+			return nil
+		}, WithPageSize(2), WithListOptions(client.InNamespace("default")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"cm-0", "cm-1", "cm-2", "cm-3", "cm-4"}))
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[0].Continue).To(BeEmpty())
+		Expect(requests[2].Continue).To(Equal("4"))
+		Expect(requests[2].Limit).To(BeEquivalentTo(2))
+		// resource version of the snapshot of the first page
+		Expect(list.ResourceVersion).To(Equal("1"))
+		Expect(list.Items).To(BeEmpty())
+	})
+
+	It("processes pages in parallel", func() {
+		var names []string
+		err := ForEachListItem(ctx, cli, &corev1.ConfigMapList{}, func(obj client.Object) error {
+			mu.Lock()
+			defer mu.Unlock()
+			names = append(names, obj.GetName())
+			return nil
+		}, WithPageSize(1), WithParallelism(3))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(ConsistOf("cm-0", "cm-1", "cm-2", "cm-3", "cm-4", "cm"))
+	})
+
+	It("stops at the first error", func() {
+		failed := errors.New("failed")
+		count := 0
+		err := ForEachListItem(ctx, cli, &corev1.ConfigMapList{}, func(obj client.Object) error {
+			count++
+			return failed
+		}, WithPageSize(2))
+		Expect(err).To(MatchError(failed))
+		Expect(count).To(Equal(1))
+		Expect(len(requests)).To(BeNumerically("<", 3))
+	})
+
+	It("stops when the context is canceled", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		err := ForEachListItem(ctx, cli, &corev1.ConfigMapList{}, func(obj client.Object) error {
+			cancel()
+			return nil
+		}, WithPageSize(1))
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
@@ -0,0 +1,421 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/AlaudaDevops/pkg/cache"
+	"github.com/AlaudaDevops/pkg/dryrun"
+)
+
+// ImpersonateClientFunc returns a client impersonating the given user
+type ImpersonateClientFunc func(ctx context.Context, u user.Info) (client.Client, error)
+
+type impersonatedUserCtxKey struct{}
+
+// WithImpersonatedUser sets the user a WrappedClient impersonates into the context
+func WithImpersonatedUser(ctx context.Context, u user.Info) context.Context {
+	return context.WithValue(ctx, impersonatedUserCtxKey{}, u)
+}
+
+// ImpersonatedUser returns the user to impersonate from the context. Returns nil if not found
+func ImpersonatedUser(ctx context.Context) user.Info {
+	u, _ := ctx.Value(impersonatedUserCtxKey{}).(user.Info)
+	return u
+}
+
+// WrappedClientOption configures a WrappedClient
+type WrappedClientOption func(*WrappedClient)
+
+// WithDefaultNamespace sets the namespace used for namespaced objects without namespace
+func WithDefaultNamespace(namespace string) WrappedClientOption {
+	return func(c *WrappedClient) {
+		c.namespace = namespace
+	}
+}
+
+// WithDryRun toggles server dry-run mode for all write requests,
+// regardless of the dryrun.Strategy in the context
+func WithDryRun(dryRun bool) WrappedClientOption {
+	return func(c *WrappedClient) {
+		c.dryRun = dryRun
+	}
+}
+
+// WithUserAnnotations toggles the injection of the creation and update time annotations,
+// and of the createdBy and updatedBy annotations when there is an impersonated user or a user in the context
+func WithUserAnnotations(inject bool) WrappedClientOption {
+	return func(c *WrappedClient) {
+		c.userAnnotations = inject
+	}
+}
+
+// WithImpersonation sets the function used to build a client for the impersonated user in the context
+func WithImpersonation(impersonate ImpersonateClientFunc) WrappedClientOption {
+	return func(c *WrappedClient) {
+		c.impersonate = impersonate
+	}
+}
+
+// WithWrappedClientClock sets the clock used for time annotations
+func WithWrappedClientClock(clock clock.PassiveClock) WrappedClientOption {
+	return func(c *WrappedClient) {
+		c.clock = clock
+	}
+}
+
+// WrappedClient wraps a client.Client adding cross-cutting behaviors:
+//   - namespaced objects without namespace get the default namespace on Get, Create, Update, Patch and Delete
+//   - requests are issued as the user set by WithImpersonatedUser when impersonation is configured
+//   - creationTime and createdBy annotations are set on Create and updateTime and updatedBy on Update and Patch
+//   - all write requests can be switched to dry-run, by WithDryRun or by the dryrun.Strategy in the context:
+//     server dry run sends them with client.DryRunAll and client dry run skips them,
+//     in both cases they are recorded into the dryrun.Summary of the context
+//
+// List and DeleteAllOf are not namespace defaulted so cluster wide requests stay possible.
+type WrappedClient struct {
+	client.Client
+
+	namespace       string
+	dryRun          bool
+	userAnnotations bool
+	impersonate     ImpersonateClientFunc
+	clock           clock.PassiveClock
+}
+
+var _ client.Client = &WrappedClient{}
+
+// NewWrappedClient wraps clt with options, user annotations are injected by default
+func NewWrappedClient(clt client.Client, opts ...WrappedClientOption) *WrappedClient {
+	c := &WrappedClient{
+		Client:          clt,
+		userAnnotations: true,
+		clock:           clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithOptions returns a copy of the client with opts applied, e.g. to toggle dry-run for a single operation
+func (c *WrappedClient) WithOptions(opts ...WrappedClientOption) *WrappedClient {
+	copied := *c
+	for _, opt := range opts {
+		opt(&copied)
+	}
+	return &copied
+}
+
+// IsDryRun returns true if write requests are sent in dry-run mode
+func (c *WrappedClient) IsDryRun() bool {
+	return c.dryRun
+}
+
+// dryRunStrategy returns the dry run strategy of a write request
+func (c *WrappedClient) dryRunStrategy(ctx context.Context) dryrun.Strategy {
+	if c.dryRun {
+		return dryrun.Server
+	}
+	return dryrun.StrategyFrom(ctx)
+}
+
+// write sends a write request by calling send, honoring the dry run strategy:
+// server dry run asks send to add client.DryRunAll and client dry run does not call send.
+// Dry run requests are recorded into the dryrun.Summary of the context
+func (c *WrappedClient) write(ctx context.Context, verb string, obj client.Object, subResource string, send func(dryRun bool) error) error {
+	switch c.dryRunStrategy(ctx) {
+	case dryrun.Client:
+		c.recordDryRun(ctx, verb, obj, subResource)
+		return nil
+	case dryrun.Server:
+		if err := send(true); err != nil {
+			return err
+		}
+		c.recordDryRun(ctx, verb, obj, subResource)
+		return nil
+	default:
+		return send(false)
+	}
+}
+
+// recordDryRun adds the write request to the dryrun.Summary of the context
+func (c *WrappedClient) recordDryRun(ctx context.Context, verb string, obj client.Object, subResource string) {
+	gvk, _ := c.Client.GroupVersionKindFor(obj)
+	dryrun.Record(ctx, dryrun.Action{
+		Verb:             verb,
+		GroupVersionKind: gvk,
+		Namespace:        obj.GetNamespace(),
+		Name:             obj.GetName(),
+		SubResource:      subResource,
+	})
+}
+
+// Get implements client.Client
+func (c *WrappedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	if key.Namespace == "" && c.namespace != "" && c.isNamespaced(obj) {
+		key.Namespace = c.namespace
+	}
+	return clt.Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Client
+func (c *WrappedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	return clt.List(ctx, list, opts...)
+}
+
+// Create implements client.Client
+func (c *WrappedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	c.defaultNamespace(obj)
+	if c.userAnnotations {
+		metav1alpha1.TrackCreation(obj, UserSubject(ctx), c.clock.Now())
+	}
+	return c.write(ctx, "create", obj, "", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return clt.Create(ctx, obj, opts...)
+	})
+}
+
+// Update implements client.Client
+func (c *WrappedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	c.defaultNamespace(obj)
+	c.setUpdatedBy(ctx, obj)
+	return c.write(ctx, "update", obj, "", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return clt.Update(ctx, obj, opts...)
+	})
+}
+
+// Patch implements client.Client
+// the updatedBy annotations are added to obj before the patch is computed,
+// so they are only sent for patch types computed from obj such as client.MergeFrom
+func (c *WrappedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	c.defaultNamespace(obj)
+	c.setUpdatedBy(ctx, obj)
+	return c.write(ctx, "patch", obj, "", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return clt.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+// Delete implements client.Client
+func (c *WrappedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	c.defaultNamespace(obj)
+	return c.write(ctx, "delete", obj, "", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return clt.Delete(ctx, obj, opts...)
+	})
+}
+
+// DeleteAllOf implements client.Client
+func (c *WrappedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	clt, err := c.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+	return c.write(ctx, "delete", obj, "", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return clt.DeleteAllOf(ctx, obj, opts...)
+	})
+}
+
+// Status implements client.Client, status writes honor dry-run mode
+// but are not impersonated since the subresource writer is not bound to a context
+func (c *WrappedClient) Status() client.SubResourceWriter {
+	return &wrappedSubResourceWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+// clientFor returns the client impersonating the user in the context if any
+func (c *WrappedClient) clientFor(ctx context.Context) (client.Client, error) {
+	u := ImpersonatedUser(ctx)
+	if c.impersonate == nil || u == nil {
+		return c.Client, nil
+	}
+	clt, err := c.impersonate(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("create client impersonating %q failed: %w", u.GetName(), err)
+	}
+	return clt, nil
+}
+
+// UserSubject returns the subject recorded in createdBy, updatedBy and deletedBy annotations:
+// the impersonated user or the user in the context. Returns nil if not found
+func UserSubject(ctx context.Context) *rbacv1.Subject {
+	if u := ImpersonatedUser(ctx); u != nil && u.GetName() != "" {
+		return subjectFor(u)
+	}
+	if u := User(ctx); u != nil && u.GetName() != "" {
+		return subjectFor(u)
+	}
+	return nil
+}
+
+func (c *WrappedClient) setUpdatedBy(ctx context.Context, obj client.Object) {
+	if c.userAnnotations {
+		metav1alpha1.TrackUpdate(obj, UserSubject(ctx), c.clock.Now())
+	}
+}
+
+func (c *WrappedClient) defaultNamespace(obj client.Object) {
+	if obj.GetNamespace() == "" && c.namespace != "" && c.isNamespaced(obj) {
+		obj.SetNamespace(c.namespace)
+	}
+}
+
+func (c *WrappedClient) isNamespaced(obj client.Object) bool {
+	namespaced, err := c.Client.IsObjectNamespaced(obj)
+	return err == nil && namespaced
+}
+
+// subjectFor converts a user into a rbac subject, service accounts are recognized by their username
+func subjectFor(u user.Info) *rbacv1.Subject {
+	const serviceAccountPrefix = "system:serviceaccount:"
+	if name := u.GetName(); strings.HasPrefix(name, serviceAccountPrefix) {
+		if parts := strings.SplitN(strings.TrimPrefix(name, serviceAccountPrefix), ":", 2); len(parts) == 2 {
+			return &rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: parts[0], Name: parts[1]}
+		}
+	}
+	return &rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: u.GetName()}
+}
+
+type wrappedSubResourceWriter struct {
+	client.SubResourceWriter
+	client *WrappedClient
+}
+
+// Create implements client.SubResourceWriter
+func (w *wrappedSubResourceWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return w.client.write(ctx, "create", obj, "status", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+	})
+}
+
+// Update implements client.SubResourceWriter
+func (w *wrappedSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return w.client.write(ctx, "update", obj, "status", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	})
+}
+
+// Patch implements client.SubResourceWriter
+func (w *wrappedSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return w.client.write(ctx, "patch", obj, "status", func(dryRun bool) error {
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+		return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+const (
+	// DefaultImpersonateClientTTL time impersonating clients are cached for by NewImpersonateClientFunc
+	DefaultImpersonateClientTTL = 10 * time.Minute
+	// DefaultImpersonateClientMaxEntries number of impersonating clients cached by NewImpersonateClientFunc
+	DefaultImpersonateClientMaxEntries = 256
+)
+
+// NewImpersonateClientFunc returns an ImpersonateClientFunc building clients from config
+// impersonating the user. Clients are cached per name, uid, groups and extra of the user,
+// for DefaultImpersonateClientTTL and up to DefaultImpersonateClientMaxEntries unless
+// overridden by cacheOpts
+func NewImpersonateClientFunc(config *rest.Config, options client.Options, cacheOpts ...cache.Option) ImpersonateClientFunc {
+	cacheOpts = append([]cache.Option{
+		cache.WithTTL(DefaultImpersonateClientTTL),
+		cache.WithMaxEntries(DefaultImpersonateClientMaxEntries),
+	}, cacheOpts...)
+	clients := cache.New[string, client.Client](cacheOpts...)
+	return func(ctx context.Context, u user.Info) (client.Client, error) {
+		return clients.GetOrLoad(ctx, impersonationKey(u), func(context.Context) (client.Client, error) {
+			cfg := rest.CopyConfig(config)
+			cfg.Impersonate = rest.ImpersonationConfig{
+				UserName: u.GetName(),
+				UID:      u.GetUID(),
+				Groups:   u.GetGroups(),
+				Extra:    u.GetExtra(),
+			}
+			return client.New(cfg, options)
+		})
+	}
+}
+
+// impersonationKey returns a key identifying everything impersonated for u
+func impersonationKey(u user.Info) string {
+	parts := append([]string{u.GetName(), u.GetUID()}, u.GetGroups()...)
+	extra := u.GetExtra()
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// the separators cannot be part of names, groups or extra values sent as headers
+		parts = append(parts, "\x01"+key+"\x02"+strings.Join(extra[key], "\x02"))
+	}
+	return strings.Join(parts, "\x00")
+}
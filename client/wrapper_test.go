@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/rest"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/AlaudaDevops/pkg/cache"
+	"github.com/AlaudaDevops/pkg/dryrun"
+)
+
+var _ = Describe("WrappedClient", func() {
+	var (
+		ctx     context.Context
+		base    client.Client
+		clt     *WrappedClient
+		now     time.Time
+		cm      *corev1.ConfigMap
+		options []WrappedClientOption
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+		base = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build()
+		now = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		ctx = WithUser(context.Background(), &user.DefaultInfo{Name: "admin"})
+		cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm"}}
+		options = []WrappedClientOption{
+			WithDefaultNamespace("default"),
+			WithWrappedClientClock(clocktesting.NewFakePassiveClock(now)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		clt = NewWrappedClient(base, options...)
+	})
+
+	It("defaults namespace and injects user annotations on create", func() {
+		Expect(clt.Create(ctx, cm)).To(Succeed())
+		Expect(cm.Namespace).To(Equal("default"))
+
+		created := &corev1.ConfigMap{}
+		Expect(clt.Get(ctx, client.ObjectKey{Name: "cm"}, created)).To(Succeed())
+		by, err := metav1alpha1.GetCreatedBy(created)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(by.User.Name).To(Equal("admin"))
+		Expect(created.Annotations).To(HaveKeyWithValue(metav1alpha1.CreatedTimeAnnotationKey, "2024-01-02T03:04:05Z"))
+		Expect(created.Annotations).NotTo(HaveKey(metav1alpha1.UpdatedByAnnotationKey))
+	})
+
+	It("injects time annotations without a user", func() {
+		Expect(clt.Create(context.Background(), cm)).To(Succeed())
+		Expect(cm.Annotations).To(Equal(map[string]string{metav1alpha1.CreatedTimeAnnotationKey: "2024-01-02T03:04:05Z"}))
+
+		Expect(clt.Update(context.Background(), cm)).To(Succeed())
+		Expect(cm.Annotations).To(HaveKeyWithValue(metav1alpha1.UpdatedTimeAnnotationKey, "2024-01-02T03:04:05Z"))
+		Expect(cm.Annotations).NotTo(HaveKey(metav1alpha1.UpdatedByAnnotationKey))
+	})
+
+	It("injects updatedBy on update and patch", func() {
+		Expect(clt.Create(ctx, cm)).To(Succeed())
+
+		ctx = WithImpersonatedUser(ctx, &user.DefaultInfo{Name: "system:serviceaccount:default:builder"})
+		base := cm.DeepCopy()
+		cm.Data = map[string]string{"a": "b"}
+		Expect(clt.Patch(ctx, cm, client.MergeFrom(base))).To(Succeed())
+
+		patched := &corev1.ConfigMap{}
+		Expect(clt.Get(ctx, client.ObjectKey{Name: "cm"}, patched)).To(Succeed())
+		by, err := metav1alpha1.GetUpdatedBy(patched)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(by.User.Kind).To(Equal("ServiceAccount"))
+		Expect(by.User.Namespace).To(Equal("default"))
+		Expect(by.User.Name).To(Equal("builder"))
+
+		createdBy, _ := metav1alpha1.GetCreatedBy(patched)
+		Expect(createdBy.User.Name).To(Equal("admin"))
+	})
+
+	When("dry-run is enabled", func() {
+		BeforeEach(func() {
+			options = append(options, WithDryRun(true))
+		})
+
+		It("does not persist writes", func() {
+			Expect(clt.IsDryRun()).To(BeTrue())
+			Expect(clt.Create(ctx, cm)).To(Succeed())
+			Expect(base.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})).NotTo(Succeed())
+
+			Expect(clt.WithOptions(WithDryRun(false)).Create(ctx, cm)).To(Succeed())
+			Expect(base.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})).To(Succeed())
+
+			Expect(clt.Delete(ctx, cm)).To(Succeed())
+			Expect(base.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})).To(Succeed())
+		})
+	})
+
+	When("a dry-run strategy is in the context", func() {
+		var summary *dryrun.Summary
+
+		BeforeEach(func() {
+			summary = dryrun.NewSummary()
+			ctx = dryrun.WithSummary(ctx, summary)
+		})
+
+		It("skips writes with client dry run", func() {
+			ctx = dryrun.WithStrategy(ctx, dryrun.Client)
+			Expect(clt.Create(ctx, cm)).To(Succeed())
+			Expect(clt.Status().Update(ctx, cm)).To(Succeed())
+			Expect(base.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})).NotTo(Succeed())
+			Expect(summary.Actions()).To(Equal([]dryrun.Action{
+				{Verb: "create", GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"), Namespace: "default", Name: "cm"},
+				{Verb: "update", GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"), Namespace: "default", Name: "cm", SubResource: "status"},
+			}))
+		})
+
+		It("sends writes with server dry run", func() {
+			ctx = dryrun.WithStrategy(ctx, dryrun.Server)
+			Expect(clt.Create(ctx, cm)).To(Succeed())
+			Expect(base.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})).NotTo(Succeed())
+			Expect(summary.Actions()).To(HaveLen(1))
+		})
+
+		It("persists writes without dry run", func() {
+			ctx = dryrun.WithStrategy(ctx, dryrun.None)
+			Expect(clt.Create(ctx, cm)).To(Succeed())
+			Expect(base.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})).To(Succeed())
+			Expect(summary.Actions()).To(BeEmpty())
+		})
+	})
+
+	When("user annotations are disabled", func() {
+		BeforeEach(func() {
+			options = append(options, WithUserAnnotations(false))
+		})
+
+		It("does not inject annotations", func() {
+			Expect(clt.Create(ctx, cm)).To(Succeed())
+			Expect(cm.Annotations).To(BeEmpty())
+		})
+	})
+
+	When("impersonation is configured", func() {
+		var impersonated []string
+
+		BeforeEach(func() {
+			impersonated = nil
+			options = append(options, WithImpersonation(func(_ context.Context, u user.Info) (client.Client, error) {
+				impersonated = append(impersonated, u.GetName())
+				return base, nil
+			}))
+		})
+
+		It("uses the impersonating client only when a user is in the context", func() {
+			Expect(clt.Create(ctx, cm)).To(Succeed())
+			Expect(impersonated).To(BeEmpty())
+
+			ctx = WithImpersonatedUser(ctx, &user.DefaultInfo{Name: "dev"})
+			Expect(clt.Get(ctx, client.ObjectKey{Name: "cm"}, &corev1.ConfigMap{})).To(Succeed())
+			Expect(impersonated).To(Equal([]string{"dev"}))
+		})
+	})
+})
+
+var _ = Describe("NewImpersonateClientFunc", func() {
+	var (
+		ctx         context.Context
+		impersonate ImpersonateClientFunc
+		fakeClock   *clocktesting.FakeClock
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClock = clocktesting.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+		config := &rest.Config{Host: "https://127.0.0.1:6443"}
+		impersonate = NewImpersonateClientFunc(config, client.Options{Mapper: meta.NewDefaultRESTMapper(nil)},
+			cache.WithClock(fakeClock), cache.WithMaxEntries(2))
+	})
+
+	It("caches clients per user including extra", func() {
+		admin := &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}}
+		first, err := impersonate(ctx, admin)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := impersonate(ctx, &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+
+		scoped, err := impersonate(ctx, &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"},
+			Extra: map[string][]string{"scopes": {"read"}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scoped).NotTo(BeIdenticalTo(first))
+		other, err := impersonate(ctx, &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"},
+			Extra: map[string][]string{"scopes": {"write"}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(BeIdenticalTo(scoped))
+
+		// the cache is bounded, admin without extra was evicted by the two scoped users
+		again, err := impersonate(ctx, admin)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).NotTo(BeIdenticalTo(first))
+	})
+
+	It("expires cached clients", func() {
+		first, err := impersonate(ctx, &user.DefaultInfo{Name: "dev"})
+		Expect(err).NotTo(HaveOccurred())
+		fakeClock.Step(DefaultImpersonateClientTTL)
+		second, err := impersonate(ctx, &user.DefaultInfo{Name: "dev"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).NotTo(BeIdenticalTo(first))
+	})
+})
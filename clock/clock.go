@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// PassiveClock allows reading the current time
+type PassiveClock = clock.PassiveClock
+
+// Clock allows reading the current time, waiting and creating timers and tickers
+type Clock = clock.WithTicker
+
+// Timer is a timer created by a Clock
+type Timer = clock.Timer
+
+// Ticker is a ticker created by a Clock
+type Ticker = clock.Ticker
+
+// RealClock is a Clock using the system time
+type RealClock = clock.RealClock
+
+type clockCtxKey struct{}
+
+// WithClock adds a clock to the context
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockCtxKey{}, c)
+}
+
+// FromContext returns the clock in the context, or a RealClock if none is present
+func FromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockCtxKey{}).(Clock); ok && c != nil {
+		return c
+	}
+	return RealClock{}
+}
+
+// Now returns the current time according to the clock in the context
+func Now(ctx context.Context) time.Time {
+	return FromContext(ctx).Now()
+}
+
+// Since returns the time elapsed since t according to the clock in the context
+func Since(ctx context.Context, t time.Time) time.Duration {
+	return FromContext(ctx).Since(t)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+var start = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestFromContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := context.Background()
+	g.Expect(FromContext(ctx)).To(Equal(RealClock{}))
+	g.Expect(Now(ctx)).To(BeTemporally("~", time.Now(), time.Second))
+
+	fake := NewFakeClock(start)
+	ctx = WithClock(ctx, fake)
+	g.Expect(FromContext(ctx)).To(BeIdenticalTo(fake))
+	g.Expect(Now(ctx)).To(Equal(start))
+
+	fake.Advance(time.Minute)
+	g.Expect(Now(ctx)).To(Equal(start.Add(time.Minute)))
+	g.Expect(Since(ctx, start)).To(Equal(time.Minute))
+}
+
+func TestFakeClock(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fake := NewFakeClock(start)
+	timer := fake.NewTimer(time.Minute)
+	ticker := fake.NewTicker(10 * time.Second)
+	after := fake.After(30 * time.Second)
+
+	fake.Advance(10 * time.Second)
+	g.Expect(ticker.C()).To(Receive(Equal(start.Add(10 * time.Second))))
+	g.Expect(after).NotTo(Receive())
+	g.Expect(timer.C()).NotTo(Receive())
+
+	fake.AdvanceTo(start.Add(30 * time.Second))
+	g.Expect(after).To(Receive())
+	g.Expect(timer.C()).NotTo(Receive())
+
+	// time does not go backwards
+	fake.AdvanceTo(start)
+	g.Expect(fake.Now()).To(Equal(start.Add(30 * time.Second)))
+
+	fake.Advance(time.Minute)
+	g.Expect(timer.C()).To(Receive())
+	g.Expect(fake.HasWaiters()).To(BeTrue(), "ticker is still waiting")
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clock provides the clock abstraction used by controllers and webhooks
+// on top of k8s.io/utils/clock, a context carrier for it and a fake clock
+// so time dependent logic like requeue after durations and time annotations
+// can be tested deterministically.
+//
+//	ctx = clock.WithClock(ctx, clock.NewFakeClock(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+//	metav1alpha1.SetCreatedTime(obj, clock.Now(ctx))
+package clock
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"time"
+
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// FakeClock is a Clock whose time only changes when Advance, SetTime or Sleep are called.
+// Timers, tickers and After channels fire once the time is advanced past their deadline.
+type FakeClock struct {
+	*clocktesting.FakeClock
+}
+
+var _ Clock = &FakeClock{}
+
+// NewFakeClock returns a FakeClock starting at t
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{FakeClock: clocktesting.NewFakeClock(t)}
+}
+
+// Advance moves the time forward by d firing any timer or ticker that expires
+func (f *FakeClock) Advance(d time.Duration) {
+	f.Step(d)
+}
+
+// AdvanceTo moves the time forward to t firing any timer or ticker that expires,
+// nothing happens if t is before the current time
+func (f *FakeClock) AdvanceTo(t time.Time) {
+	if d := t.Sub(f.Now()); d > 0 {
+		f.Step(d)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// lockRetryInterval interval between attempts to acquire the lock of a cache entry
+	lockRetryInterval = 50 * time.Millisecond
+	// lockStaleAfter age after which a lock file is considered left by a crashed process
+	lockStaleAfter = time.Minute
+)
+
+// Cache stores tokens on disk, one file per provider readable only by the user.
+// Concurrent processes, e.g. kubectl running the exec credential provider in parallel,
+// serialize their access to an entry using Lock so refresh tokens are not used twice
+type Cache struct {
+	// Dir holding the cached tokens
+	Dir string
+}
+
+// DefaultCacheDir returns the default directory caching the tokens of the cli name,
+// ~/.kube/cache/<name>/oidc
+func DefaultCacheDir(name string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.TempDir()
+	}
+	return filepath.Join(home, ".kube", "cache", name, "oidc")
+}
+
+// Key returns the cache key of the tokens of the provider
+func (p *Provider) Key() string {
+	hash := sha256.New()
+	for _, value := range []string{p.Issuer, p.ClientID, strings.Join(p.scopes(), " ")} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// path returns the path of the file of the key
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Load returns the cached token of key, nil without error if there is none
+func (c *Cache) Load(key string) (*Token, error) {
+	content, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read token cache: %w", err)
+	}
+	token := &Token{}
+	if err = json.Unmarshal(content, token); err != nil {
+		// a corrupted cache only requires logging in again
+		return nil, nil
+	}
+	return token, nil
+}
+
+// Save writes the token of key atomically
+func (c *Cache) Save(key string, token *Token) error {
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return fmt.Errorf("create token cache directory: %w", err)
+	}
+	content, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write token cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write token cache: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write token cache: %w", err)
+	}
+	if err = os.Chmod(tmp.Name(), 0o600); err != nil {
+		return fmt.Errorf("write token cache: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("write token cache: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the cached token of key
+func (c *Cache) Delete(key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete token cache: %w", err)
+	}
+	return nil
+}
+
+// Lock acquires the lock of key waiting until it is released or ctx is done,
+// the returned function releases it. Locks older than a minute are considered
+// stale, left by a process which crashed, and are taken over
+func (c *Cache) Lock(ctx context.Context, key string) (unlock func(), err error) {
+	if err = os.MkdirAll(c.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create token cache directory: %w", err)
+	}
+	lockPath := c.path(key) + ".lock"
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, _ = fmt.Fprintf(file, "%d", os.Getpid())
+			file.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock token cache: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > lockStaleAfter {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lock token cache: %w", ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
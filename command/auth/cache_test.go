@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCache(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cache := &Cache{Dir: filepath.Join(t.TempDir(), "oidc")}
+
+	token, err := cache.Load("key")
+	g.Expect(err).To(BeNil())
+	g.Expect(token).To(BeNil())
+
+	saved := &Token{IDToken: "id", RefreshToken: "refresh", Expiry: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g.Expect(cache.Save("key", saved)).To(Succeed())
+	info, err := os.Stat(filepath.Join(cache.Dir, "key.json"))
+	g.Expect(err).To(BeNil())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+	token, err = cache.Load("key")
+	g.Expect(err).To(BeNil())
+	g.Expect(token).To(Equal(saved))
+
+	g.Expect(os.WriteFile(filepath.Join(cache.Dir, "corrupted.json"), []byte("{"), 0o600)).To(Succeed())
+	token, err = cache.Load("corrupted")
+	g.Expect(err).To(BeNil())
+	g.Expect(token).To(BeNil())
+
+	g.Expect(cache.Delete("key")).To(Succeed())
+	g.Expect(cache.Delete("key")).To(Succeed())
+	token, err = cache.Load("key")
+	g.Expect(err).To(BeNil())
+	g.Expect(token).To(BeNil())
+}
+
+func TestCache_Lock(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cache := &Cache{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	unlock, err := cache.Lock(ctx, "key")
+	g.Expect(err).To(BeNil())
+
+	// a second lock waits until the first is released
+	timeout, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	_, err = cache.Lock(timeout, "key")
+	g.Expect(err).To(MatchError(ContainSubstring(context.DeadlineExceeded.Error())))
+
+	acquired := make(chan func())
+	go func() {
+		unlock, _ := cache.Lock(ctx, "key")
+		acquired <- unlock
+	}()
+	g.Consistently(acquired, 100*time.Millisecond).ShouldNot(Receive())
+	unlock()
+	var second func()
+	g.Eventually(acquired).Should(Receive(&second))
+	second()
+
+	// stale locks left by crashed processes are taken over
+	lockPath := filepath.Join(cache.Dir, "stale.json.lock")
+	g.Expect(os.WriteFile(lockPath, []byte("1"), 0o600)).To(Succeed())
+	old := time.Now().Add(-2 * lockStaleAfter)
+	g.Expect(os.Chtimes(lockPath, old, old)).To(Succeed())
+	unlock, err = cache.Lock(ctx, "stale")
+	g.Expect(err).To(BeNil())
+	unlock()
+	g.Expect(lockPath).NotTo(BeAnExistingFile())
+}
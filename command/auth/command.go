@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+// Options options of the auth command
+type Options struct {
+	Provider
+	// Flow used by login, one of device-code or auth-code, defaults to device-code when supported
+	Flow string
+	// ListenAddress of the local server of the auth-code flow, defaults to DefaultListenAddress
+	ListenAddress string
+	// NoBrowser only prints the login url instead of opening a browser
+	NoBrowser bool
+	// CacheDir directory caching the tokens, defaults to DefaultCacheDir
+	CacheDir string
+	// KubeconfigUser name of a kubeconfig user set up by login to use get-token as exec credential provider
+	KubeconfigUser string
+}
+
+// AddFlags add the provider and cache flags to the flag set
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Issuer, "issuer", o.Issuer, "issuer url of the OpenID Connect provider")
+	flags.StringVar(&o.ClientID, "client-id", o.ClientID, "client id registered in the provider")
+	flags.StringVar(&o.ClientSecret, "client-secret", o.ClientSecret, "client secret, empty for public clients")
+	flags.StringSliceVar(&o.Scopes, "scopes", o.Scopes, fmt.Sprintf("scopes to request, defaults to %s", strings.Join(DefaultScopes, ",")))
+	flags.StringVar(&o.CacheDir, "cache-dir", o.CacheDir, "directory caching the tokens")
+}
+
+// addLoginFlags add the flags of the interactive login to the flag set
+func (o *Options) addLoginFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Flow, "flow", o.Flow, fmt.Sprintf("login flow, one of %s or %s, defaults to %s when supported by the provider", FlowDeviceCode, FlowAuthCode, FlowDeviceCode))
+	flags.StringVar(&o.ListenAddress, "listen-address", o.ListenAddress, fmt.Sprintf("address of the local server receiving the authorization code, defaults to %s", DefaultListenAddress))
+	flags.BoolVar(&o.NoBrowser, "no-browser", o.NoBrowser, "only print the login url instead of opening a browser")
+}
+
+// NewCommand returns a SubcommandFunc of the auth subcommand with the subcommands
+//   - login: logs in the provider and caches the token, optionally setting up a kubeconfig user
+//   - get-token: prints the token as an ExecCredential, used as kubeconfig exec credential provider
+//   - logout: removes the cached token
+//
+// e.g.
+//
+//	root.NewRootCommand(ctx, "mycli", auth.NewCommand(&auth.Options{Provider: auth.Provider{Issuer: issuer, ClientID: "mycli"}}))
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "auth",
+			Short: "Login using OpenID Connect and provide tokens to kubectl",
+		}
+		opts.AddFlags(cmd.PersistentFlags())
+		cmd.AddCommand(
+			opts.loginCommand(ctx, name),
+			opts.getTokenCommand(ctx, name),
+			opts.logoutCommand(ctx, name),
+		)
+		return cmd
+	}
+}
+
+func (o *Options) loginCommand(ctx context.Context, name string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Login using OpenID Connect and cache the token",
+		Long: fmt.Sprintf(`Login using OpenID Connect and cache the token.
+
+With --kubeconfig-user a user running "%s auth get-token" is written into the
+kubeconfig so kubectl uses the cached token, refreshing it when needed.`, name),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			streams := cliio.MustGetIOStreams(ctx)
+			token, err := o.login(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err = SaveToken(cmd.Context(), &o.Provider, o.cache(name), token); err != nil {
+				return err
+			}
+			fmt.Fprintln(streams.ErrOut, "Logged in")
+			if o.KubeconfigUser == "" {
+				return nil
+			}
+			if err = o.setupKubeconfig(ctx, name); err != nil {
+				return err
+			}
+			fmt.Fprintf(streams.ErrOut, "Kubeconfig user %s set up\n", o.KubeconfigUser)
+			return nil
+		},
+	}
+	o.addLoginFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.KubeconfigUser, "kubeconfig-user", o.KubeconfigUser, "name of a kubeconfig user to set up with the exec credential provider")
+	return cmd
+}
+
+func (o *Options) getTokenCommand(ctx context.Context, name string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-token",
+		Short: "Print the cached token as an ExecCredential, logging in when required",
+		Long: `Print the cached token as a client.authentication.k8s.io/v1 ExecCredential.
+
+The token is refreshed when it expires and an interactive login is started
+when there is no valid token, instructions are printed into stderr.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache := o.cache(name)
+			token, err := GetToken(cmd.Context(), &o.Provider, cache)
+			if errors.Is(err, ErrLoginRequired) {
+				if token, err = o.login(cmd.Context()); err == nil {
+					err = SaveToken(cmd.Context(), &o.Provider, cache, token)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return json.NewEncoder(cliio.MustGetIOStreams(ctx).Out).Encode(ExecCredential(token))
+		},
+	}
+	o.addLoginFlags(cmd.Flags())
+	return cmd
+}
+
+func (o *Options) logoutCommand(ctx context.Context, name string) *cobra.Command {
+	return &cobra.Command{
+		Use:          "logout",
+		Short:        "Remove the cached token",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.cache(name).Delete(o.Key()); err != nil {
+				return err
+			}
+			fmt.Fprintln(cliio.MustGetIOStreams(ctx).ErrOut, "Logged out")
+			return nil
+		},
+	}
+}
+
+// login logs in interactively writing the instructions into the error stream
+func (o *Options) login(ctx context.Context) (*Token, error) {
+	opts := LoginOptions{
+		Flow:          Flow(o.Flow),
+		ListenAddress: o.ListenAddress,
+		Out:           cliio.MustGetIOStreams(ctx).ErrOut,
+	}
+	if !o.NoBrowser {
+		opts.OpenBrowser = OpenBrowser
+	}
+	return Login(ctx, &o.Provider, opts)
+}
+
+// cache returns the token cache
+func (o *Options) cache(name string) *Cache {
+	dir := o.CacheDir
+	if dir == "" {
+		dir = DefaultCacheDir(name)
+	}
+	return &Cache{Dir: dir}
+}
+
+// getTokenArgs returns the arguments of the get-token subcommand using the same provider and cache
+func (o *Options) getTokenArgs() []string {
+	args := []string{"auth", "get-token", "--issuer", o.Issuer, "--client-id", o.ClientID}
+	if o.ClientSecret != "" {
+		args = append(args, "--client-secret", o.ClientSecret)
+	}
+	if len(o.Scopes) > 0 {
+		args = append(args, "--scopes", strings.Join(o.Scopes, ","))
+	}
+	if o.CacheDir != "" {
+		args = append(args, "--cache-dir", o.CacheDir)
+	}
+	if o.Flow != "" {
+		args = append(args, "--flow", o.Flow)
+	}
+	if o.ListenAddress != "" {
+		args = append(args, "--listen-address", o.ListenAddress)
+	}
+	return args
+}
+
+// setupKubeconfig writes the kubeconfig user running get-token, the kubeconfig
+// of the --kubeconfig flag is used when the kubeflags are in the context
+func (o *Options) setupKubeconfig(ctx context.Context, name string) error {
+	command, err := os.Executable()
+	if err != nil {
+		command = name
+	}
+	var access clientcmd.ConfigAccess = clientcmd.NewDefaultPathOptions()
+	if flags := kubeflags.GetKubeFlags(ctx); flags != nil {
+		access = flags.ToRawKubeConfigLoader().ConfigAccess()
+	}
+	config, err := access.GetStartingConfig()
+	if err != nil {
+		return fmt.Errorf("load kubeconfig: %w", err)
+	}
+	if config.AuthInfos == nil {
+		config.AuthInfos = map[string]*clientcmdapi.AuthInfo{}
+	}
+	config.AuthInfos[o.KubeconfigUser] = KubeconfigUser(command, o.getTokenArgs()...)
+	if err = clientcmd.ModifyConfig(access, *config, false); err != nil {
+		return fmt.Errorf("write kubeconfig: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+func TestNewCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := newFakeProvider(t)
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	g.Expect(os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\n"), 0o600)).To(Succeed())
+
+	run := func(args ...string) (string, string, error) {
+		streams, _, out, errOut := clioptions.NewTestIOStreams()
+		ctx := cliio.WithIOStreams(context.Background(), &streams)
+		ctx = kubeflags.WithKubeFlags(ctx, kubeflags.NewKubeFlags())
+		opts := &Options{Provider: Provider{Issuer: fake.URL, ClientID: "cli"}, CacheDir: filepath.Join(dir, "cache")}
+		cmd := root.NewRootCommand(ctx, "test-cli", NewCommand(opts))
+		cmd.SetArgs(append([]string{"--kubeconfig", kubeconfig}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return out.String(), errOut.String(), err
+	}
+
+	_, errOut, err := run("auth", "login", "--no-browser", "--kubeconfig-user", "oidc")
+	g.Expect(err).To(BeNil())
+	g.Expect(errOut).To(ContainSubstring("enter the code ABCD-EFGH"))
+	g.Expect(errOut).To(ContainSubstring("Kubeconfig user oidc set up"))
+
+	config, err := clientcmd.LoadFromFile(kubeconfig)
+	g.Expect(err).To(BeNil())
+	g.Expect(config.AuthInfos).To(HaveKey("oidc"))
+	g.Expect(config.AuthInfos["oidc"].Exec.Args).To(Equal([]string{
+		"auth", "get-token", "--issuer", fake.URL, "--client-id", "cli", "--cache-dir", filepath.Join(dir, "cache"),
+	}))
+
+	out, _, err := run("auth", "get-token")
+	g.Expect(err).To(BeNil())
+	credential := &clientauthenticationv1.ExecCredential{}
+	g.Expect(json.Unmarshal([]byte(out), credential)).To(Succeed())
+	g.Expect(credential.Kind).To(Equal("ExecCredential"))
+	g.Expect(credential.Status.Token).NotTo(BeEmpty())
+
+	_, errOut, err = run("auth", "logout")
+	g.Expect(err).To(BeNil())
+	g.Expect(errOut).To(Equal("Logged out\n"))
+	token, err := (&Cache{Dir: filepath.Join(dir, "cache")}).Load((&Provider{Issuer: fake.URL, ClientID: "cli"}).Key())
+	g.Expect(err).To(BeNil())
+	g.Expect(token).To(BeNil())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// GetToken returns the cached token of the provider, refreshing it when it expires soon.
+// Returns ErrLoginRequired when there is no token or it cannot be refreshed.
+// The cache entry is locked so concurrent processes do not refresh the token twice
+func GetToken(ctx context.Context, provider *Provider, cache *Cache) (*Token, error) {
+	key := provider.Key()
+	unlock, err := cache.Lock(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	token, err := cache.Load(key)
+	if err != nil {
+		return nil, err
+	}
+	if token.Valid(time.Now()) {
+		return token, nil
+	}
+	if token == nil || token.RefreshToken == "" {
+		return nil, ErrLoginRequired
+	}
+
+	config, _, err := provider.oauth2Config(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	refreshed, err := config.TokenSource(provider.withHTTPClient(ctx), token.oauth2Token()).Token()
+	if err != nil {
+		retrieveErr := &oauth2.RetrieveError{}
+		if errors.As(err, &retrieveErr) {
+			// the refresh token expired or was revoked
+			return nil, fmt.Errorf("%w: refresh token rejected: %s", ErrLoginRequired, retrieveErr.ErrorCode)
+		}
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
+	if token, err = tokenFromOAuth2(refreshed, token); err != nil {
+		return nil, err
+	}
+	if err = cache.Save(key, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// SaveToken stores the token of the provider, e.g. after Login
+func SaveToken(ctx context.Context, provider *Provider, cache *Cache, token *Token) error {
+	key := provider.Key()
+	unlock, err := cache.Lock(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return cache.Save(key, token)
+}
+
+// ExecCredential returns the client.authentication.k8s.io/v1 ExecCredential
+// sending the ID token to the API server, printed by exec credential providers
+func ExecCredential(token *Token) *clientauthenticationv1.ExecCredential {
+	return &clientauthenticationv1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clientauthenticationv1.SchemeGroupVersion.String(),
+			Kind:       "ExecCredential",
+		},
+		Status: &clientauthenticationv1.ExecCredentialStatus{
+			Token:               token.IDToken,
+			ExpirationTimestamp: &metav1.Time{Time: token.Expiry},
+		},
+	}
+}
+
+// KubeconfigUser returns a kubeconfig user running command with args as exec credential provider,
+// so kubectl and client-go obtain tokens the same way the cli does
+func KubeconfigUser(command string, args ...string) *clientcmdapi.AuthInfo {
+	return &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion:      clientauthenticationv1.SchemeGroupVersion.String(),
+			Command:         command,
+			Args:            args,
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		},
+	}
+}
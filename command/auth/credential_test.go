@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGetToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := newFakeProvider(t)
+	provider := fake.provider()
+	cache := &Cache{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	_, err := GetToken(ctx, provider, cache)
+	g.Expect(errors.Is(err, ErrLoginRequired)).To(BeTrue())
+
+	valid := &Token{IDToken: idToken(time.Now().Add(time.Hour)), RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)}
+	g.Expect(SaveToken(ctx, provider, cache, valid)).To(Succeed())
+	token, err := GetToken(ctx, provider, cache)
+	g.Expect(err).To(BeNil())
+	g.Expect(token.IDToken).To(Equal(valid.IDToken))
+	g.Expect(fake.refreshes).To(Equal(0))
+
+	// expired tokens are refreshed and saved, keeping the refresh token
+	expired := &Token{IDToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)}
+	g.Expect(SaveToken(ctx, provider, cache, expired)).To(Succeed())
+	token, err = GetToken(ctx, provider, cache)
+	g.Expect(err).To(BeNil())
+	g.Expect(token.IDToken).NotTo(Equal("expired"))
+	g.Expect(token.RefreshToken).To(Equal("refresh"))
+	g.Expect(fake.refreshes).To(Equal(1))
+	g.Expect(fake.lastScopes).To(BeEmpty())
+	cached, err := cache.Load(provider.Key())
+	g.Expect(err).To(BeNil())
+	g.Expect(cached.IDToken).To(Equal(token.IDToken))
+	g.Expect(cached.Expiry.Equal(token.Expiry)).To(BeTrue())
+
+	// rejected refresh tokens require logging in again
+	revoked := &Token{IDToken: "expired", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Minute)}
+	g.Expect(SaveToken(ctx, provider, cache, revoked)).To(Succeed())
+	_, err = GetToken(ctx, provider, cache)
+	g.Expect(errors.Is(err, ErrLoginRequired)).To(BeTrue())
+	g.Expect(err).To(MatchError(ContainSubstring("invalid_grant")))
+
+	// expired tokens without refresh token require logging in again
+	g.Expect(SaveToken(ctx, provider, cache, &Token{IDToken: "expired"})).To(Succeed())
+	_, err = GetToken(ctx, provider, cache)
+	g.Expect(err).To(Equal(ErrLoginRequired))
+}
+
+func TestExecCredential(t *testing.T) {
+	g := NewGomegaWithT(t)
+	expiry := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	content, err := json.Marshal(ExecCredential(&Token{IDToken: "id", Expiry: expiry}))
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal(`{"kind":"ExecCredential","apiVersion":"client.authentication.k8s.io/v1","spec":{"interactive":false},"status":{"expirationTimestamp":"2025-01-01T00:00:00Z","token":"id"}}`))
+
+	user := KubeconfigUser("/usr/local/bin/cli", "auth", "get-token")
+	g.Expect(user.Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1"))
+	g.Expect(user.Exec.Command).To(Equal("/usr/local/bin/cli"))
+	g.Expect(user.Exec.Args).To(Equal([]string{"auth", "get-token"}))
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auth logs clis in an OpenID Connect provider and provides the
+// resulting tokens to kubectl and client-go.
+//
+// Login supports the device code flow, showing a code to enter in a browser,
+// and the authorization code flow with PKCE, receiving the code in a local
+// server. Tokens are cached on disk by Cache, locking each entry so parallel
+// kubectl invocations do not refresh the same token twice. GetToken returns the
+// cached token refreshing it when it expires.
+//
+// NewCommand adds an auth subcommand with login, get-token and logout.
+// get-token prints an ExecCredential so the cli can be used as a kubeconfig
+// exec credential provider, login --kubeconfig-user sets it up:
+//
+//	users:
+//	- name: oidc
+//	  user:
+//	    exec:
+//	      apiVersion: client.authentication.k8s.io/v1
+//	      command: /usr/local/bin/mycli
+//	      args: [auth, get-token, --issuer, https://dex.example.com, --client-id, mycli]
+package auth
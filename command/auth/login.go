@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/AlaudaDevops/pkg/command/exec"
+)
+
+// Flow is the OAuth2 flow used to login
+type Flow string
+
+const (
+	// FlowAuto uses the device code flow when the provider supports it, the authorization code flow otherwise
+	FlowAuto Flow = ""
+	// FlowDeviceCode shows a code to enter in a browser, works without a local browser e.g. over ssh
+	FlowDeviceCode Flow = "device-code"
+	// FlowAuthCode opens a browser redirecting to a local server, the redirect url must be registered in the provider
+	FlowAuthCode Flow = "auth-code"
+)
+
+// DefaultListenAddress address of the local server receiving the authorization code,
+// the redirect url http://localhost:8000 must be allowed for the client
+const DefaultListenAddress = "localhost:8000"
+
+// LoginOptions options of Login
+type LoginOptions struct {
+	// Flow to use, defaults to FlowAuto
+	Flow Flow
+	// ListenAddress of the local server of the authorization code flow, defaults to DefaultListenAddress
+	ListenAddress string
+	// OpenBrowser opens url in a browser, when nil the url is only printed
+	OpenBrowser func(ctx context.Context, url string) error
+	// Out receives the instructions for the user, defaults to io.Discard
+	Out io.Writer
+}
+
+// Login logs in the provider interactively and returns the issued token
+func Login(ctx context.Context, provider *Provider, opts LoginOptions) (*Token, error) {
+	if opts.Out == nil {
+		opts.Out = io.Discard
+	}
+	if opts.ListenAddress == "" {
+		opts.ListenAddress = DefaultListenAddress
+	}
+	ctx = provider.withHTTPClient(ctx)
+
+	flow := opts.Flow
+	switch flow {
+	case FlowAuto, FlowDeviceCode, FlowAuthCode:
+	default:
+		return nil, fmt.Errorf("unsupported login flow %q, use %s or %s", flow, FlowDeviceCode, FlowAuthCode)
+	}
+	config, doc, err := provider.oauth2Config(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if flow == FlowAuto {
+		flow = FlowAuthCode
+		if doc.DeviceAuthorizationEndpoint != "" {
+			flow = FlowDeviceCode
+		}
+	}
+
+	var token *oauth2.Token
+	if flow == FlowDeviceCode {
+		if doc.DeviceAuthorizationEndpoint == "" {
+			return nil, fmt.Errorf("the provider %s does not support the device code flow, use %s", provider.Issuer, FlowAuthCode)
+		}
+		token, err = deviceCodeLogin(ctx, config, opts)
+	} else {
+		if doc.AuthorizationEndpoint == "" {
+			return nil, fmt.Errorf("the provider %s has no authorization_endpoint", provider.Issuer)
+		}
+		token, err = authCodeLogin(ctx, config, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tokenFromOAuth2(token, nil)
+}
+
+// deviceCodeLogin shows the verification url and code and waits for the user to enter it
+func deviceCodeLogin(ctx context.Context, config *oauth2.Config, opts LoginOptions) (*oauth2.Token, error) {
+	device, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("request device code: %w", err)
+	}
+	if device.VerificationURIComplete != "" {
+		fmt.Fprintf(opts.Out, "Open %s in a browser and confirm the code %s\n", device.VerificationURIComplete, device.UserCode)
+		openBrowser(ctx, opts, device.VerificationURIComplete)
+	} else {
+		fmt.Fprintf(opts.Out, "Open %s in a browser and enter the code %s\n", device.VerificationURI, device.UserCode)
+		openBrowser(ctx, opts, device.VerificationURI)
+	}
+	token, err := config.DeviceAccessToken(ctx, device)
+	if err != nil {
+		return nil, fmt.Errorf("wait for device authorization: %w", err)
+	}
+	return token, nil
+}
+
+// callbackResult is the result of the redirect to the local server
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// authCodeLogin opens the authorization url and receives the code in a local server, using PKCE
+func authCodeLogin(ctx context.Context, config *oauth2.Config, opts LoginOptions) (*oauth2.Token, error) {
+	host, _, err := net.SplitHostPort(opts.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listen address %q: %w", opts.ListenAddress, err)
+	}
+	listener, err := net.Listen("tcp", opts.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("listen for the authorization code: %w", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	config.RedirectURL = fmt.Sprintf("http://%s", net.JoinHostPort(host, fmt.Sprint(port)))
+
+	state := oauth2.GenerateVerifier()
+	verifier := oauth2.GenerateVerifier()
+	results := make(chan callbackResult, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			result := callbackResult{code: query.Get("code")}
+			switch {
+			case query.Get("state") != state:
+				http.Error(w, "invalid state", http.StatusBadRequest)
+				return
+			case query.Get("error") != "":
+				result.err = fmt.Errorf("authorization failed: %s %s", query.Get("error"), query.Get("error_description"))
+			case result.code == "":
+				result.err = fmt.Errorf("authorization failed: no code in the redirect")
+			}
+			message := "Logged in, you can close this window."
+			if result.err != nil {
+				message = result.err.Error()
+			}
+			fmt.Fprintf(w, "<html><body><p>%s</p></body></html>", html.EscapeString(message))
+			select {
+			case results <- result:
+			default:
+			}
+		}),
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			results <- callbackResult{err: err}
+		}
+	}()
+	defer server.Close()
+
+	authURL := config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+	fmt.Fprintf(opts.Out, "Open %s in a browser to login\n", authURL)
+	openBrowser(ctx, opts, authURL)
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for authorization: %w", ctx.Err())
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		token, err := config.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			return nil, fmt.Errorf("exchange authorization code: %w", err)
+		}
+		return token, nil
+	}
+}
+
+// openBrowser opens url using OpenBrowser when set, failures only leave the printed url
+func openBrowser(ctx context.Context, opts LoginOptions, url string) {
+	if opts.OpenBrowser == nil {
+		return
+	}
+	if err := opts.OpenBrowser(ctx, url); err != nil {
+		fmt.Fprintf(opts.Out, "Unable to open a browser: %v\n", err)
+	}
+}
+
+// OpenBrowser opens url in the default browser of the system
+func OpenBrowser(ctx context.Context, url string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.CommandContext(ctx, "open", url).Run()
+	case "windows":
+		return exec.CommandContext(ctx, "rundll32", "url.dll,FileProtocolHandler", url).Run()
+	default:
+		return exec.CommandContext(ctx, "xdg-open", url).Run()
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLogin(t *testing.T) {
+	fake := newFakeProvider(t)
+	ctx := context.Background()
+
+	t.Run("device code flow by default", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		out := &bytes.Buffer{}
+		opened := ""
+		token, err := Login(ctx, fake.provider(), LoginOptions{Out: out, OpenBrowser: func(_ context.Context, url string) error {
+			opened = url
+			return nil
+		}})
+		g.Expect(err).To(BeNil())
+		g.Expect(token.IDToken).NotTo(BeEmpty())
+		g.Expect(token.RefreshToken).To(Equal("refresh"))
+		g.Expect(out.String()).To(Equal("Open " + fake.URL + "/verify in a browser and enter the code ABCD-EFGH\n"))
+		g.Expect(opened).To(Equal(fake.URL + "/verify"))
+	})
+
+	t.Run("authorization code flow with pkce", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		out := &bytes.Buffer{}
+		token, err := Login(ctx, fake.provider(), LoginOptions{
+			Flow:          FlowAuthCode,
+			ListenAddress: "127.0.0.1:0",
+			Out:           out,
+			// the browser follows the redirect of the provider to the local server
+			OpenBrowser: func(_ context.Context, url string) error {
+				resp, err := http.Get(url)
+				if err == nil {
+					resp.Body.Close()
+				}
+				return err
+			},
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(token.IDToken).NotTo(BeEmpty())
+		g.Expect(out.String()).To(ContainSubstring("Open " + fake.URL + "/authorize?"))
+		g.Expect(out.String()).To(ContainSubstring("code_challenge_method=S256"))
+	})
+
+	t.Run("device code flow not supported", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		fake := newFakeProvider(t)
+		fake.noDevice = true
+		_, err := Login(ctx, fake.provider(), LoginOptions{Flow: FlowDeviceCode})
+		g.Expect(err).To(MatchError(ContainSubstring("does not support the device code flow")))
+	})
+
+	t.Run("unsupported flow", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		_, err := Login(ctx, fake.provider(), LoginOptions{Flow: "password"})
+		g.Expect(err).To(MatchError(ContainSubstring("unsupported login flow \"password\"")))
+	})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// DiscoveryPath path of the OpenID Connect discovery document relative to the issuer
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// DefaultScopes scopes requested when none are given, offline_access allows refreshing tokens
+var DefaultScopes = []string{"openid", "offline_access", "email", "profile"}
+
+// Provider is an OpenID Connect provider and the client registered in it
+type Provider struct {
+	// Issuer url of the provider, e.g. https://dex.example.com
+	Issuer string
+	// ClientID of the client registered in the provider
+	ClientID string
+	// ClientSecret of the client, empty for public clients
+	ClientSecret string
+	// Scopes to request, defaults to DefaultScopes
+	Scopes []string
+	// HTTPClient used for the provider requests, defaults to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// discovery is the subset of the discovery document used for logging in
+type discovery struct {
+	Issuer                      string `json:"issuer"`
+	AuthorizationEndpoint       string `json:"authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// Validate returns an error if the issuer or client id is missing
+func (p *Provider) Validate() error {
+	switch {
+	case p.Issuer == "":
+		return fmt.Errorf("an issuer is required, use --issuer")
+	case p.ClientID == "":
+		return fmt.Errorf("a client id is required, use --client-id")
+	}
+	return nil
+}
+
+// scopes returns the scopes to request
+func (p *Provider) scopes() []string {
+	if len(p.Scopes) == 0 {
+		return DefaultScopes
+	}
+	return p.Scopes
+}
+
+// withHTTPClient returns a context using the http client of the provider for oauth2 requests
+func (p *Provider) withHTTPClient(ctx context.Context) context.Context {
+	if p.HTTPClient == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, p.HTTPClient)
+}
+
+// discover requests the discovery document of the issuer
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	issuer := strings.TrimSuffix(p.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+DiscoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	clt := p.HTTPClient
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+	resp, err := clt.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discover openid provider %s: %w", issuer, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discover openid provider %s: unexpected status %s", issuer, resp.Status)
+	}
+	doc := &discovery{}
+	if err = json.NewDecoder(resp.Body).Decode(doc); err != nil {
+		return nil, fmt.Errorf("decode discovery document of %s: %w", issuer, err)
+	}
+	if doc.TokenEndpoint == "" {
+		return nil, fmt.Errorf("discovery document of %s has no token_endpoint", issuer)
+	}
+	return doc, nil
+}
+
+// oauth2Config returns the oauth2 configuration of the provider using its discovery document
+func (p *Provider) oauth2Config(ctx context.Context, redirectURL string) (*oauth2.Config, *discovery, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &oauth2.Config{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		Scopes:       p.scopes(),
+		RedirectURL:  redirectURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:       doc.AuthorizationEndpoint,
+			TokenURL:      doc.TokenEndpoint,
+			DeviceAuthURL: doc.DeviceAuthorizationEndpoint,
+		},
+	}
+	return config, doc, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// idToken returns an unsigned id token expiring at exp
+func idToken(exp time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user","exp":%d}`, exp.Unix())))
+	return header + "." + payload + ".signature"
+}
+
+// fakeProvider is an OpenID Connect provider supporting the device code,
+// authorization code and refresh token grants
+type fakeProvider struct {
+	*httptest.Server
+	noDevice bool
+
+	lock       sync.Mutex
+	challenge  string
+	refreshes  int
+	lastScopes string
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	p := &fakeProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		doc := discovery{
+			Issuer:                p.URL,
+			AuthorizationEndpoint: p.URL + "/authorize",
+			TokenEndpoint:         p.URL + "/token",
+		}
+		if !p.noDevice {
+			doc.DeviceAuthorizationEndpoint = p.URL + "/device"
+		}
+		_ = json.NewEncoder(w).Encode(doc)
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"device_code":"device","user_code":"ABCD-EFGH","verification_uri":"%s/verify","interval":1,"expires_in":60}`, p.URL)
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		p.lock.Lock()
+		p.challenge = query.Get("code_challenge")
+		p.lock.Unlock()
+		redirect, _ := url.Parse(query.Get("redirect_uri"))
+		redirect.RawQuery = url.Values{"code": {"code"}, "state": {query.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.lastScopes = r.Form.Get("scope")
+		w.Header().Set("Content-Type", "application/json")
+		refreshToken := "refresh"
+		switch r.Form.Get("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+		case "authorization_code":
+			verifier := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if r.Form.Get("code") != "code" || base64.RawURLEncoding.EncodeToString(verifier[:]) != p.challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant"}`)
+				return
+			}
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != "refresh" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant"}`)
+				return
+			}
+			p.refreshes++
+			// the refresh token is not rotated
+			refreshToken = ""
+		}
+		fmt.Fprintf(w, `{"access_token":"access","token_type":"Bearer","expires_in":3600,"refresh_token":%q,"id_token":%q}`,
+			refreshToken, idToken(time.Now().Add(time.Hour)))
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *fakeProvider) provider() *Provider {
+	return &Provider{Issuer: p.URL + "/", ClientID: "cli"}
+}
+
+func TestProvider(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect((&Provider{}).Validate()).To(MatchError(ContainSubstring("an issuer is required")))
+	g.Expect((&Provider{Issuer: "https://issuer"}).Validate()).To(MatchError(ContainSubstring("a client id is required")))
+
+	provider := &Provider{Issuer: "https://issuer", ClientID: "cli"}
+	g.Expect(provider.Key()).To(HaveLen(64))
+	g.Expect(provider.Key()).NotTo(Equal((&Provider{Issuer: "https://issuer", ClientID: "cli", Scopes: []string{"openid"}}).Key()))
+
+	fake := newFakeProvider(t)
+	config, doc, err := fake.provider().oauth2Config(context.Background(), "")
+	g.Expect(err).To(BeNil())
+	g.Expect(doc.DeviceAuthorizationEndpoint).To(Equal(fake.URL + "/device"))
+	g.Expect(config.Scopes).To(Equal(DefaultScopes))
+
+	_, _, err = (&Provider{Issuer: fake.URL + "/missing", ClientID: "cli"}).oauth2Config(context.Background(), "")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status 404")))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ExpirySkew is subtracted from the expiry of tokens so they are refreshed before the API server rejects them
+const ExpirySkew = 30 * time.Second
+
+// ErrLoginRequired is returned when there is no valid or refreshable token, login must be run again
+var ErrLoginRequired = errors.New("login required")
+
+// Token is the result of a login, the ID token is sent to the API server as a bearer token
+type Token struct {
+	// IDToken issued by the provider
+	IDToken string `json:"id_token"`
+	// AccessToken issued by the provider
+	AccessToken string `json:"access_token,omitempty"`
+	// RefreshToken used to renew the ID token without logging in again
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Expiry of the ID token
+	Expiry time.Time `json:"expiry"`
+}
+
+// Valid returns true if the token has an ID token which does not expire soon
+func (t *Token) Valid(now time.Time) bool {
+	return t != nil && t.IDToken != "" && now.Add(ExpirySkew).Before(t.Expiry)
+}
+
+// oauth2Token returns the oauth2 token used for refreshing,
+// it is always expired so the token source refreshes it
+func (t *Token) oauth2Token() *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		Expiry:       time.Unix(1, 0),
+	}
+}
+
+// tokenFromOAuth2 returns a Token from the token endpoint response, which must include an ID token.
+// The refresh token is kept from previous when the provider does not rotate it
+func tokenFromOAuth2(token *oauth2.Token, previous *Token) (*Token, error) {
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return nil, fmt.Errorf("the token response has no id_token, check the openid scope is requested")
+	}
+	result := &Token{
+		IDToken:      idToken,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}
+	if result.RefreshToken == "" && previous != nil {
+		result.RefreshToken = previous.RefreshToken
+	}
+	if expiry, err := idTokenExpiry(idToken); err == nil {
+		result.Expiry = expiry
+	}
+	return result, nil
+}
+
+// idTokenExpiry returns the exp claim of the ID token. The signature is not verified,
+// the API server does, the expiry is only used to know when to refresh the token
+func idTokenExpiry(idToken string) (time.Time, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("malformed id token, expected 3 parts but got %d", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("malformed id token payload: %w", err)
+	}
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("malformed id token claims: %w", err)
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, fmt.Errorf("id token has no exp claim")
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
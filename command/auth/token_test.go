@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+)
+
+func TestToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var token *Token
+	g.Expect(token.Valid(now)).To(BeFalse())
+	g.Expect((&Token{IDToken: "id", Expiry: now.Add(time.Hour)}).Valid(now)).To(BeTrue())
+	g.Expect((&Token{IDToken: "id", Expiry: now.Add(10 * time.Second)}).Valid(now)).To(BeFalse())
+	g.Expect((&Token{Expiry: now.Add(time.Hour)}).Valid(now)).To(BeFalse())
+
+	expiry, err := idTokenExpiry(idToken(now))
+	g.Expect(err).To(BeNil())
+	g.Expect(expiry.Equal(now)).To(BeTrue())
+	_, err = idTokenExpiry("not-a-jwt")
+	g.Expect(err).To(MatchError(ContainSubstring("expected 3 parts")))
+	_, err = idTokenExpiry("a.e30.c")
+	g.Expect(err).To(MatchError("id token has no exp claim"))
+
+	response := (&oauth2.Token{AccessToken: "access", Expiry: now.Add(time.Minute)}).
+		WithExtra(map[string]interface{}{"id_token": idToken(now.Add(time.Hour))})
+	token, err = tokenFromOAuth2(response, &Token{RefreshToken: "previous"})
+	g.Expect(err).To(BeNil())
+	g.Expect(token.AccessToken).To(Equal("access"))
+	g.Expect(token.RefreshToken).To(Equal("previous"))
+	g.Expect(token.Expiry.Equal(now.Add(time.Hour))).To(BeTrue())
+
+	_, err = tokenFromOAuth2(&oauth2.Token{AccessToken: "access"}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("has no id_token")))
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigFlag name of the flag holding the config file path
+const ConfigFlag = "config"
+
+// Validator is implemented by configs validating themselves after loading
+type Validator interface {
+	Validate() error
+}
+
+// Option configures a Loader
+type Option func(*options)
+
+type options struct {
+	envPrefix         string
+	defaultConfigFile string
+}
+
+// WithEnvPrefix sets the prefix of environment variables, e.g. MYCLI
+// will read the env tag SERVER from MYCLI_SERVER and the config file path from MYCLI_CONFIG
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = strings.TrimSuffix(strings.ToUpper(prefix), "_")
+	}
+}
+
+// WithDefaultConfigFile sets the config file loaded when --config is not set,
+// a missing default config file is ignored
+func WithDefaultConfigFile(path string) Option {
+	return func(o *options) {
+		o.defaultConfigFile = path
+	}
+}
+
+// Loader loads a typed config of type T from file, environment variables and flags
+type Loader[T any] struct {
+	// Config is the loaded config, its initial values are the defaults
+	Config *T
+	// ConfigFile is the value of the --config flag
+	ConfigFile string
+
+	options
+	fields   []*field
+	validate func(*T) error
+}
+
+// NewLoader returns a Loader for cfg, returns an error when a tagged field has an unsupported type
+func NewLoader[T any](cfg *T, opts ...Option) (*Loader[T], error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config should not be nil")
+	}
+	loader := &Loader[T]{Config: cfg}
+	for _, opt := range opts {
+		opt(&loader.options)
+	}
+	fields, err := parseFields(cfg)
+	if err != nil {
+		return nil, err
+	}
+	loader.fields = fields
+	return loader, nil
+}
+
+// WithValidation adds a validation executed after Validate() of the config
+func (l *Loader[T]) WithValidation(validate func(*T) error) *Loader[T] {
+	l.validate = validate
+	return l
+}
+
+// AddFlags adds --config and the flags declared in the config struct
+func (l *Loader[T]) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&l.ConfigFile, ConfigFlag, l.ConfigFile, "path of the config file in YAML or JSON format")
+	for _, f := range l.fields {
+		if f.flag != "" {
+			f.addFlag(flags)
+		}
+	}
+}
+
+// Load loads the config from file, environment variables and flags and validates it
+func (l *Loader[T]) Load() error {
+	if err := l.loadFile(); err != nil {
+		return err
+	}
+	for _, f := range l.fields {
+		if err := f.applyEnv(l.envPrefix); err != nil {
+			return err
+		}
+	}
+	for _, f := range l.fields {
+		f.applyFlag()
+	}
+	return l.Validate()
+}
+
+// Validate validates the config using Validate() when implemented and the validation set by WithValidation
+func (l *Loader[T]) Validate() error {
+	if validator, ok := any(l.Config).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+	}
+	if l.validate != nil {
+		if err := l.validate(l.Config); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+	}
+	return nil
+}
+
+// Register adds the flags as persistent flags of cmd and loads the config
+// before any of its subcommands runs, making it available using FromContext
+func (l *Loader[T]) Register(cmd *cobra.Command) {
+	l.AddFlags(cmd.PersistentFlags())
+
+	preRunE, preRun := cmd.PersistentPreRunE, cmd.PersistentPreRun
+	cmd.PersistentPreRun = nil
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		if err := l.Load(); err != nil {
+			return err
+		}
+		c.SetContext(WithConfig(c.Context(), l.Config))
+		if preRunE != nil {
+			return preRunE(c, args)
+		}
+		if preRun != nil {
+			preRun(c, args)
+		}
+		return nil
+	}
+}
+
+// configFile returns the config file path and if it was explicitly requested
+func (l *Loader[T]) configFile() (string, bool) {
+	if l.ConfigFile != "" {
+		return l.ConfigFile, true
+	}
+	if l.envPrefix != "" {
+		if path := os.Getenv(l.envPrefix + "_CONFIG"); path != "" {
+			return path, true
+		}
+	}
+	return l.defaultConfigFile, false
+}
+
+func (l *Loader[T]) loadFile() error {
+	path, required := l.configFile()
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read config file %s: %w", path, err)
+	}
+	if err := yaml.UnmarshalStrict(content, l.Config); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// key for reading/writing into context
+type configKey struct{}
+
+// WithConfig adds the config into the context
+func WithConfig(ctx context.Context, cfg any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, configKey{}, cfg)
+}
+
+// FromContext returns the config of type *T stored in the context if any
+// if not found will return nil
+func FromContext[T any](ctx context.Context) *T {
+	if ctx == nil {
+		return nil
+	}
+	cfg, _ := ctx.Value(configKey{}).(*T)
+	return cfg
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type database struct {
+	Name string `json:"name" env:"DB_NAME" flag:"db-name" usage:"database name"`
+	Port int    `json:"port" flag:"db-port" usage:"database port"`
+}
+
+type testConfig struct {
+	Server   string          `json:"server" env:"SERVER" flag:"server" usage:"server address"`
+	Timeout  metav1.Duration `json:"timeout" env:"TIMEOUT" flag:"timeout" usage:"request timeout"`
+	Debug    bool            `json:"debug" flag:"debug" usage:"enables debug"`
+	Tags     []string        `json:"tags" env:"TAGS" flag:"tag" usage:"tags"`
+	Database database        `json:"database"`
+	Ignored  string          `json:"ignored"`
+}
+
+func (c *testConfig) Validate() error {
+	if c.Server == "" {
+		return fmt.Errorf("server is required")
+	}
+	return nil
+}
+
+func TestLoader_Load(t *testing.T) {
+	var data = []struct {
+		desc     string
+		env      map[string]string
+		args     []string
+		expected testConfig
+		err      string
+	}{
+		{
+			desc:     "defaults only",
+			args:     []string{"--server", "https://flag.example.com"},
+			expected: testConfig{Server: "https://flag.example.com", Timeout: metav1.Duration{Duration: time.Minute}},
+		},
+		{
+			desc: "config file overrides defaults",
+			args: []string{"--config", "testdata/config.yaml"},
+			expected: testConfig{
+				Server: "https://file.example.com", Timeout: metav1.Duration{Duration: 30 * time.Second}, Tags: []string{"file"},
+				Database: database{Name: "file-db", Port: 5432},
+			},
+		},
+		{
+			desc: "env overrides config file",
+			env:  map[string]string{"TEST_SERVER": "https://env.example.com", "TEST_TAGS": "a, b", "TEST_DB_NAME": "env-db"},
+			args: []string{"--config", "testdata/config.yaml"},
+			expected: testConfig{
+				Server: "https://env.example.com", Timeout: metav1.Duration{Duration: 30 * time.Second}, Tags: []string{"a", "b"},
+				Database: database{Name: "env-db", Port: 5432},
+			},
+		},
+		{
+			desc: "flags override env",
+			env:  map[string]string{"TEST_SERVER": "https://env.example.com", "TEST_CONFIG": "testdata/config.yaml"},
+			args: []string{"--server", "https://flag.example.com", "--debug", "--tag", "x", "--tag", "y", "--db-port", "3306", "--timeout", "5s"},
+			expected: testConfig{
+				Server: "https://flag.example.com", Timeout: metav1.Duration{Duration: 5 * time.Second}, Debug: true, Tags: []string{"x", "y"},
+				Database: database{Name: "file-db", Port: 3306},
+			},
+		},
+		{
+			desc: "validation fails",
+			err:  "invalid config: server is required",
+		},
+		{
+			desc: "unknown fields in config file",
+			args: []string{"--config", "testdata/invalid.yaml"},
+			err:  "parse config file testdata/invalid.yaml",
+		},
+		{
+			desc: "missing config file",
+			args: []string{"--config", "testdata/not-exist.yaml"},
+			err:  "read config file testdata/not-exist.yaml",
+		},
+		{
+			desc: "invalid env",
+			env:  map[string]string{"TEST_SERVER": "https://env.example.com", "TEST_TIMEOUT": "forever"},
+			err:  "invalid environment variable TEST_TIMEOUT",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			for key, value := range item.env {
+				t.Setenv(key, value)
+			}
+
+			loader, err := NewLoader(&testConfig{Timeout: metav1.Duration{Duration: time.Minute}}, WithEnvPrefix("test"), WithDefaultConfigFile("testdata/not-exist.yaml"))
+			g.Expect(err).To(BeNil())
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			loader.AddFlags(flags)
+			g.Expect(flags.Parse(item.args)).To(Succeed())
+
+			err = loader.Load()
+			if item.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(item.err))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(*loader.Config).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestNewLoader_unsupported(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := NewLoader(&struct {
+		Values map[string]string `flag:"values"`
+	}{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("unsupported type map[string]string of config field Values"))
+
+	loader, err := NewLoader(&testConfig{})
+	g.Expect(err).To(BeNil())
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	loader.AddFlags(flags)
+	g.Expect(flags.Parse([]string{"--db-port", "abc"})).NotTo(Succeed())
+}
+
+func TestLoader_Register(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	loader, err := NewLoader(&testConfig{})
+	g.Expect(err).To(BeNil())
+	loader.WithValidation(func(c *testConfig) error {
+		if c.Database.Port == 0 {
+			return fmt.Errorf("database port is required")
+		}
+		return nil
+	})
+
+	var (
+		loaded *testConfig
+		preRun bool
+	)
+	root := &cobra.Command{
+		Use:              "root",
+		PersistentPreRun: func(_ *cobra.Command, _ []string) { preRun = true },
+	}
+	root.AddCommand(&cobra.Command{
+		Use: "sub",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			loaded = FromContext[testConfig](cmd.Context())
+			return nil
+		},
+	})
+	loader.Register(root)
+
+	root.SetArgs([]string{"sub", "--server", "https://flag.example.com"})
+	err = root.ExecuteContext(context.Background())
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("database port is required"))
+	g.Expect(preRun).To(BeFalse())
+
+	root.SetArgs([]string{"sub", "--server", "https://flag.example.com", "--db-port", "1"})
+	g.Expect(root.ExecuteContext(context.Background())).To(Succeed())
+	g.Expect(preRun).To(BeTrue())
+	g.Expect(loaded).To(Equal(loader.Config))
+	g.Expect(loaded.Server).To(Equal("https://flag.example.com"))
+	g.Expect(FromContext[database](context.Background())).To(BeNil())
+}
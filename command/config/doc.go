@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads a typed configuration struct for clis.
+//
+// Values are resolved with the following precedence, from lowest to highest:
+//
+//  1. defaults already set in the struct
+//  2. the YAML or JSON file given by --config or the <PREFIX>_CONFIG environment
+//     variable, using the json tags
+//  3. environment variables declared with the env tag, prefixed with the env prefix
+//  4. flags declared with the flag tag, only when set in the command line
+//
+// The resulting struct is validated, calling Validate() when implemented,
+// before the command runs and is made available to subcommands using FromContext.
+//
+// Tagged fields can be strings, booleans, integers, floats, []string, time.Duration
+// or metav1.Duration. Prefer metav1.Duration when durations are set in the config
+// file, as time.Duration only decodes from an integer of nanoseconds.
+//
+//	type Options struct {
+//		Server  string          `json:"server" env:"SERVER" flag:"server" usage:"server address"`
+//		Timeout metav1.Duration `json:"timeout" env:"TIMEOUT" flag:"timeout" usage:"request timeout"`
+//	}
+//
+//	loader, err := config.NewLoader(&Options{Timeout: metav1.Duration{Duration: time.Minute}}, config.WithEnvPrefix("MYCLI"))
+//	loader.Register(rootCmd)
+package config
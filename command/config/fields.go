@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// field a struct field declaring an env or flag tag
+type field struct {
+	name  string
+	value reflect.Value
+	env   string
+	flag  string
+	usage string
+
+	// shadow holds the flag value, only applied into value when the flag is set
+	// so that flags take precedence over the config file and environment variables
+	shadow reflect.Value
+	pflag  *pflag.Flag
+}
+
+// parseFields returns the fields of cfg declaring env or flag tags, including nested structs
+func parseFields(cfg any) ([]*field, error) {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config should be a pointer to a struct, got %T", cfg)
+	}
+	return parseStruct(value.Elem(), "")
+}
+
+func parseStruct(value reflect.Value, path string) (fields []*field, err error) {
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		name := path + structField.Name
+		fieldValue := value.Field(i)
+
+		env, flag := structField.Tag.Get("env"), structField.Tag.Get("flag")
+		if env == "" && flag == "" {
+			if fieldValue.Kind() == reflect.Struct {
+				nested, err := parseStruct(fieldValue, name+".")
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, nested...)
+			}
+			continue
+		}
+		if !isSupported(fieldValue) {
+			return nil, fmt.Errorf("unsupported type %s of config field %s", fieldValue.Type(), name)
+		}
+		fields = append(fields, &field{
+			name:  name,
+			value: fieldValue,
+			env:   env,
+			flag:  flag,
+			usage: structField.Tag.Get("usage"),
+		})
+	}
+	return fields, nil
+}
+
+// addFlag adds the flag of the field using its current value as default
+func (f *field) addFlag(flags *pflag.FlagSet) {
+	f.shadow = reflect.New(f.value.Type())
+	f.shadow.Elem().Set(f.value)
+
+	switch p := f.shadow.Interface().(type) {
+	case *string:
+		flags.StringVar(p, f.flag, *p, f.usage)
+	case *bool:
+		flags.BoolVar(p, f.flag, *p, f.usage)
+	case *int:
+		flags.IntVar(p, f.flag, *p, f.usage)
+	case *int32:
+		flags.Int32Var(p, f.flag, *p, f.usage)
+	case *int64:
+		flags.Int64Var(p, f.flag, *p, f.usage)
+	case *uint:
+		flags.UintVar(p, f.flag, *p, f.usage)
+	case *uint32:
+		flags.Uint32Var(p, f.flag, *p, f.usage)
+	case *uint64:
+		flags.Uint64Var(p, f.flag, *p, f.usage)
+	case *float32:
+		flags.Float32Var(p, f.flag, *p, f.usage)
+	case *float64:
+		flags.Float64Var(p, f.flag, *p, f.usage)
+	case *time.Duration:
+		flags.DurationVar(p, f.flag, *p, f.usage)
+	case *metav1.Duration:
+		flags.DurationVar(&p.Duration, f.flag, p.Duration, f.usage)
+	case *[]string:
+		flags.StringSliceVar(p, f.flag, *p, f.usage)
+	}
+	f.pflag = flags.Lookup(f.flag)
+}
+
+// applyEnv sets the field from its environment variable when set
+func (f *field) applyEnv(prefix string) error {
+	if f.env == "" {
+		return nil
+	}
+	name := f.env
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	if err := setValue(f.value, raw); err != nil {
+		return fmt.Errorf("invalid environment variable %s: %w", name, err)
+	}
+	return nil
+}
+
+// applyFlag sets the field from the flag when set in the command line
+func (f *field) applyFlag() {
+	if f.pflag != nil && f.pflag.Changed {
+		f.value.Set(f.shadow.Elem())
+	}
+}
+
+func isSupported(value reflect.Value) bool {
+	switch value.Addr().Interface().(type) {
+	case *string, *bool, *int, *int32, *int64, *uint, *uint32, *uint64,
+		*float32, *float64, *time.Duration, *metav1.Duration, *[]string:
+		return true
+	}
+	return false
+}
+
+// setValue parses raw into value, slices are comma separated
+func setValue(value reflect.Value, raw string) (err error) {
+	switch p := value.Addr().Interface().(type) {
+	case *string:
+		*p = raw
+	case *bool:
+		*p, err = strconv.ParseBool(raw)
+	case *int:
+		*p, err = strconv.Atoi(raw)
+	case *int32:
+		var i int64
+		i, err = strconv.ParseInt(raw, 10, 32)
+		*p = int32(i)
+	case *int64:
+		*p, err = strconv.ParseInt(raw, 10, 64)
+	case *uint:
+		var u uint64
+		u, err = strconv.ParseUint(raw, 10, 0)
+		*p = uint(u)
+	case *uint32:
+		var u uint64
+		u, err = strconv.ParseUint(raw, 10, 32)
+		*p = uint32(u)
+	case *uint64:
+		*p, err = strconv.ParseUint(raw, 10, 64)
+	case *float32:
+		var f float64
+		f, err = strconv.ParseFloat(raw, 32)
+		*p = float32(f)
+	case *float64:
+		*p, err = strconv.ParseFloat(raw, 64)
+	case *time.Duration:
+		*p, err = time.ParseDuration(raw)
+	case *metav1.Duration:
+		p.Duration, err = time.ParseDuration(raw)
+	case *[]string:
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*p = items
+	default:
+		err = fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return
+}
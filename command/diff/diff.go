@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+	kclient "github.com/AlaudaDevops/pkg/client"
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/progress"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/render"
+)
+
+// DefaultFieldManager field manager used for the dry run apply when none is given
+const DefaultFieldManager = "diff"
+
+// ExitError is returned when the command should exit with Code,
+// like kubectl diff it is 1 when differences were found
+type ExitError struct {
+	Code int
+}
+
+// Error implements error
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ExitCode implements root.ExitCoder
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// ExitCode returns the exit code for err following kubectl diff:
+// 0 without differences, 1 when differences were found and 2 for other errors
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	exitErr := &ExitError{}
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 2
+}
+
+// Options options of the diff command
+type Options struct {
+	// Filenames of manifest files, directories, kustomizations or helm charts, - reads from stdin.
+	// See render.Detect
+	Filenames []string
+	// Namespace of the objects without namespace, defaults to the kubeflags namespace or default
+	Namespace string
+	// FieldManager used for the dry run apply, defaults to DefaultFieldManager
+	FieldManager string
+	// NoColor disables colors, otherwise colors are used when the output is a terminal
+	NoColor bool
+	// NewClient returns the client used to get and dry run apply objects, defaults to kubeflags.DefaultClientFunc
+	NewClient kubeflags.ClientFunc
+}
+
+// AddFlags add flags to options
+func (opts *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&opts.Filenames, "filename", "f", opts.Filenames, "files, directories, kustomizations or helm charts with the manifests to diff, - reads from stdin")
+	cmd.Flags().StringVar(&opts.FieldManager, "field-manager", opts.FieldManager, "field manager used for the server-side dry run apply")
+	cmd.Flags().BoolVar(&opts.NoColor, "no-color", opts.NoColor, "do not colorize the diff")
+}
+
+// NewCommand returns a SubcommandFunc of the diff subcommand. The command exits with
+// an ExitError of code 1 when differences are found, use ExitCode to exit like kubectl diff:
+//
+//	cmd := root.NewRootCommand(ctx, "mycli", diff.NewCommand(&diff.Options{}))
+//	if err := cmd.ExecuteContext(ctx); err != nil {
+//		os.Exit(diff.ExitCode(err))
+//	}
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "diff -f FILENAME",
+			Short: "Diff local manifests against the objects in the cluster",
+			Long: fmt.Sprintf(`Diff local manifests against the objects in the cluster.
+
+The manifests are applied using a server-side dry run so defaults set by the
+server are not reported. %s exits with 0 when there are no differences,
+1 when differences were found and 2 on errors.`, name),
+			Args:         cobra.NoArgs,
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if len(opts.Filenames) == 0 {
+					return fmt.Errorf("at least one --filename is required")
+				}
+				clientFunc := opts.NewClient
+				if clientFunc == nil {
+					clientFunc = kubeflags.DefaultClientFunc
+				}
+				clt, err := clientFunc(cmd.Context())
+				if err != nil {
+					return err
+				}
+				objs, err := render.Render(cmd.Context(), render.DetectAll(opts.Filenames...)...)
+				if err != nil {
+					return err
+				}
+				out := pkgio.MustGetIOStreams(ctx).Out
+				changed, err := opts.Run(cmd.Context(), clt, objs, out)
+				if err != nil {
+					return err
+				}
+				if changed > 0 {
+					cmd.SilenceErrors = true
+					return &ExitError{Code: 1}
+				}
+				return nil
+			},
+		}
+		opts.AddFlags(cmd)
+		// reuses the persistent --namespace flag of kubeflags when available
+		if kubeflags.GetKubeFlags(ctx) == nil {
+			cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", opts.Namespace, "namespace of the objects without namespace")
+		}
+		return cmd
+	}
+}
+
+// Run prints the diff of every object into out and returns the number of objects with differences
+func (opts *Options) Run(ctx context.Context, clt client.Client, objs []*unstructured.Unstructured, out io.Writer) (changed int, err error) {
+	namespace, err := opts.namespace(ctx)
+	if err != nil {
+		return 0, err
+	}
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	color := !opts.NoColor && progress.IsTerminal(out)
+
+	dryRun := client.NewDryRunClient(clt)
+	for _, obj := range objs {
+		diff, err := diffObject(ctx, clt, dryRun, obj, namespace, fieldManager)
+		if err != nil {
+			return changed, err
+		}
+		if diff == "" {
+			continue
+		}
+		changed++
+		if color {
+			diff = applyset.ColorizeDiff(diff)
+		}
+		if _, err = io.WriteString(out, diff); err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+func (opts *Options) namespace(ctx context.Context) (string, error) {
+	if opts.Namespace != "" {
+		return opts.Namespace, nil
+	}
+	if kubeflags.GetKubeFlags(ctx) != nil {
+		namespace, err := kubeflags.GetNamespace(ctx)
+		if err != nil || namespace != "" {
+			return namespace, err
+		}
+	}
+	return "default", nil
+}
+
+// diffObject returns the diff between the live object and the result of a dry run apply of obj
+func diffObject(ctx context.Context, clt, dryRun client.Client, obj *unstructured.Unstructured, namespace, fieldManager string) (string, error) {
+	obj = obj.DeepCopy()
+	gvk := obj.GroupVersionKind()
+	namespaced, err := clt.IsObjectNamespaced(obj)
+	if err != nil {
+		return "", fmt.Errorf("resolve scope of %s %q failed: %w", gvk.Kind, obj.GetName(), err)
+	}
+	switch {
+	case namespaced && obj.GetNamespace() == "":
+		obj.SetNamespace(namespace)
+	case !namespaced:
+		obj.SetNamespace("")
+	}
+	key := client.ObjectKeyFromObject(obj)
+
+	var live client.Object
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	err = clt.Get(ctx, key, current)
+	switch {
+	case err == nil:
+		live = current
+	case !apierrors.IsNotFound(err):
+		return "", err
+	}
+
+	// the dry run apply fills obj with the defaults set by the server
+	if err = kclient.Apply(ctx, dryRun, obj, fieldManager, kclient.ForceApply()); err != nil {
+		return "", fmt.Errorf("dry run apply %s %s failed: %w", gvk.Kind, key, err)
+	}
+	obj.SetGroupVersionKind(gvk)
+	return applyset.Diff(strings.ToLower(gvk.Kind)+"/"+key.String(), live, obj)
+}
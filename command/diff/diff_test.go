@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+	"github.com/AlaudaDevops/pkg/command/io"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClient(data map[string]string) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"}, Data: data},
+	).Build()
+}
+
+func TestDiffCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+	clt := newClient(map[string]string{"key": "value"})
+
+	cmd := NewCommand(&Options{
+		NewClient: func(context.Context) (client.Client, error) { return clt, nil },
+	})(ctx, "cli")
+	cmd.SetArgs([]string{"-f", "testdata/configmaps.yaml"})
+	err := cmd.ExecuteContext(ctx)
+	g.Expect(ExitCode(err)).To(Equal(1))
+	g.Expect(out.String()).To(ContainSubstring("" +
+		"--- live/configmap/default/existing\n" +
+		"+++ merged/configmap/default/existing\n"))
+	g.Expect(out.String()).To(ContainSubstring("-  key: value\n+  key: changed\n"))
+	g.Expect(out.String()).To(ContainSubstring("+++ merged/configmap/other/new\n"))
+	g.Expect(out.String()).NotTo(ContainSubstring("\x1b["))
+
+	// the dry run does not change the cluster
+	cm := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "existing"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+	g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "other", Name: "new"}, cm)).NotTo(Succeed())
+}
+
+func TestOptionsRun_noDifferences(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := context.Background()
+	clt := newClient(map[string]string{"key": "changed"})
+	objs, err := applyset.LoadManifests("testdata/configmaps.yaml")
+	g.Expect(err).To(BeNil())
+
+	out := &bytes.Buffer{}
+	changed, err := (&Options{}).Run(ctx, clt, objs[:1], out)
+	g.Expect(err).To(BeNil())
+	g.Expect(changed).To(Equal(0))
+	g.Expect(out.String()).To(BeEmpty())
+
+	opts := &Options{Namespace: "other"}
+	changed, err = opts.Run(ctx, clt, objs[:1], out)
+	g.Expect(err).To(BeNil())
+	g.Expect(changed).To(Equal(1))
+	g.Expect(out.String()).To(ContainSubstring("+++ merged/configmap/other/existing\n"))
+}
+
+func TestExitCode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(ExitCode(nil)).To(Equal(0))
+	g.Expect(ExitCode(&ExitError{Code: 1})).To(Equal(1))
+	g.Expect(ExitCode(errors.New("failed"))).To(Equal(2))
+}
@@ -15,6 +15,8 @@ limitations under the License.
 */
 
 // Package exec contains an interface for executing commands, along with helpers
+// and PodOptions to execute commands in a pod streaming the context IOStreams,
+// like kubectl exec
 // TODO(bentheelder): add standardized timeout functionality & a default timeout
 // so that commands cannot hang indefinitely (!)
 //
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+)
+
+// DefaultContainerAnnotation annotation naming the container used when none is given, like kubectl
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// ExecutorFunc returns the executor streaming the command of the exec url
+type ExecutorFunc func(config *rest.Config, method string, url *url.URL) (remotecommand.Executor, error)
+
+// PodOptions options to execute a command in a pod, like kubectl exec
+type PodOptions struct {
+	// Namespace of the pod, defaults to the kubeflags namespace
+	Namespace string
+	// Pod name, as NAME or pod/NAME, takes precedence over Selector
+	Pod string
+	// Selector is a label selector choosing the pod when Pod is empty, see kubeflags.SelectPod
+	Selector string
+	// Container name, defaults to the container of the DefaultContainerAnnotation or the first container
+	Container string
+	// Command and its arguments
+	Command []string
+	// Stdin passes the input stream to the command
+	Stdin bool
+	// TTY allocates a terminal, the local terminal is put in raw mode while the command runs
+	TTY bool
+	// NewExecutor defaults to remotecommand.NewSPDYExecutor
+	NewExecutor ExecutorFunc
+}
+
+// AddFlags add flags to the flag set
+func (o *PodOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Selector, "selector", "l", o.Selector, "label selector choosing the pod, running and ready pods are preferred")
+	flags.StringVarP(&o.Container, "container", "c", o.Container, "container name, defaults to the default container of the pod")
+	flags.BoolVarP(&o.Stdin, "stdin", "i", o.Stdin, "pass stdin to the container")
+	flags.BoolVarP(&o.TTY, "tty", "t", o.TTY, "stdin is a TTY")
+}
+
+// Run executes the command in the pod using the kubeflags and the IOStreams in the context
+func (o *PodOptions) Run(ctx context.Context) error {
+	config, err := kubeflags.GetRESTConfig(ctx)
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	if o.Namespace == "" {
+		if o.Namespace, err = kubeflags.GetNamespace(ctx); err != nil {
+			return err
+		}
+	}
+	return o.Exec(ctx, config, clientset.CoreV1(), cliio.MustGetIOStreams(ctx))
+}
+
+// Exec executes the command in the pod streaming its input and output through streams
+func (o *PodOptions) Exec(ctx context.Context, config *rest.Config, core corev1client.CoreV1Interface, streams *clioptions.IOStreams) error {
+	if len(o.Command) == 0 {
+		return fmt.Errorf("a command is required")
+	}
+	pod, err := kubeflags.SelectPod(ctx, core, o.Namespace, o.Pod, o.Selector)
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return fmt.Errorf("cannot exec into pod %s/%s, it is %s", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+	container, err := containerName(pod, o.Container)
+	if err != nil {
+		return err
+	}
+
+	tty := o.TTY
+	if tty && !o.Stdin {
+		fmt.Fprintln(streams.ErrOut, "Unable to use a TTY without stdin, use -i to pass stdin")
+		tty = false
+	}
+	if _, ok := terminalFd(streams.In); tty && !ok {
+		fmt.Fprintln(streams.ErrOut, "Unable to use a TTY - input is not a terminal")
+		tty = false
+	}
+
+	req := core.RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   o.Command,
+			Stdin:     o.Stdin,
+			Stdout:    streams.Out != nil,
+			// stderr is merged into stdout by the terminal
+			Stderr: streams.ErrOut != nil && !tty,
+			TTY:    tty,
+		}, clientgoscheme.ParameterCodec)
+
+	newExecutor := o.NewExecutor
+	if newExecutor == nil {
+		newExecutor = remotecommand.NewSPDYExecutor
+	}
+	executor, err := newExecutor(config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	options := remotecommand.StreamOptions{Stdout: streams.Out, Tty: tty}
+	if o.Stdin {
+		options.Stdin = streams.In
+	}
+	if !tty {
+		options.Stderr = streams.ErrOut
+	} else {
+		restore, err := makeRaw(streams.In)
+		if err != nil {
+			return fmt.Errorf("set terminal raw mode: %w", err)
+		}
+		defer restore()
+		if queue := newSizeQueue(ctx, streams.Out); queue != nil {
+			options.TerminalSizeQueue = queue
+		}
+	}
+	if err = executor.StreamWithContext(ctx, options); err != nil {
+		return fmt.Errorf("exec in pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// containerName returns name when set, otherwise the default container of the pod
+func containerName(pod *corev1.Pod, name string) (string, error) {
+	if name == "" {
+		name = pod.Annotations[DefaultContainerAnnotation]
+	}
+	if name == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return "", fmt.Errorf("pod %s/%s has no containers", pod.Namespace, pod.Name)
+		}
+		return pod.Spec.Containers[0].Name, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return name, nil
+		}
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("container %s not found in pod %s/%s", name, pod.Namespace, pod.Name)
+}
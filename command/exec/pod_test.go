@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// coreClient uses a real rest client to build subresource urls, the fake one is nil
+type coreClient struct {
+	corev1client.CoreV1Interface
+	rest rest.Interface
+}
+
+func (c *coreClient) RESTClient() rest.Interface {
+	return c.rest
+}
+
+type fakeExecutor struct {
+	url     *url.URL
+	options remotecommand.StreamOptions
+	err     error
+}
+
+func (e *fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	return e.StreamWithContext(context.Background(), options)
+}
+
+func (e *fakeExecutor) StreamWithContext(_ context.Context, options remotecommand.StreamOptions) error {
+	e.options = options
+	if options.Stdin != nil {
+		data, _ := io.ReadAll(options.Stdin)
+		_, _ = options.Stdout.Write(data)
+	}
+	return e.err
+}
+
+func TestPodOptions_Exec(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "api-0", Namespace: "default", Labels: map[string]string{"app": "api"},
+			Annotations: map[string]string{DefaultContainerAnnotation: "main"},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "sidecar"}, {Name: "main"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	completed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-0", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "job"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	config := &rest.Config{Host: "https://cluster.example.com"}
+	base, err := corev1client.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	core := &coreClient{CoreV1Interface: fake.NewSimpleClientset(pod, completed).CoreV1(), rest: base.RESTClient()}
+
+	var data = []struct {
+		desc  string
+		opts  PodOptions
+		err   string
+		query url.Values
+		out   string
+	}{
+		{
+			desc:  "default container by selector",
+			opts:  PodOptions{Namespace: "default", Selector: "app=api", Command: []string{"ls", "-l"}},
+			query: url.Values{"container": {"main"}, "command": {"ls", "-l"}, "stdout": {"true"}, "stderr": {"true"}},
+		},
+		{
+			desc:  "stdin without terminal",
+			opts:  PodOptions{Namespace: "default", Pod: "api-0", Container: "sidecar", Command: []string{"cat"}, Stdin: true, TTY: true},
+			query: url.Values{"container": {"sidecar"}, "command": {"cat"}, "stdin": {"true"}, "stdout": {"true"}, "stderr": {"true"}},
+			out:   "input",
+		},
+		{
+			desc: "command is required",
+			opts: PodOptions{Namespace: "default", Pod: "api-0"},
+			err:  "a command is required",
+		},
+		{
+			desc: "unknown container",
+			opts: PodOptions{Namespace: "default", Pod: "api-0", Container: "missing", Command: []string{"ls"}},
+			err:  "container missing not found in pod default/api-0",
+		},
+		{
+			desc: "completed pod",
+			opts: PodOptions{Namespace: "default", Pod: "job-0", Command: []string{"ls"}},
+			err:  "it is Succeeded",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			executor := &fakeExecutor{}
+			opts := item.opts
+			opts.NewExecutor = func(_ *rest.Config, method string, url *url.URL) (remotecommand.Executor, error) {
+				g.Expect(method).To(Equal("POST"))
+				executor.url = url
+				return executor, nil
+			}
+			streams, in, out, _ := clioptions.NewTestIOStreams()
+			in.WriteString("input")
+
+			err := opts.Exec(context.Background(), config, core, &streams)
+			if item.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(item.err))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(executor.url.Path).To(Equal("/api/v1/namespaces/default/pods/api-0/exec"))
+			g.Expect(executor.url.Query()).To(Equal(item.query))
+			g.Expect(executor.options.Tty).To(BeFalse())
+			g.Expect(out.String()).To(Equal(item.out))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	opts := PodOptions{Namespace: "default", Pod: "api-0", Command: []string{"false"}}
+	opts.NewExecutor = func(*rest.Config, string, *url.URL) (remotecommand.Executor, error) {
+		return &fakeExecutor{err: errors.New("command terminated with exit code 1")}, nil
+	}
+	streams, _, _, _ := clioptions.NewTestIOStreams()
+	err = opts.Exec(context.Background(), config, core, &streams)
+	g.Expect(err).To(MatchError("exec in pod default/api-0: command terminated with exit code 1"))
+}
+
+func TestSizeQueue(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	sizes := [][2]int{{80, 24}, {80, 24}, {120, 40}}
+	queue := &sizeQueue{ctx: ctx, interval: time.Millisecond, getSize: func() (int, int, error) {
+		size := sizes[0]
+		if len(sizes) > 1 {
+			sizes = sizes[1:]
+		}
+		return size[0], size[1], nil
+	}}
+
+	g.Expect(queue.Next()).To(Equal(&remotecommand.TerminalSize{Width: 80, Height: 24}))
+	g.Expect(queue.Next()).To(Equal(&remotecommand.TerminalSize{Width: 120, Height: 40}))
+	cancel()
+	g.Expect(queue.Next()).To(BeNil())
+	g.Expect(newSizeQueue(ctx, io.Discard)).To(BeNil())
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// terminalResizeInterval interval between checks of the terminal size
+const terminalResizeInterval = 250 * time.Millisecond
+
+// terminalFd returns the file descriptor of r when it is a terminal
+func terminalFd(r interface{}) (int, bool) {
+	file, ok := r.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return 0, false
+	}
+	return int(file.Fd()), true
+}
+
+// makeRaw puts the terminal of in into raw mode and returns a function restoring its state,
+// the function does nothing when in is not a terminal
+func makeRaw(in io.Reader) (restore func(), err error) {
+	fd, ok := terminalFd(in)
+	if !ok {
+		return func() {}, nil
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() { _ = term.Restore(fd, state) }, nil
+}
+
+// sizeQueue is a remotecommand.TerminalSizeQueue polling the size of a terminal.
+// Polling avoids relying on SIGWINCH which is not available on every platform
+type sizeQueue struct {
+	ctx      context.Context
+	interval time.Duration
+	getSize  func() (width, height int, err error)
+	last     remotecommand.TerminalSize
+}
+
+var _ remotecommand.TerminalSizeQueue = &sizeQueue{}
+
+// newSizeQueue returns a sizeQueue for the terminal of out, nil if out is not a terminal
+func newSizeQueue(ctx context.Context, out io.Writer) *sizeQueue {
+	fd, ok := terminalFd(out)
+	if !ok {
+		return nil
+	}
+	return &sizeQueue{
+		ctx:      ctx,
+		interval: terminalResizeInterval,
+		getSize:  func() (int, int, error) { return term.GetSize(fd) },
+	}
+}
+
+// Next returns the terminal size once it changes, nil when ctx is done
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	for {
+		if width, height, err := q.getSize(); err == nil {
+			size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+			if size != q.last {
+				q.last = size
+				return &size
+			}
+		}
+		select {
+		case <-q.ctx.Done():
+			return nil
+		case <-time.After(q.interval):
+		}
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package exit defines the exit codes of clis and typed errors mapping to them,
+// so automation can tell failure modes apart:
+//
+//	0 OK        the command succeeded
+//	1 Error     any other failure
+//	2 Usage     invalid flags or arguments, see UsageError
+//	3 NotFound  a required resource does not exist, see NotFoundError
+//	4 Conflict  a resource already exists or was changed concurrently, see ConflictError
+//	5 Timeout   the command did not finish in time, see TimeoutError
+//
+// Kubernetes api errors and context deadlines are classified too, see Code.
+// Line formats errors as a machine-parsable line, root.Execute prints it to ErrOut
+// and returns the exit code
+package exit
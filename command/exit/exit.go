@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// OK the command succeeded
+	OK = 0
+	// Error any other failure
+	Error = 1
+	// Usage invalid flags or arguments
+	Usage = 2
+	// NotFound a required resource does not exist
+	NotFound = 3
+	// Conflict a resource already exists or was changed concurrently
+	Conflict = 4
+	// Timeout the command did not finish in time
+	Timeout = 5
+)
+
+// Coder is implemented by errors defining the exit code of the process
+type Coder interface {
+	ExitCode() int
+}
+
+// UsageError is returned when flags or arguments are invalid
+type UsageError struct {
+	Err error
+}
+
+// Error implements error
+func (e *UsageError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error
+func (e *UsageError) Unwrap() error { return e.Err }
+
+// ExitCode implements Coder
+func (e *UsageError) ExitCode() int { return Usage }
+
+// NotFoundError is returned when a required resource does not exist
+type NotFoundError struct {
+	Err error
+}
+
+// Error implements error
+func (e *NotFoundError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error
+func (e *NotFoundError) Unwrap() error { return e.Err }
+
+// ExitCode implements Coder
+func (e *NotFoundError) ExitCode() int { return NotFound }
+
+// ConflictError is returned when a resource already exists or was changed concurrently
+type ConflictError struct {
+	Err error
+}
+
+// Error implements error
+func (e *ConflictError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error
+func (e *ConflictError) Unwrap() error { return e.Err }
+
+// ExitCode implements Coder
+func (e *ConflictError) ExitCode() int { return Conflict }
+
+// TimeoutError is returned when the command did not finish in time
+type TimeoutError struct {
+	Err error
+}
+
+// Error implements error
+func (e *TimeoutError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// ExitCode implements Coder
+func (e *TimeoutError) ExitCode() int { return Timeout }
+
+// CodeError is an error exiting with Code
+type CodeError struct {
+	Code int
+	Err  error
+}
+
+// Error implements error
+func (e *CodeError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error
+func (e *CodeError) Unwrap() error { return e.Err }
+
+// ExitCode implements Coder
+func (e *CodeError) ExitCode() int { return e.Code }
+
+// WithCode returns a CodeError exiting with code, nil when err is nil
+func WithCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &CodeError{Code: code, Err: err}
+}
+
+// Usagef returns a UsageError formatting the message like fmt.Errorf
+func Usagef(format string, args ...any) error {
+	return &UsageError{Err: fmt.Errorf(format, args...)}
+}
+
+// NotFoundf returns a NotFoundError formatting the message like fmt.Errorf
+func NotFoundf(format string, args ...any) error {
+	return &NotFoundError{Err: fmt.Errorf(format, args...)}
+}
+
+// Conflictf returns a ConflictError formatting the message like fmt.Errorf
+func Conflictf(format string, args ...any) error {
+	return &ConflictError{Err: fmt.Errorf(format, args...)}
+}
+
+// Timeoutf returns a TimeoutError formatting the message like fmt.Errorf
+func Timeoutf(format string, args ...any) error {
+	return &TimeoutError{Err: fmt.Errorf(format, args...)}
+}
+
+// Code returns the exit code of err: OK when nil, the code of the first Coder in its chain,
+// NotFound, Conflict or Timeout for the matching kubernetes api errors, Timeout when the
+// context deadline was exceeded, otherwise Error
+func Code(err error) int {
+	if err == nil {
+		return OK
+	}
+	var coder Coder
+	switch {
+	case errors.As(err, &coder):
+		return coder.ExitCode()
+	case apierrors.IsNotFound(err):
+		return NotFound
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+		return Conflict
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	}
+	return Error
+}
+
+// Reason returns the name of the exit code, e.g. NotFound
+func Reason(code int) string {
+	switch code {
+	case OK:
+		return "OK"
+	case Error:
+		return "Error"
+	case Usage:
+		return "Usage"
+	case NotFound:
+		return "NotFound"
+	case Conflict:
+		return "Conflict"
+	case Timeout:
+		return "Timeout"
+	}
+	return "Code" + strconv.Itoa(code)
+}
+
+// Line formats err as a machine-parsable line of key=value pairs with a quoted message, e.g.
+//
+//	error code=3 reason=NotFound message="configmaps \"app\" not found"
+func Line(err error) string {
+	code := Code(err)
+	return fmt.Sprintf("error code=%d reason=%s message=%s", code, Reason(code), strconv.Quote(err.Error()))
+}
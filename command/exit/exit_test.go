@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type codeError int
+
+func (e codeError) Error() string { return "code error" }
+func (e codeError) ExitCode() int { return int(e) }
+
+func TestCode(t *testing.T) {
+	g := NewGomegaWithT(t)
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+
+	g.Expect(Code(nil)).To(Equal(OK))
+	g.Expect(Code(errors.New("failed"))).To(Equal(Error))
+	g.Expect(Code(Usagef("unknown flag %s", "--foo"))).To(Equal(Usage))
+	g.Expect(Code(fmt.Errorf("get: %w", NotFoundf("app not found")))).To(Equal(NotFound))
+	g.Expect(Code(Conflictf("app exists"))).To(Equal(Conflict))
+	g.Expect(Code(Timeoutf("app not ready"))).To(Equal(Timeout))
+	g.Expect(Code(codeError(42))).To(Equal(42))
+	g.Expect(Code(fmt.Errorf("run: %w", WithCode(errors.New("failed"), 7)))).To(Equal(7))
+	g.Expect(WithCode(nil, 7)).To(BeNil())
+
+	g.Expect(Code(apierrors.NewNotFound(configMaps, "app"))).To(Equal(NotFound))
+	g.Expect(Code(apierrors.NewAlreadyExists(configMaps, "app"))).To(Equal(Conflict))
+	g.Expect(Code(apierrors.NewConflict(configMaps, "app", errors.New("modified")))).To(Equal(Conflict))
+	g.Expect(Code(fmt.Errorf("wait: %w", context.DeadlineExceeded))).To(Equal(Timeout))
+
+	// typed errors win over the classification of the wrapped error
+	g.Expect(Code(&UsageError{Err: apierrors.NewNotFound(configMaps, "app")})).To(Equal(Usage))
+}
+
+func TestLine(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	err := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "app")
+	g.Expect(Line(err)).To(Equal(`error code=3 reason=NotFound message="configmaps \"app\" not found"`))
+	g.Expect(Line(errors.New("multi\nline"))).To(Equal(`error code=1 reason=Error message="multi\nline"`))
+	g.Expect(Line(codeError(42))).To(Equal(`error code=42 reason=Code42 message="code error"`))
+}
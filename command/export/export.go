@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/normalize"
+	"github.com/AlaudaDevops/pkg/selector"
+	"github.com/AlaudaDevops/pkg/snapshot"
+)
+
+// ClusterDirectory directory of the cluster scoped objects when exporting into a directory,
+// it cannot clash with a namespace as namespace names do not allow underscores
+const ClusterDirectory = "_cluster"
+
+// Options options of the export command
+type Options struct {
+	// Selector label selector of the exported objects, all objects are exported when not set
+	Selector selector.LabelsFlag
+	// Namespace of the exported objects, defaults to the kubeflags namespace or default
+	Namespace string
+	// AllNamespaces exports the objects of all namespaces
+	AllNamespaces bool
+	// Filename writes all the objects into a single multi-document file
+	Filename string
+	// OutputDir writes one file per object into the directory,
+	// as <namespace>/<kind>.<group>-<name>.yaml and ClusterDirectory for cluster scoped objects
+	OutputDir string
+	// Secrets policy applied to secrets, defaults to snapshot.SecretsRedact
+	Secrets string
+	// NewClient returns the client used to list objects, defaults to kubeflags.DefaultClientFunc
+	NewClient kubeflags.ClientFunc
+}
+
+// AddFlags add flags to options
+func (opts *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().VarP(&opts.Selector, "selector", "l", "label selector of the exported objects")
+	cmd.Flags().BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", opts.AllNamespaces, "export the objects of all namespaces")
+	cmd.Flags().StringVarP(&opts.Filename, "filename", "f", opts.Filename, "write all the objects into a single multi-document file")
+	cmd.Flags().StringVar(&opts.OutputDir, "output-dir", opts.OutputDir, "write one file per object into the directory")
+	cmd.Flags().StringVar(&opts.Secrets, "secrets", opts.Secrets, "policy applied to secrets: Include, Redact or Omit, defaults to Redact")
+}
+
+// NewCommand returns a SubcommandFunc of the export subcommand
+//
+//	cmd := root.NewRootCommand(ctx, "mycli", export.NewCommand(&export.Options{}))
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "export <resource>... [-l SELECTOR] [-f FILENAME | --output-dir DIR]",
+			Short: "Export objects of the cluster as manifests",
+			Long: fmt.Sprintf(`Export objects of the cluster as manifests.
+
+Resources are given like kubectl, e.g. configmaps or deployments.apps. Fields set
+by the api server, owner references and node names are removed so the manifests
+can be applied into another namespace or cluster with the import subcommand.
+%s writes into the standard output when neither --filename nor --output-dir is given.`, name),
+			Args:         cobra.MinimumNArgs(1),
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if opts.Filename != "" && opts.OutputDir != "" {
+					return fmt.Errorf("--filename and --output-dir are mutually exclusive")
+				}
+				clientFunc := opts.NewClient
+				if clientFunc == nil {
+					clientFunc = kubeflags.DefaultClientFunc
+				}
+				clt, err := clientFunc(cmd.Context())
+				if err != nil {
+					return err
+				}
+				return opts.Run(cmd.Context(), clt, args, pkgio.MustGetIOStreams(ctx).Out)
+			},
+		}
+		opts.AddFlags(cmd)
+		// reuses the persistent --namespace flag of kubeflags when available
+		if kubeflags.GetKubeFlags(ctx) == nil {
+			cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", opts.Namespace, "namespace of the exported objects")
+		}
+		return cmd
+	}
+}
+
+// Run exports the objects of resources into Filename, OutputDir or out
+func (opts *Options) Run(ctx context.Context, clt client.Client, resources []string, out io.Writer) error {
+	snap, err := opts.Export(ctx, clt, resources)
+	if err != nil {
+		return err
+	}
+	switch {
+	case opts.OutputDir != "":
+		return WriteDir(snap.Objects, opts.OutputDir)
+	case opts.Filename != "":
+		f, err := os.Create(opts.Filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err = snap.Write(f); err != nil {
+			return err
+		}
+		return f.Close()
+	}
+	return snap.Write(out)
+}
+
+// Export returns a sanitized snapshot of the objects of resources
+func (opts *Options) Export(ctx context.Context, clt client.Client, resources []string) (*snapshot.Snapshot, error) {
+	labelSelector := opts.Selector.Selector
+	if labelSelector == nil {
+		labelSelector = labels.Everything()
+	}
+	namespace := ""
+	if !opts.AllNamespaces {
+		var err error
+		if namespace, err = opts.namespace(ctx); err != nil {
+			return nil, err
+		}
+	}
+	secrets := snapshot.SecretsRedact
+	if opts.Secrets != "" {
+		secrets = snapshot.SecretPolicy(opts.Secrets)
+	}
+	switch secrets {
+	case snapshot.SecretsInclude, snapshot.SecretsRedact, snapshot.SecretsOmit:
+	default:
+		return nil, fmt.Errorf("invalid secrets policy %q, should be one of %s, %s or %s", opts.Secrets, snapshot.SecretsInclude, snapshot.SecretsRedact, snapshot.SecretsOmit)
+	}
+
+	selections := make([]snapshot.Selection, 0, len(resources))
+	for _, resource := range resources {
+		for _, name := range strings.Split(resource, ",") {
+			gvk, err := kubeflags.KindFor(clt.RESTMapper(), name)
+			if err != nil {
+				return nil, err
+			}
+			selections = append(selections, snapshot.Selection{GroupVersionKind: gvk, Namespace: namespace, Selector: labelSelector})
+		}
+	}
+	snap, err := snapshot.Take(ctx, clt, selections, snapshot.WithSecretPolicy(secrets))
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range snap.Objects {
+		normalize.SanitizeContent(obj.Object)
+	}
+	return snap, nil
+}
+
+func (opts *Options) namespace(ctx context.Context) (string, error) {
+	if opts.Namespace != "" {
+		return opts.Namespace, nil
+	}
+	if kubeflags.GetKubeFlags(ctx) != nil {
+		namespace, err := kubeflags.GetNamespace(ctx)
+		if err != nil || namespace != "" {
+			return namespace, err
+		}
+	}
+	return "default", nil
+}
+
+// WriteDir writes every object into its own file of dir, see Options.OutputDir
+func WriteDir(objs []*unstructured.Unstructured, dir string) error {
+	for _, obj := range objs {
+		path := filepath.Join(dir, Filename(obj))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = (&snapshot.Snapshot{Objects: []*unstructured.Unstructured{obj}}).Write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("write %s failed: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Filename returns the path of obj relative to the output directory,
+// e.g. default/deployment.apps-name.yaml or _cluster/namespace-name.yaml
+func Filename(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		kind += "." + gvk.Group
+	}
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = ClusterDirectory
+	}
+	return filepath.Join(namespace, kind+"-"+obj.GetName()+".yaml")
+}
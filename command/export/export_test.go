@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/snapshot"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClient(objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).WithObjects(objs...).Build()
+}
+
+func newObjects() []client.Object {
+	return []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", Labels: map[string]string{"app": "demo"}, UID: "uid-a"},
+			Data:       map[string]string{"key": "value"},
+		},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "c", Labels: map[string]string{"app": "demo"}}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "s", Labels: map[string]string{"app": "demo"}},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"app": "demo"}}},
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+	clt := newClient(newObjects()...)
+
+	cmd := NewCommand(&Options{
+		NewClient: func(context.Context) (client.Client, error) { return clt, nil },
+	})(ctx, "cli")
+	cmd.SetArgs([]string{"configmaps,secrets", "-l", "app=demo"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+	snap, err := snapshot.Read(out)
+	g.Expect(err).To(BeNil())
+	g.Expect(snap.Objects).To(HaveLen(2))
+	g.Expect(snap.Objects[0].GetName()).To(Equal("a"))
+	g.Expect(snap.Objects[0].GetUID()).To(BeEmpty())
+	g.Expect(snap.Objects[0].GetResourceVersion()).To(BeEmpty())
+	g.Expect(snap.Objects[1].GetName()).To(Equal("s"))
+	g.Expect(snap.Objects[1].GetAnnotations()).To(HaveKeyWithValue(snapshot.RedactedAnnotation, "true"))
+	_, found, _ := unstructured.NestedFieldNoCopy(snap.Objects[1].Object, "data")
+	g.Expect(found).To(BeFalse())
+
+	cmd = NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{"configmaps", "-l", "app in"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring(`invalid label selector "app in"`)))
+}
+
+func TestOptionsRun_outputDir(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	clt := newClient(newObjects()...)
+	opts := &Options{AllNamespaces: true, OutputDir: dir, Secrets: string(snapshot.SecretsOmit)}
+	g.Expect(opts.Run(context.Background(), clt, []string{"configmaps", "namespaces", "secrets"}, nil)).To(Succeed())
+
+	var files []string
+	g.Expect(filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			path, err = filepath.Rel(dir, path)
+			files = append(files, path)
+		}
+		return err
+	})).To(Succeed())
+	g.Expect(files).To(Equal([]string{
+		"_cluster/namespace-other.yaml",
+		"default/configmap-a.yaml",
+		"default/configmap-b.yaml",
+		"other/configmap-c.yaml",
+	}))
+}
+
+func TestOptionsRun_filename(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	filename := filepath.Join(t.TempDir(), "export.yaml")
+	clt := newClient(newObjects()...)
+	opts := &Options{Namespace: "other", Filename: filename}
+	g.Expect(opts.Run(context.Background(), clt, []string{"configmap"}, nil)).To(Succeed())
+
+	f, err := os.Open(filename)
+	g.Expect(err).To(BeNil())
+	defer f.Close()
+	snap, err := snapshot.Read(f)
+	g.Expect(err).To(BeNil())
+	g.Expect(snap.Objects).To(HaveLen(1))
+	g.Expect(snap.Objects[0].GetName()).To(Equal("c"))
+}
+
+func TestOptionsExport_errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := context.Background()
+	clt := newClient()
+	_, err := (&Options{}).Export(ctx, clt, []string{"deployments.apps"})
+	g.Expect(err).To(MatchError(ContainSubstring(`resource "deployments.apps" not found`)))
+	_, err = (&Options{Secrets: "Encrypt"}).Export(ctx, clt, []string{"configmaps"})
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid secrets policy "Encrypt"`)))
+}
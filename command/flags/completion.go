@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+)
+
+// CompletionFunc completes the value of a flag, see cobra.Command.RegisterFlagCompletionFunc
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// DiscoveryFunc returns the discovery client listing the resources served by the cluster
+type DiscoveryFunc func(ctx context.Context) (discovery.DiscoveryInterface, error)
+
+// DefaultDiscoveryFunc returns the discovery client of the kubeflags in the context
+func DefaultDiscoveryFunc(ctx context.Context) (discovery.DiscoveryInterface, error) {
+	clientset, err := kubeflags.GetClientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.Discovery(), nil
+}
+
+// CompleteValues completes with the values starting with the value being completed,
+// e.g. common quantities for a QuantityValue flag
+func CompleteValues(values ...string) CompletionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteDurations completes DurationValue flags with common durations
+func CompleteDurations() CompletionFunc {
+	return CompleteValues("30s", "1m", "5m", "10m", "30m", "1h")
+}
+
+// CompleteGroupVersionKinds completes GroupVersionKindValue flags with the preferred versions
+// of the kinds served by the cluster as apiVersion/Kind, newDiscovery defaults to DefaultDiscoveryFunc
+func CompleteGroupVersionKinds(newDiscovery DiscoveryFunc) CompletionFunc {
+	if newDiscovery == nil {
+		newDiscovery = DefaultDiscoveryFunc
+	}
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		resources, err := newDiscovery(cmd.Context())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		// partial results are returned when some group fails to be discovered
+		lists, _ := discovery.ServerPreferredResources(resources)
+		values := sets.New[string]()
+		for _, list := range lists {
+			gv, err := schema.ParseGroupVersion(list.GroupVersion)
+			if err != nil {
+				continue
+			}
+			for _, resource := range list.APIResources {
+				// subresources like deployments/status share the kind of their resource
+				if strings.Contains(resource.Name, "/") {
+					continue
+				}
+				values.Insert(FormatGroupVersionKind(gv.WithKind(resource.Kind)))
+			}
+		}
+		return filterPrefix(sets.List(values), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteNamespacedNames completes NamespacedNameValue flags with the namespace/name of the objects of gvk.
+// The objects of the namespace before the / are listed, or of all namespaces when there is none.
+// newClient defaults to kubeflags.DefaultClientFunc
+func CompleteNamespacedNames(gvk schema.GroupVersionKind, newClient kubeflags.ClientFunc) CompletionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var listOpts []client.ListOption
+		if namespace, _, found := strings.Cut(toComplete, "/"); found {
+			listOpts = append(listOpts, client.InNamespace(namespace))
+		}
+		list, err := listMetadata(cmd.Context(), newClient, gvk, listOpts...)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		values := make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			if item.Namespace == "" {
+				values = append(values, item.Name)
+			} else {
+				values = append(values, item.Namespace+"/"+item.Name)
+			}
+		}
+		sort.Strings(values)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteLabelSelectors completes LabelSelectorValue flags with the key=value labels of the objects of gvk
+// in all namespaces, the requirements before the last comma are kept. newClient defaults to kubeflags.DefaultClientFunc
+func CompleteLabelSelectors(gvk schema.GroupVersionKind, newClient kubeflags.ClientFunc) CompletionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		list, err := listMetadata(cmd.Context(), newClient, gvk)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		prefix := ""
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			prefix = toComplete[:i+1]
+		}
+		values := sets.New[string]()
+		for _, item := range list.Items {
+			for key, value := range item.Labels {
+				values.Insert(prefix + key + "=" + value)
+			}
+		}
+		return filterPrefix(sets.List(values), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func listMetadata(ctx context.Context, newClient kubeflags.ClientFunc, gvk schema.GroupVersionKind, opts ...client.ListOption) (*metav1.PartialObjectMetadataList, error) {
+	if newClient == nil {
+		newClient = kubeflags.DefaultClientFunc
+	}
+	clt, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err = clt.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	filtered := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
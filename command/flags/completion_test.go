@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	kubetesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var configMapGVK = corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+func newCommand() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func newClientFunc() func(context.Context) (client.Client, error) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(configMapGVK, meta.RESTScopeNamespace)
+	clt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db", Labels: map[string]string{"app": "db"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "web"}},
+	).Build()
+	return func(context.Context) (client.Client, error) { return clt, nil }
+}
+
+func TestCompleteValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	values, directive := CompleteDurations()(newCommand(), nil, "1")
+	g.Expect(values).To(Equal([]string{"1m", "10m", "1h"}))
+	g.Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+}
+
+func TestCompleteGroupVersionKinds(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDiscovery := &fakediscovery.FakeDiscovery{Fake: &kubetesting.Fake{}}
+	fakeDiscovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Verbs: metav1.Verbs{"list"}},
+			{Name: "pods", Kind: "Pod", Verbs: metav1.Verbs{"list"}},
+			{Name: "pods/status", Kind: "Pod", Verbs: metav1.Verbs{"get"}},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Verbs: metav1.Verbs{"list"}},
+		}},
+	}
+	complete := CompleteGroupVersionKinds(func(context.Context) (discovery.DiscoveryInterface, error) {
+		return fakeDiscovery, nil
+	})
+
+	values, directive := complete(newCommand(), nil, "")
+	g.Expect(values).To(Equal([]string{"apps/v1/Deployment", "v1/ConfigMap", "v1/Pod"}))
+	g.Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+
+	values, _ = complete(newCommand(), nil, "v1/P")
+	g.Expect(values).To(Equal([]string{"v1/Pod"}))
+}
+
+func TestCompleteNamespacedNames(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	complete := CompleteNamespacedNames(configMapGVK, newClientFunc())
+	values, directive := complete(newCommand(), nil, "")
+	g.Expect(values).To(Equal([]string{"default/db", "default/web", "other/web"}))
+	g.Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+
+	values, _ = complete(newCommand(), nil, "other/")
+	g.Expect(values).To(Equal([]string{"other/web"}))
+}
+
+func TestCompleteLabelSelectors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	complete := CompleteLabelSelectors(configMapGVK, newClientFunc())
+	values, _ := complete(newCommand(), nil, "app=")
+	g.Expect(values).To(Equal([]string{"app=db", "app=web"}))
+
+	values, _ = complete(newCommand(), nil, "app=web,t")
+	g.Expect(values).To(Equal([]string{"app=web,tier=frontend"}))
+}
+
+func TestComplete_clientError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	values, directive := CompleteNamespacedNames(configMapGVK, func(context.Context) (client.Client, error) {
+		return nil, context.Canceled
+	})(newCommand(), nil, "")
+	g.Expect(values).To(BeEmpty())
+	g.Expect(directive).To(Equal(cobra.ShellCompDirectiveError))
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flags provides pflag.Value types for kubernetes values, like quantities,
+// durations, label selectors, group version kinds and namespaced names, validating
+// them with consistent error messages, and shell completion functions for them.
+//
+//	type Options struct {
+//		Memory   resource.Quantity
+//		Timeout  metav1.Duration
+//		Selector flags.LabelSelectorValue
+//		Kind     schema.GroupVersionKind
+//		Target   types.NamespacedName
+//	}
+//
+//	flags.QuantityVar(cmd.Flags(), &opts.Memory, "memory", "memory limit, e.g. 512Mi")
+//	flags.DurationVar(cmd.Flags(), &opts.Timeout, "timeout", "time to wait, e.g. 5m")
+//	cmd.Flags().VarP(&opts.Selector, "selector", "l", "label selector, e.g. app=web")
+//	flags.GroupVersionKindVar(cmd.Flags(), &opts.Kind, "kind", "kind of the objects, e.g. apps/v1/Deployment")
+//	flags.NamespacedNameVar(cmd.Flags(), &opts.Target, "target", "object as namespace/name")
+//	cmd.RegisterFlagCompletionFunc("kind", flags.CompleteGroupVersionKinds(nil))
+package flags
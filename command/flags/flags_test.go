@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type options struct {
+	Memory   resource.Quantity
+	Timeout  metav1.Duration
+	Selector LabelSelectorValue
+	Kind     schema.GroupVersionKind
+	Target   types.NamespacedName
+}
+
+func newFlagSet(opts *options) *pflag.FlagSet {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	QuantityVar(fs, &opts.Memory, "memory", "memory limit")
+	DurationVar(fs, &opts.Timeout, "timeout", "time to wait")
+	fs.Var(&opts.Selector, "selector", "label selector")
+	GroupVersionKindVar(fs, &opts.Kind, "kind", "kind of the objects")
+	NamespacedNameVar(fs, &opts.Target, "target", "target object")
+	return fs
+}
+
+func TestFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	opts := &options{Timeout: metav1.Duration{Duration: time.Minute}}
+	fs := newFlagSet(opts)
+	g.Expect(fs.Lookup("timeout").DefValue).To(Equal("1m0s"))
+	g.Expect(fs.Lookup("kind").Value.Type()).To(Equal("apiVersion/kind"))
+
+	g.Expect(fs.Parse([]string{
+		"--memory", "512Mi",
+		"--timeout", "1m30s",
+		"--selector", "app=web,tier in (a,b)",
+		"--kind", "Deployment.v1.apps",
+		"--target", "default/web",
+	})).To(Succeed())
+	g.Expect(opts.Memory.Equal(resource.MustParse("512Mi"))).To(BeTrue())
+	g.Expect(opts.Timeout.Duration).To(Equal(90 * time.Second))
+	g.Expect(opts.Selector.Selector.Matches(labels.Set{"app": "web", "tier": "a"})).To(BeTrue())
+	g.Expect(opts.Kind).To(Equal(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}))
+	g.Expect(opts.Target).To(Equal(types.NamespacedName{Namespace: "default", Name: "web"}))
+
+	g.Expect(fs.Lookup("memory").Value.String()).To(Equal("512Mi"))
+	g.Expect(fs.Lookup("selector").Value.String()).To(Equal("app=web,tier in (a,b)"))
+	g.Expect(fs.Lookup("kind").Value.String()).To(Equal("apps/v1/Deployment"))
+	g.Expect(fs.Lookup("target").Value.String()).To(Equal("default/web"))
+}
+
+func TestFlags_invalid(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"quantity":          {[]string{"--memory", "1GB"}, `invalid quantity "1GB"`},
+		"duration":          {[]string{"--timeout", "5"}, `invalid duration "5"`},
+		"negative duration": {[]string{"--timeout", "-5s"}, `invalid duration "-5s": must not be negative`},
+		"selector":          {[]string{"--selector", "app in"}, `invalid label selector "app in"`},
+		"kind only":         {[]string{"--kind", "Deployment"}, `invalid group version kind "Deployment": version and kind are required`},
+		"group version":     {[]string{"--kind", "apps/v1/"}, `invalid group version kind "apps/v1/": version and kind are required`},
+		"api version":       {[]string{"--kind", "a/b/c/Kind"}, `invalid group version kind "a/b/c/Kind"`},
+		"namespace":         {[]string{"--target", "Default/web"}, `invalid namespace "Default" in "Default/web"`},
+		"name":              {[]string{"--target", "web_1"}, `invalid name "web_1" in "web_1"`},
+		"too many parts":    {[]string{"--target", "a/b/c"}, `invalid namespaced name "a/b/c", expected namespace/name or name`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			fs := newFlagSet(&options{})
+			fs.SetOutput(nil)
+			g.Expect(fs.Parse(test.args)).To(MatchError(ContainSubstring(test.err)))
+		})
+	}
+}
+
+func TestParseGroupVersionKind(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gvk, err := ParseGroupVersionKind("v1/ConfigMap")
+	g.Expect(err).To(BeNil())
+	g.Expect(gvk).To(Equal(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}))
+	g.Expect(FormatGroupVersionKind(gvk)).To(Equal("v1/ConfigMap"))
+	g.Expect(FormatGroupVersionKind(schema.GroupVersionKind{})).To(BeEmpty())
+}
+
+func TestParseNamespacedName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key, err := ParseNamespacedName("web")
+	g.Expect(err).To(BeNil())
+	g.Expect(key).To(Equal(types.NamespacedName{Name: "web"}))
+	g.Expect(NewNamespacedNameValue(&key).String()).To(Equal("web"))
+}
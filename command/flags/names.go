@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseGroupVersionKind parses a user supplied group version kind given as apiVersion/Kind,
+// e.g. apps/v1/Deployment or v1/ConfigMap, or like kubectl as Kind.version.group, e.g. Deployment.v1.apps
+func ParseGroupVersionKind(value string) (schema.GroupVersionKind, error) {
+	var gvk schema.GroupVersionKind
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		gv, err := schema.ParseGroupVersion(value[:i])
+		if err != nil {
+			return gvk, fmt.Errorf("invalid group version kind %q: %w, expected apiVersion/Kind like %q", value, err, "apps/v1/Deployment")
+		}
+		gvk = gv.WithKind(value[i+1:])
+	} else if parsed, _ := schema.ParseKindArg(value); parsed != nil {
+		gvk = *parsed
+	}
+	if gvk.Version == "" || gvk.Kind == "" {
+		return gvk, fmt.Errorf("invalid group version kind %q: version and kind are required, expected apiVersion/Kind like %q or Kind.version.group like %q", value, "apps/v1/Deployment", "Deployment.v1.apps")
+	}
+	return gvk, nil
+}
+
+// FormatGroupVersionKind returns gvk as apiVersion/Kind, the format parsed by ParseGroupVersionKind
+func FormatGroupVersionKind(gvk schema.GroupVersionKind) string {
+	if gvk.Empty() {
+		return ""
+	}
+	return gvk.GroupVersion().String() + "/" + gvk.Kind
+}
+
+// ParseNamespacedName parses a user supplied namespace/name, or name alone leaving the namespace empty,
+// validating the namespace as a DNS label and the name as a DNS subdomain
+func ParseNamespacedName(value string) (types.NamespacedName, error) {
+	var key types.NamespacedName
+	parts := strings.Split(value, "/")
+	switch len(parts) {
+	case 1:
+		key.Name = parts[0]
+	case 2:
+		key.Namespace, key.Name = parts[0], parts[1]
+		if errs := validation.IsDNS1123Label(key.Namespace); len(errs) > 0 {
+			return key, fmt.Errorf("invalid namespace %q in %q: %s", key.Namespace, value, strings.Join(errs, ", "))
+		}
+	default:
+		return key, fmt.Errorf("invalid namespaced name %q, expected namespace/name or name", value)
+	}
+	if errs := validation.IsDNS1123Subdomain(key.Name); len(errs) > 0 {
+		return key, fmt.Errorf("invalid name %q in %q: %s", key.Name, value, strings.Join(errs, ", "))
+	}
+	return key, nil
+}
+
+// GroupVersionKindValue is a pflag.Value parsing a schema.GroupVersionKind with ParseGroupVersionKind
+type GroupVersionKindValue struct {
+	gvk *schema.GroupVersionKind
+}
+
+var _ pflag.Value = &GroupVersionKindValue{}
+
+// NewGroupVersionKindValue returns a GroupVersionKindValue setting p
+func NewGroupVersionKindValue(p *schema.GroupVersionKind) *GroupVersionKindValue {
+	return &GroupVersionKindValue{gvk: p}
+}
+
+// GroupVersionKindVar defines a group version kind flag setting p, the current value of p is the default
+func GroupVersionKindVar(fs *pflag.FlagSet, p *schema.GroupVersionKind, name, usage string) {
+	fs.Var(NewGroupVersionKindValue(p), name, usage)
+}
+
+// Set implements pflag.Value
+func (v *GroupVersionKindValue) Set(value string) error {
+	gvk, err := ParseGroupVersionKind(value)
+	if err != nil {
+		return err
+	}
+	*v.gvk = gvk
+	return nil
+}
+
+// String implements pflag.Value
+func (v *GroupVersionKindValue) String() string {
+	if v.gvk == nil {
+		return ""
+	}
+	return FormatGroupVersionKind(*v.gvk)
+}
+
+// Type implements pflag.Value
+func (v *GroupVersionKindValue) Type() string {
+	return "apiVersion/kind"
+}
+
+// NamespacedNameValue is a pflag.Value parsing a types.NamespacedName with ParseNamespacedName
+type NamespacedNameValue struct {
+	key *types.NamespacedName
+}
+
+var _ pflag.Value = &NamespacedNameValue{}
+
+// NewNamespacedNameValue returns a NamespacedNameValue setting p
+func NewNamespacedNameValue(p *types.NamespacedName) *NamespacedNameValue {
+	return &NamespacedNameValue{key: p}
+}
+
+// NamespacedNameVar defines a namespaced name flag setting p, the current value of p is the default.
+// The namespace is left empty when only a name is given so callers can default it
+func NamespacedNameVar(fs *pflag.FlagSet, p *types.NamespacedName, name, usage string) {
+	fs.Var(NewNamespacedNameValue(p), name, usage)
+}
+
+// Set implements pflag.Value
+func (v *NamespacedNameValue) Set(value string) error {
+	key, err := ParseNamespacedName(value)
+	if err != nil {
+		return err
+	}
+	*v.key = key
+	return nil
+}
+
+// String implements pflag.Value
+func (v *NamespacedNameValue) String() string {
+	if v.key == nil || v.key.Name == "" {
+		return ""
+	}
+	if v.key.Namespace == "" {
+		return v.key.Name
+	}
+	return v.key.String()
+}
+
+// Type implements pflag.Value
+func (v *NamespacedNameValue) Type() string {
+	return "namespace/name"
+}
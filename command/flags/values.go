@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/AlaudaDevops/pkg/selector"
+)
+
+// ParseQuantity parses a user supplied resource quantity like 500m or 1Gi
+func ParseQuantity(value string) (resource.Quantity, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return quantity, fmt.Errorf("invalid quantity %q: %w, expected a quantity like %q or %q", value, err, "500m", "1Gi")
+	}
+	return quantity, nil
+}
+
+// ParseDuration parses a user supplied duration like 1m30s, negative durations are rejected
+func ParseDuration(value string) (metav1.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return metav1.Duration{}, fmt.Errorf("invalid duration %q: %w, expected a duration like %q", value, err, "1m30s")
+	}
+	if duration < 0 {
+		return metav1.Duration{}, fmt.Errorf("invalid duration %q: must not be negative", value)
+	}
+	return metav1.Duration{Duration: duration}, nil
+}
+
+// QuantityValue is a pflag.Value parsing a resource.Quantity
+type QuantityValue struct {
+	quantity *resource.Quantity
+}
+
+var _ pflag.Value = &QuantityValue{}
+
+// NewQuantityValue returns a QuantityValue setting p
+func NewQuantityValue(p *resource.Quantity) *QuantityValue {
+	return &QuantityValue{quantity: p}
+}
+
+// QuantityVar defines a quantity flag setting p, the current value of p is the default
+func QuantityVar(fs *pflag.FlagSet, p *resource.Quantity, name, usage string) {
+	fs.Var(NewQuantityValue(p), name, usage)
+}
+
+// Set implements pflag.Value
+func (v *QuantityValue) Set(value string) error {
+	quantity, err := ParseQuantity(value)
+	if err != nil {
+		return err
+	}
+	*v.quantity = quantity
+	return nil
+}
+
+// String implements pflag.Value
+func (v *QuantityValue) String() string {
+	if v.quantity == nil {
+		return ""
+	}
+	return v.quantity.String()
+}
+
+// Type implements pflag.Value
+func (v *QuantityValue) Type() string {
+	return "quantity"
+}
+
+// DurationValue is a pflag.Value parsing a metav1.Duration
+type DurationValue struct {
+	duration *metav1.Duration
+}
+
+var _ pflag.Value = &DurationValue{}
+
+// NewDurationValue returns a DurationValue setting p
+func NewDurationValue(p *metav1.Duration) *DurationValue {
+	return &DurationValue{duration: p}
+}
+
+// DurationVar defines a duration flag setting p, the current value of p is the default
+func DurationVar(fs *pflag.FlagSet, p *metav1.Duration, name, usage string) {
+	fs.Var(NewDurationValue(p), name, usage)
+}
+
+// Set implements pflag.Value
+func (v *DurationValue) Set(value string) error {
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*v.duration = duration
+	return nil
+}
+
+// String implements pflag.Value
+func (v *DurationValue) String() string {
+	if v.duration == nil {
+		return ""
+	}
+	return v.duration.Duration.String()
+}
+
+// Type implements pflag.Value
+func (v *DurationValue) Type() string {
+	return "duration"
+}
+
+// LabelSelectorValue is a pflag.Value parsing a label selector, see selector.LabelsFlag
+type LabelSelectorValue = selector.LabelsFlag
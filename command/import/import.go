@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+const (
+	// DefaultApplySetName name of the apply set parent when none is given
+	DefaultApplySetName = "import"
+	// DefaultFieldManager field manager used to apply the objects when none is given
+	DefaultFieldManager = "import"
+)
+
+// Options options of the import command
+type Options struct {
+	// Filenames of manifest files or directories, - reads from stdin. See applyset.LoadManifests
+	Filenames []string
+	// Namespace of the apply set parent and of the objects without namespace,
+	// defaults to the kubeflags namespace or default
+	Namespace string
+	// TargetNamespace moves every namespaced object into the namespace,
+	// it takes precedence over NamespaceMappings
+	TargetNamespace string
+	// NamespaceMappings moves the objects of a namespace, the key, into another namespace, the value
+	NamespaceMappings map[string]string
+	// NamePrefix is prepended to the name of every object. References between objects are not renamed
+	NamePrefix string
+	// ApplySet name of the apply set parent, defaults to DefaultApplySetName
+	ApplySet string
+	// FieldManager used to apply the objects, defaults to DefaultFieldManager
+	FieldManager string
+	// DryRun applies the objects using a server-side dry run
+	DryRun bool
+	// Prune deletes the objects of previous imports of the same apply set which are no longer imported
+	Prune bool
+	// NewClient returns the client used to apply objects, defaults to kubeflags.DefaultClientFunc
+	NewClient kubeflags.ClientFunc
+}
+
+// AddFlags add flags to options
+func (opts *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&opts.Filenames, "filename", "f", opts.Filenames, "files or directories with the manifests to import, - reads from stdin")
+	cmd.Flags().StringVar(&opts.TargetNamespace, "target-namespace", opts.TargetNamespace, "move every namespaced object into the namespace")
+	cmd.Flags().StringToStringVar(&opts.NamespaceMappings, "namespace-mapping", opts.NamespaceMappings, "move the objects of a namespace into another, e.g. source=target")
+	cmd.Flags().StringVar(&opts.NamePrefix, "name-prefix", opts.NamePrefix, "prefix prepended to the name of every object")
+	cmd.Flags().StringVar(&opts.ApplySet, "applyset", opts.ApplySet, "name of the apply set parent, defaults to "+DefaultApplySetName)
+	cmd.Flags().StringVar(&opts.FieldManager, "field-manager", opts.FieldManager, "field manager used for the server-side apply")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", opts.DryRun, "apply the objects using a server-side dry run")
+	cmd.Flags().BoolVar(&opts.Prune, "prune", opts.Prune, "delete the objects of previous imports of the apply set which are no longer imported")
+}
+
+// NewCommand returns a SubcommandFunc of the import subcommand
+//
+//	cmd := root.NewRootCommand(ctx, "mycli", importcmd.NewCommand(&importcmd.Options{}))
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "import -f FILENAME",
+			Short: "Import manifests written by export into the cluster",
+			Long: fmt.Sprintf(`Import manifests written by export into the cluster.
+
+The objects are applied with server-side apply as part of an apply set so a later
+import with --prune deletes the objects no longer imported. %s can move the objects
+into other namespaces and prefix their names, references between objects are kept as is.`, name),
+			Args:         cobra.NoArgs,
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if len(opts.Filenames) == 0 {
+					return fmt.Errorf("at least one --filename is required")
+				}
+				clientFunc := opts.NewClient
+				if clientFunc == nil {
+					clientFunc = kubeflags.DefaultClientFunc
+				}
+				clt, err := clientFunc(cmd.Context())
+				if err != nil {
+					return err
+				}
+				objs, err := applyset.LoadManifests(opts.Filenames...)
+				if err != nil {
+					return err
+				}
+				return opts.Run(cmd.Context(), clt, objs, pkgio.MustGetIOStreams(ctx).Out)
+			},
+		}
+		opts.AddFlags(cmd)
+		// reuses the persistent --namespace flag of kubeflags when available
+		if kubeflags.GetKubeFlags(ctx) == nil {
+			cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", opts.Namespace, "namespace of the apply set parent and of the objects without namespace")
+		}
+		return cmd
+	}
+}
+
+// Run remaps objs and applies them, printing one line per change into out
+func (opts *Options) Run(ctx context.Context, clt client.Client, objs []*unstructured.Unstructured, out io.Writer) error {
+	namespace, err := opts.namespace(ctx)
+	if err != nil {
+		return err
+	}
+	name := opts.ApplySet
+	if name == "" {
+		name = DefaultApplySetName
+	}
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	applyOpts := []applyset.Option{applyset.WithFieldManager(fieldManager)}
+	if opts.DryRun {
+		applyOpts = append(applyOpts, applyset.WithDryRun())
+	}
+	if opts.Prune {
+		applyOpts = append(applyOpts, applyset.WithPrune())
+	}
+
+	remapped := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		remapped = append(remapped, opts.Remap(obj))
+	}
+	result, err := applyset.New(clt, name, namespace, applyOpts...).Apply(ctx, remapped)
+	if result != nil {
+		if printErr := result.Print(out); err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
+
+// Remap returns a copy of obj moved into the target namespace and with the name prefix.
+// Objects without namespace are left to the apply set which defaults them to its namespace
+func (opts *Options) Remap(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	if namespace := obj.GetNamespace(); namespace != "" {
+		switch mapped, ok := opts.NamespaceMappings[namespace]; {
+		case opts.TargetNamespace != "":
+			obj.SetNamespace(opts.TargetNamespace)
+		case ok:
+			obj.SetNamespace(mapped)
+		}
+	}
+	if opts.NamePrefix != "" {
+		obj.SetName(opts.NamePrefix + obj.GetName())
+	}
+	return obj
+}
+
+func (opts *Options) namespace(ctx context.Context) (string, error) {
+	switch {
+	case opts.Namespace != "":
+		return opts.Namespace, nil
+	case opts.TargetNamespace != "":
+		return opts.TargetNamespace, nil
+	}
+	if kubeflags.GetKubeFlags(ctx) != nil {
+		namespace, err := kubeflags.GetNamespace(ctx)
+		if err != nil || namespace != "" {
+			return namespace, err
+		}
+	}
+	return "default", nil
+}
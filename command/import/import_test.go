@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importcmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+	"github.com/AlaudaDevops/pkg/command/export"
+	"github.com/AlaudaDevops/pkg/command/io"
+	ktesting "github.com/AlaudaDevops/pkg/testing"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newClient(objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).WithObjects(objs...).
+		WithInterceptorFuncs(ktesting.WithoutServerSideApply(interceptor.Funcs{})).Build()
+}
+
+func TestImportCommand_roundTrip(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	source := newClient(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "a", UID: "uid-a"}, Data: map[string]string{"key": "value"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "b"}},
+	)
+	g.Expect((&export.Options{Namespace: "dev", OutputDir: dir}).Run(context.Background(), source, []string{"configmaps"}, nil)).To(Succeed())
+
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+	target := newClient()
+	cmd := NewCommand(&Options{
+		NewClient: func(context.Context) (client.Client, error) { return target, nil },
+	})(ctx, "cli")
+	cmd.SetArgs([]string{"-f", dir, "--target-namespace", "prod", "--name-prefix", "copy-"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(Equal("configmap/copy-a created\nconfigmap/copy-b created\n"))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(target.Get(ctx, client.ObjectKey{Namespace: "prod", Name: "copy-a"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+	g.Expect(cm.UID).NotTo(Equal(types.UID("uid-a")))
+	g.Expect(cm.Labels).To(HaveKeyWithValue(applyset.PartOfLabel, applyset.ID(DefaultApplySetName, "prod")))
+}
+
+func TestImportCommand_requiresFilename(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	streams, _, _, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+	cmd := NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{})
+	g.Expect(cmd.ExecuteContext(ctx)).To(MatchError("at least one --filename is required"))
+}
+
+func TestOptionsRun_dryRunPrune(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := context.Background()
+	clt := newClient()
+	objs := []*unstructured.Unstructured{newConfigMap("dev", "a"), newConfigMap("dev", "b")}
+	out := &strings.Builder{}
+	g.Expect((&Options{ApplySet: "copy", Prune: true}).Run(ctx, clt, objs, out)).To(Succeed())
+
+	out.Reset()
+	g.Expect((&Options{ApplySet: "copy", Prune: true, DryRun: true}).Run(ctx, clt, objs[:1], out)).To(Succeed())
+	g.Expect(out.String()).To(Equal("configmap/a unchanged (dry run)\nconfigmap/b pruned (dry run)\n"))
+	g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "dev", Name: "b"}, &corev1.ConfigMap{})).To(Succeed())
+}
+
+func TestOptionsRemap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	opts := &Options{NamespaceMappings: map[string]string{"dev": "test"}}
+	obj := newConfigMap("dev", "a")
+	g.Expect(opts.Remap(obj).GetNamespace()).To(Equal("test"))
+	g.Expect(obj.GetNamespace()).To(Equal("dev"))
+	g.Expect(opts.Remap(newConfigMap("other", "a")).GetNamespace()).To(Equal("other"))
+	g.Expect(opts.Remap(newConfigMap("", "a")).GetNamespace()).To(BeEmpty())
+
+	opts.TargetNamespace = "prod"
+	opts.NamePrefix = "copy-"
+	remapped := opts.Remap(obj)
+	g.Expect(remapped.GetNamespace()).To(Equal("prod"))
+	g.Expect(remapped.GetName()).To(Equal("copy-a"))
+}
+
+func newConfigMap(namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
@@ -21,28 +21,19 @@ import (
 	"os"
 
 	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
-)
 
-// key for reading/writing into context
-type ioStreamsKey struct{}
+	"github.com/AlaudaDevops/pkg/ctxutil"
+)
 
 // WithIOStreams adds IOStreams into the context
 func WithIOStreams(ctx context.Context, ioStreams *clioptions.IOStreams) context.Context {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	return context.WithValue(ctx, ioStreamsKey{}, ioStreams)
+	return ctxutil.With(ctx, ioStreams)
 }
 
 // GetIOStreams returns IOStreams stored in the context if any
 // if not found will return nil *IOStreams
 func GetIOStreams(ctx context.Context) (ioStreams *clioptions.IOStreams) {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	if val := ctx.Value(ioStreamsKey{}); val != nil {
-		ioStreams = val.(*clioptions.IOStreams)
-	}
+	ioStreams, _ = ctxutil.From[*clioptions.IOStreams](ctx)
 	return
 }
 
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeflags provides the kubeconfig and cluster connection flags
+// --kubeconfig, --context, --namespace, --as and --request-timeout for clis,
+// and accessors to the resulting rest config, client and namespace stored in the context.
+//
+// When KubeFlags are stored in the context given to root.NewRootCommand
+// the flags are added as persistent flags of the root command:
+//
+//	ctx = kubeflags.WithKubeFlags(ctx, kubeflags.NewKubeFlags())
+//	cmd := root.NewRootCommand(ctx, "my-cli", subcommands...)
+//
+// Subcommands then obtain a client with kubeflags.GetClient(ctx) once flags are parsed.
+package kubeflags
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeflags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	kscheme "github.com/AlaudaDevops/pkg/scheme"
+	"github.com/AlaudaDevops/pkg/warnings"
+)
+
+// KubeFlags cluster connection flags
+type KubeFlags struct {
+	*genericclioptions.ConfigFlags
+}
+
+// NewKubeFlags returns KubeFlags with the --kubeconfig, --context, --namespace,
+// --as and --request-timeout flags
+func NewKubeFlags() *KubeFlags {
+	empty := func() *string {
+		s := ""
+		return &s
+	}
+	timeout := "0"
+	return &KubeFlags{
+		ConfigFlags: &genericclioptions.ConfigFlags{
+			KubeConfig:  empty(),
+			Context:     empty(),
+			Namespace:   empty(),
+			Impersonate: empty(),
+			Timeout:     &timeout,
+		},
+	}
+}
+
+// AddFlags add flags to the flag set
+func (f *KubeFlags) AddFlags(flags *pflag.FlagSet) {
+	f.ConfigFlags.AddFlags(flags)
+}
+
+// GetRESTConfig returns the rest config resolved from the flags
+func (f *KubeFlags) GetRESTConfig() (*rest.Config, error) {
+	return f.ToRESTConfig()
+}
+
+// GetNamespace returns the namespace of the --namespace flag,
+// or the namespace of the current kubeconfig context when not set
+func (f *KubeFlags) GetNamespace() (string, error) {
+	namespace, _, err := f.ToRawKubeConfigLoader().Namespace()
+	return namespace, err
+}
+
+// GetClient returns a client using the rest config resolved from the flags and scheme,
+// the client-go scheme is used when scheme is nil
+func (f *KubeFlags) GetClient(scheme *runtime.Scheme) (client.Client, error) {
+	config, err := f.GetRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newClient(config, scheme)
+}
+
+func newClient(config *rest.Config, scheme *runtime.Scheme) (client.Client, error) {
+	if scheme == nil {
+		scheme = clientgoscheme.Scheme
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// key for reading/writing into context
+type kubeFlagsKey struct{}
+
+// WithKubeFlags adds KubeFlags into the context
+func WithKubeFlags(ctx context.Context, flags *KubeFlags) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, kubeFlagsKey{}, flags)
+}
+
+// GetKubeFlags returns KubeFlags stored in the context if any
+// if not found will return nil *KubeFlags
+func GetKubeFlags(ctx context.Context) (flags *KubeFlags) {
+	if ctx == nil {
+		return nil
+	}
+	flags, _ = ctx.Value(kubeFlagsKey{}).(*KubeFlags)
+	return
+}
+
+// GetRESTConfig returns the rest config resolved from the KubeFlags in the context,
+// api server warnings are sent to the warnings.Collector in the context if any
+func GetRESTConfig(ctx context.Context) (*rest.Config, error) {
+	flags, err := mustGetKubeFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config, err := flags.GetRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	if collector := warnings.CollectorFrom(ctx); collector != nil {
+		config = warnings.ConfigWithCollector(config, collector)
+	}
+	return config, nil
+}
+
+// GetNamespace returns the namespace resolved from the KubeFlags in the context
+func GetNamespace(ctx context.Context) (string, error) {
+	flags, err := mustGetKubeFlags(ctx)
+	if err != nil {
+		return "", err
+	}
+	return flags.GetNamespace()
+}
+
+// GetClient returns a client resolved from the KubeFlags in the context,
+// using the scheme and the warnings.Collector in the context if any
+func GetClient(ctx context.Context) (client.Client, error) {
+	config, err := GetRESTConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(config, kscheme.Scheme(ctx))
+}
+
+// ClientFunc returns a client to access the cluster
+type ClientFunc func(ctx context.Context) (client.Client, error)
+
+// DefaultClientFunc returns a client using the KubeFlags in the context if any,
+// otherwise the default kubeconfig resolution
+func DefaultClientFunc(ctx context.Context) (client.Client, error) {
+	if GetKubeFlags(ctx) != nil {
+		return GetClient(ctx)
+	}
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(restConfig, client.Options{})
+}
+
+// GetClientset returns a client-go clientset resolved from the KubeFlags in the context,
+// used for subresources like logs, exec and port forwarding
+func GetClientset(ctx context.Context) (kubernetes.Interface, error) {
+	config, err := GetRESTConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+func mustGetKubeFlags(ctx context.Context) (*KubeFlags, error) {
+	flags := GetKubeFlags(ctx)
+	if flags == nil {
+		return nil, fmt.Errorf("kube flags not found in context")
+	}
+	return flags, nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeflags
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/warnings"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+func parse(g *WithT, args ...string) *KubeFlags {
+	flags := NewKubeFlags()
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(flagSet)
+	g.Expect(flagSet.Parse(args)).To(Succeed())
+	return flags
+}
+
+func TestKubeFlags(t *testing.T) {
+	var data = []struct {
+		desc string
+		args []string
+
+		host      string
+		namespace string
+		as        string
+		timeout   time.Duration
+	}{
+		{
+			desc:      "current context",
+			args:      []string{"--kubeconfig", "testdata/kubeconfig.yaml"},
+			host:      "https://dev.example.com",
+			namespace: "dev-ns",
+		},
+		{
+			desc:      "explicit context and namespace",
+			args:      []string{"--kubeconfig", "testdata/kubeconfig.yaml", "--context", "prod", "-n", "team"},
+			host:      "https://prod.example.com",
+			namespace: "team",
+		},
+		{
+			desc:      "context without namespace",
+			args:      []string{"--kubeconfig", "testdata/kubeconfig.yaml", "--context", "prod"},
+			host:      "https://prod.example.com",
+			namespace: "default",
+		},
+		{
+			desc:      "impersonation and timeout",
+			args:      []string{"--kubeconfig", "testdata/kubeconfig.yaml", "--as", "admin", "--request-timeout", "5s"},
+			host:      "https://dev.example.com",
+			namespace: "dev-ns",
+			as:        "admin",
+			timeout:   5 * time.Second,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			flags := parse(g, item.args...)
+			ctx := WithKubeFlags(context.Background(), flags)
+
+			config, err := GetRESTConfig(ctx)
+			g.Expect(err).To(BeNil())
+			g.Expect(config.Host).To(Equal(item.host))
+			g.Expect(config.Impersonate.UserName).To(Equal(item.as))
+			g.Expect(config.Timeout).To(Equal(item.timeout))
+
+			namespace, err := GetNamespace(ctx)
+			g.Expect(err).To(BeNil())
+			g.Expect(namespace).To(Equal(item.namespace))
+		})
+	}
+}
+
+func TestKubeFlags_warningCollector(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := WithKubeFlags(context.Background(), parse(g, "--kubeconfig", "testdata/kubeconfig.yaml"))
+
+	config, err := GetRESTConfig(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(config.WarningHandler).To(BeNil())
+
+	collector := warnings.NewCollector()
+	config, err = GetRESTConfig(warnings.WithCollector(ctx, collector))
+	g.Expect(err).To(BeNil())
+	g.Expect(config.WarningHandler).To(BeIdenticalTo(collector))
+}
+
+func TestKubeFlags_notInContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(GetKubeFlags(context.Background())).To(BeNil())
+	_, err := GetRESTConfig(context.Background())
+	g.Expect(err).NotTo(BeNil())
+	_, err = GetClient(context.Background())
+	g.Expect(err).NotTo(BeNil())
+	_, err = GetNamespace(context.Background())
+	g.Expect(err).NotTo(BeNil())
+	_, err = GetClientset(context.Background())
+	g.Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeflags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// SelectPod returns the pod named name, which can be given as NAME or pod/NAME,
+// or when name is empty the best pod matching the label selector:
+// running and ready pods first, then the most recently created
+func SelectPod(ctx context.Context, pods corev1client.PodsGetter, namespace, name, selector string) (*corev1.Pod, error) {
+	if name != "" {
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "pods/"), "pod/")
+		return pods.Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if selector == "" {
+		return nil, fmt.Errorf("a pod name or a label selector is required")
+	}
+	items, err := ListPods(ctx, pods, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no pods found for selector %q in namespace %q", selector, namespace)
+	}
+	return &items[0], nil
+}
+
+// ListPods returns the pods matching the label selector which are not being deleted,
+// sorted with running and ready pods first, then the most recently created
+func ListPods(ctx context.Context, pods corev1client.PodsGetter, namespace, selector string) ([]corev1.Pod, error) {
+	list, err := pods.Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("list pods with selector %q: %w", selector, err)
+	}
+	items := make([]corev1.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		if pod.DeletionTimestamp == nil {
+			items = append(items, pod)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if ri, rj := podRank(&items[i]), podRank(&items[j]); ri != rj {
+			return ri > rj
+		}
+		return items[j].CreationTimestamp.Before(&items[i].CreationTimestamp)
+	})
+	return items, nil
+}
+
+// podRank ranks ready pods over running pods over pending pods
+func podRank(pod *corev1.Pod) int {
+	switch pod.Status.Phase {
+	case corev1.PodRunning:
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return 3
+			}
+		}
+		return 2
+	case corev1.PodPending:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeflags
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(name string, phase corev1.PodPhase, ready bool, age time.Duration) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "default", Labels: map[string]string{"app": "demo"},
+			CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age)),
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+	if ready {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return pod
+}
+
+func TestSelectPod(t *testing.T) {
+	terminating := newPod("terminating", corev1.PodRunning, true, 0)
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	clientset := fake.NewSimpleClientset(
+		newPod("pending", corev1.PodPending, false, 0),
+		newPod("old-ready", corev1.PodRunning, true, 2*time.Hour),
+		newPod("new-ready", corev1.PodRunning, true, time.Hour),
+		newPod("not-ready", corev1.PodRunning, false, 0),
+		terminating,
+	)
+	ctx := context.Background()
+
+	var data = []struct {
+		desc     string
+		name     string
+		selector string
+		expected string
+		err      string
+	}{
+		{"by name", "pending", "", "pending", ""},
+		{"by pod/name", "pod/old-ready", "", "old-ready", ""},
+		{"name takes precedence", "pending", "app=demo", "pending", ""},
+		{"newest ready pod by selector", "", "app=demo", "new-ready", ""},
+		{"no pods for selector", "", "app=other", "", "no pods found for selector"},
+		{"name or selector required", "", "", "", "a pod name or a label selector is required"},
+		{"missing pod", "missing", "", "", "not found"},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			pod, err := SelectPod(ctx, clientset.CoreV1(), "default", item.name, item.selector)
+			if item.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(item.err))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(pod.Name).To(Equal(item.expected))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	pods, err := ListPods(ctx, clientset.CoreV1(), "default", "app=demo")
+	g.Expect(err).To(BeNil())
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	g.Expect(names).To(Equal([]string{"new-ready", "old-ready", "not-ready", "pending"}))
+}
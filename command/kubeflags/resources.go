@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeflags
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KindFor resolves resource arguments like kubectl, e.g. deployments, deployment.apps
+// or deployments.v1.apps, using the preferred version when none is given
+func KindFor(mapper meta.RESTMapper, resource string) (schema.GroupVersionKind, error) {
+	gvr, gr := schema.ParseResourceArg(strings.ToLower(resource))
+	if gvr != nil {
+		if gvk, err := mapper.KindFor(*gvr); err == nil {
+			return gvk, nil
+		}
+	}
+	gvk, err := mapper.KindFor(gr.WithVersion(""))
+	if err != nil {
+		return gvk, fmt.Errorf("resource %q not found: %w", resource, err)
+	}
+	return gvk, nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeflags
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestKindFor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{deployment.GroupVersion()})
+	mapper.Add(deployment, meta.RESTScopeNamespace)
+
+	for _, resource := range []string{"deployments", "Deployment.apps", "deployments.v1.apps"} {
+		gvk, err := KindFor(mapper, resource)
+		g.Expect(err).To(BeNil(), resource)
+		g.Expect(gvk).To(Equal(deployment), resource)
+	}
+
+	_, err := KindFor(mapper, "foos")
+	g.Expect(err).To(MatchError(ContainSubstring(`resource "foos" not found`)))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLoggerWithFlags construct a logger using the encoding and levels of flags when logging.
+// Default fields are only added in json encoding, identifying the cli in structured logs
+// while keeping the console output terse, e.g. zap.String("command", name)
+func NewLoggerWithFlags(writer zapcore.WriteSyncer, flags *Flags, defaultFields []zap.Field, opts ...zap.Option) *zap.SugaredLogger {
+	core := &flagsCore{
+		writer:   writer,
+		flags:    flags,
+		defaults: defaultFields,
+	}
+	return zap.New(core, opts...).Sugar()
+}
+
+// flagsCore is a zapcore.Core choosing the encoder and level from Flags for every entry
+type flagsCore struct {
+	writer   zapcore.WriteSyncer
+	flags    *Flags
+	defaults []zapcore.Field
+	fields   []zapcore.Field
+}
+
+var _ zapcore.Core = &flagsCore{}
+
+func (c *flagsCore) Enabled(level zapcore.Level) bool {
+	return c.flags.Enabled(level)
+}
+
+func (c *flagsCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field{}, c.fields...), fields...)
+	return &clone
+}
+
+func (c *flagsCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.flags.EnabledFor(entry.LoggerName, entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *flagsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	var encoder zapcore.Encoder
+	all := make([]zapcore.Field, 0, len(c.defaults)+len(c.fields)+len(fields))
+	if c.flags.Encoding == EncodingJSON {
+		encoder = zapcore.NewJSONEncoder(jsonEncoderConfig())
+		all = append(all, c.defaults...)
+	} else {
+		encoder = zapcore.NewConsoleEncoder(consoleEncoderConfig())
+	}
+	all = append(append(all, c.fields...), fields...)
+
+	buf, err := encoder.EncodeEntry(entry, all)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+	if _, err = c.writer.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if entry.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+func (c *flagsCore) Sync() error {
+	return c.writer.Sync()
+}
+
+func jsonEncoderConfig() zapcore.EncoderConfig {
+	config := zap.NewProductionEncoderConfig()
+	config.TimeKey = "ts"
+	config.EncodeTime = zapcore.ISO8601TimeEncoder
+	config.EncodeDuration = zapcore.StringDurationEncoder
+	return config
+}
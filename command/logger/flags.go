@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoding is the format of log entries
+type Encoding string
+
+const (
+	// EncodingConsole human readable log entries, the default encoding
+	EncodingConsole Encoding = "console"
+	// EncodingJSON log entries as json objects, suitable to be parsed in CI
+	EncodingJSON Encoding = "json"
+)
+
+// Levels holds the log level of each named component, e.g. the logger
+// returned by Named(ctx, "client") uses the level of the client component.
+// Levels can be changed at runtime and implements pflag.Value parsing
+// a comma separated list of levels, e.g. info,client=debug,client.cache=warn
+type Levels struct {
+	lock       sync.RWMutex
+	level      zapcore.Level
+	components map[string]zapcore.Level
+}
+
+var _ pflag.Value = &Levels{}
+
+// NewLevels returns Levels using level for all components
+func NewLevels(level zapcore.Level) *Levels {
+	return &Levels{level: level, components: map[string]zapcore.Level{}}
+}
+
+// SetLevel changes the level of a component, an empty component changes the default level
+func (l *Levels) SetLevel(component string, level zapcore.Level) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if component == "" {
+		l.level = level
+		return
+	}
+	l.components[component] = level
+}
+
+// Level returns the level of the component, matching the longest configured
+// component prefix of the dot separated logger name
+func (l *Levels) Level(component string) zapcore.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	for name := component; name != ""; {
+		if level, ok := l.components[name]; ok {
+			return level
+		}
+		index := strings.LastIndex(name, ".")
+		if index < 0 {
+			break
+		}
+		name = name[:index]
+	}
+	return l.level
+}
+
+// MinLevel returns the lowest level among all components
+func (l *Levels) MinLevel() zapcore.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	min := l.level
+	for _, level := range l.components {
+		if level < min {
+			min = level
+		}
+	}
+	return min
+}
+
+// Set parses levels like info,client=debug
+func (l *Levels) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		component, levelText, found := strings.Cut(item, "=")
+		if !found {
+			component, levelText = "", item
+		}
+		level, err := zapcore.ParseLevel(levelText)
+		if err != nil {
+			return err
+		}
+		l.SetLevel(strings.TrimSpace(component), level)
+	}
+	return nil
+}
+
+// String returns the levels in the same format accepted by Set
+func (l *Levels) String() string {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	items := []string{l.level.String()}
+	components := make([]string, 0, len(l.components))
+	for component := range l.components {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		items = append(items, fmt.Sprintf("%s=%s", component, l.components[component]))
+	}
+	return strings.Join(items, ",")
+}
+
+// Type of the flag
+func (l *Levels) Type() string {
+	return "levels"
+}
+
+// Flags logging flags of a cli, read every time an entry is logged
+// so loggers created before parsing the flags honor their values
+type Flags struct {
+	// Verbose enables debug logs for all components
+	Verbose bool
+	// Encoding of log entries
+	Encoding Encoding
+	// Levels of each component
+	Levels *Levels
+}
+
+// NewFlags returns Flags with console encoding and info level
+func NewFlags() *Flags {
+	return &Flags{
+		Encoding: EncodingConsole,
+		Levels:   NewLevels(zapcore.InfoLevel),
+	}
+}
+
+// AddFlags add flags to the flag set
+func (f *Flags) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&f.Verbose, `verbose`, `v`, f.Verbose, `sets the Log level to be displayed.`)
+	flags.Var(f.Levels, "log-level", "log level of all or named components, e.g. info,client=debug")
+	flags.Var((*encodingValue)(&f.Encoding), "log-format", "log format. One of: (console, json)")
+}
+
+// Enabled decides whether a given logging level is enabled for any component
+func (f *Flags) Enabled(level zapcore.Level) bool {
+	if f.Verbose {
+		return true
+	}
+	return level >= f.Levels.MinLevel()
+}
+
+// EnabledFor decides whether a given logging level is enabled for a named component
+func (f *Flags) EnabledFor(component string, level zapcore.Level) bool {
+	if f.Verbose {
+		return true
+	}
+	return level >= f.Levels.Level(component)
+}
+
+// encodingValue implements pflag.Value validating encodings
+type encodingValue Encoding
+
+func (e *encodingValue) Set(value string) error {
+	switch Encoding(value) {
+	case EncodingConsole, EncodingJSON:
+		*e = encodingValue(value)
+		return nil
+	}
+	return fmt.Errorf("unsupported log format %q", value)
+}
+
+func (e *encodingValue) String() string {
+	return string(*e)
+}
+
+func (e *encodingValue) Type() string {
+	return "string"
+}
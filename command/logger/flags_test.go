@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevels(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	levels := NewLevels(zapcore.InfoLevel)
+	g.Expect(levels.Set("warn, client=debug,client.cache=error")).To(Succeed())
+	g.Expect(levels.String()).To(Equal("warn,client=debug,client.cache=error"))
+
+	var data = []struct {
+		component string
+		expected  zapcore.Level
+	}{
+		{"", zapcore.WarnLevel},
+		{"other", zapcore.WarnLevel},
+		{"client", zapcore.DebugLevel},
+		{"client.rest", zapcore.DebugLevel},
+		{"client.cache.informer", zapcore.ErrorLevel},
+		{"clientset", zapcore.WarnLevel},
+	}
+	for _, item := range data {
+		g.Expect(levels.Level(item.component)).To(Equal(item.expected), item.component)
+	}
+	g.Expect(levels.MinLevel()).To(Equal(zapcore.DebugLevel))
+
+	g.Expect(levels.Set("client=verbose")).NotTo(Succeed())
+}
+
+func TestFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	flags := NewFlags()
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(flagSet)
+	g.Expect(flagSet.Parse([]string{"--log-level", "client=debug", "--log-format", "json"})).To(Succeed())
+	g.Expect(flags.Encoding).To(Equal(EncodingJSON))
+	g.Expect(flags.Enabled(zapcore.DebugLevel)).To(BeTrue())
+	g.Expect(flags.EnabledFor("client", zapcore.DebugLevel)).To(BeTrue())
+	g.Expect(flags.EnabledFor("server", zapcore.DebugLevel)).To(BeFalse())
+
+	g.Expect(flagSet.Parse([]string{"-v"})).To(Succeed())
+	g.Expect(flags.EnabledFor("server", zapcore.DebugLevel)).To(BeTrue())
+
+	g.Expect(flagSet.Parse([]string{"--log-format", "xml"})).NotTo(Succeed())
+}
+
+func TestNewLoggerWithFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	out := &bytes.Buffer{}
+	flags := NewFlags()
+	log := NewLoggerWithFlags(zapcore.AddSync(out), flags, []zap.Field{zap.String("command", "test-cli")})
+	ctx := WithLogger(context.Background(), log.With("key", "value"))
+	client := Named(ctx, "client")
+
+	// loggers are created before the flags are changed
+	g.Expect(flags.Levels.Set("client=debug")).To(Succeed())
+	client.Debug("client debug")
+	log.Debug("root debug")
+	log.Info("console")
+	g.Expect(out.String()).To(Equal("client\tclient debug\t{\"key\": \"value\"}\nconsole\n"))
+
+	out.Reset()
+	flags.Encoding = EncodingJSON
+	Named(WithNamedLogger(ctx, "server"), "cache").Infow("json", "count", 1)
+	g.Expect(strings.TrimSpace(out.String())).To(MatchRegexp(
+		`^\{"level":"info","ts":".+","logger":"server.cache","msg":"json","command":"test-cli","key":"value","count":1\}$`))
+}
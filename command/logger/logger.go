@@ -20,30 +20,44 @@ import (
 	"context"
 
 	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/ctxutil"
 	"knative.dev/pkg/logging"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-// WithLogger set a logger instance into a context
+// WithLogger set a logger instance into a context,
+// it is also available to knative logging.FromContext
 func WithLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	// reusing knative method
+	ctx = ctxutil.With(ctx, logger)
 	return logging.WithLogger(ctx, logger)
 }
 
-// GetLogger get a logger instance form a context
+// GetLogger get a logger instance form a context,
+// falling back to the logger of knative logging.FromContext
 func GetLogger(ctx context.Context) (logger *zap.SugaredLogger) {
 	if ctx == nil {
 		return nil
 	}
+	if logger, ok := ctxutil.From[*zap.SugaredLogger](ctx); ok {
+		return logger
+	}
 	// reusing method from knative
 	return logging.FromContext(ctx)
 }
 
+// Named returns a child logger of the logger in the context, or of a default logger,
+// using the level of the named component when created with NewLoggerWithFlags
+func Named(ctx context.Context, name string) *zap.SugaredLogger {
+	return NewLoggerFromContext(ctx).Named(name)
+}
+
+// WithNamedLogger set a named child logger of the logger in the context into the context
+func WithNamedLogger(ctx context.Context, name string) context.Context {
+	return WithLogger(ctx, Named(ctx, name))
+}
+
 // NewLoggerFromContext similar to `GetLogger`, but return a default logger if there is no
 // logger instance in the context
 func NewLoggerFromContext(ctx context.Context) (logger *zap.SugaredLogger) {
@@ -56,7 +70,12 @@ func NewLoggerFromContext(ctx context.Context) (logger *zap.SugaredLogger) {
 
 // NewLogger construct a logger
 func NewLogger(writer zapcore.WriteSyncer, level zapcore.LevelEnabler, opts ...zap.Option) *zap.SugaredLogger {
-	encoderCfg := zapcore.EncoderConfig{
+	core := zapcore.NewCore(zapcore.NewConsoleEncoder(consoleEncoderConfig()), writer, level)
+	return zap.New(core, opts...).Sugar()
+}
+
+func consoleEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
 		MessageKey: "msg",
 		LevelKey:   "level",
 		NameKey:    "logger",
@@ -69,9 +88,6 @@ func NewLogger(writer zapcore.WriteSyncer, level zapcore.LevelEnabler, opts ...z
 		EncodeTime:     zapcore.ISO8601TimeEncoder,
 		EncodeDuration: zapcore.StringDurationEncoder,
 	}
-
-	core := zapcore.NewCore(zapcore.NewConsoleEncoder(encoderCfg), writer, level)
-	return zap.New(core, opts...).Sugar()
 }
 
 // EmojiLevelEncoder prints an emoji instead of the log level
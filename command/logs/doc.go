@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logs streams and interleaves the logs of all the containers of the
+// pods matching a label selector for cli subcommands, like kubectl logs -l.
+//
+// Each line is prefixed with its pod and container, colored when the output
+// is a terminal. In follow mode pods are watched so the logs of pods created
+// or restarted while following are streamed as well:
+//
+//	opts := &logs.Options{}
+//	opts.AddFlags(cmd.Flags())
+//	...
+//	return opts.Run(cmd.Context())
+package logs
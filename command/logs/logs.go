@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/progress"
+)
+
+// Options options to stream the logs of the pods matching a selector
+type Options struct {
+	// Namespace of the pods, defaults to the kubeflags namespace
+	Namespace string
+	// Selector is the label selector of the pods
+	Selector string
+	// Container only streams the containers with this name, all containers are streamed when empty
+	Container string
+	// Follow streams new logs until the context is done, including the logs of pods created while following
+	Follow bool
+	// Since only returns logs newer than the duration, all logs when zero
+	Since time.Duration
+	// Tail is the number of recent lines to show of each container, all lines when negative
+	Tail int64
+	// Timestamps includes timestamps on each line
+	Timestamps bool
+	// NoPrefix disables the [pod/NAME/CONTAINER] prefix of each line
+	NoPrefix bool
+	// NoColor disables colors, otherwise colors are used when the output is a terminal
+	NoColor bool
+}
+
+// NewOptions returns Options streaming all lines with prefixes
+func NewOptions() *Options {
+	return &Options{Tail: -1}
+}
+
+// AddFlags add flags to the flag set
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Selector, "selector", "l", o.Selector, "label selector of the pods")
+	flags.StringVarP(&o.Container, "container", "c", o.Container, "only print the logs of this container, defaults to all containers")
+	flags.BoolVarP(&o.Follow, "follow", "f", o.Follow, "stream new logs, including the logs of new pods")
+	flags.DurationVar(&o.Since, "since", o.Since, "only return logs newer than a relative duration like 5s, 2m or 3h")
+	flags.Int64Var(&o.Tail, "tail", o.Tail, "lines of recent logs to display for each container, -1 shows all lines")
+	flags.BoolVar(&o.Timestamps, "timestamps", o.Timestamps, "include timestamps on each line")
+	flags.BoolVar(&o.NoPrefix, "no-prefix", o.NoPrefix, "do not prefix each line with the pod and container name")
+	flags.BoolVar(&o.NoColor, "no-color", o.NoColor, "do not colorize the prefixes")
+}
+
+// Run streams the logs using the kubeflags and the IOStreams in the context
+func (o *Options) Run(ctx context.Context) error {
+	clientset, err := kubeflags.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	if o.Namespace == "" {
+		if o.Namespace, err = kubeflags.GetNamespace(ctx); err != nil {
+			return err
+		}
+	}
+	return o.Stream(ctx, clientset.CoreV1(), cliio.MustGetIOStreams(ctx))
+}
+
+// Stream writes the interleaved logs of the pods into the output stream.
+// Without Follow it returns once all logs are written, joining the errors of every container.
+// With Follow it returns when ctx is done, errors are written into the error stream
+func (o *Options) Stream(ctx context.Context, pods corev1client.PodsGetter, streams *clioptions.IOStreams) error {
+	if o.Selector == "" {
+		return fmt.Errorf("a label selector is required")
+	}
+	s := newStreamer(o, pods.Pods(o.Namespace), streams)
+
+	list, err := s.pods.List(ctx, metav1.ListOptions{LabelSelector: o.Selector})
+	if err != nil {
+		return fmt.Errorf("list pods with selector %q: %w", o.Selector, err)
+	}
+	if !o.Follow {
+		if len(list.Items) == 0 {
+			return fmt.Errorf("no pods found for selector %q in namespace %q", o.Selector, o.Namespace)
+		}
+		for i := range list.Items {
+			s.sync(ctx, &list.Items[i])
+		}
+		s.wait()
+		return errors.Join(s.errs...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.wait()
+	}()
+	s.resync(ctx, list)
+	return s.follow(ctx, list.ResourceVersion)
+}
+
+// follow watches the pods starting and stopping streams until ctx is done,
+// pods are listed again when the watch is closed by the server
+func (s *streamer) follow(ctx context.Context, resourceVersion string) error {
+	for {
+		w, err := s.pods.Watch(ctx, metav1.ListOptions{LabelSelector: s.opts.Selector, ResourceVersion: resourceVersion})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("watch pods with selector %q: %w", s.opts.Selector, err)
+		}
+		s.handleEvents(ctx, w)
+		w.Stop()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		list, err := s.pods.List(ctx, metav1.ListOptions{LabelSelector: s.opts.Selector})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("list pods with selector %q: %w", s.opts.Selector, err)
+		}
+		s.resync(ctx, list)
+		resourceVersion = list.ResourceVersion
+	}
+}
+
+// handleEvents handles the events of w until it is closed or ctx is done
+func (s *streamer) handleEvents(ctx context.Context, w watch.Interface) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			pod, isPod := event.Object.(*corev1.Pod)
+			if !isPod {
+				continue
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				s.sync(ctx, pod)
+			case watch.Deleted:
+				s.remove(pod)
+			}
+		}
+	}
+}
+
+// logOptions returns the PodLogOptions of container
+func (o *Options) logOptions(container string) *corev1.PodLogOptions {
+	options := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     o.Follow,
+		Timestamps: o.Timestamps,
+	}
+	if o.Since > 0 {
+		options.SinceSeconds = ptr.To(int64(math.Ceil(o.Since.Seconds())))
+	}
+	if o.Tail >= 0 {
+		options.TailLines = ptr.To(o.Tail)
+	}
+	return options
+}
+
+// color returns true if prefixes should be colored
+func (o *Options) color(streams *clioptions.IOStreams) bool {
+	return !o.NoColor && progress.IsTerminal(streams.Out)
+}
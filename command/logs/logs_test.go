@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func newPod(name string, restarts int32, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: name, Namespace: "default", UID: types.UID("uid-" + name), Labels: map[string]string{"app": "api"},
+	}}
+	for _, container := range containers {
+		status := corev1.ContainerStatus{Name: container, RestartCount: restarts}
+		if strings.HasPrefix(container, "waiting") {
+			status.State.Waiting = &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}
+		} else {
+			status.State.Running = &corev1.ContainerStateRunning{}
+		}
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, status)
+	}
+	return pod
+}
+
+func lines(out string) []string {
+	return strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+}
+
+func TestOptions_Stream(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		newPod("api-0", 0, "main", "sidecar"),
+		newPod("api-1", 0, "main", "waiting"),
+	)
+
+	var data = []struct {
+		desc     string
+		opts     Options
+		expected []string
+		err      string
+	}{
+		{
+			desc: "all started containers",
+			opts: Options{Selector: "app=api"},
+			expected: []string{
+				"[pod/api-0/main] fake logs",
+				"[pod/api-0/sidecar] fake logs",
+				"[pod/api-1/main] fake logs",
+			},
+		},
+		{
+			desc:     "single container without prefix",
+			opts:     Options{Selector: "app=api", Container: "sidecar", NoPrefix: true},
+			expected: []string{"fake logs"},
+		},
+		{
+			desc: "no pods",
+			opts: Options{Selector: "app=other"},
+			err:  "no pods found for selector \"app=other\" in namespace \"default\"",
+		},
+		{
+			desc: "selector is required",
+			opts: Options{},
+			err:  "a label selector is required",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			streams, _, out, _ := clioptions.NewTestIOStreams()
+			opts := item.opts
+			opts.Namespace = "default"
+
+			err := opts.Stream(context.Background(), clientset.CoreV1(), &streams)
+			if item.err != "" {
+				g.Expect(err).To(MatchError(item.err))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(lines(out.String())).To(ConsistOf(item.expected))
+		})
+	}
+}
+
+func TestOptions_StreamFollow(t *testing.T) {
+	g := NewGomegaWithT(t)
+	clientset := fake.NewSimpleClientset(newPod("api-0", 0, "main"))
+	watcher := watch.NewFake()
+	clientset.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+		return true, watcher, nil
+	})
+
+	// gbytes.Buffer is safe to read while logs are written
+	out := gbytes.NewBuffer()
+	streams, _, _, _ := clioptions.NewTestIOStreams()
+	streams.Out = out
+	opts := &Options{Namespace: "default", Selector: "app=api", Follow: true, Tail: -1}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- opts.Stream(ctx, clientset.CoreV1(), &streams)
+	}()
+
+	// a new pod starting
+	watcher.Add(newPod("api-1", 0, "waiting"))
+	watcher.Modify(newPod("api-1", 0, "main"))
+	// an update without restart is not streamed again
+	watcher.Modify(newPod("api-1", 0, "main"))
+	// a restarted container is streamed again
+	watcher.Modify(newPod("api-1", 1, "main"))
+	output := func() []string {
+		return lines(string(out.Contents()))
+	}
+	g.Eventually(output).WithTimeout(5 * time.Second).Should(ConsistOf(
+		"[pod/api-0/main] fake logs",
+		"[pod/api-1/main] fake logs",
+		"[pod/api-1/main] fake logs",
+	))
+
+	// a recreated pod is streamed again
+	watcher.Delete(newPod("api-0", 0, "main"))
+	watcher.Add(newPod("api-0", 0, "main"))
+	g.Eventually(output).WithTimeout(5 * time.Second).Should(HaveLen(4))
+	g.Expect(output()[3]).To(Equal("[pod/api-0/main] fake logs"))
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
+
+func TestOptions_logOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	opts := NewOptions()
+	g.Expect(opts.logOptions("main")).To(Equal(&corev1.PodLogOptions{Container: "main"}))
+
+	opts.Since = 1500 * time.Millisecond
+	opts.Tail = 10
+	opts.Follow = true
+	opts.Timestamps = true
+	g.Expect(opts.logOptions("main")).To(Equal(&corev1.PodLogOptions{
+		Container: "main", Follow: true, Timestamps: true,
+		SinceSeconds: ptr.To(int64(2)), TailLines: ptr.To(int64(10)),
+	}))
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// colors used for the prefixes, chosen by the hash of the pod name
+var colors = []string{"\x1b[32m", "\x1b[33m", "\x1b[34m", "\x1b[35m", "\x1b[36m", "\x1b[92m", "\x1b[93m", "\x1b[94m", "\x1b[95m", "\x1b[96m"}
+
+const colorReset = "\x1b[0m"
+
+// containerKey identifies a container of a pod
+type containerKey struct {
+	pod       string
+	container string
+}
+
+// instance identifies a run of a container, a new instance is
+// streamed when the pod is recreated or the container restarted
+type instance struct {
+	uid      types.UID
+	restarts int32
+}
+
+// activeStream is a container being streamed
+type activeStream struct {
+	cancel context.CancelFunc
+}
+
+// streamer streams the logs of many containers into the same output
+type streamer struct {
+	opts    *Options
+	pods    corev1client.PodInterface
+	streams *clioptions.IOStreams
+	color   bool
+
+	wg   sync.WaitGroup
+	lock sync.Mutex
+	// active holds the containers being streamed
+	active map[containerKey]*activeStream
+	// streamed holds the last instance streamed of each container
+	streamed map[containerKey]instance
+	errs     []error
+	// out serializes the writes of every line
+	out sync.Mutex
+}
+
+func newStreamer(opts *Options, pods corev1client.PodInterface, streams *clioptions.IOStreams) *streamer {
+	return &streamer{
+		opts:     opts,
+		pods:     pods,
+		streams:  streams,
+		color:    opts.color(streams),
+		active:   map[containerKey]*activeStream{},
+		streamed: map[containerKey]instance{},
+	}
+}
+
+// resync syncs the listed pods and stops the streams of pods not found anymore
+func (s *streamer) resync(ctx context.Context, list *corev1.PodList) {
+	found := map[string]bool{}
+	for i := range list.Items {
+		found[list.Items[i].Name] = true
+		s.sync(ctx, &list.Items[i])
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, active := range s.active {
+		if !found[key.pod] {
+			active.cancel()
+			delete(s.active, key)
+		}
+	}
+	for key := range s.streamed {
+		if !found[key.pod] {
+			delete(s.streamed, key)
+		}
+	}
+}
+
+// sync starts streaming the started containers of pod which were not streamed yet
+func (s *streamer) sync(ctx context.Context, pod *corev1.Pod) {
+	if pod.DeletionTimestamp != nil && s.opts.Follow {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, status := range pod.Status.ContainerStatuses {
+		if s.opts.Container != "" && status.Name != s.opts.Container {
+			continue
+		}
+		if status.State.Running == nil && status.State.Terminated == nil {
+			// the container did not start yet, it is streamed once it starts while following
+			continue
+		}
+		key := containerKey{pod: pod.Name, container: status.Name}
+		current := instance{uid: pod.UID, restarts: status.RestartCount}
+		// a restarted container is streamed while the stream of its previous instance ends
+		if last, ok := s.streamed[key]; ok && last == current {
+			continue
+		}
+		s.streamed[key] = current
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		active := &activeStream{cancel: cancel}
+		s.active[key] = active
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer cancel()
+			err := s.stream(streamCtx, key)
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			// the pod may have been recreated and streamed again meanwhile
+			if s.active[key] == active {
+				delete(s.active, key)
+			}
+			if err == nil || streamCtx.Err() != nil {
+				return
+			}
+			if s.opts.Follow {
+				fmt.Fprintf(s.streams.ErrOut, "error: %v\n", err)
+				return
+			}
+			s.errs = append(s.errs, err)
+		}()
+	}
+}
+
+// remove stops streaming the containers of a deleted pod
+func (s *streamer) remove(pod *corev1.Pod) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, active := range s.active {
+		if key.pod == pod.Name {
+			active.cancel()
+			delete(s.active, key)
+		}
+	}
+	for key := range s.streamed {
+		if key.pod == pod.Name {
+			delete(s.streamed, key)
+		}
+	}
+}
+
+// wait waits for all streams to finish
+func (s *streamer) wait() {
+	s.wg.Wait()
+}
+
+// stream copies the logs of the container line by line into the output stream
+func (s *streamer) stream(ctx context.Context, key containerKey) error {
+	reader, err := s.pods.GetLogs(key.pod, s.opts.logOptions(key.container)).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("get logs of pod %s container %s: %w", key.pod, key.container, err)
+	}
+	defer reader.Close()
+
+	prefix := s.prefix(key)
+	buffered := bufio.NewReader(reader)
+	for {
+		line, err := buffered.ReadString('\n')
+		if line != "" {
+			if line[len(line)-1] != '\n' {
+				line += "\n"
+			}
+			if writeErr := s.writeLine(prefix, line); writeErr != nil {
+				return writeErr
+			}
+		}
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return fmt.Errorf("read logs of pod %s container %s: %w", key.pod, key.container, err)
+		}
+	}
+}
+
+// writeLine writes a whole line so lines of different containers are not mixed
+func (s *streamer) writeLine(prefix, line string) error {
+	s.out.Lock()
+	defer s.out.Unlock()
+	_, err := io.WriteString(s.streams.Out, prefix+line)
+	return err
+}
+
+// prefix returns the prefix of the lines of the container
+func (s *streamer) prefix(key containerKey) string {
+	if s.opts.NoPrefix {
+		return ""
+	}
+	prefix := fmt.Sprintf("[pod/%s/%s]", key.pod, key.container)
+	if s.color {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(key.pod))
+		prefix = colors[hash.Sum32()%uint32(len(colors))] + prefix + colorReset
+	}
+	return prefix + " "
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/migration"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationsOptions options of the migrate annotations command
+type AnnotationsOptions struct {
+	// Migrator with the rules to apply
+	Migrator *migration.Migrator
+	// Resources are the kinds of resources to migrate
+	Resources []schema.GroupVersionKind
+	// NewClient returns the client used to migrate, defaults to kubeflags.DefaultClientFunc
+	NewClient kubeflags.ClientFunc
+
+	DryRun        bool
+	Namespace     string
+	AllNamespaces bool
+}
+
+// AddFlags add flags to options
+func (opts *AnnotationsOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "only print the objects that would be migrated")
+	cmd.Flags().BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "migrate resources in all namespaces")
+}
+
+// Summary of a migration run
+type Summary struct {
+	// Total number of objects checked
+	Total int
+	// Migrated number of objects migrated, or that would be migrated in dry run
+	Migrated int
+	// Failed number of objects that failed to migrate
+	Failed int
+}
+
+// NewCommand returns a migrate command with an annotations subcommand
+// that renames deprecated annotation and label keys
+func NewCommand(opts *AnnotationsOptions) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "migrate",
+			Short: "Migrate resources to newer conventions",
+		}
+		cmd.AddCommand(NewAnnotationsCommand(ctx, opts))
+		return cmd
+	}
+}
+
+// NewAnnotationsCommand returns the annotations command
+func NewAnnotationsCommand(ctx context.Context, opts *AnnotationsOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "annotations",
+		Short: "Rename deprecated annotation and label keys of resources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientFunc := opts.NewClient
+			if clientFunc == nil {
+				clientFunc = kubeflags.DefaultClientFunc
+			}
+			clt, err := clientFunc(cmd.Context())
+			if err != nil {
+				return err
+			}
+			run := *opts
+			if !run.AllNamespaces && run.Namespace == "" {
+				if run.Namespace, err = namespace(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			summary, err := run.Run(cmd.Context(), clt, pkgio.MustGetIOStreams(ctx).Out)
+			if err != nil {
+				return err
+			}
+			if summary.Failed > 0 {
+				return fmt.Errorf("%d objects failed to migrate", summary.Failed)
+			}
+			return nil
+		},
+	}
+	opts.AddFlags(cmd)
+	// reuses the persistent --namespace flag of kubeflags when available
+	if kubeflags.GetKubeFlags(ctx) == nil {
+		cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", opts.Namespace, "namespace of the resources to migrate, defaults to default")
+	}
+	return cmd
+}
+
+// namespace returns the namespace of the kubeflags in the context or default
+func namespace(ctx context.Context) (string, error) {
+	if kubeflags.GetKubeFlags(ctx) != nil {
+		namespace, err := kubeflags.GetNamespace(ctx)
+		if err != nil || namespace != "" {
+			return namespace, err
+		}
+	}
+	return "default", nil
+}
+
+// Run migrates all resources printing the progress to out
+func (opts *AnnotationsOptions) Run(ctx context.Context, clt client.Client, out io.Writer) (summary Summary, err error) {
+	if opts.Migrator == nil {
+		return summary, fmt.Errorf("migrator should not be nil")
+	}
+
+	listOpts := []client.ListOption{}
+	if !opts.AllNamespaces && opts.Namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(opts.Namespace))
+	}
+
+	pending := []unstructured.Unstructured{}
+	for _, gvk := range opts.Resources {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err = clt.List(ctx, list, listOpts...); err != nil {
+			return summary, fmt.Errorf("list %s: %w", gvk.Kind, err)
+		}
+		summary.Total += len(list.Items)
+		for _, item := range list.Items {
+			if opts.Migrator.NeedsMigration(&item) {
+				pending = append(pending, item)
+			}
+		}
+	}
+
+	suffix := ""
+	if opts.DryRun {
+		suffix = " (dry run)"
+	}
+	for i := range pending {
+		obj := &pending[i]
+		status := "migrated"
+		if _, err := opts.Migrator.MigrateObject(ctx, clt, obj, opts.DryRun); err != nil {
+			status = fmt.Sprintf("failed: %s", err)
+			summary.Failed++
+		} else {
+			summary.Migrated++
+		}
+		fmt.Fprintf(out, "[%d/%d] %s %s %s%s\n", i+1, len(pending), obj.GetKind(), client.ObjectKeyFromObject(obj), status, suffix)
+	}
+	fmt.Fprintf(out, "%d of %d objects migrated, %d failed%s\n", summary.Migrated, summary.Total, summary.Failed, suffix)
+	return summary, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/migration"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClient() client.Client {
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "old", Annotations: map[string]string{"cpaas.io/displayName": "old"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "new", Annotations: map[string]string{"alauda.io/displayName": "new"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "old", Annotations: map[string]string{"cpaas.io/displayName": "old"}}},
+	).Build()
+}
+
+func newOptions(clt client.Client) *AnnotationsOptions {
+	return &AnnotationsOptions{
+		Migrator:  migration.NewMigrator([]migration.Rule{migration.DomainRule("cpaas.io", "alauda.io")}, nil),
+		Resources: []schema.GroupVersionKind{corev1.SchemeGroupVersion.WithKind("ConfigMap")},
+		NewClient: func(context.Context) (client.Client, error) { return clt, nil },
+	}
+}
+
+func TestAnnotationsCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+	clt := newClient()
+
+	cmd := NewCommand(newOptions(clt))(ctx, "cli")
+	cmd.SetArgs([]string{"annotations", "--all-namespaces"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(Equal("" +
+		"[1/2] ConfigMap default/old migrated\n" +
+		"[2/2] ConfigMap other/old migrated\n" +
+		"2 of 3 objects migrated, 0 failed\n"))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "other", Name: "old"}, cm)).To(Succeed())
+	g.Expect(cm.Annotations).To(Equal(map[string]string{"alauda.io/displayName": "old"}))
+}
+
+func TestAnnotationsCommand_namespace(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+	clt := newClient()
+
+	// defaults to the default namespace without kubeflags
+	cmd := NewCommand(newOptions(clt))(ctx, "cli")
+	cmd.SetArgs([]string{"annotations", "--dry-run"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("ConfigMap default/old"))
+	g.Expect(out.String()).NotTo(ContainSubstring("ConfigMap other/old"))
+
+	// uses the persistent namespace flag of kubeflags
+	out.Reset()
+	flags := kubeflags.NewKubeFlags()
+	ctx = kubeflags.WithKubeFlags(ctx, flags)
+	cmd = NewCommand(newOptions(clt))(ctx, "cli")
+	flags.AddFlags(cmd.PersistentFlags())
+	cmd.SetArgs([]string{"-n", "other", "annotations", "--dry-run"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("ConfigMap other/old"))
+	g.Expect(out.String()).NotTo(ContainSubstring("ConfigMap default/old"))
+}
+
+func TestAnnotationsDryRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := context.Background()
+	clt := newClient()
+	opts := newOptions(clt)
+	opts.DryRun = true
+	opts.Namespace = "default"
+
+	out := &bytes.Buffer{}
+	summary, err := opts.Run(ctx, clt, out)
+	g.Expect(err).To(BeNil())
+	g.Expect(summary).To(Equal(Summary{Total: 2, Migrated: 1}))
+	g.Expect(out.String()).To(ContainSubstring("(dry run)"))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "old"}, cm)).To(Succeed())
+	g.Expect(cm.Annotations).To(HaveKey("cpaas.io/displayName"))
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package portforward forwards local ports to a pod for cli subcommands,
+// like kubectl port-forward. The pod is chosen by name or label selector
+// using the KubeFlags in the context and progress is written to the
+// IOStreams in the context:
+//
+//	opts := &portforward.Options{Selector: "app=api", Ports: []string{"8080:http"}}
+//	opts.AddFlags(cmd.Flags())
+//	...
+//	return opts.Run(cmd.Context())
+//
+// Run blocks until the context is done, Ready is called once the local
+// listeners are open which is useful for tunnel subcommands connecting to
+// the forwarded ports.
+package portforward
@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+)
+
+// DialerFunc returns the dialer upgrading the connection to the port forward url
+type DialerFunc func(config *rest.Config, method string, url *url.URL) (httpstream.Dialer, error)
+
+// Options options to forward local ports to a pod
+type Options struct {
+	// Namespace of the pod, defaults to the kubeflags namespace
+	Namespace string
+	// Pod name, as NAME or pod/NAME, takes precedence over Selector
+	Pod string
+	// Selector is a label selector choosing the pod when Pod is empty, see kubeflags.SelectPod
+	Selector string
+	// Ports to forward as [LOCAL:]REMOTE, REMOTE is a port number or the name of a container port.
+	// Without LOCAL the same port is used locally, an empty LOCAL as in :80 picks a random port
+	Ports []string
+	// Addresses to listen on, defaults to localhost
+	Addresses []string
+	// Ready is called with the forwarded ports once the local listeners are open
+	Ready func(ports []portforward.ForwardedPort)
+	// NewDialer defaults to a SPDY dialer
+	NewDialer DialerFunc
+}
+
+// AddFlags add flags to the flag set
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Selector, "selector", "l", o.Selector, "label selector choosing the pod, running and ready pods are preferred")
+	flags.StringSliceVar(&o.Addresses, "address", o.Addresses, "addresses to listen on, defaults to localhost")
+}
+
+// Run forwards the ports using the kubeflags and the IOStreams in the context until ctx is done
+func (o *Options) Run(ctx context.Context) error {
+	config, err := kubeflags.GetRESTConfig(ctx)
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	if o.Namespace == "" {
+		if o.Namespace, err = kubeflags.GetNamespace(ctx); err != nil {
+			return err
+		}
+	}
+	return o.Forward(ctx, config, clientset.CoreV1(), cliio.MustGetIOStreams(ctx))
+}
+
+// Forward forwards the ports to the pod until ctx is done,
+// forwarded connections are reported into the output streams
+func (o *Options) Forward(ctx context.Context, config *rest.Config, core corev1client.CoreV1Interface, streams *clioptions.IOStreams) error {
+	if len(o.Ports) == 0 {
+		return fmt.Errorf("at least one port is required")
+	}
+	pod, err := kubeflags.SelectPod(ctx, core, o.Namespace, o.Pod, o.Selector)
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Errorf("unable to forward ports to pod %s/%s, it is %s", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+	ports, err := TranslatePorts(pod, o.Ports)
+	if err != nil {
+		return err
+	}
+
+	req := core.RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward")
+	newDialer := o.NewDialer
+	if newDialer == nil {
+		newDialer = spdyDialer
+	}
+	dialer, err := newDialer(config, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+
+	addresses := o.Addresses
+	if len(addresses) == 0 {
+		addresses = []string{"localhost"}
+	}
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, addresses, ports, stopCh, readyCh, streams.Out, streams.ErrOut)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stopCh)
+			return
+		case <-done:
+			return
+		case <-readyCh:
+		}
+		if o.Ready != nil {
+			if forwarded, err := forwarder.GetPorts(); err == nil {
+				o.Ready(forwarded)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			close(stopCh)
+		case <-done:
+		}
+	}()
+	if err = forwarder.ForwardPorts(); err != nil {
+		return fmt.Errorf("forward ports to pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// spdyDialer returns a SPDY dialer for the url
+func spdyDialer(config *rest.Config, method string, url *url.URL) (httpstream.Dialer, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, method, url), nil
+}
+
+// TranslatePorts converts ports given as [LOCAL:]REMOTE with a named REMOTE
+// port into the LOCAL:PORT form, using the container ports of the pod
+func TranslatePorts(pod *corev1.Pod, ports []string) ([]string, error) {
+	result := make([]string, 0, len(ports))
+	for _, port := range ports {
+		local, remote, found := strings.Cut(port, ":")
+		if !found {
+			remote = port
+		}
+		number, err := remotePort(pod, remote)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !found && remote == strconv.Itoa(int(number)):
+			result = append(result, port)
+		case !found:
+			// a named port without local port listens on the same port number locally
+			result = append(result, fmt.Sprintf("%d:%d", number, number))
+		default:
+			result = append(result, fmt.Sprintf("%s:%d", local, number))
+		}
+	}
+	return result, nil
+}
+
+// remotePort returns the port number of remote, resolving container port names
+func remotePort(pod *corev1.Pod, remote string) (int32, error) {
+	if number, err := strconv.ParseUint(remote, 10, 16); err == nil {
+		if number == 0 {
+			return 0, fmt.Errorf("invalid port %q, remote port must be greater than zero", remote)
+		}
+		return int32(number), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == remote {
+				return port.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %q not found in pod %s/%s", remote, pod.Namespace, pod.Name)
+}
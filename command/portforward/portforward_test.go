@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+var pod = &corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "default", Labels: map[string]string{"app": "api"}},
+	Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name:  "main",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9090}},
+	}}},
+	Status: corev1.PodStatus{Phase: corev1.PodRunning},
+}
+
+func TestTranslatePorts(t *testing.T) {
+	var data = []struct {
+		desc     string
+		ports    []string
+		expected []string
+		err      string
+	}{
+		{"numeric ports", []string{"80", "8080:80", ":80"}, []string{"80", "8080:80", ":80"}, ""},
+		{"named ports", []string{"http", "9000:metrics", ":http"}, []string{"8080:8080", "9000:9090", ":8080"}, ""},
+		{"unknown named port", []string{"grpc"}, nil, "port \"grpc\" not found in pod default/api-0"},
+		{"zero remote port", []string{"8080:0"}, nil, "remote port must be greater than zero"},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			ports, err := TranslatePorts(pod, item.ports)
+			if item.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(item.err))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(ports).To(Equal(item.expected))
+		})
+	}
+}
+
+// coreClient uses a real rest client to build subresource urls, the fake one is nil
+type coreClient struct {
+	corev1client.CoreV1Interface
+	rest rest.Interface
+}
+
+func (c *coreClient) RESTClient() rest.Interface {
+	return c.rest
+}
+
+type failingDialer struct{}
+
+func (failingDialer) Dial(...string) (httpstream.Connection, string, error) {
+	return nil, "", errors.New("upgrade failed")
+}
+
+func TestOptions_Forward(t *testing.T) {
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	config := &rest.Config{Host: "https://cluster.example.com"}
+	base, err := corev1client.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	core := &coreClient{CoreV1Interface: fake.NewSimpleClientset(pod, pending).CoreV1(), rest: base.RESTClient()}
+
+	t.Run("forwards to the selected pod", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		var dialed *url.URL
+		opts := &Options{
+			Namespace: "default",
+			Selector:  "app=api",
+			Ports:     []string{":http"},
+			NewDialer: func(_ *rest.Config, method string, url *url.URL) (httpstream.Dialer, error) {
+				g.Expect(method).To(Equal(http.MethodPost))
+				dialed = url
+				return failingDialer{}, nil
+			},
+		}
+		streams, _, _, _ := clioptions.NewTestIOStreams()
+		err := opts.Forward(context.Background(), config, core, &streams)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("forward ports to pod default/api-0"))
+		g.Expect(dialed.Path).To(Equal("/api/v1/namespaces/default/pods/api-0/portforward"))
+	})
+
+	t.Run("requires a running pod", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		opts := &Options{Namespace: "default", Pod: "pending", Ports: []string{"80"}}
+		streams, _, _, _ := clioptions.NewTestIOStreams()
+		err := opts.Forward(context.Background(), config, core, &streams)
+		g.Expect(err).To(MatchError("unable to forward ports to pod default/pending, it is Pending"))
+	})
+
+	t.Run("requires ports", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		streams, _, _, _ := clioptions.NewTestIOStreams()
+		err := (&Options{Pod: "api-0"}).Forward(context.Background(), config, core, &streams)
+		g.Expect(err).To(MatchError("at least one port is required"))
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"time"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// condition is the minimal representation of a status condition
+type condition struct {
+	Type   string
+	Status string
+	Reason string
+}
+
+// conditions returns the status.conditions of an unstructured object
+func conditions(obj *unstructured.Unstructured) []condition {
+	items, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	result := make([]condition, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := condition{}
+		c.Type, _, _ = unstructured.NestedString(m, "type")
+		c.Status, _, _ = unstructured.NestedString(m, "status")
+		c.Reason, _, _ = unstructured.NestedString(m, "reason")
+		result = append(result, c)
+	}
+	return result
+}
+
+// topLevelCondition returns the Ready or Succeeded condition, whichever is present first
+func topLevelCondition(obj *unstructured.Unstructured) *condition {
+	items := conditions(obj)
+	for _, t := range []metav1alpha1.ConditionType{metav1alpha1.ConditionReady, metav1alpha1.ConditionSucceeded} {
+		for i := range items {
+			if items[i].Type == string(t) {
+				return &items[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ReadyColumn returns the status of the Ready condition, or the Succeeded
+// condition for run to completion resources. Returns an empty string if none.
+func ReadyColumn(obj *unstructured.Unstructured) string {
+	if c := topLevelCondition(obj); c != nil {
+		return c.Status
+	}
+	return ""
+}
+
+// StatusColumn returns status.phase when set, otherwise the reason of the
+// top level condition, falling back to its status
+func StatusColumn(obj *unstructured.Unstructured) string {
+	if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase != "" {
+		return phase
+	}
+	if c := topLevelCondition(obj); c != nil {
+		if c.Reason != "" {
+			return c.Reason
+		}
+		return c.Status
+	}
+	return ""
+}
+
+// AgeColumn returns the kubectl formatted age of the object
+func AgeColumn(obj *unstructured.Unstructured, now time.Time) string {
+	return translateTimestampSince(obj.GetCreationTimestamp().Time, now)
+}
+
+// translateTimestampSince returns a human readable duration between t and now
+func translateTimestampSince(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(t))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// ParseCustomColumns parses a kubectl custom-columns spec, a comma separated
+// list of HEADER:JSONPATH, e.g. NAME:.metadata.name,SIZE:.spec.size
+func ParseCustomColumns(spec string) ([]Column, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("custom-columns requires a spec, e.g. -o custom-columns=NAME:.metadata.name")
+	}
+	parts := strings.Split(spec, ",")
+	columns := make([]Column, 0, len(parts))
+	for _, part := range parts {
+		name, path, ok := strings.Cut(part, ":")
+		if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(path) == "" {
+			return nil, fmt.Errorf("invalid custom-columns spec %q, expected HEADER:JSONPATH, e.g. NAME:.metadata.name", part)
+		}
+		path = strings.TrimSpace(path)
+		// like kubectl both .metadata.name and {.metadata.name} are accepted
+		path = strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+		columns = append(columns, Column{Name: strings.TrimSpace(name), JSONPath: path})
+	}
+	return columns, nil
+}
+
+// CustomColumnsPrinter prints only the given columns, like kubectl -o custom-columns.
+// Headers are printed as declared and missing values as <none>
+type CustomColumnsPrinter struct {
+	// Columns to print
+	Columns []Column
+	// NoHeaders omits the header line
+	NoHeaders bool
+	// Now returns the current time used by date columns, defaults to time.Now
+	Now func() time.Time
+}
+
+var _ Printer = &CustomColumnsPrinter{}
+
+// PrintObjects prints the objects to w. Typed objects are converted to unstructured.
+func (p *CustomColumnsPrinter) PrintObjects(w io.Writer, objs ...runtime.Object) error {
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	values := make([]func(obj *unstructured.Unstructured) (string, error), 0, len(p.Columns))
+	for _, column := range p.Columns {
+		value, err := jsonPathValue(column, now)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+
+	tw := printers.GetNewTabWriter(w)
+	if !p.NoHeaders {
+		headers := make([]string, 0, len(p.Columns))
+		for _, column := range p.Columns {
+			headers = append(headers, column.Name)
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objs {
+		if _, ok := obj.(*metav1.Table); ok {
+			return fmt.Errorf("custom-columns output requires objects, not a server-side table")
+		}
+		u, err := ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		row := make([]string, 0, len(values))
+		for i, value := range values {
+			v, err := value(u)
+			if err != nil {
+				return fmt.Errorf("print column %s of %s: %w", p.Columns[i].Name, u.GetName(), err)
+			}
+			if v == "" {
+				v = "<none>"
+			}
+			row = append(row, v)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DiscoverPrinterColumns returns the additionalPrinterColumns declared in the
+// CustomResourceDefinition of the given kind and version.
+// Returns an empty slice if the kind is not a custom resource or declares no columns.
+func DiscoverPrinterColumns(ctx context.Context, clt client.Reader, gvk schema.GroupVersionKind) ([]Column, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinitionList"))
+	if err := clt.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("list custom resource definitions: %w", err)
+	}
+
+	for _, item := range list.Items {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, crd); err != nil {
+			return nil, fmt.Errorf("convert custom resource definition %s: %w", item.GetName(), err)
+		}
+		if crd.Spec.Group != gvk.Group || crd.Spec.Names.Kind != gvk.Kind {
+			continue
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Name == gvk.Version {
+				return ColumnsFromCRD(version.AdditionalPrinterColumns), nil
+			}
+		}
+	}
+	return []Column{}, nil
+}
+
+// ColumnsFromCRD converts CustomResourceDefinition columns into Columns
+func ColumnsFromCRD(definitions []apiextensionsv1.CustomResourceColumnDefinition) []Column {
+	columns := make([]Column, 0, len(definitions))
+	for _, definition := range definitions {
+		columns = append(columns, Column{
+			Name:     definition.Name,
+			Type:     definition.Type,
+			JSONPath: definition.JSONPath,
+			Priority: definition.Priority,
+		})
+	}
+	return columns
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package printer renders kubernetes resources for clis using kubectl-like
+// columns. READY and STATUS are derived from the shared condition types and
+// status phase, and custom resources can use the additionalPrinterColumns
+// declared in their CustomResourceDefinition.
+//
+// Types can declare their own columns in Go by implementing ColumnsProvider.
+// ListTable asks the API server to render a list as a Table, the same columns
+// kubectl get prints, which ResourcePrinter prints as received.
+//
+// OutputFlags adds a -o/--output flag supporting table, wide, json, yaml,
+// jsonpath, name and custom-columns formats. When stored in the context using
+// WithOutputFlags the root command registers it as a persistent flag and
+// subcommands render objects with PrintObjects.
+package printer
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+)
+
+// OutputFormat is the format used to print objects
+type OutputFormat string
+
+const (
+	// OutputFormatTable prints objects as a table using ResourcePrinter, the default format
+	OutputFormatTable OutputFormat = "table"
+	// OutputFormatWide prints objects as a table including the wide columns
+	OutputFormatWide OutputFormat = "wide"
+	// OutputFormatJSON prints objects as json
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatYAML prints objects as yaml
+	OutputFormatYAML OutputFormat = "yaml"
+	// OutputFormatJSONPath prints the result of a jsonpath template, used as jsonpath=<template>
+	OutputFormatJSONPath OutputFormat = "jsonpath"
+	// OutputFormatName prints objects as kind/name
+	OutputFormatName OutputFormat = "name"
+	// OutputFormatCustomColumns prints the given columns, used as custom-columns=<HEADER:JSONPATH,...>
+	OutputFormatCustomColumns OutputFormat = "custom-columns"
+)
+
+// SupportedOutputFormats returns all supported output formats
+func SupportedOutputFormats() []OutputFormat {
+	return []OutputFormat{
+		OutputFormatTable, OutputFormatWide, OutputFormatJSON,
+		OutputFormatYAML, OutputFormatJSONPath, OutputFormatName, OutputFormatCustomColumns,
+	}
+}
+
+// Printer renders objects into a writer
+type Printer interface {
+	PrintObjects(w io.Writer, objs ...runtime.Object) error
+}
+
+var _ Printer = &ResourcePrinter{}
+
+// OutputFlags the -o/--output flag and the printer resolved from it
+type OutputFlags struct {
+	// Output is the value of the --output flag
+	Output string
+	// Table is the printer used for table and wide output,
+	// defaults to a ResourcePrinter with the default columns
+	Table *ResourcePrinter
+	// Scheme is used to set apiVersion and kind of typed objects before printing
+	// json, yaml, jsonpath and name output, defaults to the client-go scheme
+	Scheme *runtime.Scheme
+	// NoHeaders is the value of the --no-headers flag, omits headers of table and custom-columns output
+	NoHeaders bool
+}
+
+// NewOutputFlags returns OutputFlags with table as the default output
+func NewOutputFlags() *OutputFlags {
+	return &OutputFlags{Output: string(OutputFormatTable)}
+}
+
+// AddFlags add flags to the flag set
+func (f *OutputFlags) AddFlags(flags *pflag.FlagSet) {
+	formats := make([]string, 0, len(SupportedOutputFormats()))
+	for _, format := range SupportedOutputFormats() {
+		formats = append(formats, string(format))
+	}
+	flags.StringVarP(&f.Output, "output", "o", f.Output,
+		fmt.Sprintf("Output format. One of: (%s). jsonpath and custom-columns require a template, e.g. -o jsonpath='{.metadata.name}' or -o custom-columns=NAME:.metadata.name", strings.Join(formats, ", ")))
+	flags.BoolVar(&f.NoHeaders, "no-headers", f.NoHeaders, "When using table, wide or custom-columns output, don't print headers")
+}
+
+// IsMachineReadable returns true when the output format is meant to be parsed,
+// i.e. json, yaml, jsonpath or name
+func (f *OutputFlags) IsMachineReadable() bool {
+	format, _, _ := strings.Cut(f.Output, "=")
+	switch OutputFormat(strings.ToLower(format)) {
+	case OutputFormatJSON, OutputFormatYAML, OutputFormatJSONPath, OutputFormatName:
+		return true
+	}
+	return false
+}
+
+// IsTable returns true when the output format is table or wide,
+// so lists can be requested as a server-side Table using ListTable
+func (f *OutputFlags) IsTable() bool {
+	format, _, _ := strings.Cut(f.Output, "=")
+	switch OutputFormat(strings.ToLower(format)) {
+	case "", OutputFormatTable, OutputFormatWide:
+		return true
+	}
+	return false
+}
+
+// ToPrinter returns the Printer for the current output format
+func (f *OutputFlags) ToPrinter() (Printer, error) {
+	format, template, _ := strings.Cut(f.Output, "=")
+	switch OutputFormat(strings.ToLower(format)) {
+	case "", OutputFormatTable:
+		return f.table(false), nil
+	case OutputFormatWide:
+		return f.table(true), nil
+	case OutputFormatJSON:
+		return f.runtimePrinter(&printers.JSONPrinter{}), nil
+	case OutputFormatYAML:
+		return f.runtimePrinter(&printers.YAMLPrinter{}), nil
+	case OutputFormatName:
+		return f.runtimePrinter(&printers.NamePrinter{}), nil
+	case OutputFormatJSONPath:
+		if template == "" {
+			return nil, fmt.Errorf("template is required for jsonpath output, e.g. -o jsonpath='{.metadata.name}'")
+		}
+		jsonpathPrinter, err := printers.NewJSONPathPrinter(template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jsonpath template %q: %w", template, err)
+		}
+		jsonpathPrinter.AllowMissingKeys(true)
+		return f.runtimePrinter(jsonpathPrinter), nil
+	case OutputFormatCustomColumns:
+		columns, err := ParseCustomColumns(template)
+		if err != nil {
+			return nil, err
+		}
+		customColumns := &CustomColumnsPrinter{Columns: columns, NoHeaders: f.NoHeaders}
+		if f.Table != nil {
+			customColumns.Now = f.Table.Now
+		}
+		return customColumns, nil
+	}
+	return nil, fmt.Errorf("unsupported output format %q", f.Output)
+}
+
+func (f *OutputFlags) table(wide bool) Printer {
+	table := &ResourcePrinter{}
+	if f.Table != nil {
+		copied := *f.Table
+		table = &copied
+	}
+	table.Wide = table.Wide || wide
+	table.NoHeaders = table.NoHeaders || f.NoHeaders
+	return table
+}
+
+func (f *OutputFlags) runtimePrinter(printer printers.ResourcePrinter) Printer {
+	scheme := f.Scheme
+	if scheme == nil {
+		scheme = clientgoscheme.Scheme
+	}
+	return &runtimePrinter{printer: printer, scheme: scheme}
+}
+
+// runtimePrinter adapts a cli-runtime printer to Printer.
+// A single object is printed as is, otherwise objects are wrapped in a List like kubectl does
+type runtimePrinter struct {
+	printer printers.ResourcePrinter
+	scheme  *runtime.Scheme
+}
+
+func (p *runtimePrinter) PrintObjects(w io.Writer, objs ...runtime.Object) error {
+	items := make([]unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		u, err := p.toUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		items = append(items, *u)
+	}
+	if len(items) == 1 {
+		return p.printer.PrintObj(&items[0], w)
+	}
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}, Items: items}
+	list.SetAPIVersion("v1")
+	list.SetKind("List")
+	return p.printer.PrintObj(list, w)
+}
+
+// toUnstructured converts the object and sets its apiVersion and kind from the scheme when missing
+func (p *runtimePrinter) toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	u, err := ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	if !u.GroupVersionKind().Empty() {
+		return u, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, p.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("missing apiVersion or kind of %T: %w", obj, err)
+	}
+	u = u.DeepCopy()
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// key for reading/writing into context
+type outputFlagsKey struct{}
+
+// WithOutputFlags adds OutputFlags into the context
+func WithOutputFlags(ctx context.Context, flags *OutputFlags) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, outputFlagsKey{}, flags)
+}
+
+// GetOutputFlags returns OutputFlags stored in the context if any
+// if not found will return nil *OutputFlags
+func GetOutputFlags(ctx context.Context) (flags *OutputFlags) {
+	if ctx == nil {
+		return nil
+	}
+	flags, _ = ctx.Value(outputFlagsKey{}).(*OutputFlags)
+	return
+}
+
+// GetPrinter returns the Printer resolved from the OutputFlags in the context,
+// defaults to a table printer when no OutputFlags are found.
+// Should be called after flags are parsed, e.g. inside RunE
+func GetPrinter(ctx context.Context) (Printer, error) {
+	flags := GetOutputFlags(ctx)
+	if flags == nil {
+		flags = NewOutputFlags()
+	}
+	return flags.ToPrinter()
+}
+
+// PrintObjects prints the objects into the output stream in the context
+// using the Printer resolved from the context
+func PrintObjects(ctx context.Context, objs ...runtime.Object) error {
+	printer, err := GetPrinter(ctx)
+	if err != nil {
+		return err
+	}
+	return printer.PrintObjects(cliio.MustGetIOStreams(ctx).Out, objs...)
+}
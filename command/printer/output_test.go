@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOutputFlags_ToPrinter(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Data:       map[string]string{"key": "value"},
+	}
+	widget := newObject("widget", nil)
+
+	var data = []struct {
+		desc     string
+		output   string
+		objs     []runtime.Object
+		expected string
+		err      string
+	}{
+		{
+			desc:     "name of typed and unstructured objects",
+			output:   "name",
+			objs:     []runtime.Object{configMap, widget},
+			expected: "configmap/config\nwidget.example.com/widget\n",
+		},
+		{
+			desc:     "json of a single object",
+			output:   "json",
+			objs:     []runtime.Object{configMap},
+			expected: "\"kind\": \"ConfigMap\"",
+		},
+		{
+			desc:     "json of multiple objects as a list",
+			output:   "json",
+			objs:     []runtime.Object{configMap, widget},
+			expected: "\"kind\": \"List\"",
+		},
+		{
+			desc:     "yaml",
+			output:   "yaml",
+			objs:     []runtime.Object{configMap},
+			expected: "apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\n",
+		},
+		{
+			desc:     "jsonpath over multiple objects",
+			output:   "jsonpath={.items[*].metadata.name}",
+			objs:     []runtime.Object{configMap, widget},
+			expected: "config widget",
+		},
+		{
+			desc:     "table by default",
+			output:   "",
+			objs:     []runtime.Object{widget},
+			expected: "NAME     READY   STATUS   AGE\nwidget",
+		},
+		{
+			desc:     "custom columns",
+			output:   "custom-columns=NAME:.metadata.name,SIZE:{.spec.size},MISSING:.spec.missing",
+			objs:     []runtime.Object{widget},
+			expected: "NAME     SIZE   MISSING\nwidget   3      <none>\n",
+		},
+		{
+			desc:   "custom columns without spec",
+			output: "custom-columns",
+			err:    "custom-columns requires a spec",
+		},
+		{
+			desc:   "invalid custom columns spec",
+			output: "custom-columns=NAME",
+			err:    "expected HEADER:JSONPATH",
+		},
+		{
+			desc:   "jsonpath without template",
+			output: "jsonpath",
+			err:    "template is required",
+		},
+		{
+			desc:   "unsupported format",
+			output: "xml",
+			err:    "unsupported output format \"xml\"",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			flags := NewOutputFlags()
+			flags.Output = item.output
+			flags.Table = &ResourcePrinter{Now: func() time.Time { return now }}
+
+			printer, err := flags.ToPrinter()
+			if item.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(item.err))
+				return
+			}
+			g.Expect(err).To(BeNil())
+
+			buf := &bytes.Buffer{}
+			g.Expect(printer.PrintObjects(buf, item.objs...)).To(Succeed())
+			g.Expect(buf.String()).To(ContainSubstring(item.expected))
+		})
+	}
+}
+
+func TestOutputFlags_AddFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	flags := NewOutputFlags()
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(flagSet)
+
+	g.Expect(flagSet.Parse([]string{"-o", "wide"})).To(Succeed())
+	g.Expect(flags.Output).To(Equal("wide"))
+
+	printer, err := flags.ToPrinter()
+	g.Expect(err).To(BeNil())
+	g.Expect(printer.(*ResourcePrinter).Wide).To(BeTrue())
+	g.Expect(flags.IsMachineReadable()).To(BeFalse())
+
+	g.Expect(flagSet.Parse([]string{"--output=jsonpath={.metadata.name}"})).To(Succeed())
+	g.Expect(flags.IsMachineReadable()).To(BeTrue())
+	g.Expect(flags.IsTable()).To(BeFalse())
+
+	g.Expect(flagSet.Parse([]string{"-o", "table", "--no-headers"})).To(Succeed())
+	g.Expect(flags.IsTable()).To(BeTrue())
+	printer, err = flags.ToPrinter()
+	g.Expect(err).To(BeNil())
+	g.Expect(printer.(*ResourcePrinter).NoHeaders).To(BeTrue())
+}
+
+func TestPrintObjects(t *testing.T) {
+	g := NewGomegaWithT(t)
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := cliio.WithIOStreams(context.Background(), &streams)
+
+	g.Expect(GetOutputFlags(ctx)).To(BeNil())
+	g.Expect(PrintObjects(ctx, newObject("widget", nil))).To(Succeed())
+	g.Expect(out.String()).To(HavePrefix("NAME"))
+
+	out.Reset()
+	flags := NewOutputFlags()
+	flags.Output = "name"
+	ctx = WithOutputFlags(ctx, flags)
+	g.Expect(GetOutputFlags(ctx)).To(Equal(flags))
+	g.Expect(PrintObjects(ctx, newObject("widget", nil))).To(Succeed())
+	g.Expect(out.String()).To(Equal("widget.example.com/widget\n"))
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ColumnTypeDate is the type of columns holding a timestamp,
+// rendered as a duration since now like kubectl does
+const ColumnTypeDate = "date"
+
+// Column describes an additional column, mirroring the
+// additionalPrinterColumns of a CustomResourceDefinition
+type Column struct {
+	// Name is the header of the column
+	Name string
+	// Type of the column, only "date" has a special rendering
+	Type string
+	// JSONPath is a simple JSON path evaluated against each object, e.g. .spec.replicas
+	JSONPath string
+	// Priority greater than zero are only shown in wide output
+	Priority int32
+}
+
+// ResourcePrinter prints resources as a table with kubectl-like columns.
+//
+// Without Columns it prints NAME, READY, STATUS and AGE columns derived from
+// the object conditions and phase. With Columns, or the columns of objects
+// implementing ColumnsProvider, it prints NAME, the columns and AGE, like
+// kubectl does for custom resources.
+type ResourcePrinter struct {
+	// Columns to print instead of READY and STATUS
+	Columns []Column
+	// WithNamespace adds a NAMESPACE column
+	WithNamespace bool
+	// Wide includes columns with priority greater than zero
+	Wide bool
+	// NoHeaders omits the header line
+	NoHeaders bool
+	// Now returns the current time, defaults to time.Now
+	Now func() time.Time
+}
+
+type columnPrinter struct {
+	header string
+	value  func(obj *unstructured.Unstructured) (string, error)
+}
+
+// ColumnsProvider is implemented by types declaring their own table columns in Go,
+// e.g. custom resources which do not want to depend on the additionalPrinterColumns
+// of their CustomResourceDefinition. The columns are used by ResourcePrinter
+// when no Columns are set.
+type ColumnsProvider interface {
+	PrinterColumns() []Column
+}
+
+// columnsFor returns the columns declared by the first object when it implements ColumnsProvider
+func columnsFor(objs []runtime.Object) []Column {
+	if len(objs) == 0 {
+		return nil
+	}
+	if provider, ok := objs[0].(ColumnsProvider); ok {
+		return provider.PrinterColumns()
+	}
+	return nil
+}
+
+func (p *ResourcePrinter) columns(columns []Column) ([]columnPrinter, error) {
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	age := columnPrinter{header: "AGE", value: func(obj *unstructured.Unstructured) (string, error) {
+		return AgeColumn(obj, now()), nil
+	}}
+
+	result := []columnPrinter{}
+	if p.WithNamespace {
+		result = append(result, columnPrinter{header: "NAMESPACE", value: func(obj *unstructured.Unstructured) (string, error) {
+			return obj.GetNamespace(), nil
+		}})
+	}
+	result = append(result, columnPrinter{header: "NAME", value: func(obj *unstructured.Unstructured) (string, error) {
+		return obj.GetName(), nil
+	}})
+
+	if len(columns) == 0 {
+		result = append(result,
+			columnPrinter{header: "READY", value: func(obj *unstructured.Unstructured) (string, error) {
+				return ReadyColumn(obj), nil
+			}},
+			columnPrinter{header: "STATUS", value: func(obj *unstructured.Unstructured) (string, error) {
+				return StatusColumn(obj), nil
+			}},
+			age,
+		)
+		return result, nil
+	}
+
+	hasAge := false
+	for _, column := range columns {
+		if column.Priority > 0 && !p.Wide {
+			continue
+		}
+		value, err := jsonPathValue(column, now)
+		if err != nil {
+			return nil, err
+		}
+		hasAge = hasAge || strings.EqualFold(column.Name, "age")
+		result = append(result, columnPrinter{header: strings.ToUpper(column.Name), value: value})
+	}
+	if !hasAge {
+		result = append(result, age)
+	}
+	return result, nil
+}
+
+// jsonPathValue returns a function evaluating the JSONPath of the column,
+// date columns are rendered as a duration since now
+func jsonPathValue(column Column, now func() time.Time) (func(obj *unstructured.Unstructured) (string, error), error) {
+	parser := jsonpath.New(column.Name).AllowMissingKeys(true)
+	if err := parser.Parse(fmt.Sprintf("{%s}", column.JSONPath)); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q for column %q: %w", column.JSONPath, column.Name, err)
+	}
+	return func(obj *unstructured.Unstructured) (string, error) {
+		buf := &bytes.Buffer{}
+		if err := parser.Execute(buf, obj.Object); err != nil {
+			return "", err
+		}
+		value := buf.String()
+		if column.Type == ColumnTypeDate && value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return value, nil
+			}
+			return translateTimestampSince(t, now()), nil
+		}
+		return value, nil
+	}, nil
+}
+
+// PrintObjects prints the objects to w. Typed objects are converted to unstructured,
+// tables returned by the API server, e.g. by ListTable, are printed as received.
+// When no Columns are set the columns of objects implementing ColumnsProvider are used.
+func (p *ResourcePrinter) PrintObjects(w io.Writer, objs ...runtime.Object) error {
+	if tables, ok := asTables(objs); ok {
+		return p.printTables(w, tables...)
+	}
+
+	declared := p.Columns
+	if len(declared) == 0 {
+		declared = columnsFor(objs)
+	}
+	columns, err := p.columns(declared)
+	if err != nil {
+		return err
+	}
+
+	tw := printers.GetNewTabWriter(w)
+	if !p.NoHeaders {
+		headers := make([]string, 0, len(columns))
+		for _, column := range columns {
+			headers = append(headers, column.header)
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objs {
+		u, err := ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		values := make([]string, 0, len(columns))
+		for _, column := range columns {
+			value, err := column.value(u)
+			if err != nil {
+				return fmt.Errorf("print column %s of %s: %w", column.header, u.GetName(), err)
+			}
+			values = append(values, value)
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// ToUnstructured converts a runtime.Object into an unstructured object
+func ToUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("convert %T to unstructured: %w", obj, err)
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
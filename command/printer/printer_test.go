@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	ktesting "github.com/AlaudaDevops/pkg/testing"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newObject(name string, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1alpha1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "default",
+			"creationTimestamp": now.Add(-2 * time.Hour).Format(time.RFC3339),
+		},
+		"spec": map[string]interface{}{"size": int64(3), "color": "blue"},
+	}}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+func TestStatusColumns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ready := newObject("ready", map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "Reconciling"},
+		},
+	})
+	g.Expect(ReadyColumn(ready)).To(Equal("False"))
+	g.Expect(StatusColumn(ready)).To(Equal("Reconciling"))
+
+	succeeded := newObject("succeeded", map[string]interface{}{
+		"phase":      "Completed",
+		"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "True"}},
+	})
+	g.Expect(ReadyColumn(succeeded)).To(Equal("True"))
+	g.Expect(StatusColumn(succeeded)).To(Equal("Completed"))
+
+	empty := newObject("empty", nil)
+	g.Expect(ReadyColumn(empty)).To(BeEmpty())
+	g.Expect(StatusColumn(empty)).To(BeEmpty())
+	g.Expect(AgeColumn(empty, now)).To(Equal("120m"))
+}
+
+func TestResourcePrinterDefaultColumns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	p := &ResourcePrinter{WithNamespace: true, Now: func() time.Time { return now }}
+	g.Expect(p.PrintObjects(buf, pod)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("" +
+		"NAMESPACE   NAME   READY   STATUS    AGE\n" +
+		"default     pod    True    Running   60s\n"))
+}
+
+func TestResourcePrinterCustomColumns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := &unstructured.Unstructured{}
+	g.Expect(ktesting.LoadYAML("testdata/crd.yaml", crd)).To(Succeed())
+	clt := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(crd).Build()
+
+	columns, err := DiscoverPrinterColumns(context.Background(), clt, schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Widget"})
+	g.Expect(err).To(BeNil())
+	g.Expect(columns).To(HaveLen(3))
+
+	obj := newObject("widget", map[string]interface{}{"startedAt": now.Add(-5 * time.Minute).Format(time.RFC3339)})
+
+	buf := &bytes.Buffer{}
+	p := &ResourcePrinter{Columns: columns, Now: func() time.Time { return now }}
+	g.Expect(p.PrintObjects(buf, obj)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("" +
+		"NAME     SIZE   STARTED   AGE\n" +
+		"widget   3      5m        120m\n"))
+
+	buf.Reset()
+	p.Wide = true
+	p.NoHeaders = true
+	g.Expect(p.PrintObjects(buf, obj)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("widget   3     blue   5m    120m\n"))
+
+	columns, err = DiscoverPrinterColumns(context.Background(), clt, schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Other"})
+	g.Expect(err).To(BeNil())
+	g.Expect(columns).To(BeEmpty())
+}
+
+func TestResourcePrinterInvalidColumn(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p := &ResourcePrinter{Columns: []Column{{Name: "Bad", JSONPath: ".spec[.bad"}}}
+	g.Expect(p.PrintObjects(&bytes.Buffer{})).NotTo(Succeed())
+}
+
+// widget is a typed custom resource declaring its columns in Go
+type widget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Size int `json:"size"`
+	} `json:"spec"`
+}
+
+func (w *widget) DeepCopyObject() runtime.Object {
+	copied := *w
+	w.ObjectMeta.DeepCopyInto(&copied.ObjectMeta)
+	return &copied
+}
+
+func (w *widget) PrinterColumns() []Column {
+	return []Column{{Name: "Size", JSONPath: ".spec.size"}}
+}
+
+func TestResourcePrinterColumnsProvider(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj := &widget{ObjectMeta: metav1.ObjectMeta{Name: "widget", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}}
+	obj.Spec.Size = 3
+
+	buf := &bytes.Buffer{}
+	p := &ResourcePrinter{Now: func() time.Time { return now }}
+	g.Expect(p.PrintObjects(buf, obj)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("" +
+		"NAME     SIZE   AGE\n" +
+		"widget   3      60m\n"))
+
+	buf.Reset()
+	p.Columns = []Column{{Name: "Kind", JSONPath: ".kind"}}
+	g.Expect(p.PrintObjects(buf, obj)).To(Succeed())
+	g.Expect(buf.String()).To(HavePrefix("NAME     KIND   AGE\n"))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/printers"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// TableAcceptHeader asks the API server to render lists as a meta.k8s.io/v1 Table,
+// falling back to plain json for servers not supporting server-side printing
+const TableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// ErrTableNotSupported is returned by ListTable when the API server
+// answers without a Table, e.g. for some aggregated APIs
+var ErrTableNotSupported = errors.New("server-side printing is not supported")
+
+// ListTable lists the resource in namespace asking the API server to render it as a Table,
+// the same columns kubectl get prints. An empty namespace lists across all namespaces
+// or cluster scoped resources. Returns ErrTableNotSupported if the server does not
+// support server-side printing for the resource, callers can then list the objects
+// and print them using ResourcePrinter.
+func ListTable(ctx context.Context, config *rest.Config, gvr schema.GroupVersionResource, namespace string, opts metav1.ListOptions) (*metav1.Table, error) {
+	config = rest.CopyConfig(config)
+	config.GroupVersion = &schema.GroupVersion{Group: gvr.Group, Version: gvr.Version}
+	config.APIPath = "/apis"
+	if gvr.Group == "" {
+		config.APIPath = "/api"
+	}
+	if config.NegotiatedSerializer == nil {
+		config.NegotiatedSerializer = clientgoscheme.Codecs.WithoutConversion()
+	}
+	restClient, err := rest.RESTClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := restClient.Get().
+		NamespaceIfScoped(namespace, namespace != "").
+		Resource(gvr.Resource).
+		VersionedParams(&opts, metav1.ParameterCodec).
+		SetHeader("Accept", TableAcceptHeader).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, fmt.Errorf("list %s as table: %w", gvr.GroupResource(), err)
+	}
+
+	table := &metav1.Table{}
+	if err = json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("decode table of %s: %w", gvr.GroupResource(), err)
+	}
+	if table.Kind != "Table" {
+		return nil, fmt.Errorf("list %s: %w", gvr.GroupResource(), ErrTableNotSupported)
+	}
+	return table, nil
+}
+
+// asTables returns the objects as tables when all of them are tables
+func asTables(objs []runtime.Object) ([]*metav1.Table, bool) {
+	if len(objs) == 0 {
+		return nil, false
+	}
+	tables := make([]*metav1.Table, 0, len(objs))
+	for _, obj := range objs {
+		table, ok := obj.(*metav1.Table)
+		if !ok {
+			return nil, false
+		}
+		tables = append(tables, table)
+	}
+	return tables, true
+}
+
+// printTables prints tables rendered by the API server. Columns with priority
+// greater than zero are only printed in wide output, and the namespace is read
+// from the object metadata included in each row.
+// Headers are taken from the first table, like kubectl does when printing a single resource kind
+func (p *ResourcePrinter) printTables(w io.Writer, tables ...*metav1.Table) error {
+	definitions := tables[0].ColumnDefinitions
+	visible := make([]int, 0, len(definitions))
+	for i, definition := range definitions {
+		if definition.Priority > 0 && !p.Wide {
+			continue
+		}
+		visible = append(visible, i)
+	}
+
+	tw := printers.GetNewTabWriter(w)
+	if !p.NoHeaders {
+		headers := make([]string, 0, len(visible)+1)
+		if p.WithNamespace {
+			headers = append(headers, "NAMESPACE")
+		}
+		for _, i := range visible {
+			headers = append(headers, strings.ToUpper(definitions[i].Name))
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			values := make([]string, 0, len(visible)+1)
+			if p.WithNamespace {
+				namespace, err := rowNamespace(row)
+				if err != nil {
+					return err
+				}
+				values = append(values, namespace)
+			}
+			for _, i := range visible {
+				values = append(values, formatCell(row.Cells, i))
+			}
+			fmt.Fprintln(tw, strings.Join(values, "\t"))
+		}
+	}
+	return tw.Flush()
+}
+
+// rowNamespace returns the namespace of the object included in the row
+func rowNamespace(row metav1.TableRow) (string, error) {
+	if row.Object.Object != nil {
+		if obj, ok := row.Object.Object.(metav1.Object); ok {
+			return obj.GetNamespace(), nil
+		}
+	}
+	if len(row.Object.Raw) == 0 {
+		return "", nil
+	}
+	meta := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(row.Object.Raw, meta); err != nil {
+		return "", fmt.Errorf("decode metadata of table row: %w", err)
+	}
+	return meta.Namespace, nil
+}
+
+// formatCell formats the cell at index i like kubectl, missing values are printed as <none>
+func formatCell(cells []interface{}, i int) string {
+	if i >= len(cells) || cells[i] == nil {
+		return "<none>"
+	}
+	switch value := cells[i].(type) {
+	case string:
+		return value
+	case float64:
+		// json numbers are decoded as float64, integers are printed without decimals
+		if value == float64(int64(value)) {
+			return fmt.Sprintf("%d", int64(value))
+		}
+		return fmt.Sprintf("%v", value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+const tableResponse = `{
+  "kind": "Table",
+  "apiVersion": "meta.k8s.io/v1",
+  "columnDefinitions": [
+    {"name": "Name", "type": "string", "priority": 0},
+    {"name": "Ready", "type": "string", "priority": 0},
+    {"name": "Restarts", "type": "integer", "priority": 0},
+    {"name": "Node", "type": "string", "priority": 1}
+  ],
+  "rows": [
+    {"cells": ["pod-a", "1/1", 0, "node-1"], "object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "pod-a", "namespace": "default"}}},
+    {"cells": ["pod-b", "0/1", 3, null], "object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "pod-b", "namespace": "system"}}}
+  ]
+}`
+
+func TestListTable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var accept, path, selector string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept, path, selector = r.Header.Get("Accept"), r.URL.Path, r.URL.Query().Get("labelSelector")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/apis/example.com/v1alpha1/widgets" {
+			_, _ = w.Write([]byte(`{"kind":"WidgetList","apiVersion":"example.com/v1alpha1","items":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(tableResponse))
+	}))
+	defer server.Close()
+	config := &rest.Config{Host: server.URL}
+
+	table, err := ListTable(context.Background(), config, schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "default", metav1.ListOptions{LabelSelector: "app=demo"})
+	g.Expect(err).To(BeNil())
+	g.Expect(accept).To(Equal(TableAcceptHeader))
+	g.Expect(path).To(Equal("/api/v1/namespaces/default/pods"))
+	g.Expect(selector).To(Equal("app=demo"))
+	g.Expect(table.Rows).To(HaveLen(2))
+
+	_, err = ListTable(context.Background(), config, schema.GroupVersionResource{Group: "example.com", Version: "v1alpha1", Resource: "widgets"}, "", metav1.ListOptions{})
+	g.Expect(path).To(Equal("/apis/example.com/v1alpha1/widgets"))
+	g.Expect(errors.Is(err, ErrTableNotSupported)).To(BeTrue())
+
+	buf := &bytes.Buffer{}
+	p := &ResourcePrinter{WithNamespace: true}
+	g.Expect(p.PrintObjects(buf, table)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("" +
+		"NAMESPACE   NAME    READY   RESTARTS\n" +
+		"default     pod-a   1/1     0\n" +
+		"system      pod-b   0/1     3\n"))
+
+	buf.Reset()
+	p = &ResourcePrinter{Wide: true, NoHeaders: true}
+	g.Expect(p.PrintObjects(buf, table)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("" +
+		"pod-a   1/1   0     node-1\n" +
+		"pod-b   0/1   3     <none>\n"))
+}
+
+func TestPrintTablesWithObjects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name"}, {Name: "Ratio"}},
+		Rows: []metav1.TableRow{{
+			Cells:  []interface{}{"widget", 0.5},
+			Object: runtime.RawExtension{Object: &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}},
+		}},
+	}
+	buf := &bytes.Buffer{}
+	p := &ResourcePrinter{WithNamespace: true}
+	g.Expect(p.PrintObjects(buf, table, table)).To(Succeed())
+	g.Expect(buf.String()).To(Equal("" +
+		"NAMESPACE   NAME     RATIO\n" +
+		"default     widget   0.5\n" +
+		"default     widget   0.5\n"))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	barWidth = 30
+	// barLogStep is the percentage between log lines in non interactive mode
+	barLogStep = 25
+)
+
+// Bars renders multiple progress bars together
+type Bars struct {
+	reporter *Reporter
+
+	lock     sync.Mutex
+	bars     []*Bar
+	rendered int
+	last     time.Time
+}
+
+// Bars returns a group of progress bars
+func (r *Reporter) Bars() *Bars {
+	return &Bars{reporter: r}
+}
+
+// Add adds a progress bar for a task with a known total
+func (b *Bars) Add(name string, total int64) *Bar {
+	bar := &Bar{bars: b, name: name, total: total}
+	b.lock.Lock()
+	b.bars = append(b.bars, bar)
+	b.lock.Unlock()
+
+	if b.reporter.Interactive {
+		b.render(true)
+	} else {
+		b.reporter.Logger.Infow(name, "progress", "0%")
+	}
+	return bar
+}
+
+// render redraws all the bars, skipping when last rendered within the refresh interval unless forced
+func (b *Bars) render(force bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	if !force && now.Sub(b.last) < b.reporter.interval() {
+		return
+	}
+	b.last = now
+
+	builder := &strings.Builder{}
+	if b.rendered > 0 {
+		// moves the cursor to the first bar
+		fmt.Fprintf(builder, "\033[%dA", b.rendered)
+	}
+	for _, bar := range b.bars {
+		builder.WriteString(clearLine)
+		builder.WriteString(bar.String())
+		builder.WriteString("\n")
+	}
+	b.rendered = len(b.bars)
+	b.reporter.write(builder.String())
+}
+
+// Bar is the progress bar of a task
+type Bar struct {
+	bars  *Bars
+	name  string
+	total int64
+
+	lock    sync.Mutex
+	current int64
+	logged  int64
+	err     error
+	done    bool
+}
+
+// Add increments the progress
+func (bar *Bar) Add(n int64) {
+	bar.lock.Lock()
+	bar.current += n
+	if bar.total > 0 && bar.current > bar.total {
+		bar.current = bar.total
+	}
+	bar.lock.Unlock()
+	bar.update(false)
+}
+
+// Write increments the progress by the length of p,
+// allowing to track copies using io.TeeReader or io.MultiWriter
+func (bar *Bar) Write(p []byte) (int, error) {
+	bar.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// Done marks the task as completed
+func (bar *Bar) Done() {
+	bar.lock.Lock()
+	bar.done = true
+	if bar.total > 0 {
+		bar.current = bar.total
+	}
+	bar.lock.Unlock()
+	bar.update(true)
+}
+
+// Fail marks the task as failed
+func (bar *Bar) Fail(err error) {
+	bar.lock.Lock()
+	bar.done, bar.err = true, err
+	bar.lock.Unlock()
+	bar.update(true)
+}
+
+func (bar *Bar) update(force bool) {
+	reporter := bar.bars.reporter
+	if reporter.Interactive {
+		bar.bars.render(force)
+		return
+	}
+
+	bar.lock.Lock()
+	defer bar.lock.Unlock()
+	switch {
+	case bar.err != nil:
+		reporter.Logger.Errorw(bar.name, "err", bar.err)
+	case bar.done:
+		reporter.Logger.Infow(bar.name, "progress", "100%")
+	default:
+		// logs every barLogStep percent
+		percent := bar.percent()
+		if step := percent / barLogStep * barLogStep; step > bar.logged && step < 100 {
+			bar.logged = step
+			reporter.Logger.Infow(bar.name, "progress", fmt.Sprintf("%d%%", step))
+		}
+	}
+}
+
+func (bar *Bar) percent() int64 {
+	if bar.total <= 0 {
+		return 0
+	}
+	return bar.current * 100 / bar.total
+}
+
+// String renders the bar, e.g. name [=========>          ]  33% 1/3
+func (bar *Bar) String() string {
+	bar.lock.Lock()
+	defer bar.lock.Unlock()
+
+	if bar.err != nil {
+		return fmt.Sprintf("%s %s: %v", failureMark, bar.name, bar.err)
+	}
+	percent := bar.percent()
+	filled := int(percent * barWidth / 100)
+	progress := strings.Repeat("=", filled)
+	if filled < barWidth {
+		progress += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+	mark := " "
+	if bar.done {
+		mark = successMark
+	}
+	return fmt.Sprintf("%s %s [%s] %3d%% %d/%d", mark, bar.name, progress, percent, bar.current, bar.total)
+}
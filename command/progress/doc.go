@@ -0,0 +1,23 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package progress reports the progress of long running cli operations
+// using spinners, step trackers and progress bars rendered into the error stream.
+//
+// When the error stream is not a terminal, the output format is machine readable
+// (e.g. -o json) or the logger has debug enabled, progress is reported as plain
+// log lines using the logger in the context instead.
+package progress
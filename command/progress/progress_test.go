@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/command/printer"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newReporter(interactive bool) (*Reporter, *bytes.Buffer, *bytes.Buffer) {
+	out, logs := &bytes.Buffer{}, &bytes.Buffer{}
+	return &Reporter{
+		Out:         out,
+		Interactive: interactive,
+		Logger:      logger.NewLogger(zapcore.AddSync(logs), zapcore.InfoLevel),
+		Interval:    time.Millisecond,
+	}, out, logs
+}
+
+func TestNewReporter(t *testing.T) {
+	g := NewGomegaWithT(t)
+	streams, _, _, errOut := clioptions.NewTestIOStreams()
+	ctx := cliio.WithIOStreams(context.Background(), &streams)
+
+	reporter := NewReporter(ctx)
+	g.Expect(reporter.Out).To(Equal(errOut))
+	// a buffer is not a terminal
+	g.Expect(reporter.Interactive).To(BeFalse())
+	g.Expect(reporter.Logger).NotTo(BeNil())
+
+	outputFlags := printer.NewOutputFlags()
+	outputFlags.Output = "json"
+	reporter = NewReporter(printer.WithOutputFlags(ctx, outputFlags))
+	g.Expect(reporter.Interactive).To(BeFalse())
+}
+
+func TestSpinner(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	reporter, out, logs := newReporter(true)
+	spinner := reporter.Spinner("waiting")
+	spinner.Update("still waiting")
+	spinner.Success("ready")
+	spinner.Fail(errors.New("ignored after success"))
+	g.Expect(out.String()).To(HavePrefix(clearLine + "⠋ "))
+	g.Expect(out.String()).To(MatchRegexp(`✓ ready \(\d+m?s\)\n$`))
+	g.Expect(logs.String()).To(BeEmpty())
+
+	reporter, out, logs = newReporter(false)
+	spinner = reporter.Spinner("waiting")
+	spinner.Update("still waiting")
+	spinner.Fail(errors.New("timeout"))
+	g.Expect(out.String()).To(BeEmpty())
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	g.Expect(lines).To(HaveLen(3))
+	g.Expect(lines[0]).To(ContainSubstring("waiting"))
+	g.Expect(lines[1]).To(ContainSubstring("still waiting"))
+	g.Expect(lines[2]).To(ContainSubstring(`{"err": "timeout"}`))
+}
+
+func TestSteps(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	reporter, out, _ := newReporter(true)
+	steps := reporter.Steps(2)
+	g.Expect(steps.Run("first", func() error { return nil })).To(Succeed())
+	g.Expect(steps.Run("second", func() error { return errors.New("failed") })).To(MatchError("failed"))
+	g.Expect(out.String()).To(MatchRegexp(`✓ \[1/2\] first \(.+\)\n`))
+	g.Expect(out.String()).To(HaveSuffix("✗ [2/2] second: failed\n"))
+}
+
+func TestBars(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	reporter, out, _ := newReporter(true)
+	bars := reporter.Bars()
+	upload := bars.Add("upload", 4)
+	download := bars.Add("download", 10)
+	_, _ = upload.Write([]byte("ab"))
+	download.Fail(errors.New("refused"))
+	upload.Done()
+
+	g.Expect(upload.String()).To(Equal("✓ upload [==============================] 100% 4/4"))
+	g.Expect(download.String()).To(Equal("✗ download: refused"))
+	// redraws both bars moving the cursor up
+	g.Expect(out.String()).To(HaveSuffix("\033[2A" + clearLine + upload.String() + "\n" + clearLine + download.String() + "\n"))
+
+	bar := &Bar{bars: bars, name: "half", total: 10, current: 5}
+	g.Expect(bar.String()).To(Equal("  half [===============>              ]  50% 5/10"))
+
+	reporter, out, logs := newReporter(false)
+	bar = reporter.Bars().Add("copy", 100)
+	for i := 0; i < 10; i++ {
+		bar.Add(10)
+	}
+	bar.Done()
+	g.Expect(out.String()).To(BeEmpty())
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	g.Expect(lines).To(HaveLen(5))
+	for i, percent := range []string{"0%", "25%", "50%", "75%", "100%"} {
+		g.Expect(lines[i]).To(ContainSubstring(`"progress": "` + percent + `"`))
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/term"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/command/printer"
+)
+
+const (
+	// DefaultInterval is the default refresh interval of spinners and progress bars
+	DefaultInterval = 100 * time.Millisecond
+
+	// clearLine moves the cursor to the beginning of the line and erases it
+	clearLine = "\r\033[K"
+)
+
+// Reporter creates spinners, step trackers and progress bars
+type Reporter struct {
+	// Out is where progress is rendered in interactive mode
+	Out io.Writer
+	// Interactive renders progress using terminal control sequences,
+	// otherwise progress is reported as log lines
+	Interactive bool
+	// Logger is used to report progress when not interactive
+	Logger *zap.SugaredLogger
+	// Interval is the refresh interval of spinners and progress bars
+	Interval time.Duration
+
+	// serializes writes into Out
+	lock sync.Mutex
+}
+
+// NewReporter returns a Reporter writing into the error stream in the context.
+// It is interactive when the error stream is a terminal, the output format in the
+// context is not machine readable and the logger in the context has debug disabled
+func NewReporter(ctx context.Context) *Reporter {
+	streams := cliio.MustGetIOStreams(ctx)
+	log := logger.NewLoggerFromContext(ctx)
+
+	interactive := IsTerminal(streams.ErrOut) && !log.Desugar().Core().Enabled(zap.DebugLevel)
+	if outputFlags := printer.GetOutputFlags(ctx); outputFlags != nil && outputFlags.IsMachineReadable() {
+		interactive = false
+	}
+	return &Reporter{
+		Out:         streams.ErrOut,
+		Interactive: interactive,
+		Logger:      log,
+		Interval:    DefaultInterval,
+	}
+}
+
+// IsTerminal returns true if w is a terminal
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+func (r *Reporter) interval() time.Duration {
+	if r.Interval <= 0 {
+		return DefaultInterval
+	}
+	return r.Interval
+}
+
+func (r *Reporter) write(content string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, _ = io.WriteString(r.Out, content)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const (
+	successMark = "✓"
+	failureMark = "✗"
+)
+
+// Spinner shows an operation is in progress
+type Spinner struct {
+	reporter *Reporter
+
+	lock    sync.Mutex
+	message string
+	start   time.Time
+	stop    chan struct{}
+	stopped chan struct{}
+	done    bool
+}
+
+// Spinner starts a spinner with a message
+func (r *Reporter) Spinner(message string) *Spinner {
+	s := &Spinner{
+		reporter: r,
+		message:  message,
+		start:    time.Now(),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	if r.Interactive {
+		go s.run()
+	} else {
+		close(s.stopped)
+		r.Logger.Info(message)
+	}
+	return s
+}
+
+func (s *Spinner) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.reporter.interval())
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		s.lock.Lock()
+		message := s.message
+		s.lock.Unlock()
+		s.reporter.write(fmt.Sprintf("%s%s %s", clearLine, spinnerFrames[frame%len(spinnerFrames)], message))
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update changes the message of the spinner
+func (s *Spinner) Update(message string) {
+	s.lock.Lock()
+	s.message = message
+	s.lock.Unlock()
+	if !s.reporter.Interactive {
+		s.reporter.Logger.Info(message)
+	}
+}
+
+// Success stops the spinner marking the operation as succeeded,
+// an empty message keeps the current message
+func (s *Spinner) Success(message string) {
+	if !s.finish() {
+		return
+	}
+	if message == "" {
+		message = s.message
+	}
+	elapsed := time.Since(s.start).Round(time.Millisecond)
+	if s.reporter.Interactive {
+		s.reporter.write(fmt.Sprintf("%s%s %s (%s)\n", clearLine, successMark, message, elapsed))
+		return
+	}
+	s.reporter.Logger.Infow(message, "elapsed", elapsed)
+}
+
+// Fail stops the spinner marking the operation as failed
+func (s *Spinner) Fail(err error) {
+	if !s.finish() {
+		return
+	}
+	if s.reporter.Interactive {
+		s.reporter.write(fmt.Sprintf("%s%s %s: %v\n", clearLine, failureMark, s.message, err))
+		return
+	}
+	s.reporter.Logger.Errorw(s.message, "err", err)
+}
+
+// finish stops the spinner goroutine, returns false if already finished
+func (s *Spinner) finish() bool {
+	s.lock.Lock()
+	if s.done {
+		s.lock.Unlock()
+		return false
+	}
+	s.done = true
+	s.lock.Unlock()
+
+	if s.reporter.Interactive {
+		close(s.stop)
+	}
+	<-s.stopped
+	return true
+}
+
+// Steps tracks a known number of sequential steps
+type Steps struct {
+	reporter *Reporter
+	total    int
+	current  int
+}
+
+// Steps returns a step tracker for total steps
+func (r *Reporter) Steps(total int) *Steps {
+	return &Steps{reporter: r, total: total}
+}
+
+// Run runs the next step showing a spinner with its name, e.g. [1/3] name
+func (s *Steps) Run(name string, fn func() error) error {
+	s.current++
+	spinner := s.reporter.Spinner(fmt.Sprintf("[%d/%d] %s", s.current, s.total, name))
+	if err := fn(); err != nil {
+		spinner.Fail(err)
+		return err
+	}
+	spinner.Success("")
+	return nil
+}
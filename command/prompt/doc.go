@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prompt has interactive prompt utilities reading from the IOStreams in the context.
+//
+// Prompts fail fast with ErrNotTerminal when stdin is not a terminal, unless
+// --yes or --non-interactive are set: --yes accepts all confirmations and
+// --non-interactive uses the default values, failing with ErrNonInteractive
+// when a prompt has no default. Prompts are written into the error stream
+// keeping the output stream for the command results.
+package prompt
@@ -0,0 +1,292 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+)
+
+var (
+	// ErrNotTerminal is returned when prompting without a terminal as stdin
+	ErrNotTerminal = errors.New("stdin is not a terminal, use --yes or --non-interactive")
+	// ErrNonInteractive is returned when prompting without a default value in non-interactive mode
+	ErrNonInteractive = errors.New("input required but running in non-interactive mode")
+)
+
+// Flags the --yes and --non-interactive flags
+type Flags struct {
+	// Yes accepts all confirmations
+	Yes bool
+	// NonInteractive never prompts, using default values
+	NonInteractive bool
+}
+
+// AddFlags add flags to the flag set
+func (f *Flags) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&f.Yes, "yes", "y", f.Yes, "automatically answer yes to confirmations")
+	flags.BoolVar(&f.NonInteractive, "non-interactive", f.NonInteractive, "never prompt for input, using default values")
+}
+
+// key for reading/writing into context
+type flagsKey struct{}
+
+// WithFlags adds Flags into the context
+func WithFlags(ctx context.Context, flags *Flags) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, flagsKey{}, flags)
+}
+
+// GetFlags returns Flags stored in the context if any
+// if not found will return nil *Flags
+func GetFlags(ctx context.Context) (flags *Flags) {
+	if ctx == nil {
+		return nil
+	}
+	flags, _ = ctx.Value(flagsKey{}).(*Flags)
+	return
+}
+
+// Prompter prompts for input writing into Out and reading from In
+type Prompter struct {
+	In  io.Reader
+	Out io.Writer
+	Flags
+
+	// IsTerminal returns true when In is a terminal,
+	// defaults to checking if In is a terminal file
+	IsTerminal func() bool
+	// ReadPassword reads a line without echo,
+	// defaults to term.ReadPassword when In is a terminal file
+	ReadPassword func() ([]byte, error)
+}
+
+// NewPrompter returns a Prompter using the IOStreams and Flags in the context
+func NewPrompter(ctx context.Context) *Prompter {
+	streams := cliio.MustGetIOStreams(ctx)
+	p := &Prompter{In: streams.In, Out: streams.ErrOut}
+	if flags := GetFlags(ctx); flags != nil {
+		p.Flags = *flags
+	}
+	return p
+}
+
+// Confirm asks a yes/no question, returns true without prompting when --yes is set
+func (p *Prompter) Confirm(message string, def bool) (bool, error) {
+	if p.Yes {
+		return true, nil
+	}
+	if p.NonInteractive {
+		return def, nil
+	}
+	if err := p.checkTerminal(); err != nil {
+		return false, err
+	}
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s [%s]: ", message, hint))
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.Out, "please answer yes or no")
+	}
+}
+
+// Select asks to choose one of the options, returning the index of the selected option.
+// def is the index of the default option, or -1 without default
+func (p *Prompter) Select(message string, options []string, def int) (int, error) {
+	if len(options) == 0 {
+		return -1, fmt.Errorf("no options to select")
+	}
+	hasDefault := def >= 0 && def < len(options)
+	if p.Yes || p.NonInteractive {
+		if !hasDefault {
+			return -1, ErrNonInteractive
+		}
+		return def, nil
+	}
+	if err := p.checkTerminal(); err != nil {
+		return -1, err
+	}
+
+	fmt.Fprintln(p.Out, message)
+	for i, option := range options {
+		fmt.Fprintf(p.Out, "  %d) %s\n", i+1, option)
+	}
+	question := fmt.Sprintf("choose 1-%d: ", len(options))
+	if hasDefault {
+		question = fmt.Sprintf("choose 1-%d [%d]: ", len(options), def+1)
+	}
+	for {
+		answer, err := p.ask(question)
+		if err != nil {
+			return -1, err
+		}
+		if answer == "" && hasDefault {
+			return def, nil
+		}
+		if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(options) {
+			return index - 1, nil
+		}
+		fmt.Fprintf(p.Out, "please choose a number between 1 and %d\n", len(options))
+	}
+}
+
+// Input asks for a value, returning def when the answer is empty
+func (p *Prompter) Input(message string, def string) (string, error) {
+	if p.Yes || p.NonInteractive {
+		if def == "" {
+			return "", ErrNonInteractive
+		}
+		return def, nil
+	}
+	if err := p.checkTerminal(); err != nil {
+		return "", err
+	}
+	question := fmt.Sprintf("%s: ", message)
+	if def != "" {
+		question = fmt.Sprintf("%s [%s]: ", message, def)
+	}
+	answer, err := p.ask(question)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// Password asks for a secret value without echoing it
+func (p *Prompter) Password(message string) (string, error) {
+	if p.Yes || p.NonInteractive {
+		return "", ErrNonInteractive
+	}
+	if err := p.checkTerminal(); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(p.Out, "%s: ", message)
+	readPassword := p.ReadPassword
+	if readPassword == nil {
+		readPassword = p.readPassword
+	}
+	password, err := readPassword()
+	// the new line is not echoed either
+	fmt.Fprintln(p.Out)
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+func (p *Prompter) checkTerminal() error {
+	isTerminal := p.IsTerminal
+	if isTerminal == nil {
+		isTerminal = p.isTerminal
+	}
+	if !isTerminal() {
+		return ErrNotTerminal
+	}
+	return nil
+}
+
+func (p *Prompter) isTerminal() bool {
+	file, ok := p.In.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+func (p *Prompter) readPassword() ([]byte, error) {
+	if file, ok := p.In.(*os.File); ok {
+		return term.ReadPassword(int(file.Fd()))
+	}
+	line, err := p.readLine()
+	return []byte(line), err
+}
+
+// ask writes the question and reads the trimmed answer
+func (p *Prompter) ask(question string) (string, error) {
+	fmt.Fprint(p.Out, question)
+	answer, err := p.readLine()
+	return strings.TrimSpace(answer), err
+}
+
+// readLine reads a line byte by byte to avoid buffering input of following prompts
+func (p *Prompter) readLine() (string, error) {
+	line := []byte{}
+	buf := make([]byte, 1)
+	for {
+		n, err := p.In.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return strings.TrimSuffix(string(line), "\r"), nil
+			}
+			line = append(line, buf[0])
+		}
+		if err == io.EOF {
+			if len(line) > 0 {
+				return string(line), nil
+			}
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// Confirm asks a yes/no question using the IOStreams and Flags in the context
+func Confirm(ctx context.Context, message string, def bool) (bool, error) {
+	return NewPrompter(ctx).Confirm(message, def)
+}
+
+// Select asks to choose one of the options using the IOStreams and Flags in the context
+func Select(ctx context.Context, message string, options []string, def int) (int, error) {
+	return NewPrompter(ctx).Select(message, options, def)
+}
+
+// Input asks for a value using the IOStreams and Flags in the context
+func Input(ctx context.Context, message string, def string) (string, error) {
+	return NewPrompter(ctx).Input(message, def)
+}
+
+// Password asks for a secret value using the IOStreams and Flags in the context
+func Password(ctx context.Context, message string) (string, error) {
+	return NewPrompter(ctx).Password(message)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newPrompter(input string, terminal bool, flags Flags) (*Prompter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &Prompter{
+		In:         strings.NewReader(input),
+		Out:        out,
+		Flags:      flags,
+		IsTerminal: func() bool { return terminal },
+	}, out
+}
+
+func TestPrompter_Confirm(t *testing.T) {
+	var data = []struct {
+		desc     string
+		input    string
+		terminal bool
+		flags    Flags
+		def      bool
+		expected bool
+		err      error
+	}{
+		{desc: "yes answer", input: "yes\n", terminal: true, expected: true},
+		{desc: "no answer", input: "N\n", terminal: true, def: true, expected: false},
+		{desc: "empty answer uses default", input: "\n", terminal: true, def: true, expected: true},
+		{desc: "asks again on invalid answer", input: "maybe\ny\n", terminal: true, expected: true},
+		{desc: "--yes does not prompt", flags: Flags{Yes: true}, expected: true},
+		{desc: "--non-interactive uses default", flags: Flags{NonInteractive: true}, def: false, expected: false},
+		{desc: "fails without terminal", input: "y\n", err: ErrNotTerminal},
+		{desc: "fails on end of input", input: "", terminal: true, err: io.ErrUnexpectedEOF},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			p, _ := newPrompter(item.input, item.terminal, item.flags)
+			result, err := p.Confirm("delete?", item.def)
+			if item.err != nil {
+				g.Expect(errors.Is(err, item.err)).To(BeTrue(), "got error %v", err)
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(result).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestPrompter_Select(t *testing.T) {
+	g := NewGomegaWithT(t)
+	options := []string{"first", "second", "third"}
+
+	p, out := newPrompter("4\n2\n", true, Flags{})
+	index, err := p.Select("pick one", options, -1)
+	g.Expect(err).To(BeNil())
+	g.Expect(index).To(Equal(1))
+	g.Expect(out.String()).To(ContainSubstring("  3) third\n"))
+	g.Expect(out.String()).To(ContainSubstring("please choose a number between 1 and 3"))
+
+	p, _ = newPrompter("\n", true, Flags{})
+	index, err = p.Select("pick one", options, 2)
+	g.Expect(err).To(BeNil())
+	g.Expect(index).To(Equal(2))
+
+	p, _ = newPrompter("", false, Flags{NonInteractive: true})
+	index, err = p.Select("pick one", options, 0)
+	g.Expect(err).To(BeNil())
+	g.Expect(index).To(Equal(0))
+
+	_, err = p.Select("pick one", options, -1)
+	g.Expect(err).To(Equal(ErrNonInteractive))
+
+	_, err = p.Select("pick one", nil, 0)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestPrompter_Input(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p, out := newPrompter("  value \r\nnext\n", true, Flags{})
+	value, err := p.Input("name", "default")
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal("value"))
+	g.Expect(out.String()).To(Equal("name [default]: "))
+	// following prompts read the remaining input
+	value, err = p.Input("name", "")
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal("next"))
+
+	p, _ = newPrompter("\n", true, Flags{})
+	value, err = p.Input("name", "default")
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal("default"))
+
+	p, _ = newPrompter("", false, Flags{Yes: true})
+	_, err = p.Input("name", "")
+	g.Expect(err).To(Equal(ErrNonInteractive))
+
+	p, _ = newPrompter("value\n", false, Flags{})
+	_, err = p.Input("name", "default")
+	g.Expect(err).To(Equal(ErrNotTerminal))
+}
+
+func TestPrompter_Password(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p, out := newPrompter("", true, Flags{})
+	p.ReadPassword = func() ([]byte, error) { return []byte("secret"), nil }
+	password, err := p.Password("password")
+	g.Expect(err).To(BeNil())
+	g.Expect(password).To(Equal("secret"))
+	g.Expect(out.String()).To(Equal("password: \n"))
+
+	p, _ = newPrompter("from-pipe\n", true, Flags{})
+	password, err = p.Password("password")
+	g.Expect(err).To(BeNil())
+	g.Expect(password).To(Equal("from-pipe"))
+
+	p, _ = newPrompter("", true, Flags{NonInteractive: true})
+	_, err = p.Password("password")
+	g.Expect(err).To(Equal(ErrNonInteractive))
+}
+
+func TestContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	flags := &Flags{}
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(flagSet)
+	g.Expect(flagSet.Parse([]string{"-y"})).To(Succeed())
+
+	streams, in, _, errOut := clioptions.NewTestIOStreams()
+	ctx := cliio.WithIOStreams(context.Background(), &streams)
+	g.Expect(GetFlags(ctx)).To(BeNil())
+	ctx = WithFlags(ctx, flags)
+	g.Expect(GetFlags(ctx)).To(Equal(flags))
+
+	confirmed, err := Confirm(ctx, "delete?", false)
+	g.Expect(err).To(BeNil())
+	g.Expect(confirmed).To(BeTrue())
+
+	// a buffer is not a terminal
+	in.WriteString("value\n")
+	_, err = Input(cliio.WithIOStreams(context.Background(), &streams), "name", "")
+	g.Expect(err).To(Equal(ErrNotTerminal))
+	g.Expect(errOut.String()).To(BeEmpty())
+}
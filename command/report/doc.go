@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report lets subcommands record structured results of a run, like the
+// resources created, warnings and step durations, emitted as a JSON report when
+// the command finishes so pipelines do not need to parse logs.
+//
+//	ctx = report.WithFlags(ctx, report.NewFlags())
+//	cmd := root.NewRootCommand(ctx, "my-cli", subcommands...)
+//
+//	// inside a subcommand
+//	report.Resource(cmd.Context(), report.ActionCreated, obj)
+//	report.Step(cmd.Context(), "wait", time.Since(start))
+//
+//	// my-cli apply --report-file report.json
+//	// my-cli apply --report-file -
+package report
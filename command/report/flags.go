@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"github.com/spf13/pflag"
+)
+
+// Stdout is the --report-file value writing the report into the command output
+const Stdout = "-"
+
+// Flags the --report-file flag
+type Flags struct {
+	// File the report is written into when the command finishes,
+	// Stdout writes it into the command output and empty disables the report
+	File string
+}
+
+// NewFlags returns Flags with the report disabled
+func NewFlags() *Flags {
+	return &Flags{}
+}
+
+// AddFlags add flags to the flag set
+func (f *Flags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.File, "report-file", f.File,
+		fmt.Sprintf("write a JSON report of the run into the file when the command finishes, %q writes it into stdout", Stdout))
+}
+
+// Enabled returns true when a report was requested
+func (f *Flags) Enabled() bool {
+	return f != nil && f.File != ""
+}
+
+// Write writes report as indented JSON into the requested file, or out when Stdout
+func (f *Flags) Write(out io.Writer, report Report) error {
+	if !f.Enabled() {
+		return nil
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	content = append(content, '\n')
+	if f.File == Stdout {
+		_, err = out.Write(content)
+		return err
+	}
+	if err := os.WriteFile(f.File, content, 0o644); err != nil {
+		return fmt.Errorf("write report %s: %w", f.File, err)
+	}
+	return nil
+}
+
+// WithFlags adds Flags into the context
+func WithFlags(ctx context.Context, flags *Flags) context.Context {
+	return ctxutil.With(ctx, flags)
+}
+
+// GetFlags returns Flags stored in the context if any
+// if not found will return nil *Flags
+func GetFlags(ctx context.Context) *Flags {
+	flags, _ := ctxutil.From[*Flags](ctx)
+	return flags
+}
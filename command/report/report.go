@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Actions done on resources
+const (
+	ActionCreated   = "created"
+	ActionUpdated   = "updated"
+	ActionDeleted   = "deleted"
+	ActionUnchanged = "unchanged"
+)
+
+// Report is the result of a command run
+type Report struct {
+	// Command is the path of the command, e.g. "my-cli apply"
+	Command string `json:"command"`
+	// Success is false when the command returned an error
+	Success bool `json:"success"`
+	// Error message returned by the command
+	Error string `json:"error,omitempty"`
+	// StartTime when the command started
+	StartTime time.Time `json:"startTime"`
+	// DurationSeconds of the whole command run
+	DurationSeconds float64 `json:"durationSeconds"`
+	// Resources changed by the command
+	Resources []ChangedResource `json:"resources,omitempty"`
+	// Warnings reported by the command or the api server
+	Warnings []string `json:"warnings,omitempty"`
+	// Steps durations in the order they were recorded
+	Steps []StepDuration `json:"steps,omitempty"`
+	// Results are custom values set by the command
+	Results map[string]any `json:"results,omitempty"`
+}
+
+// ChangedResource is a resource changed by a command
+type ChangedResource struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Action done on the resource, e.g. ActionCreated
+	Action string `json:"action"`
+}
+
+// StepDuration is the duration of a named step of a command
+type StepDuration struct {
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// Recorder records the results of a command run, it is safe for concurrent use
+type Recorder struct {
+	lock   sync.Mutex
+	report Report
+}
+
+// NewRecorder returns a Recorder for the command started at start
+func NewRecorder(command string, start time.Time) *Recorder {
+	return &Recorder{report: Report{Command: command, StartTime: start}}
+}
+
+// Resource records that action was done on obj, objects without metadata are ignored
+func (r *Recorder) Resource(action string, obj runtime.Object) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	apiVersion, kind := obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.report.Resources = append(r.report.Resources, ChangedResource{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  accessor.GetNamespace(),
+		Name:       accessor.GetName(),
+		Action:     action,
+	})
+}
+
+// Warning records a warning message, identical warnings are only recorded once
+func (r *Recorder) Warning(message string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, warning := range r.report.Warnings {
+		if warning == message {
+			return
+		}
+	}
+	r.report.Warnings = append(r.report.Warnings, message)
+}
+
+// Step records the duration of the step name
+func (r *Recorder) Step(name string, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.report.Steps = append(r.report.Steps, StepDuration{Name: name, DurationSeconds: duration.Seconds()})
+}
+
+// Set sets the custom result key to value, value must be serializable to JSON
+func (r *Recorder) Set(key string, value any) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.report.Results == nil {
+		r.report.Results = map[string]any{}
+	}
+	r.report.Results[key] = value
+}
+
+// Report returns the report of the command finished at end with err
+func (r *Recorder) Report(err error, end time.Time) Report {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	report := r.report
+	report.Resources = append([]ChangedResource(nil), report.Resources...)
+	report.Warnings = append([]string(nil), report.Warnings...)
+	report.Steps = append([]StepDuration(nil), report.Steps...)
+	if report.Results != nil {
+		results := make(map[string]any, len(report.Results))
+		for key, value := range report.Results {
+			results[key] = value
+		}
+		report.Results = results
+	}
+	report.Success = err == nil
+	if err != nil {
+		report.Error = err.Error()
+	}
+	report.DurationSeconds = end.Sub(report.StartTime).Seconds()
+	return report
+}
+
+// WithRecorder adds the Recorder into the context
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return ctxutil.With(ctx, recorder)
+}
+
+// GetRecorder returns the Recorder stored in the context if any,
+// the functions of this package are no-op when not found
+func GetRecorder(ctx context.Context) *Recorder {
+	recorder, _ := ctxutil.From[*Recorder](ctx)
+	return recorder
+}
+
+// Resource records that action was done on obj in the Recorder of the context
+func Resource(ctx context.Context, action string, obj runtime.Object) {
+	if recorder := GetRecorder(ctx); recorder != nil {
+		recorder.Resource(action, obj)
+	}
+}
+
+// Warning records a warning formatted using fmt.Sprintf in the Recorder of the context
+func Warning(ctx context.Context, format string, args ...any) {
+	if recorder := GetRecorder(ctx); recorder != nil {
+		recorder.Warning(fmt.Sprintf(format, args...))
+	}
+}
+
+// Step records the duration of the step name in the Recorder of the context
+func Step(ctx context.Context, name string, duration time.Duration) {
+	if recorder := GetRecorder(ctx); recorder != nil {
+		recorder.Step(name, duration)
+	}
+}
+
+// Set sets the custom result key to value in the Recorder of the context
+func Set(ctx context.Context, key string, value any) {
+	if recorder := GetRecorder(ctx); recorder != nil {
+		recorder.Set(key, value)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecorder(t *testing.T) {
+	g := NewGomegaWithT(t)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder("my-cli apply", start)
+	ctx := WithRecorder(context.Background(), recorder)
+
+	Resource(ctx, ActionUpdated, &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+	})
+	Resource(ctx, ActionCreated, nil)
+	Warning(ctx, "%d objects skipped", 2)
+	Warning(ctx, "%d objects skipped", 2)
+	Step(ctx, "wait", 1500*time.Millisecond)
+	Set(ctx, "revision", 3)
+
+	report := recorder.Report(nil, start.Add(2*time.Second))
+	g.Expect(report).To(Equal(Report{
+		Command:         "my-cli apply",
+		Success:         true,
+		StartTime:       start,
+		DurationSeconds: 2,
+		Resources: []ChangedResource{{
+			APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "app", Action: ActionUpdated,
+		}},
+		Warnings: []string{"2 objects skipped"},
+		Steps:    []StepDuration{{Name: "wait", DurationSeconds: 1.5}},
+		Results:  map[string]any{"revision": 3},
+	}))
+
+	report = recorder.Report(errors.New("timed out"), start.Add(time.Second))
+	g.Expect(report.Success).To(BeFalse())
+	g.Expect(report.Error).To(Equal("timed out"))
+
+	// no-op without a recorder
+	Warning(context.Background(), "ignored")
+	g.Expect(GetRecorder(context.Background())).To(BeNil())
+}
+
+func TestFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	flags := NewFlags()
+	g.Expect(flags.Enabled()).To(BeFalse())
+	g.Expect(GetFlags(WithFlags(context.Background(), flags))).To(Equal(flags))
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(fs)
+	file := filepath.Join(t.TempDir(), "report.json")
+	g.Expect(fs.Parse([]string{"--report-file", file})).To(Succeed())
+	g.Expect(flags.Enabled()).To(BeTrue())
+
+	report := Report{Command: "my-cli", Success: true}
+	out := &bytes.Buffer{}
+	g.Expect(flags.Write(out, report)).To(Succeed())
+	g.Expect(out.String()).To(BeEmpty())
+	content, err := os.ReadFile(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	written := Report{}
+	g.Expect(json.Unmarshal(content, &written)).To(Succeed())
+	g.Expect(written.Command).To(Equal("my-cli"))
+
+	flags.File = Stdout
+	g.Expect(flags.Write(out, report)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`"command": "my-cli"`))
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// supported shells of the completion subcommand
+const (
+	shellBash       = "bash"
+	shellZsh        = "zsh"
+	shellFish       = "fish"
+	shellPowerShell = "powershell"
+)
+
+// supported formats of the docs subcommand
+const (
+	docsFormatMarkdown = "markdown"
+	docsFormatMan      = "man"
+	docsFormatReST     = "rest"
+	docsFormatYAML     = "yaml"
+)
+
+// newCompletionCommand returns a completion subcommand writing the
+// completion script of the root command into the command output
+func newCompletionCommand(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   fmt.Sprintf("completion [%s|%s|%s|%s]", shellBash, shellZsh, shellFish, shellPowerShell),
+		Short: "Generate the autocompletion script for the specified shell",
+		Long: fmt.Sprintf(`Generate the autocompletion script for %[1]s for the specified shell.
+
+For example, to load completions in the current bash session:
+
+	source <(%[1]s completion bash)
+`, name),
+		ValidArgs:             []string{shellBash, shellZsh, shellFish, shellPowerShell},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, out := cmd.Root(), cmd.OutOrStdout()
+			switch args[0] {
+			case shellBash:
+				return root.GenBashCompletionV2(out, true)
+			case shellZsh:
+				return root.GenZshCompletion(out)
+			case shellFish:
+				return root.GenFishCompletion(out, true)
+			default:
+				return root.GenPowerShellCompletionWithDesc(out)
+			}
+		},
+	}
+}
+
+// docsOptions options of the docs subcommand
+type docsOptions struct {
+	format string
+	dir    string
+}
+
+// newDocsCommand returns a docs subcommand generating documents for
+// all commands of the root command into a directory
+func newDocsCommand(name string) *cobra.Command {
+	opts := &docsOptions{}
+	formats := []string{docsFormatMarkdown, docsFormatMan, docsFormatReST, docsFormatYAML}
+	cmd := &cobra.Command{
+		Use:     "docs",
+		Aliases: []string{"man"},
+		Short:   fmt.Sprintf("Generate documents for %s commands", name),
+		Long: fmt.Sprintf(`Generate documents for %[1]s commands, one file per command.
+
+When invoked as "man" defaults to man pages, e.g.:
+
+	%[1]s man --dir /usr/local/share/man/man1
+`, name),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.CalledAs() == "man" && !cmd.Flags().Changed("format") {
+				opts.format = docsFormatMan
+			}
+			return opts.run(cmd, name)
+		},
+	}
+	cmd.Flags().StringVar(&opts.format, "format", docsFormatMarkdown, fmt.Sprintf("document format. One of: (%s)", strings.Join(formats, ", ")))
+	cmd.Flags().StringVar(&opts.dir, "dir", "docs", "directory to write the documents into")
+	return cmd
+}
+
+func (opts *docsOptions) run(cmd *cobra.Command, name string) error {
+	if err := os.MkdirAll(opts.dir, 0o755); err != nil {
+		return fmt.Errorf("create docs directory %s: %w", opts.dir, err)
+	}
+
+	root := cmd.Root()
+	// keeps generated documents reproducible
+	root.DisableAutoGenTag = true
+
+	var err error
+	switch opts.format {
+	case docsFormatMarkdown:
+		err = doc.GenMarkdownTree(root, opts.dir)
+	case docsFormatMan:
+		err = doc.GenManTree(root, &doc.GenManHeader{Title: strings.ToUpper(name), Section: "1"}, opts.dir)
+	case docsFormatReST:
+		err = doc.GenReSTTree(root, opts.dir)
+	case docsFormatYAML:
+		err = doc.GenYamlTree(root, opts.dir)
+	default:
+		return fmt.Errorf("unsupported docs format %q", opts.format)
+	}
+	if err != nil {
+		return fmt.Errorf("generate %s docs: %w", opts.format, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "generated %s docs into %s\n", opts.format, opts.dir)
+	return nil
+}
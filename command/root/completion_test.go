@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("NewRootCommandWithOptions", func() {
+	var (
+		ctx  context.Context
+		out  *bytes.Buffer
+		opts root.Options
+		args []string
+		cmd  *cobra.Command
+		err  error
+	)
+
+	BeforeEach(func() {
+		var streams clioptions.IOStreams
+		streams, _, out, _ = clioptions.NewTestIOStreams()
+		streams.ErrOut = GinkgoWriter
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		opts = root.Options{Completion: true, Docs: true}
+	})
+
+	JustBeforeEach(func() {
+		cmd = root.NewRootCommandWithOptions(ctx, "test-cli", opts, func(_ context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "subcommand", Short: "a subcommand", Run: func(_ *cobra.Command, _ []string) {}}
+		})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	When("completion for bash", func() {
+		BeforeEach(func() {
+			args = []string{"completion", "bash"}
+		})
+		It("should write the script into the output stream", func() {
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("bash completion V2 for test-cli"))
+		})
+	})
+
+	When("completion for an unknown shell", func() {
+		BeforeEach(func() {
+			args = []string{"completion", "tcsh"}
+		})
+		It("should fail", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("generating man pages", func() {
+		var dir string
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			args = []string{"man", "--dir", dir}
+		})
+		It("should write one page per command", func() {
+			Expect(err).To(BeNil())
+			Expect(filepath.Join(dir, "test-cli.1")).To(BeAnExistingFile())
+			Expect(filepath.Join(dir, "test-cli-subcommand.1")).To(BeAnExistingFile())
+		})
+	})
+
+	When("generating markdown docs", func() {
+		var dir string
+		BeforeEach(func() {
+			dir = filepath.Join(GinkgoT().TempDir(), "docs")
+			args = []string{"docs", "--dir", dir}
+		})
+		It("should write one document per command", func() {
+			Expect(err).To(BeNil())
+			content, readErr := os.ReadFile(filepath.Join(dir, "test-cli_subcommand.md"))
+			Expect(readErr).To(BeNil())
+			Expect(string(content)).To(ContainSubstring("a subcommand"))
+		})
+	})
+
+	When("handling signals", func() {
+		var subCtx context.Context
+		BeforeEach(func() {
+			opts = root.Options{Signals: true}
+			args = []string{}
+		})
+		JustBeforeEach(func() {
+			subCtx = nil
+			sub := root.NewRootCommandWithOptions(ctx, "test-cli", opts, func(ctx context.Context, _ string) *cobra.Command {
+				subCtx = ctx
+				return &cobra.Command{Use: "subcommand"}
+			})
+			Expect(sub.Context()).To(Equal(subCtx))
+		})
+		It("should give a cancellable context to subcommands", func() {
+			Expect(err).To(BeNil())
+			Expect(subCtx).NotTo(BeNil())
+			Expect(subCtx.Done()).NotTo(BeNil())
+			Expect(subCtx.Err()).To(BeNil())
+		})
+	})
+
+	When("options are disabled", func() {
+		BeforeEach(func() {
+			opts = root.Options{}
+			args = []string{"docs"}
+		})
+		It("should not have docs subcommand", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
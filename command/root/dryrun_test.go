@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/dryrun"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("DryRun", func() {
+	var (
+		ctx      context.Context
+		errOut   *bytes.Buffer
+		args     []string
+		failure  error
+		strategy dryrun.Strategy
+		err      error
+	)
+
+	BeforeEach(func() {
+		var streams clioptions.IOStreams
+		streams, _, _, errOut = clioptions.NewTestIOStreams()
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		ctx = dryrun.WithFlags(ctx, dryrun.NewFlags())
+		args = []string{"subcommand"}
+		failure = nil
+	})
+
+	JustBeforeEach(func() {
+		cmd := root.NewRootCommand(ctx, "test-cli", func(ctx context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "subcommand", RunE: func(cmd *cobra.Command, _ []string) error {
+				strategy = dryrun.StrategyFrom(cmd.Context())
+				dryrun.Record(cmd.Context(), dryrun.Action{
+					Verb:             "create",
+					GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+					Namespace:        "default",
+					Name:             "config",
+				})
+				return failure
+			}}
+		})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	It("should not dry run by default", func() {
+		Expect(err).To(BeNil())
+		Expect(strategy).To(Equal(dryrun.None))
+		Expect(errOut.String()).To(BeEmpty())
+	})
+
+	When("a server dry run is requested", func() {
+		BeforeEach(func() {
+			args = append(args, "--dry-run=server")
+		})
+		It("should store the strategy and print the summary", func() {
+			Expect(err).To(BeNil())
+			Expect(strategy).To(Equal(dryrun.Server))
+			Expect(errOut.String()).To(Equal("Dry run (server), no changes were persisted:\n  would create ConfigMap default/config\n"))
+		})
+	})
+
+	When("the command fails", func() {
+		BeforeEach(func() {
+			args = append(args, "--dry-run")
+			failure = errors.New("failed")
+		})
+		It("should print the summary", func() {
+			Expect(err).To(MatchError("failed"))
+			Expect(strategy).To(Equal(dryrun.Client))
+			Expect(errOut.String()).To(ContainSubstring("would create ConfigMap default/config\n"))
+		})
+	})
+
+	When("the strategy is invalid", func() {
+		BeforeEach(func() {
+			args = append(args, "--dry-run=all")
+		})
+		It("should fail", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid dry run strategy")))
+		})
+	})
+})
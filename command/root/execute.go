@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AlaudaDevops/pkg/command/exit"
+)
+
+// Execute executes cmd and returns the exit code of the process following the exit package policy.
+// Errors are printed to ErrOut as a machine-parsable exit.Line instead of cobra's error message:
+//
+//	cmd := root.NewRootCommand(ctx, "mycli", subcommands...)
+//	os.Exit(root.Execute(cmd))
+func Execute(cmd *cobra.Command) int {
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	if err != nil {
+		fmt.Fprintln(cmd.ErrOrStderr(), exit.Line(err))
+	}
+	return exit.Code(err)
+}
+
+// usageErrors wraps the flag and argument errors of cmd and its subcommands in exit.UsageError
+func usageErrors(cmd *cobra.Command) {
+	if !cmd.HasParent() {
+		// inherited by subcommands
+		cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+			return &exit.UsageError{Err: err}
+		})
+	}
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			if err := args(cmd, a); err != nil {
+				return &exit.UsageError{Err: err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		usageErrors(sub)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/AlaudaDevops/pkg/command/exit"
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("Execute", func() {
+	var (
+		ctx     context.Context
+		errOut  *bytes.Buffer
+		args    []string
+		failure error
+		code    int
+	)
+
+	BeforeEach(func() {
+		var streams clioptions.IOStreams
+		streams, _, _, errOut = clioptions.NewTestIOStreams()
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		args = []string{"get", "app"}
+		failure = nil
+	})
+
+	JustBeforeEach(func() {
+		cmd := root.NewRootCommand(ctx, "test-cli", func(ctx context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "get NAME", Args: cobra.ExactArgs(1), RunE: func(_ *cobra.Command, args []string) error {
+				return failure
+			}}
+		})
+		cmd.SetArgs(args)
+		code = root.Execute(cmd)
+	})
+
+	It("should exit with 0", func() {
+		Expect(code).To(Equal(exit.OK))
+		Expect(errOut.String()).To(BeEmpty())
+	})
+
+	When("the command returns a typed error", func() {
+		BeforeEach(func() {
+			failure = exit.NotFoundf("app %q not found", "app")
+		})
+		It("should print a machine-parsable line and exit with its code", func() {
+			Expect(code).To(Equal(exit.NotFound))
+			Expect(errOut.String()).To(Equal(`error code=3 reason=NotFound message="app \"app\" not found"` + "\n"))
+		})
+	})
+
+	When("a flag is unknown", func() {
+		BeforeEach(func() {
+			args = []string{"get", "app", "--unknown"}
+		})
+		It("should exit with the usage code", func() {
+			Expect(code).To(Equal(exit.Usage))
+			Expect(errOut.String()).To(ContainSubstring("error code=2 reason=Usage message=\"unknown flag: --unknown\"\n"))
+		})
+	})
+
+	When("arguments are invalid", func() {
+		BeforeEach(func() {
+			args = []string{"get"}
+		})
+		It("should exit with the usage code", func() {
+			Expect(code).To(Equal(exit.Usage))
+			Expect(errOut.String()).To(ContainSubstring("error code=2 reason=Usage"))
+		})
+	})
+})
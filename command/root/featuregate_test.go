@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"context"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/featuregate"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("FeatureGates", func() {
+	var (
+		ctx     context.Context
+		args    []string
+		enabled map[string]bool
+		err     error
+	)
+
+	BeforeEach(func() {
+		streams, _, _, _ := clioptions.NewTestIOStreams()
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		gate := featuregate.New()
+		gate.MustAdd(map[string]featuregate.Spec{
+			"Foo": {Default: false, Maturity: featuregate.Alpha},
+			"Bar": {Default: true, Maturity: featuregate.Beta},
+		})
+		ctx = featuregate.WithGate(ctx, gate)
+		args = []string{"subcommand"}
+		enabled = nil
+	})
+
+	JustBeforeEach(func() {
+		cmd := root.NewRootCommand(ctx, "test-cli", func(ctx context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "subcommand", RunE: func(cmd *cobra.Command, _ []string) error {
+				enabled = map[string]bool{
+					"Foo": featuregate.Enabled(cmd.Context(), "Foo"),
+					"Bar": featuregate.Enabled(cmd.Context(), "Bar"),
+				}
+				return nil
+			}}
+		})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	It("should use the defaults", func() {
+		Expect(err).To(BeNil())
+		Expect(enabled).To(Equal(map[string]bool{"Foo": false, "Bar": true}))
+	})
+
+	When("the --feature-gates flag is given", func() {
+		BeforeEach(func() {
+			args = append(args, "--feature-gates=Foo=true,Bar=false")
+		})
+		It("should set the gates", func() {
+			Expect(err).To(BeNil())
+			Expect(enabled).To(Equal(map[string]bool{"Foo": true, "Bar": false}))
+		})
+	})
+
+	When("an unknown gate is given", func() {
+		BeforeEach(func() {
+			args = append(args, "--feature-gates=Baz=true")
+		})
+		It("should fail", func() {
+			Expect(err).To(MatchError(ContainSubstring(`unknown feature gate "Baz"`)))
+		})
+	})
+})
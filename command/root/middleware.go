@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/AlaudaDevops/pkg/command/exit"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/ctxutil"
+)
+
+// RunFunc is the run function of a command
+type RunFunc func(cmd *cobra.Command, args []string) error
+
+// Middleware wraps the run function of commands to add cross-cutting behavior
+type Middleware func(next RunFunc) RunFunc
+
+type middlewares []Middleware
+
+// WithMiddleware adds middlewares into the context. NewRootCommand wraps the run function of
+// every command with them, the first middleware being the outermost one
+func WithMiddleware(ctx context.Context, m ...Middleware) context.Context {
+	return ctxutil.With(ctx, middlewares(append(GetMiddlewares(ctx), m...)))
+}
+
+// GetMiddlewares returns the middlewares stored in the context
+func GetMiddlewares(ctx context.Context) []Middleware {
+	m, _ := ctxutil.From[middlewares](ctx)
+	return append([]Middleware{}, m...)
+}
+
+// applyMiddlewares wraps the run functions of cmd and its subcommands with middlewares,
+// Run functions become RunE functions returning nil
+func applyMiddlewares(cmd *cobra.Command, middlewares []Middleware) {
+	var run RunFunc
+	if cmd.RunE != nil {
+		run = cmd.RunE
+	} else if cmd.Run != nil {
+		fn := cmd.Run
+		run = func(cmd *cobra.Command, args []string) error {
+			fn(cmd, args)
+			return nil
+		}
+	}
+	if run != nil {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			run = middlewares[i](run)
+		}
+		cmd.Run, cmd.RunE = nil, run
+	}
+	for _, sub := range cmd.Commands() {
+		applyMiddlewares(sub, middlewares)
+	}
+}
+
+// Timing logs the duration of commands at debug level, visible with -v
+func Timing() Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			start := time.Now()
+			err := next(cmd, args)
+			logger.GetLogger(cmd.Context()).Debugw("command finished", "command", cmd.CommandPath(), "duration", time.Since(start).String(), "err", err)
+			return err
+		}
+	}
+}
+
+// PanicError is returned by commands which panicked when using Recover
+type PanicError struct {
+	// Value given to panic
+	Value any
+	// Stack of the panicking goroutine
+	Stack []byte
+}
+
+// Error implements error
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("unexpected error: %v, please report this issue with the output of the command run with -v", e.Value)
+}
+
+// Recover returns a PanicError instead of crashing when commands panic.
+// The stack is logged at debug level, visible with -v
+func Recover() Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if value := recover(); value != nil {
+					panicErr := &PanicError{Value: value, Stack: debug.Stack()}
+					logger.GetLogger(cmd.Context()).Debugw("command panicked", "command", cmd.CommandPath(), "panic", value, "stack", string(panicErr.Stack))
+					err = panicErr
+				}
+			}()
+			return next(cmd, args)
+		}
+	}
+}
+
+// ExitCodes maps the errors returned by commands to exit codes, wrapping them in exit.CodeError.
+// Errors mapped to 0 or already having an exit code are returned as is
+func ExitCodes(mapping func(err error) int) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			err := next(cmd, args)
+			var coder exit.Coder
+			if err == nil || errors.As(err, &coder) {
+				return err
+			}
+			if code := mapping(err); code != 0 {
+				return exit.WithCode(err, code)
+			}
+			return err
+		}
+	}
+}
+
+// Invocation of a command reported by Telemetry. Argument and flag values are never included
+type Invocation struct {
+	// Command path, e.g. mycli apply
+	Command string
+	// Flags set by the user, names only
+	Flags []string
+	// Duration of the command
+	Duration time.Duration
+	// Succeeded is true when the command returned no error
+	Succeeded bool
+	// ExitCode of the error, see exit.Code
+	ExitCode int
+}
+
+// Telemetry sends an Invocation of each command run when optedIn returns true, users must
+// opt in explicitly, e.g. with a config setting or an environment variable
+func Telemetry(optedIn func(cmd *cobra.Command) bool, send func(ctx context.Context, invocation Invocation)) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if !optedIn(cmd) {
+				return next(cmd, args)
+			}
+			start := time.Now()
+			err := next(cmd, args)
+			invocation := Invocation{
+				Command:   cmd.CommandPath(),
+				Duration:  time.Since(start),
+				Succeeded: err == nil,
+				ExitCode:  exit.Code(err),
+			}
+			cmd.Flags().Visit(func(flag *pflag.Flag) {
+				invocation.Flags = append(invocation.Flags, flag.Name)
+			})
+			send(cmd.Context(), invocation)
+			return err
+		}
+	}
+}
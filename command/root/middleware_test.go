@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlaudaDevops/pkg/command/exit"
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("Middleware", func() {
+	var (
+		ctx         context.Context
+		calls       []string
+		run         func() error
+		args        []string
+		err         error
+		middlewares []root.Middleware
+	)
+
+	record := func(name string) root.Middleware {
+		return func(next root.RunFunc) root.RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				calls = append(calls, name+":"+cmd.Name())
+				return next(cmd, args)
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		streams, _, _, _ := clioptions.NewTestIOStreams()
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		calls = nil
+		run = func() error { return nil }
+		args = []string{"subcommand"}
+		middlewares = []root.Middleware{record("first"), record("second")}
+	})
+
+	JustBeforeEach(func() {
+		ctx = root.WithMiddleware(ctx, middlewares...)
+		cmd := root.NewRootCommand(ctx, "test-cli", func(ctx context.Context, _ string) *cobra.Command {
+			sub := &cobra.Command{Use: "subcommand", RunE: func(_ *cobra.Command, _ []string) error {
+				calls = append(calls, "run")
+				return run()
+			}}
+			sub.Flags().String("name", "", "name")
+			sub.AddCommand(&cobra.Command{Use: "nested", Run: func(_ *cobra.Command, _ []string) {
+				calls = append(calls, "nested")
+			}})
+			return sub
+		})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	It("should wrap subcommands in order", func() {
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal([]string{"first:subcommand", "second:subcommand", "run"}))
+	})
+
+	When("the subcommand uses Run", func() {
+		BeforeEach(func() {
+			args = []string{"subcommand", "nested"}
+		})
+		It("should wrap it too", func() {
+			Expect(err).To(BeNil())
+			Expect(calls).To(Equal([]string{"first:nested", "second:nested", "nested"}))
+		})
+	})
+
+	When("the command panics", func() {
+		BeforeEach(func() {
+			middlewares = []root.Middleware{root.Recover()}
+			run = func() error { panic("boom") }
+		})
+		It("should return a friendly error", func() {
+			panicErr := &root.PanicError{}
+			Expect(errors.As(err, &panicErr)).To(BeTrue())
+			Expect(panicErr.Value).To(Equal("boom"))
+			Expect(panicErr.Stack).NotTo(BeEmpty())
+			Expect(err.Error()).To(ContainSubstring("unexpected error: boom"))
+			Expect(exit.Code(err)).To(Equal(1))
+		})
+	})
+
+	When("errors are mapped to exit codes", func() {
+		var notFound = errors.New("not found")
+
+		BeforeEach(func() {
+			middlewares = []root.Middleware{root.ExitCodes(func(err error) int {
+				if errors.Is(err, notFound) {
+					return 3
+				}
+				return 0
+			})}
+			run = func() error { return fmt.Errorf("get: %w", notFound) }
+		})
+		It("should return the exit code", func() {
+			Expect(err).To(MatchError(notFound))
+			Expect(exit.Code(err)).To(Equal(3))
+		})
+
+		When("the error is not mapped", func() {
+			BeforeEach(func() {
+				run = func() error { return errors.New("failed") }
+			})
+			It("should exit with 1", func() {
+				Expect(exit.Code(err)).To(Equal(1))
+			})
+		})
+	})
+
+	When("telemetry is enabled", func() {
+		var (
+			optedIn     bool
+			invocations []root.Invocation
+		)
+
+		BeforeEach(func() {
+			optedIn = true
+			invocations = nil
+			args = []string{"subcommand", "--name", "secret"}
+			run = func() error { return exit.WithCode(errors.New("failed"), 2) }
+			middlewares = []root.Middleware{root.Telemetry(
+				func(*cobra.Command) bool { return optedIn },
+				func(_ context.Context, invocation root.Invocation) { invocations = append(invocations, invocation) },
+			)}
+		})
+		It("should send the invocation without flag values", func() {
+			Expect(invocations).To(HaveLen(1))
+			Expect(invocations[0].Command).To(Equal("test-cli subcommand"))
+			Expect(invocations[0].Flags).To(Equal([]string{"name"}))
+			Expect(invocations[0].Succeeded).To(BeFalse())
+			Expect(invocations[0].ExitCode).To(Equal(2))
+			Expect(fmt.Sprint(invocations[0])).NotTo(ContainSubstring("secret"))
+		})
+
+		When("the user did not opt in", func() {
+			BeforeEach(func() {
+				optedIn = false
+			})
+			It("should not send anything", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(invocations).To(BeEmpty())
+			})
+		})
+	})
+})
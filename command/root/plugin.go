@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/AlaudaDevops/pkg/command/exec"
+	"github.com/AlaudaDevops/pkg/command/io"
+)
+
+// PluginHandler finds and executes plugins of a cli
+type PluginHandler interface {
+	// Lookup returns the path of the plugin executable named filename, if found
+	Lookup(filename string) (path string, found bool)
+	// Execute runs the plugin executable with args and the environment variables env
+	Execute(ctx context.Context, path string, args, env []string) error
+}
+
+// DefaultPluginHandler looks up plugins in PATH and runs them using
+// the exec.Cmder in the context, forwarding the IOStreams in the context
+type DefaultPluginHandler struct{}
+
+var _ PluginHandler = DefaultPluginHandler{}
+
+// Lookup looks up filename in PATH
+func (DefaultPluginHandler) Lookup(filename string) (string, bool) {
+	path, err := osexec.LookPath(filename)
+	if err != nil || path == "" {
+		return "", false
+	}
+	return path, true
+}
+
+// Execute runs the plugin
+func (DefaultPluginHandler) Execute(ctx context.Context, path string, args, env []string) error {
+	streams := io.MustGetIOStreams(ctx)
+	return exec.FromContextCmder(ctx).CommandContext(ctx, path, args...).
+		SetEnv(env...).
+		SetStdin(streams.In).
+		SetStdout(streams.Out).
+		SetStderr(streams.ErrOut).
+		Run()
+}
+
+// pluginPrefix returns the prefix of plugin executables, e.g. mycli-
+func pluginPrefix(name string) string {
+	return name + "-"
+}
+
+// enablePlugins runs a plugin named <name>-<subcommand> when the subcommand is unknown.
+// Flags of the root command are only parsed before the plugin name, all the following
+// arguments are given to the plugin as is
+func enablePlugins(ctx context.Context, rootCmd *cobra.Command, name string, handler PluginHandler) {
+	if handler == nil {
+		handler = DefaultPluginHandler{}
+	}
+	// unknown subcommands are handled by the root command
+	rootCmd.Args = cobra.ArbitraryArgs
+	// flags of plugins are unknown to the root command
+	rootCmd.DisableFlagParsing = true
+	rootCmd.Run = nil
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		// merges the persistent flags into the flag set as cobra skips it without flag parsing
+		_ = cmd.LocalFlags()
+		flagArgs, pluginArgs := splitPluginArgs(cmd.Flags(), args)
+		if err := cmd.Flags().Parse(flagArgs); err != nil {
+			return err
+		}
+		if help, _ := cmd.Flags().GetBool("help"); help || len(pluginArgs) == 0 {
+			return cmd.Help()
+		}
+		return runPlugin(ctx, cmd, name, handler, pluginArgs)
+	}
+	rootCmd.AddCommand(newPluginCommand(name))
+}
+
+// splitPluginArgs splits the leading flags of the root command from the plugin name and arguments
+func splitPluginArgs(flags *pflag.FlagSet, args []string) (flagArgs, pluginArgs []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return args[:i], args[i:]
+		}
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+		// a flag with a separated value, e.g. --kubeconfig config
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(strings.TrimPrefix(arg, "--"))
+		} else if len(arg) == 2 {
+			flag = flags.ShorthandLookup(arg[1:])
+		}
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return args, nil
+}
+
+// runPlugin runs the longest matching plugin, e.g. for mycli foo bar tries
+// mycli-foo-bar then mycli-foo
+func runPlugin(ctx context.Context, cmd *cobra.Command, name string, handler PluginHandler, args []string) error {
+	parts := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		parts = append(parts, strings.ReplaceAll(arg, "-", "_"))
+	}
+	for i := len(parts); i > 0; i-- {
+		path, found := handler.Lookup(pluginPrefix(name) + strings.Join(parts[:i], "-"))
+		if !found {
+			continue
+		}
+		return handler.Execute(ctx, path, args[i:], pluginEnv(cmd, name))
+	}
+	return fmt.Errorf("unknown command %q for %q", args[0], name)
+}
+
+// pluginEnv returns the environment of plugins: the current environment, the
+// flags of the root command set as <NAME>_<FLAG> and KUBECONFIG when --kubeconfig is set
+func pluginEnv(cmd *cobra.Command, name string) []string {
+	env := os.Environ()
+	prefix := strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if flag.Name == "help" {
+			return
+		}
+		key := prefix + strings.ToUpper(strings.ReplaceAll(flag.Name, "-", "_"))
+		env = append(env, fmt.Sprintf("%s=%s", key, flag.Value.String()))
+		if flag.Name == "kubeconfig" {
+			env = append(env, fmt.Sprintf("KUBECONFIG=%s", flag.Value.String()))
+		}
+	})
+	return env
+}
+
+// newPluginCommand returns the plugin subcommand
+func newPluginCommand(name string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: fmt.Sprintf("Provides utilities for interacting with %s plugins", name),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List all visible plugin executables named %s* on PATH", pluginPrefix(name)),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plugins, warnings := ListPlugins(name, filepath.SplitList(os.Getenv("PATH")), cmd.Root())
+			if len(plugins) == 0 {
+				return fmt.Errorf("unable to find any %s plugins in your PATH", name)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "The following compatible plugins are available:")
+			for _, plugin := range plugins {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\n", plugin)
+			}
+			for _, warning := range warnings {
+				fmt.Fprintf(cmd.ErrOrStderr(), "  - warning: %s\n", warning)
+			}
+			return nil
+		},
+	})
+	return cmd
+}
+
+// ListPlugins returns the paths of the plugin executables of the cli found in dirs,
+// with warnings about plugins shadowed by other plugins or by commands of root
+func ListPlugins(name string, dirs []string, root *cobra.Command) (plugins []string, warnings []string) {
+	prefix := pluginPrefix(name)
+	found := map[string]string{}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				warnings = append(warnings, fmt.Sprintf("%s identified as a plugin, but it is not executable", path))
+				continue
+			}
+			pluginName := strings.TrimSuffix(strings.TrimPrefix(entry.Name(), prefix), filepath.Ext(entry.Name()))
+			if first, ok := found[pluginName]; ok {
+				warnings = append(warnings, fmt.Sprintf("%s is shadowed by a similarly named plugin: %s", path, first))
+				continue
+			}
+			found[pluginName] = path
+			if root != nil {
+				if cmd, _, err := root.Find(strings.Split(pluginName, "-")); err == nil && cmd != root {
+					warnings = append(warnings, fmt.Sprintf("%s overwrites existing command: %q", path, cmd.CommandPath()))
+				}
+			}
+			plugins = append(plugins, path)
+		}
+	}
+	sort.Strings(warnings)
+	return plugins, warnings
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd" || ext == ".ps1"
+	}
+	return info.Mode()&0o111 != 0
+}
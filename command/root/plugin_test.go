@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// fakePluginHandler finds plugins in a fixed list and records executions
+type fakePluginHandler struct {
+	plugins []string
+	path    string
+	args    []string
+	env     []string
+}
+
+func (h *fakePluginHandler) Lookup(filename string) (string, bool) {
+	for _, plugin := range h.plugins {
+		if plugin == filename {
+			return "/bin/" + plugin, true
+		}
+	}
+	return "", false
+}
+
+func (h *fakePluginHandler) Execute(_ context.Context, path string, args, env []string) error {
+	h.path, h.args, h.env = path, args, env
+	return nil
+}
+
+var _ = Describe("Plugins", func() {
+	var (
+		ctx     context.Context
+		out     *bytes.Buffer
+		handler *fakePluginHandler
+		args    []string
+		cmd     *cobra.Command
+		err     error
+	)
+
+	BeforeEach(func() {
+		var streams clioptions.IOStreams
+		streams, _, out, _ = clioptions.NewTestIOStreams()
+		streams.ErrOut = GinkgoWriter
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		ctx = kubeflags.WithKubeFlags(ctx, kubeflags.NewKubeFlags())
+		handler = &fakePluginHandler{plugins: []string{"test-cli-foo", "test-cli-foo-bar_baz"}}
+	})
+
+	JustBeforeEach(func() {
+		cmd = root.NewRootCommandWithOptions(ctx, "test-cli", root.Options{Plugins: true, PluginHandler: handler},
+			func(_ context.Context, _ string) *cobra.Command {
+				return &cobra.Command{Use: "subcommand", Run: func(_ *cobra.Command, _ []string) {
+					out.WriteString("subcommand")
+				}}
+			})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	When("invoking a plugin with root flags", func() {
+		BeforeEach(func() {
+			args = []string{"--kubeconfig", "/tmp/config", "-v", "foo", "arg", "--plugin-flag"}
+		})
+		It("should execute the plugin with the remaining args", func() {
+			Expect(err).To(BeNil())
+			Expect(handler.path).To(Equal("/bin/test-cli-foo"))
+			Expect(handler.args).To(Equal([]string{"arg", "--plugin-flag"}))
+			Expect(handler.env).To(ContainElements("KUBECONFIG=/tmp/config", "TEST_CLI_KUBECONFIG=/tmp/config", "TEST_CLI_VERBOSE=true"))
+		})
+	})
+
+	When("invoking a nested plugin", func() {
+		BeforeEach(func() {
+			args = []string{"foo", "bar-baz", "qux"}
+		})
+		It("should execute the longest matching plugin", func() {
+			Expect(err).To(BeNil())
+			Expect(handler.path).To(Equal("/bin/test-cli-foo-bar_baz"))
+			Expect(handler.args).To(Equal([]string{"qux"}))
+		})
+	})
+
+	When("invoking a subcommand", func() {
+		BeforeEach(func() {
+			args = []string{"-v", "subcommand"}
+		})
+		It("should not execute plugins", func() {
+			Expect(err).To(BeNil())
+			Expect(handler.path).To(BeEmpty())
+			Expect(out.String()).To(Equal("subcommand"))
+		})
+	})
+
+	When("invoking an unknown command", func() {
+		BeforeEach(func() {
+			args = []string{"unknown"}
+		})
+		It("should fail", func() {
+			Expect(err).To(MatchError(`unknown command "unknown" for "test-cli"`))
+		})
+	})
+
+	When("without a command", func() {
+		BeforeEach(func() {
+			args = []string{"--help"}
+		})
+		It("should print help", func() {
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("plugin"))
+			Expect(handler.path).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("ListPlugins", func() {
+	It("should list executables with the cli prefix", func() {
+		first, second := GinkgoT().TempDir(), GinkgoT().TempDir()
+		write := func(dir, name string, mode os.FileMode) string {
+			path := filepath.Join(dir, name)
+			Expect(os.WriteFile(path, []byte("#!/bin/sh\n"), mode)).To(Succeed())
+			return path
+		}
+		foo := write(first, "test-cli-foo", 0o755)
+		write(first, "test-cli-data", 0o644)
+		write(first, "other-cli", 0o755)
+		shadowed := write(second, "test-cli-foo", 0o755)
+		version := write(second, "test-cli-version", 0o755)
+
+		rootCmd := &cobra.Command{Use: "test-cli"}
+		rootCmd.AddCommand(&cobra.Command{Use: "version"})
+		plugins, warnings := root.ListPlugins("test-cli", []string{first, second, "/not/exist"}, rootCmd)
+		Expect(plugins).To(Equal([]string{foo, version}))
+		Expect(warnings).To(HaveLen(3))
+		Expect(strings.Join(warnings, "\n")).To(And(
+			ContainSubstring("test-cli-data identified as a plugin, but it is not executable"),
+			ContainSubstring(shadowed+" is shadowed by a similarly named plugin: "+foo),
+			ContainSubstring(version+` overwrites existing command: "test-cli version"`),
+		))
+	})
+})
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/report"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/warnings"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("Report", func() {
+	var (
+		ctx     context.Context
+		out     *bytes.Buffer
+		args    []string
+		failure error
+		err     error
+	)
+
+	BeforeEach(func() {
+		var streams clioptions.IOStreams
+		streams, _, out, _ = clioptions.NewTestIOStreams()
+		streams.ErrOut = GinkgoWriter
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		ctx = report.WithFlags(ctx, report.NewFlags())
+		args = []string{"subcommand"}
+		failure = nil
+	})
+
+	JustBeforeEach(func() {
+		cmd := root.NewRootCommandWithOptions(ctx, "test-cli", root.Options{Warnings: true}, func(ctx context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "subcommand", RunE: func(cmd *cobra.Command, _ []string) error {
+				report.Resource(cmd.Context(), report.ActionCreated, &corev1.ConfigMap{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "config"},
+				})
+				warnings.CollectorFrom(cmd.Context()).HandleWarningHeader(299, "", "unknown field spec.foo")
+				return failure
+			}}
+		})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	It("should not write a report by default", func() {
+		Expect(err).To(BeNil())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	When("the report is written to stdout", func() {
+		BeforeEach(func() {
+			args = append(args, "--report-file", report.Stdout)
+		})
+		It("should write the results", func() {
+			Expect(err).To(BeNil())
+			result := report.Report{}
+			Expect(json.Unmarshal(out.Bytes(), &result)).To(Succeed())
+			Expect(result.Command).To(Equal("test-cli subcommand"))
+			Expect(result.Success).To(BeTrue())
+			Expect(result.Resources).To(Equal([]report.ChangedResource{{
+				APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "config", Action: report.ActionCreated,
+			}}))
+			Expect(result.Warnings).To(Equal([]string{"unknown field spec.foo"}))
+		})
+	})
+
+	When("the command fails", func() {
+		var file string
+		BeforeEach(func() {
+			file = filepath.Join(GinkgoT().TempDir(), "report.json")
+			args = append(args, "--report-file", file)
+			failure = errors.New("failed")
+		})
+		It("should write the error into the file", func() {
+			Expect(err).To(MatchError("failed"))
+			content, readErr := os.ReadFile(file)
+			Expect(readErr).To(BeNil())
+			result := report.Report{}
+			Expect(json.Unmarshal(content, &result)).To(Succeed())
+			Expect(result.Success).To(BeFalse())
+			Expect(result.Error).To(Equal("failed"))
+		})
+	})
+})
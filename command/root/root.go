@@ -19,21 +19,92 @@ package root
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
 	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/command/printer"
+	"github.com/AlaudaDevops/pkg/command/progress"
+	"github.com/AlaudaDevops/pkg/command/prompt"
+	"github.com/AlaudaDevops/pkg/command/report"
+	"github.com/AlaudaDevops/pkg/command/signals"
+	"github.com/AlaudaDevops/pkg/command/tracing"
+	"github.com/AlaudaDevops/pkg/dryrun"
+	"github.com/AlaudaDevops/pkg/featuregate"
+	"github.com/AlaudaDevops/pkg/warnings"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 // SubcommandFunc inits a subcommand to be inserted inside root
 type SubcommandFunc func(ctx context.Context, name string) *cobra.Command
 
+// Options for the root command
+type Options struct {
+	// Completion adds a completion subcommand generating bash, zsh, fish and powershell
+	// completion scripts, replacing the cobra default one
+	Completion bool
+	// Docs adds a docs subcommand, also available as man, generating markdown,
+	// man, rest or yaml documents of all commands
+	Docs bool
+	// Signals cancels the context given to subcommands on SIGINT or SIGTERM,
+	// see signals.NotifyContext and signals.OnShutdown
+	Signals bool
+	// GracePeriod is the time given to shutdown after the first signal,
+	// defaults to signals.DefaultGracePeriod
+	GracePeriod time.Duration
+	// Plugins runs executables named <name>-<subcommand> found in PATH for unknown subcommands
+	// and adds a plugin list subcommand
+	Plugins bool
+	// PluginHandler finds and executes plugins, defaults to DefaultPluginHandler
+	PluginHandler PluginHandler
+	// LogFields are added to json logs together with the command name, e.g. the cli version
+	LogFields []zap.Field
+	// Warnings collects api server warnings, like deprecated apis, using a warnings.Collector
+	// stored in the context of subcommands and prints them once to ErrOut when the command finishes
+	Warnings bool
+}
+
 // NewRootCommand initiates all commands. This is the main entrypoint of the cli
+// The logger in the context of subcommands honors the -v, --log-level and --log-format persistent flags
+// If kubeflags.KubeFlags are stored in ctx the cluster connection flags are added as persistent flags
+// If printer.OutputFlags are stored in ctx the -o/--output flag is added as a persistent flag
+// and subcommands can use printer.GetPrinter or printer.PrintObjects to render objects
+// If prompt.Flags are stored in ctx the --yes and --non-interactive flags are added as persistent flags
+// If tracing.Flags are stored in ctx the --trace-* flags are added as persistent flags
+// and each command run is traced when an endpoint is given
+// If a featuregate.Gate is stored in ctx the --feature-gates flag is added as a persistent flag
+// and subcommands can use featuregate.Enabled
+// If report.Flags are stored in ctx the --report-file flag is added as a persistent flag
+// and subcommands can record their results with the report package
+// If dryrun.Flags are stored in ctx the --dry-run flag is added as a persistent flag,
+// the strategy is stored in the context of subcommands for client.WrappedClient to honor
+// and the writes that were not persisted are summarized into ErrOut when the command finishes
+// If middlewares are stored in ctx using WithMiddleware they wrap the run function of every command,
+// outside of all the behaviors above
+// Flag and argument errors are wrapped in exit.UsageError, use Execute to exit with the code of errors
 func NewRootCommand(ctx context.Context, name string, subcommands ...SubcommandFunc) *cobra.Command {
-	logOpts := &log{}
+	return NewRootCommandWithOptions(ctx, name, Options{}, subcommands...)
+}
+
+// NewRootCommandWithOptions initiates all commands like NewRootCommand
+// adding the built-in subcommands enabled in opts
+func NewRootCommandWithOptions(ctx context.Context, name string, opts Options, subcommands ...SubcommandFunc) *cobra.Command {
+	logFlags := logger.NewFlags()
 	streams := io.MustGetIOStreams(ctx)
-	ctx = logger.WithLogger(ctx, logger.NewLogger(zapcore.AddSync(streams.ErrOut), logOpts))
+	logFields := append([]zap.Field{zap.String("command", name)}, opts.LogFields...)
+	ctx = logger.WithLogger(ctx, logger.NewLoggerWithFlags(zapcore.AddSync(streams.ErrOut), logFlags, logFields))
+	if opts.Signals {
+		// lives as long as the cli process
+		ctx, _ = signals.NotifyContext(ctx, signals.WithGracePeriod(opts.GracePeriod))
+	}
+	var collector *warnings.Collector
+	if opts.Warnings {
+		collector = warnings.NewCollector()
+		ctx = warnings.WithCollector(ctx, collector)
+	}
 
 	// sets log as persistent options and provides logger using
 	// context variables
@@ -44,12 +115,138 @@ func NewRootCommand(ctx context.Context, name string, subcommands ...SubcommandF
 			_ = cmd.Help()
 		},
 	}
+	rootCmd.SetContext(ctx)
+	// command output goes to the streams in the context
+	rootCmd.SetIn(streams.In)
+	rootCmd.SetOut(streams.Out)
+	rootCmd.SetErr(streams.ErrOut)
+
 	// will persist flag across all subcommands
-	logOpts.addFlags(rootCmd.PersistentFlags())
+	logFlags.AddFlags(rootCmd.PersistentFlags())
+	// cluster connection flags are only added when provided in the context
+	if kubeFlags := kubeflags.GetKubeFlags(ctx); kubeFlags != nil {
+		kubeFlags.AddFlags(rootCmd.PersistentFlags())
+	}
+	if outputFlags := printer.GetOutputFlags(ctx); outputFlags != nil {
+		outputFlags.AddFlags(rootCmd.PersistentFlags())
+	}
+	if promptFlags := prompt.GetFlags(ctx); promptFlags != nil {
+		promptFlags.AddFlags(rootCmd.PersistentFlags())
+	}
+	if gate := featuregate.GetGate(ctx); gate != nil {
+		gate.AddFlag(rootCmd.PersistentFlags())
+	}
+	traceFlags := tracing.GetFlags(ctx)
+	if traceFlags != nil {
+		traceFlags.AddFlags(rootCmd.PersistentFlags())
+	}
+	reportFlags := report.GetFlags(ctx)
+	if reportFlags != nil {
+		reportFlags.AddFlags(rootCmd.PersistentFlags())
+	}
+	dryRunFlags := dryrun.GetFlags(ctx)
+	if dryRunFlags != nil {
+		dryRunFlags.AddFlags(rootCmd.PersistentFlags())
+	}
 
 	for _, sub := range subcommands {
 		rootCmd.AddCommand(sub(ctx, name))
 	}
+	if opts.Completion {
+		rootCmd.CompletionOptions.DisableDefaultCmd = true
+		rootCmd.AddCommand(newCompletionCommand(name))
+	}
+	if opts.Docs {
+		rootCmd.AddCommand(newDocsCommand(name))
+	}
+	if opts.Plugins {
+		enablePlugins(ctx, rootCmd, name, opts.PluginHandler)
+	}
+	usageErrors(rootCmd)
+	// the first middleware is the outermost one, user middlewares wrap the built-in ones
+	middlewares := GetMiddlewares(ctx)
+	if reportFlags != nil {
+		middlewares = append(middlewares, reportRun(reportFlags))
+	}
+	if dryRunFlags != nil {
+		middlewares = append(middlewares, dryRun(dryRunFlags))
+	}
+	if collector != nil {
+		middlewares = append(middlewares, printWarningsAfterRun(collector))
+	}
+	if traceFlags != nil {
+		middlewares = append(middlewares, traceRun(traceFlags, name))
+	}
+	if len(middlewares) > 0 {
+		applyMiddlewares(rootCmd, middlewares)
+	}
 
 	return rootCmd
 }
+
+// traceRun runs commands inside a span named by the command path
+func traceRun(flags *tracing.Flags, name string) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			ctx, end := flags.Start(cmd.Context(), name, cmd.CommandPath())
+			cmd.SetContext(ctx)
+			err := next(cmd, args)
+			end(err)
+			return err
+		}
+	}
+}
+
+// printWarningsAfterRun prints the collected warnings when commands finish, even if they fail
+func printWarningsAfterRun(collector *warnings.Collector) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			defer func() {
+				errOut := cmd.ErrOrStderr()
+				_ = collector.Print(errOut, progress.IsTerminal(errOut))
+			}()
+			return next(cmd, args)
+		}
+	}
+}
+
+// reportRun gives commands a report.Recorder and writes the report when they finish,
+// even if they fail. Warnings returned by the api server are added to the report
+func reportRun(flags *report.Flags) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if !flags.Enabled() {
+				return next(cmd, args)
+			}
+			recorder := report.NewRecorder(cmd.CommandPath(), time.Now())
+			cmd.SetContext(report.WithRecorder(cmd.Context(), recorder))
+			err := next(cmd, args)
+			if collector := warnings.CollectorFrom(cmd.Context()); collector != nil {
+				for _, warning := range collector.Warnings() {
+					recorder.Warning(warning.Text)
+				}
+			}
+			if writeErr := flags.Write(cmd.OutOrStdout(), recorder.Report(err, time.Now())); writeErr != nil && err == nil {
+				err = writeErr
+			}
+			return err
+		}
+	}
+}
+
+// dryRun stores the dry run strategy and a dryrun.Summary in the context of commands
+// and prints the summary when they finish, even if they fail
+func dryRun(flags *dryrun.Flags) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if !flags.Enabled() {
+				return next(cmd, args)
+			}
+			summary := dryrun.NewSummary()
+			ctx := dryrun.WithStrategy(cmd.Context(), flags.Strategy)
+			cmd.SetContext(dryrun.WithSummary(ctx, summary))
+			defer func() { _ = summary.Print(cmd.ErrOrStderr(), flags.Strategy) }()
+			return next(cmd, args)
+		}
+	}
+}
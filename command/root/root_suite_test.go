@@ -23,6 +23,9 @@ import (
 	"context"
 
 	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/printer"
+	"github.com/AlaudaDevops/pkg/command/prompt"
 	"github.com/AlaudaDevops/pkg/command/root"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -62,6 +65,11 @@ var _ = Describe("NewRootCommand", func() {
 	It("cmd is not nil", func() {
 		Expect(cmd).ToNot(BeNil())
 	})
+	It("should have logging flags", func() {
+		for _, name := range []string{"verbose", "log-level", "log-format"} {
+			Expect(cmd.PersistentFlags().Lookup(name)).NotTo(BeNil(), name)
+		}
+	})
 
 	When("with subcommands", func() {
 		BeforeEach(func() {
@@ -76,6 +84,36 @@ var _ = Describe("NewRootCommand", func() {
 		})
 
 	})
+	When("kube flags are in the context", func() {
+		BeforeEach(func() {
+			ctx = kubeflags.WithKubeFlags(ctx, kubeflags.NewKubeFlags())
+		})
+		It("should have cluster connection flags", func() {
+			for _, name := range []string{"kubeconfig", "context", "namespace", "as", "request-timeout"} {
+				Expect(cmd.PersistentFlags().Lookup(name)).NotTo(BeNil(), name)
+			}
+		})
+	})
+	When("output flags are in the context", func() {
+		BeforeEach(func() {
+			ctx = printer.WithOutputFlags(ctx, printer.NewOutputFlags())
+		})
+		It("should have output flag", func() {
+			flag := cmd.PersistentFlags().ShorthandLookup("o")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.Name).To(Equal("output"))
+			Expect(flag.DefValue).To(Equal("table"))
+		})
+	})
+	When("prompt flags are in the context", func() {
+		BeforeEach(func() {
+			ctx = prompt.WithFlags(ctx, &prompt.Flags{})
+		})
+		It("should have yes and non-interactive flags", func() {
+			Expect(cmd.PersistentFlags().ShorthandLookup("y")).NotTo(BeNil())
+			Expect(cmd.PersistentFlags().Lookup("non-interactive")).NotTo(BeNil())
+		})
+	})
 	When("without subcommands", func() {
 		It("should NOT have subcommands", func() {
 			Expect(cmd.Commands()).To(HaveLen(0), "should NOT have subcommands")
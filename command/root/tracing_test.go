@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"context"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	cmdtracing "github.com/AlaudaDevops/pkg/command/tracing"
+	"github.com/AlaudaDevops/pkg/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// keepSpansExporter keeps the exported spans on shutdown
+type keepSpansExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keepSpansExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+var _ = Describe("Tracing", func() {
+	var (
+		ctx      context.Context
+		args     []string
+		exporter *tracetest.InMemoryExporter
+		traced   bool
+		err      error
+	)
+
+	BeforeEach(func() {
+		streams, _, _, _ := clioptions.NewTestIOStreams()
+		exporter = tracetest.NewInMemoryExporter()
+		flags := cmdtracing.NewFlags()
+		flags.TraceOptions = []tracing.TraceOption{tracing.WithExporter(func(*tracing.Config) (sdktrace.SpanExporter, error) {
+			return keepSpansExporter{exporter}, nil
+		})}
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		ctx = cmdtracing.WithFlags(ctx, flags)
+		args = []string{"subcommand"}
+
+		original := otel.GetTracerProvider()
+		DeferCleanup(func() { otel.SetTracerProvider(original) })
+	})
+
+	JustBeforeEach(func() {
+		cmd := root.NewRootCommand(ctx, "test-cli", func(ctx context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "subcommand", RunE: func(cmd *cobra.Command, _ []string) error {
+				traced = trace.SpanContextFromContext(cmd.Context()).IsValid()
+				return nil
+			}}
+		})
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	It("should not trace without an endpoint", func() {
+		Expect(err).To(BeNil())
+		Expect(traced).To(BeFalse())
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+
+	When("an endpoint is given", func() {
+		BeforeEach(func() {
+			args = append(args, "--trace-endpoint", "localhost:4318")
+		})
+		It("should trace the command", func() {
+			Expect(err).To(BeNil())
+			Expect(traced).To(BeTrue())
+			Expect(exporter.GetSpans()).To(HaveLen(1))
+			Expect(exporter.GetSpans()[0].Name).To(Equal("test-cli subcommand"))
+		})
+	})
+})
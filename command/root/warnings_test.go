@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/warnings"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var _ = Describe("Warnings", func() {
+	var (
+		ctx     context.Context
+		errOut  *bytes.Buffer
+		opts    root.Options
+		failure error
+		err     error
+	)
+
+	BeforeEach(func() {
+		var streams clioptions.IOStreams
+		streams, _, _, errOut = clioptions.NewTestIOStreams()
+		ctx = io.WithIOStreams(context.Background(), &streams)
+		opts = root.Options{Warnings: true}
+		failure = nil
+	})
+
+	JustBeforeEach(func() {
+		cmd := root.NewRootCommandWithOptions(ctx, "test-cli", opts, func(ctx context.Context, _ string) *cobra.Command {
+			return &cobra.Command{Use: "subcommand", RunE: func(_ *cobra.Command, _ []string) error {
+				if collector := warnings.CollectorFrom(ctx); collector != nil {
+					collector.HandleWarningHeader(299, "", "apps/v1beta1 Deployment is deprecated")
+					collector.HandleWarningHeader(299, "", "apps/v1beta1 Deployment is deprecated")
+					collector.HandleWarningHeader(299, "", "unknown field spec.foo")
+				}
+				return failure
+			}}
+		})
+		cmd.SetArgs([]string{"subcommand"})
+		err = cmd.Execute()
+	})
+
+	It("should print the warnings once", func() {
+		Expect(err).To(BeNil())
+		Expect(errOut.String()).To(Equal("Warning: apps/v1beta1 Deployment is deprecated\nWarning: unknown field spec.foo\n"))
+	})
+
+	When("the command fails", func() {
+		BeforeEach(func() {
+			failure = errors.New("failed")
+		})
+		It("should print the warnings", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(errOut.String()).To(ContainSubstring("Warning: apps/v1beta1 Deployment is deprecated\n"))
+		})
+	})
+
+	When("warnings are disabled", func() {
+		BeforeEach(func() {
+			opts = root.Options{}
+		})
+		It("should not collect warnings", func() {
+			Expect(err).To(BeNil())
+			Expect(errOut.String()).NotTo(ContainSubstring("Warning:"))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sanitize
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/normalize"
+)
+
+// Options options of the sanitize command
+type Options struct {
+	// Filenames of the manifest files to sanitize, the standard input is read when empty
+	Filenames []string
+	// InPlace writes the sanitized manifests back into their files instead of the output
+	InPlace bool
+}
+
+// AddFlags add flags to options
+func (opts *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&opts.Filenames, "filename", "f", opts.Filenames, "manifest files to sanitize, reads the standard input when not set")
+	cmd.Flags().BoolVarP(&opts.InPlace, "in-place", "i", opts.InPlace, "write the sanitized manifests back into their files")
+}
+
+// NewCommand returns a SubcommandFunc of the sanitize subcommand
+//
+//	cmd := root.NewRootCommand(ctx, "mycli", sanitize.NewCommand(&sanitize.Options{}))
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "sanitize [-f FILENAME]",
+			Short: "Remove cluster specific fields from recorded manifests",
+			Long: fmt.Sprintf(`Remove cluster specific fields from recorded manifests.
+
+uids, resource versions, managed fields, creation timestamps, last applied
+configurations and node names are removed so objects recorded from a cluster,
+e.g. with kubectl get -o yaml, can be checked in as test fixtures. %s reads
+the standard input when no --filename is given.`, name),
+			Args:         cobra.NoArgs,
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return opts.Run(ctx)
+			},
+		}
+		opts.AddFlags(cmd)
+		return cmd
+	}
+}
+
+// Run sanitizes the manifests of Filenames or of the standard input
+func (opts *Options) Run(ctx context.Context) error {
+	streams := pkgio.MustGetIOStreams(ctx)
+	if len(opts.Filenames) == 0 {
+		if opts.InPlace {
+			return fmt.Errorf("--in-place requires --filename")
+		}
+		return normalize.SanitizeManifest(streams.In, streams.Out)
+	}
+	for i, filename := range opts.Filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		sanitized := &bytes.Buffer{}
+		if err = normalize.SanitizeManifest(bytes.NewReader(data), sanitized); err != nil {
+			return fmt.Errorf("sanitize %s failed: %w", filename, err)
+		}
+		if opts.InPlace {
+			info, err := os.Stat(filename)
+			if err != nil {
+				return err
+			}
+			if err = os.WriteFile(filename, sanitized.Bytes(), info.Mode().Perm()); err != nil {
+				return err
+			}
+			continue
+		}
+		if i > 0 {
+			if _, err = fmt.Fprintln(streams.Out, "---"); err != nil {
+				return err
+			}
+		}
+		if _, err = sanitized.WriteTo(streams.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
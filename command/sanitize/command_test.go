@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sanitize
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+)
+
+func TestNewCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+	streams, in, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+
+	recorded, err := os.ReadFile("testdata/recorded.yaml")
+	g.Expect(err).To(BeNil())
+	in.Write(recorded)
+
+	cmd := NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("name: web\n"))
+	g.Expect(out.String()).NotTo(ContainSubstring("uid:"))
+	g.Expect(out.String()).NotTo(ContainSubstring("nodeName"))
+	g.Expect(out.String()).NotTo(ContainSubstring("last-applied-configuration"))
+
+	file := filepath.Join(t.TempDir(), "recorded.yaml")
+	g.Expect(os.WriteFile(file, recorded, 0o600)).To(Succeed())
+	out.Reset()
+	cmd = NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{"-i", "-f", file})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(BeEmpty())
+	sanitized, err := os.ReadFile(file)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(sanitized)).NotTo(ContainSubstring("resourceVersion"))
+
+	cmd = NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{"-i"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(MatchError("--in-place requires --filename"))
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sanitize provides a cli sanitize subcommand removing the cluster specific
+// fields, like uids, resource versions, managed fields or node names, from objects
+// recorded from real clusters so they can be checked in as test fixtures:
+//
+//	kubectl get pods -o yaml | mycli sanitize > testdata/pods.yaml
+//	mycli sanitize -i -f testdata/pods.yaml
+package sanitize
@@ -0,0 +1,26 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signals cancels a context on SIGINT or SIGTERM allowing clis to
+// shutdown gracefully. A second signal, or the grace period elapsing after the
+// first one, exits the process immediately.
+//
+//	ctx, cancel := signals.NotifyContext(ctx, signals.WithGracePeriod(10*time.Second))
+//	defer cancel()
+//	signals.OnShutdown(ctx, func(ctx context.Context) {
+//		_ = server.Shutdown(ctx)
+//	})
+package signals
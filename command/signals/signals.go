@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signals
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// DefaultGracePeriod is the default time given to shutdown after the first signal
+	DefaultGracePeriod = 30 * time.Second
+	// ExitCode is the exit code used when exiting without a graceful shutdown
+	ExitCode = 1
+)
+
+// Option configures the signal handling
+type Option func(*handler)
+
+// WithGracePeriod sets the time given to shutdown after the first signal
+// before exiting the process, zero or negative values keep the default
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(h *handler) {
+		if gracePeriod > 0 {
+			h.gracePeriod = gracePeriod
+		}
+	}
+}
+
+// WithSignals sets the signals handled, defaults to SIGINT and SIGTERM
+func WithSignals(signals ...os.Signal) Option {
+	return func(h *handler) {
+		h.signals = signals
+	}
+}
+
+// WithExitFunc sets the function used to exit the process, defaults to os.Exit
+func WithExitFunc(exit func(code int)) Option {
+	return func(h *handler) {
+		h.exit = exit
+	}
+}
+
+// handler cancels the context on the first signal and runs the shutdown hooks
+type handler struct {
+	gracePeriod time.Duration
+	signals     []os.Signal
+	exit        func(code int)
+
+	lock     sync.Mutex
+	hooks    []func(context.Context)
+	shutdown sync.Once
+	stop     sync.Once
+	stopped  chan struct{}
+}
+
+// NotifyContext returns a copy of parent cancelled on the first SIGINT or SIGTERM.
+// Shutdown hooks registered with OnShutdown are then called in reverse order, and the
+// process exits when a second signal arrives or the grace period elapses.
+// Calling the returned cancel function stops handling signals, cancels the context
+// and runs the shutdown hooks.
+func NotifyContext(parent context.Context, opts ...Option) (context.Context, context.CancelFunc) {
+	h := &handler{
+		gracePeriod: DefaultGracePeriod,
+		signals:     []os.Signal{os.Interrupt, syscall.SIGTERM},
+		exit:        os.Exit,
+		stopped:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(parent, handlerKey{}, h))
+	log := logging.FromContext(parent)
+
+	// buffered to not miss the second signal while shutting down
+	signalCh := make(chan os.Signal, 2)
+	signal.Notify(signalCh, h.signals...)
+
+	go func() {
+		select {
+		case sig := <-signalCh:
+			log.Infow("received signal, shutting down", "signal", sig.String(), "gracePeriod", h.gracePeriod)
+			cancel()
+			go h.runHooks()
+		case <-h.stopped:
+			return
+		}
+
+		select {
+		case sig := <-signalCh:
+			log.Warnw("received second signal, exiting", "signal", sig.String())
+			h.exit(ExitCode)
+		case <-time.After(h.gracePeriod):
+			log.Warnw("grace period exceeded, exiting", "gracePeriod", h.gracePeriod)
+			h.exit(ExitCode)
+		case <-h.stopped:
+		}
+	}()
+
+	return ctx, func() {
+		h.stop.Do(func() {
+			signal.Stop(signalCh)
+			close(h.stopped)
+		})
+		cancel()
+		h.runHooks()
+	}
+}
+
+// runHooks runs the shutdown hooks once, in reverse order of registration,
+// with a context cancelled after the grace period
+func (h *handler) runHooks() {
+	h.shutdown.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), h.gracePeriod)
+		defer cancel()
+
+		h.lock.Lock()
+		hooks := h.hooks
+		h.lock.Unlock()
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i](ctx)
+		}
+	})
+}
+
+// key for reading/writing into context
+type handlerKey struct{}
+
+// OnShutdown registers fn to be called when the context returned by NotifyContext
+// is cancelled, fn receives a context cancelled after the grace period.
+// When ctx was not created by NotifyContext fn is called once ctx is done.
+func OnShutdown(ctx context.Context, fn func(ctx context.Context)) {
+	h, ok := ctx.Value(handlerKey{}).(*handler)
+	if !ok {
+		context.AfterFunc(ctx, func() { fn(context.Background()) })
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.hooks = append(h.hooks, fn)
+}
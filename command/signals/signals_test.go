@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signals
+
+import (
+	"context"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type recorder struct {
+	lock  sync.Mutex
+	calls []string
+	exit  []int
+}
+
+func (r *recorder) record(call string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) Calls() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.calls...)
+}
+
+func (r *recorder) Exit(code int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.exit = append(r.exit, code)
+}
+
+func (r *recorder) ExitCodes() []int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]int{}, r.exit...)
+}
+
+func TestNotifyContext_signal(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rec := &recorder{}
+
+	ctx, cancel := NotifyContext(context.Background(),
+		WithSignals(syscall.SIGUSR1), WithExitFunc(rec.Exit), WithGracePeriod(time.Hour))
+	defer cancel()
+	OnShutdown(ctx, func(hookCtx context.Context) {
+		_, hasDeadline := hookCtx.Deadline()
+		g.Expect(hasDeadline).To(BeTrue())
+		rec.record("first")
+	})
+	OnShutdown(ctx, func(context.Context) { rec.record("second") })
+
+	g.Expect(syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)).To(Succeed())
+	g.Eventually(ctx.Done()).Should(BeClosed())
+	// hooks are called in reverse order
+	g.Eventually(rec.Calls).Should(Equal([]string{"second", "first"}))
+	g.Consistently(rec.ExitCodes, 50*time.Millisecond).Should(BeEmpty())
+
+	// a second signal exits
+	g.Expect(syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)).To(Succeed())
+	g.Eventually(rec.ExitCodes).Should(Equal([]int{ExitCode}))
+	g.Expect(rec.Calls()).To(HaveLen(2))
+}
+
+func TestNotifyContext_gracePeriod(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rec := &recorder{}
+
+	ctx, cancel := NotifyContext(context.Background(),
+		WithSignals(syscall.SIGUSR2), WithExitFunc(rec.Exit), WithGracePeriod(20*time.Millisecond))
+	defer cancel()
+
+	g.Expect(syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)).To(Succeed())
+	g.Eventually(ctx.Done()).Should(BeClosed())
+	g.Eventually(rec.ExitCodes).Should(Equal([]int{ExitCode}))
+}
+
+func TestNotifyContext_cancel(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rec := &recorder{}
+
+	ctx, cancel := NotifyContext(context.Background(),
+		WithSignals(syscall.SIGUSR1), WithExitFunc(rec.Exit), WithGracePeriod(10*time.Millisecond))
+	OnShutdown(ctx, func(context.Context) { rec.record("hook") })
+
+	cancel()
+	cancel()
+	g.Expect(ctx.Err()).To(Equal(context.Canceled))
+	g.Expect(rec.Calls()).To(Equal([]string{"hook"}))
+	g.Consistently(rec.ExitCodes, 50*time.Millisecond).Should(BeEmpty())
+}
+
+func TestOnShutdown_withoutHandler(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rec := &recorder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	OnShutdown(ctx, func(context.Context) { rec.record("hook") })
+	g.Expect(rec.Calls()).To(BeEmpty())
+	cancel()
+	g.Eventually(rec.Calls).Should(Equal([]string{"hook"}))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+)
+
+// NewCommand returns a SubcommandFunc of the telemetry subcommand
+// with status, enable and disable subcommands, e.g.
+//
+//	root.NewRootCommand(ctx, "mycli", reporter.NewCommand())
+func (r *Reporter) NewCommand() func(ctx context.Context, name string) *cobra.Command {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "telemetry",
+			Short: fmt.Sprintf("Manage anonymized usage reporting of %s", name),
+			Args:  cobra.NoArgs,
+		}
+		cmd.AddCommand(
+			&cobra.Command{
+				Use:   "status",
+				Short: "Print whether usage reporting is enabled",
+				Args:  cobra.NoArgs,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					return r.printStatus(ctx)
+				},
+			},
+			&cobra.Command{
+				Use:   "enable",
+				Short: "Opt in to anonymized usage reporting",
+				Args:  cobra.NoArgs,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					if err := r.Enable(); err != nil {
+						return err
+					}
+					return r.printStatus(ctx)
+				},
+			},
+			&cobra.Command{
+				Use:   "disable",
+				Short: "Opt out of usage reporting, dropping events not sent yet",
+				Args:  cobra.NoArgs,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					if err := r.Disable(); err != nil {
+						return err
+					}
+					return r.printStatus(ctx)
+				},
+			},
+		)
+		return cmd
+	}
+}
+
+func (r *Reporter) printStatus(ctx context.Context) error {
+	status, err := r.Status()
+	if err != nil {
+		return err
+	}
+	out := cliio.MustGetIOStreams(ctx).Out
+	switch {
+	case status.Active():
+		fmt.Fprintf(out, "Telemetry is enabled, sending to %s\n", status.Endpoint)
+		fmt.Fprintf(out, "Installation ID: %s\n", status.InstallationID)
+		fmt.Fprintf(out, "Queued events: %d\n", status.Queued)
+	case status.Enabled:
+		fmt.Fprintf(out, "Telemetry is enabled but disabled by the %s environment variable\n", status.DisabledBy)
+	default:
+		fmt.Fprintln(out, "Telemetry is disabled")
+	}
+	fmt.Fprintln(out, "Only the command path, duration, exit class and version are recorded.")
+	return nil
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package telemetry records anonymized command usage of clis when users opt in.
+// Only the command path, duration, exit class and version of the cli are recorded
+// together with a random installation id, arguments and flag values are never sent.
+//
+// Events are queued in the user config directory and sent in batches to the
+// configured endpoint, when the endpoint is unreachable they are kept for the
+// next invocation:
+//
+//	reporter := telemetry.New("mycli", "https://telemetry.example.com/v1/events")
+//	ctx = root.WithMiddleware(ctx, reporter.Middleware())
+//	root.NewRootCommand(ctx, "mycli", reporter.NewCommand())
+//
+// Telemetry is disabled until enabled with `mycli telemetry enable`, and is
+// always disabled when DO_NOT_TRACK is set or <NAME>_TELEMETRY is false, e.g.
+// MYCLI_TELEMETRY=0.
+package telemetry
@@ -0,0 +1,425 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/clock"
+
+	"github.com/AlaudaDevops/pkg/command/exit"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/command/root"
+	"github.com/AlaudaDevops/pkg/command/version"
+)
+
+const (
+	// DefaultBatchSize number of queued events sending a batch to the endpoint
+	DefaultBatchSize = 20
+	// DefaultMaxQueued number of events kept while the endpoint is unreachable,
+	// older events are dropped first
+	DefaultMaxQueued = 500
+	// DefaultTimeout of a batch request, kept short as it delays the command exit
+	DefaultTimeout = 2 * time.Second
+
+	// DoNotTrackEnv disables telemetry when set to any value other than 0 or false
+	DoNotTrackEnv = "DO_NOT_TRACK"
+
+	stateFile = "telemetry.json"
+	queueFile = "telemetry-queue.jsonl"
+)
+
+// Event is the anonymized usage of a command
+type Event struct {
+	// InstallationID random id generated when telemetry is enabled
+	InstallationID string `json:"installationID"`
+	// Command path of the command, e.g. mycli get pods
+	Command string `json:"command"`
+	// DurationMillis duration of the command in milliseconds
+	DurationMillis int64 `json:"durationMillis"`
+	// ExitClass class of the exit code, e.g. OK, Usage or NotFound
+	ExitClass string `json:"exitClass"`
+	// Version of the cli
+	Version string `json:"version"`
+	// Time the command finished at
+	Time time.Time `json:"time"`
+}
+
+// State persisted in the telemetry directory
+type State struct {
+	// Enabled is true when the user opted in
+	Enabled bool `json:"enabled"`
+	// InstallationID random id sent with the events
+	InstallationID string `json:"installationID,omitempty"`
+}
+
+// Status of telemetry as shown by the status subcommand
+type Status struct {
+	State
+	// Endpoint events are sent to
+	Endpoint string `json:"endpoint"`
+	// DisabledBy the environment variable disabling telemetry, if any
+	DisabledBy string `json:"disabledBy,omitempty"`
+	// Queued number of events not sent yet
+	Queued int `json:"queued"`
+}
+
+// Active returns true when events are recorded
+func (s Status) Active() bool {
+	return s.Enabled && s.DisabledBy == ""
+}
+
+// Option configures a Reporter
+type Option func(*Reporter)
+
+// WithDir sets the directory of the state and queue files,
+// defaults to <user config dir>/<name>
+func WithDir(dir string) Option {
+	return func(r *Reporter) {
+		r.dir = dir
+	}
+}
+
+// WithHTTPClient sets the client used to send batches
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reporter) {
+		r.httpClient = client
+	}
+}
+
+// WithBatchSize sets the number of queued events sending a batch
+func WithBatchSize(size int) Option {
+	return func(r *Reporter) {
+		r.batchSize = size
+	}
+}
+
+// WithMaxQueued sets the number of events kept while the endpoint is unreachable
+func WithMaxQueued(max int) Option {
+	return func(r *Reporter) {
+		r.maxQueued = max
+	}
+}
+
+// WithTimeout sets the timeout of a batch request
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Reporter) {
+		r.timeout = timeout
+	}
+}
+
+// WithClock sets the clock used to time events
+func WithClock(clock clock.PassiveClock) Option {
+	return func(r *Reporter) {
+		r.clock = clock
+	}
+}
+
+// Reporter records events of opted in users and sends them in batches
+type Reporter struct {
+	name       string
+	endpoint   string
+	dir        string
+	httpClient *http.Client
+	batchSize  int
+	maxQueued  int
+	timeout    time.Duration
+	clock      clock.PassiveClock
+
+	// lock serializes queue changes inside the process,
+	// concurrent clis may still race on the queue and lose events
+	lock sync.Mutex
+}
+
+// New returns a Reporter of the cli name sending events to endpoint
+func New(name, endpoint string, opts ...Option) *Reporter {
+	r := &Reporter{
+		name:       name,
+		endpoint:   endpoint,
+		httpClient: http.DefaultClient,
+		batchSize:  DefaultBatchSize,
+		maxQueued:  DefaultMaxQueued,
+		timeout:    DefaultTimeout,
+		clock:      clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.dir == "" {
+		if configDir, err := os.UserConfigDir(); err == nil {
+			r.dir = filepath.Join(configDir, name)
+		}
+	}
+	return r
+}
+
+// Env returns the environment variable enabling or disabling telemetry of the cli,
+// e.g. MYCLI_TELEMETRY
+func (r *Reporter) Env() string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(r.name)) + "_TELEMETRY"
+}
+
+// Status returns the telemetry status
+func (r *Reporter) Status() (status Status, err error) {
+	status.Endpoint = r.endpoint
+	if status.State, err = r.loadState(); err != nil {
+		return
+	}
+	status.DisabledBy = r.disabledBy()
+	events, err := r.loadQueue()
+	status.Queued = len(events)
+	return
+}
+
+// Enabled returns true when the user opted in and telemetry is not disabled by the environment
+func (r *Reporter) Enabled() bool {
+	status, err := r.Status()
+	return err == nil && status.Active()
+}
+
+// Enable opts in, generating the installation id on first use
+func (r *Reporter) Enable() error {
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	if state.InstallationID == "" {
+		if state.InstallationID, err = newInstallationID(); err != nil {
+			return err
+		}
+	}
+	state.Enabled = true
+	return r.saveState(state)
+}
+
+// Disable opts out, dropping the queued events
+func (r *Reporter) Disable() error {
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	state.Enabled = false
+	if err = r.saveState(state); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err = os.Remove(filepath.Join(r.dir, queueFile)); errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	return err
+}
+
+// Record queues the event of an invocation, sending a batch when enough events are queued.
+// Nothing is recorded when telemetry is not active.
+func (r *Reporter) Record(ctx context.Context, invocation root.Invocation) error {
+	status, err := r.Status()
+	if err != nil || !status.Active() {
+		return err
+	}
+	event := Event{
+		InstallationID: status.InstallationID,
+		Command:        invocation.Command,
+		DurationMillis: invocation.Duration.Milliseconds(),
+		ExitClass:      exit.Reason(invocation.ExitCode),
+		Version:        version.Get().Version,
+		Time:           r.clock.Now().UTC(),
+	}
+
+	r.lock.Lock()
+	events, err := r.loadQueue()
+	if err == nil {
+		events = append(events, event)
+		err = r.saveQueue(events)
+	}
+	r.lock.Unlock()
+	if err != nil || len(events) < r.batchSize {
+		return err
+	}
+	return r.Flush(ctx)
+}
+
+// Flush sends the queued events to the endpoint, the events are kept when sending fails
+func (r *Reporter) Flush(ctx context.Context) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	events, err := r.loadQueue()
+	if err != nil || len(events) == 0 {
+		return err
+	}
+	if err = r.send(ctx, events); err != nil {
+		return err
+	}
+	return r.saveQueue(nil)
+}
+
+func (r *Reporter) send(ctx context.Context, events []Event) error {
+	if r.endpoint == "" {
+		return fmt.Errorf("telemetry endpoint is not configured")
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint %s responded with status %d", r.endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// Middleware returns a root.Middleware recording every subcommand invocation when active.
+// Failures are only logged as they must not change the result of the command.
+func (r *Reporter) Middleware() root.Middleware {
+	return root.Telemetry(
+		func(cmd *cobra.Command) bool {
+			return r.Enabled()
+		},
+		func(ctx context.Context, invocation root.Invocation) {
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if err := r.Record(ctx, invocation); err != nil {
+				logger.NewLoggerFromContext(ctx).Debugw("cannot record telemetry", "err", err)
+			}
+		},
+	)
+}
+
+// disabledBy returns the environment variable disabling telemetry, if any
+func (r *Reporter) disabledBy() string {
+	for _, env := range []string{DoNotTrackEnv, r.Env()} {
+		value, ok := os.LookupEnv(env)
+		if !ok || value == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if env == DoNotTrackEnv && (err != nil || enabled) {
+			return env
+		}
+		if env != DoNotTrackEnv && err == nil && !enabled {
+			return env
+		}
+	}
+	return ""
+}
+
+func (r *Reporter) loadState() (state State, err error) {
+	if r.dir == "" {
+		return state, fmt.Errorf("telemetry directory is not configured")
+	}
+	content, err := os.ReadFile(filepath.Join(r.dir, stateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(content, &state)
+	return
+}
+
+func (r *Reporter) saveState(state State) error {
+	if r.dir == "" {
+		return fmt.Errorf("telemetry directory is not configured")
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(r.dir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, stateFile), content, 0o600)
+}
+
+// loadQueue returns the queued events, skipping malformed lines
+func (r *Reporter) loadQueue() (events []Event, err error) {
+	if r.dir == "" {
+		return nil, nil
+	}
+	file, err := os.Open(filepath.Join(r.dir, queueFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		event := Event{}
+		if json.Unmarshal(scanner.Bytes(), &event) == nil {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// saveQueue replaces the queue with the latest maxQueued events
+func (r *Reporter) saveQueue(events []Event) error {
+	if r.maxQueued > 0 && len(events) > r.maxQueued {
+		events = events[len(events)-r.maxQueued:]
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, queueFile), buf.Bytes(), 0o600)
+}
+
+func newInstallationID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
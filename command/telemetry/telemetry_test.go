@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/AlaudaDevops/pkg/command/exit"
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+type endpoint struct {
+	*httptest.Server
+	lock    sync.Mutex
+	status  int
+	batches [][]Event
+}
+
+func newEndpoint(t *testing.T) *endpoint {
+	e := &endpoint{status: http.StatusAccepted}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.lock.Lock()
+		defer e.lock.Unlock()
+		if e.status == http.StatusAccepted {
+			var events []Event
+			_ = json.NewDecoder(r.Body).Decode(&events)
+			e.batches = append(e.batches, events)
+		}
+		w.WriteHeader(e.status)
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func setEnv(t *testing.T) {
+	t.Setenv(DoNotTrackEnv, "")
+	t.Setenv("TEST_CLI_TELEMETRY", "")
+}
+
+func TestReporter_Record(t *testing.T) {
+	g := NewGomegaWithT(t)
+	setEnv(t)
+	ctx := context.Background()
+	server := newEndpoint(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	reporter := New("test-cli", server.URL, WithDir(t.TempDir()), WithBatchSize(2),
+		WithClock(clocktesting.NewFakePassiveClock(now)))
+	invocation := root.Invocation{Command: "test-cli get", Duration: 1500 * time.Millisecond, ExitCode: exit.NotFound}
+
+	// nothing is recorded until enabled
+	g.Expect(reporter.Record(ctx, invocation)).To(Succeed())
+	status, err := reporter.Status()
+	g.Expect(err).To(BeNil())
+	g.Expect(status.Active()).To(BeFalse())
+	g.Expect(status.Queued).To(Equal(0))
+
+	g.Expect(reporter.Enable()).To(Succeed())
+	status, _ = reporter.Status()
+	g.Expect(status.Active()).To(BeTrue())
+	g.Expect(status.InstallationID).To(HaveLen(32))
+
+	// events are queued until a batch is full
+	g.Expect(reporter.Record(ctx, invocation)).To(Succeed())
+	g.Expect(server.batches).To(BeEmpty())
+	g.Expect(reporter.Record(ctx, root.Invocation{Command: "test-cli apply"})).To(Succeed())
+	g.Expect(server.batches).To(HaveLen(1))
+	g.Expect(server.batches[0]).To(HaveLen(2))
+	g.Expect(server.batches[0][0]).To(Equal(Event{
+		InstallationID: status.InstallationID,
+		Command:        "test-cli get",
+		DurationMillis: 1500,
+		ExitClass:      "NotFound",
+		Version:        server.batches[0][0].Version,
+		Time:           now,
+	}))
+	g.Expect(server.batches[0][0].Version).NotTo(BeEmpty())
+	g.Expect(server.batches[0][1].ExitClass).To(Equal("OK"))
+
+	status, _ = reporter.Status()
+	g.Expect(status.Queued).To(Equal(0))
+}
+
+func TestReporter_Record_offline(t *testing.T) {
+	g := NewGomegaWithT(t)
+	setEnv(t)
+	ctx := context.Background()
+	server := newEndpoint(t)
+	server.status = http.StatusServiceUnavailable
+	reporter := New("test-cli", server.URL, WithDir(t.TempDir()), WithBatchSize(2), WithMaxQueued(3))
+	g.Expect(reporter.Enable()).To(Succeed())
+
+	for _, command := range []string{"a", "b", "c", "d"} {
+		err := reporter.Record(ctx, root.Invocation{Command: command})
+		if command == "a" {
+			g.Expect(err).To(BeNil())
+		} else {
+			g.Expect(err).To(MatchError(ContainSubstring("responded with status 503")))
+		}
+	}
+	// the oldest events are dropped
+	status, _ := reporter.Status()
+	g.Expect(status.Queued).To(Equal(3))
+
+	server.status = http.StatusAccepted
+	g.Expect(reporter.Flush(ctx)).To(Succeed())
+	g.Expect(server.batches).To(HaveLen(1))
+	commands := []string{}
+	for _, event := range server.batches[0] {
+		commands = append(commands, event.Command)
+	}
+	g.Expect(commands).To(Equal([]string{"b", "c", "d"}))
+	status, _ = reporter.Status()
+	g.Expect(status.Queued).To(Equal(0))
+}
+
+func TestReporter_Status_env(t *testing.T) {
+	g := NewGomegaWithT(t)
+	setEnv(t)
+	reporter := New("test-cli", "", WithDir(t.TempDir()))
+	g.Expect(reporter.Env()).To(Equal("TEST_CLI_TELEMETRY"))
+	g.Expect(reporter.Enable()).To(Succeed())
+	g.Expect(reporter.Enabled()).To(BeTrue())
+
+	t.Setenv("TEST_CLI_TELEMETRY", "false")
+	status, _ := reporter.Status()
+	g.Expect(status.Enabled).To(BeTrue())
+	g.Expect(status.DisabledBy).To(Equal("TEST_CLI_TELEMETRY"))
+	g.Expect(reporter.Enabled()).To(BeFalse())
+
+	t.Setenv("TEST_CLI_TELEMETRY", "true")
+	g.Expect(reporter.Enabled()).To(BeTrue())
+	t.Setenv(DoNotTrackEnv, "1")
+	g.Expect(reporter.Enabled()).To(BeFalse())
+	t.Setenv(DoNotTrackEnv, "0")
+	g.Expect(reporter.Enabled()).To(BeTrue())
+}
+
+func TestReporter_Middleware(t *testing.T) {
+	g := NewGomegaWithT(t)
+	setEnv(t)
+	server := newEndpoint(t)
+	reporter := New("test-cli", server.URL, WithDir(t.TempDir()), WithBatchSize(1))
+	g.Expect(reporter.Enable()).To(Succeed())
+
+	streams, _, _, _ := clioptions.NewTestIOStreams()
+	ctx := cliio.WithIOStreams(context.Background(), &streams)
+	ctx = root.WithMiddleware(ctx, reporter.Middleware())
+	cmd := root.NewRootCommand(ctx, "test-cli", func(ctx context.Context, name string) *cobra.Command {
+		return &cobra.Command{Use: "fail", RunE: func(cmd *cobra.Command, args []string) error {
+			return exit.Conflictf("already exists")
+		}}
+	})
+	cmd.SetArgs([]string{"fail"})
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+	g.Expect(cmd.Execute()).To(MatchError("already exists"))
+	g.Expect(server.batches).To(HaveLen(1))
+	g.Expect(server.batches[0][0].Command).To(Equal("test-cli fail"))
+	g.Expect(server.batches[0][0].ExitClass).To(Equal("Conflict"))
+}
+
+func TestReporter_NewCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+	setEnv(t)
+	dir := t.TempDir()
+	reporter := New("test-cli", "https://telemetry.example.com", WithDir(dir))
+
+	run := func(args ...string) string {
+		streams, _, out, _ := clioptions.NewTestIOStreams()
+		cmd := reporter.NewCommand()(cliio.WithIOStreams(context.Background(), &streams), "test-cli")
+		cmd.SetArgs(args)
+		g.Expect(cmd.Execute()).To(Succeed())
+		return out.String()
+	}
+
+	g.Expect(run("status")).To(HavePrefix("Telemetry is disabled\n"))
+	g.Expect(run("enable")).To(HavePrefix("Telemetry is enabled, sending to https://telemetry.example.com\n"))
+	g.Expect(reporter.Enabled()).To(BeTrue())
+	t.Setenv(DoNotTrackEnv, "true")
+	g.Expect(run("status")).To(HavePrefix("Telemetry is enabled but disabled by the DO_NOT_TRACK environment variable\n"))
+	g.Expect(run("disable")).To(HavePrefix("Telemetry is disabled\n"))
+
+	status, err := reporter.Status()
+	g.Expect(err).To(BeNil())
+	g.Expect(status.Enabled).To(BeFalse())
+	g.Expect(status.InstallationID).NotTo(BeEmpty())
+}
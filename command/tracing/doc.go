@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing adds the --trace-* persistent flags to a cli exporting a span
+// for each command run to an OTLP collector, with the trace id added to the logger.
+//
+//	ctx = tracing.WithFlags(ctx, tracing.NewFlags())
+//	cmd := root.NewRootCommand(ctx, "my-cli", subcommands...)
+//
+//	// my-cli apply --trace-endpoint otel-collector:4318 --trace-insecure
+package tracing
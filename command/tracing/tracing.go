@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"github.com/AlaudaDevops/pkg/tracing"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ShutdownTimeout is the maximum time spent flushing spans when a command finishes
+const ShutdownTimeout = 5 * time.Second
+
+// tracerName instrumentation name of the command spans
+const tracerName = "github.com/AlaudaDevops/pkg/command/tracing"
+
+// Flags the --trace-* flags configuring the export of command spans
+type Flags struct {
+	// Endpoint host and port of the OTLP http collector, tracing is disabled when empty
+	Endpoint string
+	// Insecure sends spans over http instead of https
+	Insecure bool
+	// SamplingRatio fraction of the commands traced
+	SamplingRatio float64
+	// Headers sent with each export, e.g. for authentication
+	Headers map[string]string
+
+	// TraceOptions customize the tracing setup, e.g. the exporter in tests
+	TraceOptions []tracing.TraceOption
+}
+
+// NewFlags returns Flags tracing all commands once an endpoint is given
+func NewFlags() *Flags {
+	return &Flags{SamplingRatio: 1}
+}
+
+// AddFlags add flags to the flag set
+func (f *Flags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.Endpoint, "trace-endpoint", f.Endpoint, "OTLP http collector host:port to export traces to, tracing is disabled when empty")
+	flags.BoolVar(&f.Insecure, "trace-insecure", f.Insecure, "export traces over http instead of https")
+	flags.Float64Var(&f.SamplingRatio, "trace-sampling-ratio", f.SamplingRatio, "fraction of the commands traced, between 0 and 1")
+	flags.StringToStringVar(&f.Headers, "trace-header", f.Headers, "headers sent when exporting traces, e.g. authorization=token")
+}
+
+// Enabled returns true if an endpoint is set
+func (f *Flags) Enabled() bool {
+	return f != nil && f.Endpoint != ""
+}
+
+// Config returns the tracing configuration of the flags
+func (f *Flags) Config() *tracing.Config {
+	return &tracing.Config{
+		Enable:        f.Enabled(),
+		SamplingRatio: f.SamplingRatio,
+		Backend:       tracing.ExporterBackendOTLP,
+		OTLP: tracing.OTLPConfig{
+			Endpoint: f.Endpoint,
+			Insecure: f.Insecure,
+			Headers:  f.Headers,
+		},
+	}
+}
+
+// Start sets up the export of spans and starts a span named spanName when tracing is enabled.
+// The returned context carries the span and a logger with its trace id, the returned function
+// ends the span recording err and flushes the spans. Without an endpoint ctx is returned as is
+func (f *Flags) Start(ctx context.Context, serviceName, spanName string) (context.Context, func(err error)) {
+	if !f.Enabled() {
+		return ctx, func(error) {}
+	}
+
+	opts := append([]tracing.TraceOption{tracing.WithServiceName(serviceName)}, f.TraceOptions...)
+	t := tracing.NewTracing(logger.GetLogger(ctx), opts...)
+	t.ApplyConfig(f.Config())
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, spanName)
+	ctx = logger.WithLogger(ctx, tracing.LoggerWithSpan(ctx, logger.GetLogger(ctx)))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ShutdownTimeout)
+		defer cancel()
+		if err := t.Shutdown(shutdownCtx); err != nil {
+			logger.GetLogger(ctx).Debugw("flush traces failed", "err", err)
+		}
+	}
+}
+
+// WithFlags adds Flags into the context
+func WithFlags(ctx context.Context, flags *Flags) context.Context {
+	return ctxutil.With(ctx, flags)
+}
+
+// GetFlags returns Flags stored in the context if any
+// if not found will return nil *Flags
+func GetFlags(ctx context.Context) *Flags {
+	flags, _ := ctxutil.From[*Flags](ctx)
+	return flags
+}
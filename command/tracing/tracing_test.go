@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/tracing"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
+)
+
+// keepSpansExporter keeps the exported spans on shutdown
+type keepSpansExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keepSpansExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	flags := NewFlags()
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(flagSet)
+	g.Expect(flags.Enabled()).To(BeFalse())
+
+	err := flagSet.Parse([]string{"--trace-endpoint", "otel-collector:4318", "--trace-insecure", "--trace-sampling-ratio", "0.5", "--trace-header", "authorization=token"})
+	g.Expect(err).To(Succeed())
+	g.Expect(flags.Enabled()).To(BeTrue())
+	g.Expect(flags.Config()).To(Equal(&tracing.Config{
+		Enable:        true,
+		SamplingRatio: 0.5,
+		Backend:       tracing.ExporterBackendOTLP,
+		OTLP: tracing.OTLPConfig{
+			Endpoint: "otel-collector:4318",
+			Insecure: true,
+			Headers:  map[string]string{"authorization": "token"},
+		},
+	}))
+
+	ctx := WithFlags(context.Background(), flags)
+	g.Expect(GetFlags(ctx)).To(BeIdenticalTo(flags))
+	g.Expect(GetFlags(context.Background())).To(BeNil())
+	g.Expect((*Flags)(nil).Enabled()).To(BeFalse())
+}
+
+func TestStart(t *testing.T) {
+	original := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(original)
+
+	t.Run("disabled", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
+		started, end := NewFlags().Start(ctx, "cli", "cli apply")
+		g.Expect(started).To(BeIdenticalTo(ctx))
+		end(nil)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		exporter := tracetest.NewInMemoryExporter()
+		flags := NewFlags()
+		flags.Endpoint = "otel-collector:4318"
+		flags.TraceOptions = []tracing.TraceOption{tracing.WithExporter(func(*tracing.Config) (sdktrace.SpanExporter, error) {
+			return keepSpansExporter{exporter}, nil
+		})}
+
+		logs := &bytes.Buffer{}
+		ctx := logger.WithLogger(context.Background(), logger.NewLogger(zapcore.AddSync(logs), zapcore.InfoLevel))
+		ctx, end := flags.Start(ctx, "cli", "cli apply")
+		logger.GetLogger(ctx).Info("applying")
+		end(errors.New("apply failed"))
+
+		spans := exporter.GetSpans()
+		g.Expect(spans).To(HaveLen(1), "spans are flushed when the command ends")
+		g.Expect(spans[0].Name).To(Equal("cli apply"))
+		g.Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		g.Expect(logs.String()).To(ContainSubstring(spans[0].SpanContext.TraceID().String()))
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+// Options options of the validate command
+type Options struct {
+	// Filenames of manifest files or directories to validate
+	Filenames []string
+	// SchemaFiles are files or directories with the custom resource definitions of custom resources
+	SchemaFiles []string
+	// ClusterSchemas fetches the custom resource definitions from the cluster
+	ClusterSchemas bool
+	// IgnoreUnknownKinds does not report objects without schema
+	IgnoreUnknownKinds bool
+	// NewClient returns the client used to fetch the custom resource definitions,
+	// defaults to kubeflags.DefaultClientFunc
+	NewClient kubeflags.ClientFunc
+}
+
+// AddFlags add flags to options
+func (opts *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&opts.Filenames, "filename", "f", opts.Filenames, "files or directories with the manifests to validate")
+	cmd.Flags().StringSliceVar(&opts.SchemaFiles, "schema", opts.SchemaFiles, "files or directories with the custom resource definitions of custom resources")
+	cmd.Flags().BoolVar(&opts.ClusterSchemas, "cluster-schemas", opts.ClusterSchemas, "fetch the custom resource definitions from the cluster")
+	cmd.Flags().BoolVar(&opts.IgnoreUnknownKinds, "ignore-unknown-kinds", opts.IgnoreUnknownKinds, "do not report objects of kinds without schema")
+}
+
+// NewCommand returns a SubcommandFunc of the validate subcommand printing
+// one line per validation error and failing when any is found
+//
+//	cmd := root.NewRootCommand(ctx, "mycli", validate.NewCommand(&validate.Options{}))
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "validate -f FILENAME",
+			Short: "Validate local manifests against the schemas of their kinds",
+			Long: fmt.Sprintf(`Validate local manifests against the schemas of their kinds.
+
+Built-in kinds are validated against their types and custom resources against
+the OpenAPI schemas of their custom resource definitions, loaded from files with
+--schema or fetched from the cluster with --cluster-schemas. %s prints the
+file, line and column of every error.`, name),
+			Args:         cobra.NoArgs,
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if len(opts.Filenames) == 0 {
+					return fmt.Errorf("at least one --filename is required")
+				}
+				validator, err := opts.Validator(cmd.Context())
+				if err != nil {
+					return err
+				}
+				errs, err := validator.Validate(opts.Filenames...)
+				if err != nil {
+					return err
+				}
+				out := pkgio.MustGetIOStreams(ctx).Out
+				for _, validationErr := range errs {
+					if _, err = fmt.Fprintln(out, validationErr.Error()); err != nil {
+						return err
+					}
+				}
+				if len(errs) > 0 {
+					return fmt.Errorf("%d validation errors found", len(errs))
+				}
+				return nil
+			},
+		}
+		opts.AddFlags(cmd)
+		return cmd
+	}
+}
+
+// Validator returns a Validator using the schemas of SchemaFiles and,
+// when ClusterSchemas is set, of the cluster, files taking precedence
+func (opts *Options) Validator(ctx context.Context) (*Validator, error) {
+	schemas, _ := NewSchemas()
+	if opts.ClusterSchemas {
+		clientFunc := opts.NewClient
+		if clientFunc == nil {
+			clientFunc = kubeflags.DefaultClientFunc
+		}
+		clt, err := clientFunc(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if schemas, err = FetchSchemas(ctx, clt); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.SchemaFiles) > 0 {
+		files, err := LoadSchemas(opts.SchemaFiles...)
+		if err != nil {
+			return nil, err
+		}
+		schemas.merge(files)
+	}
+	return &Validator{Schemas: schemas, IgnoreUnknownKinds: opts.IgnoreUnknownKinds}, nil
+}
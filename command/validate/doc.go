@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validate validates local manifests against the OpenAPI schemas of
+// custom resource definitions and the types of built-in kinds, reporting the
+// line and column of every error, so broken manifests are caught before they
+// reach the api server. It provides a cli validate subcommand and the library
+// used by it:
+//
+//	schemas, err := validate.LoadSchemas("config/crd/")
+//	errs, err := validate.Manifests("deploy/", schemas)
+//	for _, e := range errs {
+//		fmt.Println(e) // deploy/app.yaml:12:7: Deployment/app: spec.replicas: ...
+//	}
+package validate
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+)
+
+// Schemas are the validation schemas of custom resources by group version kind
+type Schemas struct {
+	schemas map[schema.GroupVersionKind]*crdSchema
+}
+
+// crdSchema is the schema of a version of a custom resource definition
+type crdSchema struct {
+	validator  validation.SchemaValidator
+	structural *structuralschema.Structural
+}
+
+// NewSchemas returns the Schemas of the served versions of crds
+func NewSchemas(crds ...*apiextensionsv1.CustomResourceDefinition) (*Schemas, error) {
+	s := &Schemas{schemas: map[schema.GroupVersionKind]*crdSchema{}}
+	for _, crd := range crds {
+		if err := s.Add(crd); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add adds the schemas of the served versions of crd, replacing existing ones.
+// Versions without schema are skipped.
+func (s *Schemas) Add(crd *apiextensionsv1.CustomResourceDefinition) error {
+	for _, version := range crd.Spec.Versions {
+		if !version.Served || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		props := &apiextensions.JSONSchemaProps{}
+		if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, props, nil); err != nil {
+			return fmt.Errorf("convert schema of %s %s failed: %w", crd.Name, version.Name, err)
+		}
+		validator, _, err := validation.NewSchemaValidator(props)
+		if err != nil {
+			return fmt.Errorf("build validator of %s %s failed: %w", crd.Name, version.Name, err)
+		}
+		structural, err := structuralschema.NewStructural(props)
+		if err != nil {
+			return fmt.Errorf("build structural schema of %s %s failed: %w", crd.Name, version.Name, err)
+		}
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+		s.schemas[gvk] = &crdSchema{validator: validator, structural: structural}
+	}
+	return nil
+}
+
+// Has returns true when there is a schema for gvk
+func (s *Schemas) Has(gvk schema.GroupVersionKind) bool {
+	return s != nil && s.schemas[gvk] != nil
+}
+
+// merge adds the schemas of other, replacing existing ones
+func (s *Schemas) merge(other *Schemas) {
+	for gvk, crd := range other.schemas {
+		s.schemas[gvk] = crd
+	}
+}
+
+func (s *Schemas) get(gvk schema.GroupVersionKind) *crdSchema {
+	if s == nil {
+		return nil
+	}
+	return s.schemas[gvk]
+}
+
+// LoadSchemas returns the Schemas of the custom resource definitions found in
+// files or directories, other objects are ignored. See applyset.LoadManifests
+func LoadSchemas(paths ...string) (*Schemas, error) {
+	objs, err := applyset.LoadManifests(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return schemasOf(objs)
+}
+
+// FetchSchemas returns the Schemas of the custom resource definitions in the cluster
+func FetchSchemas(ctx context.Context, clt client.Client) (*Schemas, error) {
+	// unstructured so the scheme of clt does not need to include apiextensions
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinitionList"))
+	if err := clt.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("list CustomResourceDefinitions failed: %w", err)
+	}
+	objs := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		item.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+		objs = append(objs, item)
+	}
+	return schemasOf(objs)
+}
+
+// schemasOf returns the Schemas of the custom resource definitions in objs
+func schemasOf(objs []*unstructured.Unstructured) (*Schemas, error) {
+	s, _ := NewSchemas()
+	crdKind := apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition")
+	for _, obj := range objs {
+		if obj.GroupVersionKind() != crdKind {
+			continue
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+			return nil, fmt.Errorf("decode CustomResourceDefinition %s failed: %w", obj.GetName(), err)
+		}
+		if err := s.Add(crd); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kjson "sigs.k8s.io/json"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// manifestExtensions file extensions considered manifests when walking directories
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// typeErrorField extracts the field path of json type errors,
+// e.g. cannot unmarshal string into Go struct field DeploymentSpec.spec.replicas of type int32
+var typeErrorField = regexp.MustCompile(`Go struct field [^ .]+\.(\S+) of type`)
+
+// Error is a validation error of a manifest
+type Error struct {
+	// File of the manifest
+	File string
+	// Line and Column of the invalid field, or of the object when the field is not found
+	Line   int
+	Column int
+	// Object is Kind/name of the object, empty when the document could not be decoded
+	Object string
+	// Field path of the invalid field, e.g. spec.containers[0].image
+	Field string
+	// Message describing the error
+	Message string
+}
+
+// Error implements error, e.g. deploy/app.yaml:12:7: Deployment/app: spec.replicas: Invalid value
+func (e Error) Error() string {
+	var builder strings.Builder
+	builder.WriteString(e.File)
+	if e.Line > 0 {
+		fmt.Fprintf(&builder, ":%d:%d", e.Line, e.Column)
+	}
+	for _, part := range []string{e.Object, e.Field, e.Message} {
+		if part != "" {
+			builder.WriteString(": " + part)
+		}
+	}
+	return builder.String()
+}
+
+// Validator validates manifests
+type Validator struct {
+	// Schemas of custom resources, they take precedence over Scheme
+	Schemas *Schemas
+	// Scheme of built-in kinds, defaults to the client-go scheme
+	Scheme *runtime.Scheme
+	// IgnoreUnknownKinds does not report objects without schema,
+	// otherwise they are reported as their apiVersion or kind may be misspelled
+	IgnoreUnknownKinds bool
+}
+
+// Manifests validates the manifests in dir, a directory or a file, against schemas
+// and the built-in kinds of the client-go scheme
+func Manifests(dir string, schemas *Schemas) ([]Error, error) {
+	return (&Validator{Schemas: schemas}).Validate(dir)
+}
+
+// Validate validates the yaml or json manifests in files or directories, supporting
+// multiple documents per file. Directories are walked recursively validating files with
+// yaml, yml or json extensions in lexical order. Validation errors are returned sorted
+// by file and position, the error is only returned when files cannot be read.
+func (v *Validator) Validate(paths ...string) (errs []Error, err error) {
+	for _, path := range paths {
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			// explicitly given files are validated whatever their extension
+			if d.IsDir() || (file != path && !manifestExtensions[strings.ToLower(filepath.Ext(file))]) {
+				return nil
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			fileErrs, err := v.ValidateReader(file, f)
+			errs = append(errs, fileErrs...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].File != errs[j].File {
+			return errs[i].File < errs[j].File
+		}
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return errs, nil
+}
+
+// ValidateReader validates the yaml or json documents of r, name is used as the file of the errors
+func (v *Validator) ValidateReader(name string, r io.Reader) (errs []Error, err error) {
+	decoder := yaml.NewDecoder(r)
+	for {
+		doc := &yaml.Node{}
+		err = decoder.Decode(doc)
+		if errors.Is(err, io.EOF) {
+			return errs, nil
+		}
+		if err != nil {
+			// the decoder cannot go on after a syntax error
+			return append(errs, Error{File: name, Message: err.Error()}), nil
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		errs = append(errs, v.validateNode(name, doc.Content[0])...)
+	}
+}
+
+// validateNode validates the object of node, expanding List kinds into their items
+func (v *Validator) validateNode(file string, node *yaml.Node) (errs []Error) {
+	newError := func(object, field, message string) Error {
+		position := lookup(node, field)
+		return Error{File: file, Line: position.Line, Column: position.Column, Object: object, Field: field, Message: message}
+	}
+	obj, err := decodeNode(node)
+	if err != nil {
+		return []Error{newError("", "", err.Error())}
+	}
+	if obj.IsList() {
+		items, _, _ := unstructured.NestedSlice(obj.Object, "items")
+		itemsNode := child(node, "items")
+		for i := range items {
+			if itemNode := child(itemsNode, strconv.Itoa(i)); itemNode != nil && itemNode.Kind == yaml.MappingNode {
+				errs = append(errs, v.validateNode(file, itemNode)...)
+			}
+		}
+		return errs
+	}
+
+	gvk := obj.GroupVersionKind()
+	object := gvk.Kind + "/" + obj.GetName()
+	switch {
+	case obj.GetAPIVersion() == "":
+		return []Error{newError(object, "apiVersion", "Required value")}
+	case gvk.Kind == "":
+		return []Error{newError(object, "kind", "Required value")}
+	case obj.GetName() == "" && obj.GetGenerateName() == "":
+		errs = append(errs, newError(object, "metadata.name", "Required value: name or generateName is required"))
+	}
+
+	if crd := v.Schemas.get(gvk); crd != nil {
+		for _, fieldErr := range validation.ValidateCustomResource(nil, obj.UnstructuredContent(), crd.validator) {
+			errs = append(errs, newError(object, fieldErr.Field, fieldErr.ErrorBody()))
+		}
+		unknown := pruning.PruneWithOptions(obj.DeepCopy().UnstructuredContent(), crd.structural, true,
+			structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+		for _, field := range unknown {
+			errs = append(errs, newError(object, field, "unknown field"))
+		}
+		return errs
+	}
+
+	scheme := v.Scheme
+	if scheme == nil {
+		scheme = clientgoscheme.Scheme
+	}
+	if !scheme.Recognizes(gvk) {
+		if !v.IgnoreUnknownKinds {
+			errs = append(errs, newError(object, "", fmt.Sprintf("no schema found for %s", gvk)))
+		}
+		return errs
+	}
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		return append(errs, newError(object, "", err.Error()))
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return append(errs, newError(object, "", err.Error()))
+	}
+	strictErrs, err := kjson.UnmarshalStrict(data, typed)
+	if err != nil {
+		field := ""
+		if match := typeErrorField.FindStringSubmatch(err.Error()); match != nil {
+			field = match[1]
+		}
+		errs = append(errs, newError(object, field, strings.TrimPrefix(err.Error(), "json: ")))
+	}
+	for _, strictErr := range strictErrs {
+		field, message := "", strictErr.Error()
+		var fieldErr kjson.FieldError
+		if errors.As(strictErr, &fieldErr) {
+			field = fieldErr.FieldPath()
+			message = strings.TrimSpace(strings.Replace(message, strconv.Quote(field), "", 1))
+		}
+		errs = append(errs, newError(object, field, message))
+	}
+	return errs
+}
+
+// decodeNode converts node into an object, using json so numbers are decoded as int64
+func decodeNode(node *yaml.Node) (*unstructured.Unstructured, error) {
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	data, err := sigsyaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// lookup returns the node of field in node, or of its deepest parent found.
+// Map entries return their key node so errors point at the field name
+func lookup(node *yaml.Node, field string) *yaml.Node {
+	position, current := node, node
+	for _, segment := range splitPath(field) {
+		key, value := entry(current, segment)
+		if value == nil {
+			break
+		}
+		position, current = key, value
+	}
+	return position
+}
+
+// child returns the value node of segment in node, nil if not found
+func child(node *yaml.Node, segment string) *yaml.Node {
+	_, value := entry(node, segment)
+	return value
+}
+
+// entry returns the key and value nodes of the map entry segment of node,
+// or the item at the index segment of a sequence node as both key and value
+func entry(node *yaml.Node, segment string) (key, value *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index]
+		}
+	}
+	return nil, nil
+}
+
+// splitPath splits a field path like spec.containers[0].image into its segments
+func splitPath(field string) (segments []string) {
+	for _, part := range strings.Split(field, ".") {
+		for part != "" {
+			open := strings.IndexByte(part, '[')
+			if open < 0 {
+				segments = append(segments, part)
+				break
+			}
+			if open > 0 {
+				segments = append(segments, part[:open])
+			}
+			end := strings.IndexByte(part[open:], ']')
+			if end < 0 {
+				segments = append(segments, part[open:])
+				break
+			}
+			segments = append(segments, part[open+1:open+end])
+			part = part[open+end+1:]
+		}
+	}
+	return
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var widget = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+func TestManifests(t *testing.T) {
+	g := NewGomegaWithT(t)
+	schemas, err := LoadSchemas("testdata/crd.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(schemas.Has(widget)).To(BeTrue())
+
+	errs, err := Manifests("testdata/manifests/valid.yaml", schemas)
+	g.Expect(err).To(BeNil())
+	g.Expect(errs).To(BeEmpty())
+
+	errs, err = Manifests("testdata/manifests", schemas)
+	g.Expect(err).To(BeNil())
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	g.Expect(messages).To(Equal([]string{
+		"testdata/manifests/invalid.yaml:11:11: Deployment/app: spec.template.spec.containers[0].imagee: unknown field",
+		"testdata/manifests/invalid.yaml:18:3: Widget/widget: spec.size: Invalid value: 0: spec.size in body should be greater than or equal to 1",
+		"testdata/manifests/invalid.yaml:19:3: Widget/widget: spec.colour: unknown field",
+		"testdata/manifests/invalid.yaml:25:1: ConfigMap/config: data: cannot unmarshal number into Go struct field ConfigMap.data of type string",
+	}))
+}
+
+func TestValidator_ValidateReader(t *testing.T) {
+	g := NewGomegaWithT(t)
+	validator := &Validator{}
+
+	errs, err := validator.ValidateReader("stdin", strings.NewReader(`
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  generateName: widget-
+---
+kind: ConfigMap
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: ConfigMap
+    metadata: {}
+`))
+	g.Expect(err).To(BeNil())
+	g.Expect(errs).To(HaveLen(3))
+	g.Expect(errs[0].Error()).To(Equal("stdin:2:1: Widget/: no schema found for example.com/v1, Kind=Widget"))
+	g.Expect(errs[1].Error()).To(Equal("stdin:7:1: ConfigMap/: apiVersion: Required value"))
+	g.Expect(errs[2].Error()).To(Equal("stdin:14:5: ConfigMap/: metadata.name: Required value: name or generateName is required"))
+
+	validator.IgnoreUnknownKinds = true
+	errs, err = validator.ValidateReader("stdin", strings.NewReader("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n"))
+	g.Expect(err).To(BeNil())
+	g.Expect(errs).To(BeEmpty())
+
+	errs, err = validator.ValidateReader("stdin", strings.NewReader("kind: [\n"))
+	g.Expect(err).To(BeNil())
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Message).To(ContainSubstring("yaml:"))
+}
+
+func TestNewSchemas(t *testing.T) {
+	g := NewGomegaWithT(t)
+	schemas, err := NewSchemas(&apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+				}},
+				{Name: "v2", Served: false, Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+				}},
+			},
+		},
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(schemas.Has(widget)).To(BeTrue())
+	g.Expect(schemas.Has(widget.GroupVersion().WithKind("Other"))).To(BeFalse())
+	g.Expect(schemas.Has(schema.GroupVersionKind{Group: "example.com", Version: "v2", Kind: "Widget"})).To(BeFalse())
+}
+
+func TestValidateCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	streams, _, out, _ := clioptions.NewTestIOStreams()
+	ctx := io.WithIOStreams(context.Background(), &streams)
+
+	cmd := NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{"-f", "testdata/manifests", "--schema", "testdata/crd.yaml"})
+	err := cmd.ExecuteContext(ctx)
+	g.Expect(err).To(MatchError("4 validation errors found"))
+	g.Expect(out.String()).To(ContainSubstring("testdata/manifests/invalid.yaml:18:3: Widget/widget: spec.size"))
+
+	out.Reset()
+	cmd = NewCommand(&Options{})(ctx, "cli")
+	cmd.SetArgs([]string{"-f", "testdata/manifests/valid.yaml", "--schema", "testdata/crd.yaml"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(BeEmpty())
+}
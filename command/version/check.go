@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// DefaultCheckTimeout is the default timeout of the latest version request
+const DefaultCheckTimeout = 5 * time.Second
+
+// release is the response of the latest version endpoint,
+// supporting both {"version": "v1.0.0"} and GitHub releases {"tag_name": "v1.0.0"}
+type release struct {
+	Version string `json:"version"`
+	TagName string `json:"tag_name"`
+}
+
+// LatestVersion requests the latest version from url
+func LatestVersion(ctx context.Context, client *http.Client, url string) (string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get latest version from %s: unexpected status %s", url, resp.Status)
+	}
+
+	latest := release{}
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return "", fmt.Errorf("decode latest version from %s: %w", url, err)
+	}
+	if latest.Version == "" {
+		latest.Version = latest.TagName
+	}
+	if latest.Version == "" {
+		return "", fmt.Errorf("latest version not found in the response of %s", url)
+	}
+	return latest.Version, nil
+}
+
+// IsNewer returns true if latest is a newer semantic version than current
+func IsNewer(current, latest string) (bool, error) {
+	currentVersion, err := utilversion.ParseSemantic(current)
+	if err != nil {
+		return false, fmt.Errorf("invalid current version %q: %w", current, err)
+	}
+	latestVersion, err := utilversion.ParseSemantic(latest)
+	if err != nil {
+		return false, fmt.Errorf("invalid latest version %q: %w", latest, err)
+	}
+	return currentVersion.LessThan(latestVersion), nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/command/printer"
+)
+
+// Option configures the version subcommand
+type Option func(*options)
+
+type options struct {
+	checkURL     string
+	checkTimeout time.Duration
+	httpClient   *http.Client
+}
+
+// WithUpdateCheck adds a --check flag requesting the latest version from url,
+// which should respond with {"version": "v1.0.0"} or a GitHub release
+func WithUpdateCheck(url string) Option {
+	return func(o *options) {
+		o.checkURL = url
+	}
+}
+
+// WithCheckTimeout sets the timeout of the latest version request
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.checkTimeout = timeout
+	}
+}
+
+// WithHTTPClient sets the client used to request the latest version
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// NewCommand returns a SubcommandFunc of the version subcommand, e.g.
+//
+//	root.NewRootCommand(ctx, "mycli", version.NewCommand())
+func NewCommand(opts ...Option) func(ctx context.Context, name string) *cobra.Command {
+	o := &options{checkTimeout: DefaultCheckTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(ctx context.Context, name string) *cobra.Command {
+		return o.command(ctx, name)
+	}
+}
+
+func (o *options) command(ctx context.Context, name string) *cobra.Command {
+	var (
+		output string
+		check  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: fmt.Sprintf("Print the version of %s", name),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// reuses the persistent --output flag when available
+			if outputFlags := printer.GetOutputFlags(ctx); outputFlags != nil {
+				output = outputFlags.Output
+			}
+			if err := Print(ctx, output, Get()); err != nil {
+				return err
+			}
+			if check {
+				o.checkUpdate(ctx, name)
+			}
+			return nil
+		},
+	}
+	if printer.GetOutputFlags(ctx) == nil {
+		cmd.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: (json, yaml)")
+	}
+	if o.checkURL != "" {
+		cmd.Flags().BoolVar(&check, "check", false, "check if a newer version is available")
+	}
+	return cmd
+}
+
+// Print prints the build information into the output stream in the context
+// as text, json or yaml
+func Print(ctx context.Context, output string, info Info) error {
+	out := cliio.MustGetIOStreams(ctx).Out
+	switch output {
+	case "", string(printer.OutputFormatTable), string(printer.OutputFormatWide), "text":
+		_, err := fmt.Fprint(out, info.String())
+		return err
+	case string(printer.OutputFormatJSON):
+		content, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(content))
+		return err
+	case string(printer.OutputFormatYAML):
+		content, err := yaml.Marshal(info)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(content)
+		return err
+	}
+	return fmt.Errorf("unsupported output format %q for version", output)
+}
+
+// checkUpdate prints a notice into the error stream when a newer version is available,
+// failures are only logged as the version was already printed
+func (o *options) checkUpdate(ctx context.Context, name string) {
+	log := logger.NewLoggerFromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, o.checkTimeout)
+	defer cancel()
+
+	current := Get().Version
+	latest, err := LatestVersion(ctx, o.httpClient, o.checkURL)
+	if err != nil {
+		log.Warnw("cannot check the latest version", "err", err)
+		return
+	}
+	newer, err := IsNewer(current, latest)
+	if err != nil {
+		log.Warnw("cannot compare versions", "err", err)
+		return
+	}
+	errOut := cliio.MustGetIOStreams(ctx).ErrOut
+	if newer {
+		fmt.Fprintf(errOut, "A newer version of %s is available: %s (current %s)\n", name, latest, current)
+		return
+	}
+	fmt.Fprintf(errOut, "%s %s is the latest version\n", name, current)
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package version has the version subcommand of clis and the build information
+// injection API. Build information can be set using ldflags:
+//
+//	go build -ldflags "-X github.com/AlaudaDevops/pkg/command/version.Version=v1.0.0 \
+//		-X github.com/AlaudaDevops/pkg/command/version.Commit=$(git rev-parse HEAD) \
+//		-X github.com/AlaudaDevops/pkg/command/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// or calling Set in main. When not set, the module version and vcs information
+// embedded by the go toolchain are used.
+package version
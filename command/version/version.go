@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Build information, can be set using -ldflags "-X ..." or Set
+var (
+	// Version of the cli, e.g. v1.0.0
+	Version = ""
+	// Commit the cli was built from
+	Commit = ""
+	// Date the cli was built at, e.g. 2025-01-01T00:00:00Z
+	Date = ""
+)
+
+// Set sets the build information
+func Set(version, commit, date string) {
+	Version, Commit, Date = version, commit, date
+}
+
+// Info build information of the cli
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the build information, using the information embedded
+// by the go toolchain for values not set
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && buildInfo.Main.Version != "(devel)" {
+			info.Version = buildInfo.Main.Version
+		}
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.Date == "":
+				info.Date = setting.Value
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// String returns the build information as text, one value per line
+func (i Info) String() string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "Version: %s\n", i.Version)
+	if i.Commit != "" {
+		fmt.Fprintf(builder, "Commit: %s\n", i.Commit)
+	}
+	if i.Date != "" {
+		fmt.Fprintf(builder, "Build Date: %s\n", i.Date)
+	}
+	fmt.Fprintf(builder, "Go Version: %s\n", i.GoVersion)
+	fmt.Fprintf(builder, "Platform: %s\n", i.Platform)
+	return builder.String()
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cliio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/printer"
+	. "github.com/onsi/gomega"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func setVersion(t *testing.T, version, commit, date string) {
+	previous := []string{Version, Commit, Date}
+	Set(version, commit, date)
+	t.Cleanup(func() { Set(previous[0], previous[1], previous[2]) })
+}
+
+func TestGet(t *testing.T) {
+	g := NewGomegaWithT(t)
+	setVersion(t, "v1.2.3", "abcdef", "2025-01-01T00:00:00Z")
+
+	info := Get()
+	g.Expect(info.Version).To(Equal("v1.2.3"))
+	g.Expect(info.Commit).To(Equal("abcdef"))
+	g.Expect(info.Date).To(Equal("2025-01-01T00:00:00Z"))
+	g.Expect(info.GoVersion).To(HavePrefix("go"))
+	g.Expect(info.String()).To(HavePrefix("Version: v1.2.3\nCommit: abcdef\nBuild Date: 2025-01-01T00:00:00Z\nGo Version: "))
+
+	setVersion(t, "", "", "")
+	g.Expect(Get().Version).NotTo(BeEmpty())
+}
+
+func TestIsNewer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var data = []struct {
+		current  string
+		latest   string
+		expected bool
+		err      bool
+	}{
+		{current: "v1.0.0", latest: "v1.1.0", expected: true},
+		{current: "v1.1.0", latest: "v1.1.0", expected: false},
+		{current: "v1.2.0-rc.1", latest: "v1.2.0", expected: true},
+		{current: "v2.0.0", latest: "1.9.9", expected: false},
+		{current: "dev", latest: "v1.0.0", err: true},
+	}
+	for _, item := range data {
+		newer, err := IsNewer(item.current, item.latest)
+		if item.err {
+			g.Expect(err).To(HaveOccurred())
+			continue
+		}
+		g.Expect(err).To(BeNil())
+		g.Expect(newer).To(Equal(item.expected), "%s < %s", item.current, item.latest)
+	}
+}
+
+func newReleaseServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest":
+			_, _ = w.Write([]byte(`{"version": "v1.3.0"}`))
+		case "/github":
+			_, _ = w.Write([]byte(`{"tag_name": "v1.2.3", "name": "release"}`))
+		case "/empty":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestLatestVersion(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := newReleaseServer()
+	defer server.Close()
+
+	latest, err := LatestVersion(context.Background(), nil, server.URL+"/latest")
+	g.Expect(err).To(BeNil())
+	g.Expect(latest).To(Equal("v1.3.0"))
+
+	latest, err = LatestVersion(context.Background(), server.Client(), server.URL+"/github")
+	g.Expect(err).To(BeNil())
+	g.Expect(latest).To(Equal("v1.2.3"))
+
+	_, err = LatestVersion(context.Background(), nil, server.URL+"/empty")
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = LatestVersion(context.Background(), nil, server.URL+"/missing")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status 404")))
+}
+
+func TestNewCommand(t *testing.T) {
+	setVersion(t, "v1.2.3", "abcdef", "")
+	server := newReleaseServer()
+	defer server.Close()
+
+	var data = []struct {
+		desc        string
+		outputFlags *printer.OutputFlags
+		args        []string
+		expected    string
+		expectedErr string
+	}{
+		{
+			desc:     "text",
+			expected: "Version: v1.2.3\nCommit: abcdef\n",
+		},
+		{
+			desc:     "json",
+			args:     []string{"-o", "json"},
+			expected: "{\n  \"version\": \"v1.2.3\",\n  \"commit\": \"abcdef\",\n",
+		},
+		{
+			desc:        "yaml from the persistent output flag",
+			outputFlags: &printer.OutputFlags{Output: "yaml"},
+			expected:    "commit: abcdef\n",
+		},
+		{
+			desc:        "check newer version",
+			args:        []string{"--check"},
+			expected:    "Version: v1.2.3\n",
+			expectedErr: "A newer version of test-cli is available: v1.3.0 (current v1.2.3)\n",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			streams, _, out, errOut := clioptions.NewTestIOStreams()
+			ctx := cliio.WithIOStreams(context.Background(), &streams)
+			if item.outputFlags != nil {
+				ctx = printer.WithOutputFlags(ctx, item.outputFlags)
+			}
+
+			cmd := NewCommand(WithUpdateCheck(server.URL+"/latest"))(ctx, "test-cli")
+			cmd.SetArgs(item.args)
+			g.Expect(cmd.Execute()).To(Succeed())
+			g.Expect(out.String()).To(ContainSubstring(item.expected))
+			g.Expect(errOut.String()).To(Equal(item.expectedErr))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	streams, _, _, _ := clioptions.NewTestIOStreams()
+	cmd := NewCommand()(cliio.WithIOStreams(context.Background(), &streams), "test-cli")
+	g.Expect(cmd.Flags().Lookup("check")).To(BeNil())
+	cmd.SetArgs([]string{"-o", "xml"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	g.Expect(cmd.Execute()).To(MatchError(ContainSubstring("unsupported output format")))
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/AlaudaDevops/pkg/status"
+)
+
+// Condition is what an object is waited for
+type Condition interface {
+	// Check returns true when obj meets the condition and a message describing its current state,
+	// obj is nil when the object does not exist. An error stops waiting
+	Check(obj *unstructured.Unstructured) (met bool, message string, err error)
+	// String describes the condition
+	String() string
+}
+
+// ParseCondition parses a --for expression:
+//   - empty or ready: the object is ready as computed by the status package
+//   - delete: the object does not exist
+//   - condition=Ready or condition=Ready=False: the object has the condition with the status, True by default
+//   - jsonpath={.status.phase}=Running or jsonpath={.status.phase}: the expression returns the value, or any value
+func ParseCondition(expr string) (Condition, error) {
+	name, value, _ := strings.Cut(expr, "=")
+	switch strings.ToLower(name) {
+	case "", "ready":
+		return readyCondition{}, nil
+	case "delete":
+		return deleteCondition{}, nil
+	case "condition":
+		conditionType, conditionStatus, found := strings.Cut(value, "=")
+		if conditionType == "" {
+			return nil, fmt.Errorf("condition type is required, e.g. --for=condition=Ready")
+		}
+		if !found {
+			conditionStatus = "True"
+		}
+		return conditionCondition{conditionType: conditionType, status: conditionStatus}, nil
+	case "jsonpath":
+		return parseJSONPathCondition(value)
+	}
+	return nil, fmt.Errorf("unrecognized condition %q, one of: ready, delete, condition=<type>[=<status>], jsonpath={<path>}[=<value>]", expr)
+}
+
+// readyCondition waits for the status of the object to be Current
+type readyCondition struct{}
+
+func (readyCondition) Check(obj *unstructured.Unstructured) (bool, string, error) {
+	if obj == nil {
+		return false, "object not found", nil
+	}
+	result, err := status.ComputeResult(obj)
+	if err != nil {
+		return false, "", err
+	}
+	if result.Status == status.FailedStatus {
+		return false, "", fmt.Errorf("object failed: %s", result.Message)
+	}
+	return result.Status == status.CurrentStatus, fmt.Sprintf("%s: %s", result.Status, result.Message), nil
+}
+
+func (readyCondition) String() string {
+	return "ready"
+}
+
+// deleteCondition waits for the object to be deleted
+type deleteCondition struct{}
+
+func (deleteCondition) Check(obj *unstructured.Unstructured) (bool, string, error) {
+	if obj == nil {
+		return true, "deleted", nil
+	}
+	if obj.GetDeletionTimestamp() != nil {
+		return false, "deleting", nil
+	}
+	return false, "exists", nil
+}
+
+func (deleteCondition) String() string {
+	return "delete"
+}
+
+// conditionCondition waits for a status condition of the object
+type conditionCondition struct {
+	conditionType string
+	status        string
+}
+
+func (c conditionCondition) Check(obj *unstructured.Unstructured) (bool, string, error) {
+	if obj == nil {
+		return false, "object not found", nil
+	}
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, "", err
+	}
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok || !strings.EqualFold(fmt.Sprint(condition["type"]), c.conditionType) {
+			continue
+		}
+		// like status conditions, outdated conditions are not considered
+		if observed, ok := condition["observedGeneration"].(int64); ok && observed < obj.GetGeneration() {
+			return false, fmt.Sprintf("condition %s is outdated", c.conditionType), nil
+		}
+		current := fmt.Sprint(condition["status"])
+		message := fmt.Sprintf("condition %s is %s", c.conditionType, current)
+		if reason, ok := condition["reason"].(string); ok && reason != "" {
+			message += ": " + reason
+		}
+		return strings.EqualFold(current, c.status), message, nil
+	}
+	return false, fmt.Sprintf("condition %s not found", c.conditionType), nil
+}
+
+func (c conditionCondition) String() string {
+	return fmt.Sprintf("condition=%s=%s", c.conditionType, c.status)
+}
+
+// jsonPathCondition waits for a jsonpath expression to return a value
+type jsonPathCondition struct {
+	expression string
+	parser     *jsonpath.JSONPath
+	// value expected, any value when hasValue is false
+	value    string
+	hasValue bool
+}
+
+func parseJSONPathCondition(value string) (Condition, error) {
+	if !strings.HasPrefix(value, "{") {
+		return nil, fmt.Errorf("jsonpath expression must be enclosed in braces, e.g. --for=jsonpath='{.status.phase}'=Running")
+	}
+	end := strings.LastIndex(value, "}")
+	if end < 0 {
+		return nil, fmt.Errorf("jsonpath expression %q is not closed", value)
+	}
+	condition := jsonPathCondition{expression: value[:end+1]}
+	if rest := value[end+1:]; rest != "" {
+		if !strings.HasPrefix(rest, "=") {
+			return nil, fmt.Errorf("invalid jsonpath condition %q, expected {<path>}=<value>", value)
+		}
+		condition.value, condition.hasValue = strings.Trim(rest[1:], `"'`), true
+	}
+	condition.parser = jsonpath.New("wait")
+	if err := condition.parser.Parse(condition.expression); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath expression %q: %w", condition.expression, err)
+	}
+	return condition, nil
+}
+
+func (c jsonPathCondition) Check(obj *unstructured.Unstructured) (bool, string, error) {
+	if obj == nil {
+		return false, "object not found", nil
+	}
+	results, err := c.parser.FindResults(obj.Object)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		// the field may not be set yet
+		return false, fmt.Sprintf("%s not found", c.expression), nil
+	}
+	if len(results) > 1 || len(results[0]) > 1 {
+		return false, "", fmt.Errorf("jsonpath expression %s returns multiple values", c.expression)
+	}
+	buf := &bytes.Buffer{}
+	if err = c.parser.PrintResults(buf, results[0]); err != nil {
+		return false, "", err
+	}
+	current := buf.String()
+	message := fmt.Sprintf("%s is %q", c.expression, current)
+	if !c.hasValue {
+		return true, message, nil
+	}
+	return current == c.value, message, nil
+}
+
+func (c jsonPathCondition) String() string {
+	if c.hasValue {
+		return fmt.Sprintf("jsonpath=%s=%s", c.expression, c.value)
+	}
+	return "jsonpath=" + c.expression
+}
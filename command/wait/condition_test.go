@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func newObject(content string) *unstructured.Unstructured {
+	data, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		panic(err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(data); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+const runningPod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+  generation: 2
+status:
+  phase: Running
+  conditions:
+  - type: Ready
+    status: "False"
+    reason: ContainersNotReady
+  - type: Initialized
+    status: "True"
+  - type: Outdated
+    status: "True"
+    observedGeneration: 1
+`
+
+func TestParseCondition(t *testing.T) {
+	var data = []struct {
+		desc   string
+		expr   string
+		str    string
+		parsed bool
+	}{
+		{"default", "", "ready", true},
+		{"ready", "Ready", "ready", true},
+		{"delete", "delete", "delete", true},
+		{"condition", "condition=Ready", "condition=Ready=True", true},
+		{"condition with status", "condition=Ready=false", "condition=Ready=false", true},
+		{"condition without type", "condition=", "", false},
+		{"jsonpath", "jsonpath={.status.phase}", "jsonpath={.status.phase}", true},
+		{"jsonpath with value", "jsonpath={.status.phase}=Running", "jsonpath={.status.phase}=Running", true},
+		{"jsonpath with quoted value", `jsonpath={.status.phase}="Running"`, "jsonpath={.status.phase}=Running", true},
+		{"jsonpath without braces", "jsonpath=.status.phase", "", false},
+		{"jsonpath with invalid suffix", "jsonpath={.status.phase}Running", "", false},
+		{"invalid jsonpath", "jsonpath={.status[}", "", false},
+		{"unknown", "exists", "", false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			condition, err := ParseCondition(item.expr)
+			if !item.parsed {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(condition.String()).To(Equal(item.str))
+		})
+	}
+}
+
+func TestConditionCheck(t *testing.T) {
+	var data = []struct {
+		desc    string
+		expr    string
+		obj     *unstructured.Unstructured
+		met     bool
+		message string
+		err     bool
+	}{
+		{"ready of missing object", "", nil, false, "object not found", false},
+		{"ready of not ready pod", "", newObject(runningPod), false, "InProgress", false},
+		{"delete of missing object", "delete", nil, true, "deleted", false},
+		{"delete of existing object", "delete", newObject(runningPod), false, "exists", false},
+		{"condition true", "condition=initialized", newObject(runningPod), true, "condition initialized is True", false},
+		{"condition false", "condition=Ready", newObject(runningPod), false, "condition Ready is False: ContainersNotReady", false},
+		{"expected condition false", "condition=Ready=False", newObject(runningPod), true, "condition Ready is False", false},
+		{"outdated condition", "condition=Outdated", newObject(runningPod), false, "condition Outdated is outdated", false},
+		{"missing condition", "condition=Available", newObject(runningPod), false, "condition Available not found", false},
+		{"jsonpath value", "jsonpath={.status.phase}=Running", newObject(runningPod), true, `{.status.phase} is "Running"`, false},
+		{"jsonpath other value", "jsonpath={.status.phase}=Succeeded", newObject(runningPod), false, `{.status.phase} is "Running"`, false},
+		{"jsonpath any value", "jsonpath={.status.phase}", newObject(runningPod), true, `{.status.phase} is "Running"`, false},
+		{"jsonpath missing field", "jsonpath={.status.podIP}", newObject(runningPod), false, "{.status.podIP} not found", false},
+		{"jsonpath multiple values", "jsonpath={.status.conditions[*].type}", newObject(runningPod), false, "", true},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			condition, err := ParseCondition(item.expr)
+			g.Expect(err).To(BeNil())
+
+			met, message, err := condition.Check(item.obj)
+			if item.err {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(met).To(Equal(item.met))
+			g.Expect(message).To(ContainSubstring(item.message))
+		})
+	}
+}
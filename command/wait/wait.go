@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/progress"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+const (
+	// DefaultTimeout time waited when no --timeout is given
+	DefaultTimeout = 30 * time.Second
+	// DefaultPollInterval interval between checks of the objects
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Options options of the wait command
+type Options struct {
+	// For is the condition expression, see ParseCondition
+	For string
+	// Timeout of the wait, defaults to DefaultTimeout
+	Timeout time.Duration
+	// PollInterval between checks, defaults to DefaultPollInterval
+	PollInterval time.Duration
+	// Namespace of the objects, defaults to the kubeflags namespace or default
+	Namespace string
+	// NewClient returns the client used to get objects, defaults to kubeflags.DefaultClientFunc
+	NewClient kubeflags.ClientFunc
+}
+
+// AddFlags add flags to options
+func (opts *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&opts.For, "for", opts.For,
+		"condition to wait for: ready, delete, condition=<type>[=<status>] or jsonpath='{<path>}'[=<value>]")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", DefaultTimeout, "time to wait before giving up")
+}
+
+// NewCommand returns a SubcommandFunc of the wait subcommand, e.g.
+//
+//	mycli wait deployment.apps/my-app --for=condition=Available --timeout=5m
+func NewCommand(opts *Options) root.SubcommandFunc {
+	return func(ctx context.Context, name string) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   "wait <resource>/<name>...",
+			Short: "Wait for objects in the cluster to meet a condition",
+			Example: fmt.Sprintf(`  # wait for a deployment to be ready
+  %[1]s wait deployment.apps/my-app
+
+  # wait for a condition with a timeout
+  %[1]s wait pipelineruns.tekton.dev/my-run --for=condition=Succeeded --timeout=5m
+
+  # wait for a field value
+  %[1]s wait pod/my-pod --for=jsonpath='{.status.phase}'=Running`, name),
+			Args:         cobra.MinimumNArgs(1),
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				clientFunc := opts.NewClient
+				if clientFunc == nil {
+					clientFunc = kubeflags.DefaultClientFunc
+				}
+				clt, err := clientFunc(cmd.Context())
+				if err != nil {
+					return err
+				}
+				return opts.Run(cmd.Context(), clt, args, pkgio.MustGetIOStreams(ctx).Out)
+			},
+		}
+		opts.AddFlags(cmd)
+		// reuses the persistent --namespace flag of kubeflags when available
+		if kubeflags.GetKubeFlags(ctx) == nil {
+			cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", opts.Namespace, "namespace of the objects")
+		}
+		return cmd
+	}
+}
+
+// Run waits for the objects referenced by args, like deployment.apps/name, to meet the condition.
+// Progress is reported into the error stream in the context and a line is printed into out
+// for every object meeting the condition
+func (opts *Options) Run(ctx context.Context, clt client.Client, args []string, out io.Writer) error {
+	condition, err := ParseCondition(opts.For)
+	if err != nil {
+		return err
+	}
+	namespace, err := opts.namespace(ctx)
+	if err != nil {
+		return err
+	}
+	objs, err := resolveArgs(clt, args, namespace)
+	if err != nil {
+		return err
+	}
+
+	timeout, interval := opts.Timeout, opts.PollInterval
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	// all objects share the same deadline
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	reporter := progress.NewReporter(ctx)
+	for _, obj := range objs {
+		ref := reference(obj)
+		spinner := reporter.Spinner(fmt.Sprintf("waiting for %s: %s", ref, condition))
+		if err = waitFor(ctx, clt, obj, condition, interval, spinner); err != nil {
+			spinner.Fail(err)
+			return fmt.Errorf("%s: %w", ref, err)
+		}
+		spinner.Success(ref + " condition met")
+		fmt.Fprintf(out, "%s condition met\n", ref)
+	}
+	return nil
+}
+
+// waitFor polls obj until condition is met, updating the spinner when the state changes
+func waitFor(ctx context.Context, clt client.Client, obj *unstructured.Unstructured, condition Condition, interval time.Duration, spinner *progress.Spinner) error {
+	var lastMessage string
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		err := clt.Get(ctx, client.ObjectKeyFromObject(obj), live)
+		switch {
+		case apierrors.IsNotFound(err):
+			live = nil
+		case err != nil:
+			return false, err
+		}
+		met, message, err := condition.Check(live)
+		if err != nil {
+			return false, err
+		}
+		if message != lastMessage && !met {
+			lastMessage = message
+			spinner.Update(message)
+		}
+		return met, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for %s: %s", condition, lastMessage)
+	}
+	return err
+}
+
+func (opts *Options) namespace(ctx context.Context) (string, error) {
+	if opts.Namespace != "" {
+		return opts.Namespace, nil
+	}
+	if kubeflags.GetKubeFlags(ctx) != nil {
+		namespace, err := kubeflags.GetNamespace(ctx)
+		if err != nil || namespace != "" {
+			return namespace, err
+		}
+	}
+	return "default", nil
+}
+
+// resolveArgs resolves args like deployment.apps/name into objects using the client RESTMapper
+func resolveArgs(clt client.Client, args []string, namespace string) (objs []*unstructured.Unstructured, err error) {
+	for _, arg := range args {
+		resource, name, found := strings.Cut(arg, "/")
+		if !found || resource == "" || name == "" {
+			return nil, fmt.Errorf("invalid argument %q, expected <resource>/<name>", arg)
+		}
+		gvk, err := kubeflags.KindFor(clt.RESTMapper(), resource)
+		if err != nil {
+			return nil, err
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName(name)
+		namespaced, err := clt.IsObjectNamespaced(obj)
+		if err != nil {
+			return nil, err
+		}
+		if namespaced {
+			obj.SetNamespace(namespace)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// reference returns obj like kubectl, e.g. deployment.apps/name
+func reference(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		kind += "." + gvk.Group
+	}
+	return kind + "/" + obj.GetName()
+}
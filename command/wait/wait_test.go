@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClient(objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).WithObjects(objs...).WithStatusSubresource(objs...).Build()
+}
+
+// newContext returns a context with test streams and a logger writing progress into the error stream
+func newContext(ctx context.Context) (context.Context, *bytes.Buffer, *bytes.Buffer) {
+	streams, _, out, errOut := clioptions.NewTestIOStreams()
+	ctx = io.WithIOStreams(ctx, &streams)
+	return logger.WithLogger(ctx, logger.NewLogger(zapcore.AddSync(errOut), zapcore.InfoLevel)), out, errOut
+}
+
+func TestWaitCommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx, out, errOut := newContext(context.Background())
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "other"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "other"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	clt := newClient(pod, running)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		pod.Status.Phase = corev1.PodRunning
+		_ = clt.Status().Update(ctx, pod)
+	}()
+
+	cmd := NewCommand(&Options{
+		PollInterval: 10 * time.Millisecond,
+		NewClient:    func(context.Context) (client.Client, error) { return clt, nil },
+	})(ctx, "cli")
+	cmd.SetArgs([]string{"pods/pod", "pod/running", "-n", "other", "--for=jsonpath={.status.phase}=Running", "--timeout=5s"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(out.String()).To(Equal("pod/pod condition met\npod/running condition met\n"))
+	g.Expect(errOut.String()).To(ContainSubstring(`{.status.phase} is "Pending"`))
+}
+
+func TestOptionsRun(t *testing.T) {
+	ctx := context.Background()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}}
+
+	var data = []struct {
+		desc string
+		opts Options
+		args []string
+		err  string
+	}{
+		{"timeout", Options{For: "condition=Ready"}, []string{"pod/pod"}, "pod/pod: timed out waiting for condition=Ready=True: condition Ready not found"},
+		{"deleted", Options{For: "delete"}, []string{"pod/missing"}, ""},
+		{"cluster scoped", Options{For: "delete"}, []string{"namespaces/missing"}, ""},
+		{"invalid condition", Options{For: "exists"}, []string{"pod/pod"}, "unrecognized condition"},
+		{"invalid argument", Options{}, []string{"pod"}, "expected <resource>/<name>"},
+		{"unknown resource", Options{}, []string{"tools/pod"}, `resource "tools" not found`},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			ctx, out, _ := newContext(ctx)
+			opts := item.opts
+			opts.Timeout, opts.PollInterval = 50*time.Millisecond, 10*time.Millisecond
+
+			err := opts.Run(ctx, newClient(pod.DeepCopy()), item.args, out)
+			if item.err == "" {
+				g.Expect(err).To(BeNil())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(item.err)))
+		})
+	}
+}
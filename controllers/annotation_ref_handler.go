@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// EnqueueRequestForAnnotationRef enqueues reconcile requests for the objects referenced
+// in an annotation of a dependency object, e.g. a Secret used by objects in other namespaces
+// where owner references can not be used.
+// The annotation holds a comma separated list of namespace/name references,
+// references without namespace default to the namespace of the dependency.
+// Update events enqueue references of both the old and new object so removed references
+// are reconciled as well. Use AddAnnotationRef and RemoveAnnotationRef to maintain the annotation.
+type EnqueueRequestForAnnotationRef struct {
+	// Annotation key holding the references
+	Annotation string
+}
+
+var _ handler.EventHandler = EnqueueRequestForAnnotationRef{}
+
+// Create implements handler.EventHandler
+func (e EnqueueRequestForAnnotationRef) Create(_ context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueue(q, evt.Object)
+}
+
+// Update implements handler.EventHandler
+func (e EnqueueRequestForAnnotationRef) Update(_ context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueue(q, evt.ObjectOld, evt.ObjectNew)
+}
+
+// Delete implements handler.EventHandler
+func (e EnqueueRequestForAnnotationRef) Delete(_ context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueue(q, evt.Object)
+}
+
+// Generic implements handler.EventHandler
+func (e EnqueueRequestForAnnotationRef) Generic(_ context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueue(q, evt.Object)
+}
+
+func (e EnqueueRequestForAnnotationRef) enqueue(q workqueue.TypedRateLimitingInterface[reconcile.Request], objs ...client.Object) {
+	refs := sets.New[types.NamespacedName]()
+	for _, obj := range objs {
+		refs.Insert(AnnotationRefs(obj, e.Annotation)...)
+	}
+	for ref := range refs {
+		q.Add(reconcile.Request{NamespacedName: ref})
+	}
+}
+
+// AnnotationRefs returns the references stored in the annotation key of obj,
+// references without namespace default to the namespace of obj
+func AnnotationRefs(obj client.Object, key string) []types.NamespacedName {
+	if obj == nil {
+		return nil
+	}
+	value := obj.GetAnnotations()[key]
+	if value == "" {
+		return nil
+	}
+
+	var refs []types.NamespacedName
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		ref := types.NamespacedName{Namespace: obj.GetNamespace(), Name: item}
+		if namespace, name, found := strings.Cut(item, "/"); found {
+			ref = types.NamespacedName{Namespace: namespace, Name: name}
+		}
+		if ref.Name == "" {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// AddAnnotationRef adds ref to the references in the annotation key of obj,
+// returns true if the annotation was changed
+func AddAnnotationRef(obj client.Object, key string, ref types.NamespacedName) bool {
+	refs := sets.New(AnnotationRefs(obj, key)...)
+	if refs.Has(ref) {
+		return false
+	}
+	setAnnotationRefs(obj, key, refs.Insert(ref))
+	return true
+}
+
+// RemoveAnnotationRef removes ref from the references in the annotation key of obj,
+// the annotation is deleted when no reference is left. Returns true if the annotation was changed
+func RemoveAnnotationRef(obj client.Object, key string, ref types.NamespacedName) bool {
+	refs := sets.New(AnnotationRefs(obj, key)...)
+	if !refs.Has(ref) {
+		return false
+	}
+	setAnnotationRefs(obj, key, refs.Delete(ref))
+	return true
+}
+
+// setAnnotationRefs stores refs sorted in the annotation key of obj
+func setAnnotationRefs(obj client.Object, key string, refs sets.Set[types.NamespacedName]) {
+	annotations := obj.GetAnnotations()
+	if refs.Len() == 0 {
+		delete(annotations, key)
+		obj.SetAnnotations(annotations)
+		return
+	}
+
+	values := make([]string, 0, refs.Len())
+	for ref := range refs {
+		values = append(values, ref.String())
+	}
+	sort.Strings(values)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = strings.Join(values, ",")
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const refAnnotation = "example.com/used-by"
+
+func secretWithRefs(refs string) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "credentials"}}
+	if refs != "" {
+		secret.Annotations = map[string]string{refAnnotation: refs}
+	}
+	return secret
+}
+
+func drain(q workqueue.TypedRateLimitingInterface[reconcile.Request]) []types.NamespacedName {
+	result := []types.NamespacedName{}
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		result = append(result, item.NamespacedName)
+		q.Done(item)
+	}
+	return result
+}
+
+func TestEnqueueRequestForAnnotationRef(t *testing.T) {
+	ctx := context.Background()
+	h := EnqueueRequestForAnnotationRef{Annotation: refAnnotation}
+
+	var data = []struct {
+		desc     string
+		trigger  func(q workqueue.TypedRateLimitingInterface[reconcile.Request])
+		expected []types.NamespacedName
+	}{
+		{
+			desc: "create enqueues all references",
+			trigger: func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Create(ctx, event.CreateEvent{Object: secretWithRefs("team-a/app, team-b/app,local,, broken/")}, q)
+			},
+			expected: []types.NamespacedName{
+				{Namespace: "team-a", Name: "app"},
+				{Namespace: "team-b", Name: "app"},
+				{Namespace: "shared", Name: "local"},
+			},
+		},
+		{
+			desc: "update enqueues old and new references once",
+			trigger: func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Update(ctx, event.UpdateEvent{
+					ObjectOld: secretWithRefs("team-a/app,team-b/app"),
+					ObjectNew: secretWithRefs("team-b/app,team-c/app"),
+				}, q)
+			},
+			expected: []types.NamespacedName{
+				{Namespace: "team-a", Name: "app"},
+				{Namespace: "team-b", Name: "app"},
+				{Namespace: "team-c", Name: "app"},
+			},
+		},
+		{
+			desc: "delete enqueues references",
+			trigger: func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Delete(ctx, event.DeleteEvent{Object: secretWithRefs("team-a/app")}, q)
+			},
+			expected: []types.NamespacedName{{Namespace: "team-a", Name: "app"}},
+		},
+		{
+			desc: "generic enqueues references",
+			trigger: func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Generic(ctx, event.GenericEvent{Object: secretWithRefs("team-a/app")}, q)
+			},
+			expected: []types.NamespacedName{{Namespace: "team-a", Name: "app"}},
+		},
+		{
+			desc: "no annotation enqueues nothing",
+			trigger: func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Create(ctx, event.CreateEvent{Object: secretWithRefs("")}, q)
+				h.Update(ctx, event.UpdateEvent{ObjectNew: secretWithRefs("")}, q)
+			},
+			expected: []types.NamespacedName{},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+
+			item.trigger(q)
+			g.Expect(drain(q)).To(ConsistOf(item.expected))
+		})
+	}
+}
+
+func TestAddRemoveAnnotationRef(t *testing.T) {
+	g := NewGomegaWithT(t)
+	secret := secretWithRefs("")
+	teamA := types.NamespacedName{Namespace: "team-a", Name: "app"}
+	teamB := types.NamespacedName{Namespace: "team-b", Name: "app"}
+
+	g.Expect(AddAnnotationRef(secret, refAnnotation, teamB)).To(BeTrue())
+	g.Expect(AddAnnotationRef(secret, refAnnotation, teamA)).To(BeTrue())
+	g.Expect(AddAnnotationRef(secret, refAnnotation, teamA)).To(BeFalse())
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(refAnnotation, "team-a/app,team-b/app"))
+	g.Expect(AnnotationRefs(secret, refAnnotation)).To(Equal([]types.NamespacedName{teamA, teamB}))
+
+	g.Expect(RemoveAnnotationRef(secret, refAnnotation, teamA)).To(BeTrue())
+	g.Expect(RemoveAnnotationRef(secret, refAnnotation, teamA)).To(BeFalse())
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(refAnnotation, "team-b/app"))
+
+	g.Expect(RemoveAnnotationRef(secret, refAnnotation, teamB)).To(BeTrue())
+	g.Expect(secret.Annotations).NotTo(HaveKey(refAnnotation))
+	g.Expect(AnnotationRefs(nil, refAnnotation)).To(BeNil())
+}
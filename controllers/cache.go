@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// DefaultMaxAnnotationSize is the size in bytes above which StripLargeAnnotations
+// drops an annotation value when no size is given
+const DefaultMaxAnnotationSize = 4096
+
+// DefaultCacheTransform returns the transform applied to all cached objects by NewManager,
+// it strips managed fields which are never read by controllers and are often the largest
+// part of the object.
+func DefaultCacheTransform() toolscache.TransformFunc {
+	return cache.TransformStripManagedFields()
+}
+
+// StripLargeAnnotations returns a transform removing the annotations keys and the annotations
+// whose value is larger than maxSize bytes from the cached objects, DefaultMaxAnnotationSize
+// is used when maxSize is zero and a negative maxSize only removes the keys.
+//
+// Objects read from a cache using this transform are missing the annotations and must be
+// patched, updating them removes the annotations on the server.
+func StripLargeAnnotations(maxSize int, keys ...string) toolscache.TransformFunc {
+	if maxSize == 0 {
+		maxSize = DefaultMaxAnnotationSize
+	}
+	return func(in any) (any, error) {
+		obj, err := meta.Accessor(in)
+		if err != nil {
+			// not an object, e.g. a tombstone
+			return in, nil
+		}
+		annotations := obj.GetAnnotations()
+		if len(annotations) == 0 {
+			return in, nil
+		}
+		stripped := make(map[string]string, len(annotations))
+		for key, value := range annotations {
+			if (maxSize > 0 && len(value) > maxSize) || slices.Contains(keys, key) {
+				continue
+			}
+			stripped[key] = value
+		}
+		if len(stripped) != len(annotations) {
+			obj.SetAnnotations(stripped)
+		}
+		return in, nil
+	}
+}
+
+// StripLastAppliedConfiguration returns a transform removing the kubectl last applied
+// configuration annotation from the cached objects, see StripLargeAnnotations
+func StripLastAppliedConfiguration() toolscache.TransformFunc {
+	return StripLargeAnnotations(-1, corev1.LastAppliedConfigAnnotation)
+}
+
+// ChainTransforms returns a transform applying the transforms in order, nil transforms are skipped
+func ChainTransforms(transforms ...toolscache.TransformFunc) toolscache.TransformFunc {
+	return func(in any) (out any, err error) {
+		out = in
+		for _, transform := range transforms {
+			if transform == nil {
+				continue
+			}
+			if out, err = transform(out); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+}
+
+// MetadataOnly returns a function for ManagerOptions.Customize that disables the cache of
+// the typed objects in the manager client, so reading one of them does not start an informer
+// caching whole objects, e.g. all secrets of the cluster. Controllers should watch them using
+// builder.OnlyMetadata, read them as metav1.PartialObjectMetadata from the cache and fetch
+// the whole object from the api server only when needed.
+func MetadataOnly(objs ...client.Object) func(*ctrl.Options) {
+	return func(options *ctrl.Options) {
+		if options.Client.Cache == nil {
+			options.Client.Cache = &client.CacheOptions{}
+		}
+		options.Client.Cache.DisableFor = append(options.Client.Cache.DisableFor, objs...)
+	}
+}
+
+// MetadataPredicate wraps a predicate written for typed objects so it can be used in
+// metadata only watches. Objects of events are converted to the typed object of their kind
+// in scheme with only the metadata set. Update events where only the resource version changed
+// are changes of the content that the predicate cannot see, they are accepted so reconcilers
+// do not miss them.
+func MetadataPredicate(scheme *runtime.Scheme, p predicate.Predicate) predicate.Predicate {
+	return metadataPredicate{scheme: scheme, predicate: p}
+}
+
+type metadataPredicate struct {
+	scheme    *runtime.Scheme
+	predicate predicate.Predicate
+}
+
+var _ predicate.Predicate = metadataPredicate{}
+
+// Create implements predicate.Predicate
+func (p metadataPredicate) Create(e event.CreateEvent) bool {
+	e.Object = p.typed(e.Object)
+	return p.predicate.Create(e)
+}
+
+// Delete implements predicate.Predicate
+func (p metadataPredicate) Delete(e event.DeleteEvent) bool {
+	e.Object = p.typed(e.Object)
+	return p.predicate.Delete(e)
+}
+
+// Generic implements predicate.Predicate
+func (p metadataPredicate) Generic(e event.GenericEvent) bool {
+	e.Object = p.typed(e.Object)
+	return p.predicate.Generic(e)
+}
+
+// Update implements predicate.Predicate
+func (p metadataPredicate) Update(e event.UpdateEvent) bool {
+	oldMeta, oldOk := e.ObjectOld.(*metav1.PartialObjectMetadata)
+	newMeta, newOk := e.ObjectNew.(*metav1.PartialObjectMetadata)
+	if oldOk && newOk && oldMeta.ResourceVersion != newMeta.ResourceVersion && onlyResourceVersionChanged(oldMeta, newMeta) {
+		return true
+	}
+	e.ObjectOld = p.typed(e.ObjectOld)
+	e.ObjectNew = p.typed(e.ObjectNew)
+	return p.predicate.Update(e)
+}
+
+// typed returns the typed object of the kind of obj with the metadata of obj,
+// obj is returned as is when it is not a metav1.PartialObjectMetadata or its kind is unknown
+func (p metadataPredicate) typed(obj client.Object) client.Object {
+	partial, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok || partial == nil || p.scheme == nil {
+		return obj
+	}
+	gvk := partial.GroupVersionKind()
+	newObj, err := p.scheme.New(gvk)
+	if err != nil {
+		return obj
+	}
+	typed, ok := newObj.(client.Object)
+	if !ok {
+		return obj
+	}
+	value := reflect.ValueOf(typed).Elem()
+	if value.Kind() != reflect.Struct {
+		return obj
+	}
+	field := value.FieldByName("ObjectMeta")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(metav1.ObjectMeta{}) {
+		return obj
+	}
+	field.Set(reflect.ValueOf(*partial.ObjectMeta.DeepCopy()))
+	typed.GetObjectKind().SetGroupVersionKind(gvk)
+	return typed
+}
+
+func onlyResourceVersionChanged(oldMeta, newMeta *metav1.PartialObjectMetadata) bool {
+	oldObjectMeta := oldMeta.ObjectMeta.DeepCopy()
+	oldObjectMeta.ResourceVersion = newMeta.ResourceVersion
+	oldObjectMeta.ManagedFields = newMeta.ManagedFields
+	return equality.Semantic.DeepEqual(*oldObjectMeta, newMeta.ObjectMeta)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+func TestCacheTransforms(t *testing.T) {
+	large := strings.Repeat("x", DefaultMaxAnnotationSize+1)
+	newSecret := func() *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:          "secret",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			Annotations: map[string]string{
+				"small":                            "value",
+				"large":                            large,
+				corev1.LastAppliedConfigAnnotation: "{}",
+			},
+		}}
+	}
+
+	var data = []struct {
+		desc        string
+		transform   toolscache.TransformFunc
+		annotations []string
+		managed     bool
+	}{
+		{"default strips managed fields", DefaultCacheTransform(), []string{"small", "large", corev1.LastAppliedConfigAnnotation}, false},
+		{"strips large annotations and keys", StripLargeAnnotations(0, corev1.LastAppliedConfigAnnotation), []string{"small"}, true},
+		{"strips annotations larger than size", StripLargeAnnotations(4), []string{corev1.LastAppliedConfigAnnotation}, true},
+		{"strips last applied configuration", StripLastAppliedConfiguration(), []string{"small", "large"}, true},
+		{"chains transforms", ChainTransforms(DefaultCacheTransform(), nil, StripLargeAnnotations(0)), []string{"small", corev1.LastAppliedConfigAnnotation}, false},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			out, err := item.transform(newSecret())
+			g.Expect(err).NotTo(HaveOccurred())
+			secret := out.(*corev1.Secret)
+			keys := make([]string, 0, len(secret.Annotations))
+			for key := range secret.Annotations {
+				keys = append(keys, key)
+			}
+			g.Expect(keys).To(ConsistOf(item.annotations))
+			g.Expect(secret.ManagedFields != nil).To(Equal(item.managed))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	tombstone := toolscache.DeletedFinalStateUnknown{Key: "default/secret"}
+	out, err := StripLargeAnnotations(0)(tombstone)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal(tombstone))
+}
+
+func TestMetadataOnly(t *testing.T) {
+	g := NewGomegaWithT(t)
+	options := ctrl.Options{}
+	MetadataOnly(&corev1.Secret{})(&options)
+	MetadataOnly(&corev1.ConfigMap{})(&options)
+	g.Expect(options.Client.Cache.DisableFor).To(Equal([]client.Object{&corev1.Secret{}, &corev1.ConfigMap{}}))
+}
+
+func TestMetadataPredicate(t *testing.T) {
+	partial := func(resourceVersion string, labels map[string]string) *metav1.PartialObjectMetadata {
+		obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Name: "secret", Namespace: "default", ResourceVersion: resourceVersion, Labels: labels,
+		}}
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		return obj
+	}
+	isSecret := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.Name == "secret" && secret.Namespace == "default"
+	})
+
+	t.Run("converts metadata to typed objects", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		p := MetadataPredicate(clientgoscheme.Scheme, isSecret)
+		g.Expect(p.Create(event.CreateEvent{Object: partial("1", nil)})).To(BeTrue())
+		g.Expect(p.Delete(event.DeleteEvent{Object: partial("1", nil)})).To(BeTrue())
+		g.Expect(p.Generic(event.GenericEvent{Object: partial("1", nil)})).To(BeTrue())
+		g.Expect(p.Create(event.CreateEvent{Object: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "default"}}})).To(BeTrue())
+
+		g.Expect(MetadataPredicate(nil, isSecret).Create(event.CreateEvent{Object: partial("1", nil)})).To(BeFalse())
+	})
+
+	t.Run("accepts content changes", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		p := MetadataPredicate(clientgoscheme.Scheme, SecretDataChangedPredicate{})
+		g.Expect(p.Update(event.UpdateEvent{ObjectOld: partial("1", nil), ObjectNew: partial("2", nil)})).To(BeTrue())
+		g.Expect(p.Update(event.UpdateEvent{ObjectOld: partial("1", nil), ObjectNew: partial("1", nil)})).To(BeFalse())
+	})
+
+	t.Run("evaluates metadata changes", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		p := MetadataPredicate(clientgoscheme.Scheme, LabelChangedPredicate{Keys: []string{"app"}})
+		g.Expect(p.Update(event.UpdateEvent{
+			ObjectOld: partial("1", map[string]string{"app": "a"}),
+			ObjectNew: partial("2", map[string]string{"app": "b"}),
+		})).To(BeTrue())
+		g.Expect(p.Update(event.UpdateEvent{
+			ObjectOld: partial("1", map[string]string{"app": "a"}),
+			ObjectNew: partial("2", map[string]string{"app": "a", "other": "b"}),
+		})).To(BeFalse())
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/AlaudaDevops/pkg/cache"
+	"github.com/AlaudaDevops/pkg/cel"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// celProgramsMaxEntries bounds the compiled programs kept by celPrograms,
+// expressions evicted are compiled again when used
+const celProgramsMaxEntries = 512
+
+// celPrograms caches compiled programs by expression, evicting the least recently used
+var celPrograms = cache.New[string, *cel.Program](cache.WithMaxEntries(celProgramsMaxEntries))
+
+// CELPredicate filters events using a CEL expression that must return a bool.
+// The expression can access the object as `object` and the previous object as `oldObject`:
+//
+//   - Create: object is the created object and oldObject is null
+//   - Update: object is the new object and oldObject is the old object
+//   - Delete: object is the deleted object and oldObject is null
+//   - Generic: object is the object and oldObject is null
+//
+// Events are filtered out when the expression fails to compile or evaluate.
+type CELPredicate struct {
+	// Expression is the CEL expression evaluated for each event
+	Expression string
+	// Logger is used to log compilation and evaluation errors, optional
+	Logger *zap.SugaredLogger
+}
+
+var _ predicate.Predicate = CELPredicate{}
+
+// NewCELPredicate returns a CELPredicate after validating its expression
+func NewCELPredicate(expression string, logger *zap.SugaredLogger) (CELPredicate, error) {
+	p := CELPredicate{Expression: expression, Logger: logger}
+	_, err := p.program()
+	return p, err
+}
+
+// Create implements Predicate interface for creation events.
+func (p CELPredicate) Create(e event.CreateEvent) bool {
+	return p.eval(e.Object, nil)
+}
+
+// Delete implements Predicate interface for deletion events.
+func (p CELPredicate) Delete(e event.DeleteEvent) bool {
+	return p.eval(e.Object, nil)
+}
+
+// Update implements Predicate interface for update events.
+func (p CELPredicate) Update(e event.UpdateEvent) bool {
+	return p.eval(e.ObjectNew, e.ObjectOld)
+}
+
+// Generic implements Predicate interface for generic events.
+func (p CELPredicate) Generic(e event.GenericEvent) bool {
+	return p.eval(e.Object, nil)
+}
+
+func (p CELPredicate) program() (*cel.Program, error) {
+	return celPrograms.GetOrLoad(context.Background(), p.Expression, func(context.Context) (*cel.Program, error) {
+		return cel.Compile(p.Expression)
+	})
+}
+
+func (p CELPredicate) eval(obj, oldObj client.Object) bool {
+	if obj == nil {
+		return false
+	}
+	program, err := p.program()
+	if err != nil {
+		p.logError(err)
+		return false
+	}
+	result, err := program.EvalBool(context.Background(), obj, oldObj)
+	if err != nil {
+		p.logError(err, "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return false
+	}
+	return result
+}
+
+func (p CELPredicate) logError(err error, keysAndValues ...interface{}) {
+	if p.Logger == nil {
+		return
+	}
+	p.Logger.Errorw("cel predicate failed", append([]interface{}{"err", err, "expression", p.Expression}, keysAndValues...)...)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestCELPredicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newConfigMap := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Labels: map[string]string{"watch": value}},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	p := CELPredicate{Expression: "object.metadata.labels.watch == 'true' && (oldObject == null || oldObject.data.key != object.data.key)"}
+
+	g.Expect(p.Create(event.CreateEvent{Object: newConfigMap("true")})).To(BeTrue())
+	g.Expect(p.Create(event.CreateEvent{Object: newConfigMap("false")})).To(BeFalse())
+	g.Expect(p.Delete(event.DeleteEvent{Object: newConfigMap("true")})).To(BeTrue())
+	g.Expect(p.Generic(event.GenericEvent{Object: newConfigMap("false")})).To(BeFalse())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: newConfigMap("false"), ObjectNew: newConfigMap("true")})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: newConfigMap("true"), ObjectNew: newConfigMap("true")})).To(BeFalse())
+	g.Expect(p.Update(event.UpdateEvent{})).To(BeFalse())
+}
+
+func TestCELPredicateInvalidExpression(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := NewCELPredicate("object.", nil)
+	g.Expect(err).NotTo(BeNil())
+
+	p := CELPredicate{Expression: "object."}
+	g.Expect(p.Create(event.CreateEvent{Object: &corev1.ConfigMap{}})).To(BeFalse())
+
+	p, err = NewCELPredicate("has(object.data)", nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(p.Create(event.CreateEvent{Object: &corev1.ConfigMap{Data: map[string]string{"a": "b"}}})).To(BeTrue())
+}
+
+func TestCELPredicateProgramCacheIsBounded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for i := 0; i < celProgramsMaxEntries+10; i++ {
+		_, err := NewCELPredicate(fmt.Sprintf("object.metadata.name == 'cm-%d'", i), nil)
+		g.Expect(err).To(BeNil())
+	}
+	g.Expect(celPrograms.Len()).To(Equal(celProgramsMaxEntries))
+
+	// evicted expressions are compiled again
+	p := CELPredicate{Expression: "object.metadata.name == 'cm-0'"}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm-0"}}
+	g.Expect(p.Create(event.CreateEvent{Object: cm})).To(BeTrue())
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/metrics"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultConcurrencyTuningInterval how often the concurrency is adjusted when no interval is given
+const DefaultConcurrencyTuningInterval = 10 * time.Second
+
+// ConcurrencyOptions bounds and targets of a ConcurrencyTuner
+type ConcurrencyOptions struct {
+	// Min and Max number of concurrent reconciles, Min defaults to 1 and Max to Min
+	Min int
+	Max int
+	// Interval between adjustments, defaults to DefaultConcurrencyTuningInterval
+	Interval time.Duration
+	// TargetLatency is the average reconcile duration above which the concurrency is not
+	// increased but halved, as slow reconciles usually mean a saturated dependency,
+	// zero only considers the queue depth
+	TargetLatency time.Duration
+	// Clock used to measure reconciles and tick adjustments, defaults to the real clock
+	Clock clock.Clock
+}
+
+// ConcurrencyTuner adjusts the number of concurrent reconciles of a controller at runtime.
+// The controller runs Max workers and the reconciler returned by Reconciler only lets
+// the current limit of them reconcile at the same time.
+//
+// Every interval the limit is doubled when requests are waiting in the queue or for a
+// reconcile slot and reconciles are faster than the target latency, halved when reconciles
+// are slower than the target latency, and decreased by one when the queue is empty and
+// less than half of the limit was used. The limit is always kept between Min and Max and exposed in the
+// metrics.ReconcileConcurrency gauge while the queue depth is exposed in metrics.QueueDepth.
+//
+// It must be added to the manager to run:
+//
+//	tuner := controllers.NewConcurrencyTuner("repository", controllers.ConcurrencyOptions{Min: 1, Max: 20})
+//	if err := mgr.Add(tuner); err != nil { ... }
+//	ctrl.NewControllerManagedBy(mgr).For(&v1.Repository{}).
+//		WithOptions(tuner.ControllerOptions(controller.Options{})).
+//		Complete(tuner.Reconciler(reconciler))
+type ConcurrencyTuner struct {
+	name string
+	opts ConcurrencyOptions
+
+	lock     sync.Mutex
+	limit    int
+	active   int
+	waiting  int
+	peak     int
+	changed  chan struct{}
+	queue    workqueue.TypedRateLimitingInterface[reconcile.Request]
+	count    int
+	duration time.Duration
+}
+
+// NewConcurrencyTuner returns a ConcurrencyTuner for the controller name starting at opts.Min
+func NewConcurrencyTuner(name string, opts ConcurrencyOptions) *ConcurrencyTuner {
+	if opts.Min <= 0 {
+		opts.Min = 1
+	}
+	if opts.Max < opts.Min {
+		opts.Max = opts.Min
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultConcurrencyTuningInterval
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	t := &ConcurrencyTuner{
+		name:    name,
+		opts:    opts,
+		limit:   opts.Min,
+		changed: make(chan struct{}),
+	}
+	metrics.SetReconcileConcurrency(name, t.limit)
+	return t
+}
+
+// Limit returns the current number of concurrent reconciles allowed
+func (t *ConcurrencyTuner) Limit() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.limit
+}
+
+// ControllerOptions returns opts running Max workers and keeping a reference to the
+// controller queue to measure its depth, the queue is created by opts.NewQueue if set
+func (t *ConcurrencyTuner) ControllerOptions(opts controller.Options) controller.Options {
+	opts.MaxConcurrentReconciles = t.opts.Max
+	newQueue := opts.NewQueue
+	opts.NewQueue = func(controllerName string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		var queue workqueue.TypedRateLimitingInterface[reconcile.Request]
+		if newQueue != nil {
+			queue = newQueue(controllerName, rateLimiter)
+		} else {
+			queue = workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+				Name: controllerName,
+			})
+		}
+		t.lock.Lock()
+		t.queue = queue
+		t.lock.Unlock()
+		return queue
+	}
+	return opts
+}
+
+// WithConcurrencyTuner returns a ControllerBuilderOption setting the options of the
+// controller to the ones returned by ControllerOptions, replacing previous options
+func WithConcurrencyTuner(tuner *ConcurrencyTuner, opts controller.Options) ControllerBuilderOption {
+	return func(b *builder.Builder) *builder.Builder {
+		return b.WithOptions(tuner.ControllerOptions(opts))
+	}
+}
+
+// Reconciler wraps r limiting its concurrent reconciles and measuring their duration
+func (t *ConcurrencyTuner) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		if err := t.acquire(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+		start := t.opts.Clock.Now()
+		defer func() {
+			t.release(t.opts.Clock.Since(start))
+		}()
+		return r.Reconcile(ctx, request)
+	})
+}
+
+// Start adjusts the concurrency every interval until ctx is done
+func (t *ConcurrencyTuner) Start(ctx context.Context) error {
+	ticker := t.opts.Clock.NewTicker(t.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+			t.adjust()
+		}
+	}
+}
+
+// acquire waits until less reconciles than the limit are running.
+// Waiting reconciles are counted in the queue depth as their requests
+// were already taken out of the queue
+func (t *ConcurrencyTuner) acquire(ctx context.Context) error {
+	waiting := false
+	for {
+		t.lock.Lock()
+		if t.active < t.limit {
+			if waiting {
+				t.waiting--
+			}
+			t.active++
+			if t.active > t.peak {
+				t.peak = t.active
+			}
+			t.lock.Unlock()
+			return nil
+		}
+		if !waiting {
+			waiting = true
+			t.waiting++
+		}
+		changed := t.changed
+		t.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			t.lock.Lock()
+			t.waiting--
+			t.lock.Unlock()
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// release frees the slot of a reconcile that took duration
+func (t *ConcurrencyTuner) release(duration time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.active--
+	t.count++
+	t.duration += duration
+	t.notify()
+}
+
+// adjust computes the new limit from the queue depth, including the reconciles waiting
+// for a slot, and the reconciles since the last adjustment
+func (t *ConcurrencyTuner) adjust() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	depth := t.waiting
+	if t.queue != nil {
+		depth += t.queue.Len()
+		metrics.SetQueueDepth(t.name, depth)
+	}
+	var latency time.Duration
+	if t.count > 0 {
+		latency = t.duration / time.Duration(t.count)
+	}
+
+	limit := t.limit
+	switch {
+	case t.opts.TargetLatency > 0 && latency > t.opts.TargetLatency:
+		limit /= 2
+	case depth > 0:
+		limit *= 2
+	case t.peak*2 < t.limit:
+		limit--
+	}
+	limit = max(t.opts.Min, min(t.opts.Max, limit))
+
+	t.count, t.duration, t.peak = 0, 0, t.active
+	if limit != t.limit {
+		t.limit = limit
+		metrics.SetReconcileConcurrency(t.name, limit)
+		t.notify()
+	}
+}
+
+// notify wakes up reconciles waiting in acquire, must be called with the lock held
+func (t *ConcurrencyTuner) notify() {
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/metrics"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestConcurrencyTuner_Options(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tuner := NewConcurrencyTuner("defaults", ConcurrencyOptions{Max: -1})
+	g.Expect(tuner.Limit()).To(Equal(1))
+	g.Expect(tuner.opts.Max).To(Equal(1))
+	g.Expect(tuner.opts.Interval).To(Equal(DefaultConcurrencyTuningInterval))
+
+	tuner = NewConcurrencyTuner("options", ConcurrencyOptions{Min: 2, Max: 8})
+	opts := tuner.ControllerOptions(controller.Options{})
+	g.Expect(opts.MaxConcurrentReconciles).To(Equal(8))
+	queue := opts.NewQueue("options", DefaultTypedRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	g.Expect(tuner.queue).To(Equal(queue))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileConcurrency.WithLabelValues("options"))).To(Equal(float64(2)))
+}
+
+func TestConcurrencyTuner_Adjust(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fakeClock := clock.NewFakeClock(time.Now())
+	tuner := NewConcurrencyTuner("adjust", ConcurrencyOptions{Min: 1, Max: 4, TargetLatency: time.Second, Clock: fakeClock})
+	queue := tuner.ControllerOptions(controller.Options{}).NewQueue("adjust", DefaultTypedRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+
+	var reconcileDuration time.Duration
+	r := tuner.Reconciler(reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		fakeClock.Advance(reconcileDuration)
+		return reconcile.Result{}, nil
+	}))
+
+	// requests waiting with fast reconciles double the limit up to max
+	queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: "a"}})
+	reconcileDuration = 100 * time.Millisecond
+	_, err := r.Reconcile(context.Background(), reconcile.Request{})
+	g.Expect(err).NotTo(HaveOccurred())
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(2))
+	tuner.adjust()
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(4))
+	g.Expect(testutil.ToFloat64(metrics.QueueDepth.WithLabelValues("adjust"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileConcurrency.WithLabelValues("adjust"))).To(Equal(float64(4)))
+
+	// slow reconciles halve the limit even with requests waiting
+	reconcileDuration = 2 * time.Second
+	_, err = r.Reconcile(context.Background(), reconcile.Request{})
+	g.Expect(err).NotTo(HaveOccurred())
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(2))
+
+	// an idle queue decreases the limit down to min
+	item, _ := queue.Get()
+	queue.Done(item)
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(1))
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(1))
+}
+
+func TestConcurrencyTuner_Reconciler(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tuner := NewConcurrencyTuner("reconciler", ConcurrencyOptions{Min: 1, Max: 2})
+	queue := tuner.ControllerOptions(controller.Options{}).NewQueue("reconciler", DefaultTypedRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+
+	started := make(chan struct{}, 2)
+	unblock := make(chan struct{})
+	r := tuner.Reconciler(reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		started <- struct{}{}
+		<-unblock
+		return reconcile.Result{}, nil
+	}))
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+		}()
+	}
+	g.Eventually(started).Should(Receive())
+	g.Consistently(started, 100*time.Millisecond).ShouldNot(Receive())
+
+	// raising the limit lets the waiting reconcile start
+	queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: "a"}})
+	tuner.adjust()
+	g.Eventually(started).Should(Receive())
+	close(unblock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tuner.lock.Lock()
+	tuner.active = tuner.limit
+	tuner.lock.Unlock()
+	cancel()
+	_, err := tuner.Reconciler(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	})).Reconcile(ctx, reconcile.Request{})
+	g.Expect(err).To(MatchError(context.Canceled))
+}
+
+func TestConcurrencyTuner_Adjust_burst(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tuner := NewConcurrencyTuner("burst", ConcurrencyOptions{Min: 1, Max: 20})
+	queue := tuner.ControllerOptions(controller.Options{}).NewQueue("burst", DefaultTypedRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+
+	// a burst of 10 requests is popped by the workers, one reconciles and nine wait for a slot
+	unblock := make(chan struct{})
+	r := tuner.Reconciler(reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		<-unblock
+		return reconcile.Result{}, nil
+	}))
+	defer close(unblock)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+		}()
+	}
+	g.Eventually(func() int {
+		tuner.lock.Lock()
+		defer tuner.lock.Unlock()
+		return tuner.waiting
+	}).Should(Equal(9))
+	g.Expect(queue.Len()).To(Equal(0))
+
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(2))
+	g.Expect(testutil.ToFloat64(metrics.QueueDepth.WithLabelValues("burst"))).To(Equal(float64(9)))
+	g.Eventually(func() int {
+		tuner.lock.Lock()
+		defer tuner.lock.Unlock()
+		return tuner.waiting
+	}).Should(Equal(8))
+	tuner.adjust()
+	tuner.adjust()
+	g.Expect(tuner.Limit()).To(Equal(8))
+}
+
+func TestConcurrencyTuner_Start(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fakeClock := clock.NewFakeClock(time.Now())
+	tuner := NewConcurrencyTuner("start", ConcurrencyOptions{Min: 1, Max: 2, Interval: time.Second, Clock: fakeClock})
+	queue := tuner.ControllerOptions(controller.Options{}).NewQueue("start", DefaultTypedRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: "a"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- tuner.Start(ctx) }()
+	g.Eventually(fakeClock.HasWaiters).Should(BeTrue())
+	fakeClock.Advance(time.Second)
+	g.Eventually(tuner.Limit).Should(Equal(2))
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
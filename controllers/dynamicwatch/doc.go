@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dynamicwatch lets a reconciler start and stop watches at runtime,
+// for example after a CRD referenced in a spec is installed.
+// Informers are shared and reference counted per kind, a kind is stopped when its
+// last owner unwatches it and restarted when its CRD is deleted and re-registered.
+//
+//	watcher := dynamicwatch.New(mgr.GetCache(), mgr.GetRESTMapper(), logger)
+//	err := ctrl.NewControllerManagedBy(mgr).For(&v1alpha1.Foo{}).
+//		WatchesRawSource(watcher).
+//		Complete(r)
+//
+//	// later, inside Reconcile
+//	err = watcher.Watch(ctx, client.ObjectKeyFromObject(foo).String(), gvk,
+//		handler.EnqueueRequestForOwner(scheme, mapper, &v1alpha1.Foo{}))
+package dynamicwatch
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicwatch
+
+import (
+	"context"
+	"sort"
+
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// eventHandler adapts informer events of a watch to the handlers of its owners.
+// Events are dropped once reg is no longer the active registration of the watch,
+// as informers may still deliver events after their handler was removed
+func (w *Watcher) eventHandler(e *watch, reg *registration) toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			o, ok := obj.(client.Object)
+			if !ok {
+				return
+			}
+			ctx, queue, handlers := w.handlersFor(e, reg)
+			for _, h := range handlers {
+				h.Create(ctx, event.CreateEvent{Object: o}, queue)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			o, ok := oldObj.(client.Object)
+			if !ok {
+				return
+			}
+			n, ok := newObj.(client.Object)
+			if !ok {
+				return
+			}
+			ctx, queue, handlers := w.handlersFor(e, reg)
+			for _, h := range handlers {
+				h.Update(ctx, event.UpdateEvent{ObjectOld: o, ObjectNew: n}, queue)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			var unknown bool
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj, unknown = tombstone.Obj, true
+			}
+			o, ok := obj.(client.Object)
+			if !ok {
+				return
+			}
+			ctx, queue, handlers := w.handlersFor(e, reg)
+			for _, h := range handlers {
+				h.Delete(ctx, event.DeleteEvent{Object: o, DeleteStateUnknown: unknown}, queue)
+			}
+		},
+	}
+}
+
+// handlersFor returns the handlers of a watch ordered by owner,
+// or none if reg is not the active registration of the watch
+func (w *Watcher) handlersFor(e *watch, reg *registration) (context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request], []handler.EventHandler) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if e.active != reg {
+		return nil, nil, nil
+	}
+	owners := make([]string, 0, len(e.handlers))
+	for owner := range e.handlers {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	handlers := make([]handler.EventHandler, 0, len(owners))
+	for _, owner := range owners {
+		handlers = append(handlers, e.handlers[owner])
+	}
+	return w.ctx, w.queue, handlers
+}
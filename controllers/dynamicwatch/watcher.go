@@ -0,0 +1,319 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// CustomResourceDefinitionGVK is the kind watched to detect CRDs being registered or removed
+var CustomResourceDefinitionGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// Watcher starts and stops watches of kinds at runtime and feeds their events
+// into the workqueue of the controller it was added to as a source.
+//
+// One informer is started per kind no matter how many owners watch it, and it is
+// removed once the last owner unwatches the kind. Kinds that are not registered yet
+// are kept pending and started as soon as their CRD is installed, kinds whose CRD is
+// deleted are stopped and restarted when the CRD is registered again.
+//
+// Informers are removed from the given cache when a kind is stopped, so kinds watched
+// dynamically should not be watched statically by controllers sharing the same cache.
+type Watcher struct {
+	informers cache.Informers
+	mapper    meta.RESTMapper
+	logger    *zap.SugaredLogger
+
+	lock    sync.Mutex
+	ctx     context.Context
+	queue   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	watches map[schema.GroupVersionKind]*watch
+}
+
+var _ source.Source = &Watcher{}
+
+// watch holds the owners of a kind and its running informer registration
+type watch struct {
+	gvk      schema.GroupVersionKind
+	handlers map[string]handler.EventHandler
+	// active is nil while the kind is pending or the watcher is not started
+	active *registration
+}
+
+type registration struct {
+	informer cache.Informer
+	handle   toolscache.ResourceEventHandlerRegistration
+}
+
+// New returns a Watcher getting informers from informers, usually the manager cache,
+// and checking kinds are registered using mapper
+func New(informers cache.Informers, mapper meta.RESTMapper, logger *zap.SugaredLogger) *Watcher {
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	return &Watcher{
+		informers: informers,
+		mapper:    mapper,
+		logger:    logger,
+		watches:   map[schema.GroupVersionKind]*watch{},
+	}
+}
+
+// Start implements source.Source. It starts watching CRDs and all kinds
+// requested before the controller was started
+func (w *Watcher) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.queue != nil {
+		return fmt.Errorf("dynamic watcher was already started")
+	}
+	w.ctx, w.queue = ctx, queue
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(CustomResourceDefinitionGVK)
+	informer, err := w.informers.GetInformer(ctx, crd, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("get informer for %s failed: %w", CustomResourceDefinitionGVK.Kind, err)
+	}
+	if _, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    w.crdRegistered,
+		UpdateFunc: func(_, obj interface{}) { w.crdRegistered(obj) },
+		DeleteFunc: w.crdDeleted,
+	}); err != nil {
+		return fmt.Errorf("add event handler for %s failed: %w", CustomResourceDefinitionGVK.Kind, err)
+	}
+
+	var errs []error
+	for _, e := range w.watches {
+		errs = append(errs, w.activate(e))
+	}
+	return errors.Join(errs...)
+}
+
+// String implements fmt.Stringer
+func (w *Watcher) String() string {
+	return "dynamic watcher"
+}
+
+// Watch starts watching gvk on behalf of owner, sending events to h.
+// Calling it again with the same owner and kind replaces the handler.
+// If the kind is not registered yet the watch is kept pending and started once its CRD is installed
+func (w *Watcher) Watch(ctx context.Context, owner string, gvk schema.GroupVersionKind, h handler.EventHandler) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	e, ok := w.watches[gvk]
+	if !ok {
+		e = &watch{gvk: gvk, handlers: map[string]handler.EventHandler{}}
+		w.watches[gvk] = e
+	}
+	e.handlers[owner] = h
+
+	if w.queue == nil || e.active != nil {
+		return nil
+	}
+	return w.activate(e)
+}
+
+// Unwatch removes the watch of gvk by owner, stopping the informer
+// when no other owner watches the kind
+func (w *Watcher) Unwatch(ctx context.Context, owner string, gvk schema.GroupVersionKind) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.unwatch(ctx, owner, gvk)
+}
+
+// UnwatchAll removes all watches by owner, usually called when the owner is deleted
+func (w *Watcher) UnwatchAll(ctx context.Context, owner string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var errs []error
+	for gvk := range w.watches {
+		errs = append(errs, w.unwatch(ctx, owner, gvk))
+	}
+	return errors.Join(errs...)
+}
+
+// IsWatching returns true if gvk has a running informer
+func (w *Watcher) IsWatching(gvk schema.GroupVersionKind) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	e, ok := w.watches[gvk]
+	return ok && e.active != nil
+}
+
+// Owners returns the sorted owners watching gvk, including pending watches
+func (w *Watcher) Owners(gvk schema.GroupVersionKind) []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	e, ok := w.watches[gvk]
+	if !ok {
+		return nil
+	}
+	owners := make([]string, 0, len(e.handlers))
+	for owner := range e.handlers {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+func (w *Watcher) unwatch(ctx context.Context, owner string, gvk schema.GroupVersionKind) error {
+	e, ok := w.watches[gvk]
+	if !ok {
+		return nil
+	}
+	if _, ok := e.handlers[owner]; !ok {
+		return nil
+	}
+	delete(e.handlers, owner)
+	if len(e.handlers) > 0 {
+		return nil
+	}
+	delete(w.watches, gvk)
+	return w.deactivate(ctx, e)
+}
+
+// activate starts the informer of a watch, keeping it pending if its kind is not registered yet
+func (w *Watcher) activate(e *watch) error {
+	if e.active != nil {
+		return nil
+	}
+	if _, err := w.mapper.RESTMapping(e.gvk.GroupKind(), e.gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			w.logger.Debugw("kind is not registered, waiting for its CRD", "gvk", e.gvk.String())
+			return nil
+		}
+		return fmt.Errorf("get rest mapping for %s failed: %w", e.gvk, err)
+	}
+
+	informer, err := w.informers.GetInformer(w.ctx, newObject(e.gvk), cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("get informer for %s failed: %w", e.gvk, err)
+	}
+	reg := &registration{informer: informer}
+	if reg.handle, err = informer.AddEventHandler(w.eventHandler(e, reg)); err != nil {
+		return fmt.Errorf("add event handler for %s failed: %w", e.gvk, err)
+	}
+	e.active = reg
+	w.logger.Infow("started watching", "gvk", e.gvk.String())
+	return nil
+}
+
+// deactivate stops the informer of a watch, the watch itself is kept by the caller if still referenced
+func (w *Watcher) deactivate(ctx context.Context, e *watch) error {
+	reg := e.active
+	if reg == nil {
+		return nil
+	}
+	e.active = nil
+
+	var errs []error
+	if reg.handle != nil {
+		errs = append(errs, reg.informer.RemoveEventHandler(reg.handle))
+	}
+	errs = append(errs, w.informers.RemoveInformer(ctx, newObject(e.gvk)))
+	w.logger.Infow("stopped watching", "gvk", e.gvk.String())
+	return errors.Join(errs...)
+}
+
+// crdRegistered starts pending watches of the kind defined by a CRD
+func (w *Watcher) crdRegistered(obj interface{}) {
+	gk, ok := crdGroupKind(obj)
+	if !ok {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, e := range w.watches {
+		if e.active != nil || e.gvk.GroupKind() != gk {
+			continue
+		}
+		if err := w.activate(e); err != nil {
+			w.logger.Errorw("start watching after CRD registration failed", "gvk", e.gvk.String(), "err", err)
+		}
+	}
+}
+
+// crdDeleted stops watches of the kind defined by a CRD, keeping them pending until it is registered again
+func (w *Watcher) crdDeleted(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	gk, ok := crdGroupKind(obj)
+	if !ok {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, e := range w.watches {
+		if e.active == nil || e.gvk.GroupKind() != gk {
+			continue
+		}
+		if err := w.deactivate(w.ctx, e); err != nil {
+			w.logger.Errorw("stop watching after CRD deletion failed", "gvk", e.gvk.String(), "err", err)
+		}
+	}
+}
+
+// crdGroupKind returns the group and kind defined by a CRD object
+func crdGroupKind(obj interface{}) (schema.GroupKind, bool) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return schema.GroupKind{}, false
+	}
+	group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+	if kind == "" {
+		return schema.GroupKind{}, false
+	}
+	return schema.GroupKind{Group: group, Kind: kind}, true
+}
+
+func newObject(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
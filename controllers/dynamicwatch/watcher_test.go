@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicwatch
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var fooGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+
+// counter is an event handler counting create events
+type counter struct {
+	lock  sync.Mutex
+	count int
+}
+
+func (c *counter) handler() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			c.count++
+		},
+	}
+}
+
+func (c *counter) get() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.count
+}
+
+func newCRD(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	crd := newObject(CustomResourceDefinitionGVK)
+	crd.SetName("foos." + gvk.Group)
+	_ = unstructured.SetNestedField(crd.Object, gvk.Group, "spec", "group")
+	_ = unstructured.SetNestedField(crd.Object, gvk.Kind, "spec", "names", "kind")
+	return crd
+}
+
+func newFoo(name string) *unstructured.Unstructured {
+	foo := newObject(fooGVK)
+	foo.SetNamespace("default")
+	foo.SetName(name)
+	return foo
+}
+
+func setup(t *testing.T, registered bool) (context.Context, *Watcher, *informertest.FakeInformers, *meta.DefaultRESTMapper) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	informers := &informertest.FakeInformers{}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{fooGVK.GroupVersion()})
+	if registered {
+		mapper.Add(fooGVK, meta.RESTScopeNamespace)
+	}
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+
+	w := New(informers, mapper, nil)
+	g.Expect(w.Start(ctx, queue)).To(Succeed())
+	return ctx, w, informers, mapper
+}
+
+func fakeInformer(g *WithT, ctx context.Context, informers *informertest.FakeInformers, gvk schema.GroupVersionKind) *controllertest.FakeInformer {
+	informer, err := informers.FakeInformerFor(ctx, newObject(gvk))
+	g.Expect(err).To(Succeed())
+	return informer
+}
+
+func TestWatcherDeduplication(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, w, informers, _ := setup(t, true)
+
+	first, second := &counter{}, &counter{}
+	g.Expect(w.Watch(ctx, "default/a", fooGVK, first.handler())).To(Succeed())
+	g.Expect(w.Watch(ctx, "default/b", fooGVK, second.handler())).To(Succeed())
+	g.Expect(w.Watch(ctx, "default/b", fooGVK, second.handler())).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeTrue())
+	g.Expect(w.Owners(fooGVK)).To(Equal([]string{"default/a", "default/b"}))
+	g.Expect(informers.InformersByGVK).To(HaveLen(2), "one informer for CRDs and one for the kind")
+
+	fakeInformer(g, ctx, informers, fooGVK).Add(newFoo("foo"))
+	g.Expect(first.get()).To(Equal(1))
+	g.Expect(second.get()).To(Equal(1), "watching twice registers a single handler")
+
+	g.Expect(w.Unwatch(ctx, "default/a", fooGVK)).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeTrue())
+	g.Expect(informers.InformersByGVK).To(HaveKey(fooGVK))
+
+	g.Expect(w.Unwatch(ctx, "default/b", fooGVK)).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse())
+	g.Expect(w.Owners(fooGVK)).To(BeEmpty())
+	g.Expect(informers.InformersByGVK).NotTo(HaveKey(fooGVK), "informer is removed with the last reference")
+
+	g.Expect(w.Unwatch(ctx, "default/b", fooGVK)).To(Succeed(), "unwatching twice is a no-op")
+}
+
+func TestWatcherWatchBeforeStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	informers := &informertest.FakeInformers{}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{fooGVK.GroupVersion()})
+	mapper.Add(fooGVK, meta.RESTScopeNamespace)
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+
+	w := New(informers, mapper, nil)
+	g.Expect(w.Watch(ctx, "default/a", fooGVK, &handler.EnqueueRequestForObject{})).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse())
+
+	g.Expect(w.Start(ctx, queue)).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeTrue())
+	g.Expect(w.Start(ctx, queue)).NotTo(Succeed())
+
+	fakeInformer(g, ctx, informers, fooGVK).Add(newFoo("foo"))
+	g.Expect(queue.Len()).To(Equal(1))
+}
+
+func TestWatcherCRDLifecycle(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, w, informers, mapper := setup(t, false)
+
+	c := &counter{}
+	g.Expect(w.Watch(ctx, "default/a", fooGVK, c.handler())).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse(), "kind is pending until its CRD is installed")
+	g.Expect(w.Owners(fooGVK)).To(Equal([]string{"default/a"}))
+
+	crds := fakeInformer(g, ctx, informers, CustomResourceDefinitionGVK)
+	crd := newCRD(fooGVK)
+	crds.Add(crd)
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse(), "kind is still unknown to the mapper")
+
+	mapper.Add(fooGVK, meta.RESTScopeNamespace)
+	crds.Update(crd, crd)
+	g.Expect(w.IsWatching(fooGVK)).To(BeTrue())
+
+	old := fakeInformer(g, ctx, informers, fooGVK)
+	old.Add(newFoo("foo"))
+	g.Expect(c.get()).To(Equal(1))
+
+	crds.Delete(crd)
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse())
+	g.Expect(w.Owners(fooGVK)).To(Equal([]string{"default/a"}), "owners are kept while the CRD is missing")
+	old.Add(newFoo("bar"))
+	g.Expect(c.get()).To(Equal(1), "events of a stopped informer are dropped")
+
+	crds.Add(crd)
+	g.Expect(w.IsWatching(fooGVK)).To(BeTrue(), "watch restarts when the CRD is registered again")
+	fakeInformer(g, ctx, informers, fooGVK).Add(newFoo("baz"))
+	g.Expect(c.get()).To(Equal(2))
+
+	g.Expect(w.UnwatchAll(ctx, "default/a")).To(Succeed())
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse())
+	crds.Add(crd)
+	g.Expect(w.IsWatching(fooGVK)).To(BeFalse(), "unwatched kinds are not restarted")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+// RecordConditionTransition records an event for the transition of a condition of object
+// using the reason of the condition: a normal event when it becomes True and a warning
+// event when it becomes False. Unknown conditions and updates that are not transitions are ignored
+func (r *Recorder) RecordConditionTransition(object runtime.Object, previous *metav1.Condition, current metav1.Condition) {
+	if !metav1alpha1.IsConditionTransition(previous, current) {
+		return
+	}
+	var eventtype string
+	switch current.Status {
+	case metav1.ConditionTrue:
+		eventtype = corev1.EventTypeNormal
+	case metav1.ConditionFalse:
+		eventtype = corev1.EventTypeWarning
+	default:
+		return
+	}
+	if current.Message == "" {
+		r.Eventf(object, eventtype, current.Reason, "%s is %s", current.Type, current.Status)
+		return
+	}
+	r.Eventf(object, eventtype, current.Reason, "%s is %s: %s", current.Type, current.Status, current.Message)
+}
+
+// ConditionTransitions returns a function recording the condition transitions of object,
+// to be registered with ConditionManager.OnTransition
+//
+//	manager := metav1alpha1.NewConditionManager(&obj.Status.Conditions, obj.Generation).
+//		OnTransition(recorder.ConditionTransitions(obj))
+func (r *Recorder) ConditionTransitions(object runtime.Object) metav1alpha1.ConditionTransitionFunc {
+	return func(previous *metav1.Condition, current metav1.Condition) {
+		r.RecordConditionTransition(object, previous, current)
+	}
+}
+
+// RecordConditionChanges records the transitions from the before to the after conditions of object,
+// e.g. the conditions of the object before and after reconciling it
+func (r *Recorder) RecordConditionChanges(object runtime.Object, before, after []metav1.Condition) {
+	for _, current := range after {
+		var previous *metav1.Condition
+		for i := range before {
+			if before[i].Type == current.Type {
+				previous = &before[i]
+				break
+			}
+		}
+		r.RecordConditionTransition(object, previous, current)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+func TestRecorder_ConditionTransitions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(10)
+	recorder := NewRecorder(fake, WithAnnotationKeys())
+	obj := newObject("a")
+
+	conditions := []metav1.Condition{}
+	m := metav1alpha1.NewConditionManager(&conditions, 1).OnTransition(recorder.ConditionTransitions(obj))
+	m.MarkUnknown(metav1alpha1.ConditionReady, "Reconciling", "")
+	m.MarkFalse(metav1alpha1.ConditionReady, "DeploymentFailed", "image %q not found", "app:v1")
+	m.MarkFalse(metav1alpha1.ConditionReady, "DeploymentFailed", "image %q not found", "app:v1")
+	m.MarkTrue(metav1alpha1.ConditionReady, "Ready", "")
+	m.MarkFalse(metav1alpha1.ConditionReady, "DeploymentFailed", "image %q not found", "app:v1")
+
+	g.Expect(drain(fake)).To(Equal([]string{
+		`Warning DeploymentFailed Ready is False: image "app:v1" not found`,
+		"Normal Ready Ready is True",
+	}), "unknown conditions are ignored and duplicates deduplicated")
+}
+
+func TestRecorder_RecordConditionChanges(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(10)
+	recorder := NewRecorder(fake, WithAnnotationKeys())
+	obj := newObject("a")
+
+	before := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Failed"},
+		{Type: "Synced", Status: metav1.ConditionTrue, Reason: "Synced"},
+	}
+	after := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready", Message: "all replicas available"},
+		{Type: "Synced", Status: metav1.ConditionTrue, Reason: "Synced", Message: "changed message"},
+		{Type: "Degraded", Status: metav1.ConditionFalse, Reason: "Healthy"},
+	}
+	recorder.RecordConditionChanges(obj, before, after)
+	g.Expect(drain(fake)).To(Equal([]string{
+		"Normal Ready Ready is True: all replicas available",
+		"Warning Healthy Degraded is False",
+	}))
+}
@@ -0,0 +1,22 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events wraps record.EventRecorder with typed reconcile event helpers,
+// deduplication of repeated events, rate limiting, and automatic attachment
+// of object annotations such as cpaas.io/updatedBy to recorded events.
+// Condition transitions made by a ConditionManager can be recorded as events,
+// normal when a condition becomes True and warnings when it becomes False.
+package events
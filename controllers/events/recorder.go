@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+// Reasons of the reconcile events
+const (
+	// ReasonReconcileStarted reconcile of the object started
+	ReasonReconcileStarted = "ReconcileStarted"
+	// ReasonReconcileSucceeded reconcile of the object succeeded
+	ReasonReconcileSucceeded = "ReconcileSucceeded"
+	// ReasonReconcileFailed reconcile of the object failed
+	ReasonReconcileFailed = "ReconcileFailed"
+)
+
+// DefaultDedupWindow default window in which identical events are recorded only once
+const DefaultDedupWindow = 5 * time.Minute
+
+// DefaultAnnotationKeys object annotations attached to recorded events by default
+var DefaultAnnotationKeys = []string{
+	metav1alpha1.UpdatedByAnnotationKey,
+	metav1alpha1.TriggeredByAnnotationKey,
+}
+
+// Recorder wraps a record.EventRecorder deduplicating identical events
+// within a window, optionally rate limiting events and attaching object
+// annotations to every event.
+// Recorder implements record.EventRecorder so it can replace the wrapped recorder.
+type Recorder struct {
+	record.EventRecorder
+
+	clock          clock.PassiveClock
+	dedupWindow    time.Duration
+	limiter        *rate.Limiter
+	annotationKeys []string
+
+	lock sync.Mutex
+	seen map[string]time.Time
+}
+
+var _ record.EventRecorder = &Recorder{}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithDedupWindow sets the window in which identical events are recorded once,
+// zero or negative disables deduplication
+func WithDedupWindow(window time.Duration) Option {
+	return func(r *Recorder) {
+		r.dedupWindow = window
+	}
+}
+
+// WithRateLimit limits the events recorded to qps with burst,
+// events over the limit are dropped
+func WithRateLimit(qps float64, burst int) Option {
+	return func(r *Recorder) {
+		r.limiter = rate.NewLimiter(rate.Limit(qps), burst)
+	}
+}
+
+// WithAnnotationKeys sets the object annotations attached to events
+func WithAnnotationKeys(keys ...string) Option {
+	return func(r *Recorder) {
+		r.annotationKeys = keys
+	}
+}
+
+// WithClock sets the clock used for deduplication
+func WithClock(clock clock.PassiveClock) Option {
+	return func(r *Recorder) {
+		r.clock = clock
+	}
+}
+
+// NewRecorder wraps recorder, deduplicating events within DefaultDedupWindow
+// and attaching DefaultAnnotationKeys by default
+func NewRecorder(recorder record.EventRecorder, opts ...Option) *Recorder {
+	r := &Recorder{
+		EventRecorder:  recorder,
+		clock:          clock.RealClock{},
+		dedupWindow:    DefaultDedupWindow,
+		annotationKeys: DefaultAnnotationKeys,
+		seen:           map[string]time.Time{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RecordReconcileStart records a normal event when the reconcile of object starts
+func (r *Recorder) RecordReconcileStart(object runtime.Object) {
+	r.Event(object, corev1.EventTypeNormal, ReasonReconcileStarted, "reconcile started")
+}
+
+// RecordReconcileSuccess records a normal event when the reconcile of object succeeds
+func (r *Recorder) RecordReconcileSuccess(object runtime.Object) {
+	r.Event(object, corev1.EventTypeNormal, ReasonReconcileSucceeded, "reconcile succeeded")
+}
+
+// RecordReconcileFailure records a warning event with err when the reconcile of object fails
+func (r *Recorder) RecordReconcileFailure(object runtime.Object, err error) {
+	r.Eventf(object, corev1.EventTypeWarning, ReasonReconcileFailed, "reconcile failed: %v", err)
+}
+
+// Event records an event unless it is a duplicate or over the rate limit
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, "%s", message)
+}
+
+// Eventf records an event unless it is a duplicate or over the rate limit
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, messageFmt, args...)
+}
+
+// AnnotatedEventf records an event with annotations merged over the object annotations
+// configured by WithAnnotationKeys, unless it is a duplicate or over the rate limit
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if !r.allow(object, eventtype, reason, message) {
+		return
+	}
+	annotations = r.annotations(object, annotations)
+	if len(annotations) == 0 {
+		r.EventRecorder.Event(object, eventtype, reason, message)
+		return
+	}
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// allow returns true if the event is not a duplicate and within the rate limit
+func (r *Recorder) allow(object runtime.Object, eventtype, reason, message string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	key := ""
+	if r.dedupWindow > 0 {
+		for k, t := range r.seen {
+			if now.Sub(t) >= r.dedupWindow {
+				delete(r.seen, k)
+			}
+		}
+		key = eventKey(object, eventtype, reason, message)
+		if _, ok := r.seen[key]; ok {
+			return false
+		}
+	}
+	if r.limiter != nil && !r.limiter.AllowN(now, 1) {
+		return false
+	}
+	if key != "" {
+		r.seen[key] = now
+	}
+	return true
+}
+
+// annotations returns the configured object annotations merged with extra
+func (r *Recorder) annotations(object runtime.Object, extra map[string]string) map[string]string {
+	var result map[string]string
+	if accessor, err := meta.Accessor(object); err == nil {
+		objAnnotations := accessor.GetAnnotations()
+		for _, key := range r.annotationKeys {
+			if value, ok := objAnnotations[key]; ok {
+				if result == nil {
+					result = map[string]string{}
+				}
+				result[key] = value
+			}
+		}
+	}
+	return metav1alpha1.CopyMapStringString(extra, result)
+}
+
+func eventKey(object runtime.Object, eventtype, reason, message string) string {
+	id := fmt.Sprintf("%T", object)
+	if accessor, err := meta.Accessor(object); err == nil {
+		id = fmt.Sprintf("%s/%s/%s/%s", id, accessor.GetNamespace(), accessor.GetName(), accessor.GetUID())
+	}
+	return id + "\x00" + eventtype + "\x00" + reason + "\x00" + message
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+func drain(fake *record.FakeRecorder) (events []string) {
+	for {
+		select {
+		case e := <-fake.Events:
+			events = append(events, e)
+		default:
+			return
+		}
+	}
+}
+
+func newObject(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      name,
+		Annotations: map[string]string{
+			metav1alpha1.UpdatedByAnnotationKey: `{"user":{"kind":"User","name":"admin"}}`,
+			"other":                             "value",
+		},
+	}}
+}
+
+func TestRecorder_reconcileEvents(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(10)
+	recorder := NewRecorder(fake)
+	obj := newObject("a")
+
+	recorder.RecordReconcileStart(obj)
+	recorder.RecordReconcileSuccess(obj)
+	recorder.RecordReconcileFailure(obj, errors.New("boom"))
+
+	events := drain(fake)
+	g.Expect(events).To(HaveLen(3))
+	g.Expect(events[0]).To(HavePrefix("Normal ReconcileStarted reconcile started"))
+	g.Expect(events[0]).To(ContainSubstring(metav1alpha1.UpdatedByAnnotationKey))
+	g.Expect(events[0]).NotTo(ContainSubstring("other"))
+	g.Expect(events[1]).To(HavePrefix("Normal ReconcileSucceeded reconcile succeeded"))
+	g.Expect(events[2]).To(HavePrefix("Warning ReconcileFailed reconcile failed: boom"))
+}
+
+func TestRecorder_dedup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(10)
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	recorder := NewRecorder(fake, WithClock(clock), WithDedupWindow(time.Minute), WithAnnotationKeys())
+
+	recorder.RecordReconcileFailure(newObject("a"), errors.New("boom"))
+	recorder.RecordReconcileFailure(newObject("a"), errors.New("boom"))
+	recorder.RecordReconcileFailure(newObject("a"), errors.New("other"))
+	recorder.RecordReconcileFailure(newObject("b"), errors.New("boom"))
+	g.Expect(drain(fake)).To(Equal([]string{
+		"Warning ReconcileFailed reconcile failed: boom",
+		"Warning ReconcileFailed reconcile failed: other",
+		"Warning ReconcileFailed reconcile failed: boom",
+	}))
+
+	clock.SetTime(clock.Now().Add(time.Minute))
+	recorder.RecordReconcileFailure(newObject("a"), errors.New("boom"))
+	g.Expect(drain(fake)).To(HaveLen(1))
+}
+
+func TestRecorder_rateLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(10)
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	recorder := NewRecorder(fake, WithClock(clock), WithDedupWindow(0), WithRateLimit(1, 2))
+
+	for i := 0; i < 5; i++ {
+		recorder.Event(newObject("a"), corev1.EventTypeNormal, "Test", "message")
+	}
+	g.Expect(drain(fake)).To(HaveLen(2))
+
+	clock.SetTime(clock.Now().Add(time.Second))
+	recorder.Event(newObject("a"), corev1.EventTypeNormal, "Test", "message")
+	recorder.Event(newObject("a"), corev1.EventTypeNormal, "Test", "message")
+	g.Expect(drain(fake)).To(HaveLen(1))
+}
+
+func TestRecorder_annotatedEvent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(10)
+	recorder := NewRecorder(fake, WithAnnotationKeys("other"))
+
+	recorder.AnnotatedEventf(newObject("a"), map[string]string{"extra": "1"}, corev1.EventTypeNormal, "Test", "hello %s", "world")
+	events := drain(fake)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(Equal("Normal Test hello world map[extra:1 other:value]"))
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expiry deletes temporary objects, e.g. preview environments or debug
+// resources, once the time of their cpaas.io/expiresAt annotation has passed.
+// The Collector scans an allow-list of kinds on a jittered interval using
+// metadata-only lists, only while its manager is the leader, and records an
+// event for each object deleted or with an invalid annotation.
+//
+//	collector := expiry.New(mgr.GetClient(), []schema.GroupVersionKind{
+//		corev1.SchemeGroupVersion.WithKind("Namespace"),
+//		v1alpha1.GroupVersion.WithKind("PreviewEnvironment"),
+//	}, expiry.WithInterval(10*time.Minute), expiry.WithRecorder(mgr.GetEventRecorderFor("expiry")))
+//	err := mgr.Add(collector)
+//
+// Objects are marked with metav1alpha1.SetExpiresAt.
+package expiry
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+const (
+	// DefaultInterval is the default interval between scans
+	DefaultInterval = 5 * time.Minute
+	// DefaultJitter is the default maximum fraction of the interval added to each wait
+	DefaultJitter = 0.1
+
+	// ExpiredReason reason of the events of deleted objects
+	ExpiredReason = "Expired"
+	// InvalidExpiryReason reason of the events of objects with an invalid expiry annotation
+	InvalidExpiryReason = "InvalidExpiry"
+)
+
+// Collector deletes the objects of allowed kinds past their expiry time
+type Collector struct {
+	client    client.Client
+	kinds     []schema.GroupVersionKind
+	interval  time.Duration
+	jitter    float64
+	namespace string
+	recorder  record.EventRecorder
+	clock     clock.Clock
+	logger    *zap.SugaredLogger
+}
+
+var _ manager.LeaderElectionRunnable = &Collector{}
+
+// Option configures a Collector
+type Option func(*Collector)
+
+// WithInterval sets the interval between scans, defaults to DefaultInterval
+func WithInterval(interval time.Duration) Option {
+	return func(c *Collector) {
+		c.interval = interval
+	}
+}
+
+// WithJitter sets the maximum fraction of the interval added to each wait, defaults to DefaultJitter
+func WithJitter(factor float64) Option {
+	return func(c *Collector) {
+		c.jitter = factor
+	}
+}
+
+// WithNamespace only deletes objects in namespace
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithRecorder records an event for each object deleted or with an invalid expiry annotation
+func WithRecorder(recorder record.EventRecorder) Option {
+	return func(c *Collector) {
+		c.recorder = recorder
+	}
+}
+
+// WithClock sets the clock used to compare expiry times and wait between scans
+func WithClock(clock clock.Clock) Option {
+	return func(c *Collector) {
+		c.clock = clock
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(c *Collector) {
+		c.logger = logger
+	}
+}
+
+// New returns a Collector deleting expired objects of kinds with clt
+func New(clt client.Client, kinds []schema.GroupVersionKind, opts ...Option) *Collector {
+	c := &Collector{
+		client:   clt,
+		kinds:    kinds,
+		interval: DefaultInterval,
+		jitter:   DefaultJitter,
+		clock:    clock.RealClock{},
+		logger:   zap.NewNop().Sugar(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader deletes objects
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable, scanning when started and then every interval until ctx is done
+func (c *Collector) Start(ctx context.Context) error {
+	if c.interval <= 0 {
+		return fmt.Errorf("expiry interval must be positive, got %s", c.interval)
+	}
+	for {
+		deleted, err := c.Scan(ctx)
+		if err != nil {
+			c.logger.Errorw("expiry scan failed", "err", err)
+		} else {
+			c.logger.Debugw("expiry scan deleted objects", "count", deleted)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.clock.After(wait.Jitter(c.interval, c.jitter)):
+		}
+	}
+}
+
+// Scan deletes the expired objects of all kinds, returning how many were deleted.
+// A failing kind does not stop the others
+func (c *Collector) Scan(ctx context.Context) (deleted int, err error) {
+	var errs []error
+	for _, gvk := range c.kinds {
+		count, err := c.scanKind(ctx, gvk)
+		deleted += count
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return deleted, utilerrors.NewAggregate(errs)
+}
+
+func (c *Collector) scanKind(ctx context.Context, gvk schema.GroupVersionKind) (deleted int, err error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	var opts []client.ListOption
+	if c.namespace != "" {
+		opts = append(opts, client.InNamespace(c.namespace))
+	}
+	if err = c.client.List(ctx, list, opts...); err != nil {
+		return 0, fmt.Errorf("list %s failed: %w", gvk.Kind, err)
+	}
+
+	now := c.clock.Now()
+	var errs []error
+	for i := range list.Items {
+		obj := &list.Items[i]
+		obj.SetGroupVersionKind(gvk)
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		expired, err := metav1alpha1.IsExpired(obj, now)
+		if err != nil {
+			c.event(obj, corev1.EventTypeWarning, InvalidExpiryReason, err.Error())
+			continue
+		}
+		if !expired {
+			continue
+		}
+		// the preconditions avoid deleting an object whose expiry was extended after listing
+		uid, resourceVersion := obj.GetUID(), obj.GetResourceVersion()
+		err = c.client.Delete(ctx, obj,
+			client.Preconditions{UID: &uid, ResourceVersion: &resourceVersion},
+			client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete %s %s failed: %w", gvk.Kind, client.ObjectKeyFromObject(obj), err))
+			continue
+		}
+		deleted++
+		c.logger.Infow("deleted expired object", "kind", gvk.Kind, "object", client.ObjectKeyFromObject(obj))
+		c.event(obj, corev1.EventTypeNormal, ExpiredReason, fmt.Sprintf("Deleted as it expired at %s", obj.GetAnnotations()[metav1alpha1.ExpiresAtAnnotationKey]))
+	}
+	return deleted, utilerrors.NewAggregate(errs)
+}
+
+func (c *Collector) event(obj client.Object, eventtype, reason, message string) {
+	if c.recorder != nil {
+		c.recorder.Event(obj, eventtype, reason, message)
+	}
+}
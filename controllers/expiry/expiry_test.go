@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+var now = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func configMap(namespace, name, expiresAt string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if expiresAt != "" {
+		cm.Annotations = map[string]string{metav1alpha1.ExpiresAtAnnotationKey: expiresAt}
+	}
+	return cm
+}
+
+func newClient() client.Client {
+	return fake.NewClientBuilder().WithObjects(
+		configMap("default", "expired", "2025-01-02T03:00:00Z"),
+		configMap("default", "expires-now", "2025-01-02T03:04:05Z"),
+		configMap("default", "later", "2025-01-02T04:00:00Z"),
+		configMap("default", "invalid", "tomorrow"),
+		configMap("default", "kept", ""),
+		configMap("other", "expired", "2025-01-02T03:00:00Z"),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "expired",
+			Annotations: map[string]string{metav1alpha1.ExpiresAtAnnotationKey: "2025-01-02T03:00:00Z"}}},
+	).Build()
+}
+
+func exists(g *WithT, clt client.Client, obj client.Object, namespace, name string) bool {
+	err := clt.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, obj)
+	g.Expect(client.IgnoreNotFound(err)).To(Succeed())
+	return !apierrors.IsNotFound(err)
+}
+
+func TestCollector_Scan(t *testing.T) {
+	g := NewGomegaWithT(t)
+	clt := newClient()
+	recorder := record.NewFakeRecorder(10)
+	c := New(clt, []schema.GroupVersionKind{corev1.SchemeGroupVersion.WithKind("ConfigMap")},
+		WithNamespace("default"), WithRecorder(recorder), WithClock(clock.NewFakeClock(now)))
+
+	deleted, err := c.Scan(context.Background())
+	g.Expect(err).To(BeNil())
+	g.Expect(deleted).To(Equal(2))
+
+	for name, expected := range map[string]bool{"expired": false, "expires-now": false, "later": true, "invalid": true, "kept": true} {
+		g.Expect(exists(g, clt, &corev1.ConfigMap{}, "default", name)).To(Equal(expected), name)
+	}
+	g.Expect(exists(g, clt, &corev1.ConfigMap{}, "other", "expired")).To(BeTrue(), "other namespaces are not scanned")
+	g.Expect(exists(g, clt, &corev1.Secret{}, "default", "expired")).To(BeTrue(), "only allowed kinds are deleted")
+
+	events := []string{}
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	g.Expect(events).To(ConsistOf(
+		"Normal Expired Deleted as it expired at 2025-01-02T03:00:00Z",
+		"Normal Expired Deleted as it expired at 2025-01-02T03:04:05Z",
+		`Warning InvalidExpiry invalid cpaas.io/expiresAt annotation "tomorrow": parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`,
+	))
+}
+
+func TestCollector_Start(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clt := newClient()
+	fakeClock := clock.NewFakeClock(now.Add(-time.Hour))
+	c := New(clt, []schema.GroupVersionKind{corev1.SchemeGroupVersion.WithKind("ConfigMap")},
+		WithInterval(time.Hour), WithClock(fakeClock))
+	g.Expect(c.NeedLeaderElection()).To(BeTrue())
+
+	done := make(chan error)
+	go func() { done <- c.Start(ctx) }()
+
+	// nothing expired at the first scan
+	g.Eventually(fakeClock.HasWaiters).Should(BeTrue())
+	g.Expect(exists(g, clt, &corev1.ConfigMap{}, "default", "expired")).To(BeTrue())
+
+	fakeClock.Advance(time.Hour + time.Hour/10)
+	g.Eventually(func() bool { return exists(g, clt, &corev1.ConfigMap{}, "other", "expired") }).Should(BeFalse())
+	g.Expect(exists(g, clt, &corev1.ConfigMap{}, "default", "expired")).To(BeFalse())
+	g.Expect(exists(g, clt, &corev1.ConfigMap{}, "default", "later")).To(BeTrue())
+
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+	g.Expect(New(clt, nil, WithInterval(0)).Start(ctx)).To(MatchError(ContainSubstring("expiry interval must be positive")))
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// FieldChangedPredicate implements an update predicate that only triggers
+// when any of the given fields differ between the old and the new object.
+// Fields are JSONPath-like expressions, e.g. `.spec.replicas` or `.data.token`,
+// keys containing dots can be quoted in brackets, e.g. `.metadata.labels['app.kubernetes.io/name']`.
+// Works with typed and unstructured objects alike. If a field cannot be read
+// the event is accepted so no change is missed.
+type FieldChangedPredicate struct {
+	// Fields to compare between old and new objects
+	Fields []string
+	predicate.Funcs
+}
+
+// NewFieldChangedPredicate returns a FieldChangedPredicate after validating its fields
+func NewFieldChangedPredicate(fields ...string) (FieldChangedPredicate, error) {
+	for _, field := range fields {
+		if _, err := ParseFieldPath(field); err != nil {
+			return FieldChangedPredicate{}, err
+		}
+	}
+	return FieldChangedPredicate{Fields: fields}, nil
+}
+
+// Update implements Predicate interface for update events.
+// It checks if any of the fields have different values between old and new objects.
+func (p FieldChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldContent, err := toUnstructuredContent(e.ObjectOld)
+	if err != nil {
+		return true
+	}
+	newContent, err := toUnstructuredContent(e.ObjectNew)
+	if err != nil {
+		return true
+	}
+
+	for _, field := range p.Fields {
+		path, err := ParseFieldPath(field)
+		if err != nil {
+			return true
+		}
+		oldValue, _, oldErr := unstructured.NestedFieldNoCopy(oldContent, path...)
+		newValue, _, newErr := unstructured.NestedFieldNoCopy(newContent, path...)
+		if oldErr != nil || newErr != nil {
+			return true
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFieldPath parses a JSONPath-like field expression into its path segments,
+// e.g. `.metadata.labels['app.kubernetes.io/name']` returns
+// [metadata labels app.kubernetes.io/name]
+func ParseFieldPath(field string) ([]string, error) {
+	path := []string{}
+	rest := strings.TrimSpace(field)
+	if rest == "" || rest == "." {
+		return nil, fmt.Errorf("empty field path")
+	}
+
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid field path %q: empty segment", field)
+			}
+			path = append(path, rest[:end])
+			rest = rest[end:]
+		case '[':
+			if len(rest) < 4 || (rest[1] != '\'' && rest[1] != '"') {
+				return nil, fmt.Errorf("invalid field path %q: brackets must contain a quoted key", field)
+			}
+			quote := rest[1]
+			end := strings.IndexByte(rest[2:], quote)
+			if end < 0 || len(rest) < end+4 || rest[end+3] != ']' {
+				return nil, fmt.Errorf("invalid field path %q: unterminated bracket", field)
+			}
+			path = append(path, rest[2:end+2])
+			rest = rest[end+4:]
+		default:
+			if len(path) > 0 {
+				return nil, fmt.Errorf("invalid field path %q: unexpected %q", field, rest[0])
+			}
+			// allow paths without the leading dot, e.g. spec.replicas
+			rest = "." + rest
+		}
+	}
+	return path, nil
+}
+
+// toUnstructuredContent returns the unstructured content of an object
+func toUnstructuredContent(obj runtime.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
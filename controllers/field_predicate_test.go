@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	var data = []struct {
+		field    string
+		expected []string
+		errored  bool
+	}{
+		{field: ".spec.replicas", expected: []string{"spec", "replicas"}},
+		{field: "spec.replicas", expected: []string{"spec", "replicas"}},
+		{field: ".metadata.labels['app.kubernetes.io/name']", expected: []string{"metadata", "labels", "app.kubernetes.io/name"}},
+		{field: `.data["a.b"].c`, expected: []string{"data", "a.b", "c"}},
+		{field: "", errored: true},
+		{field: ".spec..replicas", errored: true},
+		{field: ".data[a]", errored: true},
+		{field: ".data['a'", errored: true},
+	}
+
+	for _, item := range data {
+		t.Run(item.field, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			path, err := ParseFieldPath(item.field)
+			if item.errored {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(path).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestFieldChangedPredicate(t *testing.T) {
+	deploy := func(replicas int32, image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"app.kubernetes.io/name": image}},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: image}}}},
+			},
+		}
+	}
+	toUnstructured := func(obj client.Object) client.Object {
+		content, _ := toUnstructuredContent(obj)
+		return &unstructured.Unstructured{Object: content}
+	}
+
+	var data = []struct {
+		desc   string
+		fields []string
+		old    client.Object
+		new    client.Object
+
+		expected bool
+	}{
+		{desc: "field changed", fields: []string{".spec.replicas"}, old: deploy(1, "a"), new: deploy(2, "a"), expected: true},
+		{desc: "other field changed", fields: []string{".spec.replicas"}, old: deploy(1, "a"), new: deploy(1, "b"), expected: false},
+		{desc: "nested struct changed", fields: []string{".spec.template"}, old: deploy(1, "a"), new: deploy(1, "b"), expected: true},
+		{desc: "quoted key changed", fields: []string{".metadata.labels['app.kubernetes.io/name']"}, old: deploy(1, "a"), new: deploy(1, "b"), expected: true},
+		{desc: "unstructured objects", fields: []string{".spec.replicas"}, old: toUnstructured(deploy(1, "a")), new: toUnstructured(deploy(2, "a")), expected: true},
+		{desc: "missing field on both", fields: []string{".status.missing"}, old: deploy(1, "a"), new: deploy(2, "a"), expected: false},
+		{desc: "field added", fields: []string{".data.token"}, old: &corev1.ConfigMap{}, new: &corev1.ConfigMap{Data: map[string]string{"token": "a"}}, expected: true},
+		{desc: "invalid field", fields: []string{".data[x]"}, old: &corev1.ConfigMap{}, new: &corev1.ConfigMap{}, expected: true},
+		{desc: "nil objects", fields: []string{".data"}, expected: false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			p := FieldChangedPredicate{Fields: item.fields}
+			g.Expect(p.Update(event.UpdateEvent{ObjectOld: item.old, ObjectNew: item.new})).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestNewFieldChangedPredicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := NewFieldChangedPredicate(".spec.replicas", ".data[x]")
+	g.Expect(err).NotTo(BeNil())
+
+	p, err := NewFieldChangedPredicate(".spec.replicas")
+	g.Expect(err).To(BeNil())
+	g.Expect(p.Create(event.CreateEvent{Object: &corev1.ConfigMap{}})).To(BeTrue())
+}
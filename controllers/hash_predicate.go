@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/AlaudaDevops/pkg/hash"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// HashAnnotationChangedPredicate implements an update predicate that triggers when the spec hash
+// annotation, stored using hash.SetSpecHashAnnotation, drifts from the hash computed on the new object,
+// i.e. when someone changed a child resource outside of its controller.
+// Objects without the annotation are filtered out, if the hash cannot be computed the event is accepted.
+type HashAnnotationChangedPredicate struct {
+	// Spec returns the block of the object whose hash is stored in the annotation
+	Spec func(client.Object) interface{}
+	predicate.Funcs
+}
+
+// NewHashAnnotationChangedPredicate returns a HashAnnotationChangedPredicate using spec
+// to read the hashed block of objects
+func NewHashAnnotationChangedPredicate(spec func(client.Object) interface{}) HashAnnotationChangedPredicate {
+	return HashAnnotationChangedPredicate{Spec: spec}
+}
+
+// Update implements Predicate interface for update events.
+// It checks if the hash of the new object differs from its spec hash annotation.
+func (p HashAnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectNew == nil || p.Spec == nil {
+		return false
+	}
+	drifted, err := hash.SpecHashDrifted(e.ObjectNew, p.Spec(e.ObjectNew))
+	return drifted || err != nil
+}
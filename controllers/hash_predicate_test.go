@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/hash"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestHashAnnotationChangedPredicate(t *testing.T) {
+	configMapData := func(obj client.Object) interface{} {
+		return obj.(*corev1.ConfigMap).Data
+	}
+	newConfigMap := func(data map[string]string, hashed map[string]string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm"}, Data: data}
+		if hashed != nil {
+			_ = hash.SetSpecHashAnnotation(cm, hashed)
+		}
+		return cm
+	}
+	desired := map[string]string{"key": "value"}
+
+	var data = map[string]struct {
+		spec     func(client.Object) interface{}
+		obj      client.Object
+		expected bool
+	}{
+		"hash matches": {
+			spec:     configMapData,
+			obj:      newConfigMap(desired, desired),
+			expected: false,
+		},
+		"edited outside of controller": {
+			spec:     configMapData,
+			obj:      newConfigMap(map[string]string{"key": "edited"}, desired),
+			expected: true,
+		},
+		"without annotation": {
+			spec:     configMapData,
+			obj:      newConfigMap(desired, nil),
+			expected: false,
+		},
+		"hash cannot be computed": {
+			spec:     func(client.Object) interface{} { return make(chan int) },
+			obj:      newConfigMap(desired, desired),
+			expected: true,
+		},
+		"nil object": {
+			spec:     configMapData,
+			obj:      nil,
+			expected: false,
+		},
+	}
+
+	for name, item := range data {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			p := NewHashAnnotationChangedPredicate(item.spec)
+			g.Expect(p.Update(event.UpdateEvent{ObjectOld: newConfigMap(desired, desired), ObjectNew: item.obj})).To(Equal(item.expected))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlaudaDevops/pkg/fieldindexer"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SecretRefField is the index field of objects by referenced secrets,
+// values are secrets namespaced names in the form namespace/name
+const SecretRefField = "indexer.secretRef"
+
+// FieldIndexerProvider provides a field indexer, implemented by managers and clusters
+type FieldIndexerProvider interface {
+	GetFieldIndexer() client.FieldIndexer
+}
+
+// Register registers all indexers with the field indexer of provider
+func Register(ctx context.Context, provider FieldIndexerProvider, indexers ...fieldindexer.FieldIndexer) error {
+	for _, item := range indexers {
+		if err := provider.GetFieldIndexer().IndexField(ctx, item.Obj, item.Field, item.ExtractValue); err != nil {
+			return fmt.Errorf("index field %q failed: %w", item.Field, err)
+		}
+	}
+	return nil
+}
+
+// FieldIndex returns an indexer of objects of type T on field using extract.
+// Objects of other types are not indexed
+func FieldIndex[T client.Object](obj T, field string, extract func(T) []string) fieldindexer.FieldIndexer {
+	return fieldindexer.FieldIndexer{
+		Obj:   obj,
+		Field: field,
+		ExtractValue: func(o client.Object) []string {
+			typed, ok := o.(T)
+			if !ok {
+				return nil
+			}
+			return extract(typed)
+		},
+	}
+}
+
+// RegisterFieldIndex registers an index of objects of type T on field using extract
+func RegisterFieldIndex[T client.Object](ctx context.Context, provider FieldIndexerProvider, obj T, field string, extract func(T) []string) error {
+	return Register(ctx, provider, FieldIndex(obj, field, extract))
+}
+
+// OwnerField returns the index field of objects by owners of ownerGVK
+func OwnerField(ownerGVK schema.GroupVersionKind) string {
+	gk := ownerGVK.GroupKind()
+	return "indexer.owner." + gk.String()
+}
+
+// OwnerIndex returns an indexer of obj kind by the names of its owners of ownerGVK.
+// Owner references are matched by group and kind, ignoring the version
+func OwnerIndex(obj client.Object, ownerGVK schema.GroupVersionKind) fieldindexer.FieldIndexer {
+	return FieldIndex(obj, OwnerField(ownerGVK), func(o client.Object) (names []string) {
+		for _, ref := range o.GetOwnerReferences() {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil || gv.Group != ownerGVK.Group || ref.Kind != ownerGVK.Kind {
+				continue
+			}
+			names = append(names, ref.Name)
+		}
+		return names
+	})
+}
+
+// RegisterOwnerIndex registers an index of obj kind by the names of its owners of ownerGVK
+func RegisterOwnerIndex(ctx context.Context, provider FieldIndexerProvider, obj client.Object, ownerGVK schema.GroupVersionKind) error {
+	return Register(ctx, provider, OwnerIndex(obj, ownerGVK))
+}
+
+// MatchingOwner returns list options selecting children of owner indexed by RegisterOwnerIndex,
+// children are looked up in the namespace of the owner
+func MatchingOwner(owner client.Object, ownerGVK schema.GroupVersionKind) []client.ListOption {
+	return []client.ListOption{
+		client.InNamespace(owner.GetNamespace()),
+		client.MatchingFields{OwnerField(ownerGVK): owner.GetName()},
+	}
+}
+
+// SecretRefIndex returns an indexer of objects of type T by the secrets returned by refs.
+// References without namespace are defaulted to the namespace of the object
+func SecretRefIndex[T client.Object](obj T, refs func(T) []types.NamespacedName) fieldindexer.FieldIndexer {
+	return FieldIndex(obj, SecretRefField, func(o T) []string {
+		var values []string
+		for _, ref := range refs(o) {
+			if ref.Name == "" {
+				continue
+			}
+			if ref.Namespace == "" {
+				ref.Namespace = o.GetNamespace()
+			}
+			values = append(values, ref.String())
+		}
+		return values
+	})
+}
+
+// RegisterSecretRefIndex registers an index of objects of type T by the secrets returned by refs
+func RegisterSecretRefIndex[T client.Object](ctx context.Context, provider FieldIndexerProvider, obj T, refs func(T) []types.NamespacedName) error {
+	return Register(ctx, provider, SecretRefIndex(obj, refs))
+}
+
+// MatchingSecret returns a list option selecting objects referencing secret indexed by RegisterSecretRefIndex.
+// Add client.InNamespace to only select objects of a namespace
+func MatchingSecret(secret client.Object) client.MatchingFields {
+	return client.MatchingFields{SecretRefField: client.ObjectKeyFromObject(secret).String()}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeProvider registers indexes with a fake client builder
+type fakeProvider struct {
+	builder *fake.ClientBuilder
+	err     error
+}
+
+func (p *fakeProvider) GetFieldIndexer() client.FieldIndexer {
+	return p
+}
+
+func (p *fakeProvider) IndexField(_ context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.builder.WithIndex(obj, field, extractValue)
+	return nil
+}
+
+var deploymentGVK = appsv1.SchemeGroupVersion.WithKind("Deployment")
+
+func ownedConfigMap(name string, owners ...metav1.OwnerReference) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, OwnerReferences: owners}}
+}
+
+func TestOwnerIndex(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	owner := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}}
+	objs := []client.Object{
+		ownedConfigMap("owned", metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"}),
+		ownedConfigMap("old-version", metav1.OwnerReference{APIVersion: "apps/v1beta1", Kind: "Deployment", Name: "app"}),
+		ownedConfigMap("other-owner", metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "other"}),
+		ownedConfigMap("other-kind", metav1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "app"}),
+		ownedConfigMap("no-owner"),
+	}
+
+	provider := &fakeProvider{builder: fake.NewClientBuilder().WithObjects(objs...)}
+	g.Expect(RegisterOwnerIndex(ctx, provider, &corev1.ConfigMap{}, deploymentGVK)).To(Succeed())
+	clt := provider.builder.Build()
+
+	list := &corev1.ConfigMapList{}
+	g.Expect(clt.List(ctx, list, MatchingOwner(owner, deploymentGVK)...)).To(Succeed())
+	g.Expect(names(list.Items)).To(ConsistOf("owned", "old-version"))
+	g.Expect(OwnerField(deploymentGVK)).To(Equal("indexer.owner.Deployment.apps"))
+}
+
+func TestSecretRefIndex(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	refs := func(pod *corev1.Pod) (refs []types.NamespacedName) {
+		for _, secret := range pod.Spec.ImagePullSecrets {
+			refs = append(refs, types.NamespacedName{Name: secret.Name})
+		}
+		if ns := pod.Annotations["secret-namespace"]; ns != "" {
+			refs = append(refs, types.NamespacedName{Namespace: ns, Name: "shared"})
+		}
+		return refs
+	}
+	pod := func(ns, name string, secrets ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Annotations: map[string]string{}}}
+		for _, secret := range secrets {
+			p.Spec.ImagePullSecrets = append(p.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+		return p
+	}
+	crossNamespace := pod("other", "cross-namespace")
+	crossNamespace.Annotations["secret-namespace"] = "default"
+
+	provider := &fakeProvider{builder: fake.NewClientBuilder().WithObjects(
+		pod("default", "uses-pull", "pull"),
+		pod("default", "uses-both", "pull", "shared"),
+		pod("other", "same-name-other-namespace", "pull"),
+		pod("default", "unrelated", "other"),
+		crossNamespace,
+	)}
+	g.Expect(RegisterSecretRefIndex(ctx, provider, &corev1.Pod{}, refs)).To(Succeed())
+	clt := provider.builder.Build()
+
+	var data = []struct {
+		desc     string
+		secret   *corev1.Secret
+		opts     []client.ListOption
+		expected []string
+	}{
+		{
+			desc:     "pull secret in default namespace",
+			secret:   &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pull"}},
+			expected: []string{"uses-pull", "uses-both"},
+		},
+		{
+			desc:     "shared secret referenced across namespaces",
+			secret:   &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shared"}},
+			expected: []string{"uses-both", "cross-namespace"},
+		},
+		{
+			desc:     "restricted to a namespace",
+			secret:   &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shared"}},
+			opts:     []client.ListOption{client.InNamespace("other")},
+			expected: []string{"cross-namespace"},
+		},
+		{
+			desc:     "secret not referenced",
+			secret:   &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing"}},
+			expected: []string{},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			list := &corev1.PodList{}
+			g.Expect(clt.List(ctx, list, append(item.opts, MatchingSecret(item.secret))...)).To(Succeed())
+			g.Expect(names(list.Items)).To(ConsistOf(item.expected))
+		})
+	}
+}
+
+func TestRegisterFieldIndex(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	provider := &fakeProvider{builder: fake.NewClientBuilder().WithObjects(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "internal"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+	)}
+	g.Expect(RegisterFieldIndex(ctx, provider, &corev1.Service{}, "spec.type", func(svc *corev1.Service) []string {
+		return []string{string(svc.Spec.Type)}
+	})).To(Succeed())
+	clt := provider.builder.Build()
+
+	list := &corev1.ServiceList{}
+	g.Expect(clt.List(ctx, list, client.MatchingFields{"spec.type": "LoadBalancer"})).To(Succeed())
+	g.Expect(names(list.Items)).To(ConsistOf("lb"))
+
+	index := FieldIndex(&corev1.Service{}, "spec.type", func(svc *corev1.Service) []string { return []string{"value"} })
+	g.Expect(index.ExtractValue(&corev1.ConfigMap{})).To(BeEmpty(), "other types are not indexed")
+
+	provider.err = fmt.Errorf("indexer already started")
+	err := Register(ctx, provider, index)
+	g.Expect(err).To(MatchError(ContainSubstring(`index field "spec.type" failed: indexer already started`)))
+}
+
+func names[T any, PT interface {
+	*T
+	client.Object
+}](items []T) []string {
+	result := make([]string, 0, len(items))
+	for i := range items {
+		result = append(result, PT(&items[i]).GetName())
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lease locks objects with coordination.k8s.io Leases so controllers, or a cli and a
+// controller, never run conflicting operations against the same external system.
+//
+// A lock is a Lease named after the object and the operation. It is held while renewed and
+// taken over by others once its holder stopped renewing it for the lease duration:
+//
+//	locker := lease.NewLocker(cli, "repository-controller/"+podName)
+//	err := locker.Do(ctx, repository, "delete-remote", func(ctx context.Context) error {
+//		// ctx is canceled if the lock is lost
+//		return gitServer.DeleteRepository(ctx, repository.Spec.URL)
+//	})
+//	if held := (&lease.HeldError{}); errors.As(err, &held) {
+//		return ctrl.Result{RequeueAfter: held.RetryAfter}, nil
+//	}
+//
+// Leases should be read without a cache, stale reads are safe as updates use optimistic
+// concurrency but they fail with conflicts.
+package lease
@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/names"
+)
+
+const (
+	// DefaultLeaseDuration is how long a lock is held without being renewed
+	DefaultLeaseDuration = 30 * time.Second
+
+	// ObjectAnnotation is set on leases with the kind and key of the locked object
+	ObjectAnnotation = "lease.alauda.io/object"
+	// OperationAnnotation is set on leases with the operation of the lock
+	OperationAnnotation = "lease.alauda.io/operation"
+)
+
+// ErrLost is the cause of the context given to Do when the lock could not be renewed
+var ErrLost = errors.New("lock lost")
+
+// HeldError is returned when the lock is held by another holder
+type HeldError struct {
+	// Lease name
+	Lease string
+	// Holder of the lock
+	Holder string
+	// RetryAfter is the time until the lock expires if its holder does not renew it
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("lock %s is held by %s", e.Lease, e.Holder)
+}
+
+// Locker acquires locks on objects as Leases
+type Locker struct {
+	client        client.Client
+	identity      string
+	namespace     string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	clock         clock.Clock
+	logger        *zap.SugaredLogger
+}
+
+// Option configures a Locker
+type Option func(*Locker)
+
+// WithLeaseDuration sets how long a lock is held without being renewed, defaults to DefaultLeaseDuration
+func WithLeaseDuration(d time.Duration) Option {
+	return func(l *Locker) {
+		l.leaseDuration = d
+	}
+}
+
+// WithRenewInterval sets the interval between renewals in Do, defaults to a third of the lease duration
+func WithRenewInterval(d time.Duration) Option {
+	return func(l *Locker) {
+		l.renewInterval = d
+	}
+}
+
+// WithNamespace sets the namespace of the leases, defaults to the namespace of the locked object.
+// It is required to lock cluster scoped objects
+func WithNamespace(namespace string) Option {
+	return func(l *Locker) {
+		l.namespace = namespace
+	}
+}
+
+// WithClock sets the clock used for lease times and renewals
+func WithClock(clock clock.Clock) Option {
+	return func(l *Locker) {
+		l.clock = clock
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(l *Locker) {
+		l.logger = logger
+	}
+}
+
+// NewLocker returns a Locker holding locks as identity, which must be unique
+// among all the controllers and clis locking the same objects, e.g. a pod name
+func NewLocker(cli client.Client, identity string, opts ...Option) *Locker {
+	l := &Locker{
+		client:        cli,
+		identity:      identity,
+		leaseDuration: DefaultLeaseDuration,
+		clock:         clock.RealClock{},
+		logger:        zap.NewNop().Sugar(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.renewInterval <= 0 {
+		l.renewInterval = l.leaseDuration / 3
+	}
+	return l
+}
+
+// Lock is a lock held on an object
+type Lock struct {
+	locker *Locker
+	lease  *coordinationv1.Lease
+}
+
+// Lease returns the name of the lease
+func (l *Lock) Lease() string {
+	return l.lease.Name
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// leaseName returns a stable name for the lease of obj and operation
+func (l *Locker) leaseName(kind string, obj client.Object, operation string) string {
+	prefix := invalidNameChars.ReplaceAllString(strings.ToLower(kind+"-"+obj.GetName()+"-"+operation), "-")
+	return names.GenerateNameWithHashSuffix(prefix, kind+"/"+obj.GetNamespace()+"/"+obj.GetName()+"/"+operation)
+}
+
+// Acquire acquires the lock of operation on obj without waiting. It returns a HeldError
+// when another holder renewed the lock within the lease duration. Expired locks are taken over
+func (l *Locker) Acquire(ctx context.Context, obj client.Object, operation string) (*Lock, error) {
+	gvk, err := apiutil.GVKForObject(obj, l.client.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	namespace := l.namespace
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("a lease namespace is required to lock cluster scoped %s %s", gvk.Kind, obj.GetName())
+	}
+	key := client.ObjectKey{Namespace: namespace, Name: l.leaseName(gvk.Kind, obj, operation)}
+	now := metav1.NewMicroTime(l.clock.Now())
+
+	lease := &coordinationv1.Lease{}
+	err = l.client.Get(ctx, key, lease)
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Annotations: map[string]string{
+					ObjectAnnotation:    gvk.Kind + " " + client.ObjectKeyFromObject(obj).String(),
+					OperationAnnotation: operation,
+				},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(l.identity),
+				LeaseDurationSeconds: ptr.To(int32(l.leaseDuration / time.Second)),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+				LeaseTransitions:     ptr.To[int32](0),
+			},
+		}
+		if err = l.client.Create(ctx, lease); err != nil {
+			return nil, fmt.Errorf("create lease %s failed: %w", key, err)
+		}
+		return &Lock{locker: l, lease: lease}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get lease %s failed: %w", key, err)
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder != "" && holder != l.identity {
+		if remaining := l.remaining(lease); remaining > 0 {
+			return nil, &HeldError{Lease: key.String(), Holder: holder, RetryAfter: remaining}
+		}
+		l.logger.Infow("taking over expired lease", "lease", key, "holder", holder)
+	}
+	if holder != l.identity {
+		lease.Spec.HolderIdentity = ptr.To(l.identity)
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(l.leaseDuration / time.Second))
+	lease.Spec.RenewTime = &now
+	// fails with a conflict if another holder acquired it since it was read
+	if err = l.client.Update(ctx, lease); err != nil {
+		return nil, fmt.Errorf("acquire lease %s failed: %w", key, err)
+	}
+	return &Lock{locker: l, lease: lease}, nil
+}
+
+// remaining returns the time until the lease expires
+func (l *Locker) remaining(lease *coordinationv1.Lease) time.Duration {
+	renewed := lease.Spec.RenewTime
+	if renewed == nil {
+		renewed = lease.Spec.AcquireTime
+	}
+	if renewed == nil {
+		return 0
+	}
+	duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+	return renewed.Add(duration).Sub(l.clock.Now())
+}
+
+// Renew extends the lock for another lease duration. It fails when the lock was taken over
+func (l *Lock) Renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(l.locker.clock.Now())
+	lease := l.lease.DeepCopy()
+	lease.Spec.RenewTime = &now
+	if err := l.locker.client.Update(ctx, lease); err != nil {
+		return fmt.Errorf("renew lease %s failed: %w", client.ObjectKeyFromObject(lease), err)
+	}
+	l.lease = lease
+	return nil
+}
+
+// Release deletes the lease unless it was taken over by another holder
+func (l *Lock) Release(ctx context.Context) error {
+	err := l.locker.client.Delete(ctx, l.lease, client.Preconditions{
+		UID:             ptr.To(l.lease.UID),
+		ResourceVersion: ptr.To(l.lease.ResourceVersion),
+	})
+	if client.IgnoreNotFound(err) != nil && !apierrors.IsConflict(err) {
+		return fmt.Errorf("release lease %s failed: %w", client.ObjectKeyFromObject(l.lease), err)
+	}
+	return nil
+}
+
+// Do runs fn while holding the lock of operation on obj, renewing it in the background, and
+// releases it when fn returns. The context of fn is canceled with ErrLost as cause when a
+// renewal fails. It returns a HeldError without running fn when the lock is held by another holder
+func (l *Locker) Do(ctx context.Context, obj client.Object, operation string, fn func(ctx context.Context) error) error {
+	lock, err := l.Acquire(ctx, obj, operation)
+	if err != nil {
+		return err
+	}
+	fnCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := l.clock.NewTicker(l.renewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-fnCtx.Done():
+				return
+			case <-ticker.C():
+				if err := lock.Renew(fnCtx); err != nil {
+					l.logger.Errorw("lock lost", "lease", lock.Lease(), "err", err)
+					cancel(fmt.Errorf("%w: %w", ErrLost, err))
+					return
+				}
+			}
+		}
+	}()
+
+	err = fn(fnCtx)
+	close(done)
+	<-renewed
+	// released even when ctx is canceled
+	if releaseErr := lock.Release(context.WithoutCancel(ctx)); releaseErr != nil {
+		l.logger.Errorw("release lock failed", "lease", lock.Lease(), "err", releaseErr)
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var repository = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "repository"}}
+
+func TestLocker_Acquire(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	fakeClock := clock.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	controller := NewLocker(cli, "controller", WithClock(fakeClock))
+	cli2 := NewLocker(cli, "cli", WithClock(fakeClock))
+
+	lock, err := controller.Acquire(ctx, repository, "delete-remote")
+	g.Expect(err).To(BeNil())
+	g.Expect(lock.Lease()).To(HavePrefix("configmap-repository-delete-remote-"))
+
+	lease := &coordinationv1.Lease{}
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: lock.Lease()}, lease)).To(Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(Equal("controller"))
+	g.Expect(*lease.Spec.LeaseDurationSeconds).To(BeEquivalentTo(30))
+	g.Expect(lease.Annotations).To(HaveKeyWithValue(ObjectAnnotation, "ConfigMap default/repository"))
+
+	// other operations are not locked
+	_, err = cli2.Acquire(ctx, repository, "push")
+	g.Expect(err).To(BeNil())
+
+	fakeClock.Advance(20 * time.Second)
+	_, err = cli2.Acquire(ctx, repository, "delete-remote")
+	held := &HeldError{}
+	g.Expect(errors.As(err, &held)).To(BeTrue())
+	g.Expect(held.Holder).To(Equal("controller"))
+	g.Expect(held.RetryAfter).To(Equal(10 * time.Second))
+
+	// the holder can acquire it again
+	lock, err = controller.Acquire(ctx, repository, "delete-remote")
+	g.Expect(err).To(BeNil())
+
+	// renewals keep it
+	fakeClock.Advance(20 * time.Second)
+	g.Expect(lock.Renew(ctx)).To(Succeed())
+	fakeClock.Advance(20 * time.Second)
+	_, err = cli2.Acquire(ctx, repository, "delete-remote")
+	g.Expect(errors.As(err, &held)).To(BeTrue())
+
+	// stale holders are taken over
+	fakeClock.Advance(11 * time.Second)
+	taken, err := cli2.Acquire(ctx, repository, "delete-remote")
+	g.Expect(err).To(BeNil())
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: taken.Lease()}, lease)).To(Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(Equal("cli"))
+	g.Expect(*lease.Spec.LeaseTransitions).To(BeEquivalentTo(1))
+
+	// the previous holder can not renew nor release it
+	g.Expect(lock.Renew(ctx)).NotTo(Succeed())
+	g.Expect(lock.Release(ctx)).To(Succeed())
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: taken.Lease()}, lease)).To(Succeed())
+
+	g.Expect(taken.Release(ctx)).To(Succeed())
+	g.Expect(cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: taken.Lease()}, lease)).NotTo(Succeed())
+}
+
+func TestLocker_Acquire_clusterScoped(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team"}}
+
+	_, err := NewLocker(fake.NewClientBuilder().Build(), "controller").Acquire(ctx, ns, "cleanup")
+	g.Expect(err).To(MatchError(ContainSubstring("a lease namespace is required")))
+
+	lock, err := NewLocker(fake.NewClientBuilder().Build(), "controller", WithNamespace("system")).Acquire(ctx, ns, "cleanup")
+	g.Expect(err).To(BeNil())
+	g.Expect(lock.lease.Namespace).To(Equal("system"))
+}
+
+func TestLocker_Do(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	fakeClock := clock.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	locker := NewLocker(cli, "controller", WithClock(fakeClock))
+
+	ran := false
+	err := locker.Do(ctx, repository, "delete-remote", func(ctx context.Context) error {
+		ran = true
+		// held while running
+		_, err := NewLocker(cli, "cli", WithClock(fakeClock)).Acquire(ctx, repository, "delete-remote")
+		g.Expect(err).To(BeAssignableToTypeOf(&HeldError{}))
+		return errors.New("failed")
+	})
+	g.Expect(err).To(MatchError("failed"))
+	g.Expect(ran).To(BeTrue())
+
+	// released when done
+	leases := &coordinationv1.LeaseList{}
+	g.Expect(cli.List(ctx, leases)).To(Succeed())
+	g.Expect(leases.Items).To(BeEmpty())
+}
+
+func TestLocker_Do_lost(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	failRenew := false
+	cli := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if failRenew {
+				return errors.New("conflict")
+			}
+			return cli.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	fakeClock := clock.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	locker := NewLocker(cli, "controller", WithClock(fakeClock), WithRenewInterval(5*time.Second))
+
+	err := locker.Do(ctx, repository, "delete-remote", func(ctx context.Context) error {
+		failRenew = true
+		g.Eventually(func() bool {
+			fakeClock.Advance(5 * time.Second)
+			return ctx.Err() != nil
+		}).Should(BeTrue())
+		return context.Cause(ctx)
+	})
+	g.Expect(err).To(MatchError(ErrLost))
+}
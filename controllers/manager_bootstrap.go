@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	kclient "github.com/AlaudaDevops/pkg/client"
+	khealthz "github.com/AlaudaDevops/pkg/healthz"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+const (
+	// LeaderElectionDomain suffix of the leader election ids
+	LeaderElectionDomain = "alauda.io"
+
+	// CacheSyncCheckTimeout is the maximum time the readiness probe waits for informer caches
+	CacheSyncCheckTimeout = 5 * time.Second
+)
+
+// ManagerOptions standard options of controller managers created by NewManager,
+// use DefaultManagerOptions to get the defaults and AddFlags to set them from flags
+type ManagerOptions struct {
+	// Name of the component, the leader election id is derived from it
+	Name string
+	// Scheme of the manager, defaults to the controller-runtime scheme
+	Scheme *runtime.Scheme
+	// Config of the manager, defaults to the config in the context or the kubeconfig
+	Config *rest.Config
+
+	// QPS and Burst of the client side rate limiter, zero keeps the values of the config
+	QPS   float64
+	Burst int
+
+	// MetricsBindAddress address of the metrics endpoint, "0" disables it
+	MetricsBindAddress string
+	// SecureMetrics serves metrics over https to authenticated and authorized requests
+	SecureMetrics bool
+	// HealthProbeBindAddress address of the health probe endpoints, "0" disables them
+	HealthProbeBindAddress string
+
+	// LeaderElection enables leader election with the id returned by LeaderElectionID
+	LeaderElection bool
+	// LeaderElectionNamespace namespace of the lease, defaults to the namespace of the pod
+	LeaderElectionNamespace string
+	LeaseDuration           time.Duration
+	RenewDeadline           time.Duration
+	RetryPeriod             time.Duration
+
+	// GracefulShutdownTimeout time given to runnables to stop, the lease is released once they stop
+	GracefulShutdownTimeout time.Duration
+
+	// ReconcileTimeout default timeout of reconciles wrapped with TimeoutReconciler,
+	// stored in the base context of the manager, zero does not limit reconciles
+	ReconcileTimeout time.Duration
+
+	// CacheSelectors restricts the cached objects of each type to the ones matching the label selector
+	// to bound the memory used by the cache, e.g. secrets managed by the operator
+	CacheSelectors map[client.Object]labels.Selector
+	// CacheTransform is applied to all objects before they are cached, defaults to
+	// DefaultCacheTransform, see also StripLargeAnnotations and ChainTransforms
+	CacheTransform toolscache.TransformFunc
+	// MetadataOnly objects are not cached by the manager client, see MetadataOnly
+	MetadataOnly []client.Object
+
+	// Customize changes the manager options after the standard options are set
+	Customize func(*ctrl.Options)
+}
+
+// DefaultManagerOptions returns the standard options of the manager named name
+func DefaultManagerOptions(name string) ManagerOptions {
+	return ManagerOptions{
+		Name:                    name,
+		QPS:                     float64(kclient.DefaultQPS),
+		Burst:                   kclient.DefaultBurst,
+		MetricsBindAddress:      ":8443",
+		SecureMetrics:           true,
+		HealthProbeBindAddress:  ":8081",
+		LeaderElection:          true,
+		LeaseDuration:           15 * time.Second,
+		RenewDeadline:           10 * time.Second,
+		RetryPeriod:             2 * time.Second,
+		GracefulShutdownTimeout: 30 * time.Second,
+		CacheTransform:          DefaultCacheTransform(),
+	}
+}
+
+// AddFlags adds flags for the options to fs, using the current values as defaults
+func (o *ManagerOptions) AddFlags(fs *flag.FlagSet) {
+	fs.Float64Var(&o.QPS, "kube-api-qps", o.QPS, "Maximum QPS to the api server.")
+	fs.IntVar(&o.Burst, "kube-api-burst", o.Burst, "Maximum burst for throttle of requests to the api server.")
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", o.MetricsBindAddress,
+		"The address the metrics endpoint binds to, or 0 to disable the metrics service.")
+	fs.BoolVar(&o.SecureMetrics, "metrics-secure", o.SecureMetrics,
+		"Serve metrics over https to authenticated and authorized requests.")
+	fs.StringVar(&o.HealthProbeBindAddress, "health-probe-bind-address", o.HealthProbeBindAddress,
+		"The address the probe endpoint binds to.")
+	fs.BoolVar(&o.LeaderElection, "leader-elect", o.LeaderElection,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	fs.StringVar(&o.LeaderElectionNamespace, "leader-elect-namespace", o.LeaderElectionNamespace,
+		"Namespace of the leader election lease, defaults to the namespace of the pod.")
+	fs.DurationVar(&o.LeaseDuration, "lease-duration", o.LeaseDuration,
+		"lease duration is the duration that non-leader candidates will wait to force acquire leadership.")
+	fs.DurationVar(&o.RenewDeadline, "renew-deadline", o.RenewDeadline,
+		"renew deadline is the duration that the acting controlplane will retry refreshing leadership before giving up.")
+	fs.DurationVar(&o.RetryPeriod, "retry-period", o.RetryPeriod,
+		"retry period is the duration the LeaderElector clients should wait between tries of actions.")
+	fs.DurationVar(&o.GracefulShutdownTimeout, "graceful-shutdown-timeout", o.GracefulShutdownTimeout,
+		"Time given to controllers to stop before the manager exits.")
+	fs.DurationVar(&o.ReconcileTimeout, "reconcile-timeout", o.ReconcileTimeout,
+		"Default timeout of reconciles, 0 does not limit reconciles.")
+}
+
+// LeaderElectionID returns the leader election id of the component name
+func LeaderElectionID(name string) string {
+	hasher := fnv.New32a()
+	// Hash.Write never returns an error
+	_, _ = hasher.Write([]byte(name))
+	return fmt.Sprintf("%x.%s", hasher.Sum(nil), LeaderElectionDomain)
+}
+
+// NewManager returns a manager configured with the standard options, runnables get ctx
+// as their base context. The ping, cache sync and leader health checks are registered
+// and events recorded by the manager have their message shortened
+func NewManager(ctx context.Context, opts ManagerOptions) (ctrl.Manager, error) {
+	config, options, err := opts.managerOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mgr, err := ctrl.NewManager(config, options)
+	if err != nil {
+		return nil, fmt.Errorf("create manager: %w", err)
+	}
+	if _, err := RegisterHealthChecks(mgr); err != nil {
+		return nil, fmt.Errorf("register health checks: %w", err)
+	}
+	return ControllerManager{Manager: mgr}, nil
+}
+
+// RegisterHealthChecks registers the standard ping, cache sync and leader health checks
+// of the manager, the returned registry accepts additional checks
+func RegisterHealthChecks(mgr ctrl.Manager) (*khealthz.Registry, error) {
+	return khealthz.Register(mgr,
+		khealthz.Ping(),
+		khealthz.CacheSync(mgr.GetCache(), CacheSyncCheckTimeout),
+		khealthz.Leader(mgr.Elected()),
+	)
+}
+
+func (o ManagerOptions) managerOptions(ctx context.Context) (*rest.Config, ctrl.Options, error) {
+	if o.Name == "" {
+		return nil, ctrl.Options{}, errors.New("manager name is required")
+	}
+	config := o.Config
+	if config == nil {
+		config = kclient.GetAppConfig(ctx)
+	}
+	if config == nil {
+		var err error
+		if config, err = ctrl.GetConfig(); err != nil {
+			return nil, ctrl.Options{}, fmt.Errorf("get kubeconfig: %w", err)
+		}
+	}
+	config = rest.CopyConfig(config)
+	if o.ReconcileTimeout > 0 {
+		ctx = WithReconcileTimeout(ctx, o.ReconcileTimeout)
+	}
+	if o.QPS > 0 {
+		config.QPS = float32(o.QPS)
+	}
+	if o.Burst > 0 {
+		config.Burst = o.Burst
+	}
+
+	options := ctrl.Options{
+		Scheme: o.Scheme,
+		Metrics: metricsserver.Options{
+			BindAddress:   o.MetricsBindAddress,
+			SecureServing: o.SecureMetrics,
+		},
+		HealthProbeBindAddress:        o.HealthProbeBindAddress,
+		LeaderElection:                o.LeaderElection,
+		LeaderElectionID:              LeaderElectionID(o.Name),
+		LeaderElectionNamespace:       o.LeaderElectionNamespace,
+		LeaderElectionReleaseOnCancel: true,
+		BaseContext:                   func() context.Context { return ctx },
+	}
+	if o.SecureMetrics {
+		options.Metrics.FilterProvider = filters.WithAuthenticationAndAuthorization
+	}
+	if o.LeaseDuration > 0 {
+		options.LeaseDuration = &o.LeaseDuration
+	}
+	if o.RenewDeadline > 0 {
+		options.RenewDeadline = &o.RenewDeadline
+	}
+	if o.RetryPeriod > 0 {
+		options.RetryPeriod = &o.RetryPeriod
+	}
+	if o.GracefulShutdownTimeout > 0 {
+		options.GracefulShutdownTimeout = &o.GracefulShutdownTimeout
+	}
+	if len(o.CacheSelectors) > 0 {
+		options.Cache.ByObject = make(map[client.Object]cache.ByObject, len(o.CacheSelectors))
+		for obj, selector := range o.CacheSelectors {
+			options.Cache.ByObject[obj] = cache.ByObject{Label: selector}
+		}
+	}
+	options.Cache.DefaultTransform = o.CacheTransform
+	if len(o.MetadataOnly) > 0 {
+		MetadataOnly(o.MetadataOnly...)(&options)
+	}
+	if o.Customize != nil {
+		o.Customize(&options)
+	}
+	return config, options, nil
+}
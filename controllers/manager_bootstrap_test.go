@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	kclient "github.com/AlaudaDevops/pkg/client"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLeaderElectionID(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(LeaderElectionID("my-operator")).To(MatchRegexp(`^[0-9a-f]{8}\.alauda\.io$`))
+	g.Expect(LeaderElectionID("my-operator")).To(Equal(LeaderElectionID("my-operator")))
+	g.Expect(LeaderElectionID("my-operator")).NotTo(Equal(LeaderElectionID("other")))
+}
+
+func TestManagerOptions_AddFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	opts := DefaultManagerOptions("my-operator")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.AddFlags(fs)
+
+	g.Expect(fs.Parse([]string{
+		"--kube-api-qps=100", "--kube-api-burst=200", "--metrics-secure=false",
+		"--leader-elect=false", "--graceful-shutdown-timeout=1m", "--reconcile-timeout=30s",
+	})).To(Succeed())
+	g.Expect(opts.QPS).To(Equal(float64(100)))
+	g.Expect(opts.Burst).To(Equal(200))
+	g.Expect(opts.SecureMetrics).To(BeFalse())
+	g.Expect(opts.LeaderElection).To(BeFalse())
+	g.Expect(opts.GracefulShutdownTimeout).To(Equal(time.Minute))
+	g.Expect(opts.ReconcileTimeout).To(Equal(30 * time.Second))
+	g.Expect(opts.HealthProbeBindAddress).To(Equal(":8081"))
+}
+
+func TestManagerOptions_managerOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	appConfig := &rest.Config{Host: "https://cluster", QPS: 5, Burst: 10}
+	ctx := kclient.WithAppConfig(context.Background(), appConfig)
+
+	opts := DefaultManagerOptions("my-operator")
+	selector := labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "my-operator"})
+	opts.CacheSelectors = map[client.Object]labels.Selector{&corev1.Secret{}: selector}
+	customized := false
+	opts.Customize = func(*ctrl.Options) { customized = true }
+
+	config, options, err := opts.managerOptions(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Host).To(Equal("https://cluster"))
+	g.Expect(config.QPS).To(Equal(kclient.DefaultQPS))
+	g.Expect(config.Burst).To(Equal(kclient.DefaultBurst))
+	g.Expect(appConfig.QPS).To(Equal(float32(5)), "the config in the context is not modified")
+
+	g.Expect(options.LeaderElection).To(BeTrue())
+	g.Expect(options.LeaderElectionID).To(Equal(LeaderElectionID("my-operator")))
+	g.Expect(options.LeaderElectionReleaseOnCancel).To(BeTrue())
+	g.Expect(*options.LeaseDuration).To(Equal(15 * time.Second))
+	g.Expect(*options.GracefulShutdownTimeout).To(Equal(30 * time.Second))
+	g.Expect(options.Metrics.SecureServing).To(BeTrue())
+	g.Expect(options.Metrics.FilterProvider).NotTo(BeNil())
+	g.Expect(options.Cache.ByObject).To(HaveLen(1))
+	for _, byObject := range options.Cache.ByObject {
+		g.Expect(byObject.Label).To(Equal(selector))
+	}
+	g.Expect(options.Cache.DefaultTransform).NotTo(BeNil())
+	g.Expect(options.BaseContext()).To(Equal(ctx))
+	g.Expect(customized).To(BeTrue())
+
+	opts.ReconcileTimeout = time.Minute
+	_, options, err = opts.managerOptions(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(GetReconcileTimeout(options.BaseContext())).To(Equal(time.Minute))
+
+	_, _, err = ManagerOptions{}.managerOptions(ctx)
+	g.Expect(err).To(MatchError("manager name is required"))
+}
+
+func TestNewManager(t *testing.T) {
+	g := NewGomegaWithT(t)
+	opts := DefaultManagerOptions("my-operator")
+	opts.Config = &rest.Config{Host: "https://127.0.0.1:1"}
+	opts.MetricsBindAddress = "0"
+	opts.HealthProbeBindAddress = "0"
+	opts.LeaderElection = false
+
+	mgr, err := NewManager(context.Background(), opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mgr).To(BeAssignableToTypeOf(ControllerManager{}))
+	g.Expect(mgr.GetConfig().QPS).To(Equal(kclient.DefaultQPS))
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OrphanCleaner deletes child objects whose owners of a given kind no longer exist.
+// It complements the kubernetes garbage collector, e.g. when it is not running as in envtest.
+// Owners of namespaced kinds are looked up in the namespace of the child, like the garbage
+// collector does, so a reference to an owner in another namespace is treated as missing and
+// the child is deleted. Owners of cluster scoped kinds are resolved without a namespace.
+type OrphanCleaner struct {
+	// Client used to list children, get owners and delete orphans
+	Client client.Client
+
+	// OwnerGVK kind of the owners, references to other kinds are ignored
+	OwnerGVK schema.GroupVersionKind
+
+	// NewList returns an empty list of the children type
+	NewList func() client.ObjectList
+
+	// DryRun only reports orphans without deleting them
+	DryRun bool
+
+	// Logger optional logger
+	Logger *zap.SugaredLogger
+}
+
+// Clean lists children with opts and deletes the ones whose owners of OwnerGVK are all gone.
+// Children without any owner of OwnerGVK are left untouched. Returns the orphans found.
+func (c *OrphanCleaner) Clean(ctx context.Context, opts ...client.ListOption) (orphans []client.Object, err error) {
+	list := c.NewList()
+	if err = c.Client.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := []error{}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("list item %T is not a client.Object", item)
+		}
+		orphan, err := c.isOrphan(ctx, obj)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !orphan {
+			continue
+		}
+		orphans = append(orphans, obj)
+		if c.DryRun {
+			c.logInfo("found orphan", obj)
+			continue
+		}
+		if err = c.Client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("delete orphan %s/%s failed: %w", obj.GetNamespace(), obj.GetName(), err))
+			continue
+		}
+		c.logInfo("deleted orphan", obj)
+	}
+	return orphans, errors.Join(errs...)
+}
+
+// isOrphan returns true if obj has owners of OwnerGVK and none of them exists
+func (c *OrphanCleaner) isOrphan(ctx context.Context, obj client.Object) (bool, error) {
+	found := false
+	for _, ref := range obj.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv.Group != c.OwnerGVK.Group || ref.Kind != c.OwnerGVK.Kind {
+			continue
+		}
+		found = true
+
+		owner := &metav1.PartialObjectMetadata{}
+		owner.SetGroupVersionKind(c.OwnerGVK)
+		key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: ref.Name}
+		if namespaced, err := c.Client.IsObjectNamespaced(owner); err == nil && !namespaced {
+			key.Namespace = ""
+		}
+		err = c.Client.Get(ctx, key, owner)
+		switch {
+		case err == nil && owner.GetUID() == ref.UID:
+			return false, nil
+		case err != nil && !apierrors.IsNotFound(err):
+			return false, err
+		}
+	}
+	return found, nil
+}
+
+func (c *OrphanCleaner) logInfo(msg string, obj client.Object) {
+	if c.Logger == nil {
+		return
+	}
+	c.Logger.Infow(msg, "namespace", obj.GetNamespace(), "name", obj.GetName(), "ownerKind", c.OwnerGVK.Kind)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SetOwner sets owner as the controller owner of obj.
+// Cluster scoped owners cannot own namespaced objects across namespaces
+// and an object can only have one controller owner, both return an error.
+func SetOwner(owner, obj metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, obj, scheme)
+}
+
+// AddOwner adds owner as a non controller owner of obj,
+// keeping any existing owner references
+func AddOwner(owner, obj metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetOwnerReference(owner, obj, scheme)
+}
+
+// IsOwnedBy returns true if obj has an owner reference to owner
+func IsOwnedBy(obj, owner metav1.Object) bool {
+	if obj == nil || owner == nil {
+		return false
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsControlledBy returns true if owner is the controller owner of obj
+func IsControlledBy(obj, owner metav1.Object) bool {
+	if obj == nil || owner == nil {
+		return false
+	}
+	return metav1.IsControlledBy(obj, owner)
+}
+
+// ListOwnedBy lists objects of the type of list owned by owner.
+// Objects are listed in the namespace of the owner, cluster scoped owners
+// list in all namespaces. The items of list are replaced by the owned ones,
+// which are also returned as client.Object.
+func ListOwnedBy(ctx context.Context, clt client.Reader, owner metav1.Object, list client.ObjectList, opts ...client.ListOption) ([]client.Object, error) {
+	if owner.GetNamespace() != "" {
+		opts = append([]client.ListOption{client.InNamespace(owner.GetNamespace())}, opts...)
+	}
+	if err := clt.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]runtime.Object, 0, len(items))
+	objs := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("list item %T is not a client.Object", item)
+		}
+		if IsOwnedBy(obj, owner) {
+			owned = append(owned, item)
+			objs = append(objs, obj)
+		}
+	}
+	if err = meta.SetList(list, owned); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newScheme(g *WithT) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+func newOwner(name string, uid types.UID) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: uid},
+	}
+}
+
+func newChild(name string, owners ...client.Object) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	for _, owner := range owners {
+		gvk := owner.GetObjectKind().GroupVersionKind()
+		secret.OwnerReferences = append(secret.OwnerReferences, metav1.OwnerReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+		})
+	}
+	return secret
+}
+
+func TestSetOwnerAndIsOwnedBy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	scheme := newScheme(g)
+	owner := newOwner("owner", "uid-1")
+	other := newOwner("other", "uid-2")
+	child := newChild("child")
+
+	g.Expect(IsOwnedBy(child, owner)).To(BeFalse())
+	g.Expect(SetOwner(owner, child, scheme)).To(Succeed())
+	g.Expect(IsOwnedBy(child, owner)).To(BeTrue())
+	g.Expect(IsControlledBy(child, owner)).To(BeTrue())
+
+	// only one controller is allowed
+	g.Expect(SetOwner(other, child, scheme)).NotTo(Succeed())
+	g.Expect(AddOwner(other, child, scheme)).To(Succeed())
+	g.Expect(IsOwnedBy(child, other)).To(BeTrue())
+	g.Expect(IsControlledBy(child, other)).To(BeFalse())
+	g.Expect(child.OwnerReferences).To(HaveLen(2))
+
+	g.Expect(IsOwnedBy(nil, owner)).To(BeFalse())
+}
+
+func TestListOwnedBy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	owner := newOwner("owner", "uid-1")
+	other := newOwner("other", "uid-2")
+	outside := newChild("outside", owner)
+	outside.Namespace = "other"
+
+	clt := fake.NewClientBuilder().WithScheme(newScheme(g)).WithObjects(
+		newChild("a", owner), newChild("b", other), newChild("c", other, owner), newChild("d"), outside,
+	).Build()
+
+	list := &corev1.SecretList{}
+	objs, err := ListOwnedBy(context.TODO(), clt, owner, list)
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(2))
+	g.Expect(list.Items).To(HaveLen(2))
+	g.Expect(list.Items[0].Name).To(Equal("a"))
+	g.Expect(list.Items[1].Name).To(Equal("c"))
+}
+
+func TestOrphanCleaner(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+	owner := newOwner("owner", "uid-1")
+	gone := newOwner("gone", "uid-2")
+	recreated := newOwner("recreated", "uid-3")
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "uid-4"},
+	}
+
+	clt := fake.NewClientBuilder().WithScheme(newScheme(g)).WithObjects(
+		newOwner("owner", "uid-1"), newOwner("recreated", "uid-new"),
+		newChild("owned", owner),
+		newChild("orphan", gone),
+		newChild("stale-owner", recreated),
+		newChild("partially-owned", gone, owner),
+		newChild("no-owner"),
+		newChild("other-kind", pod),
+	).Build()
+
+	cleaner := &OrphanCleaner{
+		Client:   clt,
+		OwnerGVK: owner.GroupVersionKind(),
+		NewList:  func() client.ObjectList { return &corev1.SecretList{} },
+		DryRun:   true,
+	}
+	orphans, err := cleaner.Clean(ctx, client.InNamespace("default"))
+	g.Expect(err).To(BeNil())
+	g.Expect(orphans).To(HaveLen(2))
+
+	list := &corev1.SecretList{}
+	g.Expect(clt.List(ctx, list)).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(6))
+
+	cleaner.DryRun = false
+	orphans, err = cleaner.Clean(ctx, client.InNamespace("default"))
+	g.Expect(err).To(BeNil())
+	g.Expect(orphans).To(HaveLen(2))
+	g.Expect(orphans[0].GetName()).To(Equal("orphan"))
+	g.Expect(orphans[1].GetName()).To(Equal("stale-owner"))
+
+	g.Expect(clt.List(ctx, list)).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(4))
+}
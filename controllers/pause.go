@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ConditionsObject is implemented by objects storing metav1.Condition in their status,
+// allowing wrappers like NewPauseReconciler to record conditions on them
+type ConditionsObject interface {
+	client.Object
+	// GetConditions returns a pointer to the conditions of the status
+	GetConditions() *[]metav1.Condition
+}
+
+// PausedPredicate filters out events of objects paused with the v1alpha1.PausedAnnotationKey annotation.
+// Updates adding or removing the annotation are accepted so the Paused condition can be recorded.
+type PausedPredicate struct{}
+
+var _ predicate.Predicate = PausedPredicate{}
+
+// Create implements Predicate interface for creation events.
+func (PausedPredicate) Create(e event.CreateEvent) bool {
+	return e.Object != nil && !metav1alpha1.IsPaused(e.Object)
+}
+
+// Delete implements Predicate interface for deletion events.
+func (PausedPredicate) Delete(e event.DeleteEvent) bool {
+	return e.Object != nil && !metav1alpha1.IsPaused(e.Object)
+}
+
+// Generic implements Predicate interface for generic events.
+func (PausedPredicate) Generic(e event.GenericEvent) bool {
+	return e.Object != nil && !metav1alpha1.IsPaused(e.Object)
+}
+
+// Update implements Predicate interface for update events.
+// It accepts updates of objects not paused and updates changing the paused state.
+func (PausedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectNew == nil {
+		return false
+	}
+	if !metav1alpha1.IsPaused(e.ObjectNew) {
+		return true
+	}
+	return e.ObjectOld == nil || !metav1alpha1.IsPaused(e.ObjectOld)
+}
+
+// pauseReconciler skips reconciling paused objects and records their Paused condition
+type pauseReconciler struct {
+	reconciler reconcile.Reconciler
+	client     client.Client
+	object     client.Object
+}
+
+// NewPauseReconciler returns a reconciler skipping objects paused with the v1alpha1.PausedAnnotationKey annotation.
+// obj is an empty object of the reconciled type used to get the object of each request.
+// A Paused condition is recorded in the status of objects implementing duckv1.KRShaped or ConditionsObject,
+// set to True while paused and to False once resumed. Objects not found are passed to r.
+func NewPauseReconciler(r reconcile.Reconciler, clt client.Client, obj client.Object) reconcile.Reconciler {
+	return &pauseReconciler{reconciler: r, client: clt, object: obj}
+}
+
+// Reconcile implements reconcile.Reconciler
+func (p *pauseReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	obj := p.object.DeepCopyObject().(client.Object)
+	if err := p.client.Get(ctx, request.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return p.reconciler.Reconcile(ctx, request)
+		}
+		return reconcile.Result{}, err
+	}
+
+	paused := metav1alpha1.IsPaused(obj)
+	if setPausedCondition(obj, paused) {
+		if err := p.client.Status().Update(ctx, obj); err != nil {
+			return reconcile.Result{}, fmt.Errorf("update paused condition failed: %w", err)
+		}
+	}
+	if paused {
+		return reconcile.Result{}, nil
+	}
+	return p.reconciler.Reconcile(ctx, request)
+}
+
+// setPausedCondition sets the Paused condition of obj, returns true if it was changed.
+// Objects never paused are left without the condition
+func setPausedCondition(obj client.Object, paused bool) bool {
+	status, reason, message := metav1.ConditionFalse, metav1alpha1.ConditionReasonResumed, "reconciliation resumed"
+	if paused {
+		status, reason = metav1.ConditionTrue, metav1alpha1.ConditionReasonPaused
+		message = fmt.Sprintf("reconciliation paused by annotation %s", metav1alpha1.PausedAnnotationKey)
+	}
+
+	switch o := obj.(type) {
+	case duckv1.KRShaped:
+		conditionType := apis.ConditionType(metav1alpha1.ConditionPaused)
+		existing := o.GetStatus().GetCondition(conditionType)
+		if (existing == nil && !paused) ||
+			(existing != nil && string(existing.Status) == string(status) && existing.Reason == reason) {
+			return false
+		}
+		o.GetConditionSet().Manage(o.GetStatus()).SetCondition(apis.Condition{
+			Type:     conditionType,
+			Status:   corev1.ConditionStatus(status),
+			Reason:   reason,
+			Message:  message,
+			Severity: apis.ConditionSeverityInfo,
+		})
+		return true
+	case ConditionsObject:
+		manager := metav1alpha1.NewConditionManager(o.GetConditions(), o.GetGeneration())
+		existing := manager.GetCondition(metav1alpha1.ConditionPaused)
+		if (existing == nil && !paused) ||
+			(existing != nil && existing.Status == status && existing.Reason == reason) {
+			return false
+		}
+		manager.SetCondition(metav1.Condition{
+			Type:    string(metav1alpha1.ConditionPaused),
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// conditionsObject is a minimal object storing metav1.Condition in its status
+type conditionsObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            struct {
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+func (o *conditionsObject) GetConditions() *[]metav1.Condition {
+	return &o.Status.Conditions
+}
+
+func (o *conditionsObject) DeepCopyObject() runtime.Object {
+	out := &conditionsObject{TypeMeta: o.TypeMeta}
+	o.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	for _, condition := range o.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, *condition.DeepCopy())
+	}
+	return out
+}
+
+var _ ConditionsObject = &conditionsObject{}
+
+func pausedAnnotations(paused *bool) map[string]string {
+	if paused == nil {
+		return nil
+	}
+	return map[string]string{metav1alpha1.PausedAnnotationKey: strconv.FormatBool(*paused)}
+}
+
+func TestPausedPredicate(t *testing.T) {
+	paused := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{metav1alpha1.PausedAnnotationKey: "true"}}}
+	active := &corev1.ConfigMap{}
+
+	var data = []struct {
+		desc     string
+		eval     func(p PausedPredicate) bool
+		expected bool
+	}{
+		{"create active", func(p PausedPredicate) bool { return p.Create(event.CreateEvent{Object: active}) }, true},
+		{"create paused", func(p PausedPredicate) bool { return p.Create(event.CreateEvent{Object: paused}) }, false},
+		{"delete paused", func(p PausedPredicate) bool { return p.Delete(event.DeleteEvent{Object: paused}) }, false},
+		{"generic paused", func(p PausedPredicate) bool { return p.Generic(event.GenericEvent{Object: paused}) }, false},
+		{"generic nil", func(p PausedPredicate) bool { return p.Generic(event.GenericEvent{}) }, false},
+		{"update active", func(p PausedPredicate) bool {
+			return p.Update(event.UpdateEvent{ObjectOld: active, ObjectNew: active})
+		}, true},
+		{"update pausing", func(p PausedPredicate) bool {
+			return p.Update(event.UpdateEvent{ObjectOld: active, ObjectNew: paused})
+		}, true},
+		{"update resuming", func(p PausedPredicate) bool {
+			return p.Update(event.UpdateEvent{ObjectOld: paused, ObjectNew: active})
+		}, true},
+		{"update while paused", func(p PausedPredicate) bool {
+			return p.Update(event.UpdateEvent{ObjectOld: paused, ObjectNew: paused})
+		}, false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(item.eval(PausedPredicate{})).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestPauseReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	gv := schema.GroupVersion{Group: "example.com", Version: "v1"}
+	scheme.AddKnownTypeWithName(gv.WithKind("Conditions"), &conditionsObject{})
+	scheme.AddKnownTypeWithName(gv.WithKind("KResource"), &duckv1.KResource{})
+	metav1.AddToGroupVersion(scheme, gv)
+	_ = corev1.AddToScheme(scheme)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "foo"}}
+	truePtr, falsePtr := func(b bool) *bool { return &b }(true), func(b bool) *bool { return &b }(false)
+
+	var data = []struct {
+		desc   string
+		obj    client.Object
+		paused *bool
+
+		reconciled bool
+		condition  *metav1.Condition
+	}{
+		{desc: "conditions object not paused", obj: &conditionsObject{}, reconciled: true},
+		{desc: "conditions object paused", obj: &conditionsObject{}, paused: truePtr,
+			condition: &metav1.Condition{Status: metav1.ConditionTrue, Reason: metav1alpha1.ConditionReasonPaused}},
+		{desc: "conditions object resumed", obj: &conditionsObject{Status: struct {
+			Conditions []metav1.Condition `json:"conditions,omitempty"`
+		}{Conditions: []metav1.Condition{{Type: "Paused", Status: metav1.ConditionTrue, Reason: "Paused", LastTransitionTime: metav1.Now()}}}},
+			paused: falsePtr, reconciled: true,
+			condition: &metav1.Condition{Status: metav1.ConditionFalse, Reason: metav1alpha1.ConditionReasonResumed}},
+		{desc: "knative object paused", obj: &duckv1.KResource{}, paused: truePtr,
+			condition: &metav1.Condition{Status: metav1.ConditionTrue, Reason: metav1alpha1.ConditionReasonPaused}},
+		{desc: "knative object not paused", obj: &duckv1.KResource{}, reconciled: true},
+		{desc: "object without conditions paused", obj: &corev1.ConfigMap{}, paused: truePtr},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			ctx := context.Background()
+
+			obj := item.obj.DeepCopyObject().(client.Object)
+			obj.SetNamespace("default")
+			obj.SetName("foo")
+			obj.SetAnnotations(pausedAnnotations(item.paused))
+			clt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).WithStatusSubresource(obj).Build()
+
+			reconciled := false
+			r := NewPauseReconciler(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			}), clt, item.obj)
+
+			_, err := r.Reconcile(ctx, request)
+			g.Expect(err).To(Succeed())
+			g.Expect(reconciled).To(Equal(item.reconciled))
+
+			current := item.obj.DeepCopyObject().(client.Object)
+			g.Expect(clt.Get(ctx, request.NamespacedName, current)).To(Succeed())
+			condition := pausedCondition(current)
+			if item.condition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(item.condition.Status))
+			g.Expect(condition.Reason).To(Equal(item.condition.Reason))
+		})
+	}
+
+	t.Run("object not found is passed to the reconciler", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		clt := fake.NewClientBuilder().WithScheme(scheme).Build()
+		reconciled := false
+		r := NewPauseReconciler(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			reconciled = true
+			return reconcile.Result{}, nil
+		}), clt, &conditionsObject{})
+
+		_, err := r.Reconcile(context.Background(), request)
+		g.Expect(err).To(Succeed())
+		g.Expect(reconciled).To(BeTrue())
+	})
+}
+
+// pausedCondition returns the Paused condition of obj as a metav1.Condition
+func pausedCondition(obj client.Object) *metav1.Condition {
+	switch o := obj.(type) {
+	case *duckv1.KResource:
+		if c := o.Status.GetCondition("Paused"); c != nil {
+			return &metav1.Condition{Status: metav1.ConditionStatus(c.Status), Reason: c.Reason}
+		}
+	case *conditionsObject:
+		return metav1alpha1.NewConditionManager(o.GetConditions(), 0).GetCondition(metav1alpha1.ConditionPaused)
+	}
+	return nil
+}
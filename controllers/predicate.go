@@ -30,20 +30,60 @@ type SecretDataChangedPredicate struct {
 }
 
 // Update implements default UpdateEvent filter for validating generation change.
+// Objects that are not secrets are filtered out.
 func (SecretDataChangedPredicate) Update(e event.UpdateEvent) bool {
-	if e.ObjectOld == nil {
+	oldObj, ok := e.ObjectOld.(*corev1.Secret)
+	if !ok || oldObj == nil {
 		return false
 	}
-	oldObj := e.ObjectOld.(*corev1.Secret)
-
-	if e.ObjectNew == nil {
+	newObj, ok := e.ObjectNew.(*corev1.Secret)
+	if !ok || newObj == nil {
 		return false
 	}
-	newObj := e.ObjectNew.(*corev1.Secret)
 
 	return !reflect.DeepEqual(oldObj.Data, newObj.Data)
 }
 
+// ConfigMapDataChangedPredicate implements a default update predicate function on configmap data change.
+type ConfigMapDataChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements default UpdateEvent filter for validating Data and BinaryData change.
+// Objects that are not configmaps are filtered out.
+func (ConfigMapDataChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldObj, ok := e.ObjectOld.(*corev1.ConfigMap)
+	if !ok || oldObj == nil {
+		return false
+	}
+	newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+	if !ok || newObj == nil {
+		return false
+	}
+
+	return !reflect.DeepEqual(oldObj.Data, newObj.Data) ||
+		!reflect.DeepEqual(oldObj.BinaryData, newObj.BinaryData)
+}
+
+// DataChangedPredicate implements a default update predicate function on
+// data change for both secrets and configmaps, so a single watch setup can
+// cover configuration sources. Update events of any other kind are filtered out.
+type DataChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements default UpdateEvent filter for validating secret or configmap data change.
+func (DataChangedPredicate) Update(e event.UpdateEvent) bool {
+	switch e.ObjectNew.(type) {
+	case *corev1.Secret:
+		return SecretDataChangedPredicate{}.Update(e)
+	case *corev1.ConfigMap:
+		return ConfigMapDataChangedPredicate{}.Update(e)
+	default:
+		return false
+	}
+}
+
 // AnnotationChangedPredicate implements a predicate that checks for changes in specific annotations.
 // It extends the default AnnotationChangedPredicate from controller-runtime and allows filtering
 // on specific annotation keys.
@@ -105,6 +145,87 @@ func (p AnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
 	return valuesChangeInMap(p.Keys, e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations())
 }
 
+// LabelChangedPredicate implements a predicate that checks for changes in specific labels.
+// It extends the default LabelChangedPredicate from controller-runtime and allows filtering
+// on specific label keys.
+type LabelChangedPredicate struct {
+	// Keys is a list of label keys to watch for changes.
+	// If empty, all label changes will be considered.
+	Keys []string
+	predicate.LabelChangedPredicate
+}
+
+// Create implements Predicate interface for creation events.
+// It checks if any of the specified label keys have changed from nil to a value.
+func (p LabelChangedPredicate) Create(e event.CreateEvent) bool {
+
+	if len(p.Keys) == 0 {
+		return p.LabelChangedPredicate.Create(e)
+	}
+
+	return valuesChangeInMap(p.Keys, nil, e.Object.GetLabels())
+}
+
+// Delete implements Predicate interface for deletion events.
+// It checks if any of the specified label keys have changed from a value to nil.
+func (p LabelChangedPredicate) Delete(e event.DeleteEvent) bool {
+
+	if len(p.Keys) == 0 {
+		return p.LabelChangedPredicate.Delete(e)
+	}
+
+	return valuesChangeInMap(p.Keys, e.Object.GetLabels(), nil)
+}
+
+// Generic implements Predicate interface for generic events.
+// It checks if any of the specified label keys have changed.
+func (p LabelChangedPredicate) Generic(e event.GenericEvent) bool {
+
+	if len(p.Keys) == 0 {
+		return p.LabelChangedPredicate.Generic(e)
+	}
+
+	return valuesChangeInMap(p.Keys, e.Object.GetLabels(), nil)
+}
+
+// Update implements Predicate interface for update events.
+// It checks if any of the specified label keys have different values between old and new objects.
+func (p LabelChangedPredicate) Update(e event.UpdateEvent) bool {
+
+	if len(p.Keys) == 0 {
+		return p.LabelChangedPredicate.Update(e)
+	}
+
+	if e.ObjectOld == nil {
+		return false
+	}
+	if e.ObjectNew == nil {
+		return false
+	}
+
+	return valuesChangeInMap(p.Keys, e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+}
+
+// SpecOrAnnotationChangedPredicate implements a predicate that accepts update events
+// when the generation changed or any of the specified annotations changed.
+// It allows resources annotated for a forced re-sync to be reconciled
+// while pure status updates are still filtered out.
+type SpecOrAnnotationChangedPredicate struct {
+	// Keys is a list of annotation keys to watch for changes.
+	// If empty, all annotation changes will be considered.
+	Keys []string
+	predicate.Funcs
+}
+
+// Update implements Predicate interface for update events.
+// It checks if the generation or any of the specified annotation keys changed between old and new objects.
+func (p SpecOrAnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
+	if (predicate.GenerationChangedPredicate{}).Update(e) {
+		return true
+	}
+	return AnnotationChangedPredicate{Keys: p.Keys}.Update(e)
+}
+
 // valuesChangeInMap checks if any of the specified keys have different values in two maps.
 // Returns true if there's a difference in values for any of the specified keys.
 func valuesChangeInMap(keys []string, old, new map[string]string) bool {
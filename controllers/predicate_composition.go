@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// predicate operators used in CompositePredicate
+const (
+	operatorAnd = "and"
+	operatorOr  = "or"
+	operatorNot = "not"
+)
+
+// NamedPredicate is a predicate with a name used when logging
+// which predicate rejected an event
+type NamedPredicate interface {
+	predicate.Predicate
+	Name() string
+}
+
+type namedPredicate struct {
+	predicate.Predicate
+	name string
+}
+
+// Name returns the name of the predicate
+func (p namedPredicate) Name() string {
+	return p.name
+}
+
+// Named gives a name to a predicate, used when logging rejected events
+func Named(name string, p predicate.Predicate) NamedPredicate {
+	return namedPredicate{Predicate: p, name: name}
+}
+
+// predicateName returns the name of a NamedPredicate or its type
+func predicateName(p predicate.Predicate) string {
+	if named, ok := p.(NamedPredicate); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", p)
+}
+
+// CompositePredicate combines predicates with a logical operator and
+// optionally logs at debug level which predicate rejected an event
+type CompositePredicate struct {
+	operator   string
+	predicates []predicate.Predicate
+	logger     *zap.SugaredLogger
+}
+
+var _ predicate.Predicate = &CompositePredicate{}
+
+// AllOf returns a predicate that accepts events accepted by all the predicates
+func AllOf(predicates ...predicate.Predicate) *CompositePredicate {
+	return &CompositePredicate{operator: operatorAnd, predicates: predicates}
+}
+
+// AnyOf returns a predicate that accepts events accepted by any of the predicates
+func AnyOf(predicates ...predicate.Predicate) *CompositePredicate {
+	return &CompositePredicate{operator: operatorOr, predicates: predicates}
+}
+
+// Negate returns a predicate that accepts events rejected by the predicate
+func Negate(p predicate.Predicate) *CompositePredicate {
+	return &CompositePredicate{operator: operatorNot, predicates: []predicate.Predicate{p}}
+}
+
+// WithContext logs rejected events at debug level using the logger of ctx, see logger.GetLogger
+func (c *CompositePredicate) WithContext(ctx context.Context) *CompositePredicate {
+	c.logger = logger.GetLogger(ctx)
+	return c
+}
+
+// Create implements Predicate interface for creation events.
+func (c *CompositePredicate) Create(e event.CreateEvent) bool {
+	return c.eval("create", e.Object, func(p predicate.Predicate) bool { return p.Create(e) })
+}
+
+// Delete implements Predicate interface for deletion events.
+func (c *CompositePredicate) Delete(e event.DeleteEvent) bool {
+	return c.eval("delete", e.Object, func(p predicate.Predicate) bool { return p.Delete(e) })
+}
+
+// Update implements Predicate interface for update events.
+func (c *CompositePredicate) Update(e event.UpdateEvent) bool {
+	return c.eval("update", e.ObjectNew, func(p predicate.Predicate) bool { return p.Update(e) })
+}
+
+// Generic implements Predicate interface for generic events.
+func (c *CompositePredicate) Generic(e event.GenericEvent) bool {
+	return c.eval("generic", e.Object, func(p predicate.Predicate) bool { return p.Generic(e) })
+}
+
+func (c *CompositePredicate) eval(eventType string, obj client.Object, accepts func(predicate.Predicate) bool) bool {
+	switch c.operator {
+	case operatorAnd:
+		for _, p := range c.predicates {
+			if !accepts(p) {
+				c.logRejected(eventType, obj, predicateName(p))
+				return false
+			}
+		}
+		return true
+	case operatorOr:
+		for _, p := range c.predicates {
+			if accepts(p) {
+				return true
+			}
+		}
+		c.logRejected(eventType, obj, "all predicates")
+		return false
+	case operatorNot:
+		if accepts(c.predicates[0]) {
+			c.logRejected(eventType, obj, "not "+predicateName(c.predicates[0]))
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func (c *CompositePredicate) logRejected(eventType string, obj client.Object, rejectedBy string) {
+	if c.logger == nil {
+		return
+	}
+	keysAndValues := []interface{}{"operator", c.operator, "event", eventType, "rejectedBy", rejectedBy}
+	if obj != nil {
+		keysAndValues = append(keysAndValues, "namespace", obj.GetNamespace(), "name", obj.GetName())
+	}
+	c.logger.Debugw("predicate rejected event", keysAndValues...)
+}
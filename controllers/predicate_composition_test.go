@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/AlaudaDevops/pkg/command/logger"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+func TestPredicateComposition(t *testing.T) {
+	accept := predicate.NewPredicateFuncs(func(client.Object) bool { return true })
+	reject := predicate.NewPredicateFuncs(func(client.Object) bool { return false })
+
+	var data = []struct {
+		desc      string
+		predicate predicate.Predicate
+		expected  bool
+	}{
+		{desc: "and all accept", predicate: AllOf(accept, accept), expected: true},
+		{desc: "and one rejects", predicate: AllOf(accept, reject), expected: false},
+		{desc: "and empty", predicate: AllOf(), expected: true},
+		{desc: "or one accepts", predicate: AnyOf(reject, accept), expected: true},
+		{desc: "or all reject", predicate: AnyOf(reject, reject), expected: false},
+		{desc: "not accept", predicate: Negate(accept), expected: false},
+		{desc: "not reject", predicate: Negate(reject), expected: true},
+		{desc: "nested", predicate: AllOf(accept, AnyOf(reject, Negate(reject))), expected: true},
+	}
+
+	obj := &corev1.ConfigMap{}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(item.predicate.Create(event.CreateEvent{Object: obj})).To(Equal(item.expected))
+			g.Expect(item.predicate.Delete(event.DeleteEvent{Object: obj})).To(Equal(item.expected))
+			g.Expect(item.predicate.Update(event.UpdateEvent{ObjectOld: obj, ObjectNew: obj})).To(Equal(item.expected))
+			g.Expect(item.predicate.Generic(event.GenericEvent{Object: obj})).To(Equal(item.expected))
+		})
+	}
+}
+
+func TestPredicateCompositionLogging(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	buf := &bytes.Buffer{}
+	ctx := logger.WithLogger(context.Background(), logger.NewLogger(zapcore.AddSync(buf), zapcore.DebugLevel))
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}}
+
+	p := AllOf(predicate.GenerationChangedPredicate{}, Named("has-data", DataChangedPredicate{})).WithContext(ctx)
+	g.Expect(p.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+	g.Expect(buf.String()).To(BeEmpty())
+
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: obj, ObjectNew: obj})).To(BeFalse())
+	g.Expect(buf.String()).To(ContainSubstring("predicate rejected event"))
+	g.Expect(buf.String()).To(ContainSubstring(`"rejectedBy": "predicate.TypedGenerationChangedPredicate`))
+	g.Expect(buf.String()).To(ContainSubstring(`"name": "cm"`))
+
+	buf.Reset()
+	AnyOf(Named("has-data", DataChangedPredicate{})).WithContext(ctx).Update(event.UpdateEvent{ObjectOld: obj, ObjectNew: obj})
+	g.Expect(buf.String()).To(ContainSubstring(`"rejectedBy": "all predicates"`))
+}
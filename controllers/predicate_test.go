@@ -91,6 +91,78 @@ func TestSecretDataChangedPredicate(t *testing.T) {
 	}
 }
 
+func TestConfigMapDataChangedPredicate(t *testing.T) {
+	var data = []struct {
+		desc string
+		old  *corev1.ConfigMap
+		new  *corev1.ConfigMap
+
+		expected bool
+	}{
+		{
+			desc:     "no changes",
+			old:      &corev1.ConfigMap{Data: map[string]string{"a": "1"}},
+			new:      &corev1.ConfigMap{Data: map[string]string{"a": "1"}},
+			expected: false,
+		},
+		{
+			desc:     "data changes",
+			old:      &corev1.ConfigMap{Data: map[string]string{"a": "1"}},
+			new:      &corev1.ConfigMap{Data: map[string]string{"a": "2"}},
+			expected: true,
+		},
+		{
+			desc:     "binary data changes",
+			old:      &corev1.ConfigMap{BinaryData: map[string][]byte{"a": []byte("1")}},
+			new:      &corev1.ConfigMap{BinaryData: map[string][]byte{"a": []byte("2")}},
+			expected: true,
+		},
+		{
+			desc:     "old is nil",
+			old:      nil,
+			new:      &corev1.ConfigMap{Data: map[string]string{"a": "1"}},
+			expected: false,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			e := event.UpdateEvent{ObjectOld: item.old, ObjectNew: item.new}
+			if item.old == nil {
+				e.ObjectOld = nil
+			}
+
+			g.Expect(ConfigMapDataChangedPredicate{}.Update(e)).Should(BeEquivalentTo(item.expected))
+			g.Expect(DataChangedPredicate{}.Update(e)).Should(BeEquivalentTo(item.expected))
+		})
+	}
+}
+
+func TestDataChangedPredicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(DataChangedPredicate{}.Update(event.UpdateEvent{
+		ObjectOld: &corev1.Secret{Data: map[string][]byte{"a": []byte("1")}},
+		ObjectNew: &corev1.Secret{Data: map[string][]byte{"a": []byte("2")}},
+	})).To(BeTrue())
+
+	// mismatched kinds and other kinds should not panic
+	g.Expect(DataChangedPredicate{}.Update(event.UpdateEvent{
+		ObjectOld: &corev1.ConfigMap{},
+		ObjectNew: &corev1.Secret{},
+	})).To(BeFalse())
+	g.Expect(DataChangedPredicate{}.Update(event.UpdateEvent{
+		ObjectOld: &corev1.Pod{},
+		ObjectNew: &corev1.Pod{},
+	})).To(BeFalse())
+	g.Expect(SecretDataChangedPredicate{}.Update(event.UpdateEvent{
+		ObjectOld: &corev1.ConfigMap{},
+		ObjectNew: &corev1.ConfigMap{},
+	})).To(BeFalse())
+	g.Expect(DataChangedPredicate{}.Create(event.CreateEvent{Object: &corev1.Pod{}})).To(BeTrue())
+}
+
 func TestAnnotationChangedPredicate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -208,3 +280,176 @@ func TestAnnotationChangedPredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelChangedPredicate(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		oldLabels map[string]string
+		newLabels map[string]string
+		eventType string // "create", "update", "delete", "generic"
+		expected  bool
+	}{
+		{
+			name:      "create event - no keys specified",
+			newLabels: map[string]string{"test": "value"},
+			eventType: "create",
+			expected:  true,
+		},
+		{
+			name:      "create event - specific key added",
+			keys:      []string{"test"},
+			newLabels: map[string]string{"test": "value"},
+			eventType: "create",
+			expected:  true,
+		},
+		{
+			name:      "create event - irrelevant key added",
+			keys:      []string{"test"},
+			newLabels: map[string]string{"other": "value"},
+			eventType: "create",
+			expected:  false,
+		},
+		{
+			name:      "update event - no keys specified and labels changed",
+			oldLabels: map[string]string{"other": "a"},
+			newLabels: map[string]string{"other": "b"},
+			eventType: "update",
+			expected:  true,
+		},
+		{
+			name:      "update event - specific key changed",
+			keys:      []string{"test"},
+			oldLabels: map[string]string{"test": "a", "other": "a"},
+			newLabels: map[string]string{"test": "b", "other": "a"},
+			eventType: "update",
+			expected:  true,
+		},
+		{
+			name:      "update event - only irrelevant key changed",
+			keys:      []string{"test"},
+			oldLabels: map[string]string{"test": "a", "other": "a"},
+			newLabels: map[string]string{"test": "a", "other": "b"},
+			eventType: "update",
+			expected:  false,
+		},
+		{
+			name:      "delete event - specific key present",
+			keys:      []string{"test"},
+			oldLabels: map[string]string{"test": "a"},
+			eventType: "delete",
+			expected:  true,
+		},
+		{
+			name:      "generic event - specific key missing",
+			keys:      []string{"test"},
+			oldLabels: map[string]string{"other": "a"},
+			eventType: "generic",
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			pred := LabelChangedPredicate{
+				Keys: tt.keys,
+			}
+
+			var result bool
+			switch tt.eventType {
+			case "create":
+				obj := &corev1.Pod{}
+				obj.SetLabels(tt.newLabels)
+				result = pred.Create(event.CreateEvent{Object: obj})
+			case "update":
+				oldObj := &corev1.Pod{}
+				newObj := &corev1.Pod{}
+				oldObj.SetLabels(tt.oldLabels)
+				newObj.SetLabels(tt.newLabels)
+				result = pred.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})
+			case "delete":
+				obj := &corev1.Pod{}
+				obj.SetLabels(tt.oldLabels)
+				result = pred.Delete(event.DeleteEvent{Object: obj})
+			case "generic":
+				obj := &corev1.Pod{}
+				obj.SetLabels(tt.oldLabels)
+				result = pred.Generic(event.GenericEvent{Object: obj})
+			}
+
+			g.Expect(result).Should(Equal(tt.expected))
+		})
+	}
+}
+
+func TestSpecOrAnnotationChangedPredicate(t *testing.T) {
+	tests := []struct {
+		name           string
+		keys           []string
+		oldGeneration  int64
+		newGeneration  int64
+		oldAnnotations map[string]string
+		newAnnotations map[string]string
+		expected       bool
+	}{
+		{
+			name:          "generation changed",
+			keys:          []string{"cpaas.io/forceSync"},
+			oldGeneration: 1,
+			newGeneration: 2,
+			expected:      true,
+		},
+		{
+			name:           "specified annotation changed",
+			keys:           []string{"cpaas.io/forceSync"},
+			oldGeneration:  1,
+			newGeneration:  1,
+			oldAnnotations: map[string]string{"cpaas.io/forceSync": "1"},
+			newAnnotations: map[string]string{"cpaas.io/forceSync": "2"},
+			expected:       true,
+		},
+		{
+			name:           "irrelevant annotation changed",
+			keys:           []string{"cpaas.io/forceSync"},
+			oldGeneration:  1,
+			newGeneration:  1,
+			oldAnnotations: map[string]string{"other": "1"},
+			newAnnotations: map[string]string{"other": "2"},
+			expected:       false,
+		},
+		{
+			name:           "any annotation changed without keys",
+			oldGeneration:  1,
+			newGeneration:  1,
+			oldAnnotations: map[string]string{"other": "1"},
+			newAnnotations: map[string]string{"other": "2"},
+			expected:       true,
+		},
+		{
+			name:          "status only update",
+			keys:          []string{"cpaas.io/forceSync"},
+			oldGeneration: 1,
+			newGeneration: 1,
+			expected:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			oldObj := &corev1.Pod{}
+			newObj := &corev1.Pod{}
+			oldObj.SetGeneration(tt.oldGeneration)
+			newObj.SetGeneration(tt.newGeneration)
+			oldObj.SetAnnotations(tt.oldAnnotations)
+			newObj.SetAnnotations(tt.newAnnotations)
+
+			pred := SpecOrAnnotationChangedPredicate{Keys: tt.keys}
+			g.Expect(pred.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).Should(Equal(tt.expected))
+			g.Expect(pred.Create(event.CreateEvent{Object: newObj})).Should(BeTrue())
+		})
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package controllers
 
 import (
+	"sync"
 	"time"
 
+	"github.com/AlaudaDevops/pkg/clock"
 	"golang.org/x/time/rate"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -49,3 +51,133 @@ func TypedRateLimiter[T comparable](baseDelay time.Duration, maxDelay time.Durat
 		&workqueue.TypedBucketRateLimiter[T]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
 	)
 }
+
+// FastSlowRateLimiter returns a rate limiter that retries quickly with fastDelay
+// for the first maxFastAttempts failures of an item and then slowly with slowDelay
+func FastSlowRateLimiter[T comparable](fastDelay, slowDelay time.Duration, maxFastAttempts int) workqueue.TypedRateLimiter[T] {
+	return workqueue.NewTypedItemFastSlowRateLimiter[T](fastDelay, slowDelay, maxFastAttempts)
+}
+
+// ExponentialRateLimiter returns a rate limiter doubling the delay of an item
+// on every failure starting at baseDelay and capped at maxDelay
+func ExponentialRateLimiter[T comparable](baseDelay, maxDelay time.Duration) workqueue.TypedRateLimiter[T] {
+	return workqueue.NewTypedItemExponentialFailureRateLimiter[T](baseDelay, maxDelay)
+}
+
+// BucketRateLimiter returns a global token bucket rate limiter shared by all items
+// allowing qps items per second with bursts of burst items
+func BucketRateLimiter[T comparable](qps float64, burst int) workqueue.TypedRateLimiter[T] {
+	return &workqueue.TypedBucketRateLimiter[T]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)}
+}
+
+// FairTypedRateLimiter returns a rate limiter combining an exponential failure backoff
+// between baseDelay and maxDelay, a token bucket per item of perObjectQPS and perObjectBurst
+// so noisy objects do not starve the queue, and the global token bucket of DefaultTypedRateLimiter
+func FairTypedRateLimiter[T comparable](baseDelay, maxDelay time.Duration, perObjectQPS float64, perObjectBurst int) workqueue.TypedRateLimiter[T] {
+	return workqueue.NewTypedMaxOfRateLimiter(
+		ExponentialRateLimiter[T](baseDelay, maxDelay),
+		NewPerObjectRateLimiter[T](perObjectQPS, perObjectBurst),
+		BucketRateLimiter[T](10, 100),
+	)
+}
+
+// perObjectPruneInterval how often idle item limiters are removed
+const perObjectPruneInterval = time.Minute
+
+// PerObjectRateLimiter is a rate limiter keeping a token bucket per item,
+// like the namespaced name of a reconcile.Request, limiting how often each item is processed.
+// Contrary to failure rate limiters limits are kept when an item is forgotten,
+// and buckets are removed once they are full again.
+type PerObjectRateLimiter[T comparable] struct {
+	qps   rate.Limit
+	burst int
+	clock clock.PassiveClock
+
+	lock      sync.Mutex
+	limiters  map[T]*rate.Limiter
+	lastPrune time.Time
+}
+
+var _ workqueue.TypedRateLimiter[string] = &PerObjectRateLimiter[string]{}
+
+// PerObjectRateLimiterOption configures a PerObjectRateLimiter
+type PerObjectRateLimiterOption[T comparable] func(*PerObjectRateLimiter[T])
+
+// WithPerObjectClock sets the clock used by the PerObjectRateLimiter
+func WithPerObjectClock[T comparable](clock clock.PassiveClock) PerObjectRateLimiterOption[T] {
+	return func(r *PerObjectRateLimiter[T]) {
+		r.clock = clock
+	}
+}
+
+// NewPerObjectRateLimiter returns a PerObjectRateLimiter allowing each item
+// to be processed qps times per second with bursts of burst
+func NewPerObjectRateLimiter[T comparable](qps float64, burst int, opts ...PerObjectRateLimiterOption[T]) *PerObjectRateLimiter[T] {
+	r := &PerObjectRateLimiter[T]{
+		qps:      rate.Limit(qps),
+		burst:    burst,
+		clock:    clock.RealClock{},
+		limiters: map[T]*rate.Limiter{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.lastPrune = r.clock.Now()
+	return r
+}
+
+// When returns how long item should wait to respect its own rate limit
+func (r *PerObjectRateLimiter[T]) When(item T) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	r.prune(now)
+	limiter, ok := r.limiters[item]
+	if !ok {
+		limiter = rate.NewLimiter(r.qps, r.burst)
+		r.limiters[item] = limiter
+	}
+	return limiter.ReserveN(now, 1).DelayFrom(now)
+}
+
+// Forget removes the limiter of item only if it is idle
+// so items requeued in a loop remain limited
+func (r *PerObjectRateLimiter[T]) Forget(item T) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if limiter, ok := r.limiters[item]; ok && r.idle(limiter, r.clock.Now()) {
+		delete(r.limiters, item)
+	}
+}
+
+// NumRequeues returns 0 as the limiter does not count failures
+func (r *PerObjectRateLimiter[T]) NumRequeues(item T) int {
+	return 0
+}
+
+// Len returns the number of items tracked
+func (r *PerObjectRateLimiter[T]) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.limiters)
+}
+
+// prune removes idle limiters at most once per perObjectPruneInterval
+func (r *PerObjectRateLimiter[T]) prune(now time.Time) {
+	if now.Sub(r.lastPrune) < perObjectPruneInterval {
+		return
+	}
+	r.lastPrune = now
+	for item, limiter := range r.limiters {
+		if r.idle(limiter, now) {
+			delete(r.limiters, item)
+		}
+	}
+}
+
+// idle returns true when the bucket of limiter is full again
+func (r *PerObjectRateLimiter[T]) idle(limiter *rate.Limiter, now time.Time) bool {
+	return limiter.TokensAt(now) >= float64(r.burst)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func request(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+}
+
+func TestRateLimiterPresets(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fastSlow := FastSlowRateLimiter[string](time.Millisecond, time.Second, 2)
+	g.Expect(fastSlow.When("a")).To(Equal(time.Millisecond))
+	g.Expect(fastSlow.When("a")).To(Equal(time.Millisecond))
+	g.Expect(fastSlow.When("a")).To(Equal(time.Second))
+
+	exponential := ExponentialRateLimiter[string](time.Second, 3*time.Second)
+	g.Expect(exponential.When("a")).To(Equal(time.Second))
+	g.Expect(exponential.When("a")).To(Equal(2 * time.Second))
+	g.Expect(exponential.When("a")).To(Equal(3 * time.Second))
+	g.Expect(exponential.NumRequeues("a")).To(Equal(3))
+
+	bucket := BucketRateLimiter[string](1, 1)
+	g.Expect(bucket.When("a")).To(BeZero())
+	g.Expect(bucket.When("b")).To(BeNumerically(">", 0), "bucket is shared by all items")
+
+	fair := FairTypedRateLimiter[reconcile.Request](time.Millisecond, time.Second, 1, 1)
+	g.Expect(fair.When(request("a"))).To(Equal(time.Millisecond))
+	g.Expect(fair.When(request("a"))).To(BeNumerically(">", 900*time.Millisecond), "per object limit")
+	g.Expect(fair.When(request("b"))).To(Equal(time.Millisecond), "other objects are not affected")
+}
+
+func TestPerObjectRateLimiter(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := clock.NewFakeClock(time.Now())
+
+	limiter := NewPerObjectRateLimiter(1, 2, WithPerObjectClock[reconcile.Request](fake))
+	noisy, quiet := request("noisy"), request("quiet")
+
+	g.Expect(limiter.When(noisy)).To(BeZero())
+	g.Expect(limiter.When(noisy)).To(BeZero())
+	g.Expect(limiter.When(noisy)).To(Equal(time.Second))
+	g.Expect(limiter.When(noisy)).To(Equal(2 * time.Second))
+	g.Expect(limiter.When(quiet)).To(BeZero())
+	g.Expect(limiter.NumRequeues(noisy)).To(BeZero())
+
+	// limits are kept while the bucket is not full
+	limiter.Forget(noisy)
+	g.Expect(limiter.Len()).To(Equal(2))
+
+	fake.Advance(10 * time.Second)
+	limiter.Forget(noisy)
+	g.Expect(limiter.Len()).To(Equal(1))
+	g.Expect(limiter.When(noisy)).To(BeZero())
+
+	// idle limiters are pruned
+	fake.Advance(time.Minute)
+	g.Expect(limiter.When(noisy)).To(BeZero())
+	g.Expect(limiter.Len()).To(Equal(1))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultJitterFactor the default maximum fraction of the duration added by Result.RequeueAfterJitter
+const DefaultJitterFactor = 0.1
+
+// ErrorClass classification of a reconcile error
+type ErrorClass string
+
+const (
+	// ErrorClassNone no error
+	ErrorClassNone ErrorClass = ""
+	// ErrorClassConflict the object was modified concurrently, requeue immediately
+	ErrorClassConflict ErrorClass = "Conflict"
+	// ErrorClassTransient the error may go away on retry, requeue with backoff
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassPermanent retrying will not help, do not requeue
+	ErrorClassPermanent ErrorClass = "Permanent"
+)
+
+// ClassifyError classifies a reconcile error.
+// Conflicts are reported as ErrorClassConflict, terminal errors and
+// api errors caused by the request itself (bad request, invalid, forbidden,
+// unauthorized, method not supported) as ErrorClassPermanent,
+// and any other error as ErrorClassTransient.
+func ClassifyError(err error) ErrorClass {
+	switch {
+	case err == nil:
+		return ErrorClassNone
+	case errors.Is(err, reconcile.TerminalError(nil)):
+		return ErrorClassPermanent
+	case apierrors.IsConflict(err):
+		return ErrorClassConflict
+	case apierrors.IsBadRequest(err), apierrors.IsInvalid(err), apierrors.IsForbidden(err),
+		apierrors.IsUnauthorized(err), apierrors.IsMethodNotSupported(err), apierrors.IsNotAcceptable(err),
+		apierrors.IsUnsupportedMediaType(err), apierrors.IsRequestEntityTooLargeError(err):
+		return ErrorClassPermanent
+	default:
+		return ErrorClassTransient
+	}
+}
+
+// IsTransientError returns true if retrying may resolve the error
+func IsTransientError(err error) bool {
+	class := ClassifyError(err)
+	return class == ErrorClassTransient || class == ErrorClassConflict
+}
+
+// IsPermanentError returns true if retrying will not resolve the error
+func IsPermanentError(err error) bool {
+	return ClassifyError(err) == ErrorClassPermanent
+}
+
+// PermanentError marks err as permanent so it is not requeued
+func PermanentError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return reconcile.TerminalError(err)
+}
+
+// Result builds reconcile result and error pairs with consistent requeue semantics
+//
+//	return controllers.Result{}.RequeueOnError(err)
+type Result struct {
+	// JitterFactor the maximum fraction of the duration added by RequeueAfterJitter,
+	// defaults to DefaultJitterFactor when zero
+	JitterFactor float64
+}
+
+// Done finishes the reconcile without requeue
+func (Result) Done() (reconcile.Result, error) {
+	return reconcile.Result{}, nil
+}
+
+// Requeue requeues the request immediately, subject to the rate limiter
+func (Result) Requeue() (reconcile.Result, error) {
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// RequeueAfter requeues the request after d
+func (Result) RequeueAfter(d time.Duration) (reconcile.Result, error) {
+	return reconcile.Result{RequeueAfter: d}, nil
+}
+
+// RequeueAfterJitter requeues the request after d plus a random jitter
+// of up to JitterFactor of d, spreading requeues of many objects over time
+func (r Result) RequeueAfterJitter(d time.Duration) (reconcile.Result, error) {
+	factor := r.JitterFactor
+	if factor <= 0 {
+		factor = DefaultJitterFactor
+	}
+	return r.RequeueAfter(wait.Jitter(d, factor))
+}
+
+// RequeueOnError builds a result according to the class of err:
+// no error finishes the reconcile, conflicts requeue immediately without reporting an error,
+// transient errors are returned so the request is requeued with backoff,
+// and permanent errors are returned as terminal errors so they are logged but not requeued.
+func (r Result) RequeueOnError(err error) (reconcile.Result, error) {
+	switch ClassifyError(err) {
+	case ErrorClassNone:
+		return r.Done()
+	case ErrorClassConflict:
+		return r.Requeue()
+	case ErrorClassPermanent:
+		return reconcile.Result{}, PermanentError(err)
+	default:
+		return reconcile.Result{}, err
+	}
+}
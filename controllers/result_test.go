@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "configmaps"}
+	var data = []struct {
+		desc string
+		err  error
+
+		expected ErrorClass
+	}{
+		{desc: "nil", err: nil, expected: ErrorClassNone},
+		{desc: "conflict", err: apierrors.NewConflict(gr, "a", errors.New("changed")), expected: ErrorClassConflict},
+		{desc: "wrapped conflict", err: fmt.Errorf("update: %w", apierrors.NewConflict(gr, "a", errors.New("changed"))), expected: ErrorClassConflict},
+		{desc: "terminal", err: reconcile.TerminalError(errors.New("bad spec")), expected: ErrorClassPermanent},
+		{desc: "invalid", err: apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "a", nil), expected: ErrorClassPermanent},
+		{desc: "forbidden", err: apierrors.NewForbidden(gr, "a", errors.New("denied")), expected: ErrorClassPermanent},
+		{desc: "timeout", err: apierrors.NewTimeoutError("slow", 1), expected: ErrorClassTransient},
+		{desc: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), expected: ErrorClassTransient},
+		{desc: "unknown", err: errors.New("boom"), expected: ErrorClassTransient},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(ClassifyError(item.err)).To(Equal(item.expected))
+			g.Expect(IsPermanentError(item.err)).To(Equal(item.expected == ErrorClassPermanent))
+			g.Expect(IsTransientError(item.err)).To(Equal(item.expected == ErrorClassTransient || item.expected == ErrorClassConflict))
+		})
+	}
+}
+
+func TestResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result, err := Result{}.Done()
+	g.Expect(result).To(Equal(reconcile.Result{}))
+	g.Expect(err).To(BeNil())
+
+	result, err = Result{}.RequeueAfter(time.Minute)
+	g.Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+	g.Expect(err).To(BeNil())
+
+	for i := 0; i < 10; i++ {
+		result, _ = Result{}.RequeueAfterJitter(time.Minute)
+		g.Expect(result.RequeueAfter).To(BeNumerically(">=", time.Minute))
+		g.Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute+6*time.Second))
+
+		result, _ = Result{JitterFactor: 1}.RequeueAfterJitter(time.Minute)
+		g.Expect(result.RequeueAfter).To(BeNumerically("<=", 2*time.Minute))
+	}
+
+	result, err = Result{}.RequeueOnError(nil)
+	g.Expect(result).To(Equal(reconcile.Result{}))
+	g.Expect(err).To(BeNil())
+
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "a", errors.New("changed"))
+	result, err = Result{}.RequeueOnError(conflict)
+	g.Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+	g.Expect(err).To(BeNil())
+
+	transient := errors.New("boom")
+	result, err = Result{}.RequeueOnError(transient)
+	g.Expect(result).To(Equal(reconcile.Result{}))
+	g.Expect(err).To(Equal(transient))
+
+	permanent := apierrors.NewBadRequest("bad")
+	_, err = Result{}.RequeueOnError(permanent)
+	g.Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+	g.Expect(errors.Is(err, permanent)).To(BeTrue())
+
+	g.Expect(PermanentError(nil)).To(BeNil())
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resync periodically enqueues all objects of a kind into a controller,
+// independently of informer resyncs, to re-verify external state that produces no cluster events
+// like webhooks registered in a Git server.
+//
+//	resyncer := resync.New(mgr.GetClient(), &v1alpha1.RepositoryList{}, 30*time.Minute,
+//		resync.WithJitter(0.2), resync.WithLabelSelector(selector))
+//	err := ctrl.NewControllerManagedBy(mgr).For(&v1alpha1.Repository{}).
+//		WatchesRawSource(resyncer).
+//		Complete(r)
+package resync
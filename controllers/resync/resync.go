@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// DefaultJitter is the default maximum fraction of the interval added to each wait
+const DefaultJitter = 0.1
+
+// Resyncer is a source enqueuing all objects of a kind on an interval with jitter
+type Resyncer struct {
+	reader    client.Reader
+	list      client.ObjectList
+	interval  time.Duration
+	jitter    float64
+	selector  labels.Selector
+	namespace string
+	clock     clock.Clock
+	logger    *zap.SugaredLogger
+}
+
+var _ source.Source = &Resyncer{}
+
+// Option configures a Resyncer
+type Option func(*Resyncer)
+
+// WithJitter sets the maximum fraction of the interval added to each wait, defaults to DefaultJitter
+func WithJitter(factor float64) Option {
+	return func(r *Resyncer) {
+		r.jitter = factor
+	}
+}
+
+// WithLabelSelector only enqueues objects matching selector
+func WithLabelSelector(selector labels.Selector) Option {
+	return func(r *Resyncer) {
+		r.selector = selector
+	}
+}
+
+// WithNamespace only enqueues objects in namespace
+func WithNamespace(namespace string) Option {
+	return func(r *Resyncer) {
+		r.namespace = namespace
+	}
+}
+
+// WithClock sets the clock used to wait between resyncs
+func WithClock(clock clock.Clock) Option {
+	return func(r *Resyncer) {
+		r.clock = clock
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(r *Resyncer) {
+		r.logger = logger
+	}
+}
+
+// New returns a Resyncer listing objects with reader, usually the manager client backed by its cache,
+// into a copy of list every interval
+func New(reader client.Reader, list client.ObjectList, interval time.Duration, opts ...Option) *Resyncer {
+	r := &Resyncer{
+		reader:   reader,
+		list:     list,
+		interval: interval,
+		jitter:   DefaultJitter,
+		clock:    clock.RealClock{},
+		logger:   zap.NewNop().Sugar(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// String implements fmt.Stringer
+func (r *Resyncer) String() string {
+	return fmt.Sprintf("resync %T every %s", r.list, r.interval)
+}
+
+// Start implements source.Source, enqueuing objects every interval until ctx is done.
+// The first resync happens after one interval as all objects are reconciled when the controller starts
+func (r *Resyncer) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	if r.interval <= 0 {
+		return fmt.Errorf("resync interval must be positive, got %s", r.interval)
+	}
+	go r.run(ctx, queue)
+	return nil
+}
+
+func (r *Resyncer) run(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.After(wait.Jitter(r.interval, r.jitter)):
+		}
+		count, err := r.Resync(ctx, queue)
+		if err != nil {
+			r.logger.Errorw("resync failed", "err", err)
+			continue
+		}
+		r.logger.Debugw("resync enqueued objects", "count", count)
+	}
+}
+
+// Resync lists the objects and adds a request for each one to queue, returning how many were enqueued
+func (r *Resyncer) Resync(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) (int, error) {
+	list := r.list.DeepCopyObject().(client.ObjectList)
+	var opts []client.ListOption
+	if r.namespace != "" {
+		opts = append(opts, client.InNamespace(r.namespace))
+	}
+	if r.selector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: r.selector})
+	}
+	if err := r.reader.List(ctx, list, opts...); err != nil {
+		return 0, fmt.Errorf("list objects failed: %w", err)
+	}
+
+	count := 0
+	err := meta.EachListItem(list, func(item runtime.Object) error {
+		obj, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+		count++
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func configMap(namespace, name string, lbls map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: lbls}}
+}
+
+func newClient() client.Client {
+	return fake.NewClientBuilder().WithObjects(
+		configMap("default", "a", map[string]string{"webhook": "true"}),
+		configMap("default", "b", nil),
+		configMap("other", "c", map[string]string{"webhook": "true"}),
+	).Build()
+}
+
+func newQueue(t *testing.T) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+	return queue
+}
+
+func drain(queue workqueue.TypedRateLimitingInterface[reconcile.Request]) []types.NamespacedName {
+	result := []types.NamespacedName{}
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		result = append(result, item.NamespacedName)
+		queue.Done(item)
+	}
+	return result
+}
+
+func TestResync(t *testing.T) {
+	var data = []struct {
+		desc string
+		opts []Option
+
+		expected []types.NamespacedName
+	}{
+		{
+			desc: "all objects",
+			expected: []types.NamespacedName{
+				{Namespace: "default", Name: "a"},
+				{Namespace: "default", Name: "b"},
+				{Namespace: "other", Name: "c"},
+			},
+		},
+		{
+			desc: "label selector",
+			opts: []Option{WithLabelSelector(labels.SelectorFromSet(labels.Set{"webhook": "true"}))},
+			expected: []types.NamespacedName{
+				{Namespace: "default", Name: "a"},
+				{Namespace: "other", Name: "c"},
+			},
+		},
+		{
+			desc: "namespace and label selector",
+			opts: []Option{
+				WithNamespace("default"),
+				WithLabelSelector(labels.SelectorFromSet(labels.Set{"webhook": "true"})),
+			},
+			expected: []types.NamespacedName{{Namespace: "default", Name: "a"}},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			queue := newQueue(t)
+
+			r := New(newClient(), &corev1.ConfigMapList{}, time.Minute, item.opts...)
+			count, err := r.Resync(context.Background(), queue)
+			g.Expect(err).To(Succeed())
+			g.Expect(count).To(Equal(len(item.expected)))
+			g.Expect(drain(queue)).To(ConsistOf(item.expected))
+		})
+	}
+}
+
+func TestResyncerStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	queue := newQueue(t)
+	r := New(newClient(), &corev1.ConfigMapList{}, time.Minute, WithNamespace("default"), WithClock(fakeClock))
+	g.Expect(r.String()).To(Equal("resync *v1.ConfigMapList every 1m0s"))
+	g.Expect(r.Start(ctx, queue)).To(Succeed())
+
+	g.Eventually(fakeClock.HasWaiters).Should(BeTrue())
+	g.Expect(queue.Len()).To(BeZero(), "nothing is enqueued before the first interval")
+
+	fakeClock.Advance(time.Minute + time.Minute/10)
+	g.Eventually(queue.Len).Should(Equal(2))
+	g.Expect(drain(queue)).To(HaveLen(2))
+
+	g.Eventually(fakeClock.HasWaiters).Should(BeTrue())
+	fakeClock.Advance(time.Minute + time.Minute/10)
+	g.Eventually(queue.Len).Should(Equal(2), "objects are enqueued again on every interval")
+
+	cancel()
+	g.Expect(New(newClient(), &corev1.ConfigMapList{}, 0).Start(ctx, queue)).
+		To(MatchError(ContainSubstring("resync interval must be positive")))
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runnable groups background workers like cache warmers, periodic resyncs
+// and cleanup jobs and registers them with a controller-runtime manager,
+// respecting leader election, recovering panics and restarting failed workers with backoff.
+//
+//	group := runnable.NewGroup("my-operator").
+//		Add("cache-warmer", warmer.Run, runnable.WithLeaderElection(false)).
+//		AddPeriodic("cleanup", time.Hour, cleaner.Cleanup)
+//	err := group.Setup(ctx, mgr, logger)
+package runnable
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnable
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Group is a named group of background workers registered together with a manager.
+// It implements the controllers.Interface so it can be set up along with reconcilers
+type Group struct {
+	name    string
+	workers []*Worker
+}
+
+// NewGroup returns an empty Group
+func NewGroup(name string) *Group {
+	return &Group{name: name}
+}
+
+// Name returns the name of the group
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Add adds a worker running fn to the group
+func (g *Group) Add(name string, fn Func, opts ...Option) *Group {
+	g.workers = append(g.workers, NewWorker(name, fn, opts...))
+	return g
+}
+
+// AddPeriodic adds a worker calling fn immediately and then every interval,
+// errors returned by fn are logged and do not restart the worker
+func (g *Group) AddPeriodic(name string, interval time.Duration, fn Func, opts ...Option) *Group {
+	w := NewWorker(name, fn, opts...)
+	w.fn = w.periodic(interval, fn)
+	g.workers = append(g.workers, w)
+	return g
+}
+
+// Workers returns the workers of the group
+func (g *Group) Workers() []*Worker {
+	return g.workers
+}
+
+// Setup registers all workers with the manager, which starts them according to
+// their leader election needs and stops them when the manager stops
+func (g *Group) Setup(ctx context.Context, mgr manager.Manager, logger *zap.SugaredLogger) error {
+	for _, w := range g.workers {
+		w.logger = logger.With("group", g.name, "worker", w.name)
+		if err := mgr.Add(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnable
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/retry"
+	mockmanager "github.com/AlaudaDevops/pkg/testing/mock/sigs.k8s.io/controller-runtime/pkg/manager"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var noJitter = retry.Policy{InitialInterval: time.Second, Multiplier: 2, MaxInterval: time.Minute}
+
+func TestGroupSetup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mockctl := gomock.NewController(t)
+	defer mockctl.Finish()
+
+	group := NewGroup("group").
+		Add("leader", func(ctx context.Context) error { return nil }).
+		AddPeriodic("everywhere", time.Minute, func(ctx context.Context) error { return nil }, WithLeaderElection(false))
+	g.Expect(group.Name()).To(Equal("group"))
+	g.Expect(group.Workers()).To(HaveLen(2))
+
+	added := []manager.Runnable{}
+	mgr := mockmanager.NewMockManager(mockctl)
+	mgr.EXPECT().Add(gomock.Any()).DoAndReturn(func(r manager.Runnable) error {
+		added = append(added, r)
+		return nil
+	}).Times(2)
+	g.Expect(group.Setup(context.Background(), mgr, zap.NewNop().Sugar())).To(Succeed())
+
+	g.Expect(added).To(HaveLen(2))
+	g.Expect(added[0].(manager.LeaderElectionRunnable).NeedLeaderElection()).To(BeTrue())
+	g.Expect(added[1].(manager.LeaderElectionRunnable).NeedLeaderElection()).To(BeFalse())
+
+	mgr.EXPECT().Add(gomock.Any()).Return(errors.New("failed"))
+	g.Expect(group.Setup(context.Background(), mgr, zap.NewNop().Sugar())).NotTo(Succeed())
+}
+
+func TestWorkerCompletes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var calls int32
+	w := NewWorker("worker", func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	g.Expect(w.Start(context.Background())).To(Succeed())
+	g.Expect(calls).To(BeEquivalentTo(1))
+}
+
+func TestWorkerRestarts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := clock.NewFakeClock(time.Now())
+
+	var calls int32
+	w := NewWorker("worker", func(ctx context.Context) error {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			return errors.New("failed")
+		case 2:
+			panic("boom")
+		default:
+			return nil
+		}
+	}, WithClock(fake), WithRestartBackoff(noJitter))
+
+	done := make(chan error)
+	go func() { done <- w.Start(context.Background()) }()
+
+	// first restart waits one second, second restart two seconds
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second} {
+		g.Eventually(fake.HasWaiters).Should(BeTrue())
+		fake.Advance(delay - time.Millisecond)
+		g.Consistently(done, 10*time.Millisecond).ShouldNot(Receive())
+		fake.Advance(time.Millisecond)
+	}
+	g.Eventually(done).Should(Receive(BeNil()))
+	g.Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(3))
+}
+
+func TestWorkerMaxRestarts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := clock.NewFakeClock(time.Now())
+
+	w := NewWorker("worker", func(ctx context.Context) error {
+		panic("boom")
+	}, WithClock(fake), WithRestartBackoff(noJitter), WithMaxRestarts(1))
+
+	done := make(chan error)
+	go func() { done <- w.Start(context.Background()) }()
+	g.Eventually(fake.HasWaiters).Should(BeTrue())
+	fake.Advance(time.Second)
+
+	var err error
+	g.Eventually(done).Should(Receive(&err))
+	g.Expect(err).To(MatchError(ContainSubstring("worker worker failed after 1 restarts: panic in worker worker: boom")))
+}
+
+func TestWorkerStopsWithContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := NewWorker("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	done := make(chan error)
+	go func() { done <- w.Start(ctx) }()
+	g.Consistently(done, 10*time.Millisecond).ShouldNot(Receive())
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
+
+func TestPeriodicWorker(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := clock.NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int32
+	group := NewGroup("group").AddPeriodic("periodic", time.Minute, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("errors do not stop periodic workers")
+	}, WithClock(fake))
+
+	done := make(chan error)
+	go func() { done <- group.Workers()[0].Start(ctx) }()
+	g.Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(BeEquivalentTo(1))
+
+	g.Eventually(fake.HasWaiters).Should(BeTrue())
+	fake.Advance(time.Minute)
+	g.Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(BeEquivalentTo(2))
+
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnable
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/retry"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Func is a background worker running until ctx is done.
+// Returning nil before ctx is done means the work is completed and the worker is not restarted,
+// returning an error or panicking restarts the worker after a backoff.
+type Func func(ctx context.Context) error
+
+// DefaultRestartBackoff backoff used between restarts of failed workers
+var DefaultRestartBackoff = retry.Policy{
+	InitialInterval: time.Second,
+	Multiplier:      2,
+	MaxInterval:     5 * time.Minute,
+	Jitter:          0.1,
+}
+
+// Worker is a manager.Runnable running a Func
+type Worker struct {
+	name               string
+	fn                 Func
+	needLeaderElection bool
+	backoff            retry.Policy
+	maxRestarts        int
+	clock              clock.Clock
+	logger             *zap.SugaredLogger
+}
+
+var _ manager.Runnable = &Worker{}
+var _ manager.LeaderElectionRunnable = &Worker{}
+
+// Option configures a Worker
+type Option func(*Worker)
+
+// WithLeaderElection sets if the worker only runs on the leader, true by default
+func WithLeaderElection(needLeaderElection bool) Option {
+	return func(w *Worker) {
+		w.needLeaderElection = needLeaderElection
+	}
+}
+
+// WithRestartBackoff sets the backoff between restarts of the worker when it fails
+func WithRestartBackoff(backoff retry.Policy) Option {
+	return func(w *Worker) {
+		w.backoff = backoff
+	}
+}
+
+// WithMaxRestarts sets how many times the worker is restarted before its error is returned
+// stopping the manager, zero means it is always restarted
+func WithMaxRestarts(maxRestarts int) Option {
+	return func(w *Worker) {
+		w.maxRestarts = maxRestarts
+	}
+}
+
+// WithClock sets the clock used to wait between restarts and periodic runs
+func WithClock(clock clock.Clock) Option {
+	return func(w *Worker) {
+		w.clock = clock
+	}
+}
+
+// NewWorker returns a Worker named name running fn
+func NewWorker(name string, fn Func, opts ...Option) *Worker {
+	w := &Worker{
+		name:               name,
+		fn:                 fn,
+		needLeaderElection: true,
+		backoff:            DefaultRestartBackoff,
+		clock:              clock.RealClock{},
+		logger:             zap.NewNop().Sugar(),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Name returns the name of the worker
+func (w *Worker) Name() string {
+	return w.name
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (w *Worker) NeedLeaderElection() bool {
+	return w.needLeaderElection
+}
+
+// Start runs the worker until ctx is done or the worker completes,
+// restarting it with backoff when it fails or panics
+func (w *Worker) Start(ctx context.Context) error {
+	ctx = logging.WithLogger(ctx, w.logger)
+	for restarts := 0; ; restarts++ {
+		err := w.run(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			w.logger.Debugw("worker completed")
+			return nil
+		}
+		if w.maxRestarts > 0 && restarts >= w.maxRestarts {
+			return fmt.Errorf("worker %s failed after %d restarts: %w", w.name, restarts, err)
+		}
+		delay := w.backoff.Delay(restarts + 1)
+		w.logger.Errorw("worker failed, restarting", "err", err, "restarts", restarts, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.clock.After(delay):
+		}
+	}
+}
+
+// run calls the worker function converting panics into errors
+func (w *Worker) run(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.logger.Errorw("recovered panic in worker", "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic in worker %s: %v", w.name, r)
+		}
+	}()
+	return w.fn(ctx)
+}
+
+// periodic returns a Func calling fn immediately and then every interval until ctx is done,
+// errors are logged and fn is called again on the next interval
+func (w *Worker) periodic(interval time.Duration, fn Func) Func {
+	return func(ctx context.Context) error {
+		ticker := w.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := fn(ctx); err != nil && ctx.Err() == nil {
+				w.logger.Errorw("periodic worker run failed", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C():
+			}
+		}
+	}
+}
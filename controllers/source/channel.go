@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	ctrlsource "sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/AlaudaDevops/pkg/metrics"
+)
+
+// DefaultBufferSize is the default number of pending keys of a Channel
+const DefaultBufferSize = 1024
+
+// ErrFull is returned when keys were dropped because the buffer was full
+var ErrFull = errors.New("source buffer is full")
+
+// MapFunc returns the keys of the objects to reconcile because of a notification
+type MapFunc[T any] func(ctx context.Context, notification T) ([]client.ObjectKey, error)
+
+// Channel is a watch source of the objects mapped from notifications of type T
+type Channel[T any] struct {
+	mapFunc MapFunc[T]
+	name    string
+	size    int
+	logger  *zap.SugaredLogger
+
+	lock sync.Mutex
+	// pending keys in arrival order, deduplicated using keys
+	pending []client.ObjectKey
+	keys    map[client.ObjectKey]struct{}
+	started bool
+	// signal wakes up the goroutine moving pending keys into the queue
+	signal chan struct{}
+}
+
+var _ ctrlsource.Source = &Channel[any]{}
+
+// Option configures a Channel
+type Option func(*options)
+
+type options struct {
+	name   string
+	size   int
+	logger *zap.SugaredLogger
+}
+
+// WithName sets the name of the source used in metrics and logs, defaults to channel
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithBufferSize sets the maximum number of pending keys, defaults to DefaultBufferSize
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		o.size = size
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// NewChannel returns a Channel mapping notifications with mapFunc
+func NewChannel[T any](mapFunc MapFunc[T], opts ...Option) *Channel[T] {
+	o := &options{name: "channel", size: DefaultBufferSize, logger: zap.NewNop().Sugar()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Channel[T]{
+		mapFunc: mapFunc,
+		name:    o.name,
+		size:    o.size,
+		logger:  o.logger,
+		keys:    map[client.ObjectKey]struct{}{},
+		signal:  make(chan struct{}, 1),
+	}
+}
+
+// String implements fmt.Stringer
+func (c *Channel[T]) String() string {
+	return fmt.Sprintf("channel source %s", c.name)
+}
+
+// Send maps the notification and enqueues the keys. Does not wait when the buffer is full,
+// returning ErrFull so senders able to retry, e.g. webhook senders, can deliver it later
+func (c *Channel[T]) Send(ctx context.Context, notification T) error {
+	keys, err := c.mapFunc(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("map notification failed: %w", err)
+	}
+	if dropped := c.Enqueue(keys...); dropped > 0 {
+		return fmt.Errorf("%w, dropped %d of %d keys", ErrFull, dropped, len(keys))
+	}
+	return nil
+}
+
+// Forward sends the notifications received from ch until ch is closed or ctx is done,
+// failures are logged. Used to bridge a channel of a message queue client or a ticker
+func (c *Channel[T]) Forward(ctx context.Context, ch <-chan T) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := c.Send(ctx, notification); err != nil {
+				c.logger.Warnw("cannot send notification", "source", c.name, "err", err)
+			}
+		}
+	}
+}
+
+// Enqueue adds keys not already pending, returning the number of keys dropped
+func (c *Channel[T]) Enqueue(keys ...client.ObjectKey) (dropped int) {
+	c.lock.Lock()
+	for _, key := range keys {
+		if _, ok := c.keys[key]; ok {
+			continue
+		}
+		if len(c.pending) >= c.size {
+			dropped++
+			continue
+		}
+		c.keys[key] = struct{}{}
+		c.pending = append(c.pending, key)
+	}
+	c.lock.Unlock()
+
+	if dropped > 0 {
+		metrics.AddSourceDropped(c.name, dropped)
+	}
+	select {
+	case c.signal <- struct{}{}:
+	default:
+	}
+	return
+}
+
+// Len returns the number of pending keys
+func (c *Channel[T]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.pending)
+}
+
+// Start implements source.Source, moving pending keys into queue until ctx is done.
+// A Channel can only be watched by one controller
+func (c *Channel[T]) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.started {
+		return fmt.Errorf("%s is already started", c)
+	}
+	c.started = true
+	go c.run(ctx, queue)
+	return nil
+}
+
+func (c *Channel[T]) run(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.signal:
+		}
+		c.lock.Lock()
+		pending := c.pending
+		c.pending = nil
+		c.keys = make(map[client.ObjectKey]struct{}, len(pending))
+		c.lock.Unlock()
+
+		for _, key := range pending {
+			queue.Add(reconcile.Request{NamespacedName: key})
+		}
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/AlaudaDevops/pkg/metrics"
+)
+
+type notification struct {
+	names []string
+	err   error
+}
+
+func mapNotification(_ context.Context, n notification) ([]client.ObjectKey, error) {
+	keys := []client.ObjectKey{}
+	for _, name := range n.names {
+		keys = append(keys, client.ObjectKey{Namespace: "default", Name: name})
+	}
+	return keys, n.err
+}
+
+func newQueue(t *testing.T) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+	return queue
+}
+
+func names(queue workqueue.TypedRateLimitingInterface[reconcile.Request], count int) []string {
+	result := []string{}
+	for len(result) < count {
+		item, _ := queue.Get()
+		result = append(result, item.Name)
+		queue.Done(item)
+	}
+	return result
+}
+
+func TestChannel(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metrics.SourceDropped.Reset()
+	ch := NewChannel(mapNotification, WithName("test-channel"), WithBufferSize(3))
+
+	// keys are buffered and deduplicated before the controller starts
+	g.Expect(ch.Send(ctx, notification{names: []string{"a", "b"}})).To(Succeed())
+	g.Expect(ch.Send(ctx, notification{names: []string{"b", "a"}})).To(Succeed())
+	g.Expect(ch.Len()).To(Equal(2))
+
+	err := ch.Send(ctx, notification{names: []string{"c", "d", "e"}})
+	g.Expect(errors.Is(err, ErrFull)).To(BeTrue())
+	g.Expect(err).To(MatchError("source buffer is full, dropped 2 of 3 keys"))
+	g.Expect(testutil.ToFloat64(metrics.SourceDropped.WithLabelValues("test-channel"))).To(Equal(float64(2)))
+
+	g.Expect(ch.Send(ctx, notification{err: errors.New("unknown")})).To(MatchError("map notification failed: unknown"))
+
+	queue := newQueue(t)
+	g.Expect(ch.Start(ctx, queue)).To(Succeed())
+	g.Expect(ch.Start(ctx, queue)).To(MatchError("channel source test-channel is already started"))
+	g.Expect(names(queue, 3)).To(Equal([]string{"a", "b", "c"}))
+	g.Eventually(ch.Len).Should(Equal(0))
+
+	// keys are accepted again once moved into the queue
+	g.Expect(ch.Enqueue(types.NamespacedName{Namespace: "default", Name: "a"})).To(Equal(0))
+	g.Expect(names(queue, 1)).To(Equal([]string{"a"}))
+}
+
+func TestChannel_Forward(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := NewChannel(mapNotification)
+	queue := newQueue(t)
+	g.Expect(ch.Start(ctx, queue)).To(Succeed())
+
+	notifications := make(chan notification)
+	done := make(chan struct{})
+	go func() {
+		ch.Forward(ctx, notifications)
+		close(done)
+	}()
+	notifications <- notification{names: []string{"a"}}
+	notifications <- notification{err: errors.New("ignored")}
+	notifications <- notification{names: []string{"b"}}
+	close(notifications)
+
+	g.Eventually(done).WithTimeout(time.Second).Should(BeClosed())
+	g.Expect(names(queue, 2)).To(Equal([]string{"a", "b"}))
+}
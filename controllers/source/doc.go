@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package source bridges external notifications, e.g. webhook deliveries, message
+// queue messages or timers, into controller watch sources. Notifications are mapped
+// to object keys that are deduplicated while pending and buffered up to a size, the
+// keys arriving when the buffer is full are dropped and counted in the
+// alauda_source_dropped_total metric. A single goroutine moves pending keys into the
+// queue of the controller once it starts.
+//
+//	ch := source.NewChannel(func(ctx context.Context, msg *Message) ([]client.ObjectKey, error) {
+//		return []client.ObjectKey{{Namespace: msg.Namespace, Name: msg.Name}}, nil
+//	}, source.WithName("repository-events"), source.WithBufferSize(512))
+//	err := ctrl.NewControllerManagedBy(mgr).For(&v1alpha1.Repository{}).
+//		WatchesRawSource(ch).
+//		Complete(r)
+//
+//	// in the consumer of the message queue
+//	err = ch.Send(ctx, msg)
+package source
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/AlaudaDevops/pkg/metrics"
+)
+
+type reconcileTimeoutKey struct{}
+
+// WithReconcileTimeout stores the default timeout of reconciles into context,
+// used by reconcilers wrapped with TimeoutReconciler without a timeout of their own.
+// ManagerOptions.ReconcileTimeout stores it in the base context of the manager
+func WithReconcileTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, reconcileTimeoutKey{}, timeout)
+}
+
+// GetReconcileTimeout retrieves the default timeout of reconciles from context,
+// returns zero if none
+func GetReconcileTimeout(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(reconcileTimeoutKey{}).(time.Duration)
+	return timeout
+}
+
+// TimeoutReconciler wraps r so each reconcile of controller name is canceled after timeout,
+// or the timeout stored in the context by WithReconcileTimeout when timeout is zero.
+// Reconciles exceeding their deadline are counted in metrics.ReconcileTimeouts and
+// requeued with backoff by RequeueOnDeadline instead of returning an error.
+//
+//	ctrl.NewControllerManagedBy(mgr).For(&v1.Repository{}).
+//		Complete(controllers.TimeoutReconciler("repository", reconciler, 0))
+func TimeoutReconciler(name string, r reconcile.Reconciler, timeout time.Duration) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		deadline := timeout
+		if deadline <= 0 {
+			deadline = GetReconcileTimeout(ctx)
+		}
+		if deadline <= 0 {
+			return r.Reconcile(ctx, request)
+		}
+		return reconcileWithTimeout(ctx, name, r, request, deadline)
+	})
+}
+
+func reconcileWithTimeout(ctx context.Context, name string, r reconcile.Reconciler, request reconcile.Request, timeout time.Duration) (reconcile.Result, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := r.Reconcile(timeoutCtx, request)
+	// the deadline of the parent context is not the one of the reconcile
+	if IsDeadlineExceeded(err) && ctx.Err() == nil {
+		metrics.IncReconcileTimeouts(name)
+	}
+	return RequeueOnDeadline(result, err)
+}
+
+// IsDeadlineExceeded returns true if err is caused by a context deadline
+func IsDeadlineExceeded(err error) bool {
+	return err != nil && errors.Is(err, context.DeadlineExceeded)
+}
+
+// RequeueOnDeadline converts context deadline errors into a requeue of the request,
+// delayed by the rate limiter of the controller, so hanging dependencies do not
+// produce error storms. Other results and errors are returned unchanged
+//
+//	return controllers.RequeueOnDeadline(r.sync(ctx, obj))
+func RequeueOnDeadline(result reconcile.Result, err error) (reconcile.Result, error) {
+	if !IsDeadlineExceeded(err) {
+		return result, err
+	}
+	return reconcile.Result{Requeue: true}, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/AlaudaDevops/pkg/metrics"
+)
+
+// blockingReconciler waits for the context to be done, or returns after delay
+type blockingReconciler struct {
+	delay    time.Duration
+	deadline bool
+}
+
+func (r *blockingReconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	_, r.deadline = ctx.Deadline()
+	select {
+	case <-ctx.Done():
+		return reconcile.Result{}, fmt.Errorf("call external api: %w", ctx.Err())
+	case <-time.After(r.delay):
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+}
+
+func TestTimeoutReconciler(t *testing.T) {
+	g := NewGomegaWithT(t)
+	metrics.ReconcileTimeouts.Reset()
+	ctx := context.Background()
+
+	// exceeding the timeout is requeued with backoff
+	slow := &blockingReconciler{delay: time.Hour}
+	result, err := TimeoutReconciler("slow", slow, 10*time.Millisecond).Reconcile(ctx, reconcile.Request{})
+	g.Expect(err).To(BeNil())
+	g.Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileTimeouts.WithLabelValues("slow"))).To(Equal(float64(1)))
+
+	// results of reconciles within the timeout are kept
+	fast := &blockingReconciler{}
+	result, err = TimeoutReconciler("fast", fast, time.Hour).Reconcile(ctx, reconcile.Request{})
+	g.Expect(err).To(BeNil())
+	g.Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+	g.Expect(fast.deadline).To(BeTrue())
+
+	// the timeout in the context is used when none is given
+	result, err = TimeoutReconciler("slow", slow, 0).Reconcile(WithReconcileTimeout(ctx, 10*time.Millisecond), reconcile.Request{})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Requeue).To(BeTrue())
+	g.Expect(testutil.ToFloat64(metrics.ReconcileTimeouts.WithLabelValues("slow"))).To(Equal(float64(2)))
+
+	// without any timeout reconciles are not limited
+	_, err = TimeoutReconciler("fast", fast, 0).Reconcile(ctx, reconcile.Request{})
+	g.Expect(err).To(BeNil())
+	g.Expect(fast.deadline).To(BeFalse())
+}
+
+func TestTimeoutReconciler_parentCanceled(t *testing.T) {
+	g := NewGomegaWithT(t)
+	metrics.ReconcileTimeouts.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := TimeoutReconciler("canceled", &blockingReconciler{delay: time.Hour}, time.Hour).Reconcile(ctx, reconcile.Request{})
+	g.Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	g.Expect(testutil.ToFloat64(metrics.ReconcileTimeouts.WithLabelValues("canceled"))).To(BeZero())
+}
+
+func TestRequeueOnDeadline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result, err := RequeueOnDeadline(reconcile.Result{}, fmt.Errorf("get: %w", context.DeadlineExceeded))
+	g.Expect(err).To(BeNil())
+	g.Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+
+	other := errors.New("boom")
+	result, err = RequeueOnDeadline(reconcile.Result{RequeueAfter: time.Second}, other)
+	g.Expect(err).To(Equal(other))
+	g.Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Second}))
+
+	g.Expect(IsDeadlineExceeded(nil)).To(BeFalse())
+	g.Expect(GetReconcileTimeout(context.Background())).To(BeZero())
+}
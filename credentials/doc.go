@@ -14,28 +14,15 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package root
-
-import (
-	"github.com/spf13/pflag"
-	"go.uber.org/zap/zapcore"
-)
-
-// Log Log related options
-type log struct {
-	verbose bool
-}
-
-// Enabled decides whether a given logging level is enabled
-func (opts *log) Enabled(l zapcore.Level) bool {
-	if opts.verbose {
-		return true
-	}
-
-	return l >= zapcore.InfoLevel
-}
-
-// AddFlags add flags to options
-func (opts *log) addFlags(flags *pflag.FlagSet) {
-	flags.BoolVarP(&opts.verbose, `verbose`, `v`, false, `sets the Log level to be displayed.`)
-}
+// Package credentials resolves authentication material stored in secrets
+// into typed credentials that can be validated and injected into http
+// requests and git urls.
+//
+//	cred, err := credentials.Resolve(secret)
+//	if auth, ok := cred.(credentials.HTTPAuthenticator); ok {
+//		clt.Transport = credentials.NewTransport(clt.Transport, auth)
+//	}
+//	if auth, ok := cred.(credentials.URLAuthenticator); ok {
+//		cloneURL = auth.AuthenticateURL(cloneURL)
+//	}
+package credentials
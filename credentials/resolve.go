@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// SecretTokenKey key of bearer tokens in opaque secrets
+	SecretTokenKey = "token"
+	// SecretKnownHostsKey key of the optional known_hosts in ssh secrets
+	SecretKnownHostsKey = "known_hosts"
+)
+
+// Resolve returns the validated credential stored in the secret based on its type:
+//   - kubernetes.io/basic-auth: BasicAuth
+//   - kubernetes.io/ssh-auth: SSH
+//   - kubernetes.io/dockerconfigjson and kubernetes.io/dockercfg: DockerConfig
+//   - other types: BearerToken when the token key is set,
+//     otherwise BasicAuth when the username key is set
+func Resolve(secret *corev1.Secret) (Credential, error) {
+	if secret == nil {
+		return nil, fmt.Errorf("secret is nil")
+	}
+	cred, err := fromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	if errs := cred.Validate(field.NewPath("data")); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s credential in secret %s/%s: %w", cred.Type(), secret.Namespace, secret.Name, errs.ToAggregate())
+	}
+	return cred, nil
+}
+
+// ResolveAs returns the credential of the secret as type T,
+// returning an error if the secret has another type of credential
+func ResolveAs[T Credential](secret *corev1.Secret) (T, error) {
+	var zero T
+	cred, err := Resolve(secret)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := cred.(T)
+	if !ok {
+		return zero, fmt.Errorf("secret %s/%s has a %s credential, expected %T", secret.Namespace, secret.Name, cred.Type(), zero)
+	}
+	return typed, nil
+}
+
+func fromSecret(secret *corev1.Secret) (Credential, error) {
+	data := secret.Data
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth:
+		return &BasicAuth{
+			Username: string(data[corev1.BasicAuthUsernameKey]),
+			Password: string(data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	case corev1.SecretTypeSSHAuth:
+		return &SSH{
+			PrivateKey: data[corev1.SSHAuthPrivateKey],
+			KnownHosts: data[SecretKnownHostsKey],
+		}, nil
+	case corev1.SecretTypeDockerConfigJson:
+		config := &DockerConfig{}
+		if err := json.Unmarshal(data[corev1.DockerConfigJsonKey], config); err != nil {
+			return nil, fmt.Errorf("invalid %s in secret %s/%s: %w", corev1.DockerConfigJsonKey, secret.Namespace, secret.Name, err)
+		}
+		return config, nil
+	case corev1.SecretTypeDockercfg:
+		config := &DockerConfig{}
+		if err := json.Unmarshal(data[corev1.DockerConfigKey], &config.Auths); err != nil {
+			return nil, fmt.Errorf("invalid %s in secret %s/%s: %w", corev1.DockerConfigKey, secret.Namespace, secret.Name, err)
+		}
+		return config, nil
+	}
+
+	switch {
+	case len(data[SecretTokenKey]) > 0:
+		return &BearerToken{Token: string(data[SecretTokenKey]), Username: string(data[corev1.BasicAuthUsernameKey])}, nil
+	case len(data[corev1.BasicAuthUsernameKey]) > 0:
+		return &BasicAuth{
+			Username: string(data[corev1.BasicAuthUsernameKey]),
+			Password: string(data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	}
+	return nil, fmt.Errorf("secret %s/%s of type %q has no supported credential", secret.Namespace, secret.Name, secret.Type)
+}
+
+// NewTransport returns a RoundTripper authenticating requests before sending them with base.
+// When base is nil http.DefaultTransport is used
+func NewTransport(base http.RoundTripper, auth HTTPAuthenticator) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, auth: auth}
+}
+
+type transport struct {
+	base http.RoundTripper
+	auth HTTPAuthenticator
+}
+
+// RoundTrip authenticates a copy of the request, as RoundTrippers must not modify requests
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	t.auth.AuthenticateRequest(req)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Type of a credential
+type Type string
+
+const (
+	// TypeBasicAuth username and password
+	TypeBasicAuth Type = "BasicAuth"
+	// TypeBearerToken token sent as a bearer authorization header
+	TypeBearerToken Type = "BearerToken"
+	// TypeSSH ssh private key
+	TypeSSH Type = "SSH"
+	// TypeDockerConfig registry credentials of a docker config
+	TypeDockerConfig Type = "DockerConfig"
+)
+
+// DefaultTokenUsername username used when injecting a bearer token into urls
+const DefaultTokenUsername = "oauth2"
+
+// Credential authentication material resolved from a secret
+type Credential interface {
+	// Type of the credential
+	Type() Type
+	// Validate returns errors of missing or invalid fields
+	Validate(fld *field.Path) field.ErrorList
+}
+
+// HTTPAuthenticator sets authentication into http requests
+type HTTPAuthenticator interface {
+	// AuthenticateRequest sets the authentication headers of req
+	AuthenticateRequest(req *http.Request)
+}
+
+// URLAuthenticator sets authentication into urls, e.g. git https clone urls
+type URLAuthenticator interface {
+	// AuthenticateURL returns a copy of u with user information set
+	AuthenticateURL(u *url.URL) *url.URL
+}
+
+// BasicAuth username and password credential
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+var (
+	_ HTTPAuthenticator = &BasicAuth{}
+	_ URLAuthenticator  = &BasicAuth{}
+)
+
+// Type returns TypeBasicAuth
+func (c *BasicAuth) Type() Type { return TypeBasicAuth }
+
+// Validate requires the username and password
+func (c *BasicAuth) Validate(fld *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if c.Username == "" {
+		errs = append(errs, field.Required(fld.Child("username"), "username is required"))
+	}
+	if c.Password == "" {
+		errs = append(errs, field.Required(fld.Child("password"), "password is required"))
+	}
+	return errs
+}
+
+// AuthenticateRequest sets the basic authorization header
+func (c *BasicAuth) AuthenticateRequest(req *http.Request) {
+	req.SetBasicAuth(c.Username, c.Password)
+}
+
+// AuthenticateURL sets the username and password into the url
+func (c *BasicAuth) AuthenticateURL(u *url.URL) *url.URL {
+	copied := *u
+	copied.User = url.UserPassword(c.Username, c.Password)
+	return &copied
+}
+
+// BearerToken token credential
+type BearerToken struct {
+	Token string
+	// Username used when injecting the token into urls, defaults to DefaultTokenUsername
+	Username string
+}
+
+var (
+	_ HTTPAuthenticator = &BearerToken{}
+	_ URLAuthenticator  = &BearerToken{}
+)
+
+// Type returns TypeBearerToken
+func (c *BearerToken) Type() Type { return TypeBearerToken }
+
+// Validate requires the token
+func (c *BearerToken) Validate(fld *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if c.Token == "" {
+		errs = append(errs, field.Required(fld.Child("token"), "token is required"))
+	}
+	return errs
+}
+
+// AuthenticateRequest sets the bearer authorization header
+func (c *BearerToken) AuthenticateRequest(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+}
+
+// AuthenticateURL sets the token as the password of the url
+func (c *BearerToken) AuthenticateURL(u *url.URL) *url.URL {
+	username := c.Username
+	if username == "" {
+		username = DefaultTokenUsername
+	}
+	copied := *u
+	copied.User = url.UserPassword(username, c.Token)
+	return &copied
+}
+
+// SSH private key credential
+type SSH struct {
+	// PrivateKey PEM encoded private key
+	PrivateKey []byte
+	// KnownHosts known_hosts content used to verify servers
+	KnownHosts []byte
+	// InsecureIgnoreHostKey skips the verification of servers when KnownHosts is empty,
+	// which should only be used for tests as connections can be intercepted
+	InsecureIgnoreHostKey bool
+}
+
+// Type returns TypeSSH
+func (c *SSH) Type() Type { return TypeSSH }
+
+// Validate requires a private key without passphrase
+func (c *SSH) Validate(fld *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(c.PrivateKey) == 0 {
+		return append(errs, field.Required(fld.Child("privateKey"), "private key is required"))
+	}
+	if _, err := ssh.ParsePrivateKey(c.PrivateKey); err != nil {
+		errs = append(errs, field.Invalid(fld.Child("privateKey"), "<redacted>", err.Error()))
+	}
+	return errs
+}
+
+// Signer returns the ssh signer of the private key
+func (c *SSH) Signer() (ssh.Signer, error) {
+	return ssh.ParsePrivateKey(c.PrivateKey)
+}
+
+// DockerAuth credentials of a registry in a docker config
+type DockerAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Auth base64 encoded "username:password"
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+// BasicAuth returns the username and password, decoding Auth when they are not set
+func (a DockerAuth) BasicAuth() (*BasicAuth, bool) {
+	if a.Username != "" || a.Password != "" {
+		return &BasicAuth{Username: a.Username, Password: a.Password}, true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+	if err != nil {
+		return nil, false
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, false
+	}
+	return &BasicAuth{Username: username, Password: password}, true
+}
+
+// DockerConfig registry credentials by registry host
+type DockerConfig struct {
+	Auths map[string]DockerAuth `json:"auths"`
+}
+
+var _ HTTPAuthenticator = &DockerConfig{}
+
+// Type returns TypeDockerConfig
+func (c *DockerConfig) Type() Type { return TypeDockerConfig }
+
+// Validate requires at least one registry with credentials
+func (c *DockerConfig) Validate(fld *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(c.Auths) == 0 {
+		return append(errs, field.Required(fld.Child("auths"), "at least one registry is required"))
+	}
+	for registry, auth := range c.Auths {
+		if _, ok := auth.BasicAuth(); !ok && auth.IdentityToken == "" {
+			errs = append(errs, field.Invalid(fld.Child("auths").Key(registry), "<redacted>", "username and password, auth or identitytoken is required"))
+		}
+	}
+	return errs
+}
+
+// ForRegistry returns the credentials of the registry host, matching
+// registries configured as urls, e.g. https://index.docker.io/v1/, by host
+func (c *DockerConfig) ForRegistry(host string) (DockerAuth, bool) {
+	if auth, ok := c.Auths[host]; ok {
+		return auth, true
+	}
+	for registry, auth := range c.Auths {
+		if u, err := url.Parse(registry); err == nil && u.Host == host {
+			return auth, true
+		}
+	}
+	return DockerAuth{}, false
+}
+
+// AuthenticateRequest sets the credentials of the request host, if any
+func (c *DockerConfig) AuthenticateRequest(req *http.Request) {
+	auth, ok := c.ForRegistry(req.URL.Host)
+	if !ok {
+		return
+	}
+	if basic, ok := auth.BasicAuth(); ok {
+		basic.AuthenticateRequest(req)
+		return
+	}
+	if auth.IdentityToken != "" {
+		(&BearerToken{Token: auth.IdentityToken}).AuthenticateRequest(req)
+	}
+}
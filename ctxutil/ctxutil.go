@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctxutil
+
+import (
+	"context"
+	"fmt"
+)
+
+// key for reading/writing values of type T into context
+type key[T any] struct{}
+
+// With adds v into the context, replacing any value of the same type
+func With[T any](ctx context.Context, v T) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, key[T]{}, v)
+}
+
+// From returns the value of type T stored in the context if any
+func From[T any](ctx context.Context) (v T, ok bool) {
+	if ctx == nil {
+		return v, false
+	}
+	v, ok = ctx.Value(key[T]{}).(T)
+	return
+}
+
+// FromOrDefault returns the value of type T stored in the context,
+// or defaultValue when not found
+func FromOrDefault[T any](ctx context.Context, defaultValue T) T {
+	if v, ok := From[T](ctx); ok {
+		return v
+	}
+	return defaultValue
+}
+
+// MustFrom returns the value of type T stored in the context, panics when not found
+func MustFrom[T any](ctx context.Context) T {
+	v, ok := From[T](ctx)
+	if !ok {
+		panic(fmt.Sprintf("ctxutil: no %T found in context", v))
+	}
+	return v
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctxutil
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type name string
+
+type config struct {
+	Value string
+}
+
+func TestWithFrom(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := With(context.Background(), name("name"))
+	ctx = With(ctx, &config{Value: "config"})
+	ctx = With(ctx, "plain string")
+
+	n, ok := From[name](ctx)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(n).To(Equal(name("name")))
+
+	cfg, ok := From[*config](ctx)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cfg.Value).To(Equal("config"))
+
+	// the underlying type is a different key
+	g.Expect(MustFrom[string](ctx)).To(Equal("plain string"))
+
+	_, ok = From[config](ctx)
+	g.Expect(ok).To(BeFalse())
+	var nilCtx context.Context
+	_, ok = From[name](nilCtx)
+	g.Expect(ok).To(BeFalse())
+
+	// replacing a value
+	ctx = With(ctx, name("other"))
+	g.Expect(MustFrom[name](ctx)).To(Equal(name("other")))
+}
+
+func TestFromOrDefault(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(FromOrDefault(context.Background(), name("default"))).To(Equal(name("default")))
+	g.Expect(FromOrDefault(With(context.TODO(), name("set")), name("default"))).To(Equal(name("set")))
+}
+
+func TestMustFrom(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(func() { MustFrom[*config](context.Background()) }).To(PanicWith("ctxutil: no *ctxutil.config found in context"))
+	g.Expect(MustFrom[*config](With(context.Background(), &config{}))).NotTo(BeNil())
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ctxutil stores values in a context using their type as key,
+// replacing the unexported key types and accessor boilerplate of each package:
+//
+//	ctx = ctxutil.With(ctx, streams)
+//	streams, ok := ctxutil.From[*genericclioptions.IOStreams](ctx)
+//
+// Values are keyed by their type only, define a named type to store
+// different values of the same underlying type.
+package ctxutil
@@ -0,0 +1,32 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun carries the dry-run strategy of a command run in the context,
+// so client.WrappedClient can honor it on every write request, and summarizes
+// the changes that would have been done.
+//
+//	ctx = dryrun.WithFlags(ctx, dryrun.NewFlags())
+//	cmd := root.NewRootCommand(ctx, "my-cli", subcommands...)
+//
+//	// inside a subcommand, writes are sent with server dry run
+//	// or skipped with client dry run
+//	clt := client.NewWrappedClient(c)
+//	err := clt.Create(cmd.Context(), obj)
+//
+//	// my-cli apply --dry-run=server
+//	// Dry run (server), no changes were persisted:
+//	//   would create Deployment default/my-app
+package dryrun
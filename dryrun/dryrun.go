@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Strategy of a dry run
+type Strategy string
+
+const (
+	// None persists all changes
+	None Strategy = "none"
+	// Client skips write requests without sending them
+	Client Strategy = "client"
+	// Server sends write requests with server dry run,
+	// so they are validated and admitted without being persisted
+	Server Strategy = "server"
+)
+
+// Strategies are all the supported strategies
+var Strategies = []Strategy{None, Client, Server}
+
+// ParseStrategy returns the Strategy of value, empty is None
+func ParseStrategy(value string) (Strategy, error) {
+	if value == "" {
+		return None, nil
+	}
+	for _, strategy := range Strategies {
+		if Strategy(value) == strategy {
+			return strategy, nil
+		}
+	}
+	return None, fmt.Errorf("invalid dry run strategy %q, must be one of %s", value, strategyNames())
+}
+
+// Enabled returns true when changes are not persisted
+func (s Strategy) Enabled() bool {
+	return s == Client || s == Server
+}
+
+// WithStrategy adds the Strategy into the context
+func WithStrategy(ctx context.Context, strategy Strategy) context.Context {
+	return ctxutil.With(ctx, strategy)
+}
+
+// StrategyFrom returns the Strategy stored in the context, None if not found
+func StrategyFrom(ctx context.Context) Strategy {
+	return ctxutil.FromOrDefault(ctx, None)
+}
+
+// Action is a write request that was not persisted because of a dry run
+type Action struct {
+	// Verb of the request, e.g. create, update, patch or delete
+	Verb string
+	// GroupVersionKind of the object
+	GroupVersionKind schema.GroupVersionKind
+	// Namespace of the object
+	Namespace string
+	// Name of the object, empty when deleting a collection
+	Name string
+	// SubResource written, e.g. status
+	SubResource string
+}
+
+// String returns a readable sentence of the action, e.g. would create Deployment default/my-app
+func (a Action) String() string {
+	var builder strings.Builder
+	builder.WriteString("would ")
+	builder.WriteString(a.Verb)
+	if a.SubResource != "" {
+		builder.WriteString(" " + a.SubResource + " of")
+	}
+	if a.Name == "" {
+		builder.WriteString(" all")
+	}
+	if kind := a.GroupVersionKind.Kind; kind != "" {
+		builder.WriteString(" " + kind)
+	}
+	switch {
+	case a.Name == "" && a.Namespace != "":
+		builder.WriteString(" in " + a.Namespace)
+	case a.Namespace != "":
+		builder.WriteString(" " + a.Namespace + "/" + a.Name)
+	case a.Name != "":
+		builder.WriteString(" " + a.Name)
+	}
+	return builder.String()
+}
+
+// Summary collects the actions of a dry run, it is safe for concurrent use
+type Summary struct {
+	lock    sync.Mutex
+	actions []Action
+}
+
+// NewSummary returns an empty Summary
+func NewSummary() *Summary {
+	return &Summary{}
+}
+
+// Record adds action to the summary
+func (s *Summary) Record(action Action) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.actions = append(s.actions, action)
+}
+
+// Actions returns the recorded actions in the order they were recorded
+func (s *Summary) Actions() []Action {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Action(nil), s.actions...)
+}
+
+// Print writes the recorded actions into w, one per line after a header naming strategy
+// nothing is written when no action was recorded
+func (s *Summary) Print(w io.Writer, strategy Strategy) error {
+	actions := s.Actions()
+	if len(actions) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "Dry run (%s), no changes were persisted:\n", strategy); err != nil {
+		return err
+	}
+	for _, action := range actions {
+		if _, err := fmt.Fprintf(w, "  %s\n", action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithSummary adds the Summary into the context
+func WithSummary(ctx context.Context, summary *Summary) context.Context {
+	return ctxutil.With(ctx, summary)
+}
+
+// SummaryFrom returns the Summary stored in the context, nil if not found
+func SummaryFrom(ctx context.Context) *Summary {
+	summary, _ := ctxutil.From[*Summary](ctx)
+	return summary
+}
+
+// Record adds action to the Summary of the context if any
+func Record(ctx context.Context, action Action) {
+	if summary := SummaryFrom(ctx); summary != nil {
+		summary.Record(action)
+	}
+}
+
+func strategyNames() string {
+	names := make([]string, 0, len(Strategies))
+	for _, strategy := range Strategies {
+		names = append(names, string(strategy))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(StrategyFrom(context.Background())).To(Equal(None))
+	g.Expect(StrategyFrom(WithStrategy(context.Background(), Server))).To(Equal(Server))
+	g.Expect(None.Enabled()).To(BeFalse())
+	g.Expect(Client.Enabled()).To(BeTrue())
+
+	strategy, err := ParseStrategy("")
+	g.Expect(err).To(BeNil())
+	g.Expect(strategy).To(Equal(None))
+	_, err = ParseStrategy("all")
+	g.Expect(err).To(MatchError(ContainSubstring("must be one of none, client, server")))
+}
+
+func TestSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	summary := NewSummary()
+	ctx := WithSummary(context.Background(), summary)
+	Record(ctx, Action{Verb: "create", GroupVersionKind: deployment, Namespace: "default", Name: "app"})
+	Record(ctx, Action{Verb: "update", GroupVersionKind: deployment, Namespace: "default", Name: "app", SubResource: "status"})
+	Record(ctx, Action{Verb: "delete", GroupVersionKind: deployment, Namespace: "default"})
+	Record(ctx, Action{Verb: "patch", GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, Name: "default"})
+	// no-op without a summary
+	Record(context.Background(), Action{Verb: "create"})
+
+	out := &bytes.Buffer{}
+	g.Expect(summary.Print(out, Client)).To(Succeed())
+	g.Expect(out.String()).To(Equal("Dry run (client), no changes were persisted:\n" +
+		"  would create Deployment default/app\n" +
+		"  would update status of Deployment default/app\n" +
+		"  would delete all Deployment in default\n" +
+		"  would patch Namespace default\n"))
+
+	out.Reset()
+	g.Expect(NewSummary().Print(out, Client)).To(Succeed())
+	g.Expect(out.String()).To(BeEmpty())
+}
+
+func TestFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := WithFlags(context.Background(), NewFlags())
+	flags := GetFlags(ctx)
+	g.Expect(flags).NotTo(BeNil())
+	g.Expect(GetFlags(context.Background())).To(BeNil())
+
+	set := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(set)
+	g.Expect(flags.Enabled()).To(BeFalse())
+	g.Expect(set.Parse([]string{"--dry-run=server"})).To(Succeed())
+	g.Expect(flags.Strategy).To(Equal(Server))
+	g.Expect(set.Parse([]string{"--dry-run"})).To(Succeed())
+	g.Expect(flags.Strategy).To(Equal(Client))
+	g.Expect(flags.Enabled()).To(BeTrue())
+	g.Expect(set.Parse([]string{"--dry-run=all"})).NotTo(Succeed())
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"github.com/spf13/pflag"
+)
+
+// Flags the --dry-run flag
+type Flags struct {
+	// Strategy of the dry run, None by default
+	Strategy Strategy
+}
+
+// NewFlags returns Flags with dry run disabled
+func NewFlags() *Flags {
+	return &Flags{Strategy: None}
+}
+
+// AddFlags add flags to the flag set
+func (f *Flags) AddFlags(flags *pflag.FlagSet) {
+	flags.Var(&strategyValue{strategy: &f.Strategy}, "dry-run",
+		fmt.Sprintf("one of %s, client skips all changes and server sends them with server dry run without persisting them", strategyNames()))
+	// --dry-run alone means client, as kubectl used to do
+	flags.Lookup("dry-run").NoOptDefVal = string(Client)
+}
+
+// Enabled returns true when a dry run was requested
+func (f *Flags) Enabled() bool {
+	return f != nil && f.Strategy.Enabled()
+}
+
+// WithFlags adds Flags into the context
+func WithFlags(ctx context.Context, flags *Flags) context.Context {
+	return ctxutil.With(ctx, flags)
+}
+
+// GetFlags returns Flags stored in the context if any
+// if not found will return nil *Flags
+func GetFlags(ctx context.Context) *Flags {
+	flags, _ := ctxutil.From[*Flags](ctx)
+	return flags
+}
+
+// strategyValue is a pflag.Value validating the strategy
+type strategyValue struct {
+	strategy *Strategy
+}
+
+var _ pflag.Value = &strategyValue{}
+
+// String implements pflag.Value
+func (v *strategyValue) String() string {
+	if v.strategy == nil || *v.strategy == "" {
+		return string(None)
+	}
+	return string(*v.strategy)
+}
+
+// Set implements pflag.Value
+func (v *strategyValue) Set(value string) error {
+	strategy, err := ParseStrategy(value)
+	if err != nil {
+		return err
+	}
+	*v.strategy = strategy
+	return nil
+}
+
+// Type implements pflag.Value
+func (v *strategyValue) Type() string {
+	return "string"
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// StatusReasonDependencyFailed means a dependency of the request,
+// like another service or resource, failed and the request could not be completed
+const StatusReasonDependencyFailed metav1.StatusReason = "DependencyFailed"
+
+// Error is a typed error with a reason, it implements errors.APIStatus
+// so it can be used anywhere apimachinery errors are expected.
+// Use the New* functions to create errors and Reason or the Is* functions to check them.
+type Error struct {
+	reason  metav1.StatusReason
+	code    int32
+	message string
+	details *metav1.StatusDetails
+	cause   error
+}
+
+var _ errors.APIStatus = &Error{}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Unwrap returns the wrapped error if any
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Reason returns the reason of the error
+func (e *Error) Reason() metav1.StatusReason {
+	return e.reason
+}
+
+// Status implements errors.APIStatus
+func (e *Error) Status() metav1.Status {
+	status := metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    e.code,
+		Reason:  e.reason,
+		Message: e.message,
+	}
+	if e.details != nil {
+		status.Details = e.details.DeepCopy()
+	}
+	return status
+}
+
+// FieldErrors returns the field errors of a validation error
+func (e *Error) FieldErrors() field.ErrorList {
+	if e.details == nil {
+		return nil
+	}
+	list := field.ErrorList{}
+	for _, cause := range e.details.Causes {
+		list = append(list, &field.Error{Type: field.ErrorType(cause.Type), Field: cause.Field, Detail: cause.Message})
+	}
+	return list
+}
+
+// NewNotFound returns an error indicating the name of resource was not found
+func NewNotFound(resource schema.GroupResource, name string) *Error {
+	return &Error{
+		reason:  metav1.StatusReasonNotFound,
+		code:    http.StatusNotFound,
+		message: fmt.Sprintf("%s %q not found", resource.String(), name),
+		details: &metav1.StatusDetails{Group: resource.Group, Kind: resource.Resource, Name: name},
+	}
+}
+
+// NewConflict returns an error indicating the request conflicts with the current state
+func NewConflict(resource schema.GroupResource, name string, err error) *Error {
+	return &Error{
+		reason:  metav1.StatusReasonConflict,
+		code:    http.StatusConflict,
+		message: fmt.Sprintf("operation cannot be fulfilled on %s %q: %v", resource.String(), name, err),
+		details: &metav1.StatusDetails{Group: resource.Group, Kind: resource.Resource, Name: name},
+		cause:   err,
+	}
+}
+
+// NewValidation returns an error with the field errors found validating the name of resource
+func NewValidation(resource schema.GroupResource, name string, errs field.ErrorList) *Error {
+	details := &metav1.StatusDetails{Group: resource.Group, Kind: resource.Resource, Name: name}
+	for _, err := range errs {
+		details.Causes = append(details.Causes, metav1.StatusCause{
+			Type:    metav1.CauseType(err.Type),
+			Message: err.ErrorBody(),
+			Field:   err.Field,
+		})
+	}
+	return &Error{
+		reason:  metav1.StatusReasonInvalid,
+		code:    http.StatusUnprocessableEntity,
+		message: fmt.Sprintf("%s %q is invalid: %v", resource.String(), name, errs.ToAggregate()),
+		details: details,
+	}
+}
+
+// NewUnauthorized returns an error indicating the client is not authorized
+func NewUnauthorized(message string) *Error {
+	if message == "" {
+		message = "not authorized"
+	}
+	return &Error{
+		reason:  metav1.StatusReasonUnauthorized,
+		code:    http.StatusUnauthorized,
+		message: message,
+	}
+}
+
+// NewDependencyFailed returns an error indicating the dependency failed with err
+func NewDependencyFailed(dependency string, err error) *Error {
+	return &Error{
+		reason:  StatusReasonDependencyFailed,
+		code:    http.StatusFailedDependency,
+		message: fmt.Sprintf("dependency %s failed: %v", dependency, err),
+		details: &metav1.StatusDetails{Name: dependency},
+		cause:   err,
+	}
+}
+
+// FromStatus returns the typed error described by status, e.g. decoded from the body of a failed request.
+// The message defaults to the text of the status code
+func FromStatus(status metav1.Status) *Error {
+	message := status.Message
+	if message == "" {
+		message = http.StatusText(int(status.Code))
+	}
+	return &Error{
+		reason:  status.Reason,
+		code:    status.Code,
+		message: message,
+		details: status.Details.DeepCopy(),
+	}
+}
+
+// Wrap returns an error with the same reason as err with message prefixed to its message,
+// the original error is available using errors.Unwrap. Returns nil if err is nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	status := ToStatus(err)
+	return &Error{
+		reason:  status.Reason,
+		code:    status.Code,
+		message: fmt.Sprintf("%s: %s", message, err.Error()),
+		details: status.Details,
+		cause:   err,
+	}
+}
+
+// Reason returns the reason of err, or metav1.StatusReasonUnknown if err has none
+func Reason(err error) metav1.StatusReason {
+	if typed := (*Error)(nil); goerrors.As(err, &typed) {
+		return typed.Reason()
+	}
+	return errors.ReasonForError(err)
+}
+
+// IsNotFound returns true if err or any error it wraps is a not found error
+func IsNotFound(err error) bool {
+	return Reason(err) == metav1.StatusReasonNotFound
+}
+
+// IsConflict returns true if err or any error it wraps is a conflict error
+func IsConflict(err error) bool {
+	return Reason(err) == metav1.StatusReasonConflict
+}
+
+// IsValidation returns true if err or any error it wraps is a validation error
+func IsValidation(err error) bool {
+	return Reason(err) == metav1.StatusReasonInvalid
+}
+
+// IsUnauthorized returns true if err or any error it wraps is an unauthorized error
+func IsUnauthorized(err error) bool {
+	return Reason(err) == metav1.StatusReasonUnauthorized
+}
+
+// IsDependencyFailed returns true if err or any error it wraps is a dependency failed error
+func IsDependencyFailed(err error) bool {
+	return Reason(err) == StatusReasonDependencyFailed
+}
+
+// ToStatus converts err into a metav1.Status, errors without reason are internal errors
+func ToStatus(err error) metav1.Status {
+	if status := errors.APIStatus(nil); goerrors.As(err, &status) {
+		return status.Status()
+	}
+	return errors.NewInternalError(err).Status()
+}
+
+// HTTPStatusCode returns the http status code for err, same as AsStatusCode
+func HTTPStatusCode(err error) int {
+	return AsStatusCode(err)
+}
+
+// grpcCodes maps reasons to grpc codes
+var grpcCodes = map[metav1.StatusReason]codes.Code{
+	metav1.StatusReasonNotFound:           codes.NotFound,
+	metav1.StatusReasonAlreadyExists:      codes.AlreadyExists,
+	metav1.StatusReasonConflict:           codes.Aborted,
+	metav1.StatusReasonInvalid:            codes.InvalidArgument,
+	metav1.StatusReasonBadRequest:         codes.InvalidArgument,
+	metav1.StatusReasonUnauthorized:       codes.Unauthenticated,
+	metav1.StatusReasonForbidden:          codes.PermissionDenied,
+	metav1.StatusReasonTimeout:            codes.DeadlineExceeded,
+	metav1.StatusReasonServerTimeout:      codes.Unavailable,
+	metav1.StatusReasonServiceUnavailable: codes.Unavailable,
+	metav1.StatusReasonTooManyRequests:    codes.ResourceExhausted,
+	metav1.StatusReasonMethodNotAllowed:   codes.Unimplemented,
+	metav1.StatusReasonGone:               codes.NotFound,
+	metav1.StatusReasonInternalError:      codes.Internal,
+	StatusReasonDependencyFailed:          codes.Unavailable,
+}
+
+// GRPCCode returns the grpc code for err, codes.OK if err is nil and codes.Unknown if err has no known reason
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if code, ok := grpcCodes[Reason(err)]; ok {
+		return code
+	}
+	return codes.Unknown
+}
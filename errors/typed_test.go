@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var testResource = schema.GroupResource{Group: "alauda.io", Resource: "tools"}
+
+func TestTypedErrors(t *testing.T) {
+	cause := fmt.Errorf("connection refused")
+
+	table := map[string]struct {
+		Error    error
+		Reason   metav1.StatusReason
+		HTTPCode int
+		GRPCCode codes.Code
+		Is       func(error) bool
+	}{
+		"not found": {
+			NewNotFound(testResource, "name"),
+			metav1.StatusReasonNotFound, http.StatusNotFound, codes.NotFound, IsNotFound,
+		},
+		"conflict": {
+			NewConflict(testResource, "name", cause),
+			metav1.StatusReasonConflict, http.StatusConflict, codes.Aborted, IsConflict,
+		},
+		"validation": {
+			NewValidation(testResource, "name", field.ErrorList{field.Required(field.NewPath("spec", "url"), "")}),
+			metav1.StatusReasonInvalid, http.StatusUnprocessableEntity, codes.InvalidArgument, IsValidation,
+		},
+		"unauthorized": {
+			NewUnauthorized(""),
+			metav1.StatusReasonUnauthorized, http.StatusUnauthorized, codes.Unauthenticated, IsUnauthorized,
+		},
+		"dependency failed": {
+			NewDependencyFailed("gitlab", cause),
+			StatusReasonDependencyFailed, http.StatusFailedDependency, codes.Unavailable, IsDependencyFailed,
+		},
+		"wrapped with fmt": {
+			fmt.Errorf("get tool: %w", NewNotFound(testResource, "name")),
+			metav1.StatusReasonNotFound, http.StatusNotFound, codes.NotFound, IsNotFound,
+		},
+		"wrapped with Wrap": {
+			Wrap(NewConflict(testResource, "name", cause), "update tool"),
+			metav1.StatusReasonConflict, http.StatusConflict, codes.Aborted, IsConflict,
+		},
+		"apimachinery error": {
+			errors.NewForbidden(testResource, "name", cause),
+			metav1.StatusReasonForbidden, http.StatusForbidden, codes.PermissionDenied, errors.IsForbidden,
+		},
+		"random error": {
+			cause,
+			metav1.StatusReasonUnknown, http.StatusInternalServerError, codes.Unknown, func(error) bool { return true },
+		},
+	}
+
+	for name, test := range table {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(Reason(test.Error)).To(Equal(test.Reason))
+			g.Expect(HTTPStatusCode(test.Error)).To(Equal(test.HTTPCode))
+			g.Expect(GRPCCode(test.Error)).To(Equal(test.GRPCCode))
+			g.Expect(test.Is(test.Error)).To(BeTrue())
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	err := NewValidation(testResource, "name", field.ErrorList{
+		field.Required(field.NewPath("spec", "url"), ""),
+		field.Invalid(field.NewPath("spec", "replicas"), -1, "must be positive"),
+	})
+	status := ToStatus(err)
+	g.Expect(status.Code).To(Equal(int32(http.StatusUnprocessableEntity)))
+	g.Expect(status.Details.Name).To(Equal("name"))
+	g.Expect(status.Details.Causes).To(HaveLen(2))
+	g.Expect(status.Details.Causes[0].Field).To(Equal("spec.url"))
+	g.Expect(err.Error()).To(ContainSubstring(`tools.alauda.io "name" is invalid`))
+	g.Expect(err.FieldErrors()).To(HaveLen(2))
+	g.Expect(err.FieldErrors()[1].Type).To(Equal(field.ErrorTypeInvalid))
+
+	// compatible with apimachinery helpers
+	g.Expect(errors.IsInvalid(err)).To(BeTrue())
+	g.Expect(errors.IsNotFound(NewNotFound(testResource, "name"))).To(BeTrue())
+
+	status = ToStatus(fmt.Errorf("random"))
+	g.Expect(status.Reason).To(Equal(metav1.StatusReasonInternalError))
+}
+
+func TestWrap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cause := fmt.Errorf("timeout")
+	err := NewDependencyFailed("gitlab", cause)
+	g.Expect(goerrors.Is(err, cause)).To(BeTrue())
+
+	wrapped := Wrap(err, "sync repository")
+	g.Expect(wrapped.Error()).To(Equal("sync repository: dependency gitlab failed: timeout"))
+	g.Expect(goerrors.Is(wrapped, cause)).To(BeTrue())
+	g.Expect(goerrors.Unwrap(wrapped)).To(Equal(err))
+
+	g.Expect(Wrap(nil, "message")).To(BeNil())
+	g.Expect(GRPCCode(nil)).To(Equal(codes.OK))
+}
+
+func TestFromStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	original := NewValidation(testResource, "name", field.ErrorList{
+		field.Required(field.NewPath("spec", "url"), ""),
+	})
+	err := FromStatus(ToStatus(original))
+	g.Expect(IsValidation(err)).To(BeTrue())
+	g.Expect(err.Error()).To(Equal(original.Error()))
+	g.Expect(err.FieldErrors()).To(HaveLen(1))
+	g.Expect(HTTPStatusCode(err)).To(Equal(http.StatusUnprocessableEntity))
+
+	err = FromStatus(metav1.Status{Code: http.StatusServiceUnavailable})
+	g.Expect(err.Error()).To(Equal("Service Unavailable"))
+	g.Expect(errors.IsServiceUnavailable(err)).To(BeTrue())
+}
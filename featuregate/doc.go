@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package featuregate declares feature gates with a default and a maturity
+// level and resolves them consistently across binaries: clis set them with the
+// --feature-gates persistent flag added by command/root, controllers with the
+// FEATURE_GATES environment variable and a ConfigMap updated at runtime.
+//
+//	gate := featuregate.New()
+//	gate.MustAdd(map[string]featuregate.Spec{
+//		"ParallelSync": {Default: false, Maturity: featuregate.Alpha},
+//	})
+//	ctx = featuregate.WithGate(ctx, gate)
+//
+//	if featuregate.Enabled(ctx, "ParallelSync") {
+//		...
+//	}
+package featuregate
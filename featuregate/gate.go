@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featuregate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"github.com/spf13/pflag"
+)
+
+// FlagName name of the flag setting feature gates
+const FlagName = "feature-gates"
+
+// Maturity of a feature gate
+type Maturity string
+
+const (
+	// Alpha features are disabled by default and may change or be removed
+	Alpha Maturity = "ALPHA"
+	// Beta features are well tested and usually enabled by default
+	Beta Maturity = "BETA"
+	// GA features are always enabled and their gates can no longer be disabled
+	GA Maturity = "GA"
+)
+
+// Spec of a feature gate
+type Spec struct {
+	// Default value of the gate when it is not set
+	Default bool
+	// Maturity of the feature
+	Maturity Maturity
+}
+
+// Gate holds known feature gates and the values set for them.
+// Values set from a ConfigMap take precedence over values set from flags or environment variables
+type Gate struct {
+	lock    sync.RWMutex
+	known   map[string]Spec
+	enabled map[string]bool
+	dynamic map[string]bool
+}
+
+var _ pflag.Value = &Gate{}
+
+// New returns a gate without any known feature
+func New() *Gate {
+	return &Gate{
+		known:   map[string]Spec{},
+		enabled: map[string]bool{},
+		dynamic: map[string]bool{},
+	}
+}
+
+// Add adds known feature gates, returning an error if a gate
+// is already known with a different spec
+func (g *Gate) Add(specs map[string]Spec) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for name, spec := range specs {
+		if existing, ok := g.known[name]; ok && existing != spec {
+			return fmt.Errorf("feature gate %q already added with a different spec", name)
+		}
+	}
+	for name, spec := range specs {
+		g.known[name] = spec
+	}
+	return nil
+}
+
+// MustAdd adds known feature gates like Add and panics on error
+func (g *Gate) MustAdd(specs map[string]Spec) {
+	if err := g.Add(specs); err != nil {
+		panic(err)
+	}
+}
+
+// Set sets gates from a comma separated list of name=bool pairs, e.g. Foo=true,Bar=false
+func (g *Gate) Set(value string) error {
+	values, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	return g.SetFromMap(values)
+}
+
+// SetFromMap sets the given gates, returning an error for unknown gates
+// or when disabling a GA gate. No gate is set when an error is returned
+func (g *Gate) SetFromMap(values map[string]bool) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if err := g.validate(values); err != nil {
+		return err
+	}
+	for name, enabled := range values {
+		g.enabled[name] = enabled
+	}
+	return nil
+}
+
+// validate checks values can be set, must be called holding the lock
+func (g *Gate) validate(values map[string]bool) error {
+	for name, enabled := range values {
+		spec, ok := g.known[name]
+		if !ok {
+			return fmt.Errorf("unknown feature gate %q", name)
+		}
+		if spec.Maturity == GA && !enabled {
+			return fmt.Errorf("feature gate %q is GA and cannot be disabled", name)
+		}
+	}
+	return nil
+}
+
+// Enabled returns true if the gate is enabled, unknown gates are disabled
+func (g *Gate) Enabled(name string) bool {
+	if g == nil {
+		return false
+	}
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	spec, ok := g.known[name]
+	if !ok {
+		return false
+	}
+	if spec.Maturity == GA {
+		return true
+	}
+	if enabled, ok := g.dynamic[name]; ok {
+		return enabled
+	}
+	if enabled, ok := g.enabled[name]; ok {
+		return enabled
+	}
+	return spec.Default
+}
+
+// Known returns a description of each known gate sorted by name, used as flag usage
+func (g *Gate) Known() []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	known := make([]string, 0, len(g.known))
+	for name, spec := range g.known {
+		known = append(known, fmt.Sprintf("%s=true|false (%s - default=%t)", name, spec.Maturity, spec.Default))
+	}
+	sort.Strings(known)
+	return known
+}
+
+// String returns the gates set as a sorted comma separated list of name=bool pairs
+func (g *Gate) String() string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	pairs := make([]string, 0, len(g.enabled))
+	for name, enabled := range g.enabled {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Type returns the type of the flag value
+func (g *Gate) Type() string {
+	return "mapStringBool"
+}
+
+// AddFlag adds the --feature-gates flag to the flag set
+func (g *Gate) AddFlag(flags *pflag.FlagSet) {
+	usage := "comma separated list of name=bool pairs enabling or disabling features"
+	if known := g.Known(); len(known) > 0 {
+		usage += ". Options are:\n" + strings.Join(known, "\n")
+	}
+	flags.Var(g, FlagName, usage)
+}
+
+// Parse parses a comma separated list of name=bool pairs, e.g. Foo=true,Bar=false
+func Parse(value string) (map[string]bool, error) {
+	values := map[string]bool{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, raw, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("missing bool value for feature gate %q", name)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of feature gate %q: %w", name, err)
+		}
+		values[strings.TrimSpace(name)] = enabled
+	}
+	return values, nil
+}
+
+// WithGate stores the gate into the context
+func WithGate(ctx context.Context, gate *Gate) context.Context {
+	return ctxutil.With(ctx, gate)
+}
+
+// GetGate returns the gate stored in the context, or nil
+func GetGate(ctx context.Context) *Gate {
+	gate, _ := ctxutil.From[*Gate](ctx)
+	return gate
+}
+
+// Enabled returns true if the gate stored in the context enables the feature.
+// Features are disabled when no gate is stored in the context
+func Enabled(ctx context.Context, name string) bool {
+	return GetGate(ctx).Enabled(name)
+}
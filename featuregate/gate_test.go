@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featuregate
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestGate() *Gate {
+	gate := New()
+	gate.MustAdd(map[string]Spec{
+		"Foo": {Default: false, Maturity: Alpha},
+		"Bar": {Default: true, Maturity: Beta},
+		"Baz": {Default: true, Maturity: GA},
+	})
+	return gate
+}
+
+func TestGate_Set(t *testing.T) {
+	var data = []struct {
+		desc    string
+		value   string
+		want    map[string]bool
+		wantErr string
+	}{
+		{"defaults", "", map[string]bool{"Foo": false, "Bar": true, "Baz": true}, ""},
+		{"set gates", "Foo=true, Bar=false", map[string]bool{"Foo": true, "Bar": false, "Baz": true}, ""},
+		{"unknown gate", "Foo=true,Qux=true", map[string]bool{"Foo": false, "Bar": true, "Baz": true}, `unknown feature gate "Qux"`},
+		{"disable GA gate", "Baz=false", map[string]bool{"Foo": false, "Bar": true, "Baz": true}, `feature gate "Baz" is GA and cannot be disabled`},
+		{"invalid value", "Foo=yes", map[string]bool{"Foo": false, "Bar": true, "Baz": true}, `invalid value of feature gate "Foo"`},
+		{"missing value", "Foo", map[string]bool{"Foo": false, "Bar": true, "Baz": true}, `missing bool value for feature gate "Foo"`},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			gate := newTestGate()
+			err := gate.Set(item.value)
+			if item.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(item.wantErr)))
+			}
+			for name, enabled := range item.want {
+				g.Expect(gate.Enabled(name)).To(Equal(enabled), name)
+			}
+			g.Expect(gate.Enabled("Unknown")).To(BeFalse())
+		})
+	}
+}
+
+func TestGate_Add(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gate := newTestGate()
+	g.Expect(gate.Add(map[string]Spec{"Foo": {Default: false, Maturity: Alpha}})).To(Succeed())
+	g.Expect(gate.Add(map[string]Spec{"Foo": {Default: true, Maturity: Beta}})).To(MatchError(ContainSubstring(`"Foo" already added`)))
+	g.Expect(gate.Known()).To(Equal([]string{
+		"Bar=true|false (BETA - default=true)",
+		"Baz=true|false (GA - default=true)",
+		"Foo=true|false (ALPHA - default=false)",
+	}))
+}
+
+func TestGate_Flag(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gate := newTestGate()
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	gate.AddFlag(flags)
+	g.Expect(flags.Parse([]string{"--feature-gates=Foo=true", "--feature-gates=Bar=false"})).To(Succeed())
+	g.Expect(gate.Enabled("Foo")).To(BeTrue())
+	g.Expect(gate.Enabled("Bar")).To(BeFalse())
+	g.Expect(flags.Lookup(FlagName).Value.String()).To(Equal("Bar=false,Foo=true"))
+	g.Expect(flags.Lookup(FlagName).Usage).To(ContainSubstring("Foo=true|false (ALPHA - default=false)"))
+}
+
+func TestGate_SetFromEnv(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gate := newTestGate()
+	g.Expect(gate.SetFromEnv()).To(Succeed())
+	g.Expect(gate.Enabled("Foo")).To(BeFalse())
+
+	t.Setenv(EnvFeatureGates, "Foo=true")
+	g.Expect(gate.SetFromEnv()).To(Succeed())
+	g.Expect(gate.Enabled("Foo")).To(BeTrue())
+
+	t.Setenv(EnvFeatureGates, "Qux=true")
+	g.Expect(gate.SetFromEnv()).To(MatchError(ContainSubstring(EnvFeatureGates)))
+}
+
+func TestGate_SetFromConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gate := newTestGate()
+	g.Expect(gate.Set("Foo=true")).To(Succeed())
+
+	cm := &corev1.ConfigMap{Data: map[string]string{"Foo": "false", "Bar": "false"}}
+	g.Expect(gate.SetFromConfigMap(cm)).To(Succeed())
+	g.Expect(gate.Enabled("Foo")).To(BeFalse())
+	g.Expect(gate.Enabled("Bar")).To(BeFalse())
+
+	// removed keys fall back to flags or defaults
+	cm = &corev1.ConfigMap{Data: map[string]string{"Bar": "false"}}
+	g.Expect(gate.SetFromConfigMap(cm)).To(Succeed())
+	g.Expect(gate.Enabled("Foo")).To(BeTrue())
+
+	// invalid configmaps are not applied
+	cm = &corev1.ConfigMap{Data: map[string]string{"Bar": "true", "Qux": "true"}}
+	g.Expect(gate.SetFromConfigMap(cm)).To(MatchError(ContainSubstring(`unknown feature gate "Qux"`)))
+	g.Expect(gate.Enabled("Bar")).To(BeFalse())
+}
+
+func TestEnabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	g.Expect(GetGate(ctx)).To(BeNil())
+	g.Expect(Enabled(ctx, "Bar")).To(BeFalse())
+
+	gate := newTestGate()
+	ctx = WithGate(ctx, gate)
+	g.Expect(GetGate(ctx)).To(Equal(gate))
+	g.Expect(Enabled(ctx, "Bar")).To(BeTrue())
+}
+
+func TestConfigMapName(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(ConfigMapName()).To(Equal(defaultConfigMapName))
+	t.Setenv(configMapNameEnv, "custom")
+	g.Expect(ConfigMapName()).To(Equal("custom"))
+}
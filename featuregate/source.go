@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featuregate
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	kconfigmap "github.com/AlaudaDevops/pkg/configmap"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/system"
+)
+
+const (
+	// EnvFeatureGates environment variable holding feature gates as name=bool pairs
+	EnvFeatureGates = "FEATURE_GATES"
+
+	configMapNameEnv     = "CONFIG_FEATURE_GATES_NAME"
+	defaultConfigMapName = "config-feature-gates"
+)
+
+// ConfigMapName returns the name of the feature gates ConfigMap,
+// which can be changed using the CONFIG_FEATURE_GATES_NAME environment variable
+func ConfigMapName() string {
+	if name := os.Getenv(configMapNameEnv); name != "" {
+		return name
+	}
+	return defaultConfigMapName
+}
+
+// SetFromEnv sets gates from the FEATURE_GATES environment variable, if present
+func (g *Gate) SetFromEnv() error {
+	value, ok := os.LookupEnv(EnvFeatureGates)
+	if !ok {
+		return nil
+	}
+	if err := g.Set(value); err != nil {
+		return fmt.Errorf("setting feature gates from %s: %w", EnvFeatureGates, err)
+	}
+	return nil
+}
+
+// SetFromConfigMap sets gates from the data of the ConfigMap, each key being
+// the name of a gate and its value a bool. Gates set by a previous ConfigMap
+// and removed from this one return to the value set by flags or their default
+func (g *Gate) SetFromConfigMap(cm *corev1.ConfigMap) error {
+	values := map[string]bool{}
+	if cm != nil {
+		for name, raw := range cm.Data {
+			enabled, err := strconv.ParseBool(strings.TrimSpace(raw))
+			if err != nil {
+				return fmt.Errorf("invalid value of feature gate %q: %w", name, err)
+			}
+			values[name] = enabled
+		}
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if err := g.validate(values); err != nil {
+		return err
+	}
+	g.dynamic = values
+	return nil
+}
+
+// SetupDynamicGates watches the feature gates ConfigMap in the system namespace
+// and updates the gate when it changes. Invalid ConfigMaps are logged and ignored
+func SetupDynamicGates(gate *Gate, configMapWatcher configmap.DefaultingWatcher, logger *zap.SugaredLogger) {
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	dftCm := &corev1.ConfigMap{}
+	dftCm.Name = ConfigMapName()
+	dftCm.Namespace = system.Namespace()
+
+	w := kconfigmap.NewWatcher("config-feature-gates-store", configMapWatcher).WithLogger(logger)
+	w.AddWatch(dftCm.GetName(), kconfigmap.NewConfigConstructor(dftCm, func(cm *corev1.ConfigMap) {
+		if err := gate.SetFromConfigMap(cm); err != nil {
+			logger.Errorw("invalid feature gates configmap", "configmap", cm.GetName(), "err", err)
+		}
+	}))
+	w.Run()
+}
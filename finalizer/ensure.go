@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finalizer
+
+import (
+	"context"
+	"time"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	kclient "github.com/AlaudaDevops/pkg/client"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// optimisticLock makes the patches of the retry loops fail with a conflict instead of
+// dropping the finalizers added concurrently, as merge patches replace the whole list
+var optimisticLock = client.MergeFromWithOptimisticLock{}
+
+// EnsureFinalizer adds a finalizer to the object retrying on conflicts.
+// On conflict the object is fetched again before retrying.
+func EnsureFinalizer(ctx context.Context, clt client.Client, o client.Object, finalizerKey string) error {
+	return retryOnConflict(ctx, clt, o, func() error {
+		return addFinalizer(ctx, clt, o, finalizerKey, optimisticLock)
+	})
+}
+
+// EnsureFinalizerRemoved removes a finalizer from the object retrying on conflicts.
+// On conflict the object is fetched again before retrying.
+// Objects that no longer exist are ignored.
+func EnsureFinalizerRemoved(ctx context.Context, clt client.Client, o client.Object, finalizerKey string) error {
+	return retryOnConflict(ctx, clt, o, func() error {
+		return removeFinalizer(ctx, clt, o, finalizerKey, nil, optimisticLock)
+	})
+}
+
+// EnsureDeletionTracked sets the deletedBy and deletionTime annotations of an object being deleted
+// when missing, using the user in the context and the deletion timestamp, retrying on conflicts.
+// Objects not being deleted are ignored.
+func EnsureDeletionTracked(ctx context.Context, clt client.Client, o client.Object) error {
+	if o.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	return retryOnConflict(ctx, clt, o, func() error {
+		toUpdate := o.DeepCopyObject().(client.Object)
+		if !metav1alpha1.TrackDeletion(toUpdate, kclient.UserSubject(ctx), time.Time{}) {
+			return nil
+		}
+		if err := clt.Patch(ctx, toUpdate, client.MergeFromWithOptions(o, optimisticLock)); err != nil {
+			return err
+		}
+		o.SetAnnotations(toUpdate.GetAnnotations())
+		o.SetResourceVersion(toUpdate.GetResourceVersion())
+		return nil
+	})
+}
+
+func retryOnConflict(ctx context.Context, clt client.Client, o client.Object, fn func() error) error {
+	first := true
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !first {
+			if err := clt.Get(ctx, client.ObjectKeyFromObject(o), o); err != nil {
+				return client.IgnoreNotFound(err)
+			}
+		}
+		first = false
+		return fn()
+	})
+}
+
+// HasFinalizer returns true if the object has the finalizer
+func HasFinalizer(o client.Object, finalizerKey string) bool {
+	return controllerutil.ContainsFinalizer(o, finalizerKey)
+}
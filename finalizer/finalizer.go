@@ -27,6 +27,10 @@ import (
 
 // AddFinalizer adds a finalizer to the object
 func AddFinalizer(ctx context.Context, clt client.Client, o client.Object, finalizerKey string) error {
+	return addFinalizer(ctx, clt, o, finalizerKey)
+}
+
+func addFinalizer(ctx context.Context, clt client.Client, o client.Object, finalizerKey string, opts ...client.MergeFromOption) error {
 	finalizers := o.GetFinalizers()
 	if controllerutil.ContainsFinalizer(o, finalizerKey) {
 		return nil
@@ -34,7 +38,7 @@ func AddFinalizer(ctx context.Context, clt client.Client, o client.Object, final
 
 	toUpdate := o.DeepCopyObject().(client.Object)
 	toUpdate.SetFinalizers(append(finalizers, finalizerKey))
-	err := clt.Patch(ctx, toUpdate, client.MergeFrom(o))
+	err := clt.Patch(ctx, toUpdate, client.MergeFromWithOptions(o, opts...))
 	if err != nil {
 		logging.FromContext(ctx).Errorw("failed to add finalizer", "err", err,
 			"namespacedName", client.ObjectKeyFromObject(o),
@@ -73,6 +77,11 @@ func PrependFinalizer(ctx context.Context, clt client.Client, o client.Object, f
 // RemoveFinalizer removes a finalizer from the object
 func RemoveFinalizer(ctx context.Context, clt client.Client, o client.Object, finalizerKey string,
 	callback func(context.Context) error) error {
+	return removeFinalizer(ctx, clt, o, finalizerKey, callback)
+}
+
+func removeFinalizer(ctx context.Context, clt client.Client, o client.Object, finalizerKey string,
+	callback func(context.Context) error, opts ...client.MergeFromOption) error {
 	if !controllerutil.ContainsFinalizer(o, finalizerKey) {
 		return nil
 	}
@@ -85,7 +94,7 @@ func RemoveFinalizer(ctx context.Context, clt client.Client, o client.Object, fi
 
 	toUpdate := o.DeepCopyObject().(client.Object)
 	controllerutil.RemoveFinalizer(toUpdate, finalizerKey)
-	err := clt.Patch(ctx, toUpdate, client.MergeFrom(o))
+	err := clt.Patch(ctx, toUpdate, client.MergeFromWithOptions(o, opts...))
 	if client.IgnoreNotFound(err) != nil {
 		logging.FromContext(ctx).Errorw("failed to remove finalizer", "err", err,
 			"namespacedName", client.ObjectKeyFromObject(o),
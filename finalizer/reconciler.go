@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finalizer
+
+import (
+	"context"
+
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// FinalizerReconciler splits reconciliation of objects with a finalizer into
+// Reconcile, called while the object is alive, and Finalize, called once the
+// object is being deleted
+type FinalizerReconciler[T client.Object] interface {
+	// Reconcile is called after the finalizer is ensured in the object
+	Reconcile(ctx context.Context, obj T) (reconcile.Result, error)
+	// Finalize is called when the object is being deleted and still has the
+	// finalizer. The finalizer is removed when no error is returned
+	Finalize(ctx context.Context, obj T) error
+}
+
+// Reconciler implements reconcile.Reconciler managing the finalizer of the
+// objects and delegating to a FinalizerReconciler
+type Reconciler[T client.Object] struct {
+	Client       client.Client
+	FinalizerKey string
+	// NewObject returns an empty object of the reconciled type
+	NewObject  func() T
+	Reconciler FinalizerReconciler[T]
+	// TrackDeletion sets the deletedBy and deletionTime annotations before calling Finalize
+	TrackDeletion bool
+}
+
+var _ reconcile.Reconciler = &Reconciler[client.Object]{}
+
+// NewReconciler returns a Reconciler for the finalizer key
+func NewReconciler[T client.Object](clt client.Client, finalizerKey string, newObject func() T, r FinalizerReconciler[T]) *Reconciler[T] {
+	return &Reconciler[T]{
+		Client:       clt,
+		FinalizerKey: finalizerKey,
+		NewObject:    newObject,
+		Reconciler:   r,
+	}
+}
+
+// Reconcile gets the object and adds the finalizer before calling Reconcile,
+// or calls Finalize and removes the finalizer if the object is being deleted
+func (r *Reconciler[T]) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	obj := r.NewObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		if !HasFinalizer(obj, r.FinalizerKey) {
+			return reconcile.Result{}, nil
+		}
+		if r.TrackDeletion {
+			if err := EnsureDeletionTracked(ctx, r.Client, obj); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if err := r.Reconciler.Finalize(ctx, obj); err != nil {
+			return reconcile.Result{}, err
+		}
+		logging.FromContext(ctx).Debugw("finalized object", "namespacedName", req.NamespacedName, "finalizerKey", r.FinalizerKey)
+		return reconcile.Result{}, EnsureFinalizerRemoved(ctx, r.Client, obj, r.FinalizerKey)
+	}
+
+	if err := EnsureFinalizer(ctx, r.Client, obj, r.FinalizerKey); err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.Reconciler.Reconcile(ctx, obj)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finalizer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	kclient "github.com/AlaudaDevops/pkg/client"
+	testing2 "github.com/AlaudaDevops/pkg/testing"
+	"github.com/AlaudaDevops/pkg/testing/chaos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeFinalizerReconciler struct {
+	reconciled  int
+	finalized   int
+	finalizeErr error
+}
+
+func (f *fakeFinalizerReconciler) Reconcile(_ context.Context, _ *corev1.ConfigMap) (reconcile.Result, error) {
+	f.reconciled++
+	return reconcile.Result{}, nil
+}
+
+func (f *fakeFinalizerReconciler) Finalize(_ context.Context, _ *corev1.ConfigMap) error {
+	f.finalized++
+	return f.finalizeErr
+}
+
+var _ = Describe("EnsureFinalizer", func() {
+	var (
+		ctx context.Context
+		clt *chaos.Client
+		cm  *corev1.ConfigMap
+	)
+	BeforeEach(func() {
+		ctx = context.Background()
+		cm = &corev1.ConfigMap{}
+		testing2.MustLoadYaml("./testdata/configmap.yaml", cm)
+		clt = chaos.NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm.DeepCopy()).Build())
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+	})
+
+	It("should retry on conflicts", func() {
+		clt.AddRules(chaos.Rule{Verbs: []chaos.Verb{chaos.VerbPatch}, Times: 1, Error: chaos.ConflictError})
+		Expect(EnsureFinalizer(ctx, clt, cm, testFinalizerKey)).To(Succeed())
+		Expect(clt.Injected()).To(Equal(1))
+
+		current := &corev1.ConfigMap{}
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), current)).To(Succeed())
+		Expect(current.GetFinalizers()).To(ConsistOf(testFinalizerKey))
+
+		clt.AddRules(chaos.Rule{Verbs: []chaos.Verb{chaos.VerbPatch}, Times: 1, Error: chaos.ConflictError})
+		Expect(EnsureFinalizerRemoved(ctx, clt, current, testFinalizerKey)).To(Succeed())
+		Expect(HasFinalizer(current, testFinalizerKey)).To(BeFalse())
+	})
+
+	It("should keep finalizers added concurrently", func() {
+		live := cm.DeepCopy()
+		live.SetFinalizers(append(live.GetFinalizers(), "other.io/finalizer"))
+		Expect(clt.Update(ctx, live)).To(Succeed())
+
+		// cm is stale, the first patch conflicts and the retry reads the live finalizers
+		Expect(EnsureFinalizer(ctx, clt, cm, testFinalizerKey)).To(Succeed())
+		current := &corev1.ConfigMap{}
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), current)).To(Succeed())
+		Expect(current.GetFinalizers()).To(ConsistOf("other.io/finalizer", testFinalizerKey))
+
+		stale := current.DeepCopy()
+		live = current.DeepCopy()
+		live.SetFinalizers(append(live.GetFinalizers(), "another.io/finalizer"))
+		Expect(clt.Update(ctx, live)).To(Succeed())
+		Expect(EnsureFinalizerRemoved(ctx, clt, stale, testFinalizerKey)).To(Succeed())
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), current)).To(Succeed())
+		Expect(current.GetFinalizers()).To(ConsistOf("other.io/finalizer", "another.io/finalizer"))
+	})
+
+	It("should not lock the patches of AddFinalizer and RemoveFinalizer", func() {
+		live := cm.DeepCopy()
+		live.SetLabels(map[string]string{"changed": "true"})
+		Expect(clt.Update(ctx, live)).To(Succeed())
+
+		// cm is stale but the patches do not include its resource version
+		Expect(AddFinalizer(ctx, clt, cm, testFinalizerKey)).To(Succeed())
+		current := &corev1.ConfigMap{}
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), current)).To(Succeed())
+		current.Labels["changed"] = "again"
+		Expect(clt.Update(ctx, current)).To(Succeed())
+
+		Expect(RemoveFinalizer(ctx, clt, cm, testFinalizerKey, nil)).To(Succeed())
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), current)).To(Succeed())
+		Expect(HasFinalizer(current, testFinalizerKey)).To(BeFalse())
+	})
+
+	It("should return other errors", func() {
+		clt.AddRules(chaos.TransientErrors(chaos.VerbPatch, 1))
+		Expect(EnsureFinalizer(ctx, clt, cm, testFinalizerKey)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctx        context.Context
+		clt        client.Client
+		cm         *corev1.ConfigMap
+		fakeR      *fakeFinalizerReconciler
+		r          *Reconciler[*corev1.ConfigMap]
+		err        error
+		newObject  = func() *corev1.ConfigMap { return &corev1.ConfigMap{} }
+		reconcileR = func() {
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cm)})
+		}
+	)
+	BeforeEach(func() {
+		ctx = context.Background()
+		cm = &corev1.ConfigMap{}
+		testing2.MustLoadYaml("./testdata/configmap.yaml", cm)
+		clt = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+		fakeR = &fakeFinalizerReconciler{}
+		r = NewReconciler(clt, testFinalizerKey, newObject, fakeR)
+	})
+
+	It("should add the finalizer and reconcile", func() {
+		reconcileR()
+		Expect(err).To(Succeed())
+		Expect(fakeR.reconciled).To(Equal(1))
+		Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		Expect(cm.GetFinalizers()).To(ContainElement(testFinalizerKey))
+	})
+
+	When("object is being deleted", func() {
+		BeforeEach(func() {
+			reconcileR()
+			Expect(clt.Delete(ctx, cm)).To(Succeed())
+		})
+
+		It("should finalize and remove the finalizer", func() {
+			reconcileR()
+			Expect(err).To(Succeed())
+			Expect(fakeR.finalized).To(Equal(1))
+			Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), cm)).NotTo(Succeed())
+		})
+
+		It("should track the deletion before finalizing", func() {
+			r.TrackDeletion = true
+			fakeR.finalizeErr = errors.New("failed")
+			ctx = kclient.WithUser(ctx, &user.DefaultInfo{Name: "admin"})
+			reconcileR()
+			Expect(err).To(MatchError("failed"))
+			Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+			by, _ := metav1alpha1.GetDeletedBy(cm)
+			Expect(by.User.Name).To(Equal("admin"))
+			deleted, _ := metav1alpha1.GetDeletedTime(cm)
+			Expect(deleted).To(BeTemporally("~", cm.DeletionTimestamp.Time, time.Second))
+		})
+
+		It("should keep the finalizer when finalize fails", func() {
+			fakeR.finalizeErr = errors.New("failed")
+			reconcileR()
+			Expect(err).To(MatchError("failed"))
+			Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+			Expect(cm.GetFinalizers()).To(ContainElement(testFinalizerKey))
+		})
+	})
+
+	It("should ignore missing objects", func() {
+		Expect(clt.Delete(ctx, cm)).To(Succeed())
+		reconcileR()
+		Expect(err).To(Succeed())
+		Expect(fakeR.reconciled).To(Equal(0))
+	})
+})
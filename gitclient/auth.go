@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclient
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+)
+
+// authEnv returns the environment variables authenticating git commands with auth against remoteURL.
+// Basic auth and tokens are sent as an http header given in the environment, instead of being
+// stored in the remote url of the repository, and only to remoteURL so redirects and submodules
+// on other hosts or paths do not receive them. ssh keys are written into temporary files
+// removed by cleanup
+func authEnv(auth credentials.Credential, remoteURL string) (env []string, cleanup func(), err error) {
+	cleanup = func() {}
+	switch auth := auth.(type) {
+	case nil:
+		return nil, cleanup, nil
+	case *credentials.SSH:
+		return sshEnv(auth)
+	case credentials.URLAuthenticator:
+		remote, err := url.Parse(remoteURL)
+		if err != nil || remote.Host == "" {
+			return nil, cleanup, fmt.Errorf("http credentials need an http remote url, got %q", remoteURL)
+		}
+		// the header is scoped to the remote, without user info which git ignores when matching
+		remote.User = nil
+		user := auth.AuthenticateURL(&url.URL{}).User
+		password, _ := user.Password()
+		header := "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password))
+		return []string{
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http." + remote.String() + ".extraHeader",
+			"GIT_CONFIG_VALUE_0=" + header,
+		}, cleanup, nil
+	}
+	return nil, cleanup, fmt.Errorf("%s credentials are not supported by git", auth.Type())
+}
+
+// sshEnv writes the private key and known hosts into a temporary directory used by the
+// ssh command of git. Known hosts are required unless InsecureIgnoreHostKey is set
+func sshEnv(auth *credentials.SSH) (env []string, cleanup func(), err error) {
+	cleanup = func() {}
+	if len(auth.KnownHosts) == 0 && !auth.InsecureIgnoreHostKey {
+		return nil, cleanup, fmt.Errorf("ssh credentials need known hosts to verify the server, or InsecureIgnoreHostKey to skip the verification")
+	}
+	dir, err := os.MkdirTemp("", "gitclient-ssh-*")
+	if err != nil {
+		return nil, cleanup, err
+	}
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	key := filepath.Join(dir, "id")
+	if err = os.WriteFile(key, auth.PrivateKey, 0o600); err != nil {
+		return nil, cleanup, err
+	}
+	command := []string{"ssh", "-i", shellQuote(key), "-o", "IdentitiesOnly=yes"}
+	if len(auth.KnownHosts) > 0 {
+		knownHosts := filepath.Join(dir, "known_hosts")
+		if err = os.WriteFile(knownHosts, auth.KnownHosts, 0o600); err != nil {
+			return nil, cleanup, err
+		}
+		command = append(command, "-o", "UserKnownHostsFile="+shellQuote(knownHosts), "-o", "StrictHostKeyChecking=yes")
+	} else {
+		command = append(command, "-o", "UserKnownHostsFile=/dev/null", "-o", "StrictHostKeyChecking=no")
+	}
+	return []string{"GIT_SSH_COMMAND=" + strings.Join(command, " ")}, cleanup, nil
+}
+
+// shellQuote quotes s for the shell running GIT_SSH_COMMAND
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitclient clones, fetches, checks out, commits and pushes git repositories
+// authenticating with credentials resolved by the credentials package.
+//
+// New returns a Client using the git cli, which must be installed, while NewMemory
+// returns a Client keeping remotes and worktrees in memory for tests:
+//
+//	cred, err := credentials.Resolve(secret)
+//	repo, err := gitclient.New().Clone(ctx, "https://github.com/org/repo.git", dir, gitclient.CloneOptions{
+//		Auth:   cred,
+//		Branch: "main",
+//		Depth:  1,
+//	})
+//	err = repo.WriteFile("deploy/app.yaml", content)
+//	hash, err := repo.Commit(ctx, "update app", gitclient.CommitOptions{Author: gitclient.Signature{Name: "bot", Email: "bot@example.com"}})
+//	err = repo.Push(ctx, gitclient.PushOptions{Auth: cred})
+package gitclient
@@ -0,0 +1,307 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+)
+
+// DefaultGitBinary is the git executable looked up in PATH when none is given
+const DefaultGitBinary = "git"
+
+// Git is a Client running the git cli, so git must be installed
+type Git struct {
+	// Binary is the git executable, defaults to DefaultGitBinary
+	Binary string
+	// Env is added to the environment of every git command
+	Env []string
+}
+
+var _ Client = &Git{}
+
+// New returns a Client running the git cli found in PATH
+func New() *Git {
+	return &Git{}
+}
+
+// Clone implements Client. Like git, a shallow clone only fetches one branch
+func (g *Git) Clone(ctx context.Context, url, dir string, opts CloneOptions) (Repository, error) {
+	args := []string{"clone", "--quiet"}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.SingleBranch {
+		args = append(args, "--single-branch")
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter="+opts.Filter)
+	}
+	args = append(args, "--", url, dir)
+	if _, err := g.run(ctx, "", opts.Auth, url, nil, args...); err != nil {
+		if isReferenceNotFound(err) {
+			return nil, fmt.Errorf("clone %s failed: branch %s: %w", url, opts.Branch, ErrReferenceNotFound)
+		}
+		return nil, err
+	}
+	return &gitRepository{git: g, dir: dir}, nil
+}
+
+// Open implements Client
+func (g *Git) Open(ctx context.Context, dir string) (Repository, error) {
+	if _, err := g.run(ctx, dir, nil, "", nil, "rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("open repository %s failed: %w", dir, err)
+	}
+	return &gitRepository{git: g, dir: dir}, nil
+}
+
+// run runs git in dir authenticated with auth against remoteURL and returns its trimmed output
+func (g *Git) run(ctx context.Context, dir string, auth credentials.Credential, remoteURL string, env []string, args ...string) (string, error) {
+	authEnv, cleanup, err := authEnv(auth, remoteURL)
+	defer cleanup()
+	if err != nil {
+		return "", err
+	}
+	binary := g.Binary
+	if binary == "" {
+		binary = DefaultGitBinary
+	}
+	cmdArgs := args
+	if dir != "" {
+		cmdArgs = append([]string{"-C", dir}, args...)
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, binary, cmdArgs...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// never wait for credentials typed in a terminal
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(append(append(cmd.Env, g.Env...), env...), authEnv...)
+	if err = cmd.Run(); err != nil {
+		return "", &gitError{command: args[0], stderr: strings.TrimSpace(stderr.String()), err: err}
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitError is returned when a git command fails
+type gitError struct {
+	command string
+	stderr  string
+	err     error
+}
+
+func (e *gitError) Error() string {
+	return fmt.Sprintf("git %s failed: %s: %s", e.command, e.err, e.stderr)
+}
+
+func (e *gitError) Unwrap() error {
+	return e.err
+}
+
+func stderrContains(err error, substrs ...string) bool {
+	gitErr := &gitError{}
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	for _, substr := range substrs {
+		if strings.Contains(gitErr.stderr, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isReferenceNotFound(err error) bool {
+	return stderrContains(err, "invalid reference", "not found in upstream")
+}
+
+type gitRepository struct {
+	git *Git
+	dir string
+}
+
+var _ Repository = &gitRepository{}
+
+func (r *gitRepository) run(ctx context.Context, auth credentials.Credential, args ...string) (string, error) {
+	return r.git.run(ctx, r.dir, auth, "", nil, args...)
+}
+
+// runRemote runs git authenticated with auth against the url of remote, which can be a remote name or an url
+func (r *gitRepository) runRemote(ctx context.Context, auth credentials.Credential, remote string, args ...string) (string, error) {
+	remoteURL := remote
+	if auth != nil && !strings.Contains(remote, "://") {
+		var err error
+		if remoteURL, err = r.run(ctx, nil, "remote", "get-url", "--", remote); err != nil {
+			return "", err
+		}
+	}
+	return r.git.run(ctx, r.dir, auth, remoteURL, nil, args...)
+}
+
+// Dir implements Repository
+func (r *gitRepository) Dir() string {
+	return r.dir
+}
+
+// Head implements Repository
+func (r *gitRepository) Head(ctx context.Context) (ref Reference, err error) {
+	if ref.Hash, err = r.run(ctx, nil, "rev-parse", "HEAD"); err != nil {
+		return ref, err
+	}
+	branch, err := r.run(ctx, nil, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return ref, err
+	}
+	if branch != "HEAD" {
+		ref.Branch = branch
+	}
+	return ref, nil
+}
+
+// Fetch implements Repository
+func (r *gitRepository) Fetch(ctx context.Context, opts FetchOptions) error {
+	args := []string{"fetch", "--quiet"}
+	if opts.Prune {
+		args = append(args, "--prune")
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	remote := remoteOrDefault(opts.Remote)
+	args = append(append(args, remote), opts.RefSpecs...)
+	_, err := r.runRemote(ctx, opts.Auth, remote, args...)
+	return err
+}
+
+// Checkout implements Repository
+func (r *gitRepository) Checkout(ctx context.Context, ref string, opts CheckoutOptions) (err error) {
+	if opts.Create {
+		_, err = r.run(ctx, nil, "checkout", "--quiet", "-b", ref)
+	} else {
+		_, err = r.run(ctx, nil, "checkout", "--quiet", ref, "--")
+	}
+	if isReferenceNotFound(err) {
+		return fmt.Errorf("checkout %s failed: %w", ref, ErrReferenceNotFound)
+	}
+	return err
+}
+
+// ReadFile implements Repository
+func (r *gitRepository) ReadFile(path string) ([]byte, error) {
+	path, err := r.path(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// WriteFile implements Repository
+func (r *gitRepository) WriteFile(path string, data []byte) error {
+	path, err := r.path(path)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// RemoveFile implements Repository
+func (r *gitRepository) RemoveFile(path string) error {
+	path, err := r.path(path)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// path returns the path of a file of the worktree
+func (r *gitRepository) path(path string) (string, error) {
+	path, err := worktreePath(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(r.dir, filepath.FromSlash(path)), nil
+}
+
+// Commit implements Repository
+func (r *gitRepository) Commit(ctx context.Context, message string, opts CommitOptions) (string, error) {
+	if _, err := r.run(ctx, nil, "add", "--all"); err != nil {
+		return "", err
+	}
+	args := []string{"commit", "--quiet", "--message", message}
+	if opts.AllowEmpty {
+		args = append(args, "--allow-empty")
+	} else {
+		status, err := r.run(ctx, nil, "status", "--porcelain")
+		if err != nil {
+			return "", err
+		}
+		if status == "" {
+			return "", ErrNothingToCommit
+		}
+	}
+	var env []string
+	if opts.Author.Name != "" {
+		env = append(env, "GIT_AUTHOR_NAME="+opts.Author.Name, "GIT_COMMITTER_NAME="+opts.Author.Name)
+	}
+	if opts.Author.Email != "" {
+		env = append(env, "GIT_AUTHOR_EMAIL="+opts.Author.Email, "GIT_COMMITTER_EMAIL="+opts.Author.Email)
+	}
+	if _, err := r.git.run(ctx, r.dir, nil, "", env, args...); err != nil {
+		return "", err
+	}
+	return r.run(ctx, nil, "rev-parse", "HEAD")
+}
+
+// Push implements Repository
+func (r *gitRepository) Push(ctx context.Context, opts PushOptions) error {
+	branch := opts.Branch
+	if branch == "" {
+		head, err := r.Head(ctx)
+		if err != nil {
+			return err
+		}
+		if head.Branch == "" {
+			return fmt.Errorf("push failed: head is detached and no branch was given")
+		}
+		branch = head.Branch
+	}
+	args := []string{"push", "--quiet"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	remote := remoteOrDefault(opts.Remote)
+	args = append(args, remote, "HEAD:refs/heads/"+branch)
+	_, err := r.runRemote(ctx, opts.Auth, remote, args...)
+	if stderrContains(err, "non-fast-forward", "fetch first") {
+		return fmt.Errorf("push %s failed: %w", branch, ErrNonFastForward)
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclient
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+)
+
+var author = Signature{Name: "bot", Email: "bot@example.com"}
+
+// newRemote creates a bare repository with a commit on main, returning its file url
+func newRemote(t *testing.T, g *WithT) string {
+	if _, err := exec.LookPath(DefaultGitBinary); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "remote.git")
+	g.Expect(exec.Command("git", "init", "--quiet", "--bare", "--initial-branch", "main", dir).Run()).To(Succeed())
+	url := "file://" + dir
+
+	repo, err := New().Clone(ctx, url, filepath.Join(t.TempDir(), "seed"), CloneOptions{})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Checkout(ctx, "main", CheckoutOptions{Create: true})).To(Succeed())
+	g.Expect(repo.WriteFile("README.md", []byte("first"))).To(Succeed())
+	_, err = repo.Commit(ctx, "first", CommitOptions{Author: author})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.WriteFile("README.md", []byte("second"))).To(Succeed())
+	_, err = repo.Commit(ctx, "second", CommitOptions{Author: author})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Push(ctx, PushOptions{})).To(Succeed())
+	return url
+}
+
+func TestGit(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	url := newRemote(t, g)
+	client := New()
+
+	repo, err := client.Clone(ctx, url, filepath.Join(t.TempDir(), "clone"), CloneOptions{Depth: 1, Filter: FilterBlobless})
+	g.Expect(err).To(BeNil())
+	shallow, err := repo.(*gitRepository).run(ctx, nil, "rev-parse", "--is-shallow-repository")
+	g.Expect(err).To(BeNil())
+	g.Expect(shallow).To(Equal("true"))
+	content, err := repo.ReadFile("README.md")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal("second"))
+
+	_, err = repo.Commit(ctx, "nothing", CommitOptions{Author: author})
+	g.Expect(err).To(MatchError(ErrNothingToCommit))
+
+	g.Expect(repo.Checkout(ctx, "feature", CheckoutOptions{Create: true})).To(Succeed())
+	g.Expect(repo.WriteFile("deploy/app.yaml", []byte("kind: Deployment"))).To(Succeed())
+	g.Expect(repo.RemoveFile("README.md")).To(Succeed())
+	hash, err := repo.Commit(ctx, "add app", CommitOptions{Author: author})
+	g.Expect(err).To(BeNil())
+	head, err := repo.Head(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(head).To(Equal(Reference{Hash: hash, Branch: "feature"}))
+	g.Expect(repo.Push(ctx, PushOptions{})).To(Succeed())
+
+	// another clone checks out the pushed branch and its commit
+	other, err := client.Clone(ctx, url, filepath.Join(t.TempDir(), "other"), CloneOptions{})
+	g.Expect(err).To(BeNil())
+	g.Expect(other.Checkout(ctx, "feature", CheckoutOptions{})).To(Succeed())
+	head, err = other.Head(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(head).To(Equal(Reference{Hash: hash, Branch: "feature"}))
+	_, err = other.ReadFile("README.md")
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+	g.Expect(other.Checkout(ctx, "missing", CheckoutOptions{})).To(MatchError(ErrReferenceNotFound))
+
+	// pushing a diverged branch is rejected unless forced
+	g.Expect(other.WriteFile("other", []byte("other"))).To(Succeed())
+	_, err = other.Commit(ctx, "other", CommitOptions{Author: author})
+	g.Expect(err).To(BeNil())
+	g.Expect(other.Push(ctx, PushOptions{})).To(Succeed())
+	g.Expect(repo.WriteFile("diverged", []byte("diverged"))).To(Succeed())
+	_, err = repo.Commit(ctx, "diverged", CommitOptions{Author: author})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Push(ctx, PushOptions{})).To(MatchError(ErrNonFastForward))
+	g.Expect(repo.Push(ctx, PushOptions{Force: true})).To(Succeed())
+
+	g.Expect(other.Fetch(ctx, FetchOptions{Prune: true})).To(Succeed())
+	g.Expect(other.Checkout(ctx, "origin/feature", CheckoutOptions{})).To(Succeed())
+	content, err = other.ReadFile("diverged")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal("diverged"))
+	head, err = other.Head(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(head.Branch).To(BeEmpty())
+
+	opened, err := client.Open(ctx, other.Dir())
+	g.Expect(err).To(BeNil())
+	g.Expect(opened.Dir()).To(Equal(other.Dir()))
+	_, err = client.Open(ctx, t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("open repository")))
+
+	_, err = client.Clone(ctx, url, filepath.Join(t.TempDir(), "missing"), CloneOptions{Branch: "missing"})
+	g.Expect(err).To(MatchError(ErrReferenceNotFound))
+	g.Expect(repo.WriteFile("../outside", nil)).To(MatchError(ContainSubstring("is not inside the worktree")))
+}
+
+func TestAuthEnv(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	env, cleanup, err := authEnv(nil, "")
+	cleanup()
+	g.Expect(err).To(BeNil())
+	g.Expect(env).To(BeEmpty())
+
+	_, cleanup, err = authEnv(&credentials.BearerToken{Token: "secret"}, "/tmp/remote.git")
+	cleanup()
+	g.Expect(err).To(MatchError(`http credentials need an http remote url, got "/tmp/remote.git"`))
+
+	// the header is only sent to the remote
+	env, cleanup, err = authEnv(&credentials.BearerToken{Token: "secret"}, "https://bot@git.example.com/org/repo.git")
+	cleanup()
+	g.Expect(err).To(BeNil())
+	g.Expect(env).To(Equal([]string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.https://git.example.com/org/repo.git.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("oauth2:secret")),
+	}))
+
+	// host keys are verified unless explicitly disabled
+	_, cleanup, err = authEnv(&credentials.SSH{PrivateKey: []byte("key")}, "")
+	cleanup()
+	g.Expect(err).To(MatchError(ContainSubstring("ssh credentials need known hosts")))
+	env, cleanup, err = authEnv(&credentials.SSH{PrivateKey: []byte("key"), InsecureIgnoreHostKey: true}, "")
+	cleanup()
+	g.Expect(err).To(BeNil())
+	g.Expect(env[0]).To(ContainSubstring("StrictHostKeyChecking=no"))
+
+	env, cleanup, err = authEnv(&credentials.SSH{PrivateKey: []byte("key"), KnownHosts: []byte("hosts")}, "")
+	g.Expect(err).To(BeNil())
+	g.Expect(env).To(HaveLen(1))
+	g.Expect(env[0]).To(HavePrefix("GIT_SSH_COMMAND=ssh -i '"))
+	g.Expect(env[0]).To(ContainSubstring("StrictHostKeyChecking=yes"))
+	key := strings.Trim(strings.Fields(env[0])[2], "'")
+	content, err := os.ReadFile(key)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal("key"))
+	cleanup()
+	_, err = os.Stat(key)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	_, cleanup, err = authEnv(&credentials.DockerConfig{}, "")
+	cleanup()
+	g.Expect(err).To(MatchError("DockerConfig credentials are not supported by git"))
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+)
+
+const (
+	// DefaultRemote is the remote fetched and pushed when none is given
+	DefaultRemote = "origin"
+	// DefaultBranch is the branch of repositories created by Memory.SetRemote without a branch
+	DefaultBranch = "main"
+	// FilterBlobless is the partial clone filter fetching blobs on demand
+	FilterBlobless = "blob:none"
+	// FilterTreeless is the partial clone filter fetching trees and blobs on demand
+	FilterTreeless = "tree:0"
+)
+
+var (
+	// ErrNothingToCommit is returned by Repository.Commit when the worktree has no change
+	ErrNothingToCommit = errors.New("nothing to commit")
+	// ErrNonFastForward is returned by Repository.Push when the remote branch has commits
+	// missing in the local branch and the push is not forced
+	ErrNonFastForward = errors.New("non-fast-forward update rejected")
+	// ErrReferenceNotFound is returned when a branch, tag or commit does not exist
+	ErrReferenceNotFound = errors.New("reference not found")
+)
+
+// Client clones and opens repositories
+type Client interface {
+	// Clone clones the repository at url into dir
+	Clone(ctx context.Context, url, dir string, opts CloneOptions) (Repository, error)
+	// Open opens the repository previously cloned into dir
+	Open(ctx context.Context, dir string) (Repository, error)
+}
+
+// Repository is a cloned repository with a worktree
+type Repository interface {
+	// Dir is the directory of the worktree
+	Dir() string
+	// Head returns the checked out commit and branch
+	Head(ctx context.Context) (Reference, error)
+	// Fetch updates the remote tracking branches
+	Fetch(ctx context.Context, opts FetchOptions) error
+	// Checkout checks out a branch, a remote branch, a tag or a commit.
+	// Remote branches are checked out as local branches tracking them, like git checkout does
+	Checkout(ctx context.Context, ref string, opts CheckoutOptions) error
+	// ReadFile reads a file of the worktree, path is relative to Dir
+	ReadFile(path string) ([]byte, error)
+	// WriteFile writes a file of the worktree creating its parent directories, path is relative to Dir
+	WriteFile(path string, data []byte) error
+	// RemoveFile removes a file of the worktree, path is relative to Dir
+	RemoveFile(path string) error
+	// Commit commits all the changes of the worktree and returns the commit hash.
+	// Returns ErrNothingToCommit when there is no change and empty commits are not allowed
+	Commit(ctx context.Context, message string, opts CommitOptions) (string, error)
+	// Push pushes the checked out branch to the remote branch of the same name unless another is given.
+	// Returns an error wrapping ErrNonFastForward when the remote branch diverged
+	Push(ctx context.Context, opts PushOptions) error
+}
+
+// Reference is a commit and the branch it was checked out from
+type Reference struct {
+	// Hash of the commit
+	Hash string
+	// Branch checked out, empty when the head is detached
+	Branch string
+}
+
+// CloneOptions options of Client.Clone
+type CloneOptions struct {
+	// Auth authenticates against the remote, supports credentials.BasicAuth,
+	// credentials.BearerToken and credentials.SSH
+	Auth credentials.Credential
+	// Branch or tag checked out, the remote default branch when empty
+	Branch string
+	// Depth creates a shallow clone with the given number of commits when positive
+	Depth int
+	// SingleBranch only fetches Branch, or the remote default branch
+	SingleBranch bool
+	// Filter creates a partial clone fetching objects on demand, e.g. FilterBlobless
+	Filter string
+}
+
+// FetchOptions options of Repository.Fetch
+type FetchOptions struct {
+	// Auth authenticates against the remote
+	Auth credentials.Credential
+	// Remote fetched, defaults to DefaultRemote
+	Remote string
+	// RefSpecs fetched, the configured refspecs of the remote when empty
+	RefSpecs []string
+	// Depth limits the fetched history when positive
+	Depth int
+	// Prune removes remote tracking branches deleted in the remote
+	Prune bool
+}
+
+// CheckoutOptions options of Repository.Checkout
+type CheckoutOptions struct {
+	// Create creates the branch ref at the current head before checking it out
+	Create bool
+}
+
+// Signature identifies the author of commits
+type Signature struct {
+	Name  string
+	Email string
+}
+
+// CommitOptions options of Repository.Commit
+type CommitOptions struct {
+	// Author and committer of the commit, the git configuration when empty
+	Author Signature
+	// AllowEmpty creates the commit even if there is no change
+	AllowEmpty bool
+}
+
+// PushOptions options of Repository.Push
+type PushOptions struct {
+	// Auth authenticates against the remote
+	Auth credentials.Credential
+	// Remote pushed, defaults to DefaultRemote
+	Remote string
+	// Branch of the remote updated, defaults to the checked out branch
+	Branch string
+	// Force overwrites the remote branch even if it diverged
+	Force bool
+}
+
+func remoteOrDefault(remote string) string {
+	if remote == "" {
+		return DefaultRemote
+	}
+	return remote
+}
+
+// worktreePath returns the clean slash separated path of a worktree file,
+// rejecting absolute paths and paths outside of the worktree
+func worktreePath(name string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", fmt.Errorf("path %q is not inside the worktree", name)
+	}
+	return path.Clean(filepath.ToSlash(name)), nil
+}
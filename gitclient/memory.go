@@ -0,0 +1,416 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclient
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+)
+
+// Memory is a Client keeping remote repositories and worktrees in memory, for tests.
+// Remotes are created with CommitRemote and cloned using their url, worktrees are
+// identified by the directory given to Clone and are never written to disk.
+//
+// The whole history is always fetched, ignoring depth, filters and refspecs,
+// only the DefaultRemote exists and checking out discards uncommitted changes
+type Memory struct {
+	// Authorize, when set, is called with the url and credential of every clone,
+	// fetch and push, failing them when it returns an error
+	Authorize func(url string, auth credentials.Credential) error
+
+	lock     sync.Mutex
+	sequence int
+	remotes  map[string]*memoryRemote
+	repos    map[string]*memoryRepository
+}
+
+var _ Client = &Memory{}
+
+// NewMemory returns an empty Memory client
+func NewMemory() *Memory {
+	return &Memory{
+		remotes: map[string]*memoryRemote{},
+		repos:   map[string]*memoryRepository{},
+	}
+}
+
+type memoryCommit struct {
+	hash    string
+	parent  *memoryCommit
+	message string
+	author  Signature
+	files   map[string][]byte
+}
+
+type memoryRemote struct {
+	defaultBranch string
+	branches      map[string]*memoryCommit
+}
+
+// newCommit returns a commit of files on top of parent
+func (m *Memory) newCommit(parent *memoryCommit, message string, author Signature, files map[string][]byte) *memoryCommit {
+	m.sequence++
+	hash := sha1.New()
+	hash.Write([]byte(strconv.Itoa(m.sequence) + "\x00" + message + "\x00"))
+	if parent != nil {
+		hash.Write([]byte(parent.hash))
+	}
+	for _, name := range sortedKeys(files) {
+		hash.Write([]byte(name + "\x00"))
+		hash.Write(files[name])
+	}
+	return &memoryCommit{
+		hash:    hex.EncodeToString(hash.Sum(nil)),
+		parent:  parent,
+		message: message,
+		author:  author,
+		files:   maps.Clone(files),
+	}
+}
+
+// CommitRemote commits files, replacing all the files of the branch, into the repository at url
+// and returns the commit hash. The repository is created if it does not exist and its first
+// branch is the default branch, branch defaults to DefaultBranch
+func (m *Memory) CommitRemote(url, branch, message string, files map[string][]byte) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if branch == "" {
+		branch = DefaultBranch
+	}
+	remote := m.remotes[url]
+	if remote == nil {
+		remote = &memoryRemote{defaultBranch: branch, branches: map[string]*memoryCommit{}}
+		m.remotes[url] = remote
+	}
+	commit := m.newCommit(remote.branches[branch], message, Signature{}, files)
+	remote.branches[branch] = commit
+	return commit.hash
+}
+
+// RemoteBranch returns the head commit hash and files of a branch of the repository at url
+func (m *Memory) RemoteBranch(url, branch string) (hash string, files map[string][]byte, ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	remote := m.remotes[url]
+	if remote == nil {
+		return "", nil, false
+	}
+	commit := remote.branches[branch]
+	if commit == nil {
+		return "", nil, false
+	}
+	return commit.hash, maps.Clone(commit.files), true
+}
+
+// RemoteMessages returns the commit messages of a branch of the repository at url, latest first
+func (m *Memory) RemoteMessages(url, branch string) (messages []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	remote := m.remotes[url]
+	if remote == nil {
+		return nil
+	}
+	for commit := remote.branches[branch]; commit != nil; commit = commit.parent {
+		messages = append(messages, commit.message)
+	}
+	return messages
+}
+
+func (m *Memory) remote(url string, auth credentials.Credential) (*memoryRemote, error) {
+	if m.Authorize != nil {
+		if err := m.Authorize(url, auth); err != nil {
+			return nil, err
+		}
+	}
+	remote := m.remotes[url]
+	if remote == nil {
+		return nil, fmt.Errorf("repository %s not found", url)
+	}
+	return remote, nil
+}
+
+// Clone implements Client
+func (m *Memory) Clone(_ context.Context, url, dir string, opts CloneOptions) (Repository, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, exists := m.repos[dir]; exists {
+		return nil, fmt.Errorf("clone %s failed: %s already exists", url, dir)
+	}
+	remote, err := m.remote(url, opts.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("clone %s failed: %w", url, err)
+	}
+	branch := opts.Branch
+	if branch == "" {
+		branch = remote.defaultBranch
+	}
+	repo := &memoryRepository{
+		memory:         m,
+		url:            url,
+		dir:            dir,
+		branches:       map[string]*memoryCommit{},
+		remoteBranches: map[string]*memoryCommit{},
+		files:          map[string][]byte{},
+	}
+	if opts.SingleBranch {
+		if commit := remote.branches[branch]; commit != nil {
+			repo.remoteBranches[branch] = commit
+		}
+	} else {
+		maps.Copy(repo.remoteBranches, remote.branches)
+	}
+	if len(remote.branches) > 0 {
+		if err = repo.checkout(branch, CheckoutOptions{}); err != nil {
+			return nil, fmt.Errorf("clone %s failed: branch %s: %w", url, branch, ErrReferenceNotFound)
+		}
+	}
+	m.repos[dir] = repo
+	return repo, nil
+}
+
+// Open implements Client
+func (m *Memory) Open(_ context.Context, dir string) (Repository, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	repo := m.repos[dir]
+	if repo == nil {
+		return nil, fmt.Errorf("open repository %s failed: %w", dir, fs.ErrNotExist)
+	}
+	return repo, nil
+}
+
+type memoryRepository struct {
+	memory         *Memory
+	url            string
+	dir            string
+	branches       map[string]*memoryCommit
+	remoteBranches map[string]*memoryCommit
+	// branch checked out, empty when detached
+	branch string
+	head   *memoryCommit
+	files  map[string][]byte
+}
+
+var _ Repository = &memoryRepository{}
+
+// Dir implements Repository
+func (r *memoryRepository) Dir() string {
+	return r.dir
+}
+
+// Head implements Repository
+func (r *memoryRepository) Head(_ context.Context) (Reference, error) {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	if r.head == nil {
+		return Reference{}, fmt.Errorf("head: %w", ErrReferenceNotFound)
+	}
+	return Reference{Hash: r.head.hash, Branch: r.branch}, nil
+}
+
+// Fetch implements Repository
+func (r *memoryRepository) Fetch(_ context.Context, opts FetchOptions) error {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	if remote := remoteOrDefault(opts.Remote); remote != DefaultRemote {
+		return fmt.Errorf("fetch failed: remote %s not found", remote)
+	}
+	remote, err := r.memory.remote(r.url, opts.Auth)
+	if err != nil {
+		return fmt.Errorf("fetch failed: %w", err)
+	}
+	if opts.Prune {
+		for branch := range r.remoteBranches {
+			if _, ok := remote.branches[branch]; !ok {
+				delete(r.remoteBranches, branch)
+			}
+		}
+	}
+	maps.Copy(r.remoteBranches, remote.branches)
+	return nil
+}
+
+// Checkout implements Repository
+func (r *memoryRepository) Checkout(_ context.Context, ref string, opts CheckoutOptions) error {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	return r.checkout(ref, opts)
+}
+
+func (r *memoryRepository) checkout(ref string, opts CheckoutOptions) error {
+	if opts.Create {
+		if _, exists := r.branches[ref]; exists {
+			return fmt.Errorf("checkout failed: branch %s already exists", ref)
+		}
+		r.branches[ref] = r.head
+		r.branch = ref
+		return nil
+	}
+
+	branch, commit := "", r.branches[ref]
+	switch {
+	case commit != nil:
+		branch = ref
+	case r.remoteBranches[ref] != nil:
+		branch, commit = ref, r.remoteBranches[ref]
+		r.branches[branch] = commit
+	case strings.HasPrefix(ref, DefaultRemote+"/"):
+		commit = r.remoteBranches[strings.TrimPrefix(ref, DefaultRemote+"/")]
+	default:
+		commit = r.find(ref)
+	}
+	if commit == nil {
+		return fmt.Errorf("checkout %s failed: %w", ref, ErrReferenceNotFound)
+	}
+	r.branch, r.head, r.files = branch, commit, maps.Clone(commit.files)
+	return nil
+}
+
+// find returns the commit of hash reachable from a branch
+func (r *memoryRepository) find(hash string) *memoryCommit {
+	for _, heads := range []map[string]*memoryCommit{r.branches, r.remoteBranches} {
+		for _, head := range heads {
+			for commit := head; commit != nil; commit = commit.parent {
+				if commit.hash == hash {
+					return commit
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ReadFile implements Repository
+func (r *memoryRepository) ReadFile(path string) ([]byte, error) {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	name, err := worktreePath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := r.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile implements Repository
+func (r *memoryRepository) WriteFile(path string, data []byte) error {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	name, err := worktreePath(path)
+	if err != nil {
+		return err
+	}
+	r.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// RemoveFile implements Repository
+func (r *memoryRepository) RemoveFile(path string) error {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	name, err := worktreePath(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrNotExist}
+	}
+	delete(r.files, name)
+	return nil
+}
+
+// Commit implements Repository
+func (r *memoryRepository) Commit(_ context.Context, message string, opts CommitOptions) (string, error) {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	var files map[string][]byte
+	if r.head != nil {
+		files = r.head.files
+	}
+	if !opts.AllowEmpty && maps.EqualFunc(files, r.files, func(a, b []byte) bool { return string(a) == string(b) }) {
+		return "", ErrNothingToCommit
+	}
+	r.head = r.memory.newCommit(r.head, message, opts.Author, r.files)
+	if r.branch != "" {
+		r.branches[r.branch] = r.head
+	}
+	return r.head.hash, nil
+}
+
+// Push implements Repository
+func (r *memoryRepository) Push(_ context.Context, opts PushOptions) error {
+	r.memory.lock.Lock()
+	defer r.memory.lock.Unlock()
+	if remote := remoteOrDefault(opts.Remote); remote != DefaultRemote {
+		return fmt.Errorf("push failed: remote %s not found", remote)
+	}
+	branch := opts.Branch
+	if branch == "" {
+		if r.branch == "" {
+			return fmt.Errorf("push failed: head is detached and no branch was given")
+		}
+		branch = r.branch
+	}
+	if r.head == nil {
+		return fmt.Errorf("push %s failed: %w", branch, ErrReferenceNotFound)
+	}
+	remote, err := r.memory.remote(r.url, opts.Auth)
+	if err != nil {
+		return fmt.Errorf("push %s failed: %w", branch, err)
+	}
+	if current := remote.branches[branch]; current != nil && !opts.Force && !isAncestor(current, r.head) {
+		return fmt.Errorf("push %s failed: %w", branch, ErrNonFastForward)
+	}
+	if len(remote.branches) == 0 {
+		remote.defaultBranch = branch
+	}
+	remote.branches[branch] = r.head
+	r.remoteBranches[branch] = r.head
+	return nil
+}
+
+// isAncestor returns true if ancestor is commit or one of its parents
+func isAncestor(ancestor, commit *memoryCommit) bool {
+	for ; commit != nil; commit = commit.parent {
+		if commit == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/AlaudaDevops/pkg/credentials"
+)
+
+func TestMemory(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	url := "https://example.com/org/repo.git"
+	client := NewMemory()
+	first := client.CommitRemote(url, "", "first", map[string][]byte{"README.md": []byte("first")})
+	client.CommitRemote(url, "release", "release", map[string][]byte{"VERSION": []byte("v1")})
+
+	repo, err := client.Clone(ctx, url, "/work", CloneOptions{})
+	g.Expect(err).To(BeNil())
+	head, err := repo.Head(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(head).To(Equal(Reference{Hash: first, Branch: DefaultBranch}))
+	_, err = client.Clone(ctx, url, "/work", CloneOptions{})
+	g.Expect(err).To(MatchError(ContainSubstring("/work already exists")))
+
+	_, err = repo.Commit(ctx, "nothing", CommitOptions{})
+	g.Expect(err).To(MatchError(ErrNothingToCommit))
+	g.Expect(repo.WriteFile("./deploy/app.yaml", []byte("kind: Deployment"))).To(Succeed())
+	g.Expect(repo.RemoveFile("README.md")).To(Succeed())
+	hash, err := repo.Commit(ctx, "add app", CommitOptions{Author: author})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Push(ctx, PushOptions{})).To(Succeed())
+
+	remoteHash, files, ok := client.RemoteBranch(url, DefaultBranch)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(remoteHash).To(Equal(hash))
+	g.Expect(files).To(Equal(map[string][]byte{"deploy/app.yaml": []byte("kind: Deployment")}))
+	g.Expect(client.RemoteMessages(url, DefaultBranch)).To(Equal([]string{"add app", "first"}))
+
+	// remote branches are checked out as local branches, commits as detached heads
+	g.Expect(repo.Checkout(ctx, "release", CheckoutOptions{})).To(Succeed())
+	content, err := repo.ReadFile("VERSION")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal("v1"))
+	g.Expect(repo.Checkout(ctx, first, CheckoutOptions{})).To(Succeed())
+	head, _ = repo.Head(ctx)
+	g.Expect(head).To(Equal(Reference{Hash: first}))
+	_, err = repo.ReadFile("deploy/app.yaml")
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+	g.Expect(repo.Checkout(ctx, "missing", CheckoutOptions{})).To(MatchError(ErrReferenceNotFound))
+	g.Expect(repo.Push(ctx, PushOptions{})).To(MatchError(ContainSubstring("head is detached")))
+
+	// pushing a diverged branch is rejected unless forced
+	client.CommitRemote(url, "", "upstream", map[string][]byte{"upstream": nil})
+	g.Expect(repo.Checkout(ctx, DefaultBranch, CheckoutOptions{})).To(Succeed())
+	g.Expect(repo.WriteFile("local", nil)).To(Succeed())
+	_, err = repo.Commit(ctx, "local", CommitOptions{})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Push(ctx, PushOptions{})).To(MatchError(ErrNonFastForward))
+	g.Expect(repo.Push(ctx, PushOptions{Force: true})).To(Succeed())
+	g.Expect(client.RemoteMessages(url, DefaultBranch)).To(Equal([]string{"local", "add app", "first"}))
+
+	g.Expect(repo.Fetch(ctx, FetchOptions{})).To(Succeed())
+	g.Expect(repo.Checkout(ctx, "origin/release", CheckoutOptions{})).To(Succeed())
+	opened, err := client.Open(ctx, "/work")
+	g.Expect(err).To(BeNil())
+	g.Expect(opened).To(BeIdenticalTo(repo))
+	_, err = client.Open(ctx, "/other")
+	g.Expect(os.IsNotExist(errors.Unwrap(err))).To(BeTrue())
+}
+
+func TestMemory_Authorize(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	url := "https://example.com/org/repo.git"
+	client := NewMemory()
+	client.CommitRemote(url, "", "first", map[string][]byte{"README.md": nil})
+	client.Authorize = func(_ string, auth credentials.Credential) error {
+		if token, ok := auth.(*credentials.BearerToken); ok && token.Token == "secret" {
+			return nil
+		}
+		return errors.New("unauthorized")
+	}
+
+	_, err := client.Clone(ctx, url, "/work", CloneOptions{})
+	g.Expect(err).To(MatchError(ContainSubstring("unauthorized")))
+	auth := &credentials.BearerToken{Token: "secret"}
+	repo, err := client.Clone(ctx, url, "/work", CloneOptions{Auth: auth, Depth: 1})
+	g.Expect(err).To(BeNil())
+	g.Expect(repo.Fetch(ctx, FetchOptions{})).To(MatchError(ContainSubstring("unauthorized")))
+	g.Expect(repo.Fetch(ctx, FetchOptions{Auth: auth})).To(Succeed())
+	_, err = client.Clone(ctx, "https://example.com/missing.git", "/missing", CloneOptions{Auth: auth})
+	g.Expect(err).To(MatchError(ContainSubstring("repository https://example.com/missing.git not found")))
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// specHashLength number of hex characters kept from the sha256 of a spec
+const specHashLength = 16
+
+// SpecHash returns a stable hash of spec based on its json representation,
+// so map key order and pointer addresses do not change the result
+func SpecHash(spec interface{}) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:specHashLength], nil
+}
+
+// SetSpecHashAnnotation stores the hash of spec in the spec hash annotation of obj.
+// Controllers call it with the desired spec before creating or updating a child resource.
+func SetSpecHashAnnotation(obj metav1.Object, spec interface{}) error {
+	hash, err := SpecHash(spec)
+	if err != nil {
+		return err
+	}
+	metav1alpha1.SetSpecHash(obj, hash)
+	return nil
+}
+
+// SpecHashDrifted returns true when the spec hash annotation of obj differs from the hash of spec,
+// meaning the resource was changed outside of its controller.
+// Objects without the annotation are not considered drifted.
+func SpecHashDrifted(obj metav1.Object, spec interface{}) (bool, error) {
+	stored := metav1alpha1.GetSpecHash(obj)
+	if stored == "" {
+		return false, nil
+	}
+	hash, err := SpecHash(spec)
+	if err != nil {
+		return false, err
+	}
+	return stored != hash, nil
+}
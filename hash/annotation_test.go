@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hash
+
+import (
+	"testing"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSpecHash(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	first, err := SpecHash(map[string]string{"a": "1", "b": "2"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(first).To(gomega.HaveLen(specHashLength))
+
+	second, err := SpecHash(map[string]string{"b": "2", "a": "1"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(second).To(gomega.Equal(first))
+
+	third, err := SpecHash(map[string]string{"a": "1"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(third).NotTo(gomega.Equal(first))
+
+	_, err = SpecHash(make(chan int))
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestSpecHashAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cm := &corev1.ConfigMap{Data: map[string]string{"key": "value"}}
+	g.Expect(SpecHashDrifted(cm, cm.Data)).To(gomega.BeFalse(), "objects without annotation are not drifted")
+
+	g.Expect(SetSpecHashAnnotation(cm, cm.Data)).To(gomega.Succeed())
+	g.Expect(metav1alpha1.GetSpecHash(cm)).NotTo(gomega.BeEmpty())
+	g.Expect(SpecHashDrifted(cm, cm.Data)).To(gomega.BeFalse())
+
+	cm.Data["key"] = "edited"
+	g.Expect(SpecHashDrifted(cm, cm.Data)).To(gomega.BeTrue())
+
+	g.Expect(SetSpecHashAnnotation(cm, make(chan int))).NotTo(gomega.Succeed())
+	_, err := SpecHashDrifted(cm, make(chan int))
+	g.Expect(err).NotTo(gomega.BeNil())
+}
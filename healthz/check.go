@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	crhealthz "sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// Probe selects the probe endpoints a check is registered to
+type Probe int
+
+const (
+	// ProbeLiveness registers the check to the liveness (healthz) endpoint
+	ProbeLiveness Probe = 1 << iota
+	// ProbeReadiness registers the check to the readiness (readyz) endpoint
+	ProbeReadiness
+
+	// ProbeNone only reports the check in the detail endpoint
+	ProbeNone Probe = 0
+	// ProbeAll registers the check to both liveness and readiness endpoints
+	ProbeAll = ProbeLiveness | ProbeReadiness
+)
+
+// Liveness returns true if the check is registered to the liveness endpoint
+func (p Probe) Liveness() bool {
+	return p&ProbeLiveness != 0
+}
+
+// Readiness returns true if the check is registered to the readiness endpoint
+func (p Probe) Readiness() bool {
+	return p&ProbeReadiness != 0
+}
+
+// Check is a named checker and the probes it is registered to
+type Check struct {
+	// Name of the check, also used as sub path of the probe endpoints
+	Name string
+	// Checker returns an error when the check fails
+	Checker crhealthz.Checker
+	// Probes the check is registered to. Checks without probes are only
+	// reported by the detail endpoint and never fail the probes
+	Probes Probe
+}
+
+// Ping returns a check always passing, registered to liveness and readiness
+func Ping() Check {
+	return Check{Name: "ping", Checker: crhealthz.Ping, Probes: ProbeAll}
+}
+
+// CacheSync returns a readiness check passing once the informer caches of c
+// are synced, waiting at most timeout for them
+func CacheSync(c cache.Informers, timeout time.Duration) Check {
+	return Check{
+		Name:   "cache-sync",
+		Probes: ProbeReadiness,
+		Checker: func(req *http.Request) error {
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			if !c.WaitForCacheSync(ctx) {
+				return fmt.Errorf("informer caches not synced")
+			}
+			return nil
+		},
+	}
+}
+
+// Dependency returns a readiness check calling ping to verify an external
+// dependency, failing if it returns an error or does not return within timeout
+func Dependency(name string, timeout time.Duration, ping func(ctx context.Context) error) Check {
+	return Check{
+		Name:   name,
+		Probes: ProbeReadiness,
+		Checker: func(req *http.Request) error {
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- ping(ctx)
+			}()
+			select {
+			case err := <-errCh:
+				return err
+			case <-ctx.Done():
+				return fmt.Errorf("%s did not respond within %s: %w", name, timeout, ctx.Err())
+			}
+		},
+	}
+}
+
+// Leader returns a check reporting whether this instance is the elected leader,
+// i.e. elected is closed. It is only reported by the detail endpoint because
+// standby replicas are healthy and ready as well
+func Leader(elected <-chan struct{}) Check {
+	return Check{
+		Name:   "leader",
+		Probes: ProbeNone,
+		Checker: func(_ *http.Request) error {
+			select {
+			case <-elected:
+				return nil
+			default:
+				return fmt.Errorf("not the elected leader")
+			}
+		},
+	}
+}
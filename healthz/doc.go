@@ -0,0 +1,29 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthz registers named health checkers to a controller-runtime
+// manager in one call: informer cache sync, external dependency pings with
+// a timeout and leader status. Besides the liveness and readiness probes, an
+// aggregated detail endpoint served by the manager metrics server returns
+// the status of every check as JSON.
+//
+//	_, err := healthz.Register(mgr,
+//		healthz.Ping(),
+//		healthz.CacheSync(mgr.GetCache(), 5*time.Second),
+//		healthz.Dependency("gitlab", 3*time.Second, pingGitlab),
+//		healthz.Leader(mgr.Elected()),
+//	)
+package healthz
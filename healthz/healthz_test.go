@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	crhealthz "sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+type fakeManager struct {
+	healthz  map[string]crhealthz.Checker
+	readyz   map[string]crhealthz.Checker
+	handlers map[string]http.Handler
+}
+
+func newFakeManager() *fakeManager {
+	return &fakeManager{
+		healthz:  map[string]crhealthz.Checker{},
+		readyz:   map[string]crhealthz.Checker{},
+		handlers: map[string]http.Handler{},
+	}
+}
+
+func (m *fakeManager) AddHealthzCheck(name string, check crhealthz.Checker) error {
+	m.healthz[name] = check
+	return nil
+}
+
+func (m *fakeManager) AddReadyzCheck(name string, check crhealthz.Checker) error {
+	m.readyz[name] = check
+	return nil
+}
+
+func (m *fakeManager) AddMetricsServerExtraHandler(path string, handler http.Handler) error {
+	m.handlers[path] = handler
+	return nil
+}
+
+func TestChecks(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	elected := make(chan struct{})
+
+	var data = []struct {
+		desc    string
+		check   Check
+		wantErr bool
+	}{
+		{"ping", Ping(), false},
+		{"cache synced", CacheSync(&informertest.FakeInformers{Synced: ptr.To(true)}, time.Second), false},
+		{"cache not synced", CacheSync(&informertest.FakeInformers{Synced: ptr.To(false)}, time.Second), true},
+		{"dependency ok", Dependency("db", time.Second, func(context.Context) error { return nil }), false},
+		{"dependency error", Dependency("db", time.Second, func(context.Context) error { return errors.New("down") }), true},
+		{"dependency timeout", Dependency("db", 10*time.Millisecond, func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}), true},
+		{"not leader", Leader(elected), true},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			err := item.check.Checker(req)
+			if item.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+
+	t.Run("leader", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		close(elected)
+		g.Expect(Leader(elected).Checker(req)).To(Succeed())
+	})
+}
+
+func TestRegister(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mgr := newFakeManager()
+	dependencyErr := errors.New("connection refused")
+
+	registry, err := Register(mgr,
+		Ping(),
+		Dependency("gitlab", time.Second, func(context.Context) error { return dependencyErr }),
+		Leader(make(chan struct{})),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mgr.healthz).To(HaveLen(1))
+	g.Expect(mgr.healthz).To(HaveKey("ping"))
+	g.Expect(mgr.readyz).To(HaveLen(2))
+	g.Expect(mgr.readyz).To(HaveKey("ping"))
+	g.Expect(mgr.readyz).To(HaveKey("gitlab"))
+	g.Expect(mgr.handlers).To(HaveKeyWithValue(DetailPath, registry))
+
+	resp := httptest.NewRecorder()
+	registry.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, DetailPath, nil))
+	g.Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+	g.Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+
+	detail := Detail{}
+	g.Expect(json.Unmarshal(resp.Body.Bytes(), &detail)).To(Succeed())
+	g.Expect(detail.Status).To(Equal(StatusFailed))
+	g.Expect(detail.Checks).To(HaveLen(3))
+	g.Expect(detail.Checks[0].Name).To(Equal("ping"))
+	g.Expect(detail.Checks[0].Status).To(Equal(StatusOK))
+	g.Expect(detail.Checks[1].Name).To(Equal("gitlab"))
+	g.Expect(detail.Checks[1].Status).To(Equal(StatusFailed))
+	g.Expect(detail.Checks[1].Error).To(Equal("connection refused"))
+	g.Expect(detail.Checks[1].Readiness).To(BeTrue())
+	g.Expect(detail.Checks[1].Liveness).To(BeFalse())
+	g.Expect(detail.Checks[2].Name).To(Equal("leader"))
+	g.Expect(detail.Checks[2].Status).To(Equal(StatusFailed))
+}
+
+func TestRegistry_InformationalChecksDoNotFail(t *testing.T) {
+	g := NewGomegaWithT(t)
+	registry := NewRegistry(Ping(), Leader(make(chan struct{})))
+	registry.Add(Check{Name: "ping", Checker: crhealthz.Ping, Probes: ProbeLiveness})
+	g.Expect(registry.Checks()).To(HaveLen(2))
+	g.Expect(registry.Checks()[0].Probes).To(Equal(ProbeLiveness))
+
+	resp := httptest.NewRecorder()
+	registry.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, DetailPath, nil))
+	g.Expect(resp.Code).To(Equal(http.StatusOK))
+
+	detail := Detail{}
+	g.Expect(json.Unmarshal(resp.Body.Bytes(), &detail)).To(Succeed())
+	g.Expect(detail.Status).To(Equal(StatusOK))
+	g.Expect(detail.Checks[1].Status).To(Equal(StatusFailed))
+}
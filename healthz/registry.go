@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	crhealthz "sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// DetailPath is the path of the detail endpoint in the manager metrics server
+const DetailPath = "/healthz/detail"
+
+const (
+	// StatusOK status of a passing check or of the aggregate when all probe checks pass
+	StatusOK = "ok"
+	// StatusFailed status of a failing check or of the aggregate when a probe check fails
+	StatusFailed = "failed"
+)
+
+// Manager is the subset of the controller-runtime manager used to register checks
+type Manager interface {
+	AddHealthzCheck(name string, check crhealthz.Checker) error
+	AddReadyzCheck(name string, check crhealthz.Checker) error
+	AddMetricsServerExtraHandler(path string, handler http.Handler) error
+}
+
+// Register registers each check to the probe endpoints of the manager
+// selected by its Probes and serves the detail endpoint of all checks
+// at DetailPath in the manager metrics server
+func Register(mgr Manager, checks ...Check) (*Registry, error) {
+	registry := NewRegistry(checks...)
+	for _, check := range registry.Checks() {
+		if check.Probes.Liveness() {
+			if err := mgr.AddHealthzCheck(check.Name, check.Checker); err != nil {
+				return nil, fmt.Errorf("adding liveness check %q: %w", check.Name, err)
+			}
+		}
+		if check.Probes.Readiness() {
+			if err := mgr.AddReadyzCheck(check.Name, check.Checker); err != nil {
+				return nil, fmt.Errorf("adding readiness check %q: %w", check.Name, err)
+			}
+		}
+	}
+	if err := mgr.AddMetricsServerExtraHandler(DetailPath, registry); err != nil {
+		return nil, fmt.Errorf("adding health detail handler: %w", err)
+	}
+	return registry, nil
+}
+
+// Registry holds named checks and serves their aggregated status as JSON
+type Registry struct {
+	lock   sync.RWMutex
+	checks []Check
+}
+
+var _ http.Handler = &Registry{}
+
+// NewRegistry returns a registry with the given checks
+func NewRegistry(checks ...Check) *Registry {
+	r := &Registry{}
+	r.Add(checks...)
+	return r
+}
+
+// Add adds checks to the registry, replacing checks with the same name
+func (r *Registry) Add(checks ...Check) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, check := range checks {
+		replaced := false
+		for i := range r.checks {
+			if r.checks[i].Name == check.Name {
+				r.checks[i] = check
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			r.checks = append(r.checks, check)
+		}
+	}
+}
+
+// Checks returns a copy of the checks in the registry in the order they were added
+func (r *Registry) Checks() []Check {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return append([]Check(nil), r.checks...)
+}
+
+// CheckStatus is the result of a single check
+type CheckStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	Liveness  bool   `json:"liveness"`
+	Readiness bool   `json:"readiness"`
+	Duration  string `json:"duration"`
+}
+
+// Detail is the aggregated result of all checks in the registry
+type Detail struct {
+	// Status is StatusFailed if any check registered to a probe fails
+	Status string        `json:"status"`
+	Checks []CheckStatus `json:"checks"`
+}
+
+// Run runs all checks concurrently and returns their aggregated result
+func (r *Registry) Run(req *http.Request) Detail {
+	checks := r.Checks()
+	detail := Detail{Status: StatusOK, Checks: make([]CheckStatus, len(checks))}
+
+	wg := sync.WaitGroup{}
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			detail.Checks[i] = runCheck(req, checks[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, status := range detail.Checks {
+		if status.Status != StatusOK && checks[i].Probes != ProbeNone {
+			detail.Status = StatusFailed
+		}
+	}
+	return detail
+}
+
+func runCheck(req *http.Request, check Check) CheckStatus {
+	status := CheckStatus{
+		Name:      check.Name,
+		Status:    StatusOK,
+		Liveness:  check.Probes.Liveness(),
+		Readiness: check.Probes.Readiness(),
+	}
+	start := time.Now()
+	if err := check.Checker(req); err != nil {
+		status.Status = StatusFailed
+		status.Error = err.Error()
+	}
+	status.Duration = time.Since(start).String()
+	return status
+}
+
+// ServeHTTP writes the aggregated result of all checks as JSON,
+// with status code 503 if any check registered to a probe fails
+func (r *Registry) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	detail := r.Run(req)
+	code := http.StatusOK
+	if detail.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	_ = json.NewEncoder(resp).Encode(detail)
+}
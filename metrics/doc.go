@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics declares the standard Prometheus metrics of our operators:
+// reconcile duration by result, external API call latency by host and status,
+// queue depth, reconcile concurrency, reconcile timeouts, admission request
+// latency and events dropped by watch sources.
+// Metrics are registered in the controller-runtime registry served by the
+// manager metrics endpoint.
+//
+//	r = metrics.InstrumentReconciler("repository", r)
+//
+//	clt, err := http.NewClient(http.WithMetrics(true))
+//
+//	start := time.Now()
+//	err := sync(ctx)
+//	metrics.ObserveReconcile(ctx, err, start)
+package metrics
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Namespace prefixes the name of all metrics declared in this package
+const Namespace = "alauda"
+
+const (
+	// ResultSuccess result label of reconciles returning no error
+	ResultSuccess = "success"
+	// ResultError result label of reconciles returning an error
+	ResultError = "error"
+)
+
+var (
+	// ReconcileDuration observes the duration of reconciles by controller and result
+	ReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "reconcile",
+		Name:      "duration_seconds",
+		Help:      "Duration of reconciles by controller and result",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{"controller", "result"})
+
+	// ExternalRequestDuration observes the latency of requests to external APIs by host, method and status code
+	ExternalRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "external_request",
+		Name:      "duration_seconds",
+		Help:      "Latency of requests to external APIs by host, method and status code",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"host", "method", "code"})
+
+	// QueueDepth reports the number of items waiting in a named queue
+	QueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "queue",
+		Name:      "depth",
+		Help:      "Number of items waiting in a queue",
+	}, []string{"queue"})
+
+	// ReconcileConcurrency reports the number of concurrent reconciles allowed by controller
+	ReconcileConcurrency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "reconcile",
+		Name:      "concurrency",
+		Help:      "Number of concurrent reconciles allowed by controller",
+	}, []string{"controller"})
+
+	// SourceDropped counts the events dropped by a named watch source because its buffer was full
+	SourceDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "source",
+		Name:      "dropped_total",
+		Help:      "Number of events dropped by a watch source because its buffer was full",
+	}, []string{"source"})
+
+	// ReconcileTimeouts counts the reconciles stopped because they exceeded their deadline by controller
+	ReconcileTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "reconcile",
+		Name:      "timeouts_total",
+		Help:      "Number of reconciles that exceeded their deadline by controller",
+	}, []string{"controller"})
+
+	// WebhookRequestDuration observes the latency of admission requests by kind, operation and result
+	WebhookRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "webhook_request",
+		Name:      "duration_seconds",
+		Help:      "Latency of admission requests by kind, operation and result",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"kind", "operation", "result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(Collectors()...)
+}
+
+// Collectors returns all metrics declared in this package,
+// used to register them into registries other than the controller-runtime one
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{ReconcileDuration, ExternalRequestDuration, QueueDepth, ReconcileConcurrency, SourceDropped, ReconcileTimeouts, WebhookRequestDuration}
+}
+
+// SetQueueDepth sets the depth of the queue named name
+func SetQueueDepth(name string, depth int) {
+	QueueDepth.WithLabelValues(name).Set(float64(depth))
+}
+
+// SetReconcileConcurrency sets the number of concurrent reconciles allowed for controller
+func SetReconcileConcurrency(controller string, concurrency int) {
+	ReconcileConcurrency.WithLabelValues(controller).Set(float64(concurrency))
+}
+
+// AddSourceDropped adds count to the events dropped by the source named name
+func AddSourceDropped(name string, count int) {
+	SourceDropped.WithLabelValues(name).Add(float64(count))
+}
+
+// IncReconcileTimeouts counts a reconcile of controller that exceeded its deadline
+func IncReconcileTimeouts(controller string) {
+	ReconcileTimeouts.WithLabelValues(controller).Inc()
+}
+
+// ObserveWebhookRequest observes the latency of an admission request for kind and operation,
+// result being allowed, denied or error
+func ObserveWebhookRequest(kind, operation, result string, duration time.Duration) {
+	WebhookRequestDuration.WithLabelValues(kind, operation, result).Observe(duration.Seconds())
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRegistered(t *testing.T) {
+	g := NewGomegaWithT(t)
+	for _, c := range Collectors() {
+		err := ctrlmetrics.Registry.Register(c)
+		g.Expect(err).To(BeAssignableToTypeOf(prometheus.AlreadyRegisteredError{}))
+	}
+}
+
+func TestObserveReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ReconcileDuration.Reset()
+
+	ctx := WithController(context.Background(), "repository")
+	ObserveReconcile(ctx, nil, time.Now())
+	ObserveReconcile(ctx, errors.New("failed"), time.Now())
+	ObserveReconcile(context.Background(), nil, time.Now())
+
+	g.Expect(ControllerFromContext(ctx)).To(Equal("repository"))
+	g.Expect(testutil.CollectAndCount(ReconcileDuration)).To(Equal(3))
+	g.Expect(histogramCount(ReconcileDuration, "repository", ResultSuccess)).To(Equal(uint64(1)))
+	g.Expect(histogramCount(ReconcileDuration, "repository", ResultError)).To(Equal(uint64(1)))
+	g.Expect(histogramCount(ReconcileDuration, UnknownController, ResultSuccess)).To(Equal(uint64(1)))
+}
+
+func TestInstrumentReconciler(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ReconcileDuration.Reset()
+
+	var controller string
+	r := InstrumentReconciler("webhook", reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		controller = ControllerFromContext(ctx)
+		return reconcile.Result{}, errors.New("failed")
+	}))
+	_, err := r.Reconcile(context.Background(), reconcile.Request{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(controller).To(Equal("webhook"))
+	g.Expect(histogramCount(ReconcileDuration, "webhook", ResultError)).To(Equal(uint64(1)))
+}
+
+func TestInstrumentTransport(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ExternalRequestDuration.Reset()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	host := mustParse(server.URL).Host
+
+	clt := &http.Client{Transport: InstrumentTransport(nil)}
+	resp, err := clt.Get(server.URL)
+	g.Expect(err).To(Succeed())
+	resp.Body.Close()
+
+	server.Close()
+	_, err = clt.Post(server.URL, "text/plain", nil)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(histogramCount(ExternalRequestDuration, host, http.MethodGet, "404")).To(Equal(uint64(1)))
+	g.Expect(histogramCount(ExternalRequestDuration, host, http.MethodPost, CodeNetworkError)).To(Equal(uint64(1)))
+}
+
+func TestSetQueueDepth(t *testing.T) {
+	g := NewGomegaWithT(t)
+	SetQueueDepth("events", 3)
+	g.Expect(testutil.ToFloat64(QueueDepth.WithLabelValues("events"))).To(Equal(float64(3)))
+}
+
+func TestSetReconcileConcurrency(t *testing.T) {
+	g := NewGomegaWithT(t)
+	SetReconcileConcurrency("repository", 4)
+	g.Expect(testutil.ToFloat64(ReconcileConcurrency.WithLabelValues("repository"))).To(Equal(float64(4)))
+}
+
+func histogramCount(vec *prometheus.HistogramVec, labels ...string) uint64 {
+	metric := &dto.Metric{}
+	_ = vec.WithLabelValues(labels...).(prometheus.Histogram).Write(metric)
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func mustParse(raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// UnknownController controller label used when no controller name is found in the context
+const UnknownController = "unknown"
+
+// controllerName is the name of the controller stored in context
+type controllerName string
+
+// WithController stores the controller name used as label by ObserveReconcile into the context
+func WithController(ctx context.Context, name string) context.Context {
+	return ctxutil.With(ctx, controllerName(name))
+}
+
+// ControllerFromContext returns the controller name stored in the context,
+// or UnknownController if none
+func ControllerFromContext(ctx context.Context) string {
+	return string(ctxutil.FromOrDefault(ctx, controllerName(UnknownController)))
+}
+
+// ObserveReconcile observes the duration of a reconcile started at start
+// for the controller in the context, labeled by the result according to err
+func ObserveReconcile(ctx context.Context, err error, start time.Time) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultError
+	}
+	ReconcileDuration.WithLabelValues(ControllerFromContext(ctx), result).Observe(time.Since(start).Seconds())
+}
+
+// InstrumentReconciler returns a reconciler observing the duration of each reconcile of r
+// under the controller name, which is also stored into the context passed to r
+func InstrumentReconciler(name string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
+		ctx = WithController(ctx, name)
+		start := time.Now()
+		defer func() {
+			ObserveReconcile(ctx, err, start)
+		}()
+		return r.Reconcile(ctx, request)
+	})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CodeNetworkError code label of requests failing without a response
+const CodeNetworkError = "error"
+
+// Transport is a http.RoundTripper observing the latency of requests in ExternalRequestDuration
+type Transport struct {
+	base http.RoundTripper
+}
+
+// InstrumentTransport wraps rt observing the latency of its requests by host, method and status code.
+// A nil rt uses http.DefaultTransport
+func InstrumentTransport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Transport{base: rt}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	code := CodeNetworkError
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	ExternalRequestDuration.WithLabelValues(req.URL.Host, req.Method, code).Observe(time.Since(start).Seconds())
+	return resp, err
+}
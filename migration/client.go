@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MigrateObject renames old keys of the object and persists the change
+// using a merge patch. Returns true if the object was changed.
+// When dryRun is true the object is changed in memory and the patch is
+// sent as a server side dry run.
+func (m *Migrator) MigrateObject(ctx context.Context, clt client.Client, obj client.Object, dryRun bool) (bool, error) {
+	base := obj.DeepCopyObject().(client.Object)
+	if !m.Migrate(obj) {
+		return false, nil
+	}
+
+	opts := []client.PatchOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := clt.Patch(ctx, obj, client.MergeFrom(base), opts...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
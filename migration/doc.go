@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration renames annotation and label keys, e.g. when moving
+// keys off the cpaas.io/ domain. It provides dual-read helpers that accept
+// both the old and the new keys, a Migrator that rewrites old keys in
+// objects and helpers to persist the migration using a client.
+//
+//	migrator := migration.NewMigrator(
+//		[]migration.Rule{migration.DomainRule("cpaas.io", "alauda.io")},
+//		nil,
+//	)
+//	displayName, _ := migrator.Annotation(obj, "alauda.io/displayName")
+package migration
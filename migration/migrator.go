@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Migrator renames annotation and label keys according to its rules
+type Migrator struct {
+	// AnnotationRules applied to annotation keys, first match wins
+	AnnotationRules []Rule
+	// LabelRules applied to label keys, first match wins
+	LabelRules []Rule
+}
+
+// NewMigrator returns a Migrator for annotation and label rules
+func NewMigrator(annotationRules, labelRules []Rule) *Migrator {
+	return &Migrator{AnnotationRules: annotationRules, LabelRules: labelRules}
+}
+
+// Annotation returns the value of the annotation using the new key
+// falling back to any old key that is renamed to it
+func (m *Migrator) Annotation(obj metav1.Object, key string) (string, bool) {
+	return DualRead(obj.GetAnnotations(), key, m.AnnotationRules...)
+}
+
+// Label returns the value of the label using the new key
+// falling back to any old key that is renamed to it
+func (m *Migrator) Label(obj metav1.Object, key string) (string, bool) {
+	return DualRead(obj.GetLabels(), key, m.LabelRules...)
+}
+
+// NeedsMigration returns true if the object has any old annotation or label key
+func (m *Migrator) NeedsMigration(obj metav1.Object) bool {
+	return len(OldKeys(obj.GetAnnotations(), m.AnnotationRules...)) > 0 ||
+		len(OldKeys(obj.GetLabels(), m.LabelRules...)) > 0
+}
+
+// Migrate renames old annotation and label keys of the object.
+// Returns true if the object was changed
+func (m *Migrator) Migrate(obj metav1.Object) (changed bool) {
+	if annotations, ok := RenameKeys(obj.GetAnnotations(), m.AnnotationRules...); ok {
+		obj.SetAnnotations(annotations)
+		changed = true
+	}
+	if labels, ok := RenameKeys(obj.GetLabels(), m.LabelRules...); ok {
+		obj.SetLabels(labels)
+		changed = true
+	}
+	return
+}
+
+// DualRead returns the value for the key, or for any old key
+// renamed to key by the rules when the key is not present
+func DualRead(kv map[string]string, key string, rules ...Rule) (string, bool) {
+	if value, ok := kv[key]; ok {
+		return value, true
+	}
+	for _, rule := range rules {
+		if oldKey, ok := rule.Reverse(key); ok {
+			if value, ok := kv[oldKey]; ok {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// OldKeys returns the keys matching any of the rules
+func OldKeys(kv map[string]string, rules ...Rule) (keys []string) {
+	for key := range kv {
+		for _, rule := range rules {
+			if _, ok := rule.Rename(key); ok {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	return
+}
+
+// RenameKeys returns a copy of kv with old keys renamed and true if any key was renamed.
+// When both the old and the new keys exist the value of the new key is kept.
+func RenameKeys(kv map[string]string, rules ...Rule) (map[string]string, bool) {
+	oldKeys := OldKeys(kv, rules...)
+	if len(oldKeys) == 0 {
+		return kv, false
+	}
+
+	result := make(map[string]string, len(kv))
+	for key, value := range kv {
+		result[key] = value
+	}
+	for _, oldKey := range oldKeys {
+		for _, rule := range rules {
+			newKey, ok := rule.Rename(oldKey)
+			if !ok {
+				continue
+			}
+			if _, exists := kv[newKey]; !exists {
+				result[newKey] = kv[oldKey]
+			}
+			delete(result, oldKey)
+			break
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRule(t *testing.T) {
+	var data = []struct {
+		desc string
+		rule Rule
+		key  string
+
+		renamed  string
+		matched  bool
+		reversed bool
+	}{
+		{desc: "key rule", rule: KeyRule("cpaas.io/displayName", "alauda.io/display-name"), key: "cpaas.io/displayName", renamed: "alauda.io/display-name", matched: true},
+		{desc: "key rule not matching", rule: KeyRule("cpaas.io/displayName", "alauda.io/display-name"), key: "cpaas.io/other"},
+		{desc: "domain rule", rule: DomainRule("cpaas.io", "alauda.io"), key: "cpaas.io/displayName", renamed: "alauda.io/displayName", matched: true},
+		{desc: "domain rule subdomain", rule: DomainRule("cpaas.io/", "alauda.io/"), key: "ui.cpaas.io/descriptors", renamed: "ui.alauda.io/descriptors", matched: true},
+		{desc: "domain rule similar domain", rule: DomainRule("cpaas.io", "alauda.io"), key: "xcpaas.io/displayName"},
+		{desc: "domain rule without domain", rule: DomainRule("cpaas.io", "alauda.io"), key: "displayName"},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			renamed, matched := item.rule.Rename(item.key)
+			g.Expect(matched).To(Equal(item.matched))
+			g.Expect(renamed).To(Equal(item.renamed))
+			if matched {
+				reversed, ok := item.rule.Reverse(renamed)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(reversed).To(Equal(item.key))
+			}
+		})
+	}
+}
+
+func newMigrator() *Migrator {
+	return NewMigrator(
+		[]Rule{DomainRule("cpaas.io", "alauda.io")},
+		[]Rule{KeyRule("cpaas.io/project", "alauda.io/project")},
+	)
+}
+
+func TestMigrator(t *testing.T) {
+	g := NewGomegaWithT(t)
+	m := newMigrator()
+
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			"cpaas.io/displayName":   "old",
+			"cpaas.io/creationTime":  "old-time",
+			"alauda.io/creationTime": "new-time",
+			"other":                  "value",
+		},
+		Labels: map[string]string{"cpaas.io/project": "demo"},
+	}}
+
+	value, ok := m.Annotation(obj, "alauda.io/displayName")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(value).To(Equal("old"))
+	value, _ = m.Annotation(obj, "alauda.io/creationTime")
+	g.Expect(value).To(Equal("new-time"))
+	value, _ = m.Label(obj, "alauda.io/project")
+	g.Expect(value).To(Equal("demo"))
+	_, ok = m.Label(obj, "alauda.io/missing")
+	g.Expect(ok).To(BeFalse())
+
+	g.Expect(m.NeedsMigration(obj)).To(BeTrue())
+	g.Expect(m.Migrate(obj)).To(BeTrue())
+	g.Expect(obj.Annotations).To(Equal(map[string]string{
+		"alauda.io/displayName":  "old",
+		"alauda.io/creationTime": "new-time",
+		"other":                  "value",
+	}))
+	g.Expect(obj.Labels).To(Equal(map[string]string{"alauda.io/project": "demo"}))
+	g.Expect(m.NeedsMigration(obj)).To(BeFalse())
+	g.Expect(m.Migrate(obj)).To(BeFalse())
+}
+
+func TestReconciler(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "cm",
+		Annotations: map[string]string{"cpaas.io/displayName": "name"},
+	}}
+	clt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(obj).Build()
+	r := &Reconciler{Client: clt, Migrator: newMigrator(), NewObject: func() client.Object { return &corev1.ConfigMap{} }}
+
+	g.Expect(r.Migrator.Predicate().Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+	g.Expect(err).To(BeNil())
+
+	updated := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, client.ObjectKeyFromObject(obj), updated)).To(Succeed())
+	g.Expect(updated.Annotations).To(Equal(map[string]string{"alauda.io/displayName": "name"}))
+	g.Expect(r.Migrator.Predicate().Generic(event.GenericEvent{Object: updated})).To(BeFalse())
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "missing"}})
+	g.Expect(err).To(BeNil())
+}
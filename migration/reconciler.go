@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Predicate returns a predicate that only accepts objects that need migration
+func (m *Migrator) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return m.NeedsMigration(obj)
+	})
+}
+
+// Reconciler rewrites old keys of objects when they are touched.
+// Should be used together with the Migrator Predicate to avoid
+// reconciling objects that do not need migration.
+type Reconciler struct {
+	Client   client.Client
+	Migrator *Migrator
+	// NewObject returns an empty object of the reconciled type
+	NewObject func() client.Object
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// Reconcile migrates the keys of the requested object
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	obj := r.NewObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	changed, err := r.Migrator.MigrateObject(ctx, r.Client, obj, false)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if changed {
+		logging.FromContext(ctx).Debugw("migrated annotation and label keys", "object", req.NamespacedName)
+	}
+	return reconcile.Result{}, nil
+}
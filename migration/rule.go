@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"strings"
+)
+
+// Rule renames a key. When Old and New end with a "/" the rule renames the
+// domain of keys, including its subdomains, e.g. the rule "cpaas.io/" to
+// "alauda.io/" renames "ui.cpaas.io/descriptors" to "ui.alauda.io/descriptors"
+type Rule struct {
+	// Old is the deprecated key or domain
+	Old string
+	// New is the replacement key or domain
+	New string
+}
+
+// KeyRule returns a rule renaming a single key
+func KeyRule(oldKey, newKey string) Rule {
+	return Rule{Old: oldKey, New: newKey}
+}
+
+// DomainRule returns a rule renaming the domain of keys
+func DomainRule(oldDomain, newDomain string) Rule {
+	return Rule{Old: strings.TrimSuffix(oldDomain, "/") + "/", New: strings.TrimSuffix(newDomain, "/") + "/"}
+}
+
+// IsDomain returns true if the rule renames a domain
+func (r Rule) IsDomain() bool {
+	return strings.HasSuffix(r.Old, "/")
+}
+
+// Rename returns the new key for a key matching the old key or domain
+func (r Rule) Rename(key string) (string, bool) {
+	return replace(key, r.Old, r.New, r.IsDomain())
+}
+
+// Reverse returns the old key for a key matching the new key or domain
+func (r Rule) Reverse(key string) (string, bool) {
+	return replace(key, r.New, r.Old, r.IsDomain())
+}
+
+func replace(key, from, to string, domain bool) (string, bool) {
+	if !domain {
+		if key == from {
+			return to, true
+		}
+		return "", false
+	}
+
+	index := strings.Index(key, "/")
+	if index < 0 {
+		return "", false
+	}
+	prefix, name := key[:index+1], key[index+1:]
+	switch {
+	case prefix == from:
+		return to + name, true
+	case strings.HasSuffix(prefix, "."+from):
+		return strings.TrimSuffix(prefix, from) + to + name, true
+	}
+	return "", false
+}
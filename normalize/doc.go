@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package normalize removes from objects the fields populated by the api server,
+// like status, managed fields or resource version, and the defaults it sets, like
+// container image pull policies, port protocols or default tolerations, so that
+// comparing the live and desired objects only reports actual changes instead of
+// triggering perpetual updates. Normalizations are registered per kind in a Registry.
+//
+//	equal, err := normalize.Equivalent(live, desired)
+//	if !equal {
+//		err = clt.Update(ctx, desired)
+//	}
+//
+//	normalize.Register(schema.GroupKind{Group: "example.io", Kind: "Foo"},
+//		normalize.RemoveFields([]string{"spec", "generatedName"}))
+//
+// Sanitize removes the fields specific to the cluster objects were read from but keeps
+// their status, e.g. to check in recorded objects as fixtures or to export them.
+package normalize
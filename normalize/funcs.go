@@ -0,0 +1,285 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normalize
+
+import (
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ServerMetadataFields are the metadata fields set by the api server or by kubectl
+var ServerMetadataFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"metadata", "annotations", corev1.LastAppliedConfigAnnotation},
+}
+
+// ServerFields are set by the api server and do not describe the desired state of objects,
+// the ServerMetadataFields and the status
+var ServerFields = append(append([][]string{}, ServerMetadataFields...), []string{"status"})
+
+// RemoveFields returns a Func removing the fields at the paths
+func RemoveFields(paths ...[]string) Func {
+	return func(content map[string]interface{}) {
+		for _, path := range paths {
+			unstructured.RemoveNestedField(content, path...)
+		}
+	}
+}
+
+// RemoveServerFields removes the ServerFields and empty annotations and labels
+func RemoveServerFields(content map[string]interface{}) {
+	RemoveFields(ServerFields...)(content)
+	metadata := nestedMap(content, "metadata")
+	removeEmpty(metadata, "annotations")
+	removeEmpty(metadata, "labels")
+}
+
+// defaultTolerations are added to pods by the DefaultTolerationSeconds admission plugin
+var defaultTolerations = []map[string]interface{}{
+	{"key": "node.kubernetes.io/not-ready", "operator": "Exists", "effect": "NoExecute", "tolerationSeconds": int64(300)},
+	{"key": "node.kubernetes.io/unreachable", "operator": "Exists", "effect": "NoExecute", "tolerationSeconds": int64(300)},
+}
+
+// PodSpecDefaults returns a Func removing the defaults of the pod spec at path:
+// dns policy, restart policy, scheduler name, termination grace period, service links,
+// default tolerations and the image pull policy, termination message, port protocol
+// and probe defaults of containers
+func PodSpecDefaults(path ...string) Func {
+	return func(content map[string]interface{}) {
+		spec := nestedMap(content, path...)
+		if spec == nil {
+			return
+		}
+		removeIfEqual(spec, "dnsPolicy", "ClusterFirst")
+		removeIfEqual(spec, "restartPolicy", "Always")
+		removeIfEqual(spec, "schedulerName", "default-scheduler")
+		removeIfEqual(spec, "terminationGracePeriodSeconds", int64(30))
+		removeIfEqual(spec, "enableServiceLinks", true)
+		removeEmpty(spec, "securityContext")
+		removeItems(spec, "tolerations", func(item map[string]interface{}) bool {
+			for _, toleration := range defaultTolerations {
+				if equal(item, toleration) {
+					return true
+				}
+			}
+			return false
+		})
+		for _, key := range []string{"initContainers", "containers"} {
+			for _, container := range items(spec, key) {
+				containerDefaults(container)
+			}
+		}
+	}
+}
+
+func containerDefaults(container map[string]interface{}) {
+	if image, ok := container["image"].(string); ok {
+		removeIfEqual(container, "imagePullPolicy", defaultPullPolicy(image))
+	}
+	removeIfEqual(container, "terminationMessagePath", "/dev/termination-log")
+	removeIfEqual(container, "terminationMessagePolicy", "File")
+	removeEmpty(container, "resources")
+	for _, port := range items(container, "ports") {
+		removeIfEqual(port, "protocol", "TCP")
+	}
+	for _, key := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+		if probe, ok := container[key].(map[string]interface{}); ok {
+			removeIfEqual(probe, "timeoutSeconds", int64(1))
+			removeIfEqual(probe, "periodSeconds", int64(10))
+			removeIfEqual(probe, "successThreshold", int64(1))
+			removeIfEqual(probe, "failureThreshold", int64(3))
+		}
+	}
+}
+
+// defaultPullPolicy returns the pull policy defaulted by the server for the image
+func defaultPullPolicy(image string) string {
+	if strings.Contains(image, "@") {
+		return "IfNotPresent"
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if tag := strings.LastIndex(name, ":"); tag < 0 || name[tag+1:] == "latest" {
+		return "Always"
+	}
+	return "IfNotPresent"
+}
+
+// ServiceDefaults removes the defaults and allocated fields of a service spec:
+// type, session affinity, cluster ips, ip families, traffic policy,
+// port protocols and target ports equal to the port
+func ServiceDefaults(content map[string]interface{}) {
+	spec := nestedMap(content, "spec")
+	if spec == nil {
+		return
+	}
+	removeIfEqual(spec, "type", "ClusterIP")
+	removeIfEqual(spec, "sessionAffinity", "None")
+	removeIfEqual(spec, "ipFamilyPolicy", "SingleStack")
+	removeIfEqual(spec, "internalTrafficPolicy", "Cluster")
+	for _, key := range []string{"clusterIP", "clusterIPs", "ipFamilies"} {
+		delete(spec, key)
+	}
+	for _, port := range items(spec, "ports") {
+		removeIfEqual(port, "protocol", "TCP")
+		// a zero target port is not set in typed objects
+		removeIfEqual(port, "targetPort", int64(0))
+		removeIfEqual(port, "targetPort", port["port"])
+	}
+}
+
+// DeploymentDefaults removes the defaults of a deployment spec:
+// revision history limit, progress deadline and rolling update strategy
+func DeploymentDefaults(content map[string]interface{}) {
+	spec := nestedMap(content, "spec")
+	if spec == nil {
+		return
+	}
+	removeIfEqual(spec, "revisionHistoryLimit", int64(10))
+	removeIfEqual(spec, "progressDeadlineSeconds", int64(600))
+	removeIfEqual(spec, "strategy", map[string]interface{}{
+		"type":          "RollingUpdate",
+		"rollingUpdate": map[string]interface{}{"maxSurge": "25%", "maxUnavailable": "25%"},
+	})
+}
+
+// nestedMap returns the map at path in content without copying it, or nil
+func nestedMap(content map[string]interface{}, path ...string) map[string]interface{} {
+	current := content
+	for _, key := range path {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// items returns the maps of the list at key without copying them
+func items(m map[string]interface{}, key string) []map[string]interface{} {
+	list, _ := m[key].([]interface{})
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if itemMap, ok := item.(map[string]interface{}); ok {
+			result = append(result, itemMap)
+		}
+	}
+	return result
+}
+
+// removeItems removes the items of the list at key matching remove, and the list once empty
+func removeItems(m map[string]interface{}, key string, remove func(map[string]interface{}) bool) {
+	list, ok := m[key].([]interface{})
+	if !ok {
+		return
+	}
+	kept := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if itemMap, ok := item.(map[string]interface{}); ok && remove(itemMap) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	m[key] = kept
+	removeEmpty(m, key)
+}
+
+func removeIfEqual(m map[string]interface{}, key string, value interface{}) {
+	if current, ok := m[key]; ok && equal(current, value) {
+		delete(m, key)
+	}
+}
+
+// removeEmpty removes the map or list at key if it is empty or null
+func removeEmpty(m map[string]interface{}, key string) {
+	if m == nil {
+		return
+	}
+	value, ok := m[key]
+	if !ok {
+		return
+	}
+	if value == nil {
+		delete(m, key)
+		return
+	}
+	if v := reflect.ValueOf(value); (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0 {
+		delete(m, key)
+	}
+}
+
+// pruneEmpty recursively removes null values and empty maps and lists from m,
+// including the ones left empty by the removal of their content
+func pruneEmpty(m map[string]interface{}) {
+	for key, value := range m {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			pruneEmpty(v)
+		case []interface{}:
+			for _, item := range v {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					pruneEmpty(itemMap)
+				}
+			}
+		}
+		removeEmpty(m, key)
+	}
+}
+
+// equal compares unstructured values, numbers are compared by value whatever their type
+func equal(a, b interface{}) bool {
+	if an, ok := number(a); ok {
+		bn, ok := number(b)
+		return ok && an == bn
+	}
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if aIsMap || bIsMap {
+		if !aIsMap || !bIsMap || len(am) != len(bm) {
+			return false
+		}
+		for key, value := range am {
+			if other, ok := bm[key]; !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
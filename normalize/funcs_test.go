@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normalize
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestDefaultPullPolicy(t *testing.T) {
+	var data = []struct {
+		image string
+		want  string
+	}{
+		{"nginx", "Always"},
+		{"nginx:latest", "Always"},
+		{"registry:5000/nginx", "Always"},
+		{"registry:5000/nginx:1.25", "IfNotPresent"},
+		{"nginx@sha256:abc", "IfNotPresent"},
+	}
+	for _, item := range data {
+		t.Run(item.image, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(defaultPullPolicy(item.image)).To(Equal(item.want))
+		})
+	}
+}
+
+func TestPodSpecDefaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+	desired := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app", Image: "app:v1",
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+			}},
+			Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+		}}},
+	}
+	live := desired.DeepCopy()
+	live.ResourceVersion = "5"
+	live.Generation = 2
+	live.Status.Replicas = 1
+	live.Spec.RevisionHistoryLimit = ptr.To[int32](10)
+	live.Spec.ProgressDeadlineSeconds = ptr.To[int32](600)
+	live.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType, RollingUpdate: &appsv1.RollingUpdateDeployment{
+		MaxSurge: ptr.To(intstr.FromString("25%")), MaxUnavailable: ptr.To(intstr.FromString("25%")),
+	}}
+	spec := &live.Spec.Template.Spec
+	spec.RestartPolicy = corev1.RestartPolicyAlways
+	spec.DNSPolicy = corev1.DNSClusterFirst
+	spec.SchedulerName = "default-scheduler"
+	spec.TerminationGracePeriodSeconds = ptr.To[int64](30)
+	spec.SecurityContext = &corev1.PodSecurityContext{}
+	spec.Tolerations = append(spec.Tolerations, corev1.Toleration{
+		Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists,
+		Effect: corev1.TaintEffectNoExecute, TolerationSeconds: ptr.To[int64](300),
+	})
+	container := &spec.Containers[0]
+	container.ImagePullPolicy = corev1.PullIfNotPresent
+	container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	container.Ports[0].Protocol = corev1.ProtocolTCP
+
+	equal, err := Equivalent(live, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(equal).To(BeTrue())
+
+	// non default values are kept
+	container.ImagePullPolicy = corev1.PullAlways
+	equal, err = Equivalent(live, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(equal).To(BeFalse())
+}
+
+func TestServiceDefaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+	desired := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "metrics", Port: 9090, TargetPort: intstr.FromString("metrics")}},
+		},
+	}
+	live := desired.DeepCopy()
+	live.Spec.Type = corev1.ServiceTypeClusterIP
+	live.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	live.Spec.ClusterIP = "10.0.0.1"
+	live.Spec.ClusterIPs = []string{"10.0.0.1"}
+	live.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol}
+	live.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicySingleStack)
+	live.Spec.InternalTrafficPolicy = ptr.To(corev1.ServiceInternalTrafficPolicyCluster)
+	live.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+	live.Spec.Ports[0].TargetPort = intstr.FromInt32(80)
+	live.Spec.Ports[1].Protocol = corev1.ProtocolTCP
+
+	equal, err := Equivalent(live, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(equal).To(BeTrue())
+
+	content, err := Normalize(live)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(content).To(HaveKeyWithValue("spec", HaveKeyWithValue("ports", ContainElement(HaveKeyWithValue("targetPort", "metrics")))))
+}
+
+func TestRemoveServerFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+	content := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "cm",
+			"resourceVersion": "1",
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "test"}},
+			"annotations":     map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+			"labels":          map[string]interface{}{},
+		},
+		"status": map[string]interface{}{"phase": "Active"},
+	}
+	RemoveServerFields(content)
+	g.Expect(content).To(Equal(map[string]interface{}{"metadata": map[string]interface{}{"name": "cm"}}))
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normalize
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Func normalizes the unstructured content of an object in place
+type Func func(content map[string]interface{})
+
+// Registry holds normalization funcs applied to all objects and per kind
+type Registry struct {
+	scheme *runtime.Scheme
+
+	lock   sync.RWMutex
+	common []Func
+	kinds  map[schema.GroupKind][]Func
+}
+
+// NewRegistry returns an empty registry resolving the kind of typed objects with scheme,
+// the client-go scheme is used if scheme is nil
+func NewRegistry(scheme *runtime.Scheme) *Registry {
+	if scheme == nil {
+		scheme = clientgoscheme.Scheme
+	}
+	return &Registry{scheme: scheme, kinds: map[schema.GroupKind][]Func{}}
+}
+
+// RegisterCommon registers funcs applied to objects of all kinds
+func (r *Registry) RegisterCommon(funcs ...Func) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.common = append(r.common, funcs...)
+}
+
+// Register registers funcs applied to objects of the kind, after the common funcs
+func (r *Registry) Register(gk schema.GroupKind, funcs ...Func) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.kinds[gk] = append(r.kinds[gk], funcs...)
+}
+
+// Normalize returns the normalized unstructured content of a copy of obj.
+// Only the common funcs are applied to objects of unknown kind. Null values and
+// empty maps and lists are removed last since they are equivalent to missing fields
+func (r *Registry) Normalize(obj runtime.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	funcs := append([]Func{}, r.common...)
+	if gvk, err := apiutil.GVKForObject(obj, r.scheme); err == nil {
+		funcs = append(funcs, r.kinds[gvk.GroupKind()]...)
+	}
+	r.lock.RUnlock()
+
+	for _, fn := range funcs {
+		fn(content)
+	}
+	pruneEmpty(content)
+	return content, nil
+}
+
+// Equivalent returns true if the normalized contents of live and desired are semantically equal
+func (r *Registry) Equivalent(live, desired runtime.Object) (bool, error) {
+	liveContent, err := r.Normalize(live)
+	if err != nil {
+		return false, err
+	}
+	desiredContent, err := r.Normalize(desired)
+	if err != nil {
+		return false, err
+	}
+	return equality.Semantic.DeepEqual(liveContent, desiredContent), nil
+}
+
+// DefaultRegistry removes the ServerFields from all objects and the defaults
+// of pod templates, services and workloads of the built-in kinds
+var DefaultRegistry = NewRegistry(nil)
+
+func init() {
+	DefaultRegistry.RegisterCommon(RemoveServerFields)
+	DefaultRegistry.Register(schema.GroupKind{Kind: "Pod"}, PodSpecDefaults("spec"))
+	DefaultRegistry.Register(schema.GroupKind{Kind: "Service"}, ServiceDefaults)
+	for _, kind := range []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet"} {
+		DefaultRegistry.Register(schema.GroupKind{Group: "apps", Kind: kind}, PodSpecDefaults("spec", "template", "spec"))
+	}
+	DefaultRegistry.Register(schema.GroupKind{Group: "apps", Kind: "Deployment"}, DeploymentDefaults)
+	DefaultRegistry.Register(schema.GroupKind{Group: "batch", Kind: "Job"}, PodSpecDefaults("spec", "template", "spec"))
+	DefaultRegistry.Register(schema.GroupKind{Group: "batch", Kind: "CronJob"}, PodSpecDefaults("spec", "jobTemplate", "spec", "template", "spec"))
+}
+
+// Register registers funcs for the kind in the DefaultRegistry
+func Register(gk schema.GroupKind, funcs ...Func) {
+	DefaultRegistry.Register(gk, funcs...)
+}
+
+// Normalize returns the normalized content of a copy of obj using the DefaultRegistry
+func Normalize(obj runtime.Object) (map[string]interface{}, error) {
+	return DefaultRegistry.Normalize(obj)
+}
+
+// Equivalent returns true if live and desired are equal once normalized using the DefaultRegistry
+func Equivalent(live, desired runtime.Object) (bool, error) {
+	return DefaultRegistry.Equivalent(live, desired)
+}
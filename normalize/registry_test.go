@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normalize
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRegistry(t *testing.T) {
+	g := NewGomegaWithT(t)
+	registry := NewRegistry(nil)
+	registry.RegisterCommon(RemoveServerFields)
+	fooKind := schema.GroupKind{Group: "example.io", Kind: "Foo"}
+	registry.Register(fooKind, RemoveFields([]string{"spec", "generated"}))
+
+	foo := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.io/v1", "kind": "Foo",
+		"metadata": map[string]interface{}{"name": "foo", "resourceVersion": "1"},
+		"spec":     map[string]interface{}{"generated": "x", "url": "https://example.io"},
+	}}
+	content, err := registry.Normalize(foo)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(content).To(Equal(map[string]interface{}{
+		"apiVersion": "example.io/v1", "kind": "Foo",
+		"metadata": map[string]interface{}{"name": "foo"},
+		"spec":     map[string]interface{}{"url": "https://example.io"},
+	}))
+	// the object is not changed
+	g.Expect(foo.Object).To(HaveKeyWithValue("spec", HaveKey("generated")))
+
+	// typed objects without type meta are resolved using the scheme
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", ResourceVersion: "2"}, Data: map[string]string{"a": "b"}}
+	registry.Register(schema.GroupKind{Kind: "ConfigMap"}, RemoveFields([]string{"data"}))
+	content, err = registry.Normalize(cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(content).To(Equal(map[string]interface{}{"metadata": map[string]interface{}{"name": "cm"}}))
+
+	other := foo.DeepCopy()
+	other.SetResourceVersion("2")
+	g.Expect(unstructured.SetNestedField(other.Object, "y", "spec", "generated")).To(Succeed())
+	equal, err := registry.Equivalent(foo, other)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(equal).To(BeTrue())
+
+	g.Expect(unstructured.SetNestedField(other.Object, "https://other.io", "spec", "url")).To(Succeed())
+	equal, err = registry.Equivalent(foo, other)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(equal).To(BeFalse())
+}
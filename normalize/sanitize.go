@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normalize
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/AlaudaDevops/pkg/yamlutil"
+)
+
+// SanitizeFields are the fields specific to the cluster objects were recorded from,
+// removed by Sanitize so the objects can be checked in as fixtures or exported: the
+// ServerMetadataFields and the node pods are scheduled to
+var SanitizeFields = append(append([][]string{}, ServerMetadataFields...),
+	[]string{"spec", "nodeName"},
+	[]string{"status", "nominatedNodeName"},
+)
+
+// Sanitize removes the SanitizeFields and the uids of owner references from obj,
+// and from the items of lists, keeping the spec and status describing the object.
+// obj can be typed or *unstructured.Unstructured
+//
+//	err := normalize.Sanitize(recordedPod)
+func Sanitize(obj runtime.Object) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		SanitizeContent(u.Object)
+		return nil
+	}
+	if list, ok := obj.(*unstructured.UnstructuredList); ok {
+		SanitizeContent(list.Object)
+		for i := range list.Items {
+			SanitizeContent(list.Items[i].Object)
+		}
+		return nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("convert %T to unstructured failed: %w", obj, err)
+	}
+	SanitizeContent(content)
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj)
+}
+
+// SanitizeContent is Sanitize for the content of an unstructured object
+func SanitizeContent(content map[string]interface{}) {
+	RemoveFields(SanitizeFields...)(content)
+	metadata, _ := content["metadata"].(map[string]interface{})
+	for _, key := range []string{"annotations", "labels"} {
+		if values, ok := metadata[key].(map[string]interface{}); ok && len(values) == 0 {
+			delete(metadata, key)
+		}
+	}
+	if owners, ok := metadata["ownerReferences"].([]interface{}); ok {
+		for _, owner := range owners {
+			if ownerMap, ok := owner.(map[string]interface{}); ok {
+				delete(ownerMap, "uid")
+			}
+		}
+	}
+	if items, ok := content["items"].([]interface{}); ok {
+		for _, item := range items {
+			if itemMap, ok := item.(map[string]interface{}); ok {
+				SanitizeContent(itemMap)
+			}
+		}
+	}
+}
+
+// SanitizeManifest sanitizes every document of the yaml or json manifest read from r
+// and writes them into w as yaml documents
+func SanitizeManifest(r io.Reader, w io.Writer) error {
+	decoder := yamlutil.NewDecoder(r)
+	for i := 0; ; i++ {
+		content := map[string]interface{}{}
+		err := decoder.Decode(&content)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decode document %d failed: %w", i, err)
+		}
+		if len(content) == 0 {
+			i--
+			continue
+		}
+		SanitizeContent(content)
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("marshal document %d failed: %w", i, err)
+		}
+		if i > 0 {
+			if _, err = io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(data); err != nil {
+			return err
+		}
+	}
+}
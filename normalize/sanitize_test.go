@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normalize
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSanitize(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web", UID: "uid", ResourceVersion: "1", Generation: 2,
+			Annotations:     map[string]string{corev1.LastAppliedConfigAnnotation: "{}"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc", UID: "owner"}},
+		},
+		Spec:   corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, NominatedNodeName: "node-2"},
+	}
+	g.Expect(Sanitize(pod)).To(Succeed())
+	g.Expect(pod.ObjectMeta).To(Equal(metav1.ObjectMeta{
+		Name:            "web",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc"}},
+	}))
+	g.Expect(pod.Spec.NodeName).To(BeEmpty())
+	g.Expect(pod.Status).To(Equal(corev1.PodStatus{Phase: corev1.PodRunning}))
+
+	list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{{}}}
+	list.Items[0].SetName("cm")
+	list.Items[0].SetUID("uid")
+	g.Expect(Sanitize(list)).To(Succeed())
+	g.Expect(list.Items[0].GetUID()).To(BeEmpty())
+}
+
+func TestSanitizeManifest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  uid: x\n---\n---\n{\"apiVersion\": \"v1\", \"kind\": \"ConfigMap\", \"metadata\": {\"name\": \"b\", \"resourceVersion\": \"1\"}}\n"
+	out := &bytes.Buffer{}
+	g.Expect(SanitizeManifest(bytes.NewBufferString(manifest), out)).To(Succeed())
+	g.Expect(out.String()).To(Equal("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n"))
+
+	g.Expect(SanitizeManifest(bytes.NewBufferString("a: [\n"), out)).To(MatchError(ContainSubstring("decode document 0 failed")))
+}
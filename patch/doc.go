@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package patch computes RFC6902 JSON patches, JSON merge patches and strategic
+// merge patches between two objects, leaving out ignored paths such as status or
+// managed fields, and builds metadata-only patches adding or removing labels and
+// annotations without reading and updating the whole object.
+//
+//	data, err := patch.JSONPatch(original, modified, patch.IgnorePaths("/spec/replicas"))
+//
+//	operations, err := patch.JSONPatchOperations(original, modified)
+//	return admission.Patched("defaulted", operations...)
+//
+//	p, err := patch.StrategicMergePatch(original, modified)
+//	err = clt.Patch(ctx, obj, p)
+//
+//	err = clt.Patch(ctx, obj, patch.Annotate("example.io/synced", "true").RemoveLabel("stale"))
+package patch
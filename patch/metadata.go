@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MetadataPatch is a client.Patch adding, changing or removing labels and annotations
+// with a JSON merge patch, without reading the object nor checking its resource version
+type MetadataPatch struct {
+	labels      map[string]*string
+	annotations map[string]*string
+}
+
+var _ client.Patch = &MetadataPatch{}
+
+// Metadata returns an empty metadata patch
+func Metadata() *MetadataPatch {
+	return &MetadataPatch{}
+}
+
+// Annotate returns a metadata patch setting the annotation
+func Annotate(key, value string) *MetadataPatch {
+	return Metadata().Annotate(key, value)
+}
+
+// Label returns a metadata patch setting the label
+func Label(key, value string) *MetadataPatch {
+	return Metadata().Label(key, value)
+}
+
+// Annotate sets the annotation
+func (p *MetadataPatch) Annotate(key, value string) *MetadataPatch {
+	p.annotations = set(p.annotations, key, &value)
+	return p
+}
+
+// RemoveAnnotation removes the annotation
+func (p *MetadataPatch) RemoveAnnotation(key string) *MetadataPatch {
+	p.annotations = set(p.annotations, key, nil)
+	return p
+}
+
+// Label sets the label
+func (p *MetadataPatch) Label(key, value string) *MetadataPatch {
+	p.labels = set(p.labels, key, &value)
+	return p
+}
+
+// RemoveLabel removes the label
+func (p *MetadataPatch) RemoveLabel(key string) *MetadataPatch {
+	p.labels = set(p.labels, key, nil)
+	return p
+}
+
+// IsEmpty returns true if the patch does not change any label or annotation
+func (p *MetadataPatch) IsEmpty() bool {
+	return len(p.labels) == 0 && len(p.annotations) == 0
+}
+
+// Type implements client.Patch
+func (p *MetadataPatch) Type() types.PatchType {
+	return types.MergePatchType
+}
+
+// Data implements client.Patch, removed keys are sent as null
+func (p *MetadataPatch) Data(_ client.Object) ([]byte, error) {
+	metadata := map[string]interface{}{}
+	if len(p.labels) > 0 {
+		metadata["labels"] = p.labels
+	}
+	if len(p.annotations) > 0 {
+		metadata["annotations"] = p.annotations
+	}
+	return json.Marshal(map[string]interface{}{"metadata": metadata})
+}
+
+func set(values map[string]*string, key string, value *string) map[string]*string {
+	if values == nil {
+		values = map[string]*string{}
+	}
+	values[key] = value
+	return values
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMetadataPatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(Metadata().IsEmpty()).To(BeTrue())
+
+	p := Annotate("a", "1").Label("l", "x").RemoveLabel("old").RemoveAnnotation("b")
+	g.Expect(p.IsEmpty()).To(BeFalse())
+	g.Expect(p.Type()).To(Equal(types.MergePatchType))
+	data, err := p.Data(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`{"metadata":{"annotations":{"a":"1","b":null},"labels":{"l":"x","old":null}}}`))
+
+	data, err = Label("l", "y").Data(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`{"metadata":{"labels":{"l":"y"}}}`))
+}
+
+func TestMetadataPatch_Client(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "cm", Namespace: "default",
+		Labels:      map[string]string{"old": "true", "keep": "true"},
+		Annotations: map[string]string{"b": "2"},
+	}}
+	clt := fake.NewClientBuilder().WithObjects(cm).Build()
+
+	// a stale object is patched without conflicts
+	stale := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}}
+	g.Expect(clt.Patch(ctx, stale, Annotate("a", "1").RemoveAnnotation("b").Label("l", "x").RemoveLabel("old"))).To(Succeed())
+
+	patched := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, client.ObjectKeyFromObject(cm), patched)).To(Succeed())
+	g.Expect(patched.Annotations).To(Equal(map[string]string{"a": "1"}))
+	g.Expect(patched.Labels).To(Equal(map[string]string{"keep": "true", "l": "x"}))
+}
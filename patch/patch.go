@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatchv5 "github.com/evanphx/json-patch/v5"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultIgnoredPaths are JSON pointers to fields set by the api server,
+// left out of patches unless WithoutDefaultIgnoredPaths is given
+var DefaultIgnoredPaths = []string{
+	"/metadata/resourceVersion",
+	"/metadata/managedFields",
+	"/metadata/generation",
+	"/metadata/uid",
+	"/metadata/creationTimestamp",
+	"/status",
+}
+
+// Option configures how patches are computed
+type Option func(*options)
+
+type options struct {
+	ignoredPaths []string
+	skipDefaults bool
+}
+
+// IgnorePaths leaves the fields at the given JSON pointers, e.g. /spec/replicas, out of the patch.
+// Pointers address object fields only, array items cannot be ignored individually
+func IgnorePaths(paths ...string) Option {
+	return func(o *options) {
+		o.ignoredPaths = append(o.ignoredPaths, paths...)
+	}
+}
+
+// WithoutDefaultIgnoredPaths includes the DefaultIgnoredPaths in the patch
+func WithoutDefaultIgnoredPaths() Option {
+	return func(o *options) {
+		o.skipDefaults = true
+	}
+}
+
+// JSONPatchOperations returns the RFC6902 JSON patch operations turning original into modified
+func JSONPatchOperations(original, modified interface{}, opts ...Option) ([]jsonpatch.Operation, error) {
+	originalJSON, modifiedJSON, err := marshal(original, modified, opts)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := jsonpatch.CreatePatch(originalJSON, modifiedJSON)
+	if err != nil {
+		return nil, fmt.Errorf("create json patch: %w", err)
+	}
+	return operations, nil
+}
+
+// JSONPatch returns the RFC6902 JSON patch turning original into modified,
+// an empty JSON array if they are equal
+func JSONPatch(original, modified interface{}, opts ...Option) ([]byte, error) {
+	operations, err := JSONPatchOperations(original, modified, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if operations == nil {
+		operations = []jsonpatch.Operation{}
+	}
+	return json.Marshal(operations)
+}
+
+// MergePatch returns the RFC7386 JSON merge patch turning original into modified
+func MergePatch(original, modified interface{}, opts ...Option) ([]byte, error) {
+	originalJSON, modifiedJSON, err := marshal(original, modified, opts)
+	if err != nil {
+		return nil, err
+	}
+	data, err := jsonpatchv5.CreateMergePatch(originalJSON, modifiedJSON)
+	if err != nil {
+		return nil, fmt.Errorf("create merge patch: %w", err)
+	}
+	return data, nil
+}
+
+// StrategicMergePatch returns a client.Patch with the strategic merge patch turning original
+// into modified. Strategic merge patches require the go type of the object, so a JSON merge patch
+// is returned instead for unstructured objects and types not registered in the client-go scheme
+func StrategicMergePatch(original, modified runtime.Object, opts ...Option) (client.Patch, error) {
+	if !isBuiltIn(original) {
+		data, err := MergePatch(original, modified, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return client.RawPatch(types.MergePatchType, data), nil
+	}
+	originalJSON, modifiedJSON, err := marshal(original, modified, opts)
+	if err != nil {
+		return nil, err
+	}
+	data, err := strategicpatch.CreateTwoWayMergePatch(originalJSON, modifiedJSON, original)
+	if err != nil {
+		return nil, fmt.Errorf("create strategic merge patch: %w", err)
+	}
+	return client.RawPatch(types.StrategicMergePatchType, data), nil
+}
+
+// IsEmpty returns true if a JSON patch or merge patch does not change anything
+func IsEmpty(data []byte) bool {
+	trimmed := strings.TrimSpace(string(data))
+	return trimmed == "" || trimmed == "{}" || trimmed == "[]" || trimmed == "null"
+}
+
+// marshal returns the JSON of original and modified without the ignored paths
+func marshal(original, modified interface{}, opts []Option) ([]byte, []byte, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	ignored := o.ignoredPaths
+	if !o.skipDefaults {
+		ignored = append(append([]string{}, DefaultIgnoredPaths...), ignored...)
+	}
+	originalJSON, err := marshalWithout(original, ignored)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal original object: %w", err)
+	}
+	modifiedJSON, err := marshalWithout(modified, ignored)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal modified object: %w", err)
+	}
+	return originalJSON, modifiedJSON, nil
+}
+
+func marshalWithout(obj interface{}, ignored []string) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil || len(ignored) == 0 {
+		return data, err
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		// not an object, nothing to remove
+		return data, nil
+	}
+	for _, path := range ignored {
+		removePath(doc, path)
+	}
+	return json.Marshal(doc)
+}
+
+// removePath removes the field at the JSON pointer from doc
+func removePath(doc map[string]interface{}, pointer string) {
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		if i == len(segments)-1 {
+			delete(doc, segment)
+			return
+		}
+		child, ok := doc[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		doc = child
+	}
+}
+
+// isBuiltIn returns true if obj is a typed object strategic merge patches can be computed for
+func isBuiltIn(obj runtime.Object) bool {
+	if _, ok := obj.(runtime.Unstructured); ok {
+		return false
+	}
+	gvks, _, err := clientgoscheme.Scheme.ObjectKinds(obj)
+	return err == nil && len(gvks) > 0
+}
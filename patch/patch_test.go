@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gomodules.xyz/jsonpatch/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func newDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", ResourceVersion: "1"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "app:v1"}, {Name: "sidecar", Image: "sidecar:v1"}},
+			}},
+		},
+	}
+}
+
+func TestJSONPatchOperations(t *testing.T) {
+	original := newDeployment()
+	modified := original.DeepCopy()
+	modified.ResourceVersion = "2"
+	modified.Status.Replicas = 2
+	modified.Spec.Replicas = ptr.To[int32](3)
+	modified.Spec.Template.Spec.Containers[0].Image = "app:v2"
+
+	var data = []struct {
+		desc string
+		opts []Option
+		want []jsonpatch.Operation
+	}{
+		{"default ignored paths", nil, []jsonpatch.Operation{
+			jsonpatch.NewOperation("replace", "/spec/replicas", float64(3)),
+			jsonpatch.NewOperation("replace", "/spec/template/spec/containers/0/image", "app:v2"),
+		}},
+		{"ignored paths", []Option{IgnorePaths("/spec/replicas")}, []jsonpatch.Operation{
+			jsonpatch.NewOperation("replace", "/spec/template/spec/containers/0/image", "app:v2"),
+		}},
+		{"without default ignored paths", []Option{WithoutDefaultIgnoredPaths(), IgnorePaths("/spec")}, []jsonpatch.Operation{
+			jsonpatch.NewOperation("replace", "/metadata/resourceVersion", "2"),
+			jsonpatch.NewOperation("add", "/status/replicas", float64(2)),
+		}},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			operations, err := JSONPatchOperations(original, modified, item.opts...)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(operations).To(ConsistOf(item.want))
+		})
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	original := newDeployment()
+
+	data, err := JSONPatch(original, original.DeepCopy())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal("[]"))
+	g.Expect(IsEmpty(data)).To(BeTrue())
+
+	modified := original.DeepCopy()
+	modified.Labels = map[string]string{"a/b": "c"}
+	data, err = JSONPatch(original, modified)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`[{"op":"add","path":"/metadata/labels","value":{"a/b":"c"}}]`))
+	g.Expect(IsEmpty(data)).To(BeFalse())
+
+	// escaped pointers
+	data, err = JSONPatch(original, modified, IgnorePaths("/metadata/labels/a~1b"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`[{"op":"add","path":"/metadata/labels","value":{}}]`))
+}
+
+func TestMergePatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	original := newDeployment()
+	modified := original.DeepCopy()
+	modified.ResourceVersion = "2"
+	modified.Spec.Replicas = nil
+
+	data, err := MergePatch(original, modified)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`{"spec":{"replicas":null}}`))
+}
+
+func TestStrategicMergePatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	original := newDeployment()
+	modified := original.DeepCopy()
+	modified.Spec.Template.Spec.Containers[1].Image = "sidecar:v2"
+
+	p, err := StrategicMergePatch(original, modified)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Type()).To(Equal(types.StrategicMergePatchType))
+	data, err := p.Data(modified)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`{"spec":{"template":{"spec":{"$setElementOrder/containers":[{"name":"app"},{"name":"sidecar"}],"containers":[{"image":"sidecar:v2","name":"sidecar"}]}}}}`))
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "example.io/v1", "kind": "Foo", "spec": map[string]interface{}{"a": "b"}}}
+	modifiedU := u.DeepCopy()
+	modifiedU.Object["spec"] = map[string]interface{}{"a": "c"}
+	p, err = StrategicMergePatch(u, modifiedU)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Type()).To(Equal(types.MergePatchType))
+	data, err = p.Data(modifiedU)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(MatchJSON(`{"spec":{"a":"c"}}`))
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package profiles provides named profiles of resource requirements, scheduling
+// and security settings that operators apply to the pod templates they generate.
+// Built-in small, medium and large profiles run as non root with the runtime default
+// seccomp profile and spread pods across nodes and zones; they can be overridden and
+// new profiles added with a ConfigMap updated at runtime.
+//
+// Profiles only default what the template leaves unset, explicit settings always win.
+//
+//	registry := profiles.New()
+//	profiles.SetupDynamicProfiles(registry, configMapWatcher, logger)
+//	ctx = profiles.WithRegistry(ctx, registry)
+//
+//	deploy := builder.Deployment(name, namespace, selector, podSpec)
+//	err := profiles.Apply(ctx, &deploy.Spec.Template, app.Spec.Profile)
+package profiles
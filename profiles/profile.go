@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiles
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Small profile for lightweight components like sidecars and webhooks
+	Small = "small"
+	// Medium profile for most controllers, the default profile
+	Medium = "medium"
+	// Large profile for components handling heavy workloads like api servers
+	Large = "large"
+)
+
+// Profile of settings defaulted in pod templates
+type Profile struct {
+	// Resources defaulted in each container, per resource name
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Affinity of pods without affinity
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Tolerations of pods without tolerations
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// TopologySpreadConstraints of pods without constraints, constraints without a label selector
+	// select the pods with the labels of the template
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PodSecurityContext of pods without a security context
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// SecurityContext of containers without a security context
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// Builtin returns the built-in profiles
+func Builtin() map[string]Profile {
+	return map[string]Profile{
+		Small:  newProfile("100m", "128Mi", "500m", "256Mi"),
+		Medium: newProfile("250m", "256Mi", "1", "512Mi"),
+		Large:  newProfile("500m", "512Mi", "2", "2Gi"),
+	}
+}
+
+func newProfile(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) Profile {
+	return Profile{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+				corev1.ResourceMemory: resource.MustParse(memoryRequest),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+				corev1.ResourceMemory: resource.MustParse(memoryLimit),
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+			{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway},
+		},
+		PodSecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:   ptr.To(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
+}
+
+// Merge returns a copy of the profile with the fields set in override replacing its own,
+// resources are merged per resource name
+func (p Profile) Merge(override Profile) Profile {
+	merged := *p.DeepCopy()
+	merged.Resources.Requests = mergeResources(merged.Resources.Requests, override.Resources.Requests)
+	merged.Resources.Limits = mergeResources(merged.Resources.Limits, override.Resources.Limits)
+	if override.Affinity != nil {
+		merged.Affinity = override.Affinity.DeepCopy()
+	}
+	if override.Tolerations != nil {
+		merged.Tolerations = override.DeepCopy().Tolerations
+	}
+	if override.TopologySpreadConstraints != nil {
+		merged.TopologySpreadConstraints = override.DeepCopy().TopologySpreadConstraints
+	}
+	if override.PodSecurityContext != nil {
+		merged.PodSecurityContext = override.PodSecurityContext.DeepCopy()
+	}
+	if override.SecurityContext != nil {
+		merged.SecurityContext = override.SecurityContext.DeepCopy()
+	}
+	return merged
+}
+
+// Apply defaults the settings of the profile left unset in the template and its containers.
+// Requests greater than the limit of a container, and limits lower than its request, are not defaulted
+func (p Profile) Apply(template *corev1.PodTemplateSpec) {
+	spec := &template.Spec
+	for i := range spec.InitContainers {
+		p.applyContainer(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		p.applyContainer(&spec.Containers[i])
+	}
+	if spec.Affinity == nil && p.Affinity != nil {
+		spec.Affinity = p.Affinity.DeepCopy()
+	}
+	if len(spec.Tolerations) == 0 && len(p.Tolerations) > 0 {
+		spec.Tolerations = p.DeepCopy().Tolerations
+	}
+	if len(spec.TopologySpreadConstraints) == 0 && len(p.TopologySpreadConstraints) > 0 {
+		spec.TopologySpreadConstraints = p.DeepCopy().TopologySpreadConstraints
+		for i := range spec.TopologySpreadConstraints {
+			constraint := &spec.TopologySpreadConstraints[i]
+			if constraint.LabelSelector == nil && len(template.Labels) > 0 {
+				constraint.LabelSelector = metav1.SetAsLabelSelector(template.Labels)
+			}
+		}
+	}
+	if spec.SecurityContext == nil && p.PodSecurityContext != nil {
+		spec.SecurityContext = p.PodSecurityContext.DeepCopy()
+	}
+}
+
+func (p Profile) applyContainer(container *corev1.Container) {
+	resources := &container.Resources
+	for name, request := range p.Resources.Requests {
+		if _, ok := resources.Requests[name]; ok {
+			continue
+		}
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			continue
+		}
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[name] = request.DeepCopy()
+	}
+	for name, limit := range p.Resources.Limits {
+		if _, ok := resources.Limits[name]; ok {
+			continue
+		}
+		if request, ok := resources.Requests[name]; ok && limit.Cmp(request) < 0 {
+			continue
+		}
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Limits[name] = limit.DeepCopy()
+	}
+	if container.SecurityContext == nil && p.SecurityContext != nil {
+		container.SecurityContext = p.SecurityContext.DeepCopy()
+	}
+}
+
+func mergeResources(dst, src corev1.ResourceList) corev1.ResourceList {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = corev1.ResourceList{}
+	}
+	for name, quantity := range src {
+		dst[name] = quantity.DeepCopy()
+	}
+	return dst
+}
+
+// DeepCopy returns a deep copy of the profile
+func (p *Profile) DeepCopy() *Profile {
+	if p == nil {
+		return nil
+	}
+	out := &Profile{
+		Affinity:           p.Affinity.DeepCopy(),
+		PodSecurityContext: p.PodSecurityContext.DeepCopy(),
+		SecurityContext:    p.SecurityContext.DeepCopy(),
+	}
+	p.Resources.DeepCopyInto(&out.Resources)
+	if p.Tolerations != nil {
+		out.Tolerations = make([]corev1.Toleration, len(p.Tolerations))
+		for i := range p.Tolerations {
+			p.Tolerations[i].DeepCopyInto(&out.Tolerations[i])
+		}
+	}
+	if p.TopologySpreadConstraints != nil {
+		out.TopologySpreadConstraints = make([]corev1.TopologySpreadConstraint, len(p.TopologySpreadConstraints))
+		for i := range p.TopologySpreadConstraints {
+			p.TopologySpreadConstraints[i].DeepCopyInto(&out.TopologySpreadConstraints[i])
+		}
+	}
+	return out
+}
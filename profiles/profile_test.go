@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiles
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newTemplate(containers ...corev1.Container) *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo"}},
+		Spec:       corev1.PodSpec{Containers: containers},
+	}
+}
+
+func TestProfile_Apply(t *testing.T) {
+	g := NewGomegaWithT(t)
+	profile := Builtin()[Small]
+
+	template := newTemplate(
+		corev1.Container{Name: "empty"},
+		corev1.Container{Name: "explicit", Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		}, SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)}},
+	)
+	template.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+	profile.Apply(template)
+
+	empty := template.Spec.Containers[0]
+	g.Expect(empty.Resources.Requests.Cpu().String()).To(Equal("100m"))
+	g.Expect(empty.Resources.Limits.Memory().String()).To(Equal("256Mi"))
+	g.Expect(*empty.SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+	g.Expect(template.Spec.InitContainers[0].Resources.Requests.Memory().String()).To(Equal("128Mi"))
+
+	// explicit values are kept and invalid combinations are not defaulted
+	explicit := template.Spec.Containers[1]
+	g.Expect(explicit.Resources.Requests.Cpu().String()).To(Equal("1"))
+	g.Expect(explicit.Resources.Limits.Cpu().IsZero()).To(BeTrue(), "limit lower than the request")
+	g.Expect(explicit.Resources.Limits.Memory().String()).To(Equal("64Mi"))
+	g.Expect(explicit.Resources.Requests.Memory().IsZero()).To(BeTrue(), "request greater than the limit")
+	g.Expect(*explicit.SecurityContext.Privileged).To(BeTrue())
+
+	g.Expect(*template.Spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+	g.Expect(template.Spec.TopologySpreadConstraints).To(HaveLen(2))
+	g.Expect(template.Spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(Equal(map[string]string{"app": "demo"}))
+	// the profile is not modified
+	g.Expect(profile.TopologySpreadConstraints[0].LabelSelector).To(BeNil())
+
+	template = newTemplate(corev1.Container{Name: "app"})
+	template.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated"}}
+	template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{TopologyKey: "rack", MaxSkew: 2}}
+	profile.Tolerations = []corev1.Toleration{{Key: "other"}}
+	profile.Apply(template)
+	g.Expect(template.Spec.Tolerations).To(Equal([]corev1.Toleration{{Key: "dedicated"}}))
+	g.Expect(template.Spec.TopologySpreadConstraints).To(HaveLen(1))
+}
+
+func TestProfile_Merge(t *testing.T) {
+	g := NewGomegaWithT(t)
+	base := Builtin()[Medium]
+
+	merged := base.Merge(Profile{
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		Tolerations: []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+	})
+	g.Expect(merged.Resources.Limits.Memory().String()).To(Equal("1Gi"))
+	g.Expect(merged.Resources.Limits.Cpu().String()).To(Equal("1"))
+	g.Expect(merged.Tolerations).To(HaveLen(1))
+	g.Expect(merged.PodSecurityContext).To(Equal(base.PodSecurityContext))
+	g.Expect(base.Resources.Limits.Memory().String()).To(Equal("512Mi"))
+	g.Expect(base.Tolerations).To(BeNil())
+}
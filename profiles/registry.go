@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	kconfigmap "github.com/AlaudaDevops/pkg/configmap"
+	"github.com/AlaudaDevops/pkg/ctxutil"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/system"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultProfileKey key of the ConfigMap selecting the profile used when none is requested
+	DefaultProfileKey = "defaultProfile"
+
+	configMapNameEnv     = "CONFIG_PROFILES_NAME"
+	defaultConfigMapName = "config-profiles"
+)
+
+// ConfigMapName returns the name of the profiles ConfigMap,
+// which can be changed using the CONFIG_PROFILES_NAME environment variable
+func ConfigMapName() string {
+	if name := os.Getenv(configMapNameEnv); name != "" {
+		return name
+	}
+	return defaultConfigMapName
+}
+
+// Registry holds the built-in profiles and the profiles configured in a ConfigMap.
+// Configured profiles are merged over the built-in profile with the same name
+type Registry struct {
+	lock              sync.RWMutex
+	builtin           map[string]Profile
+	configured        map[string]Profile
+	configuredDefault string
+}
+
+// New returns a registry with the built-in profiles, using Medium by default
+func New() *Registry {
+	return &Registry{
+		builtin:    Builtin(),
+		configured: map[string]Profile{},
+	}
+}
+
+// Get returns the profile with name, an empty name returns the default profile
+func (r *Registry) Get(name string) (Profile, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if name == "" {
+		name = r.defaultName()
+	}
+	if profile, ok := r.configured[name]; ok {
+		return *profile.DeepCopy(), nil
+	}
+	if profile, ok := r.builtin[name]; ok {
+		return *profile.DeepCopy(), nil
+	}
+	return Profile{}, fmt.Errorf("unknown profile %q, known profiles are: %s", name, strings.Join(r.names(), ", "))
+}
+
+// Names returns the sorted names of the known profiles
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.names()
+}
+
+// Apply defaults the settings of the profile with name in the template,
+// an empty name applies the default profile
+func (r *Registry) Apply(template *corev1.PodTemplateSpec, name string) error {
+	profile, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+	profile.Apply(template)
+	return nil
+}
+
+// SetFromConfigMap sets the configured profiles from the data of the ConfigMap, each key
+// being the name of a profile and its value the profile in YAML, except DefaultProfileKey.
+// Profiles removed from the ConfigMap return to their built-in value
+func (r *Registry) SetFromConfigMap(cm *corev1.ConfigMap) error {
+	configured := map[string]Profile{}
+	defaultName := ""
+	if cm != nil {
+		for name, raw := range cm.Data {
+			if name == DefaultProfileKey {
+				defaultName = strings.TrimSpace(raw)
+				continue
+			}
+			var profile Profile
+			if err := yaml.UnmarshalStrict([]byte(raw), &profile); err != nil {
+				return fmt.Errorf("invalid profile %q: %w", name, err)
+			}
+			configured[name] = profile
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for name, profile := range configured {
+		if builtin, ok := r.builtin[name]; ok {
+			configured[name] = builtin.Merge(profile)
+		}
+	}
+	if _, known := configured[defaultName]; defaultName != "" && !known {
+		if _, known = r.builtin[defaultName]; !known {
+			return fmt.Errorf("unknown default profile %q", defaultName)
+		}
+	}
+	r.configured = configured
+	r.configuredDefault = defaultName
+	return nil
+}
+
+func (r *Registry) defaultName() string {
+	if r.configuredDefault != "" {
+		return r.configuredDefault
+	}
+	return Medium
+}
+
+func (r *Registry) names() []string {
+	names := make([]string, 0, len(r.builtin)+len(r.configured))
+	for name := range r.builtin {
+		names = append(names, name)
+	}
+	for name := range r.configured {
+		if _, ok := r.builtin[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetupDynamicProfiles watches the profiles ConfigMap in the system namespace
+// and updates the registry when it changes. Invalid ConfigMaps are logged and ignored
+func SetupDynamicProfiles(registry *Registry, configMapWatcher configmap.DefaultingWatcher, logger *zap.SugaredLogger) {
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	dftCm := &corev1.ConfigMap{}
+	dftCm.Name = ConfigMapName()
+	dftCm.Namespace = system.Namespace()
+
+	w := kconfigmap.NewWatcher("config-profiles-store", configMapWatcher).WithLogger(logger)
+	w.AddWatch(dftCm.GetName(), kconfigmap.NewConfigConstructor(dftCm, func(cm *corev1.ConfigMap) {
+		if err := registry.SetFromConfigMap(cm); err != nil {
+			logger.Errorw("invalid profiles configmap", "configmap", cm.GetName(), "err", err)
+		}
+	}))
+	w.Run()
+}
+
+// WithRegistry stores the registry into the context
+func WithRegistry(ctx context.Context, registry *Registry) context.Context {
+	return ctxutil.With(ctx, registry)
+}
+
+// GetRegistry returns the registry stored in the context, or nil
+func GetRegistry(ctx context.Context) *Registry {
+	registry, _ := ctxutil.From[*Registry](ctx)
+	return registry
+}
+
+// Apply defaults the settings of the profile with name in the template using the registry
+// stored in the context, or the built-in profiles when there is none
+func Apply(ctx context.Context, template *corev1.PodTemplateSpec, name string) error {
+	registry := GetRegistry(ctx)
+	if registry == nil {
+		registry = New()
+	}
+	return registry.Apply(template, name)
+}
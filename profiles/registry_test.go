@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiles
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRegistry_SetFromConfigMap(t *testing.T) {
+	var data = []struct {
+		desc    string
+		data    map[string]string
+		wantErr string
+	}{
+		{"empty", nil, ""},
+		{"override and add", map[string]string{
+			"small":           "resources:\n  limits:\n    memory: 300Mi\n",
+			"gpu":             "tolerations:\n- key: nvidia.com/gpu\n  operator: Exists\n",
+			DefaultProfileKey: "small",
+		}, ""},
+		{"invalid profile", map[string]string{"small": "resources: [1]"}, `invalid profile "small"`},
+		{"unknown field", map[string]string{"small": "cpu: 1"}, `invalid profile "small"`},
+		{"unknown default", map[string]string{DefaultProfileKey: "huge"}, `unknown default profile "huge"`},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			registry := New()
+			err := registry.SetFromConfigMap(&corev1.ConfigMap{Data: item.data})
+			if item.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(item.wantErr)))
+				g.Expect(registry.Names()).To(Equal([]string{"large", "medium", "small"}))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestRegistry_Get(t *testing.T) {
+	g := NewGomegaWithT(t)
+	registry := New()
+
+	profile, err := registry.Get("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(profile.Resources.Limits.Memory().String()).To(Equal("512Mi"))
+
+	_, err = registry.Get("gpu")
+	g.Expect(err).To(MatchError(`unknown profile "gpu", known profiles are: large, medium, small`))
+
+	g.Expect(registry.SetFromConfigMap(&corev1.ConfigMap{Data: map[string]string{
+		"small":           "resources:\n  limits:\n    memory: 300Mi\n",
+		"gpu":             "tolerations:\n- key: nvidia.com/gpu\n  operator: Exists\n",
+		DefaultProfileKey: "small",
+	}})).To(Succeed())
+	g.Expect(registry.Names()).To(Equal([]string{"gpu", "large", "medium", "small"}))
+
+	// configured profiles are merged over the built-in profile
+	profile, err = registry.Get("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(profile.Resources.Limits.Memory().String()).To(Equal("300Mi"))
+	g.Expect(profile.Resources.Limits.Cpu().String()).To(Equal("500m"))
+
+	profile, err = registry.Get("gpu")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(profile.Tolerations).To(HaveLen(1))
+	g.Expect(profile.Resources.Limits).To(BeNil())
+
+	// removed profiles return to the built-in value
+	g.Expect(registry.SetFromConfigMap(nil)).To(Succeed())
+	profile, _ = registry.Get(Small)
+	g.Expect(profile.Resources.Limits.Memory().String()).To(Equal("256Mi"))
+	_, err = registry.Get("gpu")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestApply(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	template := newTemplate(corev1.Container{Name: "app"})
+	g.Expect(Apply(ctx, template, "")).To(Succeed())
+	g.Expect(template.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("250m"))
+
+	registry := New()
+	g.Expect(registry.SetFromConfigMap(&corev1.ConfigMap{Data: map[string]string{DefaultProfileKey: Large}})).To(Succeed())
+	ctx = WithRegistry(ctx, registry)
+	g.Expect(GetRegistry(ctx)).To(BeIdenticalTo(registry))
+
+	template = newTemplate(corev1.Container{Name: "app"})
+	g.Expect(Apply(ctx, template, "")).To(Succeed())
+	g.Expect(template.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("500m"))
+	g.Expect(Apply(ctx, template, "unknown")).To(HaveOccurred())
+}
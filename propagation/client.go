@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PropagateObject propagates the labels and annotations of parent into child
+// and persists the change using a merge patch. Returns true if child was changed.
+func (p *Propagator) PropagateObject(ctx context.Context, clt client.Client, parent, child client.Object) (bool, error) {
+	base := child.DeepCopyObject().(client.Object)
+	changed, err := p.propagate(parent, child)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err = clt.Patch(ctx, child, client.MergeFrom(base)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PropagateChildren propagates the labels and annotations of parent into the objects
+// of list controlled by parent, listed in the namespace of parent using opts.
+// Returns the number of children changed, failing children do not stop the others.
+func (p *Propagator) PropagateChildren(ctx context.Context, clt client.Client, parent client.Object, list client.ObjectList, opts ...client.ListOption) (changed int, err error) {
+	if parent.GetNamespace() != "" {
+		opts = append([]client.ListOption{client.InNamespace(parent.GetNamespace())}, opts...)
+	}
+	if err = clt.List(ctx, list, opts...); err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	err = meta.EachListItem(list, func(item runtime.Object) error {
+		child, ok := item.(client.Object)
+		if !ok || !metav1.IsControlledBy(child, parent) {
+			return nil
+		}
+		ok, err := p.PropagateObject(ctx, clt, parent, child)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("propagate to %s failed: %w", client.ObjectKeyFromObject(child), err))
+		} else if ok {
+			changed++
+		}
+		return nil
+	})
+	if err != nil {
+		return changed, err
+	}
+	return changed, utilerrors.NewAggregate(errs)
+}
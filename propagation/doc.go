@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package propagation copies a configurable set of labels and annotations,
+// e.g. the display name or project labels, from parent objects to the
+// objects generated from them. Keys are selected by rules matching a key or
+// a domain, deny-lists exclude keys such as tracking annotations and a
+// conflict policy decides what happens when a child already has a different
+// value.
+//
+// Reconcilers propagate before creating or applying children, or patch the
+// existing children of a parent:
+//
+//	propagator := propagation.NewPropagator(
+//		propagation.WithAnnotations(propagation.KeyRule(metav1alpha1.DisplayNameAnnotationKey)),
+//		propagation.WithLabels(propagation.DomainRule("project.alauda.io")),
+//	)
+//	err := propagator.Propagate(parent, deployment)
+//
+// The same propagator enforces the policy in a mutating webhook registered
+// for the child kinds, looking up the controller owner of the admitted object:
+//
+//	mgr.GetWebhookServer().Register(propagation.Path, propagator.Webhook(ctx, mgr.GetScheme(), mgr.GetAPIReader()))
+package propagation
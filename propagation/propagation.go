@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+// ConflictPolicy decides what happens when a child already has
+// a propagated key with a different value
+type ConflictPolicy string
+
+const (
+	// Overwrite sets the value of the parent
+	Overwrite ConflictPolicy = "Overwrite"
+	// Keep keeps the value of the child
+	Keep ConflictPolicy = "Keep"
+	// Fail returns a ConflictError
+	Fail ConflictPolicy = "Fail"
+)
+
+// Rule selects keys to propagate. When Key ends with a "/" the rule matches
+// all the keys of the domain, including its subdomains, e.g. the rule
+// "alauda.io/" matches "alauda.io/project" and "ui.alauda.io/descriptors"
+type Rule struct {
+	// Key is the key or domain matched
+	Key string
+	// Policy on conflicts, defaults to the policy of the Propagator
+	Policy ConflictPolicy
+}
+
+// KeyRule returns a rule matching a single key
+func KeyRule(key string) Rule {
+	return Rule{Key: key}
+}
+
+// DomainRule returns a rule matching the keys of a domain
+func DomainRule(domain string) Rule {
+	return Rule{Key: strings.TrimSuffix(domain, "/") + "/"}
+}
+
+// WithPolicy returns a copy of the rule using policy on conflicts
+func (r Rule) WithPolicy(policy ConflictPolicy) Rule {
+	r.Policy = policy
+	return r
+}
+
+// IsDomain returns true if the rule matches a domain
+func (r Rule) IsDomain() bool {
+	return strings.HasSuffix(r.Key, "/")
+}
+
+// Matches returns true if the key is the key of the rule or belongs to its domain
+func (r Rule) Matches(key string) bool {
+	if !r.IsDomain() {
+		return key == r.Key
+	}
+	index := strings.Index(key, "/")
+	if index < 0 {
+		return false
+	}
+	prefix := key[:index+1]
+	return prefix == r.Key || strings.HasSuffix(prefix, "."+r.Key)
+}
+
+// DefaultDeniedAnnotations are never propagated as they describe the parent itself
+var DefaultDeniedAnnotations = []Rule{
+	KeyRule(corev1.LastAppliedConfigAnnotation),
+	KeyRule(metav1alpha1.CreatedByAnnotationKey),
+	KeyRule(metav1alpha1.CreatedTimeAnnotationKey),
+	KeyRule(metav1alpha1.UpdatedByAnnotationKey),
+	KeyRule(metav1alpha1.UpdatedTimeAnnotationKey),
+	KeyRule(metav1alpha1.DeletedByAnnotationKey),
+	KeyRule(metav1alpha1.DeletedTimeAnnotationKey),
+	KeyRule(metav1alpha1.SpecHashAnnotationKey),
+	KeyRule(metav1alpha1.PausedAnnotationKey),
+	KeyRule(metav1alpha1.ExpiresAtAnnotationKey),
+}
+
+// ConflictError is returned by the Fail policy when a child has a different value
+type ConflictError struct {
+	// Type is label or annotation
+	Type string
+	// Key of the label or annotation
+	Key string
+	// Parent value
+	Parent string
+	// Child value
+	Child string
+}
+
+// Error implements error
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %q of the parent %q conflicts with the value %q of the child", e.Type, e.Key, e.Parent, e.Child)
+}
+
+// Option configures a Propagator
+type Option func(*Propagator)
+
+// WithLabels adds rules selecting the labels propagated
+func WithLabels(rules ...Rule) Option {
+	return func(p *Propagator) {
+		p.Labels = append(p.Labels, rules...)
+	}
+}
+
+// WithAnnotations adds rules selecting the annotations propagated
+func WithAnnotations(rules ...Rule) Option {
+	return func(p *Propagator) {
+		p.Annotations = append(p.Annotations, rules...)
+	}
+}
+
+// WithDeniedLabels adds rules of labels never propagated
+func WithDeniedLabels(rules ...Rule) Option {
+	return func(p *Propagator) {
+		p.DeniedLabels = append(p.DeniedLabels, rules...)
+	}
+}
+
+// WithDeniedAnnotations adds rules of annotations never propagated,
+// in addition to DefaultDeniedAnnotations
+func WithDeniedAnnotations(rules ...Rule) Option {
+	return func(p *Propagator) {
+		p.DeniedAnnotations = append(p.DeniedAnnotations, rules...)
+	}
+}
+
+// WithConflictPolicy sets the policy of rules without a policy, defaults to Overwrite
+func WithConflictPolicy(policy ConflictPolicy) Option {
+	return func(p *Propagator) {
+		p.Policy = policy
+	}
+}
+
+// Propagator copies labels and annotations from parents to children
+type Propagator struct {
+	// Labels rules selecting the labels propagated, first match wins
+	Labels []Rule
+	// Annotations rules selecting the annotations propagated, first match wins
+	Annotations []Rule
+	// DeniedLabels rules of labels never propagated
+	DeniedLabels []Rule
+	// DeniedAnnotations rules of annotations never propagated
+	DeniedAnnotations []Rule
+	// Policy of rules without a policy
+	Policy ConflictPolicy
+}
+
+// NewPropagator returns a Propagator with options
+func NewPropagator(opts ...Option) *Propagator {
+	p := &Propagator{
+		DeniedAnnotations: append([]Rule{}, DefaultDeniedAnnotations...),
+		Policy:            Overwrite,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Propagate copies the selected labels and annotations of parent into child.
+// Keys removed from the parent are not removed from the child.
+// Returns a ConflictError when a key with the Fail policy has a different value,
+// in which case child is not changed.
+func (p *Propagator) Propagate(parent, child metav1.Object) error {
+	_, err := p.propagate(parent, child)
+	return err
+}
+
+// NeedsPropagation returns true if propagating would change the child
+func (p *Propagator) NeedsPropagation(parent, child metav1.Object) bool {
+	labels, err := p.merge("label", parent.GetLabels(), child.GetLabels(), p.Labels, p.DeniedLabels)
+	if err == nil && labels != nil {
+		return true
+	}
+	annotations, err := p.merge("annotation", parent.GetAnnotations(), child.GetAnnotations(), p.Annotations, p.DeniedAnnotations)
+	return err == nil && annotations != nil
+}
+
+func (p *Propagator) propagate(parent, child metav1.Object) (changed bool, err error) {
+	labels, err := p.merge("label", parent.GetLabels(), child.GetLabels(), p.Labels, p.DeniedLabels)
+	if err != nil {
+		return false, err
+	}
+	annotations, err := p.merge("annotation", parent.GetAnnotations(), child.GetAnnotations(), p.Annotations, p.DeniedAnnotations)
+	if err != nil {
+		return false, err
+	}
+	if labels != nil {
+		child.SetLabels(labels)
+		changed = true
+	}
+	if annotations != nil {
+		child.SetAnnotations(annotations)
+		changed = true
+	}
+	return
+}
+
+// merge returns a copy of child with the selected keys of parent,
+// or nil when there is no change
+func (p *Propagator) merge(kind string, parent, child map[string]string, rules, denied []Rule) (map[string]string, error) {
+	var keys []string
+	for _, key := range sortedKeys(parent) {
+		rule, ok := match(key, rules)
+		if !ok {
+			continue
+		}
+		if _, ok := match(key, denied); ok {
+			continue
+		}
+		value := parent[key]
+		current, exists := child[key]
+		if exists && current == value {
+			continue
+		}
+		if exists {
+			policy := rule.Policy
+			if policy == "" {
+				policy = p.Policy
+			}
+			switch policy {
+			case Keep:
+				continue
+			case Fail:
+				return nil, &ConflictError{Type: kind, Key: key, Parent: value, Child: current}
+			}
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(child)+len(keys))
+	for key, value := range child {
+		result[key] = value
+	}
+	for _, key := range keys {
+		result[key] = parent[key]
+	}
+	return result, nil
+}
+
+func match(key string, rules []Rule) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.Matches(key) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func sortedKeys(kv map[string]string) []string {
+	keys := make([]string, 0, len(kv))
+	for key := range kv {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metav1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+)
+
+func newPropagator(opts ...Option) *Propagator {
+	return NewPropagator(append([]Option{
+		WithAnnotations(KeyRule(metav1alpha1.DisplayNameAnnotationKey), DomainRule("cpaas.io")),
+		WithLabels(DomainRule("project.alauda.io")),
+		WithDeniedLabels(KeyRule("project.alauda.io/internal")),
+	}, opts...)...)
+}
+
+func newParent() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "parent",
+			Namespace: "default",
+			UID:       "parent-uid",
+			Labels: map[string]string{
+				"project.alauda.io/name":     "demo",
+				"project.alauda.io/internal": "true",
+				"app":                        "parent",
+			},
+			Annotations: map[string]string{
+				metav1alpha1.DisplayNameAnnotationKey: "Parent",
+				metav1alpha1.CreatedByAnnotationKey:   `{"user":{"name":"admin"}}`,
+				"cpaas.io/description":                "description",
+			},
+		},
+	}
+}
+
+func newChild(name string, parent metav1.Object) *corev1.ConfigMap {
+	child := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "child"}},
+	}
+	if parent != nil {
+		child.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "v1", Kind: "ConfigMap", Name: parent.GetName(), UID: parent.GetUID(), Controller: ptr.To(true),
+		}}
+	}
+	return child
+}
+
+func TestRule_Matches(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(KeyRule("cpaas.io/displayName").Matches("cpaas.io/displayName")).To(BeTrue())
+	g.Expect(KeyRule("cpaas.io/displayName").Matches("cpaas.io/other")).To(BeFalse())
+	g.Expect(DomainRule("alauda.io").Matches("alauda.io/project")).To(BeTrue())
+	g.Expect(DomainRule("alauda.io/").Matches("ui.alauda.io/descriptors")).To(BeTrue())
+	g.Expect(DomainRule("alauda.io").Matches("xalauda.io/project")).To(BeFalse())
+	g.Expect(DomainRule("alauda.io").Matches("project")).To(BeFalse())
+}
+
+func TestPropagator_Propagate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	parent := newParent()
+	child := newChild("child", nil)
+	child.Annotations = map[string]string{"cpaas.io/description": "own"}
+	p := newPropagator()
+
+	g.Expect(p.NeedsPropagation(parent, child)).To(BeTrue())
+	g.Expect(p.Propagate(parent, child)).To(Succeed())
+	g.Expect(child.Labels).To(Equal(map[string]string{"app": "child", "project.alauda.io/name": "demo"}))
+	g.Expect(child.Annotations).To(Equal(map[string]string{
+		metav1alpha1.DisplayNameAnnotationKey: "Parent",
+		"cpaas.io/description":                "description",
+	}))
+	g.Expect(p.NeedsPropagation(parent, child)).To(BeFalse())
+}
+
+func TestPropagator_Propagate_conflicts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	parent := newParent()
+
+	child := newChild("child", nil)
+	child.Annotations = map[string]string{"cpaas.io/description": "own"}
+	g.Expect(newPropagator(WithConflictPolicy(Keep)).Propagate(parent, child)).To(Succeed())
+	g.Expect(child.Annotations).To(HaveKeyWithValue("cpaas.io/description", "own"))
+	g.Expect(child.Annotations).To(HaveKeyWithValue(metav1alpha1.DisplayNameAnnotationKey, "Parent"))
+
+	child = newChild("child", nil)
+	child.Annotations = map[string]string{"cpaas.io/description": "own"}
+	err := newPropagator(WithConflictPolicy(Fail)).Propagate(parent, child)
+	g.Expect(err).To(MatchError(`annotation "cpaas.io/description" of the parent "description" conflicts with the value "own" of the child`))
+	g.Expect(child.Annotations).To(Equal(map[string]string{"cpaas.io/description": "own"}))
+	g.Expect(child.Labels).NotTo(HaveKey("project.alauda.io/name"))
+
+	// the policy of the rule wins over the default policy
+	p := NewPropagator(WithConflictPolicy(Fail), WithLabels(KeyRule("app").WithPolicy(Overwrite)))
+	g.Expect(p.Propagate(parent, child)).To(Succeed())
+	g.Expect(child.Labels).To(HaveKeyWithValue("app", "parent"))
+}
+
+func TestPropagator_PropagateChildren(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	parent := newParent()
+	other := newParent()
+	other.Name, other.UID = "other", "other-uid"
+	clt := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		parent, newChild("a", parent), newChild("b", parent), newChild("c", other), newChild("d", nil),
+	).Build()
+	p := newPropagator()
+
+	changed, err := p.PropagateChildren(ctx, clt, parent, &corev1.ConfigMapList{})
+	g.Expect(err).To(BeNil())
+	g.Expect(changed).To(Equal(2))
+
+	for name, propagated := range map[string]bool{"a": true, "b": true, "c": false, "d": false} {
+		cm := &corev1.ConfigMap{}
+		g.Expect(clt.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm)).To(Succeed())
+		if propagated {
+			g.Expect(cm.Annotations).To(HaveKeyWithValue(metav1alpha1.DisplayNameAnnotationKey, "Parent"), name)
+		} else {
+			g.Expect(cm.Annotations).NotTo(HaveKey(metav1alpha1.DisplayNameAnnotationKey), name)
+		}
+	}
+
+	changed, err = p.PropagateChildren(ctx, clt, parent, &corev1.ConfigMapList{})
+	g.Expect(err).To(BeNil())
+	g.Expect(changed).To(Equal(0))
+}
+
+func request(obj runtime.Object) admission.Request {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "uid",
+		Name:      "child",
+		Namespace: "default",
+		Operation: admissionv1.Create,
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Object:    runtime.RawExtension{Raw: raw},
+	}}
+}
+
+func TestPropagator_Webhook(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	parent := newParent()
+	clt := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(parent).Build()
+	hook := newPropagator().Webhook(ctx, clientgoscheme.Scheme, clt)
+
+	child := newChild("child", parent)
+	child.Namespace = ""
+	resp := hook.Handle(ctx, request(child))
+	g.Expect(resp.Allowed).To(BeTrue())
+	paths := []string{}
+	for _, patch := range resp.Patches {
+		paths = append(paths, patch.Path)
+	}
+	g.Expect(paths).To(ConsistOf("/metadata/annotations", "/metadata/labels/project.alauda.io~1name"))
+
+	// objects without owner or with a missing owner are not changed
+	resp = hook.Handle(ctx, request(newChild("child", nil)))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Patches).To(BeEmpty())
+	missing := newParent()
+	missing.Name = "missing"
+	resp = hook.Handle(ctx, request(newChild("child", missing)))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Patches).To(BeEmpty())
+
+	// conflicts of the fail policy are denied
+	hook = newPropagator(WithConflictPolicy(Fail)).Webhook(ctx, clientgoscheme.Scheme, clt)
+	child = newChild("child", parent)
+	child.Annotations = map[string]string{metav1alpha1.DisplayNameAnnotationKey: "Child"}
+	resp = hook.Handle(ctx, request(child))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("conflicts with the value"))
+}
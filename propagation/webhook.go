@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/AlaudaDevops/pkg/webhook"
+	kadmission "github.com/AlaudaDevops/pkg/webhook/admission"
+)
+
+// Path of the propagation webhook, registered for all the child kinds
+const Path = "/mutate-propagation"
+
+// Defaulter returns a webhook.Defaulter propagating the labels and annotations of the
+// controller owner of any object, read using reader. Objects without a controller owner
+// or whose owner does not exist are not changed, conflicts of the Fail policy are denied.
+func (p *Propagator) Defaulter(reader client.Reader) webhook.Defaulter[*unstructured.Unstructured] {
+	return webhook.DefaulterFunc[*unstructured.Unstructured](func(ctx context.Context, obj *unstructured.Unstructured) error {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil {
+			return nil
+		}
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = kadmission.AdmissionRequest(ctx).Namespace
+		}
+
+		parent := &metav1.PartialObjectMetadata{}
+		parent.SetGroupVersionKind(schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind))
+		err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, parent)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get owner %s %s failed: %w", owner.Kind, owner.Name, err)
+		}
+		if parent.GetUID() != owner.UID {
+			return nil
+		}
+		changed, err := p.propagate(parent, obj)
+		if changed {
+			logging.FromContext(ctx).Debugw("propagated labels and annotations", "owner", owner.Name, "kind", owner.Kind)
+		}
+		return err
+	})
+}
+
+// Webhook returns a mutating webhook propagating labels and annotations into objects of any kind,
+// it should be registered with Path and configured for the kinds of the children
+func (p *Propagator) Webhook(ctx context.Context, scheme *runtime.Scheme, reader client.Reader) *admission.Webhook {
+	return webhook.DefaultingWebhookFor(ctx, scheme, func() *unstructured.Unstructured { return &unstructured.Unstructured{} }, p.Defaulter(reader))
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render abstracts the sources of manifests: plain files and directories,
+// kustomize overlays and Helm charts all render into the same objects consumed by
+// applyset, the diff command and the testing fixture loader, so consumers do not
+// care how manifests were authored.
+//
+//	objs, err := render.Render(ctx,
+//		render.Detect("deploy/base"),
+//		render.Kustomize("deploy/overlays/prod"),
+//		&render.Helm{Chart: "charts/app", ReleaseName: "app", ValuesFiles: []string{"values-prod.yaml"}},
+//	)
+package render
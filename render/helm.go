@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+)
+
+const (
+	// HelmChartFile is the file identifying a Helm chart directory
+	HelmChartFile = "Chart.yaml"
+	// DefaultHelmBinary is the helm executable looked up in PATH when none is given
+	DefaultHelmBinary = "helm"
+	// DefaultReleaseName is the release name used when none is given
+	DefaultReleaseName = "release"
+)
+
+// Helm is a Source rendering a chart with helm template, so the helm cli must be installed
+type Helm struct {
+	// Chart is a chart directory, archive, repo/name reference or url
+	Chart string
+	// Version of the chart, latest when empty
+	Version string
+	// ReleaseName of the rendered release, defaults to DefaultReleaseName
+	ReleaseName string
+	// Namespace of the release, the helm default when empty
+	Namespace string
+	// ValuesFiles are values files applied in order
+	ValuesFiles []string
+	// Values applied after ValuesFiles
+	Values map[string]any
+	// IncludeCRDs renders the crds of the chart too
+	IncludeCRDs bool
+	// Binary is the helm executable, defaults to DefaultHelmBinary
+	Binary string
+}
+
+var _ Source = &Helm{}
+
+// Render implements Source
+func (h *Helm) Render(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	args, cleanup, err := h.args()
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+	binary := h.Binary
+	if binary == "" {
+		binary = DefaultHelmBinary
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("render helm chart %s failed: %w: %s", h.Chart, err, strings.TrimSpace(stderr.String()))
+	}
+	return applyset.ReadManifests(stdout)
+}
+
+// args returns the helm template arguments, cleanup removes the temporary values file
+func (h *Helm) args() (args []string, cleanup func(), err error) {
+	cleanup = func() {}
+	releaseName := h.ReleaseName
+	if releaseName == "" {
+		releaseName = DefaultReleaseName
+	}
+	args = []string{"template", releaseName, h.Chart}
+	if h.Version != "" {
+		args = append(args, "--version", h.Version)
+	}
+	if h.Namespace != "" {
+		args = append(args, "--namespace", h.Namespace)
+	}
+	if h.IncludeCRDs {
+		args = append(args, "--include-crds")
+	}
+	for _, file := range h.ValuesFiles {
+		args = append(args, "--values", file)
+	}
+	if len(h.Values) > 0 {
+		content, err := yaml.Marshal(h.Values)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("encode helm values failed: %w", err)
+		}
+		file, err := os.CreateTemp("", "values-*.yaml")
+		if err != nil {
+			return nil, cleanup, err
+		}
+		cleanup = func() { _ = os.Remove(file.Name()) }
+		_, err = file.Write(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, cleanup, err
+		}
+		args = append(args, "--values", file.Name())
+	}
+	return args, cleanup, nil
+}
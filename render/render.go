@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/AlaudaDevops/pkg/applyset"
+)
+
+// Source of manifests
+type Source interface {
+	// Render returns the objects of the source
+	Render(ctx context.Context) ([]*unstructured.Unstructured, error)
+}
+
+// SourceFunc is a function implementing Source
+type SourceFunc func(ctx context.Context) ([]*unstructured.Unstructured, error)
+
+// Render implements Source
+func (f SourceFunc) Render(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	return f(ctx)
+}
+
+// Render returns the objects of all sources in order
+func Render(ctx context.Context, sources ...Source) (objs []*unstructured.Unstructured, err error) {
+	for _, source := range sources {
+		rendered, err := source.Render(ctx)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rendered...)
+	}
+	return objs, nil
+}
+
+// Detect returns the Source of path: Kustomize for directories with a kustomization file,
+// a Helm chart without values for directories with a Chart.yaml and Files otherwise.
+// - is read from stdin
+func Detect(path string) Source {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return Files(path)
+	}
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if fileExists(filepath.Join(path, name)) {
+			return Kustomize(path)
+		}
+	}
+	if fileExists(filepath.Join(path, HelmChartFile)) {
+		return &Helm{Chart: path}
+	}
+	return Files(path)
+}
+
+// DetectAll returns the Source of every path, see Detect
+func DetectAll(paths ...string) []Source {
+	sources := make([]Source, 0, len(paths))
+	for _, path := range paths {
+		sources = append(sources, Detect(path))
+	}
+	return sources
+}
+
+// Files returns a Source loading yaml or json files and directories, see applyset.LoadManifests
+func Files(paths ...string) Source {
+	return SourceFunc(func(context.Context) ([]*unstructured.Unstructured, error) {
+		return applyset.LoadManifests(paths...)
+	})
+}
+
+// Kustomize returns a Source building the kustomization in dir with the default
+// kustomize options, like kubectl kustomize
+func Kustomize(dir string) Source {
+	return SourceFunc(func(context.Context) ([]*unstructured.Unstructured, error) {
+		kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+		resources, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+		if err != nil {
+			return nil, fmt.Errorf("build kustomization %s failed: %w", dir, err)
+		}
+		content, err := resources.AsYaml()
+		if err != nil {
+			return nil, fmt.Errorf("encode kustomization %s failed: %w", dir, err)
+		}
+		return applyset.ReadManifests(bytes.NewReader(content))
+	})
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeHelm writes an executable printing its arguments and the values files
+// into a ConfigMap, returning its path
+func fakeHelm(t *testing.T) string {
+	binary := filepath.Join(t.TempDir(), "helm")
+	script := `#!/bin/sh
+echo "apiVersion: v1"
+echo "kind: ConfigMap"
+echo "metadata:"
+echo "  name: $2"
+echo "data:"
+echo "  args: \"$*\""
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--values" ]; then
+    echo "  values: |"
+    sed 's/^/    /' "$2"
+  fi
+  shift
+done
+`
+	if err := os.WriteFile(binary, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return binary
+}
+
+func TestRender(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	objs, err := Render(ctx, Detect("testdata/files"), Detect("testdata/overlay"), Files("testdata/base/configmap.yaml"))
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(3))
+	g.Expect(objs[0].GetName()).To(Equal("files"))
+	g.Expect(objs[1].GetName()).To(Equal("prod-config"))
+	g.Expect(objs[1].GetNamespace()).To(Equal("prod"))
+	g.Expect(objs[1].Object["data"]).To(Equal(map[string]interface{}{"env": "prod"}))
+	g.Expect(objs[2].GetName()).To(Equal("config"))
+
+	_, err = Render(ctx, Kustomize("testdata/files"))
+	g.Expect(err).To(MatchError(ContainSubstring("build kustomization testdata/files failed")))
+}
+
+func TestDetect(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Detect("testdata/chart")).To(Equal(&Helm{Chart: "testdata/chart"}))
+	g.Expect(DetectAll("testdata/chart", "testdata/files")).To(HaveLen(2))
+}
+
+func TestHelm(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	helm := &Helm{
+		Chart:       "testdata/chart",
+		ReleaseName: "app",
+		Namespace:   "apps",
+		Version:     "0.1.0",
+		IncludeCRDs: true,
+		Values:      map[string]any{"replicas": 3},
+		Binary:      fakeHelm(t),
+	}
+	objs, err := helm.Render(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(1))
+	g.Expect(objs[0].GetName()).To(Equal("app"))
+	data := objs[0].Object["data"].(map[string]interface{})
+	g.Expect(data["args"]).To(HavePrefix("template app testdata/chart --version 0.1.0 --namespace apps --include-crds --values "))
+	g.Expect(data["values"]).To(Equal("replicas: 3\n"))
+
+	// the temporary values file is removed
+	values := strings.TrimSpace(data["args"].(string)[strings.LastIndex(data["args"].(string), " "):])
+	_, err = os.Stat(values)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	helm.Binary = filepath.Join(t.TempDir(), "not-found")
+	_, err = helm.Render(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("render helm chart testdata/chart failed")))
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+// ErrOpen is returned when a call is rejected by an open circuit breaker
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a circuit breaker
+type State string
+
+const (
+	// StateClosed calls are allowed and failures are counted
+	StateClosed State = "closed"
+	// StateOpen calls are rejected until the open timeout ends
+	StateOpen State = "open"
+	// StateHalfOpen a limited number of probe calls decide if the breaker closes or opens again
+	StateHalfOpen State = "half-open"
+)
+
+// BreakerPolicy configures when a Breaker opens and how it probes before closing
+type BreakerPolicy struct {
+	// FailureThreshold consecutive failures opening the breaker, defaults to 5
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before probing, defaults to 30s
+	OpenTimeout time.Duration
+	// HalfOpenProbes concurrent probe calls allowed when half-open, defaults to 1
+	HalfOpenProbes int
+	// SuccessThreshold successful probes closing the breaker, defaults to 1
+	SuccessThreshold int
+	// IsFailure returns true if the error of a call counts as a failure, defaults to any error
+	IsFailure func(err error) bool
+}
+
+// DefaultBreakerPolicy returns the policy used for zero fields
+func DefaultBreakerPolicy() BreakerPolicy {
+	return BreakerPolicy{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenProbes:   1,
+		SuccessThreshold: 1,
+	}
+}
+
+func (p BreakerPolicy) withDefaults() BreakerPolicy {
+	defaults := DefaultBreakerPolicy()
+	if p.FailureThreshold <= 0 {
+		p.FailureThreshold = defaults.FailureThreshold
+	}
+	if p.OpenTimeout <= 0 {
+		p.OpenTimeout = defaults.OpenTimeout
+	}
+	if p.HalfOpenProbes <= 0 {
+		p.HalfOpenProbes = defaults.HalfOpenProbes
+	}
+	if p.SuccessThreshold <= 0 {
+		p.SuccessThreshold = defaults.SuccessThreshold
+	}
+	if p.IsFailure == nil {
+		p.IsFailure = func(err error) bool { return err != nil }
+	}
+	return p
+}
+
+// Breaker is a circuit breaker safe for concurrent use
+type Breaker struct {
+	name          string
+	policy        BreakerPolicy
+	clock         clock.PassiveClock
+	onStateChange func(name string, from, to State)
+
+	lock      sync.Mutex
+	state     State
+	failures  int
+	successes int
+	probes    int
+	openedAt  time.Time
+	// generation changes with the state, results of calls allowed in other generations are ignored
+	generation uint64
+}
+
+// BreakerOption configures a Breaker
+type BreakerOption func(*Breaker)
+
+// WithBreakerClock sets the clock used to end the open timeout
+func WithBreakerClock(clock clock.PassiveClock) BreakerOption {
+	return func(b *Breaker) {
+		b.clock = clock
+	}
+}
+
+// WithStateChange calls fn on every state change, fn is called holding the breaker lock
+// so it must not use the breaker
+func WithStateChange(fn func(name string, from, to State)) BreakerOption {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
+
+// NewBreaker returns a closed Breaker, name identifies it in metrics, e.g. the host it protects
+func NewBreaker(name string, policy BreakerPolicy, opts ...BreakerOption) *Breaker {
+	b := &Breaker{
+		name:   name,
+		policy: policy.withDefaults(),
+		clock:  clock.RealClock{},
+		state:  StateClosed,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	BreakerState.WithLabelValues(name).Set(stateValue(StateClosed))
+	return b
+}
+
+// Name returns the name of the breaker
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state, an open breaker whose timeout ended is reported half-open
+func (b *Breaker) State() State {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.state == StateOpen && b.openTimeoutEnded() {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Allow returns ErrOpen if the call is rejected, otherwise done must be called with the
+// error of the call, or nil when it succeeded
+func (b *Breaker) Allow() (done func(err error), err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.state == StateOpen {
+		if !b.openTimeoutEnded() {
+			BreakerRejected.WithLabelValues(b.name).Inc()
+			return nil, ErrOpen
+		}
+		b.setState(StateHalfOpen)
+	}
+	if b.state == StateHalfOpen {
+		if b.probes >= b.policy.HalfOpenProbes {
+			BreakerRejected.WithLabelValues(b.name).Inc()
+			return nil, ErrOpen
+		}
+		b.probes++
+	}
+	generation := b.generation
+	return func(err error) { b.done(generation, err) }, nil
+}
+
+// Do calls fn unless the breaker is open, recording its result
+func (b *Breaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn(ctx)
+	done(err)
+	return err
+}
+
+func (b *Breaker) done(generation uint64, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if generation != b.generation {
+		return
+	}
+	failed := b.policy.IsFailure(err)
+	switch b.state {
+	case StateClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.policy.FailureThreshold {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		b.probes--
+		if failed {
+			b.setState(StateOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.policy.SuccessThreshold {
+			b.setState(StateClosed)
+		}
+	}
+}
+
+func (b *Breaker) openTimeoutEnded() bool {
+	return b.clock.Since(b.openedAt) >= b.policy.OpenTimeout
+}
+
+// setState changes the state resetting the counters, the lock must be held
+func (b *Breaker) setState(state State) {
+	from := b.state
+	b.state = state
+	b.failures, b.successes, b.probes = 0, 0, 0
+	b.generation++
+	if state == StateOpen {
+		b.openedAt = b.clock.Now()
+	}
+	BreakerState.WithLabelValues(b.name).Set(stateValue(state))
+	if b.onStateChange != nil {
+		b.onStateChange(b.name, from, state)
+	}
+}
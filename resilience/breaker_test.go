@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+var errFailed = errors.New("failed")
+
+func TestBreaker(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	var transitions []State
+	breaker := NewBreaker("breaker-test", BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Minute, SuccessThreshold: 2},
+		WithBreakerClock(fakeClock),
+		WithStateChange(func(_ string, _, to State) { transitions = append(transitions, to) }),
+	)
+	fail := func(context.Context) error { return errFailed }
+	succeed := func(context.Context) error { return nil }
+
+	// successes reset the consecutive failures
+	g.Expect(breaker.Do(ctx, fail)).To(MatchError(errFailed))
+	g.Expect(breaker.Do(ctx, succeed)).To(Succeed())
+	g.Expect(breaker.Do(ctx, fail)).To(MatchError(errFailed))
+	g.Expect(breaker.State()).To(Equal(StateClosed))
+	g.Expect(breaker.Do(ctx, fail)).To(MatchError(errFailed))
+	g.Expect(breaker.State()).To(Equal(StateOpen))
+	g.Expect(breaker.Do(ctx, succeed)).To(MatchError(ErrOpen))
+	g.Expect(testutil.ToFloat64(BreakerState.WithLabelValues("breaker-test"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(BreakerRejected.WithLabelValues("breaker-test"))).To(Equal(1.0))
+
+	// a failed probe opens the breaker again
+	fakeClock.Advance(time.Minute)
+	g.Expect(breaker.State()).To(Equal(StateHalfOpen))
+	g.Expect(breaker.Do(ctx, fail)).To(MatchError(errFailed))
+	g.Expect(breaker.State()).To(Equal(StateOpen))
+
+	// only one probe at a time is allowed and two successful probes close the breaker
+	fakeClock.Advance(time.Minute)
+	done, err := breaker.Allow()
+	g.Expect(err).To(BeNil())
+	_, err = breaker.Allow()
+	g.Expect(err).To(MatchError(ErrOpen))
+	done(nil)
+	g.Expect(breaker.State()).To(Equal(StateHalfOpen))
+	g.Expect(breaker.Do(ctx, succeed)).To(Succeed())
+	g.Expect(breaker.State()).To(Equal(StateClosed))
+	g.Expect(testutil.ToFloat64(BreakerState.WithLabelValues("breaker-test"))).To(Equal(0.0))
+
+	g.Expect(transitions).To(Equal([]State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}))
+}
+
+func TestBreaker_staleResults(t *testing.T) {
+	g := NewGomegaWithT(t)
+	breaker := NewBreaker("breaker-stale-test", BreakerPolicy{FailureThreshold: 1})
+
+	slow, err := breaker.Allow()
+	g.Expect(err).To(BeNil())
+	done, err := breaker.Allow()
+	g.Expect(err).To(BeNil())
+	done(errFailed)
+	g.Expect(breaker.State()).To(Equal(StateOpen))
+
+	// results of calls allowed before the breaker opened are ignored
+	slow(nil)
+	g.Expect(breaker.State()).To(Equal(StateOpen))
+}
+
+func TestBreaker_isFailure(t *testing.T) {
+	g := NewGomegaWithT(t)
+	breaker := NewBreaker("breaker-failure-test", BreakerPolicy{
+		FailureThreshold: 1,
+		IsFailure:        func(err error) bool { return errors.Is(err, errFailed) },
+	})
+
+	g.Expect(breaker.Do(context.Background(), func(context.Context) error { return context.Canceled })).To(MatchError(context.Canceled))
+	g.Expect(breaker.State()).To(Equal(StateClosed))
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resilience protects callers from slow or failing external systems with
+// circuit breakers and client-side rate limiting keyed by host.
+//
+// A Breaker opens after consecutive failures, failing fast with ErrOpen instead of
+// waiting for the external system, and lets a limited number of probe calls through
+// once half-open to decide if it closes again. Hosts keeps a breaker and a rate limiter
+// per host, which NewTransport applies to every request:
+//
+//	breaker := resilience.DefaultBreakerPolicy()
+//	hosts := resilience.NewHosts(resilience.Policy{
+//		Breaker: &breaker,
+//		QPS:     20,
+//		Burst:   40,
+//	}, resilience.WithHostPolicy("registry.example.com", resilience.Policy{QPS: 5, Burst: 5}))
+//	clt, err := http.NewClient(http.WithResilience(hosts))
+//
+// Breaker states, rejected calls and rate limiting delays are observed in the metrics
+// registered in the controller-runtime registry.
+package resilience
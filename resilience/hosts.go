@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/AlaudaDevops/pkg/retry"
+)
+
+// Policy of the calls to a host
+type Policy struct {
+	// Breaker opens the circuit of the host after consecutive failures, nil disables it.
+	// Unless set in the policy, failures are network errors and 429 or 5xx responses
+	Breaker *BreakerPolicy
+	// QPS limits the requests per second sent to the host, zero disables rate limiting
+	QPS float64
+	// Burst of requests allowed above QPS, defaults to QPS rounded up
+	Burst int
+}
+
+// HostsOption configures Hosts
+type HostsOption func(*Hosts)
+
+// WithHostPolicy uses policy for host instead of the default policy
+func WithHostPolicy(host string, policy Policy) HostsOption {
+	return func(h *Hosts) {
+		h.hostPolicies[host] = policy
+	}
+}
+
+// WithBreakerOptions configures the breakers created for hosts
+func WithBreakerOptions(opts ...BreakerOption) HostsOption {
+	return func(h *Hosts) {
+		h.breakerOptions = append(h.breakerOptions, opts...)
+	}
+}
+
+// Hosts keeps a circuit breaker and a rate limiter per host, created on first use
+type Hosts struct {
+	policy         Policy
+	hostPolicies   map[string]Policy
+	breakerOptions []BreakerOption
+
+	lock     sync.Mutex
+	breakers map[string]*Breaker
+	limiters map[string]*rate.Limiter
+}
+
+// NewHosts returns Hosts using policy for the hosts without their own policy
+func NewHosts(policy Policy, opts ...HostsOption) *Hosts {
+	h := &Hosts{
+		policy:       policy,
+		hostPolicies: map[string]Policy{},
+		breakers:     map[string]*Breaker{},
+		limiters:     map[string]*rate.Limiter{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Hosts) policyOf(host string) Policy {
+	if policy, ok := h.hostPolicies[host]; ok {
+		return policy
+	}
+	return h.policy
+}
+
+// Breaker returns the breaker of host, nil when its policy has no breaker
+func (h *Hosts) Breaker(host string) *Breaker {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if breaker, ok := h.breakers[host]; ok {
+		return breaker
+	}
+	var breaker *Breaker
+	if policy := h.policyOf(host).Breaker; policy != nil {
+		breakerPolicy := *policy
+		if breakerPolicy.IsFailure == nil {
+			breakerPolicy.IsFailure = retry.IsTransient
+		}
+		breaker = NewBreaker(host, breakerPolicy, h.breakerOptions...)
+	}
+	h.breakers[host] = breaker
+	return breaker
+}
+
+// Limiter returns the rate limiter of host, nil when its policy has no rate limit
+func (h *Hosts) Limiter(host string) *rate.Limiter {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if limiter, ok := h.limiters[host]; ok {
+		return limiter
+	}
+	var limiter *rate.Limiter
+	if policy := h.policyOf(host); policy.QPS > 0 {
+		burst := policy.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(policy.QPS))
+		}
+		limiter = rate.NewLimiter(rate.Limit(policy.QPS), burst)
+	}
+	h.limiters[host] = limiter
+	return limiter
+}
+
+// Wait blocks until the rate limiter of host allows a call or ctx is done
+func (h *Hosts) Wait(ctx context.Context, host string) error {
+	limiter := h.Limiter(host)
+	if limiter == nil {
+		return nil
+	}
+	start := time.Now()
+	err := limiter.Wait(ctx)
+	RateLimitDelay.WithLabelValues(host).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return fmt.Errorf("rate limit of %s: %w", host, err)
+	}
+	return nil
+}
+
+// NewTransport returns a RoundTripper rate limiting requests and failing fast with ErrOpen
+// when the breaker of their host is open, before sending them with base.
+// When base is nil http.DefaultTransport is used
+func NewTransport(base http.RoundTripper, hosts *Hosts) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, hosts: hosts}
+}
+
+type transport struct {
+	base  http.RoundTripper
+	hosts *Hosts
+}
+
+type statusCodeError struct {
+	code int
+}
+
+func (e *statusCodeError) Error() string { return fmt.Sprintf("unexpected status code %d", e.code) }
+
+func (e *statusCodeError) StatusCode() int { return e.code }
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.hosts.Wait(req.Context(), host); err != nil {
+		return nil, err
+	}
+	breaker := t.hosts.Breaker(host)
+	if breaker == nil {
+		return t.base.RoundTrip(req)
+	}
+	done, err := breaker.Allow()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", host, err)
+	}
+	resp, err := t.base.RoundTrip(req)
+	result := err
+	if err == nil && retry.IsTransientStatusCode(resp.StatusCode) {
+		result = &statusCodeError{code: resp.StatusCode}
+	}
+	done(result)
+	return resp, err
+}
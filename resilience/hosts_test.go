@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHosts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	breaker := DefaultBreakerPolicy()
+	hosts := NewHosts(Policy{Breaker: &breaker, QPS: 10}, WithHostPolicy("unlimited.example.com", Policy{}))
+
+	g.Expect(hosts.Breaker("example.com")).To(BeIdenticalTo(hosts.Breaker("example.com")))
+	g.Expect(hosts.Breaker("example.com").Name()).To(Equal("example.com"))
+	g.Expect(hosts.Breaker("other.example.com")).NotTo(BeIdenticalTo(hosts.Breaker("example.com")))
+	g.Expect(hosts.Limiter("example.com").Burst()).To(Equal(10))
+	g.Expect(hosts.Breaker("unlimited.example.com")).To(BeNil())
+	g.Expect(hosts.Limiter("unlimited.example.com")).To(BeNil())
+	g.Expect(hosts.Wait(context.Background(), "unlimited.example.com")).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(hosts.Wait(ctx, "example.com")).To(MatchError(context.Canceled))
+}
+
+func TestTransport(t *testing.T) {
+	g := NewGomegaWithT(t)
+	var calls, status int32
+	atomic.StoreInt32(&status, http.StatusInternalServerError)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	breaker := BreakerPolicy{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond}
+	hosts := NewHosts(Policy{Breaker: &breaker, QPS: 1000, Burst: 1})
+	clt := &http.Client{Transport: NewTransport(nil, hosts)}
+	get := func() (int, error) {
+		resp, err := clt.Get(server.URL)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	// 5xx responses are returned and count as failures, opening the breaker
+	code, err := get()
+	g.Expect(err).To(BeNil())
+	g.Expect(code).To(Equal(http.StatusInternalServerError))
+	_, _ = get()
+	_, err = get()
+	g.Expect(err).To(MatchError(ErrOpen))
+	g.Expect(err).To(MatchError(ContainSubstring(serverURL.Host)))
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+
+	// the probe succeeds once the open timeout ends
+	atomic.StoreInt32(&status, http.StatusNotFound)
+	g.Eventually(get).Should(Equal(http.StatusNotFound))
+	g.Expect(hosts.Breaker(serverURL.Host).State()).To(Equal(StateClosed))
+}
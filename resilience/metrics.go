@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/AlaudaDevops/pkg/metrics"
+)
+
+var (
+	// BreakerState reports the state of circuit breakers by name: 0 closed, 1 half-open and 2 open
+	BreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "state",
+		Help:      "State of circuit breakers by name: 0 closed, 1 half-open and 2 open",
+	}, []string{"name"})
+
+	// BreakerRejected counts the calls rejected by circuit breakers by name
+	BreakerRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "rejected_total",
+		Help:      "Calls rejected by open circuit breakers by name",
+	}, []string{"name"})
+
+	// RateLimitDelay observes how long requests waited for the client-side rate limiter by host
+	RateLimitDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "rate_limit",
+		Name:      "delay_seconds",
+		Help:      "Time requests waited for the client-side rate limiter by host",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"host"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(Collectors()...)
+}
+
+// Collectors returns all metrics declared in this package,
+// used to register them into registries other than the controller-runtime one
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{BreakerState, BreakerRejected, RateLimitDelay}
+}
+
+func stateValue(state State) float64 {
+	switch state {
+	case StateHalfOpen:
+		return 1
+	case StateOpen:
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Predicate returns true if the error can be retried
+type Predicate func(err error) bool
+
+// Any returns a Predicate that is true when any of the predicates is true
+func Any(predicates ...Predicate) Predicate {
+	return func(err error) bool {
+		for _, predicate := range predicates {
+			if predicate(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Always retries all errors
+func Always(err error) bool {
+	return err != nil
+}
+
+// IsConflict retries kubernetes conflict errors
+func IsConflict(err error) bool {
+	return apierrors.IsConflict(err)
+}
+
+// StatusCoder is implemented by errors carrying an http status code
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// IsTransient retries errors that are expected to succeed later:
+//   - kubernetes too many requests, timeouts, internal and service unavailable errors
+//   - errors with a 429 or 5xx status code, except 501 not implemented
+//   - network timeouts and connection errors
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if apierrors.IsTooManyRequests(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		return IsTransientStatusCode(coder.StatusCode())
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// IsTransientStatusCode returns true for 429 and 5xx status codes, except 501 not implemented
+func IsTransientStatusCode(code int) bool {
+	return code == http.StatusTooManyRequests || (code >= 500 && code != http.StatusNotImplemented)
+}
+
+// suggestedDelay returns the delay suggested by the server in the error, if any
+func suggestedDelay(err error) (time.Duration, bool) {
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so it is not retried regardless of the policy
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
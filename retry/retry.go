@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"knative.dev/pkg/logging"
+)
+
+// Policy configures how functions are retried
+type Policy struct {
+	// InitialInterval delay before the first retry, Do uses the one of DefaultPolicy when zero
+	InitialInterval time.Duration
+	// Multiplier applied to the delay after each retry
+	Multiplier float64
+	// MaxInterval maximum delay between retries, zero means no limit
+	MaxInterval time.Duration
+	// Jitter randomizes delays by up to this fraction, e.g. 0.1 is ±10%
+	Jitter float64
+	// MaxAttempts maximum number of calls including the first one, zero means no limit
+	// when MaxElapsedTime is set, otherwise Do uses the one of DefaultPolicy
+	MaxAttempts int
+	// MaxElapsedTime stops retrying when the next retry would start after this duration
+	// since the first call, zero means no limit
+	MaxElapsedTime time.Duration
+	// RetryOn returns true if the error can be retried, defaults to IsTransient
+	RetryOn Predicate
+}
+
+// DefaultPolicy returns a Policy retrying transient errors up to 5 attempts
+// starting with 100ms and doubling the delay up to 10s
+func DefaultPolicy() Policy {
+	return Policy{
+		InitialInterval: 100 * time.Millisecond,
+		Multiplier:      2,
+		MaxInterval:     10 * time.Second,
+		Jitter:          0.1,
+		MaxAttempts:     5,
+		RetryOn:         IsTransient,
+	}
+}
+
+// WithRetryOn returns a copy of the policy retrying errors matching the predicate
+func (p Policy) WithRetryOn(retryOn Predicate) Policy {
+	p.RetryOn = retryOn
+	return p
+}
+
+// WithMaxAttempts returns a copy of the policy with a maximum number of attempts
+func (p Policy) WithMaxAttempts(maxAttempts int) Policy {
+	p.MaxAttempts = maxAttempts
+	return p
+}
+
+// WithMaxElapsedTime returns a copy of the policy with a maximum elapsed time
+func (p Policy) WithMaxElapsedTime(maxElapsedTime time.Duration) Policy {
+	p.MaxElapsedTime = maxElapsedTime
+	return p
+}
+
+// Delay returns the delay before the retry following attempt, starting at 1
+func (p Policy) Delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialInterval) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxInterval > 0 && delay > float64(p.MaxInterval) {
+		delay = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1) // nolint: gosec // G404: jitter does not need a secure random
+	}
+	return time.Duration(delay)
+}
+
+// withDefaults returns the policy with the InitialInterval and MaxAttempts of DefaultPolicy
+// when missing, so a zero Policy neither retries without delay nor without limit
+func (p Policy) withDefaults() Policy {
+	defaults := DefaultPolicy()
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = defaults.InitialInterval
+	}
+	if p.MaxAttempts <= 0 && p.MaxElapsedTime <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	return p
+}
+
+func (p Policy) retryable(err error) bool {
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	if p.RetryOn == nil {
+		return IsTransient(err)
+	}
+	return p.RetryOn(err)
+}
+
+// Do calls fn until it succeeds, returns an error that can not be retried,
+// the policy limits are reached or ctx is done. The last error of fn is returned,
+// unwrapped from Permanent
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	_, err := DoWithResult(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// DoWithResult is Do for functions returning a value
+func DoWithResult[T any](ctx context.Context, policy Policy, fn func(ctx context.Context) (T, error)) (T, error) {
+	policy = policy.withDefaults()
+	logger := logging.FromContext(ctx)
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+		if !policy.retryable(err) {
+			return result, unwrapPermanent(err)
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return result, fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		delay := policy.Delay(attempt)
+		if suggested, ok := suggestedDelay(err); ok && suggested > delay {
+			delay = suggested
+		}
+		if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+			return result, fmt.Errorf("giving up after %d attempts in %s: %w", attempt, time.Since(start).Round(time.Millisecond), err)
+		}
+		logger.Debugw("retrying after error", "attempt", attempt, "delay", delay, "err", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+func unwrapPermanent(err error) error {
+	if permanent, ok := err.(*permanentError); ok {
+		return permanent.err
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type statusError int
+
+func (e statusError) Error() string   { return fmt.Sprintf("status %d", int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestIsTransient(t *testing.T) {
+	gr := corev1.Resource("configmaps")
+	var data = []struct {
+		desc     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("unavailable"), true},
+		{"internal error", apierrors.NewInternalError(errors.New("boom")), true},
+		{"conflict", apierrors.NewConflict(gr, "cm", errors.New("conflict")), false},
+		{"not found", apierrors.NewNotFound(gr, "cm"), false},
+		{"status 503", fmt.Errorf("wrapped: %w", statusError(http.StatusServiceUnavailable)), true},
+		{"status 501", statusError(http.StatusNotImplemented), false},
+		{"status 404", statusError(http.StatusNotFound), false},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"canceled", context.Canceled, false},
+		{"generic error", errors.New("invalid"), false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(IsTransient(item.err)).To(Equal(item.expected))
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	retryOn := Any(IsConflict, IsTransient)
+	g.Expect(retryOn(apierrors.NewConflict(gr, "cm", errors.New("conflict")))).To(BeTrue())
+	g.Expect(retryOn(errors.New("invalid"))).To(BeFalse())
+	g.Expect(Always(errors.New("invalid"))).To(BeTrue())
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	g := NewGomegaWithT(t)
+	policy := Policy{InitialInterval: time.Second, Multiplier: 2, MaxInterval: 5 * time.Second}
+	g.Expect(policy.Delay(1)).To(Equal(time.Second))
+	g.Expect(policy.Delay(2)).To(Equal(2 * time.Second))
+	g.Expect(policy.Delay(3)).To(Equal(4 * time.Second))
+	g.Expect(policy.Delay(4)).To(Equal(5 * time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		g.Expect(policy.Delay(1)).To(BeNumerically("~", time.Second, 500*time.Millisecond))
+	}
+}
+
+func fastPolicy() Policy {
+	return Policy{InitialInterval: time.Millisecond, Multiplier: 1, MaxAttempts: 3, RetryOn: Always}
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("failure")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		calls := 0
+		err := Do(ctx, fastPolicy(), func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return failure
+			}
+			return nil
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(calls).To(Equal(3))
+	})
+
+	t.Run("zero policy is bounded and delayed", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		calls := 0
+		start := time.Now()
+		err := Do(ctx, Policy{RetryOn: Always}, func(ctx context.Context) error {
+			calls++
+			return failure
+		})
+		g.Expect(err.Error()).To(ContainSubstring("giving up after 5 attempts"))
+		g.Expect(calls).To(Equal(DefaultPolicy().MaxAttempts))
+		g.Expect(time.Since(start)).To(BeNumerically(">=", 4*DefaultPolicy().InitialInterval))
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		calls := 0
+		err := Do(ctx, fastPolicy(), func(ctx context.Context) error {
+			calls++
+			return failure
+		})
+		g.Expect(err).To(MatchError(failure))
+		g.Expect(err.Error()).To(ContainSubstring("giving up after 3 attempts"))
+		g.Expect(calls).To(Equal(3))
+	})
+
+	t.Run("does not retry not retryable errors", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		calls := 0
+		err := Do(ctx, fastPolicy().WithRetryOn(IsConflict), func(ctx context.Context) error {
+			calls++
+			return failure
+		})
+		g.Expect(err).To(Equal(failure))
+		g.Expect(calls).To(Equal(1))
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		calls := 0
+		err := Do(ctx, fastPolicy(), func(ctx context.Context) error {
+			calls++
+			return Permanent(failure)
+		})
+		g.Expect(err).To(Equal(failure))
+		g.Expect(calls).To(Equal(1))
+		g.Expect(Permanent(nil)).To(BeNil())
+	})
+
+	t.Run("gives up after max elapsed time", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		policy := fastPolicy().WithMaxAttempts(0).WithMaxElapsedTime(20 * time.Millisecond)
+		policy.InitialInterval = 5 * time.Millisecond
+		calls := 0
+		err := Do(ctx, policy, func(ctx context.Context) error {
+			calls++
+			return failure
+		})
+		g.Expect(err).To(MatchError(failure))
+		g.Expect(err.Error()).To(ContainSubstring("giving up after"))
+		g.Expect(calls).To(BeNumerically(">", 1))
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		ctx, cancel := context.WithCancel(ctx)
+		policy := fastPolicy().WithMaxAttempts(0)
+		policy.InitialInterval = time.Hour
+		err := Do(ctx, policy, func(ctx context.Context) error {
+			cancel()
+			return failure
+		})
+		g.Expect(err).To(MatchError(context.Canceled))
+		g.Expect(err).To(MatchError(failure))
+	})
+
+	t.Run("returns the result", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		calls := 0
+		result, err := DoWithResult(ctx, fastPolicy(), func(ctx context.Context) (int, error) {
+			calls++
+			if calls < 2 {
+				return 0, failure
+			}
+			return calls, nil
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(result).To(Equal(2))
+	})
+}
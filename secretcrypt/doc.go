@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretcrypt seals secret values with envelope encryption so they can be
+// committed to git and decrypted by controllers holding the private key.
+//
+// Each value is encrypted with a random data key using AES-256-GCM and the data key is
+// wrapped for every recipient, an X25519 public key or a key managed by a KMS plugin.
+// Sealed values are single line strings like ENC[secretcrypt:v1:...] fitting in yaml files:
+//
+//	// in the cli, with the public key committed next to the configuration
+//	recipient, err := secretcrypt.ParseX25519Recipient(publicKey)
+//	sealed, err := secretcrypt.Encrypt(ctx, []byte(password), recipient)
+//
+//	// in the controller, with the private key mounted from a secret
+//	identities, err := secretcrypt.IdentitiesFromSecret(secret)
+//	data, err := secretcrypt.DecryptMap(ctx, configMap.Data, identities...)
+package secretcrypt
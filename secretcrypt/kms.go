@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretcrypt
+
+import (
+	"context"
+)
+
+// KMSType is the stanza type of KMS recipients
+const KMSType = "kms"
+
+// KMS is implemented by plugins encrypting data keys with keys held by a key management service,
+// e.g. a cloud KMS or vault transit. The private key never leaves the service
+type KMS interface {
+	// KeyID identifies the key used by Encrypt, e.g. its resource name
+	KeyID() string
+	// Encrypt encrypts the data key
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	// Decrypt decrypts a data key encrypted using the key identified by keyID
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// KMSRecipient returns a Recipient wrapping data keys with kms
+func KMSRecipient(kms KMS) Recipient {
+	return &kmsKey{kms: kms}
+}
+
+// KMSIdentity returns an Identity unwrapping data keys with kms,
+// only stanzas of its KeyID are unwrapped
+func KMSIdentity(kms KMS) Identity {
+	return &kmsKey{kms: kms}
+}
+
+type kmsKey struct {
+	kms KMS
+}
+
+// Wrap implements Recipient
+func (k *kmsKey) Wrap(ctx context.Context, dataKey []byte) (*Stanza, error) {
+	body, err := k.kms.Encrypt(ctx, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Stanza{Type: KMSType, KeyID: k.kms.KeyID(), Body: body}, nil
+}
+
+// Unwrap implements Identity
+func (k *kmsKey) Unwrap(ctx context.Context, stanza *Stanza) ([]byte, error) {
+	if stanza.Type != KMSType || stanza.KeyID != k.kms.KeyID() {
+		return nil, ErrNoIdentity
+	}
+	return k.kms.Decrypt(ctx, stanza.KeyID, stanza.Body)
+}
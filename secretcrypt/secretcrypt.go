@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Prefix of sealed values
+	Prefix = "ENC[secretcrypt:v1:"
+	// Suffix of sealed values
+	Suffix = "]"
+
+	dataKeySize = 32
+)
+
+var (
+	// ErrNoRecipient is returned when encrypting without recipients
+	ErrNoRecipient = errors.New("at least one recipient is required")
+	// ErrNoIdentity is returned when none of the identities can decrypt a value
+	ErrNoIdentity = errors.New("no identity matches the recipients of the value")
+	// ErrNotSealed is returned when decrypting a value which is not sealed
+	ErrNotSealed = errors.New("value is not sealed")
+)
+
+// Stanza is the data key wrapped for a recipient
+type Stanza struct {
+	// Type of the recipient, e.g. x25519 or kms
+	Type string `json:"type"`
+	// KeyID identifies the key of the recipient, identities skip stanzas of other keys
+	KeyID string `json:"kid"`
+	// Args are recipient specific arguments, e.g. the ephemeral public key
+	Args []string `json:"args,omitempty"`
+	// Body is the wrapped data key
+	Body []byte `json:"body"`
+}
+
+// Recipient wraps data keys so that only its identity can unwrap them
+type Recipient interface {
+	Wrap(ctx context.Context, dataKey []byte) (*Stanza, error)
+}
+
+// Identity unwraps data keys wrapped for its recipient. It returns ErrNoIdentity
+// when the stanza was wrapped for another key
+type Identity interface {
+	Unwrap(ctx context.Context, stanza *Stanza) ([]byte, error)
+}
+
+// envelope is the encoded content of a sealed value
+type envelope struct {
+	Stanzas []*Stanza `json:"stanzas"`
+	Nonce   []byte    `json:"nonce"`
+	Data    []byte    `json:"data"`
+}
+
+// IsSealed returns true when value is a sealed value
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, Prefix) && strings.HasSuffix(value, Suffix)
+}
+
+// Encrypt seals plaintext for recipients, any of their identities can decrypt it
+func Encrypt(ctx context.Context, plaintext []byte, recipients ...Recipient) (string, error) {
+	if len(recipients) == 0 {
+		return "", ErrNoRecipient
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	env := &envelope{}
+	for _, recipient := range recipients {
+		stanza, err := recipient.Wrap(ctx, dataKey)
+		if err != nil {
+			return "", fmt.Errorf("wrap data key failed: %w", err)
+		}
+		env.Stanzas = append(env.Stanzas, stanza)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return "", err
+	}
+	env.Data = aead.Seal(nil, env.Nonce, plaintext, []byte(Prefix))
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+	return Prefix + base64.RawURLEncoding.EncodeToString(data) + Suffix, nil
+}
+
+// Decrypt opens a sealed value using the first identity able to unwrap its data key
+func Decrypt(ctx context.Context, sealed string, identities ...Identity) ([]byte, error) {
+	if !IsSealed(sealed) {
+		return nil, ErrNotSealed
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(sealed, Prefix), Suffix))
+	if err != nil {
+		return nil, fmt.Errorf("decode sealed value failed: %w", err)
+	}
+	env := &envelope{}
+	if err = json.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("decode sealed value failed: %w", err)
+	}
+
+	dataKey, err := unwrap(ctx, env.Stanzas, identities)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Data, []byte(Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt value failed: %w", err)
+	}
+	return plaintext, nil
+}
+
+func unwrap(ctx context.Context, stanzas []*Stanza, identities []Identity) ([]byte, error) {
+	for _, identity := range identities {
+		for _, stanza := range stanzas {
+			dataKey, err := identity.Unwrap(ctx, stanza)
+			if errors.Is(err, ErrNoIdentity) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unwrap data key failed: %w", err)
+			}
+			if len(dataKey) != dataKeySize {
+				return nil, errors.New("invalid data key size")
+			}
+			return dataKey, nil
+		}
+	}
+	return nil, ErrNoIdentity
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptMap returns a copy of data with its values sealed for recipients,
+// values already sealed are kept as is
+func EncryptMap(ctx context.Context, data map[string]string, recipients ...Recipient) (map[string]string, error) {
+	result := make(map[string]string, len(data))
+	for key, value := range data {
+		if IsSealed(value) {
+			result[key] = value
+			continue
+		}
+		sealed, err := Encrypt(ctx, []byte(value), recipients...)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt %q failed: %w", key, err)
+		}
+		result[key] = sealed
+	}
+	return result, nil
+}
+
+// DecryptMap returns a copy of data with its sealed values decrypted,
+// other values are kept as is
+func DecryptMap(ctx context.Context, data map[string]string, identities ...Identity) (map[string]string, error) {
+	result := make(map[string]string, len(data))
+	for key, value := range data {
+		if !IsSealed(value) {
+			result[key] = value
+			continue
+		}
+		plaintext, err := Decrypt(ctx, value, identities...)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt %q failed: %w", key, err)
+		}
+		result[key] = string(plaintext)
+	}
+	return result, nil
+}
+
+// IdentitiesFromSecret parses the X25519 identities stored in the values of secret,
+// see ParseIdentities
+func IdentitiesFromSecret(secret *corev1.Secret) ([]Identity, error) {
+	var identities []Identity
+	for key, value := range secret.Data {
+		parsed, err := ParseIdentities(string(value))
+		if err != nil {
+			return nil, fmt.Errorf("parse identities of %s/%s key %q failed: %w", secret.Namespace, secret.Name, key, err)
+		}
+		identities = append(identities, parsed...)
+	}
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no identity", secret.Namespace, secret.Name)
+	}
+	return identities, nil
+}
+
+// ParseIdentities parses X25519 identities, one per line. Empty lines and lines starting with # are ignored
+func ParseIdentities(data string) ([]Identity, error) {
+	var identities []Identity
+	for _, line := range parseLines(data) {
+		identity, err := ParseX25519Identity(line)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return identities, nil
+}
+
+// ParseRecipients parses X25519 recipients, one per line. Empty lines and lines starting with # are ignored
+func ParseRecipients(data string) ([]Recipient, error) {
+	var recipients []Recipient
+	for _, line := range parseLines(data) {
+		recipient, err := ParseX25519Recipient(line)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+func parseLines(data string) (lines []string) {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
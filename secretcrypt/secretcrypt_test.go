@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretcrypt
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeKMS xors data keys with a byte, which is enough to check the stanzas
+type fakeKMS struct {
+	keyID string
+	xor   byte
+}
+
+func (k *fakeKMS) KeyID() string { return k.keyID }
+
+func (k *fakeKMS) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	return k.apply(plaintext), nil
+}
+
+func (k *fakeKMS) Decrypt(_ context.Context, _ string, ciphertext []byte) ([]byte, error) {
+	return k.apply(ciphertext), nil
+}
+
+func (k *fakeKMS) apply(data []byte) []byte {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		result[i] = b ^ k.xor
+	}
+	return result
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	alice, err := GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+	bob, err := GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+	other, err := GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+	kms := &fakeKMS{keyID: "projects/p/keys/k", xor: 42}
+
+	sealed, err := Encrypt(ctx, []byte("s3cr3t"), alice.Recipient(), bob.Recipient(), KMSRecipient(kms))
+	g.Expect(err).To(BeNil())
+	g.Expect(IsSealed(sealed)).To(BeTrue())
+	g.Expect(sealed).NotTo(ContainSubstring("s3cr3t"))
+
+	for _, identity := range []Identity{alice, bob, KMSIdentity(kms)} {
+		plaintext, err := Decrypt(ctx, sealed, other, identity)
+		g.Expect(err).To(BeNil())
+		g.Expect(string(plaintext)).To(Equal("s3cr3t"))
+	}
+
+	_, err = Decrypt(ctx, sealed, other, KMSIdentity(&fakeKMS{keyID: "other"}))
+	g.Expect(err).To(MatchError(ErrNoIdentity))
+
+	// each encryption uses a new data key
+	again, err := Encrypt(ctx, []byte("s3cr3t"), alice.Recipient())
+	g.Expect(err).To(BeNil())
+	g.Expect(again).NotTo(Equal(sealed))
+
+	_, err = Encrypt(ctx, []byte("s3cr3t"))
+	g.Expect(err).To(MatchError(ErrNoRecipient))
+	_, err = Decrypt(ctx, "s3cr3t", alice)
+	g.Expect(err).To(MatchError(ErrNotSealed))
+}
+
+func TestDecrypt_tampered(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	identity, err := GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+
+	sealed, err := Encrypt(ctx, []byte("s3cr3t"), identity.Recipient())
+	g.Expect(err).To(BeNil())
+	payload := []byte(strings.TrimSuffix(strings.TrimPrefix(sealed, Prefix), Suffix))
+	payload[len(payload)-5] ^= 1
+
+	_, err = Decrypt(ctx, Prefix+string(payload)+Suffix, identity)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestX25519_encoding(t *testing.T) {
+	g := NewGomegaWithT(t)
+	identity, err := GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+
+	parsed, err := ParseX25519Identity(identity.String())
+	g.Expect(err).To(BeNil())
+	g.Expect(parsed.String()).To(Equal(identity.String()))
+	g.Expect(identity.String()).To(HavePrefix(X25519IdentityPrefix))
+
+	recipient, err := ParseX25519Recipient(identity.Recipient().String())
+	g.Expect(err).To(BeNil())
+	g.Expect(recipient.KeyID()).To(Equal(identity.Recipient().KeyID()))
+
+	_, err = ParseX25519Recipient(identity.String())
+	g.Expect(err).To(MatchError(ContainSubstring("does not start with")))
+	_, err = ParseX25519Identity(X25519IdentityPrefix + "AAAA")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid x25519 private key")))
+}
+
+func TestMaps(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	identity, err := GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+	recipients, err := ParseRecipients("# team key\n" + identity.Recipient().String() + "\n\n")
+	g.Expect(err).To(BeNil())
+
+	sealed, err := EncryptMap(ctx, map[string]string{"password": "s3cr3t"}, recipients...)
+	g.Expect(err).To(BeNil())
+	g.Expect(IsSealed(sealed["password"])).To(BeTrue())
+	sealed["host"] = "example.com"
+
+	// sealed values are kept when encrypting again
+	resealed, err := EncryptMap(ctx, sealed, recipients...)
+	g.Expect(err).To(BeNil())
+	g.Expect(resealed["password"]).To(Equal(sealed["password"]))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "keys"},
+		Data:       map[string][]byte{"identity": []byte(identity.String() + "\n")},
+	}
+	identities, err := IdentitiesFromSecret(secret)
+	g.Expect(err).To(BeNil())
+	data, err := DecryptMap(ctx, sealed, identities...)
+	g.Expect(err).To(BeNil())
+	g.Expect(data).To(Equal(map[string]string{"password": "s3cr3t", "host": "example.com"}))
+
+	_, err = IdentitiesFromSecret(&corev1.Secret{ObjectMeta: secret.ObjectMeta})
+	g.Expect(err).To(MatchError("secret default/keys has no identity"))
+}
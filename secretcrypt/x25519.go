@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretcrypt
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// X25519Type is the stanza type of X25519 recipients
+	X25519Type = "x25519"
+	// X25519RecipientPrefix prefixes encoded X25519 public keys
+	X25519RecipientPrefix = "secretcrypt-x25519-public:"
+	// X25519IdentityPrefix prefixes encoded X25519 private keys
+	X25519IdentityPrefix = "secretcrypt-x25519-private:"
+
+	x25519Info = "secretcrypt/v1/x25519"
+)
+
+// X25519Recipient wraps data keys for an X25519 public key
+type X25519Recipient struct {
+	key *ecdh.PublicKey
+}
+
+var _ Recipient = &X25519Recipient{}
+
+// X25519Identity unwraps data keys using an X25519 private key
+type X25519Identity struct {
+	key *ecdh.PrivateKey
+}
+
+var _ Identity = &X25519Identity{}
+
+// GenerateX25519Identity generates a new random private key
+func GenerateX25519Identity() (*X25519Identity, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{key: key}, nil
+}
+
+// ParseX25519Identity parses a private key encoded by X25519Identity.String
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	data, err := decodeKey(s, X25519IdentityPrefix)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdh.X25519().NewPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x25519 private key: %w", err)
+	}
+	return &X25519Identity{key: key}, nil
+}
+
+// ParseX25519Recipient parses a public key encoded by X25519Recipient.String
+func ParseX25519Recipient(s string) (*X25519Recipient, error) {
+	data, err := decodeKey(s, X25519RecipientPrefix)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdh.X25519().NewPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x25519 public key: %w", err)
+	}
+	return &X25519Recipient{key: key}, nil
+}
+
+func decodeKey(s, prefix string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, prefix) {
+		return nil, fmt.Errorf("key does not start with %s", prefix)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(s, prefix))
+	if err != nil {
+		return nil, fmt.Errorf("decode key failed: %w", err)
+	}
+	return data, nil
+}
+
+// Recipient returns the public key of the identity
+func (i *X25519Identity) Recipient() *X25519Recipient {
+	return &X25519Recipient{key: i.key.PublicKey()}
+}
+
+// String encodes the private key, it must be kept secret
+func (i *X25519Identity) String() string {
+	return X25519IdentityPrefix + base64.RawURLEncoding.EncodeToString(i.key.Bytes())
+}
+
+// String encodes the public key
+func (r *X25519Recipient) String() string {
+	return X25519RecipientPrefix + base64.RawURLEncoding.EncodeToString(r.key.Bytes())
+}
+
+// KeyID identifies the public key without revealing it
+func (r *X25519Recipient) KeyID() string {
+	sum := sha256.Sum256(r.key.Bytes())
+	return hex.EncodeToString(sum[:8])
+}
+
+// Wrap implements Recipient. The data key is encrypted with a key derived from
+// the shared secret of an ephemeral key and the public key
+func (r *X25519Recipient) Wrap(_ context.Context, dataKey []byte) (*Stanza, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(r.key)
+	if err != nil {
+		return nil, err
+	}
+	wrapKey, err := deriveWrapKey(shared, ephemeral.PublicKey().Bytes(), r.key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(wrapKey)
+	if err != nil {
+		return nil, err
+	}
+	// the wrap key is never reused so the nonce can be fixed
+	body := aead.Seal(nil, make([]byte, aead.NonceSize()), dataKey, nil)
+	return &Stanza{
+		Type:  X25519Type,
+		KeyID: r.KeyID(),
+		Args:  []string{base64.RawURLEncoding.EncodeToString(ephemeral.PublicKey().Bytes())},
+		Body:  body,
+	}, nil
+}
+
+// Unwrap implements Identity
+func (i *X25519Identity) Unwrap(_ context.Context, stanza *Stanza) ([]byte, error) {
+	public := i.key.PublicKey().Bytes()
+	if stanza.Type != X25519Type || stanza.KeyID != i.Recipient().KeyID() {
+		return nil, ErrNoIdentity
+	}
+	if len(stanza.Args) != 1 {
+		return nil, fmt.Errorf("invalid %s stanza", X25519Type)
+	}
+	ephemeralData, err := base64.RawURLEncoding.DecodeString(stanza.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s stanza: %w", X25519Type, err)
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(ephemeralData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s stanza: %w", X25519Type, err)
+	}
+	shared, err := i.key.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	wrapKey, err := deriveWrapKey(shared, ephemeralData, public)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(wrapKey)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, aead.NonceSize()), stanza.Body, nil)
+}
+
+func deriveWrapKey(shared, ephemeral, public []byte) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeral...), public...)
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(x25519Info)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selector builds label and field selectors with validation and parses
+// user supplied selectors, e.g. from cli flags, reporting friendly errors.
+//
+//	sel, err := selector.Labels().Eq("app", "web").In("tier", "frontend", "backend").Exists("team").Build()
+//
+//	opt, err := selector.Fields().Eq("metadata.name", "web").ListOption()
+//	err = clt.List(ctx, list, opt)
+//
+//	flag := &selector.LabelsFlag{}
+//	cmd.Flags().VarP(flag, "selector", "l", "label selector, e.g. app=web,tier in (a,b)")
+package selector
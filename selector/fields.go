@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldBuilder builds a field selector from terms which must all match.
+// Invalid fields are reported by Build
+type FieldBuilder struct {
+	selectors []fields.Selector
+	errs      []error
+}
+
+// Fields returns an empty field selector builder, matching everything
+func Fields() *FieldBuilder {
+	return &FieldBuilder{}
+}
+
+// Eq requires the field to have the value
+func (b *FieldBuilder) Eq(field, value string) *FieldBuilder {
+	if err := validateField(field); err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.selectors = append(b.selectors, fields.OneTermEqualSelector(field, value))
+	return b
+}
+
+// NotEq requires the field to have another value
+func (b *FieldBuilder) NotEq(field, value string) *FieldBuilder {
+	if err := validateField(field); err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.selectors = append(b.selectors, fields.OneTermNotEqualSelector(field, value))
+	return b
+}
+
+// Build returns the selector, or the errors of invalid terms joined
+func (b *FieldBuilder) Build() (fields.Selector, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	if len(b.selectors) == 0 {
+		return fields.Everything(), nil
+	}
+	return fields.AndSelectors(b.selectors...), nil
+}
+
+// MustBuild returns the selector like Build and panics on error,
+// for selectors built from constants
+func (b *FieldBuilder) MustBuild() fields.Selector {
+	selector, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+// ListOption returns the selector as a list option
+func (b *FieldBuilder) ListOption() (client.ListOption, error) {
+	selector, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.MatchingFieldsSelector{Selector: selector}, nil
+}
+
+func validateField(field string) error {
+	if strings.TrimSpace(field) == "" {
+		return fmt.Errorf("field name must not be empty")
+	}
+	if strings.ContainsAny(field, "=!,") {
+		return fmt.Errorf("invalid field name %q: must not contain '=', '!' or ','", field)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"errors"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LabelBuilder builds a label selector from requirements which must all match.
+// Invalid keys or values are reported by Build
+type LabelBuilder struct {
+	requirements labels.Requirements
+	errs         []error
+}
+
+// Labels returns an empty label selector builder, matching everything
+func Labels() *LabelBuilder {
+	return &LabelBuilder{}
+}
+
+// Eq requires the label key to have the value
+func (b *LabelBuilder) Eq(key, value string) *LabelBuilder {
+	return b.add(key, selection.Equals, value)
+}
+
+// NotEq requires the label key to be missing or to have another value
+func (b *LabelBuilder) NotEq(key, value string) *LabelBuilder {
+	return b.add(key, selection.NotEquals, value)
+}
+
+// In requires the label key to have one of the values
+func (b *LabelBuilder) In(key string, values ...string) *LabelBuilder {
+	return b.add(key, selection.In, values...)
+}
+
+// NotIn requires the label key to be missing or to have none of the values
+func (b *LabelBuilder) NotIn(key string, values ...string) *LabelBuilder {
+	return b.add(key, selection.NotIn, values...)
+}
+
+// Exists requires the label key to be set
+func (b *LabelBuilder) Exists(key string) *LabelBuilder {
+	return b.add(key, selection.Exists)
+}
+
+// DoesNotExist requires the label key to be missing
+func (b *LabelBuilder) DoesNotExist(key string) *LabelBuilder {
+	return b.add(key, selection.DoesNotExist)
+}
+
+// Gt requires the label key to be an integer greater than value
+func (b *LabelBuilder) Gt(key string, value int64) *LabelBuilder {
+	return b.add(key, selection.GreaterThan, strconv.FormatInt(value, 10))
+}
+
+// Lt requires the label key to be an integer lower than value
+func (b *LabelBuilder) Lt(key string, value int64) *LabelBuilder {
+	return b.add(key, selection.LessThan, strconv.FormatInt(value, 10))
+}
+
+// Matching requires all labels of the set, like client.MatchingLabels
+func (b *LabelBuilder) Matching(set map[string]string) *LabelBuilder {
+	for key, value := range set {
+		b.Eq(key, value)
+	}
+	return b
+}
+
+func (b *LabelBuilder) add(key string, op selection.Operator, values ...string) *LabelBuilder {
+	requirement, err := labels.NewRequirement(key, op, values)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.requirements = append(b.requirements, *requirement)
+	return b
+}
+
+// Build returns the selector, or the errors of invalid requirements joined
+func (b *LabelBuilder) Build() (labels.Selector, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	return labels.NewSelector().Add(b.requirements...), nil
+}
+
+// MustBuild returns the selector like Build and panics on error,
+// for selectors built from constants
+func (b *LabelBuilder) MustBuild() labels.Selector {
+	selector, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+// ListOption returns the selector as a list option
+func (b *LabelBuilder) ListOption() (client.ListOption, error) {
+	selector, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.MatchingLabelsSelector{Selector: selector}, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	labelsExample = "app=web,tier in (frontend,backend),!canary"
+	fieldsExample = "metadata.name=web,status.phase!=Running"
+)
+
+// ParseLabels parses a user supplied label selector, an empty string matches everything
+func ParseLabels(value string) (labels.Selector, error) {
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w, expected comma separated requirements like %q", value, err, labelsExample)
+	}
+	return selector, nil
+}
+
+// ParseFields parses a user supplied field selector, an empty string matches everything
+func ParseFields(value string) (fields.Selector, error) {
+	selector, err := fields.ParseSelector(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector %q: %w, expected comma separated terms like %q", value, err, fieldsExample)
+	}
+	return selector, nil
+}
+
+// LabelsFlag is a pflag.Value parsing a label selector
+type LabelsFlag struct {
+	// Selector parsed from the flag, nil if the flag is not set
+	Selector labels.Selector
+	value    string
+}
+
+var _ pflag.Value = &LabelsFlag{}
+
+// Set implements pflag.Value
+func (f *LabelsFlag) Set(value string) error {
+	selector, err := ParseLabels(value)
+	if err != nil {
+		return err
+	}
+	f.Selector, f.value = selector, value
+	return nil
+}
+
+// String implements pflag.Value
+func (f *LabelsFlag) String() string {
+	return f.value
+}
+
+// Type implements pflag.Value
+func (f *LabelsFlag) Type() string {
+	return "selector"
+}
+
+// ListOptions returns the selector as list options, none if the flag is not set
+func (f *LabelsFlag) ListOptions() []client.ListOption {
+	if f.Selector == nil || f.Selector.Empty() {
+		return nil
+	}
+	return []client.ListOption{client.MatchingLabelsSelector{Selector: f.Selector}}
+}
+
+// FieldsFlag is a pflag.Value parsing a field selector
+type FieldsFlag struct {
+	// Selector parsed from the flag, nil if the flag is not set
+	Selector fields.Selector
+	value    string
+}
+
+var _ pflag.Value = &FieldsFlag{}
+
+// Set implements pflag.Value
+func (f *FieldsFlag) Set(value string) error {
+	selector, err := ParseFields(value)
+	if err != nil {
+		return err
+	}
+	f.Selector, f.value = selector, value
+	return nil
+}
+
+// String implements pflag.Value
+func (f *FieldsFlag) String() string {
+	return f.value
+}
+
+// Type implements pflag.Value
+func (f *FieldsFlag) Type() string {
+	return "selector"
+}
+
+// ListOptions returns the selector as list options, none if the flag is not set
+func (f *FieldsFlag) ListOptions() []client.ListOption {
+	if f.Selector == nil || f.Selector.Empty() {
+		return nil
+	}
+	return []client.ListOption{client.MatchingFieldsSelector{Selector: f.Selector}}
+}
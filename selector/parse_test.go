@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParseLabels(t *testing.T) {
+	var data = []struct {
+		desc    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{"empty", "", "", ""},
+		{"valid", "app=web, tier in (a,b),!canary", "app=web,!canary,tier in (a,b)", ""},
+		{"invalid", "app=(x", "", `invalid label selector "app=(x": `},
+		{"unclosed set", "tier in (a", "", "expected comma separated requirements like"},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			selector, err := ParseLabels(item.value)
+			if item.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(item.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(selector.String()).To(Equal(item.want))
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+	selector, err := ParseFields("metadata.name=web,status.phase!=Running")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(selector.String()).To(Equal("metadata.name=web,status.phase!=Running"))
+
+	_, err = ParseFields("metadata.name")
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid field selector "metadata.name"`)))
+}
+
+func TestFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	labelsFlag, fieldsFlag := &LabelsFlag{}, &FieldsFlag{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.VarP(labelsFlag, "selector", "l", "")
+	flags.Var(fieldsFlag, "field-selector", "")
+	g.Expect(labelsFlag.ListOptions()).To(BeEmpty())
+	g.Expect(fieldsFlag.ListOptions()).To(BeEmpty())
+
+	g.Expect(flags.Parse([]string{"-l", "app=web", "--field-selector", "metadata.name=web"})).To(Succeed())
+	g.Expect(labelsFlag.String()).To(Equal("app=web"))
+	g.Expect(labelsFlag.Selector.Matches(labels.Set{"app": "web"})).To(BeTrue())
+	g.Expect(fieldsFlag.String()).To(Equal("metadata.name=web"))
+
+	listOpts := (&client.ListOptions{}).ApplyOptions(append(labelsFlag.ListOptions(), fieldsFlag.ListOptions()...))
+	g.Expect(listOpts.LabelSelector.String()).To(Equal("app=web"))
+	g.Expect(listOpts.FieldSelector.String()).To(Equal("metadata.name=web"))
+
+	err := flags.Parse([]string{"-l", "app in (web"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid label selector")))
+	g.Expect(labelsFlag.String()).To(Equal("app=web"))
+}
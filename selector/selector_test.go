@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLabels(t *testing.T) {
+	var data = []struct {
+		desc     string
+		builder  *LabelBuilder
+		want     string
+		matches  labels.Set
+		mismatch labels.Set
+		wantErr  bool
+	}{
+		{"empty matches everything", Labels(), "", labels.Set{"a": "b"}, nil, false},
+		{"equality and set based",
+			Labels().Eq("app", "x").In("tier", "b", "a").NotIn("env", "dev").Exists("team").DoesNotExist("canary"),
+			"app=x,!canary,env notin (dev),team,tier in (a,b)",
+			labels.Set{"app": "x", "tier": "a", "team": "t"},
+			labels.Set{"app": "x", "tier": "a", "team": "t", "canary": "true"}, false},
+		{"numeric", Labels().Gt("generation", 1).Lt("generation", 5).NotEq("app", "y"),
+			"app!=y,generation>1,generation<5",
+			labels.Set{"generation": "3"}, labels.Set{"generation": "5"}, false},
+		{"matching set", Labels().Matching(map[string]string{"b": "2", "a": "1"}), "a=1,b=2",
+			labels.Set{"a": "1", "b": "2"}, labels.Set{"a": "1"}, false},
+		{"invalid key", Labels().Eq("invalid key", "x"), "", nil, nil, true},
+		{"invalid value", Labels().In("app", "in valid"), "", nil, nil, true},
+		{"missing values", Labels().In("app"), "", nil, nil, true},
+	}
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			selector, err := item.builder.Build()
+			if item.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(func() { item.builder.MustBuild() }).To(Panic())
+				_, err = item.builder.ListOption()
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(selector.String()).To(Equal(item.want))
+			if item.matches != nil {
+				g.Expect(selector.Matches(item.matches)).To(BeTrue())
+			}
+			if item.mismatch != nil {
+				g.Expect(selector.Matches(item.mismatch)).To(BeFalse())
+			}
+
+			opt, err := item.builder.ListOption()
+			g.Expect(err).NotTo(HaveOccurred())
+			listOpts := &client.ListOptions{}
+			opt.ApplyToList(listOpts)
+			g.Expect(listOpts.LabelSelector.String()).To(Equal(item.want))
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(Fields().MustBuild().Empty()).To(BeTrue())
+
+	selector, err := Fields().Eq("metadata.name", "web").NotEq("status.phase", "Running").Build()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(selector.Matches(fields.Set{"metadata.name": "web", "status.phase": "Pending"})).To(BeTrue())
+	g.Expect(selector.Matches(fields.Set{"metadata.name": "web", "status.phase": "Running"})).To(BeFalse())
+
+	opt, err := Fields().Eq("metadata.name", "web").ListOption()
+	g.Expect(err).NotTo(HaveOccurred())
+	listOpts := &client.ListOptions{}
+	opt.ApplyToList(listOpts)
+	g.Expect(listOpts.FieldSelector.String()).To(Equal("metadata.name=web"))
+
+	_, err = Fields().Eq("", "web").NotEq("a=b", "c").Build()
+	g.Expect(err).To(MatchError(ContainSubstring("field name must not be empty")))
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid field name "a=b"`)))
+	g.Expect(func() { Fields().Eq(" ", "x").MustBuild() }).To(Panic())
+}
@@ -23,7 +23,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"hash/fnv"
 	"log"
 	"net/http"
 	"os"
@@ -68,7 +67,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlcluster "sigs.k8s.io/controller-runtime/pkg/cluster"
-	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
@@ -387,13 +385,6 @@ func (a *AppBuilder) WithFieldIndexer(fieldIndexer ...fieldindexer.FieldIndexer)
 	return a
 }
 
-func getLeaderElectionID(name, domain string) string {
-	hasher := fnv.New32a()
-	// Hash.Write never returns an error
-	_, _ = hasher.Write([]byte(name))
-	return fmt.Sprintf("%x.%s", hasher.Sum(nil), domain)
-}
-
 // Controllers adds controllers to the app, will start a manager under the hood
 func (a *AppBuilder) Controllers(ctors ...controllers.SetupChecker) *AppBuilder {
 	a.init()
@@ -409,7 +400,7 @@ func (a *AppBuilder) Controllers(ctors ...controllers.SetupChecker) *AppBuilder
 		LeaseDuration:          &LeaderElectionLeaseDuration,
 		RetryPeriod:            &LeaderElectionRetryPeriod,
 		RenewDeadline:          &LeaderElectionRenewDeadline,
-		LeaderElectionID:       getLeaderElectionID(a.Name, "alauda.io"),
+		LeaderElectionID:       controllers.LeaderElectionID(a.Name),
 	}
 	// If the value is empty, the default behavior will still be used.
 	// Ref: https://github.com/kubernetes-sigs/controller-runtime/blob/b9219528d95974cb4f5b06f86c9b1c9b7d3045a5/pkg/manager/manager.go#L551
@@ -431,11 +422,8 @@ func (a *AppBuilder) Controllers(ctors ...controllers.SetupChecker) *AppBuilder
 	a.Manager = controllers.ControllerManager{
 		Manager: a.Manager,
 	}
-	if err := a.Manager.AddHealthzCheck(healthzRoutePath, healthz.Ping); err != nil {
-		a.Logger.Fatalw("unable to set up health check", "err", err)
-	}
-	if err := a.Manager.AddReadyzCheck(readyzRoutePath, healthz.Ping); err != nil {
-		a.Logger.Fatalw("unable to set up ready check", "err", err)
+	if _, err := controllers.RegisterHealthChecks(a.Manager); err != nil {
+		a.Logger.Fatalw("unable to set up health checks", "err", err)
 	}
 
 	a.Context = kmanager.WithManager(a.Context, a.Manager)
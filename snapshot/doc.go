@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot saves selected cluster objects into a multi-document YAML
+// archive and restores them, e.g. for backup and migration subcommands of clis.
+//
+// Objects are selected by kind, namespace and label selector, stripped of the
+// fields set by the api server and written in a stable order. Secrets can be
+// included as is, redacted, omitted or sealed with secretcrypt recipients:
+//
+//	snap, err := snapshot.Take(ctx, clt, []snapshot.Selection{
+//		{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"), Namespace: "demo"},
+//		{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Secret"), Namespace: "demo"},
+//	}, snapshot.WithSecretPolicy(snapshot.SecretsRedact))
+//	err = snap.Write(file)
+//
+// Restoring creates the objects, existing objects are skipped, overwritten or
+// fail the restore depending on the collision policy:
+//
+//	snap, err := snapshot.Read(file)
+//	result, err := snapshot.Restore(ctx, clt, snap,
+//		snapshot.WithCollisionPolicy(snapshot.Overwrite), snapshot.WithTargetNamespace("demo-copy"))
+package snapshot
@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/AlaudaDevops/pkg/secretcrypt"
+)
+
+// CollisionPolicy decides what happens when a restored object already exists
+type CollisionPolicy string
+
+const (
+	// Skip keeps the existing object
+	Skip CollisionPolicy = "Skip"
+	// Overwrite replaces the existing object
+	Overwrite CollisionPolicy = "Overwrite"
+	// Fail stops the restore
+	Fail CollisionPolicy = "Fail"
+)
+
+// Action done on a restored object
+type Action string
+
+const (
+	// CreatedAction the object did not exist and was created
+	CreatedAction Action = "created"
+	// OverwrittenAction the object existed and was replaced
+	OverwrittenAction Action = "overwritten"
+	// SkippedAction the object was not restored
+	SkippedAction Action = "skipped"
+)
+
+// Change is the result of restoring an object
+type Change struct {
+	// GroupVersionKind of the object
+	GroupVersionKind schema.GroupVersionKind
+	// Key of the object
+	Key client.ObjectKey
+	// Action done on the object
+	Action Action
+	// Reason the object was skipped
+	Reason string
+}
+
+// String returns the change like kubectl, e.g. configmap/name created
+func (c Change) String() string {
+	kind := strings.ToLower(c.GroupVersionKind.Kind)
+	if c.GroupVersionKind.Group != "" {
+		kind += "." + c.GroupVersionKind.Group
+	}
+	if c.Reason != "" {
+		return fmt.Sprintf("%s/%s %s: %s", kind, c.Key.Name, c.Action, c.Reason)
+	}
+	return fmt.Sprintf("%s/%s %s", kind, c.Key.Name, c.Action)
+}
+
+// Result of restoring a snapshot
+type Result struct {
+	// DryRun is true when no change was persisted
+	DryRun bool
+	// Changes done on the objects in order
+	Changes []Change
+}
+
+// Print writes one line per change into w
+func (r *Result) Print(w io.Writer) error {
+	suffix := ""
+	if r.DryRun {
+		suffix = " (dry run)"
+	}
+	for _, change := range r.Changes {
+		if _, err := fmt.Fprintf(w, "%s%s\n", change, suffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreOption configures Restore
+type RestoreOption func(*restoreOptions)
+
+type restoreOptions struct {
+	collisionPolicy CollisionPolicy
+	namespace       string
+	identities      []secretcrypt.Identity
+	dryRun          bool
+}
+
+// WithCollisionPolicy sets what happens when an object already exists, defaults to Skip
+func WithCollisionPolicy(policy CollisionPolicy) RestoreOption {
+	return func(o *restoreOptions) {
+		o.collisionPolicy = policy
+	}
+}
+
+// WithTargetNamespace restores namespaced objects into namespace
+func WithTargetNamespace(namespace string) RestoreOption {
+	return func(o *restoreOptions) {
+		o.namespace = namespace
+	}
+}
+
+// WithIdentities unseals the values of secrets sealed by WithRecipients
+func WithIdentities(identities ...secretcrypt.Identity) RestoreOption {
+	return func(o *restoreOptions) {
+		o.identities = append(o.identities, identities...)
+	}
+}
+
+// WithDryRun sends the changes as server side dry runs
+func WithDryRun() RestoreOption {
+	return func(o *restoreOptions) {
+		o.dryRun = true
+	}
+}
+
+// restorePriority of group kinds other objects depend on, restored first
+var restorePriority = map[schema.GroupKind]int{
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: 2,
+	{Kind: "Namespace"}: 1,
+}
+
+// Restore creates the objects of the snapshot, definitions and namespaces first.
+// Redacted secrets are skipped. Changes done before a failure are returned with the error
+func Restore(ctx context.Context, clt client.Client, snapshot *Snapshot, opts ...RestoreOption) (*Result, error) {
+	options := &restoreOptions{collisionPolicy: Skip}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	objects := append([]*unstructured.Unstructured{}, snapshot.Objects...)
+	sort.SliceStable(objects, func(i, j int) bool {
+		return restorePriority[objects[i].GroupVersionKind().GroupKind()] > restorePriority[objects[j].GroupVersionKind().GroupKind()]
+	})
+
+	result := &Result{DryRun: options.dryRun}
+	for _, obj := range objects {
+		change, err := options.restore(ctx, clt, obj.DeepCopy())
+		if err != nil {
+			return result, fmt.Errorf("restore %s %s failed: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	return result, nil
+}
+
+func (o *restoreOptions) restore(ctx context.Context, clt client.Client, obj *unstructured.Unstructured) (change Change, err error) {
+	change.GroupVersionKind = obj.GroupVersionKind()
+	if o.namespace != "" && obj.GetNamespace() != "" {
+		obj.SetNamespace(o.namespace)
+	}
+	change.Key = client.ObjectKeyFromObject(obj)
+
+	if change.GroupVersionKind.GroupKind() == secretGroupKind {
+		if obj.GetAnnotations()[RedactedAnnotation] == "true" {
+			change.Action, change.Reason = SkippedAction, "values are redacted"
+			return
+		}
+		if err = o.unseal(ctx, obj); err != nil {
+			return
+		}
+	}
+
+	var createOpts []client.CreateOption
+	var updateOpts []client.UpdateOption
+	if o.dryRun {
+		createOpts = append(createOpts, client.DryRunAll)
+		updateOpts = append(updateOpts, client.DryRunAll)
+	}
+	err = clt.Create(ctx, obj, createOpts...)
+	if err == nil {
+		change.Action = CreatedAction
+		return
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return
+	}
+
+	switch o.collisionPolicy {
+	case Overwrite:
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err = clt.Get(ctx, change.Key, live); err != nil {
+			return
+		}
+		obj.SetResourceVersion(live.GetResourceVersion())
+		if err = clt.Update(ctx, obj, updateOpts...); err != nil {
+			return
+		}
+		change.Action = OverwrittenAction
+	case Fail:
+		return
+	default:
+		change.Action, change.Reason, err = SkippedAction, "already exists", nil
+	}
+	return
+}
+
+func (o *restoreOptions) unseal(ctx context.Context, obj *unstructured.Unstructured) error {
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return err
+	}
+	sealed := false
+	for _, value := range data {
+		sealed = sealed || secretcrypt.IsSealed(value)
+	}
+	if !sealed {
+		return nil
+	}
+	if data, err = secretcrypt.DecryptMap(ctx, data, o.identities...); err != nil {
+		return err
+	}
+	return unstructured.SetNestedStringMap(obj.Object, data, "data")
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kclient "github.com/AlaudaDevops/pkg/client"
+	"github.com/AlaudaDevops/pkg/normalize"
+	"github.com/AlaudaDevops/pkg/secretcrypt"
+	"github.com/AlaudaDevops/pkg/yamlutil"
+)
+
+// RedactedAnnotation marks secrets whose values were redacted, they are not restored
+const RedactedAnnotation = "snapshot.alauda.io/redacted"
+
+// Selection selects objects of a kind
+type Selection struct {
+	// GroupVersionKind of the objects
+	GroupVersionKind schema.GroupVersionKind
+	// Namespace of the objects, all namespaces when empty. Ignored for cluster scoped kinds
+	Namespace string
+	// Selector of the objects labels, all objects when nil
+	Selector labels.Selector
+}
+
+// SecretPolicy decides how the values of secrets are saved
+type SecretPolicy string
+
+const (
+	// SecretsInclude saves the values of secrets as is
+	SecretsInclude SecretPolicy = "Include"
+	// SecretsRedact removes the values of secrets, redacted secrets are not restored
+	SecretsRedact SecretPolicy = "Redact"
+	// SecretsOmit does not save secrets
+	SecretsOmit SecretPolicy = "Omit"
+)
+
+var secretGroupKind = schema.GroupKind{Kind: "Secret"}
+
+// Snapshot is an ordered set of objects
+type Snapshot struct {
+	// Objects sorted by group, kind, namespace and name
+	Objects []*unstructured.Unstructured
+}
+
+// TakeOption configures Take
+type TakeOption func(*takeOptions)
+
+type takeOptions struct {
+	secretPolicy SecretPolicy
+	recipients   []secretcrypt.Recipient
+}
+
+// WithSecretPolicy sets how the values of secrets are saved, defaults to SecretsInclude
+func WithSecretPolicy(policy SecretPolicy) TakeOption {
+	return func(o *takeOptions) {
+		o.secretPolicy = policy
+	}
+}
+
+// WithRecipients seals the values of included secrets for recipients,
+// restoring them requires one of their identities
+func WithRecipients(recipients ...secretcrypt.Recipient) TakeOption {
+	return func(o *takeOptions) {
+		o.recipients = append(o.recipients, recipients...)
+	}
+}
+
+// Take lists the selected objects, removing the fields set by the api server and owner references
+// as the uids of the owners change when restored
+func Take(ctx context.Context, clt client.Client, selections []Selection, opts ...TakeOption) (*Snapshot, error) {
+	options := &takeOptions{secretPolicy: SecretsInclude}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	snapshot := &Snapshot{}
+	for _, selection := range selections {
+		gvk := selection.GroupVersionKind
+		if gvk.GroupKind() == secretGroupKind && options.secretPolicy == SecretsOmit {
+			continue
+		}
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		mapping, err := clt.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, err
+		}
+		var listOpts []client.ListOption
+		if selection.Namespace != "" && mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			listOpts = append(listOpts, client.InNamespace(selection.Namespace))
+		}
+		if selection.Selector != nil {
+			listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selection.Selector})
+		}
+		err = kclient.ForEachListItem(ctx, clt, list, func(item client.Object) error {
+			obj, ok := item.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("list item %T is not unstructured", item)
+			}
+			obj = obj.DeepCopy()
+			obj.SetGroupVersionKind(gvk)
+			normalize.RemoveServerFields(obj.Object)
+			unstructured.RemoveNestedField(obj.Object, "metadata", "ownerReferences")
+			if gvk.GroupKind() == secretGroupKind {
+				if err := options.secret(ctx, obj); err != nil {
+					return fmt.Errorf("secret %s: %w", client.ObjectKeyFromObject(obj), err)
+				}
+			}
+			snapshot.Objects = append(snapshot.Objects, obj)
+			return nil
+		}, kclient.WithListOptions(listOpts...))
+		if err != nil {
+			return nil, fmt.Errorf("list %s failed: %w", gvk.Kind, err)
+		}
+	}
+	snapshot.sort()
+	return snapshot, nil
+}
+
+func (o *takeOptions) secret(ctx context.Context, obj *unstructured.Unstructured) error {
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	switch {
+	case o.secretPolicy == SecretsRedact:
+		unstructured.RemoveNestedField(obj.Object, "data")
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[RedactedAnnotation] = "true"
+		obj.SetAnnotations(annotations)
+	case len(o.recipients) > 0:
+		if data, err = secretcrypt.EncryptMap(ctx, data, o.recipients...); err != nil {
+			return err
+		}
+		return unstructured.SetNestedStringMap(obj.Object, data, "data")
+	}
+	return nil
+}
+
+func (s *Snapshot) sort() {
+	sort.SliceStable(s.Objects, func(i, j int) bool {
+		a, b := s.Objects[i], s.Objects[j]
+		gka, gkb := a.GroupVersionKind().GroupKind(), b.GroupVersionKind().GroupKind()
+		switch {
+		case gka.Group != gkb.Group:
+			return gka.Group < gkb.Group
+		case gka.Kind != gkb.Kind:
+			return gka.Kind < gkb.Kind
+		case a.GetNamespace() != b.GetNamespace():
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+}
+
+// Write writes the objects into w as a multi-document YAML archive
+func (s *Snapshot) Write(w io.Writer) error {
+	for i, obj := range s.Objects {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("marshal %s %s failed: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		}
+		if i > 0 {
+			if _, err = io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read reads a snapshot written by Write
+func Read(r io.Reader) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	decoder := yamlutil.NewDecoder(r)
+	for {
+		content := map[string]interface{}{}
+		err := decoder.Decode(&content)
+		if errors.Is(err, io.EOF) {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj := &unstructured.Unstructured{Object: content}
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("document %d is not an object with kind and name", len(snapshot.Objects))
+		}
+		snapshot.Objects = append(snapshot.Objects, obj)
+	}
+}
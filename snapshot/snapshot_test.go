@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/AlaudaDevops/pkg/secretcrypt"
+)
+
+var (
+	configMapGVK = corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	secretGVK    = corev1.SchemeGroupVersion.WithKind("Secret")
+	namespaceGVK = corev1.SchemeGroupVersion.WithKind("Namespace")
+)
+
+func newClient(objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(configMapGVK, meta.RESTScopeNamespace)
+	mapper.Add(secretGVK, meta.RESTScopeNamespace)
+	mapper.Add(namespaceGVK, meta.RESTScopeRoot)
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(mapper).WithObjects(objs...).Build()
+}
+
+func objects() []client.Object {
+	return []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo", Labels: map[string]string{"app": "demo"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "b", Labels: map[string]string{"app": "demo"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "a", UID: "uid", Controller: ptr.To(true)}}},
+			Data: map[string]string{"key": "b"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "a", Labels: map[string]string{"app": "demo"}}, Data: map[string]string{"key": "a"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "other"}, Data: map[string]string{"key": "other"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "token", Labels: map[string]string{"app": "demo"}}, Data: map[string][]byte{"token": []byte("secret")}},
+	}
+}
+
+func selections() []Selection {
+	selector := labels.SelectorFromSet(labels.Set{"app": "demo"})
+	return []Selection{
+		{GroupVersionKind: secretGVK, Namespace: "demo", Selector: selector},
+		{GroupVersionKind: configMapGVK, Namespace: "demo", Selector: selector},
+		{GroupVersionKind: namespaceGVK, Namespace: "ignored", Selector: selector},
+	}
+}
+
+func TestTake(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := newClient(objects()...)
+
+	snap, err := Take(ctx, clt, selections())
+	g.Expect(err).To(BeNil())
+	buf := &bytes.Buffer{}
+	g.Expect(snap.Write(buf)).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`apiVersion: v1
+data:
+  key: a
+kind: ConfigMap
+metadata:
+  labels:
+    app: demo
+  name: a
+  namespace: demo
+---
+apiVersion: v1
+data:
+  key: b
+kind: ConfigMap
+metadata:
+  labels:
+    app: demo
+  name: b
+  namespace: demo
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  labels:
+    app: demo
+  name: demo
+spec: {}
+---
+apiVersion: v1
+data:
+  token: c2VjcmV0
+kind: Secret
+metadata:
+  labels:
+    app: demo
+  name: token
+  namespace: demo
+`))
+
+	read, err := Read(buf)
+	g.Expect(err).To(BeNil())
+	g.Expect(read).To(Equal(snap))
+
+	_, err = Read(bytes.NewBufferString("key: value\n"))
+	g.Expect(err).To(MatchError("document 0 is not an object with kind and name"))
+}
+
+func TestTake_secrets(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := newClient(objects()...)
+
+	snap, err := Take(ctx, clt, selections(), WithSecretPolicy(SecretsOmit))
+	g.Expect(err).To(BeNil())
+	g.Expect(snap.Objects).To(HaveLen(3))
+
+	snap, err = Take(ctx, clt, selections(), WithSecretPolicy(SecretsRedact))
+	g.Expect(err).To(BeNil())
+	secret := snap.Objects[3]
+	g.Expect(secret.GetAnnotations()).To(HaveKeyWithValue(RedactedAnnotation, "true"))
+	g.Expect(secret.Object).NotTo(HaveKey("data"))
+
+	identity, err := secretcrypt.GenerateX25519Identity()
+	g.Expect(err).To(BeNil())
+	snap, err = Take(ctx, clt, selections(), WithRecipients(identity.Recipient()))
+	g.Expect(err).To(BeNil())
+	g.Expect(secretcrypt.IsSealed(snap.Objects[3].Object["data"].(map[string]interface{})["token"].(string))).To(BeTrue())
+
+	// sealed secrets are restored with an identity
+	target := newClient()
+	_, err = Restore(ctx, target, snap)
+	g.Expect(err).To(MatchError(ContainSubstring("restore Secret demo/token failed")))
+	_, err = Restore(ctx, target, snap, WithIdentities(identity))
+	g.Expect(err).To(BeNil())
+	restored := &corev1.Secret{}
+	g.Expect(target.Get(ctx, client.ObjectKey{Namespace: "demo", Name: "token"}, restored)).To(Succeed())
+	g.Expect(restored.Data).To(Equal(map[string][]byte{"token": []byte("secret")}))
+}
+
+func TestRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	snap, err := Take(ctx, newClient(objects()...), selections(), WithSecretPolicy(SecretsRedact))
+	g.Expect(err).To(BeNil())
+
+	changed := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "copy", Name: "a"}, Data: map[string]string{"key": "changed"}}
+	target := newClient(changed)
+	result, err := Restore(ctx, target, snap, WithTargetNamespace("copy"))
+	g.Expect(err).To(BeNil())
+	buf := &bytes.Buffer{}
+	g.Expect(result.Print(buf)).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`namespace/demo created
+configmap/a skipped: already exists
+configmap/b created
+secret/token skipped: values are redacted
+`))
+	cm := &corev1.ConfigMap{}
+	g.Expect(target.Get(ctx, client.ObjectKey{Namespace: "copy", Name: "b"}, cm)).To(Succeed())
+	g.Expect(cm.OwnerReferences).To(BeEmpty())
+
+	result, err = Restore(ctx, target, snap, WithTargetNamespace("copy"), WithCollisionPolicy(Overwrite))
+	g.Expect(err).To(BeNil())
+	g.Expect(result.Changes[1].Action).To(Equal(OverwrittenAction))
+	g.Expect(target.Get(ctx, client.ObjectKey{Namespace: "copy", Name: "a"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "a"}))
+
+	result, err = Restore(ctx, target, snap, WithTargetNamespace("copy"), WithCollisionPolicy(Fail))
+	g.Expect(err).To(MatchError(ContainSubstring("restore Namespace /demo failed")))
+	g.Expect(result.Changes).To(BeEmpty())
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// deploymentResult waits for all replicas to be updated, ready and available
+// and fails when the progress deadline is exceeded
+func deploymentResult(obj *unstructured.Unstructured) (Result, error) {
+	conditions, err := getConditions(obj)
+	if err != nil {
+		return Result{}, err
+	}
+	if c, ok := conditions["Progressing"]; ok && c.Reason == "ProgressDeadlineExceeded" {
+		return Result{Status: FailedStatus, Message: c.message()}, nil
+	}
+	replicas := nestedInt(obj, 1, "spec", "replicas")
+	updated := nestedInt(obj, 0, "status", "updatedReplicas")
+	ready := nestedInt(obj, 0, "status", "readyReplicas")
+	available := nestedInt(obj, 0, "status", "availableReplicas")
+	total := nestedInt(obj, 0, "status", "replicas")
+	switch {
+	case updated < replicas:
+		return inProgress("updated: %d/%d", updated, replicas), nil
+	case total > updated:
+		return inProgress("pending termination: %d", total-updated), nil
+	case available < updated:
+		return inProgress("available: %d/%d", available, updated), nil
+	case ready < updated:
+		return inProgress("ready: %d/%d", ready, updated), nil
+	}
+	if c, ok := conditions["Available"]; ok && c.Status == "False" {
+		return Result{Status: InProgressStatus, Message: c.message()}, nil
+	}
+	return currentResult("deployment is available, replicas: %d", replicas), nil
+}
+
+// statefulSetResult waits for all replicas to be ready and updated to the latest revision
+func statefulSetResult(obj *unstructured.Unstructured) (Result, error) {
+	replicas := nestedInt(obj, 1, "spec", "replicas")
+	ready := nestedInt(obj, 0, "status", "readyReplicas")
+	current := nestedInt(obj, 0, "status", "currentReplicas")
+	updated := nestedInt(obj, 0, "status", "updatedReplicas")
+	partition := nestedInt(obj, 0, "spec", "updateStrategy", "rollingUpdate", "partition")
+	strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+
+	if ready < replicas {
+		return inProgress("ready: %d/%d", ready, replicas), nil
+	}
+	if strategy == "OnDelete" {
+		return currentResult("statefulset is ready, replicas: %d", replicas), nil
+	}
+	if partition > 0 {
+		if expected := replicas - partition; updated < expected {
+			return inProgress("updated: %d/%d in partition", updated, expected), nil
+		}
+		return currentResult("partitioned rollout complete, updated: %d", updated), nil
+	}
+	currentRevision, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+	if currentRevision != updateRevision {
+		return inProgress("updating to revision %s, updated: %d/%d", updateRevision, updated, replicas), nil
+	}
+	if current < replicas {
+		return inProgress("current: %d/%d", current, replicas), nil
+	}
+	return currentResult("statefulset is ready, replicas: %d", replicas), nil
+}
+
+// daemonSetResult waits for all scheduled pods to be updated, ready and available
+func daemonSetResult(obj *unstructured.Unstructured) (Result, error) {
+	desired := nestedInt(obj, 0, "status", "desiredNumberScheduled")
+	updated := nestedInt(obj, 0, "status", "updatedNumberScheduled")
+	ready := nestedInt(obj, 0, "status", "numberReady")
+	available := nestedInt(obj, 0, "status", "numberAvailable")
+	switch {
+	case updated < desired:
+		return inProgress("updated: %d/%d", updated, desired), nil
+	case ready < desired:
+		return inProgress("ready: %d/%d", ready, desired), nil
+	case available < desired:
+		return inProgress("available: %d/%d", available, desired), nil
+	}
+	return currentResult("daemonset is ready, scheduled: %d", desired), nil
+}
+
+// replicaSetResult waits for all replicas to be labeled, ready and available
+func replicaSetResult(obj *unstructured.Unstructured) (Result, error) {
+	replicas := nestedInt(obj, 1, "spec", "replicas")
+	labeled := nestedInt(obj, 0, "status", "fullyLabeledReplicas")
+	ready := nestedInt(obj, 0, "status", "readyReplicas")
+	available := nestedInt(obj, 0, "status", "availableReplicas")
+	switch {
+	case labeled < replicas:
+		return inProgress("labeled: %d/%d", labeled, replicas), nil
+	case ready < replicas:
+		return inProgress("ready: %d/%d", ready, replicas), nil
+	case available < replicas:
+		return inProgress("available: %d/%d", available, replicas), nil
+	}
+	return currentResult("replicaset is ready, replicas: %d", replicas), nil
+}
+
+// podResult is Current when the pod is ready or succeeded
+// and Failed when it failed or a container is crash looping
+func podResult(obj *unstructured.Unstructured) (Result, error) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return currentResult("pod succeeded"), nil
+	case "Failed":
+		return Result{Status: FailedStatus, Message: "pod failed"}, nil
+	}
+	statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+	for _, item := range statuses {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		reason, _, _ := unstructured.NestedString(fields, "state", "waiting", "reason")
+		if reason == "CrashLoopBackOff" || reason == "ImagePullBackOff" || reason == "ErrImagePull" {
+			name, _, _ := unstructured.NestedString(fields, "name")
+			return Result{Status: FailedStatus, Message: fmt.Sprintf("container %s is waiting: %s", name, reason)}, nil
+		}
+	}
+	conditions, err := getConditions(obj)
+	if err != nil {
+		return Result{}, err
+	}
+	if c, ok := conditions["Ready"]; ok && c.Status == "True" {
+		return currentResult("pod is ready"), nil
+	}
+	return inProgress("pod is %s", phaseOrUnknown(phase)), nil
+}
+
+// jobResult is Current when the job completed and Failed when it failed
+func jobResult(obj *unstructured.Unstructured) (Result, error) {
+	conditions, err := getConditions(obj)
+	if err != nil {
+		return Result{}, err
+	}
+	if c, ok := conditions["Failed"]; ok && c.Status == "True" {
+		return Result{Status: FailedStatus, Message: c.message()}, nil
+	}
+	if c, ok := conditions["Complete"]; ok && c.Status == "True" {
+		return currentResult("job completed"), nil
+	}
+	succeeded := nestedInt(obj, 0, "status", "succeeded")
+	active := nestedInt(obj, 0, "status", "active")
+	return inProgress("job is running, active: %d, succeeded: %d", active, succeeded), nil
+}
+
+// pvcResult is Current when the claim is bound
+func pvcResult(obj *unstructured.Unstructured) (Result, error) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	if phase == "Bound" {
+		return currentResult("volume claim is bound"), nil
+	}
+	return inProgress("volume claim is %s", phaseOrUnknown(phase)), nil
+}
+
+// serviceResult waits for load balancers to get an ingress address
+func serviceResult(obj *unstructured.Unstructured) (Result, error) {
+	serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+	if serviceType != "LoadBalancer" {
+		return currentResult("service is ready"), nil
+	}
+	ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+	if len(ingress) == 0 {
+		return inProgress("waiting for load balancer ingress"), nil
+	}
+	return currentResult("load balancer is ready"), nil
+}
+
+// crdResult is Current when the definition is established and Failed when its names are not accepted
+func crdResult(obj *unstructured.Unstructured) (Result, error) {
+	conditions, err := getConditions(obj)
+	if err != nil {
+		return Result{}, err
+	}
+	if c, ok := conditions["NamesAccepted"]; ok && c.Status == "False" {
+		return Result{Status: FailedStatus, Message: c.message()}, nil
+	}
+	if c, ok := conditions["Established"]; ok && c.Status == "True" {
+		return currentResult("custom resource definition is established"), nil
+	}
+	return inProgress("custom resource definition is not established"), nil
+}
+
+func inProgress(format string, args ...interface{}) Result {
+	return Result{Status: InProgressStatus, Message: fmt.Sprintf(format, args...)}
+}
+
+func currentResult(format string, args ...interface{}) Result {
+	return Result{Status: CurrentStatus, Message: fmt.Sprintf(format, args...)}
+}
+
+func phaseOrUnknown(phase string) string {
+	if phase == "" {
+		return "Unknown"
+	}
+	return phase
+}
+
+// nestedInt returns the integer in fields of obj, or defaultValue if not set or invalid
+func nestedInt(obj *unstructured.Unstructured, defaultValue int64, fields ...string) int64 {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	if err != nil || !found {
+		return defaultValue
+	}
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+	return defaultValue
+}
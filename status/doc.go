@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status computes the readiness of kubernetes objects, in the spirit of kstatus,
+// for built-in kinds and custom resources following the standard conditions,
+// and waits for a set of objects to become ready.
+//
+//	result, err := status.ComputeResult(obj)
+//	if result.Status == status.FailedStatus {
+//		return fmt.Errorf("%s failed: %s", obj.GetName(), result.Message)
+//	}
+//
+//	err = status.WaitForReady(ctx, cli, objs, 5*time.Minute)
+package status
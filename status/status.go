@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Status is the readiness status of an object
+type Status string
+
+const (
+	// InProgressStatus the object is being created or updated
+	InProgressStatus Status = "InProgress"
+	// FailedStatus the object failed and will not become ready without changes
+	FailedStatus Status = "Failed"
+	// CurrentStatus the object is ready and its status reflects its spec
+	CurrentStatus Status = "Current"
+	// TerminatingStatus the object is being deleted
+	TerminatingStatus Status = "Terminating"
+	// NotFoundStatus the object does not exist
+	NotFoundStatus Status = "NotFound"
+)
+
+// Standard condition types used by custom resources to report their status
+const (
+	// ReadyConditionType condition reporting if the object is ready
+	ReadyConditionType = "Ready"
+	// ReconcilingConditionType condition reporting the object is being reconciled
+	ReconcilingConditionType = "Reconciling"
+	// StalledConditionType condition reporting the object cannot make progress
+	StalledConditionType = "Stalled"
+)
+
+// Result is the status of an object with a human readable message
+type Result struct {
+	Status  Status
+	Message string
+}
+
+// computeFunc computes the result of a specific kind
+type computeFunc func(obj *unstructured.Unstructured) (Result, error)
+
+// kindComputeFuncs built-in kinds with specific readiness rules
+var kindComputeFuncs = map[schema.GroupKind]computeFunc{
+	{Group: "apps", Kind: "Deployment"}:                               deploymentResult,
+	{Group: "apps", Kind: "StatefulSet"}:                              statefulSetResult,
+	{Group: "apps", Kind: "DaemonSet"}:                                daemonSetResult,
+	{Group: "apps", Kind: "ReplicaSet"}:                               replicaSetResult,
+	{Group: "", Kind: "Pod"}:                                          podResult,
+	{Group: "", Kind: "PersistentVolumeClaim"}:                        pvcResult,
+	{Group: "", Kind: "Service"}:                                      serviceResult,
+	{Group: "batch", Kind: "Job"}:                                     jobResult,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: crdResult,
+}
+
+// Compute returns the readiness status of obj
+func Compute(obj *unstructured.Unstructured) (Status, error) {
+	result, err := ComputeResult(obj)
+	return result.Status, err
+}
+
+// ComputeResult returns the readiness status of obj with a message explaining it.
+// Objects being deleted are Terminating, built-in kinds like Deployments, StatefulSets, Pods or Jobs
+// follow their own rules, other kinds are evaluated using status.observedGeneration
+// and the Stalled, Reconciling and Ready conditions. Objects without status are Current.
+func ComputeResult(obj *unstructured.Unstructured) (Result, error) {
+	if obj == nil {
+		return Result{Status: NotFoundStatus, Message: "object not found"}, nil
+	}
+	if obj.GetDeletionTimestamp() != nil {
+		return Result{Status: TerminatingStatus, Message: "object is being deleted"}, nil
+	}
+	if result, done := generationResult(obj); done {
+		return result, nil
+	}
+	if compute, ok := kindComputeFuncs[obj.GroupVersionKind().GroupKind()]; ok {
+		return compute(obj)
+	}
+	return conditionsResult(obj)
+}
+
+// generationResult returns InProgress when the status of obj was not updated for its latest generation
+func generationResult(obj *unstructured.Unstructured) (result Result, done bool) {
+	observed := nestedInt(obj, -1, "status", "observedGeneration")
+	if generation := obj.GetGeneration(); observed >= 0 && observed < generation {
+		return Result{
+			Status:  InProgressStatus,
+			Message: fmt.Sprintf("generation %d is not observed yet, observed generation is %d", generation, observed),
+		}, true
+	}
+	return result, false
+}
+
+// conditionsResult evaluates the standard conditions of obj
+func conditionsResult(obj *unstructured.Unstructured) (Result, error) {
+	conditions, err := getConditions(obj)
+	if err != nil {
+		return Result{}, err
+	}
+	if c, ok := conditions[StalledConditionType]; ok && c.Status == "True" {
+		return Result{Status: FailedStatus, Message: c.message()}, nil
+	}
+	if c, ok := conditions[ReconcilingConditionType]; ok && c.Status == "True" {
+		return Result{Status: InProgressStatus, Message: c.message()}, nil
+	}
+	if c, ok := conditions[ReadyConditionType]; ok && c.Status != "True" {
+		return Result{Status: InProgressStatus, Message: c.message()}, nil
+	}
+	return Result{Status: CurrentStatus, Message: "resource is current"}, nil
+}
+
+// condition is the part of a status condition used to compute the status
+type condition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+func (c condition) message() string {
+	if c.Message != "" {
+		return c.Message
+	}
+	if c.Reason != "" {
+		return c.Reason
+	}
+	return fmt.Sprintf("%s is %s", c.Type, c.Status)
+}
+
+// getConditions returns the conditions in status.conditions of obj by type
+func getConditions(obj *unstructured.Unstructured) (map[string]condition, error) {
+	items, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return nil, err
+	}
+	conditions := make(map[string]condition, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := condition{}
+		c.Type, _, _ = unstructured.NestedString(fields, "type")
+		c.Status, _, _ = unstructured.NestedString(fields, "status")
+		c.Reason, _, _ = unstructured.NestedString(fields, "reason")
+		c.Message, _, _ = unstructured.NestedString(fields, "message")
+		conditions[c.Type] = c
+	}
+	return conditions, nil
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func fromYAML(g *WithT, content string) *unstructured.Unstructured {
+	data, err := yaml.YAMLToJSON([]byte(content))
+	g.Expect(err).To(BeNil())
+	obj := &unstructured.Unstructured{}
+	g.Expect(obj.UnmarshalJSON(data)).To(Succeed())
+	return obj
+}
+
+func TestCompute(t *testing.T) {
+	var data = []struct {
+		desc     string
+		yaml     string
+		expected Status
+	}{
+		{
+			desc: "terminating",
+			yaml: `
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: cm, deletionTimestamp: "2024-01-01T00:00:00Z"}`,
+			expected: TerminatingStatus,
+		},
+		{
+			desc: "object without status",
+			yaml: `
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: cm}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "generation not observed",
+			yaml: `
+apiVersion: example.com/v1
+kind: Foo
+metadata: {name: foo, generation: 2}
+status: {observedGeneration: 1, conditions: [{type: Ready, status: "True"}]}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "custom resource ready",
+			yaml: `
+apiVersion: example.com/v1
+kind: Foo
+metadata: {name: foo, generation: 2}
+status: {observedGeneration: 2, conditions: [{type: Ready, status: "True"}]}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "custom resource not ready",
+			yaml: `
+apiVersion: example.com/v1
+kind: Foo
+metadata: {name: foo}
+status: {conditions: [{type: Ready, status: "Unknown"}]}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "custom resource reconciling",
+			yaml: `
+apiVersion: example.com/v1
+kind: Foo
+metadata: {name: foo}
+status: {conditions: [{type: Reconciling, status: "True"}]}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "custom resource stalled",
+			yaml: `
+apiVersion: example.com/v1
+kind: Foo
+metadata: {name: foo}
+status: {conditions: [{type: Stalled, status: "True", message: "invalid spec"}]}`,
+			expected: FailedStatus,
+		},
+		{
+			desc: "deployment available",
+			yaml: `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: deploy, generation: 1}
+spec: {replicas: 2}
+status: {observedGeneration: 1, replicas: 2, updatedReplicas: 2, readyReplicas: 2, availableReplicas: 2}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "deployment rolling out",
+			yaml: `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: deploy, generation: 1}
+spec: {replicas: 2}
+status: {observedGeneration: 1, replicas: 3, updatedReplicas: 2, readyReplicas: 2, availableReplicas: 2}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "deployment progress deadline exceeded",
+			yaml: `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: deploy}
+spec: {replicas: 1}
+status: {conditions: [{type: Progressing, status: "False", reason: ProgressDeadlineExceeded}]}`,
+			expected: FailedStatus,
+		},
+		{
+			desc: "statefulset updating revision",
+			yaml: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata: {name: sts}
+spec: {replicas: 1}
+status: {readyReplicas: 1, currentReplicas: 1, updatedReplicas: 0, currentRevision: a, updateRevision: b}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "statefulset ready",
+			yaml: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata: {name: sts}
+spec: {replicas: 1}
+status: {readyReplicas: 1, currentReplicas: 1, updatedReplicas: 1, currentRevision: a, updateRevision: a}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "statefulset partitioned",
+			yaml: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata: {name: sts}
+spec: {replicas: 3, updateStrategy: {type: RollingUpdate, rollingUpdate: {partition: 2}}}
+status: {readyReplicas: 3, currentReplicas: 2, updatedReplicas: 1, currentRevision: a, updateRevision: b}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "daemonset not ready",
+			yaml: `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata: {name: ds}
+status: {desiredNumberScheduled: 3, updatedNumberScheduled: 3, numberReady: 2, numberAvailable: 2}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "replicaset ready",
+			yaml: `
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata: {name: rs}
+spec: {replicas: 1}
+status: {fullyLabeledReplicas: 1, readyReplicas: 1, availableReplicas: 1}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "pod ready",
+			yaml: `
+apiVersion: v1
+kind: Pod
+metadata: {name: pod}
+status: {phase: Running, conditions: [{type: Ready, status: "True"}]}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "pod pending",
+			yaml: `
+apiVersion: v1
+kind: Pod
+metadata: {name: pod}
+status: {phase: Pending}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "pod crash looping",
+			yaml: `
+apiVersion: v1
+kind: Pod
+metadata: {name: pod}
+status: {phase: Running, containerStatuses: [{name: app, state: {waiting: {reason: CrashLoopBackOff}}}]}`,
+			expected: FailedStatus,
+		},
+		{
+			desc: "pod succeeded",
+			yaml: `
+apiVersion: v1
+kind: Pod
+metadata: {name: pod}
+status: {phase: Succeeded}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "job failed",
+			yaml: `
+apiVersion: batch/v1
+kind: Job
+metadata: {name: job}
+status: {conditions: [{type: Failed, status: "True", reason: BackoffLimitExceeded}]}`,
+			expected: FailedStatus,
+		},
+		{
+			desc: "job complete",
+			yaml: `
+apiVersion: batch/v1
+kind: Job
+metadata: {name: job}
+status: {conditions: [{type: Complete, status: "True"}]}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "pvc pending",
+			yaml: `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata: {name: pvc}
+status: {phase: Pending}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "load balancer without ingress",
+			yaml: `
+apiVersion: v1
+kind: Service
+metadata: {name: svc}
+spec: {type: LoadBalancer}`,
+			expected: InProgressStatus,
+		},
+		{
+			desc: "cluster ip service",
+			yaml: `
+apiVersion: v1
+kind: Service
+metadata: {name: svc}
+spec: {type: ClusterIP}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "crd established",
+			yaml: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata: {name: foos.example.com}
+status: {conditions: [{type: NamesAccepted, status: "True"}, {type: Established, status: "True"}]}`,
+			expected: CurrentStatus,
+		},
+		{
+			desc: "crd names conflict",
+			yaml: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata: {name: foos.example.com}
+status: {conditions: [{type: NamesAccepted, status: "False", reason: NameConflict}]}`,
+			expected: FailedStatus,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			result, err := ComputeResult(fromYAML(g, item.yaml))
+			g.Expect(err).To(BeNil())
+			g.Expect(result.Status).To(Equal(item.expected), result.Message)
+			g.Expect(result.Message).NotTo(BeEmpty())
+		})
+	}
+}
+
+func TestCompute_invalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	status, err := Compute(nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(status).To(Equal(NotFoundStatus))
+
+	_, err = Compute(fromYAML(g, `
+apiVersion: example.com/v1
+kind: Foo
+metadata: {name: foo}
+status: {conditions: invalid}`))
+	g.Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPollInterval interval between checks of WaitForReady
+const DefaultPollInterval = 2 * time.Second
+
+type waitOptions struct {
+	pollInterval time.Duration
+}
+
+// WaitOption customizes WaitForReady
+type WaitOption func(*waitOptions)
+
+// WithPollInterval sets the interval between checks
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// WaitForReady waits until all objs are Current.
+// Returns an error as soon as an object is Failed, or when timeout expires
+// listing the objects that are not ready yet. Objects not found yet are waited for.
+func WaitForReady(ctx context.Context, cli client.Client, objs []client.Object, timeout time.Duration, opts ...WaitOption) error {
+	options := waitOptions{pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	logger := logging.FromContext(ctx)
+
+	pending := map[string]Result{}
+	err := wait.PollUntilContextTimeout(ctx, options.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		pending = map[string]Result{}
+		for _, obj := range objs {
+			key, result, err := getResult(ctx, cli, obj)
+			if err != nil {
+				return false, err
+			}
+			switch result.Status {
+			case CurrentStatus:
+				continue
+			case FailedStatus:
+				return false, fmt.Errorf("%s failed: %s", key, result.Message)
+			}
+			pending[key] = result
+		}
+		logger.Debugw("waiting for objects to be ready", "pending", len(pending), "total", len(objs))
+		return len(pending) == 0, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for objects to be ready: %s", describe(pending))
+	}
+	return err
+}
+
+// getResult gets the latest version of obj and computes its status
+func getResult(ctx context.Context, cli client.Client, obj client.Object) (key string, result Result, err error) {
+	gvk, err := cli.GroupVersionKindFor(obj)
+	if err != nil {
+		return "", result, err
+	}
+	key = fmt.Sprintf("%s %s", gvk.Kind, client.ObjectKeyFromObject(obj))
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(gvk)
+	if err = cli.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		if apierrors.IsNotFound(err) {
+			return key, Result{Status: NotFoundStatus, Message: "object not found"}, nil
+		}
+		return key, result, err
+	}
+	result, err = ComputeResult(live)
+	return key, result, err
+}
+
+// describe returns a sorted description of the pending objects
+func describe(pending map[string]Result) string {
+	descriptions := make([]string, 0, len(pending))
+	for key, result := range pending {
+		descriptions = append(descriptions, fmt.Sprintf("%s is %s: %s", key, result.Status, result.Message))
+	}
+	sort.Strings(descriptions)
+	return strings.Join(descriptions, "; ")
+}
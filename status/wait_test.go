@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWaitForReady(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}}
+	cli := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(deploy, cm).WithStatusSubresource(deploy).Build()
+	objs := []client.Object{deploy.DeepCopy(), cm.DeepCopy()}
+
+	err := WaitForReady(ctx, cli, objs, 50*time.Millisecond, WithPollInterval(10*time.Millisecond))
+	g.Expect(err).To(MatchError(ContainSubstring("timed out waiting for objects to be ready: Deployment default/deploy is InProgress: updated: 0/1")))
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		deploy.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1}
+		_ = cli.Status().Update(ctx, deploy)
+	}()
+	g.Expect(WaitForReady(ctx, cli, objs, 5*time.Second, WithPollInterval(10*time.Millisecond))).To(Succeed())
+}
+
+func TestWaitForReady_failed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+	}
+	missing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}}
+	cli := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(pod).Build()
+
+	err := WaitForReady(context.Background(), cli, []client.Object{pod}, 5*time.Second, WithPollInterval(10*time.Millisecond))
+	g.Expect(err).To(MatchError("Pod default/pod failed: pod failed"))
+
+	err = WaitForReady(context.Background(), cli, []client.Object{missing}, 30*time.Millisecond, WithPollInterval(10*time.Millisecond))
+	g.Expect(err).To(MatchError(ContainSubstring("ConfigMap default/missing is NotFound")))
+}
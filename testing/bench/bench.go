@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Options describes a benchmark run
+type Options struct {
+	// Client is used to seed objects before the run.
+	// When it is a *CountingClient its counters are reset after seeding
+	// and included in the Report.
+	Client client.Client
+	// Reconciler is the reconciler under benchmark
+	Reconciler reconcile.Reconciler
+
+	// Objects is the number of objects seeded before the run
+	Objects int
+	// NewObject builds the i-th seeded object
+	NewObject func(i int) client.Object
+
+	// Events is the total number of reconcile requests sent.
+	// Defaults to Objects when not set.
+	Events int
+	// EventsPerSecond limits the rate of reconcile requests.
+	// A value lower or equal to zero sends requests as fast as possible.
+	EventsPerSecond int
+	// Workers is the number of concurrent reconcile workers. Defaults to 1.
+	Workers int
+}
+
+func (o *Options) validate() error {
+	if o.Client == nil {
+		return fmt.Errorf("client should not be nil")
+	}
+	if o.Reconciler == nil {
+		return fmt.Errorf("reconciler should not be nil")
+	}
+	if o.Objects <= 0 {
+		return fmt.Errorf("objects should be greater than zero")
+	}
+	if o.NewObject == nil {
+		return fmt.Errorf("new object function should not be nil")
+	}
+	return nil
+}
+
+func (o *Options) defaults() {
+	if o.Events <= 0 {
+		o.Events = o.Objects
+	}
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+}
+
+// Seed creates all objects described by the options and returns
+// the requests that point to them
+func Seed(ctx context.Context, clt client.Client, count int, newObject func(i int) client.Object) ([]reconcile.Request, error) {
+	requests := make([]reconcile.Request, 0, count)
+	for i := 0; i < count; i++ {
+		obj := newObject(i)
+		if err := clt.Create(ctx, obj); err != nil {
+			return nil, fmt.Errorf("seed object %d: %w", i, err)
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+	}
+	return requests, nil
+}
+
+// Run seeds the objects and drives the reconciler with the configured
+// number of events, returning a Report with the collected measurements
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	opts.defaults()
+
+	requests, err := Seed(ctx, opts.Client, opts.Objects, opts.NewObject)
+	if err != nil {
+		return nil, err
+	}
+	return RunRequests(ctx, opts, requests)
+}
+
+// RunRequests drives the reconciler with the configured number of events cycling
+// through requests, e.g. of objects seeded beforehand into a fake client, returning
+// a Report with the collected measurements. Objects and NewObject are ignored
+// and Events defaults to the number of requests
+func RunRequests(ctx context.Context, opts Options, requests []reconcile.Request) (*Report, error) {
+	if opts.Reconciler == nil {
+		return nil, fmt.Errorf("reconciler should not be nil")
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("requests should not be empty")
+	}
+	if opts.Events <= 0 {
+		opts.Events = len(requests)
+	}
+	opts.defaults()
+
+	counting, _ := opts.Client.(*CountingClient)
+	if counting != nil {
+		counting.Reset()
+	}
+
+	limit := rate.Inf
+	if opts.EventsPerSecond > 0 {
+		limit = rate.Limit(opts.EventsPerSecond)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	report := &Report{Latencies: make([]time.Duration, 0, opts.Events)}
+	queue := make(chan reconcile.Request, opts.Workers)
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	start := time.Now()
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range queue {
+				began := time.Now()
+				result, err := opts.Reconciler.Reconcile(ctx, req)
+				latency := time.Since(began)
+
+				lock.Lock()
+				report.Latencies = append(report.Latencies, latency)
+				if err != nil {
+					report.Errors++
+				}
+				if result.Requeue || result.RequeueAfter > 0 {
+					report.Requeues++
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	var runErr error
+	for i := 0; i < opts.Events; i++ {
+		if runErr = limiter.Wait(ctx); runErr != nil {
+			break
+		}
+		queue <- requests[i%len(requests)]
+	}
+	close(queue)
+	wg.Wait()
+
+	report.Duration = time.Since(start)
+	report.Reconciles = len(report.Latencies)
+	if counting != nil {
+		report.ClientCalls = counting.Counts()
+	}
+	report.sortLatencies()
+	return report, runErr
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type configMapReconciler struct {
+	client.Client
+}
+
+func (r *configMapReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, cm); err != nil {
+		return reconcile.Result{}, err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data["reconciled"] = "true"
+	return reconcile.Result{}, r.Update(ctx, cm)
+}
+
+func newConfigMap(i int) client.Object {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("cm-%d", i)}}
+}
+
+func TestRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	clt := NewCountingClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+	report, err := Run(context.Background(), Options{
+		Client:     clt,
+		Reconciler: &configMapReconciler{Client: clt},
+		Objects:    10,
+		NewObject:  newConfigMap,
+		Events:     30,
+		Workers:    3,
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(report.Reconciles).To(Equal(30))
+	g.Expect(report.Errors).To(Equal(0))
+	g.Expect(report.ClientCalls).To(Equal(map[string]int64{VerbGet: 30, VerbUpdate: 30}))
+	g.Expect(report.ReconcilesPerSecond()).To(BeNumerically(">", 0))
+	g.Expect(report.P99()).To(BeNumerically(">=", report.Percentile(50)))
+}
+
+func TestRunValidation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := Run(context.Background(), Options{})
+	g.Expect(err).To(MatchError("client should not be nil"))
+}
+
+func TestRunRateLimited(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	clt := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	report, err := Run(context.Background(), Options{
+		Client:          clt,
+		Reconciler:      &configMapReconciler{Client: clt},
+		Objects:         2,
+		NewObject:       newConfigMap,
+		Events:          5,
+		EventsPerSecond: 50,
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(report.Reconciles).To(Equal(5))
+	g.Expect(report.ClientCalls).To(BeNil())
+	g.Expect(report.Duration).To(BeNumerically(">=", 60*time.Millisecond))
+}
+
+func TestReportPercentile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	report := &Report{}
+	g.Expect(report.P99()).To(Equal(time.Duration(0)))
+
+	for i := 100; i > 0; i-- {
+		report.Latencies = append(report.Latencies, time.Duration(i)*time.Millisecond)
+	}
+	report.sortLatencies()
+	g.Expect(report.Percentile(50)).To(Equal(50 * time.Millisecond))
+	g.Expect(report.P99()).To(Equal(99 * time.Millisecond))
+	g.Expect(report.Percentile(100)).To(Equal(100 * time.Millisecond))
+}
+
+func BenchmarkConfigMapReconciler(b *testing.B) {
+	clt := NewCountingClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+	report, err := Run(context.Background(), Options{
+		Client:     clt,
+		Reconciler: &configMapReconciler{Client: clt},
+		Objects:    100,
+		NewObject:  newConfigMap,
+		Events:     b.N,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	report.ReportMetrics(b)
+}
+
+func TestRunRequests(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	clt := NewCountingClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+	requests, err := Seed(ctx, clt, 5, newConfigMap)
+	g.Expect(err).To(BeNil())
+
+	report, err := RunRequests(ctx, Options{Client: clt, Reconciler: &configMapReconciler{Client: clt}}, requests)
+	g.Expect(err).To(BeNil())
+	g.Expect(report.Reconciles).To(Equal(5))
+	g.Expect(report.ClientCalls).To(Equal(map[string]int64{VerbGet: 5, VerbUpdate: 5}))
+
+	_, err = RunRequests(ctx, Options{Reconciler: &configMapReconciler{Client: clt}}, nil)
+	g.Expect(err).To(MatchError("requests should not be empty"))
+}
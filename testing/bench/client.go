@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Client call verbs counted by CountingClient
+const (
+	VerbGet          = "get"
+	VerbList         = "list"
+	VerbCreate       = "create"
+	VerbUpdate       = "update"
+	VerbPatch        = "patch"
+	VerbDelete       = "delete"
+	VerbDeleteAllOf  = "deleteallof"
+	VerbStatusUpdate = "status.update"
+	VerbStatusPatch  = "status.patch"
+)
+
+// CountingClient wraps a client.Client and counts every call by verb.
+// It is safe for concurrent use.
+type CountingClient struct {
+	client.Client
+
+	lock   sync.Mutex
+	counts map[string]int64
+}
+
+var _ client.Client = &CountingClient{}
+
+// NewCountingClient returns a CountingClient wrapping the given client
+func NewCountingClient(clt client.Client) *CountingClient {
+	return &CountingClient{Client: clt, counts: map[string]int64{}}
+}
+
+// Counts returns a copy of the current call counts indexed by verb
+func (c *CountingClient) Counts() map[string]int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	counts := make(map[string]int64, len(c.counts))
+	for verb, count := range c.counts {
+		counts[verb] = count
+	}
+	return counts
+}
+
+// Reset clears all call counts
+func (c *CountingClient) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.counts = map[string]int64{}
+}
+
+func (c *CountingClient) inc(verb string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.counts[verb]++
+}
+
+// Get counts and forwards a Get call
+func (c *CountingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.inc(VerbGet)
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// List counts and forwards a List call
+func (c *CountingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.inc(VerbList)
+	return c.Client.List(ctx, list, opts...)
+}
+
+// Create counts and forwards a Create call
+func (c *CountingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.inc(VerbCreate)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Update counts and forwards an Update call
+func (c *CountingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.inc(VerbUpdate)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch counts and forwards a Patch call
+func (c *CountingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.inc(VerbPatch)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete counts and forwards a Delete call
+func (c *CountingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.inc(VerbDelete)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// DeleteAllOf counts and forwards a DeleteAllOf call
+func (c *CountingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.inc(VerbDeleteAllOf)
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status returns a status writer that counts its calls
+func (c *CountingClient) Status() client.SubResourceWriter {
+	return &countingStatusWriter{SubResourceWriter: c.Client.Status(), parent: c}
+}
+
+type countingStatusWriter struct {
+	client.SubResourceWriter
+	parent *CountingClient
+}
+
+// Update counts and forwards a status Update call
+func (w *countingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	w.parent.inc(VerbStatusUpdate)
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+// Patch counts and forwards a status Patch call
+func (w *countingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	w.parent.inc(VerbStatusPatch)
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
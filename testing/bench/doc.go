@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bench contains a harness to benchmark reconcilers against a fake
+// or envtest cluster. It seeds a number of objects, drives reconcile requests
+// at a fixed rate and reports throughput, latency percentiles and the number
+// of client calls made by the reconciler.
+//
+//	func BenchmarkReconcile(b *testing.B) {
+//		clt := fake.NewClientBuilder().WithScheme(scheme).Build()
+//		counting := bench.NewCountingClient(clt)
+//		report, err := bench.Run(context.Background(), bench.Options{
+//			Client:          counting,
+//			Reconciler:      &MyReconciler{Client: counting},
+//			Objects:         100,
+//			NewObject:       newConfigMap,
+//			EventsPerSecond: 500,
+//			Events:          b.N,
+//		})
+//		if err != nil {
+//			b.Fatal(err)
+//		}
+//		report.ReportMetrics(b)
+//	}
+package bench
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report contains the measurements of a benchmark run
+type Report struct {
+	// Reconciles is the number of reconcile calls made
+	Reconciles int
+	// Errors is the number of reconcile calls that returned an error
+	Errors int
+	// Requeues is the number of reconcile calls that asked to be requeued
+	Requeues int
+	// Duration is the wall time of the run, excluding seeding
+	Duration time.Duration
+	// Latencies of each reconcile call, sorted ascending
+	Latencies []time.Duration
+	// ClientCalls is the number of client calls made during the run indexed by verb.
+	// Only populated when the run used a CountingClient.
+	ClientCalls map[string]int64
+}
+
+// MetricReporter reports custom benchmark metrics, implemented by *testing.B
+type MetricReporter interface {
+	ReportMetric(n float64, unit string)
+}
+
+func (r *Report) sortLatencies() {
+	sort.Slice(r.Latencies, func(i, j int) bool { return r.Latencies[i] < r.Latencies[j] })
+}
+
+// ReconcilesPerSecond returns the reconcile throughput of the run
+func (r *Report) ReconcilesPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Reconciles) / r.Duration.Seconds()
+}
+
+// Percentile returns the latency at the given percentile (0-100)
+// using the nearest-rank method
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(r.Latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(r.Latencies) {
+		rank = len(r.Latencies)
+	}
+	return r.Latencies[rank-1]
+}
+
+// P99 returns the 99th percentile reconcile latency
+func (r *Report) P99() time.Duration {
+	return r.Percentile(99)
+}
+
+// TotalClientCalls returns the sum of all client calls
+func (r *Report) TotalClientCalls() (total int64) {
+	for _, count := range r.ClientCalls {
+		total += count
+	}
+	return
+}
+
+// ReportMetrics reports the run measurements as custom benchmark metrics
+func (r *Report) ReportMetrics(b MetricReporter) {
+	b.ReportMetric(r.ReconcilesPerSecond(), "reconciles/s")
+	b.ReportMetric(float64(r.Percentile(50).Microseconds()), "p50-µs")
+	b.ReportMetric(float64(r.P99().Microseconds()), "p99-µs")
+	if r.Reconciles > 0 && r.ClientCalls != nil {
+		b.ReportMetric(float64(r.TotalClientCalls())/float64(r.Reconciles), "calls/reconcile")
+	}
+}
+
+// String returns a human readable summary of the report
+func (r *Report) String() string {
+	verbs := make([]string, 0, len(r.ClientCalls))
+	for verb := range r.ClientCalls {
+		verbs = append(verbs, verb)
+	}
+	sort.Strings(verbs)
+	calls := make([]string, 0, len(verbs))
+	for _, verb := range verbs {
+		calls = append(calls, fmt.Sprintf("%s=%d", verb, r.ClientCalls[verb]))
+	}
+	return fmt.Sprintf("reconciles=%d errors=%d requeues=%d duration=%s reconciles/s=%.2f p50=%s p99=%s calls=[%s]",
+		r.Reconciles, r.Errors, r.Requeues, r.Duration, r.ReconcilesPerSecond(),
+		r.Percentile(50), r.P99(), strings.Join(calls, " "))
+}
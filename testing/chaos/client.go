@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Client wraps a client.Client and injects failures according to its rules.
+// It is safe for concurrent use.
+type Client struct {
+	client.Client
+
+	lock     sync.Mutex
+	rules    []*ruleState
+	versions map[string]*objectVersions
+}
+
+var _ client.Client = &Client{}
+
+type ruleState struct {
+	Rule
+	matched  int
+	injected int
+}
+
+// objectVersions keeps the last two observed versions of an object
+type objectVersions struct {
+	previous runtime.Object
+	latest   runtime.Object
+}
+
+// NewClient returns a Client wrapping clt that applies the given rules
+// in order. The first rule returning an error wins, delays are cumulative.
+func NewClient(clt client.Client, rules ...Rule) *Client {
+	c := &Client{Client: clt, versions: map[string]*objectVersions{}}
+	c.AddRules(rules...)
+	return c
+}
+
+// AddRules appends rules to the client
+func (c *Client) AddRules(rules ...Rule) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, rule := range rules {
+		c.rules = append(c.rules, &ruleState{Rule: rule})
+	}
+}
+
+// ClearRules removes all rules, the client behaves as the wrapped client afterwards
+func (c *Client) ClearRules() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.rules = nil
+}
+
+// Injected returns the total number of injected faults
+func (c *Client) Injected() (total int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, rule := range c.rules {
+		total += rule.injected
+	}
+	return
+}
+
+// fault is the aggregated result of all rules for a call
+type fault struct {
+	delay time.Duration
+	err   error
+	stale bool
+}
+
+// evaluate applies the rules matching call, stale rules only apply when canStale
+// as they would not change the result of the call otherwise
+func (c *Client) evaluate(call Call, canStale bool) (f fault) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, rule := range c.rules {
+		if !rule.matches(call) {
+			continue
+		}
+		rule.matched++
+		if rule.matched <= rule.After || (rule.Times > 0 && rule.injected >= rule.Times) || (rule.Stale && !canStale) {
+			continue
+		}
+		rule.injected++
+		f.delay += rule.Delay
+		if f.err == nil && rule.Error != nil {
+			f.err = rule.Error(call)
+		}
+		f.stale = f.stale || rule.Stale
+	}
+	return
+}
+
+// inject waits for the delay and returns the error of the fault
+func (c *Client) inject(ctx context.Context, call Call) (fault, error) {
+	return c.injectWith(ctx, call, false)
+}
+
+func (c *Client) injectWith(ctx context.Context, call Call, canStale bool) (fault, error) {
+	f := c.evaluate(call, canStale)
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return f, ctx.Err()
+		}
+	}
+	return f, f.err
+}
+
+func (c *Client) newCall(verb Verb, obj runtime.Object) Call {
+	call := Call{Verb: verb}
+	if obj == nil {
+		return call
+	}
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		call.GroupVersionKind = gvk
+	}
+	if o, ok := obj.(client.Object); ok {
+		call.Key = client.ObjectKeyFromObject(o)
+	}
+	return call
+}
+
+func versionKey(obj client.Object, key client.ObjectKey) string {
+	return fmt.Sprintf("%T/%s", obj, key)
+}
+
+// observe records obj as the latest observed version, objects read by Get and written by
+// Create, Update and Patch are observed
+func (c *Client) observe(obj client.Object, key client.ObjectKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	id := versionKey(obj, key)
+	versions, ok := c.versions[id]
+	if !ok {
+		versions = &objectVersions{}
+		c.versions[id] = versions
+	}
+	if latest, ok := versions.latest.(client.Object); ok && latest.GetResourceVersion() == obj.GetResourceVersion() {
+		return
+	}
+	versions.previous = versions.latest
+	versions.latest = obj.DeepCopyObject()
+}
+
+// previous returns the previously observed version of obj if any
+func (c *Client) previous(obj client.Object, key client.ObjectKey) runtime.Object {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if versions, ok := c.versions[versionKey(obj, key)]; ok && versions.previous != nil {
+		return versions.previous.DeepCopyObject()
+	}
+	return nil
+}
+
+// Get injects faults and forwards a Get call.
+// Stale faults return the previously observed version of the object when known.
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	call := c.newCall(VerbGet, obj)
+	call.Key = key
+	previous := c.previous(obj, key)
+	f, err := c.injectWith(ctx, call, previous != nil)
+	if err != nil {
+		return err
+	}
+	if f.stale {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(previous).Elem())
+		return nil
+	}
+	if err = c.Client.Get(ctx, key, obj, opts...); err == nil {
+		c.observe(obj, key)
+	}
+	return err
+}
+
+// List injects faults and forwards a List call
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if _, err := c.inject(ctx, c.newCall(VerbList, list)); err != nil {
+		return err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+// Create injects faults and forwards a Create call
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, err := c.inject(ctx, c.newCall(VerbCreate, obj)); err != nil {
+		return err
+	}
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.observe(obj, client.ObjectKeyFromObject(obj))
+	return nil
+}
+
+// Update injects faults and forwards an Update call
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if _, err := c.inject(ctx, c.newCall(VerbUpdate, obj)); err != nil {
+		return err
+	}
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.observe(obj, client.ObjectKeyFromObject(obj))
+	return nil
+}
+
+// Patch injects faults and forwards a Patch call
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, err := c.inject(ctx, c.newCall(VerbPatch, obj)); err != nil {
+		return err
+	}
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.observe(obj, client.ObjectKeyFromObject(obj))
+	return nil
+}
+
+// Delete injects faults and forwards a Delete call
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if _, err := c.inject(ctx, c.newCall(VerbDelete, obj)); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// DeleteAllOf injects faults and forwards a DeleteAllOf call
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	call := c.newCall(VerbDeleteAllOf, obj)
+	call.Key = client.ObjectKey{}
+	if _, err := c.inject(ctx, call); err != nil {
+		return err
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status returns a status writer that injects faults
+func (c *Client) Status() client.SubResourceWriter {
+	return &statusWriter{SubResourceWriter: c.Client.Status(), parent: c}
+}
+
+type statusWriter struct {
+	client.SubResourceWriter
+	parent *Client
+}
+
+// Update injects faults and forwards a status Update call
+func (w *statusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if _, err := w.parent.inject(ctx, w.parent.newCall(VerbStatusUpdate, obj)); err != nil {
+		return err
+	}
+	if err := w.SubResourceWriter.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	w.parent.observe(obj, client.ObjectKeyFromObject(obj))
+	return nil
+}
+
+// Patch injects faults and forwards a status Patch call
+func (w *statusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if _, err := w.parent.inject(ctx, w.parent.newCall(VerbStatusPatch, obj)); err != nil {
+		return err
+	}
+	if err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	w.parent.observe(obj, client.ObjectKeyFromObject(obj))
+	return nil
+}
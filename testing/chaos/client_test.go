@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var key = client.ObjectKey{Namespace: "default", Name: "cm"}
+
+func newFakeClient() client.Client {
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data:       map[string]string{"version": "1"},
+	}).Build()
+}
+
+func TestConflictOnNthUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := NewClient(newFakeClient(), ConflictOnNthUpdate(2))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, key, cm)).To(Succeed())
+	g.Expect(clt.Update(ctx, cm)).To(Succeed())
+
+	err := clt.Update(ctx, cm)
+	g.Expect(apierrors.IsConflict(err)).To(BeTrue(), "error: %v", err)
+	g.Expect(err.Error()).To(ContainSubstring("configmap"))
+
+	g.Expect(clt.Update(ctx, cm)).To(Succeed())
+	g.Expect(clt.Injected()).To(Equal(1))
+}
+
+func TestTransientErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := NewClient(newFakeClient(), TransientErrors(VerbGet, 2))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(apierrors.IsInternalError(clt.Get(ctx, key, cm))).To(BeTrue())
+	g.Expect(apierrors.IsInternalError(clt.Get(ctx, key, cm))).To(BeTrue())
+	g.Expect(clt.Get(ctx, key, cm)).To(Succeed())
+	g.Expect(clt.List(ctx, &corev1.ConfigMapList{})).To(Succeed())
+
+	clt.ClearRules()
+	g.Expect(clt.Injected()).To(Equal(0))
+}
+
+func TestSlowResponses(t *testing.T) {
+	g := NewGomegaWithT(t)
+	clt := NewClient(newFakeClient(), SlowResponses(50*time.Millisecond, VerbList))
+
+	start := time.Now()
+	g.Expect(clt.List(context.Background(), &corev1.ConfigMapList{})).To(Succeed())
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(clt.List(ctx, &corev1.ConfigMapList{})).To(MatchError(context.Canceled))
+}
+
+func TestStaleReads(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := NewClient(newFakeClient())
+
+	clt.AddRules(StaleReads(1))
+
+	// no earlier version was observed, the rule does not apply
+	cm := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, key, cm)).To(Succeed())
+	g.Expect(clt.Injected()).To(Equal(0))
+
+	cm.Data["version"] = "2"
+	g.Expect(clt.Update(ctx, cm)).To(Succeed())
+	stale := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, key, stale)).To(Succeed())
+	g.Expect(stale.Data["version"]).To(Equal("1"))
+	g.Expect(clt.Injected()).To(Equal(1))
+
+	fresh := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, key, fresh)).To(Succeed())
+	g.Expect(fresh.Data["version"]).To(Equal("2"))
+}
+
+func TestStatusFaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	clt := NewClient(newFakeClient(), Rule{
+		Verbs: []Verb{VerbStatusUpdate},
+		Match: func(call Call) bool { return call.Key == key },
+		Error: InternalError,
+	})
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(clt.Get(ctx, key, cm)).To(Succeed())
+	g.Expect(apierrors.IsInternalError(clt.Status().Update(ctx, cm))).To(BeTrue())
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos provides a client decorator that injects configurable
+// failures into a controller-runtime client, making it possible to test
+// resilience paths like conflicts, transient server errors, slow responses
+// and stale reads.
+//
+//	clt := chaos.NewClient(fake.NewClientBuilder().Build(),
+//		chaos.ConflictOnNthUpdate(2),
+//		chaos.TransientErrors(chaos.VerbGet, 3),
+//	)
+//
+// ONLY FOR TEST USAGE
+package chaos
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Verb of a client call
+type Verb string
+
+const (
+	VerbGet          Verb = "get"
+	VerbList         Verb = "list"
+	VerbCreate       Verb = "create"
+	VerbUpdate       Verb = "update"
+	VerbPatch        Verb = "patch"
+	VerbDelete       Verb = "delete"
+	VerbDeleteAllOf  Verb = "deleteallof"
+	VerbStatusUpdate Verb = "status.update"
+	VerbStatusPatch  Verb = "status.patch"
+)
+
+// Call describes a client call that is about to be made
+type Call struct {
+	Verb Verb
+	// Key of the object, empty for list and deleteallof calls
+	Key client.ObjectKey
+	// GroupVersionKind of the object or list when it can be determined
+	GroupVersionKind schema.GroupVersionKind
+}
+
+// Rule describes when and how a failure is injected
+type Rule struct {
+	// Verbs the rule applies to, empty matches all verbs
+	Verbs []Verb
+	// Match further filters calls, nil matches all calls
+	Match func(Call) bool
+
+	// After skips the first matching calls before injecting
+	After int
+	// Times is the maximum number of injections, zero means unlimited
+	Times int
+
+	// Delay is added before the call is forwarded
+	Delay time.Duration
+	// Error returns the error to be returned instead of forwarding the call
+	Error func(Call) error
+	// Stale returns the previously observed version of the object for get calls, objects are
+	// observed when read or written through the Client. The rule only applies, and counts
+	// towards Times, when a previous version was observed
+	Stale bool
+}
+
+func (r *Rule) matches(call Call) bool {
+	if len(r.Verbs) > 0 {
+		found := false
+		for _, verb := range r.Verbs {
+			if verb == call.Verb {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return r.Match == nil || r.Match(call)
+}
+
+// ConflictOnNthUpdate returns a conflict error on the nth update call
+func ConflictOnNthUpdate(n int) Rule {
+	return Rule{
+		Verbs: []Verb{VerbUpdate},
+		After: n - 1,
+		Times: 1,
+		Error: ConflictError,
+	}
+}
+
+// TransientErrors returns an internal server error for the first times
+// calls of the verb, succeeding afterwards
+func TransientErrors(verb Verb, times int) Rule {
+	return Rule{
+		Verbs: []Verb{verb},
+		Times: times,
+		Error: InternalError,
+	}
+}
+
+// SlowResponses delays every call of the given verbs, all verbs when empty
+func SlowResponses(delay time.Duration, verbs ...Verb) Rule {
+	return Rule{
+		Verbs: verbs,
+		Delay: delay,
+	}
+}
+
+// StaleReads returns the previously observed version of objects
+// on the first times get calls, zero means on every get call
+func StaleReads(times int) Rule {
+	return Rule{
+		Verbs: []Verb{VerbGet},
+		Times: times,
+		Stale: true,
+	}
+}
+
+// ConflictError returns a conflict error for the call
+func ConflictError(call Call) error {
+	return apierrors.NewConflict(groupResource(call), call.Key.Name, fmt.Errorf("injected conflict"))
+}
+
+// InternalError returns an internal server error for the call
+func InternalError(call Call) error {
+	return apierrors.NewInternalError(fmt.Errorf("injected %s failure", call.Verb))
+}
+
+func groupResource(call Call) schema.GroupResource {
+	return schema.GroupResource{
+		Group:    call.GroupVersionKind.Group,
+		Resource: strings.ToLower(call.GroupVersionKind.Kind),
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+	clioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/logger"
+)
+
+// TestStreams are the buffers backing the IOStreams of a context returned by WithTestContext
+type TestStreams struct {
+	// In is read by commands as their standard input
+	In *bytes.Buffer
+	// Out captures the standard output of commands
+	Out *bytes.Buffer
+	// ErrOut captures the standard error of commands
+	ErrOut *bytes.Buffer
+}
+
+// TestContextOption customizes the context returned by WithTestContext
+type TestContextOption func(ctx context.Context) context.Context
+
+// WithTestKubeconfig stores kubeflags.KubeFlags using the kubeconfig file at path in the context,
+// so root commands add the cluster connection flags and subcommands connect to its cluster
+//
+//	ctx, _ := WithTestContext(t, WithTestKubeconfig(NewKubeconfigFile(t, env.Config)))
+func WithTestKubeconfig(path string) TestContextOption {
+	return func(ctx context.Context) context.Context {
+		flags := kubeflags.NewKubeFlags()
+		*flags.KubeConfig = path
+		return kubeflags.WithKubeFlags(ctx, flags)
+	}
+}
+
+// WithTestContext returns a context to execute commands built on command/root in t,
+// with IOStreams backed by the returned buffers and a logger writing into the test log.
+// The context is canceled when the test finishes.
+//
+//	ctx, streams := WithTestContext(t)
+//	cmd := root.NewRootCommand(ctx, "cli", version.NewCommand())
+//	cmd.SetArgs([]string{"version"})
+//	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+//	g.Expect(streams.Out.String()).To(ContainSubstring("cli version"))
+func WithTestContext(t testing.TB, opts ...TestContextOption) (context.Context, *TestStreams) {
+	t.Helper()
+	streams := &TestStreams{In: &bytes.Buffer{}, Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ctx = pkgio.WithIOStreams(ctx, &clioptions.IOStreams{In: streams.In, Out: streams.Out, ErrOut: streams.ErrOut})
+	ctx = logger.WithLogger(ctx, zaptest.NewLogger(t).Sugar())
+	for _, opt := range opts {
+		ctx = opt(ctx)
+	}
+	return ctx, streams
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+
+	pkgio "github.com/AlaudaDevops/pkg/command/io"
+	"github.com/AlaudaDevops/pkg/command/kubeflags"
+	"github.com/AlaudaDevops/pkg/command/logger"
+	"github.com/AlaudaDevops/pkg/command/root"
+)
+
+func TestWithTestContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+	kubeconfig := NewKubeconfigFile(t, &rest.Config{Host: "https://127.0.0.1:6443"})
+	ctx, streams := WithTestContext(t, WithTestKubeconfig(kubeconfig))
+	streams.In.WriteString("input")
+
+	echo := func(ctx context.Context, name string) *cobra.Command {
+		return &cobra.Command{
+			Use: "echo",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				config, err := kubeflags.GetRESTConfig(cmd.Context())
+				if err != nil {
+					return err
+				}
+				streams := pkgio.MustGetIOStreams(cmd.Context())
+				input := make([]byte, 5)
+				if _, err = streams.In.Read(input); err != nil {
+					return err
+				}
+				logger.GetLogger(cmd.Context()).Infow("echoing", "host", config.Host)
+				_, err = fmt.Fprintf(streams.Out, "%s %s", input, config.Host)
+				return err
+			},
+		}
+	}
+	cmd := root.NewRootCommand(ctx, "cli", echo)
+	cmd.SetArgs([]string{"echo"})
+	g.Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	g.Expect(streams.Out.String()).To(Equal("input https://127.0.0.1:6443"))
+	// logs go to the test log instead of ErrOut
+	g.Expect(streams.ErrOut.String()).To(BeEmpty())
+	g.Expect(ctx.Err()).To(BeNil())
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+// DefaultFakeTime is the time fake clocks start at when no time is given
+var DefaultFakeTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// WithFakeClock returns a fake clock starting at now, or DefaultFakeTime if now is zero,
+// and a copy of ctx carrying it so code using clock.FromContext is deterministic.
+// The fake clock can also be given to options accepting a clock like events.WithClock
+func WithFakeClock(ctx context.Context, now time.Time) (context.Context, *clock.FakeClock) {
+	if now.IsZero() {
+		now = DefaultFakeTime
+	}
+	fake := clock.NewFakeClock(now)
+	return clock.WithClock(ctx, fake), fake
+}
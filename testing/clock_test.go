@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	. "github.com/onsi/gomega"
+)
+
+func TestWithFakeClock(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx, fake := WithFakeClock(context.Background(), time.Time{})
+	g.Expect(clock.Now(ctx)).To(Equal(DefaultFakeTime))
+
+	fake.Advance(time.Hour)
+	g.Expect(clock.Now(ctx)).To(Equal(DefaultFakeTime.Add(time.Hour)))
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx, _ = WithFakeClock(context.Background(), now)
+	g.Expect(clock.Now(ctx)).To(Equal(now))
+}
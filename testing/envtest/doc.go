@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envtest bootstraps a controller-runtime envtest environment for integration tests.
+// It starts a local control plane, installs CRDs loaded from fixture directories,
+// and starts a manager with a test logger, returning a teardown function:
+//
+//	env, teardown, err := envtest.StartTestEnv("../../config/crd/bases")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer teardown()
+//
+// The control plane binaries are located using the KUBEBUILDER_ASSETS environment variable.
+package envtest
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/zapr"
+	"github.com/onsi/ginkgo/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/AlaudaDevops/pkg/command/logger"
+	pkgtesting "github.com/AlaudaDevops/pkg/testing"
+)
+
+// Options options to start a test environment
+type Options struct {
+	// CRDDirectoryPaths directories containing yaml or json CRD files to install
+	CRDDirectoryPaths []string
+
+	// Scheme used by the client and manager, defaults to the client-go scheme
+	Scheme *runtime.Scheme
+
+	// Logger used by the manager and controller-runtime,
+	// defaults to a debug logger writing to the ginkgo writer
+	Logger *zap.SugaredLogger
+}
+
+// TestEnv a running test environment
+type TestEnv struct {
+	// Environment the underlying envtest environment
+	Environment *envtest.Environment
+
+	// Config rest config to access the control plane
+	Config *rest.Config
+
+	// Client a client reading directly from the control plane
+	Client client.Client
+
+	// Manager a started manager, controllers can be added to it after start
+	Manager manager.Manager
+
+	// Logger test logger
+	Logger *zap.SugaredLogger
+
+	// CRDs the installed CRDs
+	CRDs []*apiextensionsv1.CustomResourceDefinition
+}
+
+// StartTestEnv starts a test environment installing the CRDs found in crdPaths.
+// The returned teardown function stops the manager and the control plane.
+func StartTestEnv(crdPaths ...string) (*TestEnv, func() error, error) {
+	return Start(Options{CRDDirectoryPaths: crdPaths})
+}
+
+// Start starts a test environment with options.
+// The returned teardown function stops the manager and the control plane.
+func Start(opts Options) (env *TestEnv, teardown func() error, err error) {
+	if opts.Scheme == nil {
+		opts.Scheme = clientgoscheme.Scheme
+	}
+	if opts.Logger == nil {
+		opts.Logger = NewTestLogger()
+	}
+	logf.SetLogger(zapr.NewLogger(opts.Logger.Desugar()))
+
+	env = &TestEnv{Logger: opts.Logger}
+	if env.CRDs, err = LoadCRDs(opts.CRDDirectoryPaths...); err != nil {
+		return nil, nil, err
+	}
+
+	env.Environment = &envtest.Environment{
+		CRDInstallOptions: envtest.CRDInstallOptions{
+			CRDs:            env.CRDs,
+			CleanUpAfterUse: true,
+		},
+	}
+	if env.Config, err = env.Environment.Start(); err != nil {
+		return nil, nil, fmt.Errorf("start test environment failed: %w", err)
+	}
+
+	stopEnv := func() error {
+		if err := env.Environment.Stop(); err != nil {
+			return fmt.Errorf("stop test environment failed: %w", err)
+		}
+		return nil
+	}
+
+	if env.Client, err = client.New(env.Config, client.Options{Scheme: opts.Scheme}); err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("create client failed: %w", err), stopEnv())
+	}
+
+	env.Manager, err = manager.New(env.Config, manager.Options{
+		Scheme:  opts.Scheme,
+		Logger:  zapr.NewLogger(opts.Logger.Desugar()),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("create manager failed: %w", err), stopEnv())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- env.Manager.Start(ctx)
+	}()
+	if !env.Manager.GetCache().WaitForCacheSync(ctx) {
+		cancel()
+		return nil, nil, errors.Join(fmt.Errorf("wait for manager cache sync failed: %w", <-done), stopEnv())
+	}
+
+	teardown = func() error {
+		cancel()
+		return errors.Join(<-done, stopEnv())
+	}
+	return env, teardown, nil
+}
+
+// NewTestLogger returns a debug logger writing to the ginkgo writer
+func NewTestLogger() *zap.SugaredLogger {
+	return logger.NewLogger(zapcore.AddSync(ginkgo.GinkgoWriter), zapcore.DebugLevel, zap.Development())
+}
+
+// LoadCRDs loads the CRDs from yaml or json files in the directories.
+// Documents of other kinds are ignored.
+func LoadCRDs(dirs ...string) (crds []*apiextensionsv1.CustomResourceDefinition, err error) {
+	scheme := runtime.NewScheme()
+	if err = apiextensionsv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		objs, err := pkgtesting.LoadObjectsFromDir(dir, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("load CRDs from %s failed: %w", dir, err)
+		}
+		for _, obj := range objs {
+			if crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition); ok {
+				crds = append(crds, crd)
+			}
+		}
+	}
+	return crds, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLoadCRDs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crds, err := LoadCRDs("testdata/crds")
+	g.Expect(err).To(BeNil())
+	g.Expect(crds).To(HaveLen(1))
+	g.Expect(crds[0].Name).To(Equal("widgets.example.com"))
+	g.Expect(crds[0].Spec.Scope).To(Equal(apiextensionsv1.NamespaceScoped))
+
+	_, err = LoadCRDs("testdata/not-exist")
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestStartTestEnv(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set, skipping test environment bootstrap")
+	}
+	g := NewGomegaWithT(t)
+
+	env, teardown, err := StartTestEnv("testdata/crds")
+	g.Expect(err).To(BeNil())
+	defer func() {
+		g.Expect(teardown()).To(Succeed())
+	}()
+
+	widgets := &unstructured.UnstructuredList{}
+	widgets.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "WidgetList"})
+	g.Expect(env.Client.List(context.TODO(), widgets, client.InNamespace("default"))).To(Succeed())
+	g.Expect(env.Manager.GetClient()).NotTo(BeNil())
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// WithoutServerSideApply wraps the Patch function of funcs to reject apply patches with a
+// MethodNotSupported error like api servers without server-side apply, so code falling back
+// to a client side apply can be tested with the fake client which does not support them:
+//
+//	fake.NewClientBuilder().WithInterceptorFuncs(ktesting.WithoutServerSideApply(interceptor.Funcs{})).Build()
+func WithoutServerSideApply(funcs interceptor.Funcs) interceptor.Funcs {
+	patch := funcs.Patch
+	funcs.Patch = func(ctx context.Context, clt client.WithWatch, obj client.Object, p client.Patch, opts ...client.PatchOption) error {
+		if p.Type() == types.ApplyPatchType {
+			return apierrors.NewMethodNotSupported(schema.GroupResource{}, "apply")
+		}
+		if patch != nil {
+			return patch(ctx, clt, obj, p, opts...)
+		}
+		return clt.Patch(ctx, obj, p, opts...)
+	}
+	return funcs
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestWithoutServerSideApply(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}}
+	clt := fake.NewClientBuilder().WithObjects(cm).WithInterceptorFuncs(WithoutServerSideApply(interceptor.Funcs{})).Build()
+
+	applied := &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, ObjectMeta: cm.ObjectMeta}
+	err := clt.Patch(ctx, applied, client.Apply, client.FieldOwner("test"))
+	g.Expect(apierrors.IsMethodNotSupported(err)).To(BeTrue())
+
+	patch := client.MergeFrom(cm.DeepCopy())
+	cm.Labels = map[string]string{"app": "demo"}
+	g.Expect(clt.Patch(ctx, cm, patch)).To(Succeed())
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"yunion.io/x/pkg/errors"
+)
+
+// fixtureExtensions file extensions considered fixture files
+var fixtureExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// LoadObjectsFromDir walks dir recursively and loads every yaml or json fixture file,
+// supporting multiple documents per file, returning objects ready to seed a fake client.
+// Objects whose GroupVersionKind is registered in scheme are converted to their typed
+// counterpart, other objects are returned as *unstructured.Unstructured.
+// Files are visited in lexical order so the result is deterministic.
+func LoadObjectsFromDir(dir string, scheme *runtime.Scheme) (objs []client.Object, err error) {
+	errs := []error{}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !fixtureExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		fileObjs, err := loadObjectsFromFile(path, scheme)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		objs = append(objs, fileObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objs, errors.NewAggregate(errs)
+}
+
+// MustLoadObjectsFromDir loads objects from dir or panics if loading fails.
+func MustLoadObjectsFromDir(dir string, scheme *runtime.Scheme) []client.Object {
+	objs, err := LoadObjectsFromDir(dir, scheme)
+	if err != nil {
+		panic(fmt.Sprintf("load fixtures failed, dir: %s, err: %s", dir, err))
+	}
+	return objs
+}
+
+// ManifestSource renders objects from manifests, e.g. the sources of the render package
+// rendering directories, kustomize overlays or helm charts
+type ManifestSource interface {
+	Render(ctx context.Context) ([]*unstructured.Unstructured, error)
+}
+
+// LoadObjectsFromSources renders sources in order returning objects ready to seed a fake client,
+// converted to their typed counterpart like LoadObjectsFromDir
+//
+//	objs, err := LoadObjectsFromSources(ctx, scheme, render.Kustomize("testdata/overlay"))
+func LoadObjectsFromSources(ctx context.Context, scheme *runtime.Scheme, sources ...ManifestSource) (objs []client.Object, err error) {
+	for _, source := range sources {
+		rendered, err := source.Render(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i, u := range rendered {
+			if u.GetKind() == "" || u.GetAPIVersion() == "" {
+				return nil, fmt.Errorf("rendered object %d: apiVersion and kind are required", i)
+			}
+			var runtimeObj runtime.Object = u
+			if scheme != nil {
+				if runtimeObj, err = convertFromUnstructuredIfNecessary(scheme, u); err != nil {
+					return nil, fmt.Errorf("rendered object %s %s: %w", u.GetKind(), u.GetName(), err)
+				}
+			}
+			obj, err := DefaultConvertRuntimeToClientobjectFunc(runtimeObj)
+			if err != nil {
+				return nil, fmt.Errorf("rendered object %s %s: %w", u.GetKind(), u.GetName(), err)
+			}
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+func loadObjectsFromFile(path string, scheme *runtime.Scheme) (objs []client.Object, err error) {
+	us := []*unstructured.Unstructured{}
+	if err = LoadMultiYamlOrJson(path, &us); err != nil {
+		return nil, fmt.Errorf("load fixture file %s failed: %w", path, err)
+	}
+	for i, u := range us {
+		if len(u.Object) == 0 {
+			continue
+		}
+		if u.GetKind() == "" || u.GetAPIVersion() == "" {
+			return objs, fmt.Errorf("fixture file %s document %d: apiVersion and kind are required", path, i)
+		}
+		var runtimeObj runtime.Object = u
+		if scheme != nil {
+			if runtimeObj, err = convertFromUnstructuredIfNecessary(scheme, u); err != nil {
+				return objs, fmt.Errorf("fixture file %s document %d: %w", path, i, err)
+			}
+		}
+		obj, err := DefaultConvertRuntimeToClientobjectFunc(runtimeObj)
+		if err != nil {
+			return objs, fmt.Errorf("fixture file %s document %d: %w", path, i, err)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoadObjectsFromDir(t *testing.T) {
+	g := NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	objs, err := LoadObjectsFromDir("testdata/fixtures", scheme)
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(4))
+
+	g.Expect(objs[0]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+	g.Expect(objs[0].GetName()).To(Equal("configmap-1"))
+	g.Expect(objs[1].GetName()).To(Equal("configmap-2"))
+	g.Expect(objs[2]).To(BeAssignableToTypeOf(&unstructured.Unstructured{}))
+	g.Expect(objs[2].GetName()).To(Equal("widget"))
+	g.Expect(objs[3]).To(BeAssignableToTypeOf(&corev1.Secret{}))
+
+	clt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs[0], objs[1], objs[3]).Build()
+	secret := &corev1.Secret{}
+	g.Expect(clt.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "secret"}, secret)).To(Succeed())
+	g.Expect(secret.StringData).To(HaveKeyWithValue("username", "admin"))
+}
+
+func TestLoadObjectsFromDir_withoutScheme(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objs, err := LoadObjectsFromDir("testdata/fixtures", nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(4))
+	for _, obj := range objs {
+		g.Expect(obj).To(BeAssignableToTypeOf(&unstructured.Unstructured{}))
+	}
+}
+
+func TestLoadObjectsFromDir_fail(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := LoadObjectsFromDir("testdata/not-exist", nil)
+	g.Expect(err).NotTo(BeNil())
+
+	g.Expect(func() {
+		MustLoadObjectsFromDir("testdata/not-exist", nil)
+	}).Should(Panic())
+}
+
+// sourceFunc renders objs, like the sources of the render package
+type sourceFunc func(ctx context.Context) ([]*unstructured.Unstructured, error)
+
+func (f sourceFunc) Render(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	return f(ctx)
+}
+
+func TestLoadObjectsFromSources(t *testing.T) {
+	g := NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	objs, err := LoadObjectsFromSources(context.TODO(), scheme, sourceFunc(func(context.Context) ([]*unstructured.Unstructured, error) {
+		cm := &unstructured.Unstructured{}
+		cm.SetAPIVersion("v1")
+		cm.SetKind("ConfigMap")
+		cm.SetName("rendered")
+		return []*unstructured.Unstructured{cm}, nil
+	}))
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(1))
+	g.Expect(objs[0]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+	g.Expect(objs[0].GetName()).To(Equal("rendered"))
+
+	_, err = LoadObjectsFromSources(context.TODO(), scheme, sourceFunc(func(context.Context) ([]*unstructured.Unstructured, error) {
+		return []*unstructured.Unstructured{{Object: map[string]interface{}{}}}, nil
+	}))
+	g.Expect(err).To(MatchError(ContainSubstring("apiVersion and kind are required")))
+}
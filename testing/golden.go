@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+)
+
+// UpdateGoldenEnv environment variable that when set to true
+// makes golden assertions rewrite golden files instead of comparing
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// UpdateGoldenFlag is the name of the flag that when set makes golden assertions
+// rewrite golden files instead of comparing
+const UpdateGoldenFlag = "update"
+
+// RegisterUpdateGoldenFlag defines the -update flag in fs unless already defined,
+// nothing is registered when importing this package so test packages opt in explicitly:
+//
+//	func init() {
+//		ktesting.RegisterUpdateGoldenFlag(flag.CommandLine)
+//	}
+func RegisterUpdateGoldenFlag(fs *flag.FlagSet) {
+	if fs.Lookup(UpdateGoldenFlag) == nil {
+		fs.Bool(UpdateGoldenFlag, false, "update golden files instead of comparing against them")
+	}
+}
+
+// ShouldUpdateGolden returns true when golden files should be rewritten, either by
+// the -update flag when defined or by the UPDATE_GOLDEN environment variable
+func ShouldUpdateGolden() bool {
+	if f := flag.Lookup(UpdateGoldenFlag); f != nil {
+		if update, _ := strconv.ParseBool(f.Value.String()); update {
+			return true
+		}
+	}
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv))
+	return update
+}
+
+// AssertGolden compares actual with the content of goldenFile
+// and fails the test with a diff if they differ.
+// When ShouldUpdateGolden returns true the golden file is rewritten with actual instead.
+func AssertGolden(t testing.TB, goldenFile string, actual []byte) {
+	t.Helper()
+	if ShouldUpdateGolden() {
+		writeGolden(t, goldenFile, actual)
+		return
+	}
+	expected, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("read golden file %s failed: %s, run with -update or UPDATE_GOLDEN=true to create it", goldenFile, err)
+		return
+	}
+	if diff := cmp.Diff(string(expected), string(actual)); diff != "" {
+		t.Errorf("golden file %s mismatch %s", goldenFile, PrintDiffWantGot(diff))
+	}
+}
+
+// AssertGoldenYAML marshals obj as yaml and compares it with goldenFile.
+// The comparison is semantic, so formatting and key order of the golden file do not matter.
+func AssertGoldenYAML(t testing.TB, goldenFile string, obj interface{}) {
+	t.Helper()
+	actual, err := yaml.Marshal(obj)
+	if err != nil {
+		t.Fatalf("marshal object as yaml failed: %s", err)
+		return
+	}
+	assertGoldenStructured(t, goldenFile, actual)
+}
+
+// AssertGoldenJSON marshals obj as indented json and compares it with goldenFile.
+// The comparison is semantic, so formatting and key order of the golden file do not matter.
+func AssertGoldenJSON(t testing.TB, goldenFile string, obj interface{}) {
+	t.Helper()
+	actual, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal object as json failed: %s", err)
+		return
+	}
+	assertGoldenStructured(t, goldenFile, append(actual, '\n'))
+}
+
+// assertGoldenStructured compares yaml or json documents after decoding both sides
+func assertGoldenStructured(t testing.TB, goldenFile string, actual []byte) {
+	t.Helper()
+	if ShouldUpdateGolden() {
+		writeGolden(t, goldenFile, actual)
+		return
+	}
+	expected, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("read golden file %s failed: %s, run with -update or UPDATE_GOLDEN=true to create it", goldenFile, err)
+		return
+	}
+	var want, got interface{}
+	if err = yaml.Unmarshal(expected, &want); err != nil {
+		t.Fatalf("parse golden file %s failed: %s", goldenFile, err)
+		return
+	}
+	if err = yaml.Unmarshal(actual, &got); err != nil {
+		t.Fatalf("parse actual content failed: %s", err)
+		return
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("golden file %s mismatch %s", goldenFile, PrintDiffWantGot(diff))
+	}
+}
+
+func writeGolden(t testing.TB, goldenFile string, content []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(goldenFile), 0o755); err != nil {
+		t.Fatalf("create golden file directory failed: %s", err)
+		return
+	}
+	if err := os.WriteFile(goldenFile, content, 0o644); err != nil {
+		t.Fatalf("write golden file %s failed: %s", goldenFile, err)
+	}
+}
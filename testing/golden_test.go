@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recordingT records failures instead of failing the running test
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+func goldenConfigMap(value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "golden", Namespace: "default"},
+		Data:       map[string]string{"key": value},
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	AssertGolden(t, "testdata/golden/text.golden", []byte("hello golden\n"))
+
+	rt := &recordingT{TB: t}
+	AssertGolden(rt, "testdata/golden/text.golden", []byte("hello world\n"))
+	g.Expect(rt.failures).To(HaveLen(1))
+	g.Expect(rt.failures[0]).To(ContainSubstring("(-want, +got)"))
+
+	rt = &recordingT{TB: t}
+	AssertGolden(rt, "testdata/golden/missing.golden", []byte("hello"))
+	g.Expect(rt.failures).To(HaveLen(1))
+	g.Expect(rt.failures[0]).To(ContainSubstring("-update"))
+}
+
+func TestAssertGoldenYAMLAndJSON(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	AssertGoldenYAML(t, "testdata/golden/configmap.yaml", goldenConfigMap("value"))
+
+	configmap := goldenConfigMap("value")
+	configmap.TypeMeta = metav1.TypeMeta{}
+	AssertGoldenJSON(t, "testdata/golden/configmap.json", configmap)
+
+	rt := &recordingT{TB: t}
+	AssertGoldenYAML(rt, "testdata/golden/configmap.yaml", goldenConfigMap("changed"))
+	g.Expect(rt.failures).To(HaveLen(1))
+	g.Expect(rt.failures[0]).To(ContainSubstring("changed"))
+}
+
+func TestAssertGolden_update(t *testing.T) {
+	g := NewGomegaWithT(t)
+	t.Setenv(UpdateGoldenEnv, "true")
+	file := filepath.Join(t.TempDir(), "nested", "updated.yaml")
+
+	AssertGoldenYAML(t, file, goldenConfigMap("value"))
+
+	content, err := os.ReadFile(file)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(ContainSubstring("key: value"))
+
+	t.Setenv(UpdateGoldenEnv, "false")
+	AssertGoldenYAML(t, file, goldenConfigMap("value"))
+}
+
+func TestShouldUpdateGolden_flag(t *testing.T) {
+	g := NewGomegaWithT(t)
+	t.Setenv(UpdateGoldenEnv, "false")
+	g.Expect(flag.Lookup(UpdateGoldenFlag)).To(BeNil())
+	g.Expect(ShouldUpdateGolden()).To(BeFalse())
+
+	RegisterUpdateGoldenFlag(flag.CommandLine)
+	RegisterUpdateGoldenFlag(flag.CommandLine)
+	g.Expect(ShouldUpdateGolden()).To(BeFalse())
+
+	g.Expect(flag.Set(UpdateGoldenFlag, "true")).To(Succeed())
+	defer flag.Set(UpdateGoldenFlag, "false")
+	g.Expect(ShouldUpdateGolden()).To(BeTrue())
+}
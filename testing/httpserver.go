@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// RecordedRequest a request received by a FixtureHTTPServer
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// FixtureHTTPServer an httptest.Server serving fixture files and recording received requests
+type FixtureHTTPServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	routes   map[string]string
+	requests []RecordedRequest
+}
+
+// NewFixtureHTTPServer starts a server that responds with the content of fixture files.
+// Route keys are either a path like "/api/v1/repos" matching any method,
+// or a method and a path like "GET /api/v1/repos", which takes precedence.
+// YAML fixtures are converted to JSON and served as application/json,
+// JSON fixtures are served as is. Unknown routes respond with 404.
+// The server must be closed by the caller.
+func NewFixtureHTTPServer(routes map[string]string) *FixtureHTTPServer {
+	s := &FixtureHTTPServer{routes: routes}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Requests returns a copy of the requests received so far
+func (s *FixtureHTTPServer) Requests() []RecordedRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]RecordedRequest{}, s.requests...)
+}
+
+// RequestsTo returns the received requests matching the method and path,
+// an empty method matches any method
+func (s *FixtureHTTPServer) RequestsTo(method, path string) (requests []RecordedRequest) {
+	for _, req := range s.Requests() {
+		if (method == "" || req.Method == method) && req.Path == path {
+			requests = append(requests, req)
+		}
+	}
+	return
+}
+
+// Reset clears the recorded requests
+func (s *FixtureHTTPServer) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = nil
+}
+
+func (s *FixtureHTTPServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.lock.Lock()
+	s.requests = append(s.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	s.lock.Unlock()
+
+	file, ok := s.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		file, ok = s.routes[r.URL.Path]
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	content, err := loadFixtureResponse(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	// write errors mean the client went away, which the client reports itself
+	_, _ = w.Write(content)
+}
+
+func loadFixtureResponse(file string) ([]byte, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		if content, err = yaml.YAMLToJSON(content); err != nil {
+			return nil, fmt.Errorf("convert fixture %s to json failed: %w", file, err)
+		}
+	}
+	return content, nil
+}
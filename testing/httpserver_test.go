@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFixtureHTTPServer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := NewFixtureHTTPServer(map[string]string{
+		"/repos":            "testdata/http/repos.yaml",
+		"GET /repos/demo":   "testdata/http/repo.json",
+		"POST /repos/demo":  "testdata/http/repos.yaml",
+		"/repos/not-exists": "testdata/http/not-exists.json",
+	})
+	defer server.Close()
+
+	get := func(method, path string, body string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		g.Expect(err).To(BeNil())
+		resp, err := http.DefaultClient.Do(req)
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		content, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(content)
+	}
+
+	status, body := get(http.MethodGet, "/repos?page=2", "")
+	g.Expect(status).To(Equal(http.StatusOK))
+	g.Expect(body).To(MatchJSON(`[{"name": "demo", "private": false}]`))
+
+	status, body = get(http.MethodGet, "/repos/demo", "")
+	g.Expect(status).To(Equal(http.StatusOK))
+	g.Expect(body).To(MatchJSON(`{"name": "demo", "private": true}`))
+
+	status, body = get(http.MethodPost, "/repos/demo", `{"name": "demo"}`)
+	g.Expect(status).To(Equal(http.StatusOK))
+	g.Expect(body).To(MatchJSON(`[{"name": "demo", "private": false}]`))
+
+	status, _ = get(http.MethodDelete, "/repos/demo", "")
+	g.Expect(status).To(Equal(http.StatusNotFound))
+
+	status, _ = get(http.MethodGet, "/repos/not-exists", "")
+	g.Expect(status).To(Equal(http.StatusInternalServerError))
+
+	g.Expect(server.Requests()).To(HaveLen(5))
+	g.Expect(server.Requests()[0].Query).To(Equal("page=2"))
+	posts := server.RequestsTo(http.MethodPost, "/repos/demo")
+	g.Expect(posts).To(HaveLen(1))
+	g.Expect(string(posts[0].Body)).To(Equal(`{"name": "demo"}`))
+	g.Expect(server.RequestsTo("", "/repos/demo")).To(HaveLen(3))
+
+	server.Reset()
+	g.Expect(server.Requests()).To(BeEmpty())
+}
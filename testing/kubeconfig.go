@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeconfigContextName is the name of the cluster, user and context of kubeconfig files
+// written by NewKubeconfigFile
+const KubeconfigContextName = "test"
+
+// NewKubeconfigFile writes a kubeconfig connecting to the cluster of config, e.g. the config
+// of an envtest environment, into a temporary directory of the test removed when it finishes,
+// returning the path of the file. Each call writes its own file so parallel tests do not share it.
+//
+//	kubeconfig := NewKubeconfigFile(t, env.Config)
+//	cmd.SetArgs([]string{"--kubeconfig", kubeconfig, "get", "widgets"})
+func NewKubeconfigFile(t testing.TB, config *rest.Config) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := clientcmd.WriteToFile(*NewKubeconfig(config), path); err != nil {
+		t.Fatalf("write kubeconfig %s failed: %s", path, err)
+	}
+	return path
+}
+
+// NewKubeconfig returns a kubeconfig with a single current context connecting to the cluster of config
+func NewKubeconfig(config *rest.Config) *clientcmdapi.Config {
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = config.Host + config.APIPath
+	cluster.CertificateAuthority = config.CAFile
+	cluster.CertificateAuthorityData = config.CAData
+	cluster.InsecureSkipTLSVerify = config.Insecure
+	cluster.TLSServerName = config.ServerName
+
+	user := clientcmdapi.NewAuthInfo()
+	user.ClientCertificate = config.CertFile
+	user.ClientCertificateData = config.CertData
+	user.ClientKey = config.KeyFile
+	user.ClientKeyData = config.KeyData
+	user.Token = config.BearerToken
+	user.TokenFile = config.BearerTokenFile
+	user.Username = config.Username
+	user.Password = config.Password
+
+	context := clientcmdapi.NewContext()
+	context.Cluster = KubeconfigContextName
+	context.AuthInfo = KubeconfigContextName
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[KubeconfigContextName] = cluster
+	kubeconfig.AuthInfos[KubeconfigContextName] = user
+	kubeconfig.Contexts[KubeconfigContextName] = context
+	kubeconfig.CurrentContext = KubeconfigContextName
+	return kubeconfig
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestNewKubeconfigFile(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		t.Run(fmt.Sprintf("parallel-%d", i), func(t *testing.T) {
+			t.Parallel()
+			g := NewGomegaWithT(t)
+			config := &rest.Config{
+				Host:            fmt.Sprintf("https://127.0.0.1:%d", 6443+i),
+				BearerToken:     "token",
+				TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")},
+			}
+
+			path := NewKubeconfigFile(t, config)
+			loaded, err := clientcmd.BuildConfigFromFlags("", path)
+			g.Expect(err).To(BeNil())
+			g.Expect(loaded.Host).To(Equal(config.Host))
+			g.Expect(loaded.BearerToken).To(Equal("token"))
+			g.Expect(loaded.CAData).To(Equal([]byte("ca")))
+
+			raw, err := clientcmd.LoadFromFile(path)
+			g.Expect(err).To(BeNil())
+			g.Expect(raw.CurrentContext).To(Equal(KubeconfigContextName))
+		})
+	}
+}
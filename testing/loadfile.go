@@ -24,12 +24,19 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"text/template"
 
+	sprig "github.com/go-task/slim-sprig/v3"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
+
+	"github.com/AlaudaDevops/pkg/yamlutil"
 )
 
 // MustLoadFileString loads a file as string
@@ -68,8 +75,8 @@ func MustLoadJSON(file string, obj interface{}) {
 	}
 }
 
-// LoadMultiYamlOrJson loads multi yamls
-func LoadMultiYamlOrJson[T any](file string, list *[]T) (err error) {
+// LoadMultiYamlOrJson loads multi yamls, see LoadMultiYamlOrJsonFromBytes for opts
+func LoadMultiYamlOrJson[T any](file string, list *[]T, opts ...yamlutil.Option) (err error) {
 	if list == nil {
 		return errors.New("list should not be nil")
 	}
@@ -77,7 +84,7 @@ func LoadMultiYamlOrJson[T any](file string, list *[]T) (err error) {
 	if data, err = os.ReadFile(file); err != nil {
 		return
 	}
-	return LoadMultiYamlOrJsonFromBytes(data, list)
+	return LoadMultiYamlOrJsonFromBytes(data, list, opts...)
 }
 
 // LoadMultiYamlOrJsonFromBytes loads multi yamls
@@ -85,12 +92,41 @@ func LoadMultiYamlOrJson[T any](file string, list *[]T) (err error) {
 // However, --- is not a valid separator for JSON documents.
 // To be compatible with the previous handling logic, we cannot directly use the k8s built-in multiple document unmarshalling method
 // and need to read line by line to implement it.
-func LoadMultiYamlOrJsonFromBytes[T any](data []byte, list *[]T) (err error) {
+// When opts are given, e.g. yamlutil.Strict(), documents are decoded by yamlutil instead
+// and must be valid yaml, which json documents are
+func LoadMultiYamlOrJsonFromBytes[T any](data []byte, list *[]T, opts ...yamlutil.Option) (err error) {
+	if len(opts) > 0 {
+		items, err := yamlutil.UnmarshalAll[T](data, opts...)
+		*list = append(*list, items...)
+		return err
+	}
+	return ForEachYamlOrJsonDoc(bytes.NewReader(data), func(doc []byte) error {
+		obj := new(T)
+		if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(doc), len(doc)).Decode(obj); err != nil {
+			return err
+		}
+		*list = append(*list, *obj)
+		return nil
+	})
+}
 
-	docs := [][]byte{}
+// ForEachYamlOrJsonDoc reads documents separated by --- from r one at a time and calls fn
+// with the raw content of each non empty document, so large multi-document manifests
+// are processed without buffering all of them in memory.
+// The slice passed to fn is only valid until fn returns and must be copied to be retained.
+// Iteration stops at the first error returned by fn or by the reader.
+func ForEachYamlOrJsonDoc(r io.Reader, fn func(raw []byte) error) error {
 	var currentDoc = bytes.NewBuffer(make([]byte, 0, 4096))
 
-	reader := bufio.NewReader(bytes.NewReader(data))
+	flush := func() error {
+		defer currentDoc.Reset()
+		if len(bytes.TrimSpace(currentDoc.Bytes())) == 0 {
+			return nil
+		}
+		return fn(currentDoc.Bytes())
+	}
+
+	reader := bufio.NewReader(r)
 	for {
 		line, err := reader.ReadBytes('\n')
 
@@ -99,41 +135,17 @@ func LoadMultiYamlOrJsonFromBytes[T any](data []byte, list *[]T) (err error) {
 		}
 
 		if isSeparator(line) {
-			if currentDoc.Len() > 0 {
-				docCopy := make([]byte, currentDoc.Len())
-				copy(docCopy, currentDoc.Bytes())
-				docs = append(docs, docCopy)
-				currentDoc.Reset()
+			if flushErr := flush(); flushErr != nil {
+				return flushErr
 			}
 		} else {
 			currentDoc.Write(line)
 		}
 
 		if err == io.EOF {
-			if currentDoc.Len() > 0 {
-				docCopy := make([]byte, currentDoc.Len())
-				copy(docCopy, currentDoc.Bytes())
-				docs = append(docs, docCopy)
-				currentDoc.Reset()
-			}
-			break
-		}
-	}
-
-	for _, doc := range docs {
-		if len(bytes.TrimSpace(doc)) == 0 {
-			continue
+			return flush()
 		}
-		obj := new(T)
-		err = utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(doc), len(doc)).Decode(obj)
-		if err != nil {
-			return
-		}
-
-		*list = append(*list, *obj)
 	}
-
-	return nil
 }
 
 func isSeparator(line []byte) bool {
@@ -148,26 +160,112 @@ func isSeparator(line []byte) bool {
 }
 
 // MustLoadMultiYamlOrJson loads multi yamls or panics if the parse fails.
-func MustLoadMultiYamlOrJson[T any](file string, list *[]T) {
-	err := LoadMultiYamlOrJson(file, list)
+func MustLoadMultiYamlOrJson[T any](file string, list *[]T, opts ...yamlutil.Option) {
+	err := LoadMultiYamlOrJson(file, list, opts...)
 	if err != nil {
 		panic(fmt.Sprintf("load yaml file failed, file path: %s, err: %s", file, err))
 	}
 }
 
-// LoadYAML loads yaml
-func LoadYAML(file string, obj interface{}) (err error) {
+// LoadYAML loads yaml. When opts are given, e.g. yamlutil.Strict(), it is decoded by yamlutil
+func LoadYAML(file string, obj interface{}, opts ...yamlutil.Option) (err error) {
+	var data []byte
+	if data, err = os.ReadFile(file); err != nil {
+		return
+	}
+	return unmarshalYAML(data, obj, opts)
+}
+
+func unmarshalYAML(data []byte, obj interface{}, opts []yamlutil.Option) error {
+	if len(opts) > 0 {
+		return yamlutil.Unmarshal(data, obj, opts...)
+	}
+	return yaml.Unmarshal(data, obj)
+}
+
+// LoadUnstructured loads a yaml or json file as unstructured
+// apiVersion and kind are required in the file
+func LoadUnstructured(file string) (obj *unstructured.Unstructured, err error) {
 	var data []byte
 	if data, err = os.ReadFile(file); err != nil {
 		return
 	}
-	err = yaml.Unmarshal(data, obj)
+	if data, err = yaml.YAMLToJSON(data); err != nil {
+		return nil, fmt.Errorf("parse file %s failed: %w", file, err)
+	}
+	obj = &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("decode file %s failed: %w", file, err)
+	}
+	return
+}
+
+// LoadTyped loads a yaml or json file into the go type registered in scheme
+// for its apiVersion and kind, useful when the concrete type is not known up front.
+// Returns an error if the kind is not registered in scheme
+func LoadTyped(file string, scheme *runtime.Scheme) (obj runtime.Object, err error) {
+	var data []byte
+	if data, err = os.ReadFile(file); err != nil {
+		return
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	if obj, _, err = decoder.Decode(data, nil, nil); err != nil {
+		return nil, fmt.Errorf("decode file %s failed: %w", file, err)
+	}
 	return
 }
 
+// TemplateFuncs returns the functions available in yaml templates:
+// the sprig functions such as b64enc, now, default and quote,
+// plus toYaml to embed a value as yaml
+func TemplateFuncs() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	funcs["toYaml"] = func(v interface{}) (string, error) {
+		data, err := yaml.Marshal(v)
+		return string(bytes.TrimSuffix(data, []byte("\n"))), err
+	}
+	return funcs
+}
+
+// RenderTemplate renders a text/template file with data using TemplateFuncs
+// referencing a missing map key is an error
+func RenderTemplate(file string, data any) (content []byte, err error) {
+	var text []byte
+	if text, err = os.ReadFile(file); err != nil {
+		return
+	}
+	tpl, err := template.New(file).Funcs(TemplateFuncs()).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s failed: %w", file, err)
+	}
+	buf := &bytes.Buffer{}
+	if err = tpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("render template %s failed: %w", file, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadYAMLTemplate renders file as a text/template with data before unmarshalling it into obj
+// useful for fixtures that need per test names, namespaces or timestamps
+func LoadYAMLTemplate(file string, data any, obj interface{}, opts ...yamlutil.Option) (err error) {
+	var content []byte
+	if content, err = RenderTemplate(file, data); err != nil {
+		return
+	}
+	return unmarshalYAML(content, obj, opts)
+}
+
+// MustLoadYAMLTemplate loads a yaml template or panics if the render or parse fails.
+func MustLoadYAMLTemplate(file string, data any, obj interface{}, opts ...yamlutil.Option) {
+	err := LoadYAMLTemplate(file, data, obj, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("load yaml template failed, file path: %s, err: %s", file, err))
+	}
+}
+
 // MustLoadYaml loads yaml or panics if the parse fails.
-func MustLoadYaml(file string, obj interface{}) {
-	err := LoadYAML(file, obj)
+func MustLoadYaml(file string, obj interface{}, opts ...yamlutil.Option) {
+	err := LoadYAML(file, obj, opts...)
 	if err != nil {
 		panic(fmt.Sprintf("load yaml file failed, file path: %s, err: %s", file, err))
 	}
@@ -17,12 +17,19 @@ limitations under the License.
 package testing
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/AlaudaDevops/pkg/yamlutil"
 )
 
 func TestMustLoadJSON_success(t *testing.T) {
@@ -116,6 +123,30 @@ func TestMustLoadMultiYaml_fail(t *testing.T) {
 	}).Should(Panic())
 }
 
+func TestLoadMultiYaml_strict(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the typo is ignored by default
+	cms := []corev1.ConfigMap{}
+	g.Expect(LoadMultiYamlOrJson("./testdata/loadYaml.configmap.strict.yaml", &cms)).To(Succeed())
+	g.Expect(cms).To(HaveLen(2))
+
+	cms = []corev1.ConfigMap{}
+	err := LoadMultiYamlOrJson("./testdata/loadYaml.configmap.strict.yaml", &cms, yamlutil.Strict())
+	g.Expect(err).To(MatchError(ContainSubstring(`document 2: json: unknown field "metdata"`)))
+
+	// merged keys can be overridden in strict mode
+	cms = []corev1.ConfigMap{}
+	data := MustLoadFileBytes("./testdata/loadYaml.configmap.strict.yaml")
+	data = []byte(strings.Replace(string(data), "metdata:\n  name: typo\n", "", 1))
+	g.Expect(LoadMultiYamlOrJsonFromBytes(data, &cms, yamlutil.Strict())).To(Succeed())
+	g.Expect(cms[1].Data).To(Equal(map[string]string{"key": "overridden", "other": "value"}))
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(LoadYAML("./testdata/loadYaml.configmap.strict.yaml", cm, yamlutil.Strict())).To(Succeed())
+	g.Expect(cm.Name).To(Equal("abc-1"))
+}
+
 func TestMustLoadFileString_success(t *testing.T) {
 	g := NewGomegaWithT(t)
 	var strs string
@@ -148,3 +179,87 @@ func TestMustLoadFileBytes_fail(t *testing.T) {
 		MustLoadFileBytes("./testdata/not-exist.yaml")
 	}).Should(Panic())
 }
+
+func TestLoadYAMLTemplate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cm := &corev1.ConfigMap{}
+	data := map[string]interface{}{
+		"Name":      "abc",
+		"Namespace": "",
+		"Labels":    map[string]string{"app": "demo"},
+		"Password":  "admin",
+	}
+	g.Expect(LoadYAMLTemplate("./testdata/loadYamlTemplate.configmap.yaml", data, cm)).To(Succeed())
+	g.Expect(cm.Name).To(Equal("abc"))
+	g.Expect(cm.Namespace).To(Equal("default"))
+	g.Expect(cm.Labels).To(Equal(map[string]string{"app": "demo"}))
+	g.Expect(cm.Annotations["cpaas.io/creationTime"]).To(HaveLen(4))
+	g.Expect(cm.Data["password"]).To(Equal("YWRtaW4="))
+}
+
+func TestLoadYAMLTemplate_fail(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cm := &corev1.ConfigMap{}
+	err := LoadYAMLTemplate("./testdata/loadYamlTemplate.configmap.yaml", map[string]interface{}{"Name": "abc"}, cm)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("render template"))
+
+	g.Expect(func() {
+		MustLoadYAMLTemplate("./testdata/not-exist.yaml", nil, cm)
+	}).Should(Panic())
+}
+
+func TestForEachYamlOrJsonDoc(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	input := "a: 1\n---\n\n--- # comment\nb: 2\n---\nc: 3"
+	docs := []string{}
+	err := ForEachYamlOrJsonDoc(strings.NewReader(input), func(raw []byte) error {
+		docs = append(docs, string(raw))
+		return nil
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(docs).To(Equal([]string{"a: 1\n", "b: 2\n", "c: 3"}))
+
+	stop := errors.New("stop")
+	count := 0
+	err = ForEachYamlOrJsonDoc(strings.NewReader(input), func(raw []byte) error {
+		count++
+		return stop
+	})
+	g.Expect(err).To(Equal(stop))
+	g.Expect(count).To(Equal(1))
+}
+
+func TestLoadUnstructured(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj, err := LoadUnstructured("./testdata/loadTyped.configmap.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(obj.GroupVersionKind()).To(Equal(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}))
+	g.Expect(obj.GetName()).To(Equal("configmap"))
+	g.Expect(obj.Object["data"]).To(Equal(map[string]interface{}{"key": "value"}))
+
+	_, err = LoadUnstructured("./testdata/valid_json.json")
+	g.Expect(err).NotTo(BeNil(), "kind is missing")
+
+	_, err = LoadUnstructured("./testdata/not-exist.yaml")
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestLoadTyped(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj, err := LoadTyped("./testdata/loadTyped.configmap.yaml", clientgoscheme.Scheme)
+	g.Expect(err).To(BeNil())
+	g.Expect(obj).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+	cm := obj.(*corev1.ConfigMap)
+	g.Expect(cm.Name).To(Equal("configmap"))
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+
+	_, err = LoadTyped("./testdata/loadTyped.configmap.yaml", runtime.NewScheme())
+	g.Expect(err).To(MatchError(ContainSubstring(`no kind "ConfigMap" is registered`)))
+
+	_, err = LoadTyped("./testdata/not-exist.yaml", clientgoscheme.Scheme)
+	g.Expect(err).NotTo(BeNil())
+}
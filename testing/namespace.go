@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/names"
+	. "github.com/onsi/ginkgo/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultNamespaceSandboxPrefix prefix of sandbox namespace names
+	DefaultNamespaceSandboxPrefix = "test-"
+	// DefaultNamespaceSandboxCleanupTimeout time waited for the namespace to be deleted
+	// before stripping finalizers
+	DefaultNamespaceSandboxCleanupTimeout = 30 * time.Second
+)
+
+// NamespaceSandbox is a uniquely named namespace created for a single test
+// so parallel integration tests do not stomp on each other.
+// The namespace is deleted when the test finishes.
+type NamespaceSandbox struct {
+	// Namespace created for the test
+	Namespace *corev1.Namespace
+
+	client           client.Client
+	prefix           string
+	labels           map[string]string
+	cleanupTimeout   time.Duration
+	pollInterval     time.Duration
+	finalizableLists []client.ObjectList
+	t                testing.TB
+}
+
+// NamespaceSandboxOption customizes a NamespaceSandbox
+type NamespaceSandboxOption func(*NamespaceSandbox)
+
+// WithNamespacePrefix sets the prefix of the generated namespace name
+func WithNamespacePrefix(prefix string) NamespaceSandboxOption {
+	return func(s *NamespaceSandbox) {
+		s.prefix = prefix
+	}
+}
+
+// WithNamespaceLabels adds labels to the namespace
+func WithNamespaceLabels(labels map[string]string) NamespaceSandboxOption {
+	return func(s *NamespaceSandbox) {
+		s.labels = labels
+	}
+}
+
+// WithCleanupTimeout sets how long cleanup waits for the namespace to be deleted
+// before stripping finalizers
+func WithCleanupTimeout(timeout time.Duration) NamespaceSandboxOption {
+	return func(s *NamespaceSandbox) {
+		s.cleanupTimeout = timeout
+	}
+}
+
+// WithFinalizerStripping sets the kinds of objects, given as lists like &corev1.ConfigMapList{},
+// whose finalizers are removed inside the namespace when its deletion hangs
+func WithFinalizerStripping(lists ...client.ObjectList) NamespaceSandboxOption {
+	return func(s *NamespaceSandbox) {
+		s.finalizableLists = append(s.finalizableLists, lists...)
+	}
+}
+
+// WithTestingT registers the cleanup with t.Cleanup and reports cleanup errors using t,
+// by default the cleanup is registered with ginkgo DeferCleanup
+func WithTestingT(t testing.TB) NamespaceSandboxOption {
+	return func(s *NamespaceSandbox) {
+		s.t = t
+	}
+}
+
+// NewNamespaceSandbox creates a uniquely named namespace and registers its cleanup,
+// by default using ginkgo DeferCleanup, or t.Cleanup when WithTestingT is used
+func NewNamespaceSandbox(ctx context.Context, cli client.Client, opts ...NamespaceSandboxOption) (*NamespaceSandbox, error) {
+	s := &NamespaceSandbox{
+		client:         cli,
+		prefix:         DefaultNamespaceSandboxPrefix,
+		cleanupTimeout: DefaultNamespaceSandboxCleanupTimeout,
+		pollInterval:   100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Namespace = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   names.GenerateName(s.prefix),
+			Labels: s.labels,
+		},
+	}
+	if err := cli.Create(ctx, s.Namespace); err != nil {
+		return nil, fmt.Errorf("create namespace %s failed: %w", s.Namespace.Name, err)
+	}
+
+	// the test context may already be canceled when the cleanup runs
+	cleanupCtx := context.WithoutCancel(ctx)
+	if s.t != nil {
+		s.t.Cleanup(func() {
+			if err := s.Cleanup(cleanupCtx); err != nil {
+				s.t.Errorf("cleanup namespace sandbox failed: %s", err)
+			}
+		})
+	} else {
+		DeferCleanup(func() error {
+			return s.Cleanup(cleanupCtx)
+		})
+	}
+	return s, nil
+}
+
+// Name returns the name of the namespace
+func (s *NamespaceSandbox) Name() string {
+	return s.Namespace.Name
+}
+
+// ObjectKeyIn returns the key of an object named name inside the namespace
+func (s *NamespaceSandbox) ObjectKeyIn(name string) client.ObjectKey {
+	return client.ObjectKey{Namespace: s.Namespace.Name, Name: name}
+}
+
+// ObjectMetaIn returns the metadata of an object named name inside the namespace
+func (s *NamespaceSandbox) ObjectMetaIn(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: s.Namespace.Name, Name: name}
+}
+
+// Cleanup deletes the namespace and waits until it is removed.
+// If the deletion does not finish within the cleanup timeout
+// finalizers are stripped from the objects configured using WithFinalizerStripping and from the namespace.
+// Clusters without a namespace controller, like envtest, never remove namespaces
+// so Cleanup does not wait again after stripping finalizers.
+func (s *NamespaceSandbox) Cleanup(ctx context.Context) error {
+	if err := client.IgnoreNotFound(s.client.Delete(ctx, s.Namespace)); err != nil {
+		return fmt.Errorf("delete namespace %s failed: %w", s.Namespace.Name, err)
+	}
+	err := wait.PollUntilContextTimeout(ctx, s.pollInterval, s.cleanupTimeout, true, func(ctx context.Context) (bool, error) {
+		err := s.client.Get(ctx, client.ObjectKeyFromObject(s.Namespace), &corev1.Namespace{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err == nil || !wait.Interrupted(err) {
+		return err
+	}
+	return s.stripFinalizers(ctx)
+}
+
+// stripFinalizers removes finalizers from objects in the namespace and from the namespace itself
+func (s *NamespaceSandbox) stripFinalizers(ctx context.Context) error {
+	errs := []error{}
+	for _, list := range s.finalizableLists {
+		list = list.DeepCopyObject().(client.ObjectList)
+		if err := s.client.List(ctx, list, client.InNamespace(s.Namespace.Name)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, item := range items {
+			if obj, ok := item.(client.Object); ok {
+				errs = append(errs, removeFinalizers(ctx, s.client, obj))
+			}
+		}
+	}
+
+	ns := &corev1.Namespace{}
+	if err := s.client.Get(ctx, client.ObjectKeyFromObject(s.Namespace), ns); err != nil {
+		errs = append(errs, client.IgnoreNotFound(err))
+		return utilerrors.NewAggregate(errs)
+	}
+	errs = append(errs, removeFinalizers(ctx, s.client, ns))
+	if len(ns.Spec.Finalizers) > 0 {
+		ns.Spec.Finalizers = nil
+		errs = append(errs, client.IgnoreNotFound(s.client.SubResource("finalize").Update(ctx, ns)))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// removeFinalizers patches obj removing all its finalizers
+func removeFinalizers(ctx context.Context, cli client.Client, obj client.Object) error {
+	if len(obj.GetFinalizers()) == 0 {
+		return nil
+	}
+	base := obj.DeepCopyObject().(client.Object)
+	obj.SetFinalizers(nil)
+	return client.IgnoreNotFound(cli.Patch(ctx, obj, client.MergeFrom(base)))
+}
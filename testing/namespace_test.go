@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceSandbox(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	var first, second *NamespaceSandbox
+	t.Run("create", func(t *testing.T) {
+		var err error
+		first, err = NewNamespaceSandbox(ctx, cli, WithTestingT(t), WithNamespaceLabels(map[string]string{"a": "b"}))
+		g.Expect(err).To(BeNil())
+		second, err = NewNamespaceSandbox(ctx, cli, WithTestingT(t), WithNamespacePrefix("other-"))
+		g.Expect(err).To(BeNil())
+
+		g.Expect(first.Name()).To(HavePrefix(DefaultNamespaceSandboxPrefix))
+		g.Expect(second.Name()).To(HavePrefix("other-"))
+		g.Expect(first.Name()).NotTo(Equal(second.Name()))
+		g.Expect(first.ObjectKeyIn("cm")).To(Equal(client.ObjectKey{Namespace: first.Name(), Name: "cm"}))
+		g.Expect(first.ObjectMetaIn("cm").Namespace).To(Equal(first.Name()))
+
+		ns := &corev1.Namespace{}
+		g.Expect(cli.Get(ctx, client.ObjectKey{Name: first.Name()}, ns)).To(Succeed())
+		g.Expect(ns.Labels).To(Equal(map[string]string{"a": "b"}))
+	})
+
+	// namespaces are deleted by the cleanup registered with t
+	for _, sandbox := range []*NamespaceSandbox{first, second} {
+		err := cli.Get(ctx, client.ObjectKey{Name: sandbox.Name()}, &corev1.Namespace{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+}
+
+func TestNamespaceSandbox_StripFinalizers(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	sandbox, err := NewNamespaceSandbox(ctx, cli, WithTestingT(t),
+		WithCleanupTimeout(200*time.Millisecond), WithFinalizerStripping(&corev1.ConfigMapList{}))
+	g.Expect(err).To(BeNil())
+
+	sandbox.Namespace.Finalizers = []string{"test/hang"}
+	g.Expect(cli.Update(ctx, sandbox.Namespace)).To(Succeed())
+	cm := &corev1.ConfigMap{ObjectMeta: sandbox.ObjectMetaIn("cm")}
+	cm.Finalizers = []string{"test/hang"}
+	g.Expect(cli.Create(ctx, cm)).To(Succeed())
+	g.Expect(cli.Delete(ctx, cm)).To(Succeed())
+
+	g.Expect(sandbox.Cleanup(ctx)).To(Succeed())
+
+	err = cli.Get(ctx, client.ObjectKey{Name: sandbox.Name()}, &corev1.Namespace{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = cli.Get(ctx, sandbox.ObjectKeyIn("cm"), &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultDiffIgnoreFields are the volatile fields always ignored by DiffObjects
+var DefaultDiffIgnoreFields = []string{
+	"metadata.resourceVersion",
+	"metadata.managedFields",
+	"metadata.creationTimestamp",
+}
+
+// DiffObjects returns a unified diff between the yaml representation of expected and actual,
+// or an empty string if they are equivalent.
+// DefaultDiffIgnoreFields and ignoreFields, given as dot separated paths
+// like "status" or "metadata.labels", are removed from both objects before comparing.
+func DiffObjects(expected, actual client.Object, ignoreFields ...string) (string, error) {
+	ignoreFields = append(append([]string{}, DefaultDiffIgnoreFields...), ignoreFields...)
+	want, err := objectYAML(expected, ignoreFields)
+	if err != nil {
+		return "", fmt.Errorf("convert expected object failed: %w", err)
+	}
+	got, err := objectYAML(actual, ignoreFields)
+	if err != nil {
+		return "", fmt.Errorf("convert actual object failed: %w", err)
+	}
+	if want == got {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(want),
+		B:        difflib.SplitLines(got),
+		FromFile: "expected",
+		ToFile:   "actual",
+		Context:  3,
+	})
+}
+
+// objectYAML converts obj to yaml with sorted keys after removing ignoreFields
+// obj is copied first because unstructured objects are converted without copying
+func objectYAML(obj client.Object, ignoreFields []string) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return "", err
+	}
+	for _, field := range ignoreFields {
+		unstructured.RemoveNestedField(content, strings.Split(field, ".")...)
+	}
+	data, err := yaml.Marshal(content)
+	return string(data), err
+}
+
+// BeEquivalentObject succeeds if actual is a client.Object without differences to expected
+// according to DiffObjects, failures print the unified yaml diff
+//
+//	Expect(obj).To(BeEquivalentObject(expected, "status"))
+func BeEquivalentObject(expected client.Object, ignoreFields ...string) gomega.OmegaMatcher {
+	return &equivalentObjectMatcher{expected: expected, ignoreFields: ignoreFields}
+}
+
+type equivalentObjectMatcher struct {
+	expected     client.Object
+	ignoreFields []string
+
+	diff string
+}
+
+// Match compares actual with the expected object using DiffObjects
+func (m *equivalentObjectMatcher) Match(actual interface{}) (success bool, err error) {
+	obj, ok := actual.(client.Object)
+	if !ok {
+		return false, fmt.Errorf("BeEquivalentObject expects a client.Object. Got:\n%s", format.Object(actual, 1))
+	}
+	if m.diff, err = DiffObjects(m.expected, obj, m.ignoreFields...); err != nil {
+		return false, err
+	}
+	return m.diff == "", nil
+}
+
+// FailureMessage returns the diff between the objects
+func (m *equivalentObjectMatcher) FailureMessage(_ interface{}) string {
+	return "Expected objects to be equivalent, diff:\n" + m.diff
+}
+
+// NegatedFailureMessage returns a message when the objects are unexpectedly equivalent
+func (m *equivalentObjectMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, "not to be equivalent to", m.expected)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiffObjects(t *testing.T) {
+	newConfigMap := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	var data = map[string]struct {
+		expected     *corev1.ConfigMap
+		actual       *corev1.ConfigMap
+		ignoreFields []string
+		diff         string
+	}{
+		"equal objects": {
+			expected: newConfigMap("value"),
+			actual:   newConfigMap("value"),
+		},
+		"volatile fields are ignored": {
+			expected: newConfigMap("value"),
+			actual: func() *corev1.ConfigMap {
+				cm := newConfigMap("value")
+				cm.ResourceVersion = "10"
+				cm.CreationTimestamp = metav1.Now()
+				cm.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "test"}}
+				return cm
+			}(),
+		},
+		"different data": {
+			expected: newConfigMap("value"),
+			actual:   newConfigMap("changed"),
+			diff: `--- expected
++++ actual
+@@ -1,5 +1,5 @@
+ data:
+-  key: value
++  key: changed
+ metadata:
+   name: cm
+   namespace: default
+`,
+		},
+		"ignored fields": {
+			expected: newConfigMap("value"),
+			actual: func() *corev1.ConfigMap {
+				cm := newConfigMap("changed")
+				cm.Labels = map[string]string{"a": "b"}
+				return cm
+			}(),
+			ignoreFields: []string{"data", "metadata.labels"},
+		},
+	}
+
+	for name, item := range data {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			diff, err := DiffObjects(item.expected, item.actual, item.ignoreFields...)
+			g.Expect(err).To(BeNil())
+			g.Expect(diff).To(Equal(item.diff))
+		})
+	}
+}
+
+func TestBeEquivalentObject(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	expected := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", ResourceVersion: "1"},
+		Data:       map[string]string{"key": "value"},
+	}
+	actual := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cm", "resourceVersion": "2"},
+		"data":       map[string]interface{}{"key": "value"},
+	}}
+	g.Expect(actual).To(BeEquivalentObject(expected))
+
+	expected.Data["key"] = "other"
+	g.Expect(actual).NotTo(BeEquivalentObject(expected))
+	g.Expect(actual).To(BeEquivalentObject(expected, "data"))
+
+	matcher := BeEquivalentObject(expected)
+	success, err := matcher.Match(actual)
+	g.Expect(success).To(BeFalse())
+	g.Expect(err).To(BeNil())
+	g.Expect(matcher.FailureMessage(actual)).To(ContainSubstring("-  key: other\n+  key: value"))
+
+	_, err = matcher.Match("not an object")
+	g.Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Redacted replaces credentials in cassettes
+const Redacted = "REDACTED"
+
+var (
+	// DefaultRedactedHeaders are headers whose values are replaced by Redacted
+	DefaultRedactedHeaders = []string{
+		"Authorization",
+		"Proxy-Authorization",
+		"Cookie",
+		"Set-Cookie",
+		"Private-Token",
+		"X-Api-Key",
+		"X-Auth-Token",
+		"X-Gitlab-Token",
+		"X-Hub-Signature-256",
+	}
+	// DefaultRedactedQueryParams are query parameters whose values are replaced by Redacted
+	DefaultRedactedQueryParams = []string{"access_token", "token", "private_token", "api_key", "client_secret", "password"}
+)
+
+// Cassette is a list of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it received
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a request or response body, saved as text or as base64 when it is binary
+type Body []byte
+
+// MarshalJSON saves text bodies as strings and binary bodies as base64 prefixed with "base64:"
+func (b Body) MarshalJSON() ([]byte, error) {
+	content := string(b)
+	if !utf8.Valid(b) || strings.HasPrefix(content, base64Prefix) {
+		content = base64Prefix + base64.StdEncoding.EncodeToString(b)
+	}
+	return json.Marshal(content)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var content string
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	if encoded, ok := strings.CutPrefix(content, base64Prefix); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("decode base64 body failed: %w", err)
+		}
+		*b = decoded
+		return nil
+	}
+	*b = Body(content)
+	return nil
+}
+
+const base64Prefix = "base64:"
+
+// LoadCassette reads a cassette file
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{}
+	if err = yaml.Unmarshal(content, cassette); err != nil {
+		return nil, fmt.Errorf("parse cassette %s failed: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Save writes the cassette into path, creating its directory
+func (c *Cassette) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// sanitizer redacts credentials of interactions
+type sanitizer struct {
+	headers     []string
+	queryParams []string
+}
+
+func (s *sanitizer) header(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	header = header.Clone()
+	for _, name := range s.headers {
+		if values := header.Values(name); len(values) > 0 {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = Redacted
+			}
+			header[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	return header
+}
+
+// url removes the user information and redacts the credential query parameters of u
+func (s *sanitizer) url(u *url.URL) string {
+	copied := *u
+	copied.User = nil
+	if copied.RawQuery != "" {
+		query := copied.Query()
+		for _, param := range s.queryParams {
+			if query.Has(param) {
+				query.Set(param, Redacted)
+			}
+		}
+		copied.RawQuery = query.Encode()
+	}
+	return copied.String()
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recorder records real HTTP interactions into YAML cassettes and replays
+// them in tests, so code calling external APIs is tested deterministically and offline.
+// Credentials in headers, urls and query parameters are redacted before being saved.
+//
+// Cassettes are replayed by default and recorded against the real services when the
+// RECORD_CASSETTES environment variable is true or when they do not exist yet:
+//
+//	rec := recorder.Start(t, "testdata/github.yaml")
+//	clt, err := http.NewClient(http.WithTransportWrapper(rec.Wrap))
+//
+// ONLY FOR TEST USAGE
+package recorder
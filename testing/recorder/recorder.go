@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// RecordEnv environment variable that when set to true records cassettes against the real services
+const RecordEnv = "RECORD_CASSETTES"
+
+// Mode of a Recorder
+type Mode string
+
+const (
+	// ModeReplay responds with the recorded interactions, failing requests that were not recorded
+	ModeReplay Mode = "replay"
+	// ModeRecord sends requests to the real services and records the interactions
+	ModeRecord Mode = "record"
+)
+
+// ModeFromEnv returns ModeRecord when RecordEnv is true or the cassette does not exist, otherwise ModeReplay
+func ModeFromEnv(cassette string) Mode {
+	if record, _ := strconv.ParseBool(os.Getenv(RecordEnv)); record {
+		return ModeRecord
+	}
+	if _, err := os.Stat(cassette); errors.Is(err, fs.ErrNotExist) {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// MatchFunc returns true if req matches the recorded request, whose url is sanitized as url
+type MatchFunc func(req *http.Request, url string, body []byte, recorded Request) bool
+
+// MatchMethodAndURL matches requests by method and url, the default
+func MatchMethodAndURL(req *http.Request, url string, _ []byte, recorded Request) bool {
+	return req.Method == recorded.Method && url == recorded.URL
+}
+
+// MatchMethodURLAndBody matches requests by method, url and body
+func MatchMethodURLAndBody(req *http.Request, url string, body []byte, recorded Request) bool {
+	return MatchMethodAndURL(req, url, body, recorded) && bytes.Equal(body, recorded.Body)
+}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithRedactedHeaders redacts the headers in addition to DefaultRedactedHeaders
+func WithRedactedHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.sanitizer.headers = append(r.sanitizer.headers, headers...)
+	}
+}
+
+// WithRedactedQueryParams redacts the query parameters in addition to DefaultRedactedQueryParams
+func WithRedactedQueryParams(params ...string) Option {
+	return func(r *Recorder) {
+		r.sanitizer.queryParams = append(r.sanitizer.queryParams, params...)
+	}
+}
+
+// WithMatcher sets how requests are matched with recorded interactions, defaults to MatchMethodAndURL
+func WithMatcher(match MatchFunc) Option {
+	return func(r *Recorder) {
+		r.match = match
+	}
+}
+
+// Recorder records or replays the interactions of a cassette.
+// Each recorded interaction is replayed once, in order, so repeated requests
+// receive the responses in the order they were recorded
+type Recorder struct {
+	path      string
+	mode      Mode
+	match     MatchFunc
+	sanitizer sanitizer
+
+	lock     sync.Mutex
+	cassette *Cassette
+	replayed []bool
+}
+
+// New returns a Recorder of the cassette at path in mode, loading the cassette when replaying
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:  path,
+		mode:  mode,
+		match: MatchMethodAndURL,
+		sanitizer: sanitizer{
+			headers:     append([]string{}, DefaultRedactedHeaders...),
+			queryParams: append([]string{}, DefaultRedactedQueryParams...),
+		},
+		cassette: &Cassette{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if mode == ModeReplay {
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.replayed = make([]bool, len(cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Start returns a Recorder in the mode returned by ModeFromEnv,
+// saving the recorded cassette when the test finishes
+func Start(t testing.TB, path string, opts ...Option) *Recorder {
+	t.Helper()
+	r, err := New(path, ModeFromEnv(path), opts...)
+	if err != nil {
+		t.Fatalf("load cassette %s failed: %s", path, err)
+		return nil
+	}
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Errorf("save cassette %s failed: %s", path, err)
+		}
+	})
+	return r
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Stop saves the cassette when recording
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Wrap returns a RoundTripper recording the interactions sent with base, or replaying them.
+// When base is nil http.DefaultTransport is used
+func (r *Recorder) Wrap(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{recorder: r, base: base}
+}
+
+type transport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.recorder.mode == ModeReplay {
+		return t.recorder.replay(req, body)
+	}
+	return t.recorder.record(t.base, req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	url := r.sanitizer.url(req.URL)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !r.match(req, url, body, interaction.Request) {
+			continue
+		}
+		r.replayed[i] = true
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no interaction recorded in %s for %s %s", r.path, req.Method, url)
+}
+
+func (r *Recorder) record(base http.RoundTripper, req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.sanitizer.url(req.URL),
+			Header: r.sanitizer.header(req.Header),
+			Body:   body,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.sanitizer.header(resp.Header),
+			Body:       respBody,
+		},
+	}
+	r.lock.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.lock.Unlock()
+	return resp, nil
+}
+
+// readRequestBody returns the body of req, which can still be sent afterwards
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
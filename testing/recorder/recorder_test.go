@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRecorder(t *testing.T) {
+	g := NewGomegaWithT(t)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(r.Method + " " + string(body) + " " + r.URL.Query().Get("page")))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+	g.Expect(ModeFromEnv(path)).To(Equal(ModeRecord))
+
+	send := func(clt *http.Client, method, query, body string) string {
+		req, err := http.NewRequest(method, server.URL+"/api?"+query, strings.NewReader(body))
+		g.Expect(err).To(BeNil())
+		req.SetBasicAuth("user", "password")
+		resp, err := clt.Do(req)
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		content, _ := io.ReadAll(resp.Body)
+		return string(content)
+	}
+
+	rec, err := New(path, ModeRecord)
+	g.Expect(err).To(BeNil())
+	clt := &http.Client{Transport: rec.Wrap(nil)}
+	g.Expect(send(clt, http.MethodGet, "page=1&token=abc", "")).To(Equal("GET  1"))
+	g.Expect(send(clt, http.MethodGet, "page=2&token=abc", "")).To(Equal("GET  2"))
+	g.Expect(send(clt, http.MethodPost, "", "payload")).To(Equal("POST payload "))
+	g.Expect(rec.Stop()).To(Succeed())
+	g.Expect(calls).To(Equal(3))
+
+	content, err := os.ReadFile(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).NotTo(ContainSubstring("password"))
+	g.Expect(string(content)).NotTo(ContainSubstring("secret"))
+	g.Expect(string(content)).NotTo(ContainSubstring("abc"))
+	g.Expect(string(content)).To(ContainSubstring("token=REDACTED"))
+	g.Expect(ModeFromEnv(path)).To(Equal(ModeReplay))
+
+	// replays without calling the server, matching the redacted urls
+	rec, err = New(path, ModeReplay)
+	g.Expect(err).To(BeNil())
+	clt = &http.Client{Transport: rec.Wrap(nil)}
+	g.Expect(send(clt, http.MethodPost, "", "other")).To(Equal("POST payload "))
+	g.Expect(send(clt, http.MethodGet, "token=other&page=2", "")).To(Equal("GET  2"))
+	g.Expect(send(clt, http.MethodGet, "page=1&token=abc", "")).To(Equal("GET  1"))
+	_, err = clt.Get(server.URL + "/api?page=1&token=abc")
+	g.Expect(err).To(MatchError(ContainSubstring("no interaction recorded in " + path + " for GET")))
+	g.Expect(calls).To(Equal(3))
+
+	rec, err = New(path, ModeReplay, WithMatcher(MatchMethodURLAndBody))
+	g.Expect(err).To(BeNil())
+	clt = &http.Client{Transport: rec.Wrap(nil)}
+	_, err = clt.Post(server.URL+"/api", "text/plain", strings.NewReader("other"))
+	g.Expect(err).To(MatchError(ContainSubstring("no interaction recorded")))
+}
+
+func TestStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.yaml")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Api-Key", "secret")
+		_, _ = w.Write([]byte{0xff, 0x00})
+	}))
+	defer server.Close()
+
+	t.Run("record", func(t *testing.T) {
+		rec := Start(t, path, WithRedactedHeaders("X-Custom"))
+		g.Expect(rec.Mode()).To(Equal(ModeRecord))
+		resp, err := (&http.Client{Transport: rec.Wrap(nil)}).Get(server.URL)
+		g.Expect(err).To(BeNil())
+		resp.Body.Close()
+	})
+
+	cassette, err := LoadCassette(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(cassette.Interactions).To(HaveLen(1))
+	g.Expect(cassette.Interactions[0].Response.Header.Get("X-Api-Key")).To(Equal(Redacted))
+	g.Expect(cassette.Interactions[0].Response.Body).To(Equal(Body{0xff, 0x00}))
+
+	t.Run("replay", func(t *testing.T) {
+		rec := Start(t, path)
+		g.Expect(rec.Mode()).To(Equal(ModeReplay))
+		resp, err := (&http.Client{Transport: rec.Wrap(nil)}).Get(server.URL)
+		g.Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		g.Expect(body).To(Equal([]byte{0xff, 0x00}))
+	})
+}
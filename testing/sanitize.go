@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/AlaudaDevops/pkg/normalize"
+)
+
+// Sanitize removes the fields specific to the cluster objects were recorded from,
+// so they can be checked in as fixtures, see normalize.Sanitize
+//
+//	pod := &corev1.Pod{}
+//	MustLoadYaml("testdata/pod.recorded.yaml", pod)
+//	Sanitize(pod)
+func Sanitize(obj runtime.Object) error {
+	return normalize.Sanitize(obj)
+}
+
+// SanitizeContent is Sanitize for the content of an unstructured object
+func SanitizeContent(content map[string]interface{}) {
+	normalize.SanitizeContent(content)
+}
+
+// SanitizeManifest sanitizes every document of the yaml or json manifest read from r
+// and writes them into w as yaml documents, see normalize.SanitizeManifest
+func SanitizeManifest(r io.Reader, w io.Writer) error {
+	return normalize.SanitizeManifest(r, w)
+}
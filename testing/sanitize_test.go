@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSanitize(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &corev1.Pod{}
+	MustLoadYaml("testdata/sanitize/pod.recorded.yaml", pod)
+	g.Expect(pod.UID).NotTo(BeEmpty())
+
+	g.Expect(Sanitize(pod)).To(Succeed())
+	g.Expect(pod.UID).To(BeEmpty())
+	g.Expect(pod.ResourceVersion).To(BeEmpty())
+	g.Expect(pod.ManagedFields).To(BeEmpty())
+	g.Expect(pod.CreationTimestamp.IsZero()).To(BeTrue())
+	g.Expect(pod.Annotations).To(BeEmpty())
+	g.Expect(pod.OwnerReferences).To(Equal([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc"}}))
+	g.Expect(pod.Spec.NodeName).To(BeEmpty())
+	g.Expect(pod.Labels).To(HaveKeyWithValue("app", "web"))
+	g.Expect(pod.Status.Phase).To(Equal(corev1.PodRunning))
+}
+
+func TestSanitize_unstructured(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deploy := &unstructured.Unstructured{}
+	deploy.SetAPIVersion("apps/v1")
+	deploy.SetKind("Deployment")
+	deploy.SetName("web")
+	deploy.SetUID("uid")
+	deploy.SetResourceVersion("1")
+	deploy.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "keep": "me"})
+
+	g.Expect(Sanitize(deploy)).To(Succeed())
+	g.Expect(deploy.Object).To(Equal(map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"annotations": map[string]interface{}{"keep": "me"},
+		},
+	}))
+
+	list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*deploy.DeepCopy()}}
+	list.Items[0].SetUID("uid")
+	g.Expect(Sanitize(list)).To(Succeed())
+	g.Expect(list.Items[0].GetUID()).To(BeEmpty())
+
+	typed := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: 2, UID: "uid"}}
+	g.Expect(Sanitize(typed)).To(Succeed())
+	g.Expect(typed.ObjectMeta).To(Equal(metav1.ObjectMeta{Name: "web"}))
+}
+
+func TestSanitizeManifest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	recorded, err := os.Open("testdata/sanitize/pod.recorded.yaml")
+	g.Expect(err).To(BeNil())
+	defer recorded.Close()
+
+	out := &bytes.Buffer{}
+	g.Expect(SanitizeManifest(recorded, out)).To(Succeed())
+
+	golden, err := os.ReadFile("testdata/sanitize/pod.golden.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(out.String()).To(Equal(string(golden)))
+
+	g.Expect(SanitizeManifest(bytes.NewBufferString("a: [\n"), out)).To(MatchError(ContainSubstring("decode document 0 failed")))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MultiYamlWriter writes objects as yaml documents separated by ---,
+// the inverse of LoadMultiYamlOrJson. Keys are sorted so the output is stable
+// and null creationTimestamp fields added by typed objects are removed.
+type MultiYamlWriter struct {
+	// StripManagedFields removes metadata.managedFields
+	StripManagedFields bool
+	// StripStatus removes the status of objects
+	StripStatus bool
+}
+
+// MarshalMultiYaml returns the objects as yaml documents separated by ---
+func MarshalMultiYaml(objs ...interface{}) ([]byte, error) {
+	return MultiYamlWriter{}.Marshal(objs...)
+}
+
+// SaveMultiYaml writes the objects into w as yaml documents separated by ---
+func SaveMultiYaml(w io.Writer, objs ...interface{}) error {
+	return MultiYamlWriter{}.Save(w, objs...)
+}
+
+// Marshal returns the objects as yaml documents separated by ---
+func (m MultiYamlWriter) Marshal(objs ...interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := m.Save(buf, objs...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes the objects into w as yaml documents separated by ---
+func (m MultiYamlWriter) Save(w io.Writer, objs ...interface{}) error {
+	for i, obj := range objs {
+		doc, err := m.marshal(obj)
+		if err != nil {
+			return fmt.Errorf("marshal object %d failed: %w", i, err)
+		}
+		if i > 0 {
+			if _, err = io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// marshal converts obj into generic values, so keys are sorted,
+// before stripping fields and converting to yaml
+func (m MultiYamlWriter) marshal(obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var content interface{}
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	if object, ok := content.(map[string]interface{}); ok {
+		if m.StripStatus {
+			delete(object, "status")
+		}
+		if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+			if m.StripManagedFields {
+				delete(metadata, "managedFields")
+			}
+			if value, found := metadata["creationTimestamp"]; found && value == nil {
+				delete(metadata, "creationTimestamp")
+			}
+		}
+	}
+	return yaml.Marshal(content)
+}
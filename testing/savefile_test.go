@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMarshalMultiYaml(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "pod",
+			Namespace:     "default",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	cm := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm"},
+		Data:       map[string]string{"b": "2", "a": "1"},
+	}
+
+	data, err := MarshalMultiYaml(cm, map[string]string{"z": "last", "a": "first"})
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(Equal(`apiVersion: v1
+data:
+  a: "1"
+  b: "2"
+kind: ConfigMap
+metadata:
+  name: cm
+---
+a: first
+z: last
+`))
+
+	data, err = MultiYamlWriter{StripManagedFields: true, StripStatus: true}.Marshal(pod)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).NotTo(ContainSubstring("managedFields"))
+	g.Expect(string(data)).NotTo(ContainSubstring("status"))
+
+	data, err = MarshalMultiYaml(pod)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(ContainSubstring("managedFields"))
+	g.Expect(string(data)).To(ContainSubstring("phase: Running"))
+
+	// output can be loaded back
+	buf := &bytes.Buffer{}
+	g.Expect(SaveMultiYaml(buf, cm, pod)).To(Succeed())
+	cms := []corev1.ConfigMap{}
+	g.Expect(LoadMultiYamlOrJsonFromBytes(buf.Bytes(), &cms)).To(Succeed())
+	g.Expect(cms).To(HaveLen(2))
+	g.Expect(cms[0].Data).To(Equal(cm.Data))
+	g.Expect(cms[1].Name).To(Equal("pod"))
+
+	_, err = MarshalMultiYaml(make(chan int))
+	g.Expect(err).NotTo(BeNil())
+	g.Expect(SaveMultiYaml(failingWriter{}, cm)).NotTo(Succeed())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scale generates any number of synthetic objects from a template fixture,
+// varying their names, namespaces and labels, and seeds them into a fake client or
+// an envtest cluster, so predicates and list or index heavy controllers can be
+// benchmarked at increasing object counts with repeatable results:
+//
+//	func BenchmarkReconcile(b *testing.B) {
+//		gen := scale.MustFromFixture("testdata/widget.yaml", scheme, scale.WithLabelCardinality("team", 10))
+//		scale.RunSizes(b, scale.DefaultSizes, func(b *testing.B, count int) {
+//			clt := bench.NewCountingClient(gen.NewFakeClientBuilder(scheme, count).
+//				WithIndex(&v1.Widget{}, "team", indexTeam).Build())
+//			report, err := bench.RunRequests(ctx, bench.Options{
+//				Client:     clt,
+//				Reconciler: &WidgetReconciler{Client: clt},
+//				Events:     b.N,
+//				Workers:    4,
+//			}, gen.Requests(count))
+//			if err != nil {
+//				b.Fatal(err)
+//			}
+//			report.ReportMetrics(b)
+//		})
+//	}
+//
+// Predicates are measured with MeasureUpdatePredicate and MeasureCreatePredicate.
+package scale
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ktesting "github.com/AlaudaDevops/pkg/testing"
+	"github.com/AlaudaDevops/pkg/testing/bench"
+)
+
+// DefaultNameFormat formats the name of generated objects from the name of the template and their index
+const DefaultNameFormat = "%s-%d"
+
+// Generator generates objects copying a template
+type Generator struct {
+	template    client.Object
+	nameFormat  string
+	namespaces  []string
+	labels      map[string]int
+	labelPrefix string
+}
+
+// Option customizes a Generator
+type Option func(*Generator)
+
+// WithNameFormat sets the format of the names of generated objects, taking the name
+// of the template and the index of the object, defaults to DefaultNameFormat
+func WithNameFormat(format string) Option {
+	return func(g *Generator) {
+		g.nameFormat = format
+	}
+}
+
+// WithNamespaces spreads generated objects over namespaces in a round robin,
+// the namespace of the template is used otherwise
+func WithNamespaces(namespaces ...string) Option {
+	return func(g *Generator) {
+		g.namespaces = namespaces
+	}
+}
+
+// WithLabelCardinality sets the label key on generated objects with cardinality distinct values,
+// e.g. a cardinality of 10 spreads objects over 10 values for selectors and indexes to filter
+func WithLabelCardinality(key string, cardinality int) Option {
+	return func(g *Generator) {
+		if cardinality > 0 {
+			g.labels[key] = cardinality
+		}
+	}
+}
+
+// WithLabelValuePrefix sets the prefix of the values of labels set by WithLabelCardinality,
+// the value being the prefix followed by the index of the value, defaults to "value-"
+func WithLabelValuePrefix(prefix string) Option {
+	return func(g *Generator) {
+		g.labelPrefix = prefix
+	}
+}
+
+// NewGenerator returns a Generator of copies of template
+func NewGenerator(template client.Object, opts ...Option) *Generator {
+	g := &Generator{
+		template:    template,
+		nameFormat:  DefaultNameFormat,
+		labels:      map[string]int{},
+		labelPrefix: "value-",
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// FromFixture returns a Generator using the first object of the fixture file at path as template,
+// converted to its typed counterpart when its kind is registered in scheme
+func FromFixture(path string, scheme *runtime.Scheme, opts ...Option) (*Generator, error) {
+	objs, err := ktesting.LoadObjectsFromDir(path, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("fixture %s has no object", path)
+	}
+	return NewGenerator(objs[0], opts...), nil
+}
+
+// MustFromFixture is FromFixture panicking on errors
+func MustFromFixture(path string, scheme *runtime.Scheme, opts ...Option) *Generator {
+	g, err := FromFixture(path, scheme, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("load template fixture failed, path: %s, err: %s", path, err))
+	}
+	return g
+}
+
+// Object returns the i-th object, a copy of the template with its own name, namespace and labels.
+// It can be used as the NewObject function of bench.Options
+func (g *Generator) Object(i int) client.Object {
+	obj := g.template.DeepCopyObject().(client.Object)
+	obj.SetName(fmt.Sprintf(g.nameFormat, g.template.GetName(), i))
+	if len(g.namespaces) > 0 {
+		obj.SetNamespace(g.namespaces[i%len(g.namespaces)])
+	}
+	if len(g.labels) > 0 {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, len(g.labels))
+		}
+		for key, cardinality := range g.labels {
+			labels[key] = fmt.Sprintf("%s%d", g.labelPrefix, i%cardinality)
+		}
+		obj.SetLabels(labels)
+	}
+	return obj
+}
+
+// Objects returns the first count objects
+func (g *Generator) Objects(count int) []client.Object {
+	objs := make([]client.Object, count)
+	for i := range objs {
+		objs[i] = g.Object(i)
+	}
+	return objs
+}
+
+// Requests returns the reconcile requests of the first count objects
+func (g *Generator) Requests(count int) []reconcile.Request {
+	requests := make([]reconcile.Request, count)
+	for i := range requests {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(g.Object(i))}
+	}
+	return requests
+}
+
+// NewFakeClientBuilder returns a fake client builder seeded with the first count objects,
+// indexes and status subresources can be added before building the client
+func (g *Generator) NewFakeClientBuilder(scheme *runtime.Scheme, count int) *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(g.Objects(count)...)
+}
+
+// Seed creates the first count objects with clt, e.g. the client of an envtest cluster,
+// returning their reconcile requests
+func (g *Generator) Seed(ctx context.Context, clt client.Client, count int) ([]reconcile.Request, error) {
+	return bench.Seed(ctx, clt, count, g.Object)
+}
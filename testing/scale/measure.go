@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/AlaudaDevops/pkg/testing/bench"
+)
+
+// DefaultSizes are the object counts benchmarks are run with by RunSizes
+var DefaultSizes = []int{10, 100, 1000}
+
+// RunSizes runs fn as a sub benchmark of b for each object count of sizes,
+// named like objects=100, so results can be compared between counts
+func RunSizes(b *testing.B, sizes []int, fn func(b *testing.B, count int)) {
+	for _, count := range sizes {
+		count := count
+		b.Run(fmt.Sprintf("objects=%d", count), func(b *testing.B) {
+			fn(b, count)
+		})
+	}
+}
+
+// PredicateReport measurements of a predicate evaluated against generated objects
+type PredicateReport struct {
+	// Evaluated is the number of events evaluated
+	Evaluated int
+	// Passed is the number of events accepted by the predicate
+	Passed int
+	// Duration is the total time spent evaluating events
+	Duration time.Duration
+}
+
+// EventsPerSecond returns the throughput of the predicate
+func (r *PredicateReport) EventsPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Evaluated) / r.Duration.Seconds()
+}
+
+// ReportMetrics reports the throughput and the ratio of accepted events into b
+func (r *PredicateReport) ReportMetrics(b bench.MetricReporter) {
+	b.ReportMetric(r.EventsPerSecond(), "events/s")
+	if r.Evaluated > 0 {
+		b.ReportMetric(float64(r.Passed)/float64(r.Evaluated), "passed/event")
+	}
+}
+
+// MeasureUpdatePredicate evaluates the update events of pred for the first count objects,
+// each object being updated into the object returned by update, a copy of it the function can change.
+// A nil update sends events without changes, like resyncs do
+func (g *Generator) MeasureUpdatePredicate(pred predicate.Predicate, count int, update func(obj client.Object) client.Object) *PredicateReport {
+	events := make([]event.UpdateEvent, count)
+	for i := range events {
+		old := g.Object(i)
+		updated := old.DeepCopyObject().(client.Object)
+		if update != nil {
+			updated = update(updated)
+		}
+		events[i] = event.UpdateEvent{ObjectOld: old, ObjectNew: updated}
+	}
+
+	report := &PredicateReport{Evaluated: count}
+	start := time.Now()
+	for _, e := range events {
+		if pred.Update(e) {
+			report.Passed++
+		}
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+// MeasureCreatePredicate evaluates the create events of pred for the first count objects
+func (g *Generator) MeasureCreatePredicate(pred predicate.Predicate, count int) *PredicateReport {
+	objs := g.Objects(count)
+	report := &PredicateReport{Evaluated: count}
+	start := time.Now()
+	for _, obj := range objs {
+		if pred.Create(event.CreateEvent{Object: obj}) {
+			report.Passed++
+		}
+	}
+	report.Duration = time.Since(start)
+	return report
+}
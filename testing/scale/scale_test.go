@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/AlaudaDevops/pkg/testing/bench"
+)
+
+type configMapReconciler struct {
+	client.Client
+}
+
+func (r *configMapReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return reconcile.Result{}, r.Get(ctx, req.NamespacedName, &corev1.ConfigMap{})
+}
+
+func TestGenerator(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gen := MustFromFixture("testdata/configmap.yaml", scheme.Scheme,
+		WithNamespaces("ns-a", "ns-b"), WithLabelCardinality("team", 3))
+	objs := gen.Objects(4)
+	g.Expect(objs).To(HaveLen(4))
+	g.Expect(objs[0]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+	g.Expect(objs[1].GetName()).To(Equal("config-1"))
+	g.Expect(objs[1].GetNamespace()).To(Equal("ns-b"))
+	g.Expect(objs[2].GetNamespace()).To(Equal("ns-a"))
+	g.Expect(objs[3].GetLabels()).To(Equal(map[string]string{"app": "scale", "team": "value-0"}))
+	g.Expect(objs[2].GetLabels()).To(HaveKeyWithValue("team", "value-2"))
+	g.Expect(objs[0].(*corev1.ConfigMap).Data).To(HaveKeyWithValue("key", "value"))
+
+	g.Expect(gen.Requests(2)[1].NamespacedName).To(Equal(client.ObjectKey{Namespace: "ns-b", Name: "config-1"}))
+
+	_, err := FromFixture("testdata/missing.yaml", scheme.Scheme)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestGenerator_fakeClient(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	gen := NewGenerator(&corev1.ConfigMap{}, WithNameFormat("cm%[2]d"), WithNamespaces("default"), WithLabelCardinality("team", 2))
+
+	clt := gen.NewFakeClientBuilder(scheme.Scheme, 10).Build()
+	list := &corev1.ConfigMapList{}
+	g.Expect(clt.List(ctx, list, client.MatchingLabels{"team": "value-1"})).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(5))
+
+	requests, err := gen.Seed(ctx, clt, 0)
+	g.Expect(err).To(BeNil())
+	g.Expect(requests).To(BeEmpty())
+
+	counting := bench.NewCountingClient(clt)
+	report, err := bench.RunRequests(ctx, bench.Options{Client: counting, Reconciler: &configMapReconciler{Client: counting}, Workers: 2}, gen.Requests(10))
+	g.Expect(err).To(BeNil())
+	g.Expect(report.Reconciles).To(Equal(10))
+	g.Expect(report.Errors).To(Equal(0))
+}
+
+func TestMeasurePredicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gen := NewGenerator(&corev1.ConfigMap{}, WithNamespaces("default"))
+
+	report := gen.MeasureUpdatePredicate(predicate.GenerationChangedPredicate{}, 10, nil)
+	g.Expect(report.Evaluated).To(Equal(10))
+	g.Expect(report.Passed).To(Equal(0))
+
+	report = gen.MeasureUpdatePredicate(predicate.LabelChangedPredicate{}, 10, func(obj client.Object) client.Object {
+		obj.SetLabels(map[string]string{"changed": "true"})
+		return obj
+	})
+	g.Expect(report.Passed).To(Equal(10))
+	g.Expect(report.EventsPerSecond()).To(BeNumerically(">", 0))
+
+	report = gen.MeasureCreatePredicate(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == "-1"
+	}), 3)
+	g.Expect(report.Passed).To(Equal(1))
+}
+
+func BenchmarkLabelChangedPredicate(b *testing.B) {
+	gen := NewGenerator(&corev1.ConfigMap{}, WithLabelCardinality("team", 10))
+	RunSizes(b, DefaultSizes, func(b *testing.B, count int) {
+		for i := 0; i < b.N; i++ {
+			gen.MeasureUpdatePredicate(predicate.LabelChangedPredicate{}, count, nil).ReportMetrics(b)
+		}
+	})
+}
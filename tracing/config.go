@@ -39,6 +39,7 @@ const (
 	samplingRatioKey = "sampling-ratio"
 	jaegerConfigKey  = "jaeger-config"
 	zipkinConfigKey  = "zipkin-config"
+	otlpConfigKey    = "otlp-config"
 	customConfigKey  = "custom-config"
 )
 
@@ -48,6 +49,7 @@ type ExporterBackend string
 var (
 	ExporterBackendJaeger ExporterBackend = "jaeger"
 	ExporterBackendZipkin ExporterBackend = "zipkin"
+	ExporterBackendOTLP   ExporterBackend = "otlp"
 	ExporterBackendCustom ExporterBackend = "custom"
 )
 
@@ -72,9 +74,11 @@ type Config struct {
 	// Zipkin The configuration used by zipkin backend
 	Zipkin ZipkinConfig `json:"zipkin" yaml:"zipkin"`
 
+	// OTLP The configuration used by otlp backend
+	OTLP OTLPConfig `json:"otlp" yaml:"otlp"`
+
 	// Custom The configuration used by custom backend
 	Custom string `json:"custom" yaml:"custom"`
-	// todo support OTLP
 }
 
 // ZipkinConfig The configuration used by zipkin backend
@@ -83,6 +87,23 @@ type ZipkinConfig struct {
 	Url string `json:"url" yaml:"url"`
 }
 
+// OTLPConfig The configuration used by otlp backend exporting spans over http
+type OTLPConfig struct {
+	// Endpoint The host and port of the collector, e.g. otel-collector:4318.
+	// default localhost:4318.
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+	// URLPath The path spans are sent to.
+	// default /v1/traces.
+	URLPath string `json:"url_path" yaml:"urlPath"`
+
+	// Insecure Send spans over http instead of https.
+	Insecure bool `json:"insecure" yaml:"insecure"`
+
+	// Headers Additional headers sent with each export, e.g. for authentication.
+	Headers map[string]string `json:"headers" yaml:"headers"`
+}
+
 // JaegerConfig The configuration used by Jaeger backend
 type JaegerConfig struct {
 	// Host The host of jaeger backend.
@@ -134,6 +155,12 @@ func newTracingConfigFromConfigMap(config *corev1.ConfigMap) (*Config, error) {
 				return nil, err
 			}
 		}
+	case ExporterBackendOTLP:
+		if s, ok := config.Data[otlpConfigKey]; ok && s != "" {
+			if err := json.Unmarshal([]byte(s), &c.OTLP); err != nil {
+				return nil, err
+			}
+		}
 	default:
 		logging.FromContext(context.TODO()).Warnw("unknown tracing backend", "backend", c.Backend)
 	}
@@ -17,10 +17,15 @@ limitations under the License.
 package tracing
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -75,6 +80,25 @@ func Test_newTracingConfigFromConfigMap(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			input: map[string]string{
+				enableKey:        "true",
+				backendKey:       "otlp",
+				samplingRatioKey: "1",
+				otlpConfigKey:    `{"endpoint":"otel-collector:4318","insecure":true,"headers":{"authorization":"token"}}`,
+			},
+			want: &Config{
+				Enable:        true,
+				Backend:       ExporterBackendOTLP,
+				SamplingRatio: 1,
+				OTLP: OTLPConfig{
+					Endpoint: "otel-collector:4318",
+					Insecure: true,
+					Headers:  map[string]string{"authorization": "token"},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			input: map[string]string{
 				enableKey:        "true typo",
@@ -126,3 +150,37 @@ func TestConfigMapName(t *testing.T) {
 		g.Expect(got).Should(Equal(tt.want))
 	}
 }
+
+func TestOTLPExporter(t *testing.T) {
+	g := NewGomegaWithT(t)
+	original := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(original)
+
+	tracing := NewTracing(zap.NewNop().Sugar())
+	exporter, err := tracing.exporter(&Config{
+		Backend: ExporterBackendOTLP,
+		OTLP:    OTLPConfig{Endpoint: "localhost:4318", URLPath: "/traces", Insecure: true},
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(exporter).NotTo(BeNil())
+
+	memory := tracetest.NewInMemoryExporter()
+	tracing = NewTracing(zap.NewNop().Sugar(), WithExporter(func(*Config) (trace.SpanExporter, error) {
+		return keepSpansExporter{memory}, nil
+	}))
+	tracing.ApplyConfig(&Config{Enable: true, SamplingRatio: 1, Backend: ExporterBackendCustom})
+	_, span := otel.Tracer("test").Start(context.Background(), "command")
+	span.End()
+	g.Expect(memory.GetSpans()).To(BeEmpty(), "spans are batched")
+	g.Expect(tracing.Shutdown(context.Background())).To(Succeed())
+	g.Expect(memory.GetSpans()).To(HaveLen(1), "shutdown flushes the spans")
+}
+
+// keepSpansExporter keeps the exported spans on shutdown
+type keepSpansExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keepSpansExporter) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// TraceIDKey logger field holding the trace id of the current span
+	TraceIDKey = "trace_id"
+	// SpanIDKey logger field holding the id of the current span
+	SpanIDKey = "span_id"
+)
+
+// LoggerFields returns the trace and span ids of the span in ctx as logger key value pairs.
+// Returns nil if ctx has no valid span
+func LoggerFields(ctx context.Context) []interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []interface{}{
+		TraceIDKey, spanContext.TraceID().String(),
+		SpanIDKey, spanContext.SpanID().String(),
+	}
+}
+
+// LoggerWithSpan returns logger with the trace and span ids of the span in ctx,
+// logger is returned as is if ctx has no valid span
+func LoggerWithSpan(ctx context.Context, logger *zap.SugaredLogger) *zap.SugaredLogger {
+	fields := LoggerFields(ctx)
+	if logger == nil || len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
+
+// WithSpanLogger stores into ctx its logger with the trace and span ids of the span in ctx,
+// so logs written during the span can be correlated with it
+func WithSpanLogger(ctx context.Context) context.Context {
+	if len(LoggerFields(ctx)) == 0 {
+		return ctx
+	}
+	return logging.WithLogger(ctx, LoggerWithSpan(ctx, logging.FromContext(ctx)))
+}
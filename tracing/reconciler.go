@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// tracerName instrumentation name of the spans created by this package
+const tracerName = "github.com/AlaudaDevops/pkg/tracing"
+
+// WrapReconciler returns a reconciler running each reconcile of r inside a span named
+// "Reconcile <name>" with the object key, the result and the error as attributes.
+// The logger in the context given to r includes the trace and span ids
+func WrapReconciler(name string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
+		ctx, span := otel.Tracer(tracerName).Start(ctx, "Reconcile "+name,
+			trace.WithAttributes(
+				attribute.String("controller", name),
+				attribute.String("k8s.namespace.name", request.Namespace),
+				attribute.String("k8s.object.name", request.Name),
+			),
+		)
+		defer span.End()
+
+		result, err = r.Reconcile(WithSpanLogger(ctx), request)
+
+		span.SetAttributes(
+			attribute.Bool("reconcile.requeue", result.Requeue),
+			attribute.String("reconcile.requeue_after", result.RequeueAfter.String()),
+		)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return result, err
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// useSpanRecorder sets a global trace provider recording spans until the test ends
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(original) })
+	return recorder
+}
+
+func bufferLogger() (*zap.SugaredLogger, *bytes.Buffer) {
+	buffer := &bytes.Buffer{}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(buffer), zapcore.InfoLevel)
+	return zap.New(core).Sugar(), buffer
+}
+
+func TestWrapReconciler(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "foo"}}
+
+	var data = []struct {
+		desc   string
+		result reconcile.Result
+		err    error
+
+		status codes.Code
+	}{
+		{desc: "success", result: reconcile.Result{RequeueAfter: time.Minute}, status: codes.Unset},
+		{desc: "error", err: errors.New("sync failed"), status: codes.Error},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			logger, buffer := bufferLogger()
+			ctx := logging.WithLogger(context.Background(), logger)
+
+			r := WrapReconciler("foo", reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				logging.FromContext(ctx).Info("reconciling")
+				return item.result, item.err
+			}))
+			result, err := r.Reconcile(ctx, request)
+			g.Expect(result).To(Equal(item.result))
+			if item.err == nil {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(item.err))
+			}
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			g.Expect(span.Name()).To(Equal("Reconcile foo"))
+			g.Expect(span.Status().Code).To(Equal(item.status))
+			g.Expect(span.Attributes()).To(ContainElements(
+				attribute.String("k8s.namespace.name", "default"),
+				attribute.String("k8s.object.name", "foo"),
+				attribute.String("reconcile.requeue_after", item.result.RequeueAfter.String()),
+			))
+			g.Expect(buffer.String()).To(ContainSubstring(`"trace_id":"` + span.SpanContext().TraceID().String() + `"`))
+		})
+	}
+}
+
+func TestLoggerFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+	logger, _ := bufferLogger()
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	g.Expect(LoggerFields(ctx)).To(BeNil())
+	g.Expect(LoggerWithSpan(ctx, logger)).To(BeIdenticalTo(logger))
+	g.Expect(WithSpanLogger(ctx)).To(BeIdenticalTo(ctx))
+
+	useSpanRecorder(t)
+	ctx, span := otel.Tracer("test").Start(ctx, "test")
+	defer span.End()
+	g.Expect(LoggerFields(ctx)).To(Equal([]interface{}{
+		TraceIDKey, span.SpanContext().TraceID().String(),
+		SpanIDKey, span.SpanContext().SpanID().String(),
+	}))
+	g.Expect(LoggerWithSpan(ctx, nil)).To(BeNil())
+}
@@ -22,6 +22,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/zipkin"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -65,6 +66,9 @@ type Tracing struct {
 
 	traceProviderOptions []trace.TracerProviderOption
 	Propagators          []propagation.TextMapPropagator
+
+	// provider the last trace provider applied
+	provider traceApi.TracerProvider
 }
 
 // ApplyConfig Apply configuration and reinitialize global tracing.
@@ -89,6 +93,19 @@ func (t *Tracing) ApplyConfig(cfg *Config) {
 	}
 
 	otel.SetTracerProvider(tp)
+	t.provider = tp
+}
+
+// Shutdown flushes the spans and stops the trace provider applied by ApplyConfig.
+// Short lived processes like CLIs should call it before exiting.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if provider, ok := t.provider.(interface{ Shutdown(context.Context) error }); ok {
+		return provider.Shutdown(ctx)
+	}
+	return nil
 }
 
 // traceProvider construct traceProvider according to the specified configuration.
@@ -147,6 +164,8 @@ func (t *Tracing) exporter(config *Config) (exporter trace.SpanExporter, err err
 			exporter, err = t.constructJaegerExporter(config.Jaeger)
 		case ExporterBackendZipkin:
 			exporter, err = t.constructZipkinExporter(config.Zipkin)
+		case ExporterBackendOTLP:
+			exporter, err = t.constructOTLPExporter(config.OTLP)
 		case ExporterBackendCustom:
 			t.logger.Errorw("Use WithExporter function to customize exporter",
 				"err", err,
@@ -172,6 +191,31 @@ func (t *Tracing) constructZipkinExporter(cfg ZipkinConfig) (exporter trace.Span
 	return exporter, err
 }
 
+// constructOTLPExporter construct otlp http exporter according to the specified configuration.
+func (t *Tracing) constructOTLPExporter(cfg OTLPConfig) (exporter trace.SpanExporter, err error) {
+	ops := make([]otlptracehttp.Option, 0)
+	if cfg.Endpoint != "" {
+		ops = append(ops, otlptracehttp.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.URLPath != "" {
+		ops = append(ops, otlptracehttp.WithURLPath(cfg.URLPath))
+	}
+	if cfg.Insecure {
+		ops = append(ops, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		ops = append(ops, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exporter, err = otlptracehttp.New(context.Background(), ops...)
+	if err != nil {
+		t.logger.Errorw("Tracing construct otlp exporter error",
+			"err", err,
+			"config", cfg,
+		)
+	}
+	return exporter, err
+}
+
 // constructJaegerExporter construct jaeger exporter according to the specified configuration.
 func (t *Tracing) constructJaegerExporter(cfg JaegerConfig) (exporter trace.SpanExporter, err error) {
 	ops := make([]jaeger.AgentEndpointOption, 0)
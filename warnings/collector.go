@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warnings
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+// APIWarning is a warning returned by the api server in a Warning header
+type APIWarning struct {
+	// Code is the warning code, 299 for deprecation and other warnings
+	Code int
+	// Agent is the name of the component which added the warning
+	Agent string
+	// Text is the warning message
+	Text string
+}
+
+// IsDeprecation returns true if the warning is about a deprecated api or field
+func (w APIWarning) IsDeprecation() bool {
+	return strings.Contains(strings.ToLower(w.Text), "deprecated")
+}
+
+// Collector is a rest.WarningHandler collecting warnings returned by the api server
+// instead of printing them, so they can be queried or printed once at the end of a command.
+// Identical warnings are only collected once.
+type Collector struct {
+	lock     sync.Mutex
+	warnings []APIWarning
+	seen     map[string]bool
+	printed  int
+}
+
+var _ rest.WarningHandler = &Collector{}
+
+// NewCollector returns an empty Collector
+func NewCollector() *Collector {
+	return &Collector{seen: map[string]bool{}}
+}
+
+// HandleWarningHeader implements rest.WarningHandler
+// only warnings with code 299 are collected, as done by client-go handlers
+func (c *Collector) HandleWarningHeader(code int, agent string, text string) {
+	if code != 299 || len(text) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.seen[text] {
+		return
+	}
+	c.seen[text] = true
+	c.warnings = append(c.warnings, APIWarning{Code: code, Agent: agent, Text: text})
+}
+
+// Warnings returns the collected warnings in the order they were received
+func (c *Collector) Warnings() []APIWarning {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]APIWarning{}, c.warnings...)
+}
+
+// Deprecations returns the collected deprecation warnings
+func (c *Collector) Deprecations() (deprecations []APIWarning) {
+	for _, w := range c.Warnings() {
+		if w.IsDeprecation() {
+			deprecations = append(deprecations, w)
+		}
+	}
+	return
+}
+
+// Print writes the warnings not printed yet into w, one per line prefixed with Warning:
+// the prefix is colorized when color is true
+func (c *Collector) Print(w io.Writer, color bool) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	prefix := "Warning:"
+	if color {
+		prefix = "\033[33;1mWarning:\033[0m"
+	}
+	for ; c.printed < len(c.warnings); c.printed++ {
+		if _, err := fmt.Fprintf(w, "%s %s\n", prefix, c.warnings[c.printed].Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigWithCollector returns a copy of config using collector as its warning handler
+func ConfigWithCollector(config *rest.Config, collector *Collector) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.WarningHandler = collector
+	return config
+}
+
+type collectorKey struct{}
+
+// WithCollector adds a Collector to the context
+func WithCollector(ctx context.Context, collector *Collector) context.Context {
+	return context.WithValue(ctx, collectorKey{}, collector)
+}
+
+// CollectorFrom returns the Collector in the context, nil if not found
+func CollectorFrom(ctx context.Context) *Collector {
+	collector, _ := ctx.Value(collectorKey{}).(*Collector)
+	return collector
+}
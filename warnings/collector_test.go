@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warnings
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("Collector", func() {
+	var collector *Collector
+
+	BeforeEach(func() {
+		collector = NewCollector()
+		collector.HandleWarningHeader(299, "kube-apiserver", "batch/v1beta1 CronJob is deprecated in v1.21+")
+		collector.HandleWarningHeader(299, "kube-apiserver", "batch/v1beta1 CronJob is deprecated in v1.21+")
+		collector.HandleWarningHeader(299, "kube-apiserver", "unknown field \"spec.foo\"")
+		collector.HandleWarningHeader(199, "kube-apiserver", "ignored code")
+		collector.HandleWarningHeader(299, "kube-apiserver", "")
+	})
+
+	It("should collect deduplicated warnings", func() {
+		Expect(collector.Warnings()).To(Equal([]APIWarning{
+			{Code: 299, Agent: "kube-apiserver", Text: "batch/v1beta1 CronJob is deprecated in v1.21+"},
+			{Code: 299, Agent: "kube-apiserver", Text: "unknown field \"spec.foo\""},
+		}))
+		Expect(collector.Deprecations()).To(HaveLen(1))
+	})
+
+	It("should print warnings once", func() {
+		out := &bytes.Buffer{}
+		Expect(collector.Print(out, false)).To(Succeed())
+		Expect(out.String()).To(Equal("Warning: batch/v1beta1 CronJob is deprecated in v1.21+\nWarning: unknown field \"spec.foo\"\n"))
+
+		out.Reset()
+		Expect(collector.Print(out, true)).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+
+		collector.HandleWarningHeader(299, "", "new warning")
+		Expect(collector.Print(out, true)).To(Succeed())
+		Expect(out.String()).To(Equal("\033[33;1mWarning:\033[0m new warning\n"))
+	})
+
+	It("should be set in configs and contexts", func() {
+		config := &rest.Config{Host: "https://example.com"}
+		withCollector := ConfigWithCollector(config, collector)
+		Expect(withCollector.WarningHandler).To(BeIdenticalTo(collector))
+		Expect(config.WarningHandler).To(BeNil())
+
+		Expect(CollectorFrom(context.Background())).To(BeNil())
+		Expect(CollectorFrom(WithCollector(context.Background(), collector))).To(BeIdenticalTo(collector))
+	})
+})
@@ -15,6 +15,7 @@ limitations under the License.
 */
 
 // Package warnings contains useful functions to manage warnings in the status
+// and to collect warnings returned by the api server
 package warnings
 
 import "knative.dev/pkg/apis"
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	mv1alpha1 "github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/migration"
 	admissionv1 "k8s.io/api/admission/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -106,6 +108,7 @@ func WithUpdatedBy() TransformFunc {
 }
 
 // WithUpdateTime adds a updateTime annotation to the object
+// using the clock in the context
 func WithUpdateTime() TransformFunc {
 	return func(ctx context.Context, obj runtime.Object, req admission.Request) {
 		if req.Operation != admissionv1.Update {
@@ -116,7 +119,55 @@ func WithUpdateTime() TransformFunc {
 		if annotations == nil {
 			annotations = map[string]string{}
 		}
-		annotations[mv1alpha1.UpdatedTimeAnnotationKey] = time.Now().Format(time.RFC3339)
+		annotations[mv1alpha1.UpdatedTimeAnnotationKey] = clock.Now(ctx).Format(time.RFC3339)
 		newObj.SetAnnotations(annotations)
 	}
 }
+
+// WithCreatedTime adds a creationTime annotation to the object when missing
+// using the clock in the context
+func WithCreatedTime() TransformFunc {
+	return func(ctx context.Context, obj runtime.Object, req admission.Request) {
+		if req.Operation != admissionv1.Create {
+			return
+		}
+		if metaobj, ok := obj.(metav1.Object); ok {
+			mv1alpha1.TrackCreation(metaobj, nil, clock.Now(ctx))
+		}
+	}
+}
+
+// WithTracking adds the createdBy and creationTime annotations on create and
+// the updatedBy and updateTime annotations on update, using the request user
+// and the clock in the context. Existing creation annotations are kept
+func WithTracking() TransformFunc {
+	return func(ctx context.Context, obj runtime.Object, req admission.Request) {
+		metaobj, ok := obj.(metav1.Object)
+		if !ok {
+			return
+		}
+		switch req.Operation {
+		case admissionv1.Create:
+			mv1alpha1.TrackCreation(metaobj, SubjectFromRequest(req), clock.Now(ctx))
+		case admissionv1.Update:
+			mv1alpha1.TrackUpdate(metaobj, SubjectFromRequest(req), clock.Now(ctx))
+		}
+	}
+}
+
+// WithKeyMigration renames deprecated annotation and label keys of the object
+// on create and update using the migrator rules
+func WithKeyMigration(migrator *migration.Migrator) TransformFunc {
+	return func(ctx context.Context, obj runtime.Object, req admission.Request) {
+		if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+			return
+		}
+		metaobj, ok := obj.(metav1.Object)
+		if !ok {
+			return
+		}
+		if migrator.Migrate(metaobj) {
+			logging.FromContext(ctx).Debugw("migrated annotation and label keys", "name", metaobj.GetName())
+		}
+	}
+}
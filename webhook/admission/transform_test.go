@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/AlaudaDevops/pkg/apis/meta/v1alpha1"
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/migration"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/matchers"
 	v1 "k8s.io/api/admission/v1"
@@ -59,6 +61,10 @@ func TestWithUpdateTime(t *testing.T) {
 	WithUpdateTime()(ctx, obj, req)
 	beTimeString := matchers.NewWithTransformMatcher(checkTimeString, BeTrue())
 	g.Expect(obj.Annotations[v1alpha1.UpdatedTimeAnnotationKey]).To(beTimeString)
+
+	ctx = clock.WithClock(ctx, clock.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	WithUpdateTime()(ctx, obj, req)
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(v1alpha1.UpdatedTimeAnnotationKey, "2024-01-02T03:04:05Z"))
 }
 
 func checkTimeString(actual interface{}) (interface{}, error) {
@@ -69,3 +75,52 @@ func checkTimeString(actual interface{}) (interface{}, error) {
 	_, err := time.Parse(time.RFC3339, str)
 	return err == nil, err
 }
+
+func TestWithKeyMigration(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	migrator := migration.NewMigrator([]migration.Rule{migration.DomainRule("cpaas.io", "alauda.io")}, nil)
+	obj := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod",
+			Annotations: map[string]string{"cpaas.io/displayName": "Pod"},
+		},
+	}
+
+	req := admission.Request{AdmissionRequest: v1.AdmissionRequest{Operation: v1.Delete}}
+	WithKeyMigration(migrator)(ctx, obj, req)
+	g.Expect(obj.Annotations).To(HaveKey("cpaas.io/displayName"))
+
+	req.Operation = v1.Update
+	WithKeyMigration(migrator)(ctx, obj, req)
+	g.Expect(obj.Annotations).To(Equal(map[string]string{"alauda.io/displayName": "Pod"}))
+}
+
+func TestWithTracking(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := clock.WithClock(context.Background(), clock.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}}
+
+	req := admission.Request{AdmissionRequest: v1.AdmissionRequest{Operation: v1.Create}}
+	req.UserInfo.Username = "admin"
+	WithTracking()(ctx, obj, req)
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(v1alpha1.CreatedTimeAnnotationKey, "2024-01-02T03:04:05Z"))
+	by, err := v1alpha1.GetCreatedBy(obj)
+	g.Expect(err).To(BeNil())
+	g.Expect(by.User.Name).To(Equal("admin"))
+	g.Expect(obj.Annotations).NotTo(HaveKey(v1alpha1.UpdatedTimeAnnotationKey))
+
+	ctx = clock.WithClock(ctx, clock.NewFakeClock(time.Date(2024, 2, 2, 3, 4, 5, 0, time.UTC)))
+	req.Operation = v1.Update
+	req.UserInfo.Username = "system:serviceaccount:default:builder"
+	WithTracking()(ctx, obj, req)
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(v1alpha1.CreatedTimeAnnotationKey, "2024-01-02T03:04:05Z"))
+	g.Expect(obj.Annotations).To(HaveKeyWithValue(v1alpha1.UpdatedTimeAnnotationKey, "2024-02-02T03:04:05Z"))
+	updatedBy, _ := v1alpha1.GetUpdatedBy(obj)
+	g.Expect(updatedBy.User.Kind).To(Equal("ServiceAccount"))
+
+	created := &corev1.ConfigMap{}
+	req.Operation = v1.Create
+	WithCreatedTime()(ctx, created, req)
+	g.Expect(created.Annotations).To(Equal(map[string]string{v1alpha1.CreatedTimeAnnotationKey: "2024-02-02T03:04:05Z"}))
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// CACertKey key of the CA certificate in the secret
+	CACertKey = "ca.crt"
+	// CAKeyKey key of the CA private key in the secret
+	CAKeyKey = "ca.key"
+	// CertKey key of the serving certificate in the secret and the certificate directory
+	CertKey = corev1.TLSCertKey
+	// KeyKey key of the serving private key in the secret and the certificate directory
+	KeyKey = corev1.TLSPrivateKeyKey
+
+	// DefaultCAValidity validity of generated CA certificates
+	DefaultCAValidity = 10 * 365 * 24 * time.Hour
+	// DefaultCertValidity validity of generated serving certificates
+	DefaultCertValidity = 365 * 24 * time.Hour
+	// DefaultRotationThreshold certificates are rotated when
+	// expiring in less than this duration
+	DefaultRotationThreshold = 30 * 24 * time.Hour
+	// DefaultCertDirSyncInterval interval of the certificate directory sync of every replica
+	DefaultCertDirSyncInterval = time.Minute
+)
+
+// KeyPair PEM encoded certificate and private key
+type KeyPair struct {
+	Cert []byte
+	Key  []byte
+}
+
+// Certificates CA and serving key pairs of a webhook server
+type Certificates struct {
+	CA      KeyPair
+	Serving KeyPair
+}
+
+// FromSecret returns the certificates stored in the secret data
+func FromSecret(secret *corev1.Secret) *Certificates {
+	return &Certificates{
+		CA:      KeyPair{Cert: secret.Data[CACertKey], Key: secret.Data[CAKeyKey]},
+		Serving: KeyPair{Cert: secret.Data[CertKey], Key: secret.Data[KeyKey]},
+	}
+}
+
+// SecretData returns the certificates as secret data
+func (c *Certificates) SecretData() map[string][]byte {
+	return map[string][]byte{
+		CACertKey: c.CA.Cert,
+		CAKeyKey:  c.CA.Key,
+		CertKey:   c.Serving.Cert,
+		KeyKey:    c.Serving.Key,
+	}
+}
+
+// Equal returns true if both certificates have the same content
+func (c *Certificates) Equal(other *Certificates) bool {
+	return bytes.Equal(c.CA.Cert, other.CA.Cert) && bytes.Equal(c.CA.Key, other.CA.Key) &&
+		bytes.Equal(c.Serving.Cert, other.Serving.Cert) && bytes.Equal(c.Serving.Key, other.Serving.Key)
+}
+
+// GenerateCA generates a self signed CA valid from now for the validity duration
+func GenerateCA(commonName string, now time.Time, validity time.Duration) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return generate(template, nil, nil)
+}
+
+// GenerateServingCert generates a serving certificate for the dns names signed by the CA,
+// valid from now for the validity duration
+func GenerateServingCert(ca *KeyPair, dnsNames []string, now time.Time, validity time.Duration) (*KeyPair, error) {
+	if len(dnsNames) == 0 {
+		return nil, errors.New("at least one dns name is required")
+	}
+	caCert, err := parseCert(ca.Cert)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA certificate failed: %w", err)
+	}
+	caKey, err := parseKey(ca.Key)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA private key failed: %w", err)
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-time.Minute),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return generate(template, caCert, caKey)
+}
+
+// generate creates a new private key and a certificate from template signed by parent,
+// or self signed when parent is nil
+func generate(template, parent *x509.Certificate, parentKey crypto.Signer) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate private key failed: %w", err)
+	}
+	if template.SerialNumber, err = rand.Int(rand.Reader, big.NewInt(math.MaxInt64)); err != nil {
+		return nil, fmt.Errorf("generate serial number failed: %w", err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate failed: %w", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key failed: %w", err)
+	}
+	return &KeyPair{
+		Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}, nil
+}
+
+// Validate returns an error if the CA is invalid or expires before deadline,
+// caExpired is true when the CA itself needs to be regenerated. Otherwise
+// returns an error if the serving certificate is invalid, expires before deadline,
+// is not signed by the CA or does not cover all the dns names
+func (c *Certificates) Validate(dnsNames []string, deadline time.Time) (caExpired bool, err error) {
+	caCert, err := parseCert(c.CA.Cert)
+	if err != nil {
+		return true, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	if _, err = parseKey(c.CA.Key); err != nil {
+		return true, fmt.Errorf("invalid CA private key: %w", err)
+	}
+	if caCert.NotAfter.Before(deadline) {
+		return true, fmt.Errorf("CA certificate expires at %s", caCert.NotAfter)
+	}
+
+	cert, err := parseCert(c.Serving.Cert)
+	if err != nil {
+		return false, fmt.Errorf("invalid serving certificate: %w", err)
+	}
+	if _, err = parseKey(c.Serving.Key); err != nil {
+		return false, fmt.Errorf("invalid serving private key: %w", err)
+	}
+	if cert.NotAfter.Before(deadline) {
+		return false, fmt.Errorf("serving certificate expires at %s", cert.NotAfter)
+	}
+	if err = cert.CheckSignatureFrom(caCert); err != nil {
+		return false, fmt.Errorf("serving certificate is not signed by the CA: %w", err)
+	}
+	for _, name := range dnsNames {
+		if err = cert.VerifyHostname(name); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// NotAfter returns the earliest expiration time of the CA and serving certificates
+func (c *Certificates) NotAfter() (time.Time, error) {
+	caCert, err := parseCert(c.CA.Cert)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cert, err := parseCert(c.Serving.Cert)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if caCert.NotAfter.Before(cert.NotAfter) {
+		return caCert.NotAfter, nil
+	}
+	return cert.NotAfter, nil
+}
+
+func parseCert(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to find certificate PEM data")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parseKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to find private key PEM data")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCertificates_Validate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+	dnsNames := []string{"webhook.system.svc"}
+
+	ca, err := GenerateCA("ca", now, time.Hour)
+	g.Expect(err).To(BeNil())
+	serving, err := GenerateServingCert(ca, dnsNames, now, 30*time.Minute)
+	g.Expect(err).To(BeNil())
+	otherCA, err := GenerateCA("other", now, time.Hour)
+	g.Expect(err).To(BeNil())
+
+	var data = []struct {
+		desc      string
+		certs     *Certificates
+		dnsNames  []string
+		deadline  time.Time
+		caExpired bool
+		valid     bool
+	}{
+		{"valid certificates", &Certificates{CA: *ca, Serving: *serving}, dnsNames, now, false, true},
+		{"empty certificates", &Certificates{}, dnsNames, now, true, false},
+		{"expiring CA", &Certificates{CA: *ca, Serving: *serving}, dnsNames, now.Add(2 * time.Hour), true, false},
+		{"expiring serving certificate", &Certificates{CA: *ca, Serving: *serving}, dnsNames, now.Add(45 * time.Minute), false, false},
+		{"serving certificate of another CA", &Certificates{CA: *otherCA, Serving: *serving}, dnsNames, now, false, false},
+		{"missing dns name", &Certificates{CA: *ca, Serving: *serving}, []string{"other.system.svc"}, now, false, false},
+		{"missing serving key", &Certificates{CA: *ca, Serving: KeyPair{Cert: serving.Cert}}, dnsNames, now, false, false},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			caExpired, err := item.certs.Validate(item.dnsNames, item.deadline)
+			g.Expect(caExpired).To(Equal(item.caExpired))
+			if item.valid {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).NotTo(BeNil())
+			}
+		})
+	}
+
+	notAfter, err := (&Certificates{CA: *ca, Serving: *serving}).NotAfter()
+	g.Expect(err).To(BeNil())
+	g.Expect(notAfter).To(BeTemporally("~", now.Add(30*time.Minute), time.Second))
+
+	_, err = GenerateServingCert(ca, nil, now, time.Hour)
+	g.Expect(err).NotTo(BeNil())
+	_, err = GenerateServingCert(&KeyPair{}, dnsNames, now, time.Hour)
+	g.Expect(err).NotTo(BeNil())
+}
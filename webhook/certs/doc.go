@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs manages self signed certificates of webhook servers
+// as an alternative to cert-manager.
+//
+// The Reconciler generates a CA and a serving certificate for the webhook
+// service, stores them in a Secret, writes the serving certificate into the
+// webhook server certificate directory and patches the CA bundle into the
+// configured ValidatingWebhookConfiguration, MutatingWebhookConfiguration and
+// CustomResourceDefinition conversion webhooks. Certificates are rotated
+// before they expire.
+//
+// With several replicas the controller runs in the leader only, every replica
+// syncs its certificate directory from the Secret periodically.
+//
+//	reconciler := certs.NewReconciler(certs.Options{
+//		Secret:  types.NamespacedName{Namespace: "system", Name: "webhook-certs"},
+//		Service: types.NamespacedName{Namespace: "system", Name: "webhook"},
+//		CertDir: mgr.GetWebhookServer().(*webhook.DefaultServer).Options.CertDir,
+//		ValidatingWebhookConfigurations: []string{"validating-webhook-configuration"},
+//	})
+//	err := reconciler.Setup(ctx, mgr, logger)
+package certs
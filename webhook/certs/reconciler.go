@@ -0,0 +1,417 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Options of the certificates managed by the Reconciler
+type Options struct {
+	// Secret where certificates are stored
+	Secret types.NamespacedName
+
+	// Service of the webhook server, its cluster dns names are added to the serving certificate
+	Service types.NamespacedName
+	// DNSNames additional dns names of the serving certificate
+	DNSNames []string
+
+	// CertDir directory of the webhook server where the serving certificate
+	// and key are written as tls.crt and tls.key. Optional
+	CertDir string
+
+	// MutatingWebhookConfigurations names of the configurations to patch the CA bundle of all webhooks
+	MutatingWebhookConfigurations []string
+	// ValidatingWebhookConfigurations names of the configurations to patch the CA bundle of all webhooks
+	ValidatingWebhookConfigurations []string
+	// CustomResourceDefinitions names of the CRDs to patch the CA bundle of the conversion webhook.
+	// apiextensions/v1 needs to be registered in the manager scheme
+	CustomResourceDefinitions []string
+
+	// CAValidity validity of generated CA certificates, defaults to DefaultCAValidity
+	CAValidity time.Duration
+	// CertValidity validity of generated serving certificates, defaults to DefaultCertValidity
+	CertValidity time.Duration
+	// RotationThreshold certificates are rotated when expiring in less than this duration,
+	// defaults to DefaultRotationThreshold
+	RotationThreshold time.Duration
+	// CertDirSyncInterval interval at which every replica, leader or not, writes the serving
+	// certificate of the secret into CertDir, defaults to DefaultCertDirSyncInterval
+	CertDirSyncInterval time.Duration
+}
+
+// DNSNamesOrDefault returns the dns names of the serving certificate
+func (o Options) DNSNamesOrDefault() []string {
+	names := []string{}
+	if o.Service.Name != "" {
+		svc := o.Service.Name + "." + o.Service.Namespace
+		names = append(names, o.Service.Name, svc, svc+".svc", svc+".svc.cluster.local")
+	}
+	for _, name := range o.DNSNames {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Reconciler generates, rotates and distributes the certificates of a webhook server
+type Reconciler struct {
+	Options
+
+	// Client used to manage the secret and patch the CA bundles.
+	// A client without cache avoids caching all secrets of the cluster
+	Client client.Client
+
+	// Clock used to check certificates expiration
+	Clock clock.PassiveClock
+
+	*zap.SugaredLogger
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// NewReconciler returns a Reconciler with defaults for the options
+func NewReconciler(opts Options) *Reconciler {
+	if opts.CAValidity == 0 {
+		opts.CAValidity = DefaultCAValidity
+	}
+	if opts.CertValidity == 0 {
+		opts.CertValidity = DefaultCertValidity
+	}
+	if opts.RotationThreshold == 0 {
+		opts.RotationThreshold = DefaultRotationThreshold
+	}
+	if opts.CertDirSyncInterval == 0 {
+		opts.CertDirSyncInterval = DefaultCertDirSyncInterval
+	}
+	return &Reconciler{
+		Options:       opts,
+		Clock:         clock.RealClock{},
+		SugaredLogger: zap.NewNop().Sugar(),
+	}
+}
+
+// Name of the controller
+func (r *Reconciler) Name() string {
+	return "webhook-certs"
+}
+
+// Setup ensures the certificates, so the webhook server can start with them,
+// and adds the controller into the manager. The controller reconciles
+// when the webhook configurations or CRDs change and before the certificates expire.
+// As the controller only runs in the leader, a runnable syncing CertDir from the secret
+// is added to run in every replica so rotated certificates are served by all of them
+func (r *Reconciler) Setup(ctx context.Context, mgr manager.Manager, logger *zap.SugaredLogger) (err error) {
+	r.SugaredLogger = logger.Named(r.Name())
+	if r.Client == nil {
+		// the manager cache is not started yet, and would cache all secrets
+		if r.Client, err = client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()}); err != nil {
+			return err
+		}
+	}
+	if _, err = r.Ensure(ctx); err != nil {
+		return fmt.Errorf("ensure webhook certificates failed: %w", err)
+	}
+	if r.CertDir != "" {
+		if err = mgr.Add(&certDirSyncer{Reconciler: r}); err != nil {
+			return err
+		}
+	}
+
+	toSecret := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: r.Secret}}
+	})
+	initial := make(chan event.GenericEvent, 1)
+	initial <- event.GenericEvent{Object: &corev1.Secret{}}
+
+	b := ctrl.NewControllerManagedBy(mgr).Named(r.Name()).
+		WatchesRawSource(source.Channel(initial, toSecret))
+	if len(r.MutatingWebhookConfigurations) > 0 {
+		b = b.Watches(&admissionregistrationv1.MutatingWebhookConfiguration{}, toSecret,
+			builder.WithPredicates(namePredicate(r.MutatingWebhookConfigurations)))
+	}
+	if len(r.ValidatingWebhookConfigurations) > 0 {
+		b = b.Watches(&admissionregistrationv1.ValidatingWebhookConfiguration{}, toSecret,
+			builder.WithPredicates(namePredicate(r.ValidatingWebhookConfigurations)))
+	}
+	if len(r.CustomResourceDefinitions) > 0 {
+		b = b.Watches(&apiextensionsv1.CustomResourceDefinition{}, toSecret,
+			builder.WithPredicates(namePredicate(r.CustomResourceDefinitions)))
+	}
+	return b.Complete(r)
+}
+
+func namePredicate(names []string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return slices.Contains(names, obj.GetName())
+	})
+}
+
+// Reconcile ensures the certificates and requeues before they need to be rotated
+func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	requeueAfter, err := r.Ensure(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// Ensure generates or rotates the certificates in the secret, writes them into
+// the certificate directory and patches the CA bundles. Returns the duration until
+// the certificates need to be rotated
+func (r *Reconciler) Ensure(ctx context.Context) (time.Duration, error) {
+	certs, err := r.ensureSecret(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err = r.writeCertDir(certs); err != nil {
+		return 0, err
+	}
+	if err = r.patchCABundles(ctx, certs.CA.Cert); err != nil {
+		return 0, err
+	}
+
+	notAfter, err := certs.NotAfter()
+	if err != nil {
+		return 0, err
+	}
+	requeueAfter := notAfter.Add(-r.RotationThreshold).Sub(r.Clock.Now())
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+	return requeueAfter, nil
+}
+
+// ensureSecret returns the certificates in the secret, generating and storing
+// new ones when missing, invalid or expiring. When another replica creates or
+// updates the secret concurrently the secret is read again
+func (r *Reconciler) ensureSecret(ctx context.Context) (certs *Certificates, err error) {
+	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err)
+	}, func() (err error) {
+		certs, err = r.storeSecret(ctx)
+		return err
+	})
+	return certs, err
+}
+
+func (r *Reconciler) storeSecret(ctx context.Context) (*Certificates, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, r.Secret, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+
+	current := FromSecret(secret)
+	certs, err := r.generate(current)
+	if err != nil {
+		return nil, err
+	}
+	if exists && certs.Equal(current) {
+		return certs, nil
+	}
+
+	secret.Data = certs.SecretData()
+	if !exists {
+		secret.Name, secret.Namespace = r.Secret.Name, r.Secret.Namespace
+		secret.Type = corev1.SecretTypeTLS
+		err = r.Client.Create(ctx, secret)
+	} else {
+		err = r.Client.Update(ctx, secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.Infow("stored webhook certificates", "secret", r.Secret)
+	return certs, nil
+}
+
+// generate returns current when valid, otherwise new certificates
+// reusing the CA when it is still valid
+func (r *Reconciler) generate(current *Certificates) (*Certificates, error) {
+	now := r.Clock.Now()
+	dnsNames := r.DNSNamesOrDefault()
+	caExpired, err := current.Validate(dnsNames, now.Add(r.RotationThreshold))
+	if err == nil {
+		return current, nil
+	}
+	r.Infow("generating webhook certificates", "reason", err.Error(), "ca", caExpired)
+
+	certs := &Certificates{CA: current.CA}
+	if caExpired {
+		ca, err := GenerateCA(r.Secret.Name+"-ca", now, r.CAValidity)
+		if err != nil {
+			return nil, err
+		}
+		certs.CA = *ca
+	}
+	serving, err := GenerateServingCert(&certs.CA, dnsNames, now, r.CertValidity)
+	if err != nil {
+		return nil, err
+	}
+	certs.Serving = *serving
+	return certs, nil
+}
+
+// SyncCertDir writes the serving certificate of the secret into the certificate directory,
+// missing or incomplete secrets are skipped as they are stored by the leader
+func (r *Reconciler) SyncCertDir(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, r.Secret, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	certs := FromSecret(secret)
+	if len(certs.Serving.Cert) == 0 || len(certs.Serving.Key) == 0 {
+		return nil
+	}
+	return r.writeCertDir(certs)
+}
+
+// certDirSyncer runs SyncCertDir periodically in every replica
+type certDirSyncer struct {
+	*Reconciler
+}
+
+var _ manager.LeaderElectionRunnable = &certDirSyncer{}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (s *certDirSyncer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable
+func (s *certDirSyncer) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.SyncCertDir(ctx); err != nil {
+			s.Errorw("sync webhook certificate directory failed", "err", err, "secret", s.Secret)
+		}
+	}, s.CertDirSyncInterval)
+	return nil
+}
+
+// writeCertDir writes the serving certificate and key into the certificate directory
+// when changed. The webhook server watches the files and reloads them
+func (r *Reconciler) writeCertDir(certs *Certificates) error {
+	if r.CertDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(r.CertDir, 0o755); err != nil {
+		return err
+	}
+	for name, data := range map[string][]byte{CertKey: certs.Serving.Cert, KeyKey: certs.Serving.Key} {
+		path := filepath.Join(r.CertDir, name)
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+			continue
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchCABundles sets caBundle in all configured webhooks,
+// missing objects are skipped as they are reconciled once created
+func (r *Reconciler) patchCABundles(ctx context.Context, caBundle []byte) error {
+	errs := []error{}
+	for _, name := range r.MutatingWebhookConfigurations {
+		errs = append(errs, r.patch(ctx, name, &admissionregistrationv1.MutatingWebhookConfiguration{}, func(obj client.Object) bool {
+			webhooks := obj.(*admissionregistrationv1.MutatingWebhookConfiguration).Webhooks
+			changed := false
+			for i := range webhooks {
+				changed = setCABundle(&webhooks[i].ClientConfig.CABundle, caBundle) || changed
+			}
+			return changed
+		}))
+	}
+	for _, name := range r.ValidatingWebhookConfigurations {
+		errs = append(errs, r.patch(ctx, name, &admissionregistrationv1.ValidatingWebhookConfiguration{}, func(obj client.Object) bool {
+			webhooks := obj.(*admissionregistrationv1.ValidatingWebhookConfiguration).Webhooks
+			changed := false
+			for i := range webhooks {
+				changed = setCABundle(&webhooks[i].ClientConfig.CABundle, caBundle) || changed
+			}
+			return changed
+		}))
+	}
+	for _, name := range r.CustomResourceDefinitions {
+		errs = append(errs, r.patch(ctx, name, &apiextensionsv1.CustomResourceDefinition{}, func(obj client.Object) bool {
+			conversion := obj.(*apiextensionsv1.CustomResourceDefinition).Spec.Conversion
+			if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+				return false
+			}
+			return setCABundle(&conversion.Webhook.ClientConfig.CABundle, caBundle)
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// patch gets the object by name and patches it if mutate changes it
+func (r *Reconciler) patch(ctx context.Context, name string, obj client.Object, mutate func(client.Object) bool) error {
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Debugw("skipping CA bundle of missing object", "type", fmt.Sprintf("%T", obj), "name", name)
+			return nil
+		}
+		return err
+	}
+	base := obj.DeepCopyObject().(client.Object)
+	if !mutate(obj) {
+		return nil
+	}
+	if err := r.Client.Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("patch CA bundle of %T %s failed: %w", obj, name, err)
+	}
+	r.Infow("patched CA bundle", "type", fmt.Sprintf("%T", obj), "name", name)
+	return nil
+}
+
+func setCABundle(current *[]byte, caBundle []byte) bool {
+	if bytes.Equal(*current, caBundle) {
+		return false
+	}
+	*current = caBundle
+	return true
+}
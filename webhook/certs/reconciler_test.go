@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestOptions_DNSNamesOrDefault(t *testing.T) {
+	g := NewGomegaWithT(t)
+	opts := Options{
+		Service:  types.NamespacedName{Namespace: "system", Name: "webhook"},
+		DNSNames: []string{"webhook.system.svc", "webhook.example.com"},
+	}
+	g.Expect(opts.DNSNamesOrDefault()).To(Equal([]string{
+		"webhook", "webhook.system", "webhook.system.svc", "webhook.system.svc.cluster.local", "webhook.example.com",
+	}))
+}
+
+func TestReconciler_Ensure(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "validating"},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "a.example.com"}, {Name: "b.example.com"}},
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook:  &apiextensionsv1.WebhookConversion{ClientConfig: &apiextensionsv1.WebhookClientConfig{}},
+			},
+		},
+	}
+	clt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(validating, crd).Build()
+
+	now := time.Now()
+	clock := clocktesting.NewFakePassiveClock(now)
+	certDir := filepath.Join(t.TempDir(), "certs")
+	r := NewReconciler(Options{
+		Secret:                          types.NamespacedName{Namespace: "system", Name: "webhook-certs"},
+		Service:                         types.NamespacedName{Namespace: "system", Name: "webhook"},
+		CertDir:                         certDir,
+		ValidatingWebhookConfigurations: []string{"validating"},
+		MutatingWebhookConfigurations:   []string{"missing"},
+		CustomResourceDefinitions:       []string{"widgets.example.com"},
+	})
+	r.Client, r.Clock = clt, clock
+
+	requeueAfter, err := r.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(requeueAfter).To(BeNumerically("~", DefaultCertValidity-DefaultRotationThreshold, time.Minute))
+
+	secret := &corev1.Secret{}
+	g.Expect(clt.Get(ctx, r.Secret, secret)).To(Succeed())
+	g.Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+	certs := FromSecret(secret)
+	_, err = certs.Validate(r.DNSNamesOrDefault(), now)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(os.ReadFile(filepath.Join(certDir, CertKey))).To(Equal(certs.Serving.Cert))
+	g.Expect(os.ReadFile(filepath.Join(certDir, KeyKey))).To(Equal(certs.Serving.Key))
+
+	g.Expect(clt.Get(ctx, client.ObjectKeyFromObject(validating), validating)).To(Succeed())
+	for _, webhook := range validating.Webhooks {
+		g.Expect(webhook.ClientConfig.CABundle).To(Equal(certs.CA.Cert))
+	}
+	g.Expect(clt.Get(ctx, client.ObjectKeyFromObject(crd), crd)).To(Succeed())
+	g.Expect(crd.Spec.Conversion.Webhook.ClientConfig.CABundle).To(Equal(certs.CA.Cert))
+
+	// valid certificates are kept
+	_, err = r.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(clt.Get(ctx, r.Secret, secret)).To(Succeed())
+	g.Expect(FromSecret(secret).Equal(certs)).To(BeTrue())
+
+	// serving certificate is rotated before expiring, keeping the CA
+	clock.SetTime(now.Add(DefaultCertValidity - DefaultRotationThreshold + time.Hour))
+	_, err = r.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(clt.Get(ctx, r.Secret, secret)).To(Succeed())
+	rotated := FromSecret(secret)
+	g.Expect(rotated.CA).To(Equal(certs.CA))
+	g.Expect(rotated.Serving.Cert).NotTo(Equal(certs.Serving.Cert))
+	g.Expect(os.ReadFile(filepath.Join(certDir, CertKey))).To(Equal(rotated.Serving.Cert))
+
+	// CA is rotated before expiring and patched into the webhooks
+	clock.SetTime(now.Add(DefaultCAValidity - DefaultRotationThreshold + time.Hour))
+	_, err = r.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(clt.Get(ctx, r.Secret, secret)).To(Succeed())
+	g.Expect(FromSecret(secret).CA.Cert).NotTo(Equal(certs.CA.Cert))
+	g.Expect(clt.Get(ctx, client.ObjectKeyFromObject(validating), validating)).To(Succeed())
+	g.Expect(validating.Webhooks[0].ClientConfig.CABundle).To(Equal(FromSecret(secret).CA.Cert))
+}
+
+func TestReconciler_Ensure_concurrentReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	opts := Options{
+		Secret:  types.NamespacedName{Namespace: "system", Name: "webhook-certs"},
+		Service: types.NamespacedName{Namespace: "system", Name: "webhook"},
+		CertDir: filepath.Join(t.TempDir(), "certs"),
+	}
+	other := NewReconciler(opts)
+	created := false
+	clt := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		// another replica creates the secret between the get and the create
+		Create: func(ctx context.Context, clt client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*corev1.Secret); ok && !created {
+				created = true
+				other.Client = clt
+				if _, err := other.Ensure(ctx); err != nil {
+					return err
+				}
+			}
+			return clt.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	r := NewReconciler(opts)
+	r.Client = clt
+	_, err := r.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(created).To(BeTrue())
+
+	secret := &corev1.Secret{}
+	g.Expect(clt.Get(ctx, r.Secret, secret)).To(Succeed())
+	g.Expect(os.ReadFile(filepath.Join(r.CertDir, CertKey))).To(Equal(FromSecret(secret).Serving.Cert))
+}
+
+func TestReconciler_SyncCertDir(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	clt := fake.NewClientBuilder().Build()
+	opts := Options{
+		Secret:  types.NamespacedName{Namespace: "system", Name: "webhook-certs"},
+		Service: types.NamespacedName{Namespace: "system", Name: "webhook"},
+	}
+	follower := NewReconciler(Options{Secret: opts.Secret, CertDir: filepath.Join(t.TempDir(), "certs")})
+	follower.Client = clt
+	g.Expect((&certDirSyncer{Reconciler: follower}).NeedLeaderElection()).To(BeFalse())
+
+	// nothing to write until the leader stores the secret
+	g.Expect(follower.SyncCertDir(ctx)).To(Succeed())
+	_, err := os.Stat(filepath.Join(follower.CertDir, CertKey))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	now := time.Now()
+	clock := clocktesting.NewFakePassiveClock(now)
+	leader := NewReconciler(opts)
+	leader.Client, leader.Clock = clt, clock
+	_, err = leader.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(follower.SyncCertDir(ctx)).To(Succeed())
+	secret := &corev1.Secret{}
+	g.Expect(clt.Get(ctx, opts.Secret, secret)).To(Succeed())
+	g.Expect(os.ReadFile(filepath.Join(follower.CertDir, CertKey))).To(Equal(FromSecret(secret).Serving.Cert))
+
+	// the certificate rotated by the leader is written by the follower
+	clock.SetTime(now.Add(DefaultCertValidity - DefaultRotationThreshold + time.Hour))
+	_, err = leader.Ensure(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(follower.SyncCertDir(ctx)).To(Succeed())
+	g.Expect(clt.Get(ctx, opts.Secret, secret)).To(Succeed())
+	g.Expect(os.ReadFile(filepath.Join(follower.CertDir, KeyKey))).To(Equal(FromSecret(secret).Serving.Key))
+}
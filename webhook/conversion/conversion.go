@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"knative.dev/pkg/logging"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Path of the conversion webhook, the same used by controller-runtime and kubebuilder
+const Path = "/convert"
+
+// Converter converts objects between the versions of their kinds through hub versions
+// and serves conversion reviews of the api server
+type Converter struct {
+	scheme  *runtime.Scheme
+	decoder runtime.Decoder
+	// hubs by group kind
+	hubs map[schema.GroupKind]schema.GroupVersionKind
+	// spokes by group version kind, hubs are not included
+	spokes map[schema.GroupVersionKind]*spoke
+}
+
+type spoke struct {
+	hub       schema.GroupVersionKind
+	newObject func() client.Object
+	newHub    func() client.Object
+	to        func(src, dst client.Object) error
+	from      func(src, dst client.Object) error
+}
+
+var _ http.Handler = &Converter{}
+
+// NewConverter returns a Converter for the types registered in scheme
+func NewConverter(scheme *runtime.Scheme) *Converter {
+	return &Converter{
+		scheme:  scheme,
+		decoder: serializer.NewCodecFactory(scheme).UniversalDeserializer(),
+		hubs:    map[schema.GroupKind]schema.GroupVersionKind{},
+		spokes:  map[schema.GroupVersionKind]*spoke{},
+	}
+}
+
+// RegisterConversion registers the conversion of Spoke to and from its Hub. All the spokes of a kind
+// must use the same hub and both types must be registered in the scheme of the converter
+func RegisterConversion[Spoke, Hub client.Object](c *Converter, to func(src Spoke, dst Hub) error, from func(src Hub, dst Spoke) error) error {
+	spokeGVK, err := apiutil.GVKForObject(newObject[Spoke](), c.scheme)
+	if err != nil {
+		return err
+	}
+	hubGVK, err := apiutil.GVKForObject(newObject[Hub](), c.scheme)
+	if err != nil {
+		return err
+	}
+	if spokeGVK.GroupKind() != hubGVK.GroupKind() {
+		return fmt.Errorf("spoke %s and hub %s are not the same kind", spokeGVK, hubGVK)
+	}
+	if spokeGVK == hubGVK {
+		return fmt.Errorf("spoke %s is the hub", spokeGVK)
+	}
+	if hub, ok := c.hubs[hubGVK.GroupKind()]; ok && hub != hubGVK {
+		return fmt.Errorf("hub of %s is already %s", hubGVK.GroupKind(), hub.Version)
+	}
+	if _, ok := c.spokes[spokeGVK]; ok {
+		return fmt.Errorf("conversion of %s is already registered", spokeGVK)
+	}
+	if _, ok := c.spokes[hubGVK]; ok {
+		return fmt.Errorf("hub %s is already registered as a spoke", hubGVK)
+	}
+	c.hubs[hubGVK.GroupKind()] = hubGVK
+	c.spokes[spokeGVK] = &spoke{
+		hub:       hubGVK,
+		newObject: func() client.Object { return newObject[Spoke]() },
+		newHub:    func() client.Object { return newObject[Hub]() },
+		to: func(src, dst client.Object) error {
+			return to(src.(Spoke), dst.(Hub))
+		},
+		from: func(src, dst client.Object) error {
+			return from(src.(Hub), dst.(Spoke))
+		},
+	}
+	return nil
+}
+
+// newObject returns a new object of the pointer type T
+func newObject[T client.Object]() T {
+	var obj T
+	return reflect.New(reflect.TypeOf(obj).Elem()).Interface().(T)
+}
+
+// Spokes returns the group version kinds of the registered spokes in a stable order
+func (c *Converter) Spokes() []schema.GroupVersionKind {
+	gvks := make([]schema.GroupVersionKind, 0, len(c.spokes))
+	for gvk := range c.spokes {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+	return gvks
+}
+
+// New returns a new object of gvk, which must be a registered hub or spoke
+func (c *Converter) New(gvk schema.GroupVersionKind) (client.Object, error) {
+	if spoke, ok := c.spokes[gvk]; ok {
+		return spoke.newObject(), nil
+	}
+	if hub, ok := c.hubs[gvk.GroupKind()]; ok && hub == gvk {
+		for _, spoke := range c.spokes {
+			if spoke.hub == gvk {
+				return spoke.newHub(), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no conversion registered for %s", gvk)
+}
+
+// Convert converts src into dst, through the hub when both are spokes.
+// The group version kind of dst is set
+func (c *Converter) Convert(src, dst client.Object) error {
+	srcGVK, err := apiutil.GVKForObject(src, c.scheme)
+	if err != nil {
+		return err
+	}
+	dstGVK, err := apiutil.GVKForObject(dst, c.scheme)
+	if err != nil {
+		return err
+	}
+	if srcGVK.GroupKind() != dstGVK.GroupKind() {
+		return fmt.Errorf("cannot convert %s into %s", srcGVK, dstGVK)
+	}
+	if err = c.convert(src, srcGVK, dst, dstGVK); err != nil {
+		return fmt.Errorf("convert %s into %s failed: %w", srcGVK, dstGVK, err)
+	}
+	dst.GetObjectKind().SetGroupVersionKind(dstGVK)
+	return nil
+}
+
+func (c *Converter) convert(src client.Object, srcGVK schema.GroupVersionKind, dst client.Object, dstGVK schema.GroupVersionKind) error {
+	srcSpoke, srcIsSpoke := c.spokes[srcGVK]
+	dstSpoke, dstIsSpoke := c.spokes[dstGVK]
+	switch {
+	case srcGVK == dstGVK:
+		// the api server does not send objects already in the desired version,
+		// a deep copy is enough for other callers
+		reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src.DeepCopyObject()).Elem())
+		return nil
+	case srcIsSpoke && !dstIsSpoke && srcSpoke.hub == dstGVK:
+		return srcSpoke.to(src, dst)
+	case dstIsSpoke && !srcIsSpoke && dstSpoke.hub == srcGVK:
+		return dstSpoke.from(src, dst)
+	case srcIsSpoke && dstIsSpoke:
+		hub := srcSpoke.newHub()
+		if err := srcSpoke.to(src, hub); err != nil {
+			return err
+		}
+		return dstSpoke.from(hub, dst)
+	}
+	return fmt.Errorf("no conversion registered")
+}
+
+// Register registers the converter as the conversion webhook of the manager webhook server
+func (c *Converter) Register(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(Path, c)
+}
+
+// ServeHTTP serves the conversion reviews sent by the api server
+func (c *Converter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context())
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		logger.Errorw("decode conversion review failed", "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		logger.Errorw("conversion review has no request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	objects, err := c.convertRequest(review.Request)
+	if err != nil {
+		logger.Errorw("conversion failed", "uid", review.Request.UID, "desiredAPIVersion", review.Request.DesiredAPIVersion, "err", err)
+		review.Response = &apiextensionsv1.ConversionResponse{
+			Result: metav1.Status{Status: metav1.StatusFailure, Message: err.Error()},
+		}
+	} else {
+		review.Response = &apiextensionsv1.ConversionResponse{
+			ConvertedObjects: objects,
+			Result:           metav1.Status{Status: metav1.StatusSuccess},
+		}
+	}
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(review); err != nil {
+		logger.Errorw("write conversion review failed", "err", err)
+	}
+}
+
+func (c *Converter) convertRequest(req *apiextensionsv1.ConversionRequest) ([]runtime.RawExtension, error) {
+	desired, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]runtime.RawExtension, 0, len(req.Objects))
+	for _, raw := range req.Objects {
+		decoded, srcGVK, err := c.decoder.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		src, ok := decoded.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an object", srcGVK)
+		}
+		dstGVK := desired.WithKind(srcGVK.Kind)
+		dst, err := c.New(dstGVK)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.convert(src, *srcGVK, dst, dstGVK); err != nil {
+			return nil, fmt.Errorf("convert %s %s into %s failed: %w", srcGVK, client.ObjectKeyFromObject(src), dstGVK.Version, err)
+		}
+		dst.GetObjectKind().SetGroupVersionKind(dstGVK)
+		objects = append(objects, runtime.RawExtension{Object: dst})
+	}
+	return objects, nil
+}
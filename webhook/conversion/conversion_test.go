@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	v1alpha1GV = schema.GroupVersion{Group: "example.com", Version: "v1alpha1"}
+	v1beta1GV  = schema.GroupVersion{Group: "example.com", Version: "v1beta1"}
+	v1GV       = schema.GroupVersion{Group: "example.com", Version: "v1"}
+)
+
+// widgetV1alpha1 stores the size as a string
+type widgetV1alpha1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Size              string `json:"size,omitempty"`
+}
+
+func (w *widgetV1alpha1) DeepCopyObject() runtime.Object {
+	out := *w
+	w.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+// widgetV1beta1 names the size replicas
+type widgetV1beta1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Replicas          int32 `json:"replicas,omitempty"`
+}
+
+func (w *widgetV1beta1) DeepCopyObject() runtime.Object {
+	out := *w
+	w.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+// widgetV1 is the hub
+type widgetV1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Replicas          int32 `json:"replicas,omitempty"`
+}
+
+func (w *widgetV1) DeepCopyObject() runtime.Object {
+	out := *w
+	w.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+func newTestConverter(g *WithT) *Converter {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(v1alpha1GV.WithKind("Widget"), &widgetV1alpha1{})
+	scheme.AddKnownTypeWithName(v1beta1GV.WithKind("Widget"), &widgetV1beta1{})
+	scheme.AddKnownTypeWithName(v1GV.WithKind("Widget"), &widgetV1{})
+
+	c := NewConverter(scheme)
+	g.Expect(RegisterConversion(c, func(src *widgetV1alpha1, dst *widgetV1) error {
+		dst.ObjectMeta = src.ObjectMeta
+		if src.Size != "" {
+			size, err := strconv.Atoi(src.Size)
+			if err != nil {
+				return err
+			}
+			dst.Replicas = int32(size)
+		}
+		return nil
+	}, func(src *widgetV1, dst *widgetV1alpha1) error {
+		dst.ObjectMeta = src.ObjectMeta
+		if src.Replicas != 0 {
+			dst.Size = strconv.Itoa(int(src.Replicas))
+		}
+		return nil
+	})).To(Succeed())
+	g.Expect(RegisterConversion(c, func(src *widgetV1beta1, dst *widgetV1) error {
+		dst.ObjectMeta = src.ObjectMeta
+		dst.Replicas = src.Replicas
+		return nil
+	}, func(src *widgetV1, dst *widgetV1beta1) error {
+		dst.ObjectMeta = src.ObjectMeta
+		dst.Replicas = src.Replicas
+		return nil
+	})).To(Succeed())
+	return c
+}
+
+func TestRegisterConversion(t *testing.T) {
+	g := NewGomegaWithT(t)
+	c := newTestConverter(g)
+	g.Expect(c.Spokes()).To(Equal([]schema.GroupVersionKind{v1alpha1GV.WithKind("Widget"), v1beta1GV.WithKind("Widget")}))
+
+	noop := func(*widgetV1beta1, *widgetV1) error { return nil }
+	noopFrom := func(*widgetV1, *widgetV1beta1) error { return nil }
+	g.Expect(RegisterConversion(c, noop, noopFrom)).To(MatchError(ContainSubstring("already registered")))
+
+	// another hub for the same kind
+	err := RegisterConversion(c, func(*widgetV1, *widgetV1beta1) error { return nil }, func(*widgetV1beta1, *widgetV1) error { return nil })
+	g.Expect(err).To(MatchError("hub of Widget.example.com is already v1"))
+}
+
+func TestConverter_Convert(t *testing.T) {
+	g := NewGomegaWithT(t)
+	c := newTestConverter(g)
+
+	alpha := &widgetV1alpha1{ObjectMeta: metav1.ObjectMeta{Name: "widget"}, Size: "3"}
+	beta := &widgetV1beta1{}
+	g.Expect(c.Convert(alpha, beta)).To(Succeed())
+	g.Expect(beta.Replicas).To(BeEquivalentTo(3))
+	g.Expect(beta.Name).To(Equal("widget"))
+	g.Expect(beta.GroupVersionKind()).To(Equal(v1beta1GV.WithKind("Widget")))
+
+	hub := &widgetV1{}
+	g.Expect(c.Convert(beta, hub)).To(Succeed())
+	g.Expect(hub.Replicas).To(BeEquivalentTo(3))
+
+	alpha = &widgetV1alpha1{}
+	g.Expect(c.Convert(hub, alpha)).To(Succeed())
+	g.Expect(alpha.Size).To(Equal("3"))
+
+	err := c.Convert(&widgetV1alpha1{Size: "three"}, &widgetV1{})
+	g.Expect(err).To(MatchError(ContainSubstring("convert example.com/v1alpha1, Kind=Widget into example.com/v1, Kind=Widget failed")))
+}
+
+func TestConverter_ServeHTTP(t *testing.T) {
+	g := NewGomegaWithT(t)
+	c := newTestConverter(g)
+
+	review := func(desired string, objects ...string) *apiextensionsv1.ConversionReview {
+		req := &apiextensionsv1.ConversionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+			Request:  &apiextensionsv1.ConversionRequest{UID: "123", DesiredAPIVersion: desired},
+		}
+		for _, obj := range objects {
+			req.Request.Objects = append(req.Request.Objects, runtime.RawExtension{Raw: []byte(obj)})
+		}
+		body, _ := json.Marshal(req)
+		recorder := httptest.NewRecorder()
+		c.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+		g.Expect(recorder.Code).To(Equal(http.StatusOK))
+		resp := &apiextensionsv1.ConversionReview{}
+		g.Expect(json.Unmarshal(recorder.Body.Bytes(), resp)).To(Succeed())
+		return resp
+	}
+
+	resp := review("example.com/v1beta1",
+		`{"apiVersion":"example.com/v1alpha1","kind":"Widget","metadata":{"name":"a"},"size":"2"}`,
+		`{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"b"},"replicas":5}`,
+	)
+	g.Expect(resp.Response.UID).To(BeEquivalentTo("123"))
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+	g.Expect(resp.Response.ConvertedObjects).To(HaveLen(2))
+	g.Expect(string(resp.Response.ConvertedObjects[0].Raw)).To(MatchJSON(`{"apiVersion":"example.com/v1beta1","kind":"Widget","metadata":{"name":"a","creationTimestamp":null},"replicas":2}`))
+	g.Expect(string(resp.Response.ConvertedObjects[1].Raw)).To(MatchJSON(`{"apiVersion":"example.com/v1beta1","kind":"Widget","metadata":{"name":"b","creationTimestamp":null},"replicas":5}`))
+
+	resp = review("example.com/v1", `{"apiVersion":"example.com/v1alpha1","kind":"Widget","metadata":{"name":"a"},"size":"two"}`)
+	g.Expect(resp.Response.UID).To(BeEquivalentTo("123"))
+	g.Expect(resp.Response.Result.Status).To(Equal(metav1.StatusFailure))
+	g.Expect(resp.Response.Result.Message).To(ContainSubstring("convert example.com/v1alpha1, Kind=Widget /a into v1 failed"))
+
+	recorder := httptest.NewRecorder()
+	c.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader([]byte("{}"))))
+	g.Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestFuzzRoundTrip(t *testing.T) {
+	g := NewGomegaWithT(t)
+	c := newTestConverter(g)
+
+	// only numeric sizes survive the conversion
+	FuzzRoundTrip(t, c, WithHubRoundTrip(), WithFuzzFuncs(func(w *widgetV1alpha1, f fuzz.Continue) {
+		f.FuzzNoCustom(w)
+		w.Size = ""
+		if replicas := f.Int31(); replicas != 0 {
+			w.Size = strconv.Itoa(int(replicas))
+		}
+	}))
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion has generics based hub and spoke conversions of multi-version
+// custom resources and the conversion webhook serving them.
+//
+// Every version of a kind converts to and from a single hub version, so converting between
+// two spokes goes through the hub. Unlike controller-runtime the api types do not need to
+// implement conversion.Hub and conversion.Convertible:
+//
+//	converter := conversion.NewConverter(mgr.GetScheme())
+//	err := conversion.RegisterConversion(converter, v1beta1.ConvertWidgetToV1, v1beta1.ConvertWidgetFromV1)
+//	converter.Register(mgr)
+//
+// FuzzRoundTrip checks in unit tests that every registered spoke survives a round trip
+// through its hub:
+//
+//	func TestConversion(t *testing.T) {
+//		conversion.FuzzRoundTrip(t, converter)
+//	}
+package conversion
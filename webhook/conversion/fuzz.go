@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/dump"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultFuzzIterations is the number of objects fuzzed per spoke by FuzzRoundTrip
+const DefaultFuzzIterations = 100
+
+// FuzzOptions options of FuzzRoundTrip
+type FuzzOptions struct {
+	// Iterations is the number of objects fuzzed per spoke, defaults to DefaultFuzzIterations
+	Iterations int
+	// Seed of the fuzzer, zero uses a random seed which is logged on failures
+	Seed int64
+	// Funcs are custom fuzz functions, see fuzz.Fuzzer.Funcs
+	Funcs []interface{}
+	// Hub also checks that hubs survive a round trip through each spoke,
+	// which requires spokes to keep the fields they lack, e.g. in annotations
+	Hub bool
+}
+
+// FuzzOption configures FuzzOptions
+type FuzzOption func(*FuzzOptions)
+
+// WithIterations sets the number of objects fuzzed per spoke
+func WithIterations(n int) FuzzOption {
+	return func(opts *FuzzOptions) {
+		opts.Iterations = n
+	}
+}
+
+// WithSeed sets the seed of the fuzzer to reproduce a failure
+func WithSeed(seed int64) FuzzOption {
+	return func(opts *FuzzOptions) {
+		opts.Seed = seed
+	}
+}
+
+// WithFuzzFuncs adds custom fuzz functions, e.g. to only generate valid enum values
+func WithFuzzFuncs(funcs ...interface{}) FuzzOption {
+	return func(opts *FuzzOptions) {
+		opts.Funcs = append(opts.Funcs, funcs...)
+	}
+}
+
+// WithHubRoundTrip also checks that hubs survive a round trip through each spoke
+func WithHubRoundTrip() FuzzOption {
+	return func(opts *FuzzOptions) {
+		opts.Hub = true
+	}
+}
+
+// FuzzRoundTrip checks that random objects of every spoke registered in c are unchanged
+// after being converted to their hub and back, one subtest per spoke
+func FuzzRoundTrip(t *testing.T, c *Converter, opts ...FuzzOption) {
+	t.Helper()
+	options := FuzzOptions{Iterations: DefaultFuzzIterations}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if len(c.spokes) == 0 {
+		t.Fatal("no conversion registered")
+	}
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	fuzzer := fuzz.New().NilChance(0.2).NumElements(0, 3).RandSource(rand.NewSource(seed)).Funcs(append([]interface{}{
+		// type meta is set by the conversion
+		func(*metav1.TypeMeta, fuzz.Continue) {},
+	}, options.Funcs...)...)
+
+	for _, gvk := range c.Spokes() {
+		spoke := c.spokes[gvk]
+		t.Run(gvk.String(), func(t *testing.T) {
+			for i := 0; i < options.Iterations; i++ {
+				roundTrip(t, c, fuzzer, spoke.newObject, spoke.newHub)
+				if options.Hub {
+					roundTrip(t, c, fuzzer, spoke.newHub, spoke.newObject)
+				}
+				if t.Failed() {
+					t.Logf("reproduce with conversion.WithSeed(%d)", seed)
+					return
+				}
+			}
+		})
+	}
+}
+
+// roundTrip converts a random object created by newObject into an object created by newOther and back
+func roundTrip(t *testing.T, c *Converter, fuzzer *fuzz.Fuzzer, newObject, newOther func() client.Object) {
+	t.Helper()
+	original := newObject()
+	fuzzer.Fuzz(original)
+	original.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	src := original.DeepCopyObject().(client.Object)
+
+	other, result := newOther(), newObject()
+	if err := c.Convert(src, other); err != nil {
+		t.Errorf("convert failed: %v\n%s", err, dump.Pretty(original))
+		return
+	}
+	if err := c.Convert(other, result); err != nil {
+		t.Errorf("convert back failed: %v\n%s", err, dump.Pretty(original))
+		return
+	}
+	if !equality.Semantic.DeepEqual(src, original) {
+		t.Errorf("conversion changed its source:\n%s", diff(original, src))
+		return
+	}
+	result.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	if !equality.Semantic.DeepEqual(original, result) {
+		t.Errorf("round trip through %T changed the object:\n%s", other, diff(original, result))
+	}
+}
+
+func diff(want, got interface{}) string {
+	return cmp.Diff(want, got, cmp.Exporter(func(reflect.Type) bool { return true }))
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook has generics based admission webhooks for typed objects.
+//
+// Defaulter[T] and Validator[T] receive decoded objects of type T, including the
+// old object on updates, and validation errors built with field.ErrorList are
+// denied with a structured Invalid status keeping the field paths. Panics in
+// handlers are recovered into internal server error responses. The RequestLogging
+// middleware logs each request with its latency and result and observes the
+// latency in the metrics package.
+//
+//	webhook.RegisterValidator(ctx, mgr, func() *v1alpha1.Widget { return &v1alpha1.Widget{} }, &widgetValidator{})
+package webhook
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/metrics"
+	"github.com/AlaudaDevops/pkg/tracing"
+)
+
+const (
+	// RequestResultAllowed result of admission requests allowed
+	RequestResultAllowed = "allowed"
+	// RequestResultDenied result of admission requests denied
+	RequestResultDenied = "denied"
+	// RequestResultError result of admission requests failing with an internal error
+	RequestResultError = "error"
+
+	// DefaultSlowRequestThreshold latency above which admission requests are logged as slow
+	DefaultSlowRequestThreshold = time.Second
+	// DefaultMaxLoggedBodySize maximum number of bytes of the objects logged when bodies are captured
+	DefaultMaxLoggedBodySize = 4096
+)
+
+// RequestLogOptions options of the RequestLogging middleware,
+// use DefaultRequestLogOptions to get the defaults and AddFlags to set them from flags
+type RequestLogOptions struct {
+	// Level of the log of each admission request, denied requests are logged at least at info level
+	Level zapcore.Level
+	// SlowThreshold latency above which admission requests are logged at warn level,
+	// zero disables slow request logs
+	SlowThreshold time.Duration
+	// CaptureBody logs the objects of admission requests, which may contain sensitive data,
+	// truncated to MaxBodySize bytes. It should only be enabled to debug
+	CaptureBody bool
+	// MaxBodySize maximum number of bytes of each logged object
+	MaxBodySize int
+	// Logger used to log requests, defaults to the logger in the context of requests
+	Logger *zap.SugaredLogger
+	// Clock used to measure latency, defaults to the real clock
+	Clock clock.PassiveClock
+}
+
+// DefaultRequestLogOptions returns the default options logging requests at debug level
+func DefaultRequestLogOptions() RequestLogOptions {
+	return RequestLogOptions{
+		Level:         zapcore.DebugLevel,
+		SlowThreshold: DefaultSlowRequestThreshold,
+		MaxBodySize:   DefaultMaxLoggedBodySize,
+	}
+}
+
+// AddFlags adds flags for the options to fs, using the current values as defaults
+func (o *RequestLogOptions) AddFlags(fs *flag.FlagSet) {
+	fs.Var(&o.Level, "webhook-log-level", "Level of the logs of admission requests.")
+	fs.DurationVar(&o.SlowThreshold, "webhook-slow-request-threshold", o.SlowThreshold,
+		"Latency above which admission requests are logged as slow, 0 disables slow request logs.")
+	fs.BoolVar(&o.CaptureBody, "webhook-debug-body", o.CaptureBody,
+		"Log the objects of admission requests, which may contain sensitive data.")
+}
+
+// RequestLogging returns a Middleware logging each admission request with its kind, operation,
+// user, latency and result, including the reason of denied requests, and observing its latency
+// in metrics.WebhookRequestDuration. Logs have the trace and span ids of the request if any
+//
+//	webhook.RegisterValidator(ctx, mgr, newWidget, validator, webhook.RequestLogging(webhook.DefaultRequestLogOptions()))
+func RequestLogging(opts RequestLogOptions) Middleware {
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	return func(next admission.Handler) admission.Handler {
+		return admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+			start := opts.Clock.Now()
+			resp := next.Handle(ctx, req)
+			latency := opts.Clock.Since(start)
+
+			result := requestResult(resp)
+			metrics.ObserveWebhookRequest(req.Kind.Kind, string(req.Operation), result, latency)
+
+			level := opts.Level
+			message := "admission request handled"
+			if result != RequestResultAllowed && level < zapcore.InfoLevel {
+				level = zapcore.InfoLevel
+			}
+			if opts.SlowThreshold > 0 && latency > opts.SlowThreshold {
+				level = zapcore.WarnLevel
+				message = "slow admission request"
+			}
+			if result == RequestResultError {
+				level = zapcore.ErrorLevel
+			}
+
+			logger := opts.Logger
+			if logger == nil {
+				logger = logging.FromContext(ctx)
+			}
+			logger = tracing.LoggerWithSpan(ctx, logger)
+			if !logger.Desugar().Core().Enabled(level) {
+				return resp
+			}
+			logger.Logw(level, message, requestFields(req, resp, result, latency, opts)...)
+			return resp
+		})
+	}
+}
+
+func requestResult(resp admission.Response) string {
+	switch {
+	case resp.Allowed:
+		return RequestResultAllowed
+	case resp.Result != nil && resp.Result.Code >= http.StatusInternalServerError:
+		return RequestResultError
+	default:
+		return RequestResultDenied
+	}
+}
+
+func requestFields(req admission.Request, resp admission.Response, result string, latency time.Duration, opts RequestLogOptions) []interface{} {
+	fields := []interface{}{
+		"uid", string(req.UID),
+		"kind", req.Kind.String(),
+		"resource", req.Resource.Resource,
+		"operation", string(req.Operation),
+		"namespace", req.Namespace,
+		"name", req.Name,
+		"user", req.UserInfo.Username,
+		"latency", latency,
+		"result", result,
+	}
+	if req.SubResource != "" {
+		fields = append(fields, "subResource", req.SubResource)
+	}
+	if req.DryRun != nil && *req.DryRun {
+		fields = append(fields, "dryRun", true)
+	}
+	if len(resp.Patches) > 0 {
+		fields = append(fields, "patches", len(resp.Patches))
+	}
+	if len(resp.Warnings) > 0 {
+		fields = append(fields, "warnings", resp.Warnings)
+	}
+	if !resp.Allowed && resp.Result != nil {
+		fields = append(fields, "code", resp.Result.Code, "reason", string(resp.Result.Reason), "message", resp.Result.Message)
+		if resp.Result.Details != nil {
+			causes := make([]string, 0, len(resp.Result.Details.Causes))
+			for _, cause := range resp.Result.Details.Causes {
+				causes = append(causes, cause.Field+": "+cause.Message)
+			}
+			fields = append(fields, "causes", strings.Join(causes, "; "))
+		}
+	}
+	if opts.CaptureBody {
+		fields = append(fields, "object", truncate(req.Object.Raw, opts.MaxBodySize))
+		if len(req.OldObject.Raw) > 0 {
+			fields = append(fields, "oldObject", truncate(req.OldObject.Raw, opts.MaxBodySize))
+		}
+	}
+	return fields
+}
+
+// truncate returns the first size bytes of raw as a string, size lower or equal to zero keeps all of them
+func truncate(raw []byte, size int) string {
+	if size <= 0 || len(raw) <= size {
+		return string(raw)
+	}
+	return string(raw[:size]) + "...(truncated)"
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/AlaudaDevops/pkg/clock"
+	"github.com/AlaudaDevops/pkg/metrics"
+)
+
+func TestRequestLogging(t *testing.T) {
+	g := NewGomegaWithT(t)
+	metrics.WebhookRequestDuration.Reset()
+	ctx := context.Background()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	opts := DefaultRequestLogOptions()
+	opts.Logger = zap.New(core).Sugar()
+	opts.CaptureBody = true
+	opts.MaxBodySize = 10
+	wh := ValidatingWebhookFor(ctx, clientgoscheme.Scheme, newConfigMap, &configMapValidator{}, RequestLogging(opts))
+
+	req := configMapRequest(admissionv1.Create, &corev1.ConfigMap{Data: map[string]string{"key": "value"}}, nil)
+	req.UserInfo.Username = "admin"
+	g.Expect(wh.Handle(ctx, req).Allowed).To(BeTrue())
+
+	g.Expect(logs.Len()).To(Equal(1))
+	entry := logs.All()[0]
+	g.Expect(entry.Level).To(Equal(zapcore.DebugLevel))
+	g.Expect(entry.Message).To(Equal("admission request handled"))
+	fields := entry.ContextMap()
+	g.Expect(fields).To(HaveKeyWithValue("kind", "/v1, Kind=ConfigMap"))
+	g.Expect(fields).To(HaveKeyWithValue("operation", "CREATE"))
+	g.Expect(fields).To(HaveKeyWithValue("user", "admin"))
+	g.Expect(fields).To(HaveKeyWithValue("result", RequestResultAllowed))
+	g.Expect(fields).To(HaveKeyWithValue("warnings", ConsistOf("deprecated")))
+	g.Expect(fields).To(HaveKeyWithValue("object", HaveSuffix("...(truncated)")))
+
+	resp := wh.Handle(ctx, configMapRequest(admissionv1.Create, &corev1.ConfigMap{}, nil))
+	g.Expect(resp.Allowed).To(BeFalse())
+	entry = logs.All()[1]
+	g.Expect(entry.Level).To(Equal(zapcore.InfoLevel))
+	g.Expect(entry.ContextMap()).To(HaveKeyWithValue("result", RequestResultDenied))
+	g.Expect(entry.ContextMap()).To(HaveKeyWithValue("reason", "Invalid"))
+	g.Expect(entry.ContextMap()).To(HaveKeyWithValue("causes", "data.key: Required value: key is required"))
+
+	g.Expect(testutil.CollectAndCount(metrics.WebhookRequestDuration)).To(Equal(2))
+}
+
+func TestRequestLogging_slow(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fake := clock.NewFakeClock(time.Now())
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	opts := DefaultRequestLogOptions()
+	opts.Logger = zap.New(core).Sugar()
+	opts.Clock = fake
+	latency := time.Millisecond
+	handler := Chain(admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		fake.Advance(latency)
+		if req.Name == "error" {
+			return admission.Errored(http.StatusInternalServerError, errors.New("boom"))
+		}
+		return admission.Allowed("")
+	}), RequestLogging(opts))
+
+	// allowed requests are logged at debug level, disabled here
+	handler.Handle(context.Background(), admission.Request{})
+	g.Expect(logs.Len()).To(Equal(0))
+
+	latency = 2 * time.Second
+	handler.Handle(context.Background(), admission.Request{})
+	g.Expect(logs.Len()).To(Equal(1))
+	g.Expect(logs.All()[0].Message).To(Equal("slow admission request"))
+	g.Expect(logs.All()[0].Level).To(Equal(zapcore.WarnLevel))
+	g.Expect(logs.All()[0].ContextMap()).NotTo(HaveKey("object"))
+
+	req := admission.Request{}
+	req.Name = "error"
+	resp := handler.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(logs.All()[1].Level).To(Equal(zapcore.ErrorLevel))
+	g.Expect(logs.All()[1].ContextMap()).To(HaveKeyWithValue("result", RequestResultError))
+}
+
+func TestRequestLogOptions_AddFlags(t *testing.T) {
+	g := NewGomegaWithT(t)
+	opts := DefaultRequestLogOptions()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.AddFlags(fs)
+
+	g.Expect(fs.Parse([]string{"--webhook-log-level=info", "--webhook-slow-request-threshold=500ms", "--webhook-debug-body"})).To(Succeed())
+	g.Expect(opts.Level).To(Equal(zapcore.InfoLevel))
+	g.Expect(opts.SlowThreshold).To(Equal(500 * time.Millisecond))
+	g.Expect(opts.CaptureBody).To(BeTrue())
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Middleware wraps an admission handler
+type Middleware func(admission.Handler) admission.Handler
+
+// Recovery returns a Middleware recovering panics of the handler
+// into internal server error responses, logging the stack trace
+func Recovery() Middleware {
+	return func(next admission.Handler) admission.Handler {
+		return admission.HandlerFunc(func(ctx context.Context, req admission.Request) (resp admission.Response) {
+			defer func() {
+				if r := recover(); r != nil {
+					logging.FromContext(ctx).Errorw("recovered panic handling admission request",
+						"panic", r, "uid", req.UID, "kind", req.Kind, "name", req.Name, "namespace", req.Namespace,
+						"stack", string(debug.Stack()))
+					resp = admission.Errored(http.StatusInternalServerError, fmt.Errorf("panic handling admission request: %v", r))
+				}
+			}()
+			return next.Handle(ctx, req)
+		})
+	}
+}
+
+// Chain wraps handler with the middlewares, the first middleware is the outermost
+func Chain(handler admission.Handler, middlewares ...Middleware) admission.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
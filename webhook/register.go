@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// MutatePath returns the path of the mutating webhook of gvk,
+// the same used by controller-runtime and kubebuilder markers
+func MutatePath(gvk schema.GroupVersionKind) string {
+	return "/mutate-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" +
+		gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+// ValidatePath returns the path of the validating webhook of gvk,
+// the same used by controller-runtime and kubebuilder markers
+func ValidatePath(gvk schema.GroupVersionKind) string {
+	return "/validate-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" +
+		gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+// RegisterDefaulter registers the mutating webhook of the defaulter into the manager webhook server
+func RegisterDefaulter[T client.Object](ctx context.Context, mgr ctrl.Manager, newObject func() T, defaulter Defaulter[T], middlewares ...Middleware) error {
+	gvk, err := apiutil.GVKForObject(newObject(), mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(MutatePath(gvk), DefaultingWebhookFor(ctx, mgr.GetScheme(), newObject, defaulter, middlewares...))
+	return nil
+}
+
+// RegisterValidator registers the validating webhook of the validator into the manager webhook server
+func RegisterValidator[T client.Object](ctx context.Context, mgr ctrl.Manager, newObject func() T, validator Validator[T], middlewares ...Middleware) error {
+	gvk, err := apiutil.GVKForObject(newObject(), mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(ValidatePath(gvk), ValidatingWebhookFor(ctx, mgr.GetScheme(), newObject, validator, middlewares...))
+	return nil
+}
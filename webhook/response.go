@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Warnings are returned to the client together with the admission response
+type Warnings = admission.Warnings
+
+// Deny returns a response denying the request with err:
+//   - *field.Error and aggregates of them, e.g. field.ErrorList.ToAggregate(),
+//     are converted into an Invalid status with one cause per field path
+//   - errors with an api status, e.g. apierrors.NewForbidden, keep their status
+//   - other errors are denied with a Forbidden status and the error message
+func Deny(gk schema.GroupKind, name string, err error) admission.Response {
+	if fieldErrors := toFieldErrors(err); len(fieldErrors) > 0 {
+		return responseFromStatus(apierrors.NewInvalid(gk, name, fieldErrors).Status())
+	}
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		return responseFromStatus(apiStatus.Status())
+	}
+	return admission.Denied(err.Error())
+}
+
+// toFieldErrors returns the field errors of err,
+// or nil when err contains any other type of error
+func toFieldErrors(err error) field.ErrorList {
+	var fieldError *field.Error
+	if errors.As(err, &fieldError) {
+		return field.ErrorList{fieldError}
+	}
+	var aggregate utilerrors.Aggregate
+	if !errors.As(err, &aggregate) {
+		return nil
+	}
+	fieldErrors := make(field.ErrorList, 0, len(aggregate.Errors()))
+	for _, item := range aggregate.Errors() {
+		if !errors.As(item, &fieldError) {
+			return nil
+		}
+		fieldErrors = append(fieldErrors, fieldError)
+	}
+	return fieldErrors
+}
+
+// responseFromStatus returns a response denying the request with the status
+func responseFromStatus(status metav1.Status) admission.Response {
+	return admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
+}
+
+// erroredResponse returns a response for errors decoding the request
+func erroredResponse(err error) admission.Response {
+	return admission.Errored(http.StatusBadRequest, err)
+}
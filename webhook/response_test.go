@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestDeny(t *testing.T) {
+	gk := schema.GroupKind{Kind: "ConfigMap"}
+	var data = []struct {
+		desc   string
+		err    error
+		code   int32
+		reason metav1.StatusReason
+		fields []string
+	}{
+		{
+			desc:   "field error list",
+			err:    field.ErrorList{field.Required(field.NewPath("spec", "a"), ""), field.Invalid(field.NewPath("spec", "b"), 1, "")}.ToAggregate(),
+			code:   http.StatusUnprocessableEntity,
+			reason: metav1.StatusReasonInvalid,
+			fields: []string{"spec.a", "spec.b"},
+		},
+		{
+			desc:   "wrapped field error",
+			err:    fmt.Errorf("wrapped: %w", field.Forbidden(field.NewPath("spec", "c"), "")),
+			code:   http.StatusUnprocessableEntity,
+			reason: metav1.StatusReasonInvalid,
+			fields: []string{"spec.c"},
+		},
+		{
+			desc:   "api status error",
+			err:    apierrors.NewConflict(corev1.Resource("configmaps"), "cm", errors.New("conflict")),
+			code:   http.StatusConflict,
+			reason: metav1.StatusReasonConflict,
+		},
+		{
+			desc:   "generic error",
+			err:    errors.New("denied"),
+			code:   http.StatusForbidden,
+			reason: metav1.StatusReasonForbidden,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.desc, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			resp := Deny(gk, "cm", item.err)
+			g.Expect(resp.Allowed).To(BeFalse())
+			g.Expect(resp.Result.Code).To(Equal(item.code))
+			g.Expect(resp.Result.Reason).To(Equal(item.reason))
+			if len(item.fields) > 0 {
+				fields := []string{}
+				for _, cause := range resp.Result.Details.Causes {
+					fields = append(fields, cause.Field)
+				}
+				g.Expect(fields).To(Equal(item.fields))
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	kadmission "github.com/AlaudaDevops/pkg/webhook/admission"
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Defaulter sets defaults of objects of type T
+type Defaulter[T client.Object] interface {
+	// Default sets the defaults of obj, on updates the old object is available using OldObject
+	Default(ctx context.Context, obj T) error
+}
+
+// DefaulterFunc implements Defaulter using a function
+type DefaulterFunc[T client.Object] func(ctx context.Context, obj T) error
+
+// Default calls the function
+func (f DefaulterFunc[T]) Default(ctx context.Context, obj T) error {
+	return f(ctx, obj)
+}
+
+// Validator validates operations on objects of type T
+type Validator[T client.Object] interface {
+	ValidateCreate(ctx context.Context, obj T) (Warnings, error)
+	ValidateUpdate(ctx context.Context, oldObj, newObj T) (Warnings, error)
+	ValidateDelete(ctx context.Context, obj T) (Warnings, error)
+}
+
+// OldObject returns the old object of an update request from the context
+func OldObject[T client.Object](ctx context.Context) (obj T, ok bool) {
+	obj, ok = apis.GetBaseline(ctx).(T)
+	return
+}
+
+// DefaultingWebhookFor returns a mutating webhook for objects of type T,
+// newObject returns an empty object used to decode requests
+func DefaultingWebhookFor[T client.Object](ctx context.Context, scheme *runtime.Scheme, newObject func() T, defaulter Defaulter[T], middlewares ...Middleware) *admission.Webhook {
+	handler := &defaultingHandler[T]{
+		decoder:       admission.NewDecoder(scheme),
+		newObject:     newObject,
+		defaulter:     defaulter,
+		SugaredLogger: logging.FromContext(ctx),
+	}
+	return &admission.Webhook{Handler: Chain(handler, append([]Middleware{Recovery()}, middlewares...)...)}
+}
+
+// ValidatingWebhookFor returns a validating webhook for objects of type T,
+// newObject returns an empty object used to decode requests
+func ValidatingWebhookFor[T client.Object](ctx context.Context, scheme *runtime.Scheme, newObject func() T, validator Validator[T], middlewares ...Middleware) *admission.Webhook {
+	handler := &validatingHandler[T]{
+		decoder:       admission.NewDecoder(scheme),
+		newObject:     newObject,
+		validator:     validator,
+		SugaredLogger: logging.FromContext(ctx),
+	}
+	return &admission.Webhook{Handler: Chain(handler, append([]Middleware{Recovery()}, middlewares...)...)}
+}
+
+// withRequest adds the logger, the admission request and the knative
+// operation helpers, e.g. apis.IsInCreate, into the context
+func withRequest(ctx context.Context, log *zap.SugaredLogger, req admission.Request, old runtime.Object) context.Context {
+	ctx = logging.WithLogger(ctx, log.With("uid", req.UID, "name", req.Name, "namespace", req.Namespace, "operation", req.Operation))
+	ctx = kadmission.WithAdmissionRequest(ctx, req)
+	switch req.Operation {
+	case admissionv1.Create:
+		ctx = apis.WithinCreate(ctx)
+	case admissionv1.Update:
+		ctx = apis.WithinUpdate(ctx, old)
+	case admissionv1.Delete:
+		ctx = apis.WithinDelete(ctx)
+	}
+	return ctx
+}
+
+func groupKind(req admission.Request) schema.GroupKind {
+	return schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}
+}
+
+type defaultingHandler[T client.Object] struct {
+	decoder   admission.Decoder
+	newObject func() T
+	defaulter Defaulter[T]
+
+	*zap.SugaredLogger
+}
+
+// Handle decodes the object, and the old object on updates, and patches the defaults
+func (h *defaultingHandler[T]) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	obj := h.newObject()
+	if err := h.decoder.DecodeRaw(req.Object, obj); err != nil {
+		return erroredResponse(err)
+	}
+	var old runtime.Object
+	if req.Operation == admissionv1.Update {
+		oldObj := h.newObject()
+		if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return erroredResponse(err)
+		}
+		old = oldObj
+	}
+	ctx = withRequest(ctx, h.SugaredLogger, req, old)
+
+	if err := h.defaulter.Default(ctx, obj); err != nil {
+		return Deny(groupKind(req), req.Name, err)
+	}
+	marshalled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
+
+type validatingHandler[T client.Object] struct {
+	decoder   admission.Decoder
+	newObject func() T
+	validator Validator[T]
+
+	*zap.SugaredLogger
+}
+
+// Handle decodes the objects of the operation and validates them
+func (h *validatingHandler[T]) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var (
+		warnings Warnings
+		err      error
+	)
+	switch req.Operation {
+	case admissionv1.Create:
+		obj := h.newObject()
+		if err := h.decoder.DecodeRaw(req.Object, obj); err != nil {
+			return erroredResponse(err)
+		}
+		warnings, err = h.validator.ValidateCreate(withRequest(ctx, h.SugaredLogger, req, nil), obj)
+	case admissionv1.Update:
+		obj, oldObj := h.newObject(), h.newObject()
+		if err := h.decoder.DecodeRaw(req.Object, obj); err != nil {
+			return erroredResponse(err)
+		}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return erroredResponse(err)
+		}
+		warnings, err = h.validator.ValidateUpdate(withRequest(ctx, h.SugaredLogger, req, oldObj), oldObj, obj)
+	case admissionv1.Delete:
+		// OldObject contains the object being deleted
+		obj := h.newObject()
+		if err := h.decoder.DecodeRaw(req.OldObject, obj); err != nil {
+			return erroredResponse(err)
+		}
+		warnings, err = h.validator.ValidateDelete(withRequest(ctx, h.SugaredLogger, req, nil), obj)
+	default:
+		return admission.Allowed("")
+	}
+
+	if err != nil {
+		return Deny(groupKind(req), req.Name, err).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
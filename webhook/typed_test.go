@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type configMapValidator struct {
+	old *corev1.ConfigMap
+}
+
+func (v *configMapValidator) ValidateCreate(ctx context.Context, obj *corev1.ConfigMap) (Warnings, error) {
+	if !apis.IsInCreate(ctx) {
+		return nil, errors.New("not in create")
+	}
+	if obj.Data["panic"] != "" {
+		panic(obj.Data["panic"])
+	}
+	errs := field.ErrorList{}
+	if obj.Data["key"] == "" {
+		errs = append(errs, field.Required(field.NewPath("data", "key"), "key is required"))
+	}
+	return Warnings{"deprecated"}, errs.ToAggregate()
+}
+
+func (v *configMapValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *corev1.ConfigMap) (Warnings, error) {
+	v.old, _ = OldObject[*corev1.ConfigMap](ctx)
+	if oldObj.Data["key"] != newObj.Data["key"] {
+		return nil, field.Forbidden(field.NewPath("data", "key"), "key is immutable")
+	}
+	return nil, nil
+}
+
+func (v *configMapValidator) ValidateDelete(ctx context.Context, obj *corev1.ConfigMap) (Warnings, error) {
+	return nil, apierrors.NewForbidden(corev1.Resource("configmaps"), obj.Name, errors.New("protected"))
+}
+
+func newConfigMap() *corev1.ConfigMap { return &corev1.ConfigMap{} }
+
+func configMapRequest(op admissionv1.Operation, obj, old *corev1.ConfigMap) admission.Request {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "uid",
+		Name:      "cm",
+		Operation: op,
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+	}}
+	if obj != nil {
+		req.Object = runtime.RawExtension{Raw: mustMarshal(obj)}
+	}
+	if old != nil {
+		req.OldObject = runtime.RawExtension{Raw: mustMarshal(old)}
+	}
+	return req
+}
+
+func mustMarshal(obj any) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func configMapWith(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm"},
+		Data:       data,
+	}
+}
+
+func TestValidatingWebhookFor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	validator := &configMapValidator{}
+	hook := ValidatingWebhookFor(ctx, clientgoscheme.Scheme, newConfigMap, validator)
+
+	resp := hook.Handle(ctx, configMapRequest(admissionv1.Create, configMapWith(map[string]string{"key": "value"}), nil))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Warnings).To(Equal([]string{"deprecated"}))
+
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Create, configMapWith(nil), nil))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Warnings).To(Equal([]string{"deprecated"}))
+	g.Expect(resp.Result.Code).To(BeEquivalentTo(http.StatusUnprocessableEntity))
+	g.Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonInvalid))
+	g.Expect(resp.Result.Details.Causes).To(HaveLen(1))
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal("data.key"))
+
+	old := configMapWith(map[string]string{"key": "old"})
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Update, configMapWith(map[string]string{"key": "new"}), old))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal("data.key"))
+	g.Expect(validator.old).NotTo(BeNil())
+	g.Expect(validator.old.Data["key"]).To(Equal("old"))
+
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Delete, nil, old))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonForbidden))
+
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Create, configMapWith(map[string]string{"panic": "boom"}), nil))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+	g.Expect(resp.Result.Message).To(ContainSubstring("boom"))
+
+	req := configMapRequest(admissionv1.Create, nil, nil)
+	req.Object = runtime.RawExtension{Raw: []byte("{")}
+	resp = hook.Handle(ctx, req)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Code).To(BeEquivalentTo(http.StatusBadRequest))
+}
+
+func TestDefaultingWebhookFor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	var inUpdate bool
+	hook := DefaultingWebhookFor(ctx, clientgoscheme.Scheme, newConfigMap, DefaulterFunc[*corev1.ConfigMap](func(ctx context.Context, obj *corev1.ConfigMap) error {
+		if obj.Data["fail"] != "" {
+			return field.Invalid(field.NewPath("data", "fail"), obj.Data["fail"], "cannot default")
+		}
+		if old, ok := OldObject[*corev1.ConfigMap](ctx); ok {
+			inUpdate = apis.IsInUpdate(ctx) && old.Data["key"] == "old"
+		}
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+		obj.Labels["defaulted"] = "true"
+		return nil
+	}))
+
+	resp := hook.Handle(ctx, configMapRequest(admissionv1.Create, configMapWith(nil), nil))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Patches).To(HaveLen(1))
+	g.Expect(resp.Patches[0].Path).To(Equal("/metadata/labels"))
+
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Update, configMapWith(nil), configMapWith(map[string]string{"key": "old"})))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(inUpdate).To(BeTrue())
+
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Create, configMapWith(map[string]string{"fail": "x"}), nil))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal("data.fail"))
+
+	resp = hook.Handle(ctx, configMapRequest(admissionv1.Delete, nil, configMapWith(nil)))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Patches).To(BeEmpty())
+}
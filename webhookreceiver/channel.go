@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// MapFunc returns the objects to reconcile because of an event
+type MapFunc func(ctx context.Context, event *Event) ([]client.Object, error)
+
+// Channel is a Handler sending a GenericEvent for each object mapped from an event
+// into a buffered channel watched by controllers using Source.
+//
+// Handle does not wait when the channel is full, returning ErrBusy to ask the sender to
+// retry later, so deliveries received by replicas not running the controllers are not lost
+type Channel struct {
+	mapFunc MapFunc
+	events  chan event.GenericEvent
+}
+
+var _ Handler = &Channel{}
+
+// NewChannel returns a Channel mapping events with mapFunc into a channel buffering size events
+func NewChannel(mapFunc MapFunc, size int) *Channel {
+	return &Channel{mapFunc: mapFunc, events: make(chan event.GenericEvent, size)}
+}
+
+// Handle implements Handler
+func (c *Channel) Handle(ctx context.Context, ev *Event) error {
+	objs, err := c.mapFunc(ctx, ev)
+	if err != nil {
+		return fmt.Errorf("map %s %s event failed: %w", ev.Provider, ev.Type, err)
+	}
+	if len(objs) > cap(c.events)-len(c.events) {
+		return ErrBusy
+	}
+	for _, obj := range objs {
+		select {
+		case c.events <- event.GenericEvent{Object: obj}:
+		default:
+			return ErrBusy
+		}
+	}
+	return nil
+}
+
+// Events returns the channel of generic events
+func (c *Channel) Events() <-chan event.GenericEvent {
+	return c.events
+}
+
+// Source returns a source of the generic events handled by handler, to be watched by a controller
+func (c *Channel) Source(handler handler.EventHandler) source.Source {
+	return source.Channel[client.Object](c.events, handler)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+func configMap(name string) client.Object {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+}
+
+func TestChannel(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	channel := NewChannel(func(_ context.Context, event *Event) ([]client.Object, error) {
+		if event.Type == "invalid" {
+			return nil, errors.New("invalid")
+		}
+		return []client.Object{configMap(event.DeliveryID)}, nil
+	}, 1)
+	g.Expect(channel.Source(&handler.EnqueueRequestForObject{})).NotTo(BeNil())
+
+	g.Expect(channel.Handle(ctx, &Event{Provider: "github", Type: "push", DeliveryID: "a"})).To(Succeed())
+	g.Expect(channel.Handle(ctx, &Event{Provider: "github", Type: "push", DeliveryID: "b"})).To(MatchError(ErrBusy))
+	g.Expect(channel.Handle(ctx, &Event{Provider: "github", Type: "invalid"})).To(MatchError("map github invalid event failed: invalid"))
+
+	received := <-channel.Events()
+	g.Expect(received.Object.GetName()).To(Equal("a"))
+	g.Expect(channel.Handle(ctx, &Event{Provider: "github", Type: "push", DeliveryID: "b"})).To(Succeed())
+}
+
+func TestServer(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	receiver := New(HandlerFunc(func(context.Context, *Event) error { return nil }))
+	receiver.Handle("/github", &GitHub{Secret: secret})
+	server := NewServer("127.0.0.1:0", receiver)
+	g.Expect(server.NeedLeaderElection()).To(BeFalse())
+
+	listener, err := net.Listen("tcp", server.Addr)
+	g.Expect(err).To(BeNil())
+	done := make(chan error)
+	go func() { done <- server.Serve(ctx, listener) }()
+
+	req := githubRequest(`{}`, "push", "1")
+	req.RequestURI = ""
+	req.URL.Scheme, req.URL.Host = "http", listener.Addr().String()
+	resp, err := http.DefaultClient.Do(req)
+	g.Expect(err).To(BeNil())
+	g.Expect(resp.Body.Close()).To(Succeed())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+	cancel()
+	g.Expect(<-done).To(Succeed())
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhookreceiver receives webhook callbacks of external systems like GitHub,
+// GitLab or Harbor, verifying their signature or secret token, rejecting replayed
+// deliveries and decoding their payload before handing them to a Handler.
+//
+// Channel is a Handler converting events into GenericEvents of a channel
+// watched by controllers, and Server serves the receiver as a manager runnable:
+//
+//	events := webhookreceiver.NewChannel(func(ctx context.Context, event *webhookreceiver.Event) ([]client.Object, error) {
+//		push, _ := webhookreceiver.PayloadAs[PushPayload](event)
+//		return reposOf(ctx, push)
+//	}, 100)
+//	receiver := webhookreceiver.New(events, webhookreceiver.Schema[PushPayload]("github", "push"))
+//	receiver.Handle("/github", &webhookreceiver.GitHub{Secret: secret})
+//	err := mgr.Add(webhookreceiver.NewServer(":8090", receiver))
+//
+//	err = ctrl.NewControllerManagedBy(mgr).For(&Repository{}).
+//		WatchesRawSource(events.Source(&handler.EnqueueRequestForObject{})).
+//		Complete(reconciler)
+package webhookreceiver
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// GitHubSignatureHeader is the HMAC-SHA256 signature of GitHub payloads
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+	// GitHubEventHeader is the type of GitHub events
+	GitHubEventHeader = "X-GitHub-Event"
+	// GitHubDeliveryHeader is the delivery id of GitHub events
+	GitHubDeliveryHeader = "X-GitHub-Delivery"
+
+	// GitLabTokenHeader is the secret token of GitLab deliveries
+	GitLabTokenHeader = "X-Gitlab-Token"
+	// GitLabEventHeader is the type of GitLab events
+	GitLabEventHeader = "X-Gitlab-Event"
+	// GitLabDeliveryHeader is the delivery id of GitLab events
+	GitLabDeliveryHeader = "X-Gitlab-Event-UUID"
+
+	// HarborAuthHeader is the auth header configured in Harbor webhook policies
+	HarborAuthHeader = "Authorization"
+)
+
+var errMissingSecret = errors.New("no secret is configured")
+
+// GitHub verifies deliveries signed with the webhook secret,
+// see https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+type GitHub struct {
+	// Secret of the webhook
+	Secret []byte
+}
+
+var _ Provider = &GitHub{}
+
+// Name returns github
+func (p *GitHub) Name() string { return "github" }
+
+// Verify checks the HMAC-SHA256 signature of the payload
+func (p *GitHub) Verify(req *http.Request, payload []byte) error {
+	return verifyHMAC(p.Secret, req.Header.Get(GitHubSignatureHeader), "sha256=", payload)
+}
+
+// Identify returns the event and delivery headers
+func (p *GitHub) Identify(req *http.Request, _ []byte) (eventType, deliveryID string, err error) {
+	return identifyByHeaders(req, GitHubEventHeader, GitHubDeliveryHeader)
+}
+
+// GitLab verifies deliveries sending the webhook secret token,
+// see https://docs.gitlab.com/ee/user/project/integrations/webhooks.html
+type GitLab struct {
+	// Token is the secret token of the webhook
+	Token string
+}
+
+var _ Provider = &GitLab{}
+
+// Name returns gitlab
+func (p *GitLab) Name() string { return "gitlab" }
+
+// Verify compares the secret token
+func (p *GitLab) Verify(req *http.Request, _ []byte) error {
+	return verifyToken(p.Token, req.Header.Get(GitLabTokenHeader))
+}
+
+// Identify returns the event and delivery headers
+func (p *GitLab) Identify(req *http.Request, _ []byte) (eventType, deliveryID string, err error) {
+	return identifyByHeaders(req, GitLabEventHeader, GitLabDeliveryHeader)
+}
+
+// Harbor verifies deliveries sending the auth header of the webhook policy,
+// see https://goharbor.io/docs/main/working-with-projects/project-configuration/configure-webhooks/
+type Harbor struct {
+	// AuthHeader is the auth header of the webhook policy
+	AuthHeader string
+}
+
+var _ Provider = &Harbor{}
+
+// Name returns harbor
+func (p *Harbor) Name() string { return "harbor" }
+
+// Verify compares the auth header
+func (p *Harbor) Verify(req *http.Request, _ []byte) error {
+	return verifyToken(p.AuthHeader, req.Header.Get(HarborAuthHeader))
+}
+
+// Identify returns the type field of the payload, Harbor does not send delivery ids
+func (p *Harbor) Identify(_ *http.Request, payload []byte) (eventType, deliveryID string, err error) {
+	event := struct {
+		Type string `json:"type"`
+	}{}
+	if err = json.Unmarshal(payload, &event); err != nil {
+		return "", "", fmt.Errorf("decode harbor event failed: %w", err)
+	}
+	if event.Type == "" {
+		return "", "", fmt.Errorf("harbor event has no type")
+	}
+	return event.Type, "", nil
+}
+
+// verifyHMAC checks the hex encoded HMAC-SHA256 signature of payload prefixed with prefix
+func verifyHMAC(secret []byte, signature, prefix string, payload []byte) error {
+	if len(secret) == 0 {
+		return errMissingSecret
+	}
+	encoded, ok := strings.CutPrefix(signature, prefix)
+	if !ok {
+		return fmt.Errorf("missing %s signature", strings.TrimSuffix(prefix, "="))
+	}
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(decoded, mac.Sum(nil)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// verifyToken compares token in constant time
+func verifyToken(expected, token string) error {
+	if expected == "" {
+		return errMissingSecret
+	}
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(token)) != 1 {
+		return fmt.Errorf("token mismatch")
+	}
+	return nil
+}
+
+// identifyByHeaders returns the event type and delivery id headers, requiring the event type
+func identifyByHeaders(req *http.Request, eventHeader, deliveryHeader string) (eventType, deliveryID string, err error) {
+	eventType = req.Header.Get(eventHeader)
+	if eventType == "" {
+		return "", "", fmt.Errorf("missing %s header", eventHeader)
+	}
+	return eventType, req.Header.Get(deliveryHeader), nil
+}
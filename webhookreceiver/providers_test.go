@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGitHub(t *testing.T) {
+	g := NewGomegaWithT(t)
+	provider := &GitHub{Secret: secret}
+	req := githubRequest(`{}`, "push", "id")
+
+	g.Expect(provider.Verify(req, []byte(`{}`))).To(Succeed())
+	g.Expect(provider.Verify(req, []byte(`{"changed":true}`))).To(MatchError("signature mismatch"))
+	g.Expect((&GitHub{}).Verify(req, []byte(`{}`))).To(MatchError(errMissingSecret))
+	req.Header.Del(GitHubSignatureHeader)
+	g.Expect(provider.Verify(req, []byte(`{}`))).To(MatchError("missing sha256 signature"))
+
+	eventType, deliveryID, err := provider.Identify(req, nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(eventType).To(Equal("push"))
+	g.Expect(deliveryID).To(Equal("id"))
+}
+
+func TestGitLab(t *testing.T) {
+	g := NewGomegaWithT(t)
+	provider := &GitLab{Token: "token"}
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(GitLabTokenHeader, "token")
+	req.Header.Set(GitLabEventHeader, "Push Hook")
+	req.Header.Set(GitLabDeliveryHeader, "uuid")
+
+	g.Expect(provider.Verify(req, nil)).To(Succeed())
+	g.Expect((&GitLab{Token: "other"}).Verify(req, nil)).To(MatchError("token mismatch"))
+	g.Expect((&GitLab{}).Verify(req, nil)).To(MatchError(errMissingSecret))
+
+	eventType, deliveryID, err := provider.Identify(req, nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(eventType).To(Equal("Push Hook"))
+	g.Expect(deliveryID).To(Equal("uuid"))
+
+	req.Header.Del(GitLabEventHeader)
+	_, _, err = provider.Identify(req, nil)
+	g.Expect(err).To(MatchError("missing X-Gitlab-Event header"))
+}
+
+func TestHarbor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	provider := &Harbor{AuthHeader: "Bearer token"}
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(HarborAuthHeader, "Bearer token")
+
+	g.Expect(provider.Verify(req, nil)).To(Succeed())
+	req.Header.Del(HarborAuthHeader)
+	g.Expect(provider.Verify(req, nil)).To(MatchError("token mismatch"))
+
+	eventType, deliveryID, err := provider.Identify(req, []byte(`{"type":"PUSH_ARTIFACT","occur_at":1}`))
+	g.Expect(err).To(BeNil())
+	g.Expect(eventType).To(Equal("PUSH_ARTIFACT"))
+	g.Expect(deliveryID).To(BeEmpty())
+	_, _, err = provider.Identify(req, []byte(`{}`))
+	g.Expect(err).To(MatchError("harbor event has no type"))
+}
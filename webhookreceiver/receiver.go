@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/cache"
+	"github.com/AlaudaDevops/pkg/clock"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// DefaultMaxPayloadSize is the maximum size of payloads, the GitHub limit
+	DefaultMaxPayloadSize = 25 << 20
+	// DefaultReplayWindow is how long delivery ids are remembered to reject replays
+	DefaultReplayWindow = time.Hour
+	// DefaultMaxDeliveries is the maximum number of delivery ids remembered
+	DefaultMaxDeliveries = 10000
+)
+
+// ErrBusy is returned by handlers which cannot accept events now,
+// the sender is asked to retry the delivery later
+var ErrBusy = errors.New("webhook receiver is busy")
+
+// Event is a verified webhook delivery
+type Event struct {
+	// Provider name, e.g. github
+	Provider string
+	// Type of the event, e.g. push
+	Type string
+	// DeliveryID uniquely identifies the delivery, a hash of the payload when the provider sends no id
+	DeliveryID string
+	// Header of the request
+	Header http.Header
+	// Raw payload
+	Raw []byte
+	// Payload decoded with the schema registered for the provider and type, nil when none is registered
+	Payload any
+	// ReceivedAt is the time the delivery was received
+	ReceivedAt time.Time
+}
+
+// Provider verifies and identifies the webhook deliveries of an external system
+type Provider interface {
+	// Name of the provider, e.g. github
+	Name() string
+	// Verify returns an error if the request was not sent by the provider, payload is the request body
+	Verify(req *http.Request, payload []byte) error
+	// Identify returns the event type and the delivery id of a verified request.
+	// The delivery id is empty when the provider does not send one
+	Identify(req *http.Request, payload []byte) (eventType, deliveryID string, err error)
+}
+
+// Handler handles verified events. Returning ErrBusy asks the sender to retry later
+type Handler interface {
+	Handle(ctx context.Context, event *Event) error
+}
+
+// HandlerFunc is a function implementing Handler
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handle implements Handler
+func (f HandlerFunc) Handle(ctx context.Context, event *Event) error {
+	return f(ctx, event)
+}
+
+// Option configures a Receiver
+type Option func(*Receiver)
+
+// WithMaxPayloadSize sets the maximum size of payloads, defaults to DefaultMaxPayloadSize
+func WithMaxPayloadSize(size int64) Option {
+	return func(r *Receiver) {
+		r.maxPayloadSize = size
+	}
+}
+
+// WithReplayWindow sets how long delivery ids are remembered to reject replays,
+// defaults to DefaultReplayWindow. Zero disables replay protection
+func WithReplayWindow(window time.Duration) Option {
+	return func(r *Receiver) {
+		r.replayWindow = window
+	}
+}
+
+// WithClock sets the clock used to timestamp events and expire delivery ids
+func WithClock(clock clock.PassiveClock) Option {
+	return func(r *Receiver) {
+		r.clock = clock
+	}
+}
+
+// WithSchema decodes the json payloads of a provider event type into the value returned by newPayload
+func WithSchema(provider, eventType string, newPayload func() any) Option {
+	return func(r *Receiver) {
+		r.schemas[schemaKey{provider: provider, eventType: eventType}] = newPayload
+	}
+}
+
+// Schema decodes the json payloads of a provider event type into a *T
+func Schema[T any](provider, eventType string) Option {
+	return WithSchema(provider, eventType, func() any { return new(T) })
+}
+
+// PayloadAs returns the payload of the event decoded with Schema[T]
+func PayloadAs[T any](event *Event) (*T, bool) {
+	payload, ok := event.Payload.(*T)
+	return payload, ok
+}
+
+type schemaKey struct {
+	provider  string
+	eventType string
+}
+
+// Receiver is an http.Handler receiving webhook deliveries of the providers
+// registered with Handle and passing the verified ones to a Handler.
+//
+// It responds with 202 when the event is handled, 200 when the delivery is a replay,
+// 401 when the verification fails, 400 when the payload is invalid
+// and 503 when the handler returns ErrBusy
+type Receiver struct {
+	handler        Handler
+	maxPayloadSize int64
+	replayWindow   time.Duration
+	clock          clock.PassiveClock
+	schemas        map[schemaKey]func() any
+	mux            *http.ServeMux
+
+	// lock makes checking and recording deliveries atomic
+	lock       sync.Mutex
+	deliveries *cache.Cache[string, struct{}]
+}
+
+var _ http.Handler = &Receiver{}
+
+// New returns a Receiver passing events to handler
+func New(handler Handler, opts ...Option) *Receiver {
+	r := &Receiver{
+		handler:        handler,
+		maxPayloadSize: DefaultMaxPayloadSize,
+		replayWindow:   DefaultReplayWindow,
+		clock:          clock.RealClock{},
+		schemas:        map[schemaKey]func() any{},
+		mux:            http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.replayWindow > 0 {
+		r.deliveries = cache.New[string, struct{}](
+			cache.WithTTL(r.replayWindow),
+			cache.WithMaxEntries(DefaultMaxDeliveries),
+			cache.WithClock(r.clock),
+		)
+	}
+	return r
+}
+
+// Handle receives the deliveries of provider at path
+func (r *Receiver) Handle(path string, provider Provider) {
+	r.mux.Handle(path, &providerHandler{receiver: r, path: path, provider: provider})
+}
+
+// ServeHTTP implements http.Handler
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
+
+type providerHandler struct {
+	receiver *Receiver
+	path     string
+	provider Provider
+}
+
+func (h *providerHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r := h.receiver
+	name := h.provider.Name()
+	logger := logging.FromContext(req.Context()).With("provider", name, "path", h.path)
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.maxPayloadSize))
+	if err != nil {
+		status := http.StatusBadRequest
+		if maxBytesErr := (&http.MaxBytesError{}); errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("read payload failed: %s", err), status)
+		return
+	}
+	if err = h.provider.Verify(req, payload); err != nil {
+		logger.Infow("webhook delivery verification failed", "err", err)
+		http.Error(w, "verification failed", http.StatusUnauthorized)
+		return
+	}
+	event, err := r.event(h.provider, req, payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	logger = logger.With("event", event.Type, "delivery", event.DeliveryID)
+
+	replayKey := h.path + "/" + event.DeliveryID
+	if !r.record(replayKey) {
+		logger.Debugw("ignoring replayed webhook delivery")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err = r.handler.Handle(req.Context(), event); err != nil {
+		// the delivery is expected to be sent again
+		r.forget(replayKey)
+		if errors.Is(err, ErrBusy) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		logger.Errorw("handle webhook event failed", "err", err)
+		http.Error(w, "handle event failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// event returns the event of a verified delivery, decoding its payload when a schema is registered
+func (r *Receiver) event(provider Provider, req *http.Request, payload []byte) (*Event, error) {
+	eventType, deliveryID, err := provider.Identify(req, payload)
+	if err != nil {
+		return nil, err
+	}
+	if deliveryID == "" {
+		sum := sha256.Sum256(payload)
+		deliveryID = hex.EncodeToString(sum[:])
+	}
+	event := &Event{
+		Provider:   provider.Name(),
+		Type:       eventType,
+		DeliveryID: deliveryID,
+		Header:     req.Header.Clone(),
+		Raw:        payload,
+		ReceivedAt: r.clock.Now(),
+	}
+	if newPayload, ok := r.schemas[schemaKey{provider: event.Provider, eventType: eventType}]; ok {
+		event.Payload = newPayload()
+		if err = json.Unmarshal(payload, event.Payload); err != nil {
+			return nil, fmt.Errorf("decode %s %s payload failed: %w", event.Provider, eventType, err)
+		}
+	}
+	return event, nil
+}
+
+// record remembers the delivery and returns false if it was already received
+func (r *Receiver) record(key string) bool {
+	if r.deliveries == nil {
+		return true
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.deliveries.Get(key); ok {
+		return false
+	}
+	r.deliveries.Set(key, struct{}{})
+	return true
+}
+
+// forget removes a delivery whose handling failed
+func (r *Receiver) forget(key string) {
+	if r.deliveries != nil {
+		r.deliveries.Delete(key)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/AlaudaDevops/pkg/clock"
+)
+
+var secret = []byte("secret")
+
+type pushPayload struct {
+	Ref string `json:"ref"`
+}
+
+// githubRequest returns a GitHub delivery signed with secret
+func githubRequest(payload, eventType, delivery string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/github", strings.NewReader(payload))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	req.Header.Set(GitHubSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(GitHubEventHeader, eventType)
+	req.Header.Set(GitHubDeliveryHeader, delivery)
+	return req
+}
+
+func serve(receiver *Receiver, req *http.Request) int {
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestReceiver(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	var events []*Event
+	receiver := New(HandlerFunc(func(_ context.Context, event *Event) error {
+		events = append(events, event)
+		return nil
+	}), Schema[pushPayload]("github", "push"), WithClock(fakeClock), WithReplayWindow(time.Minute))
+	receiver.Handle("/github", &GitHub{Secret: secret})
+
+	g.Expect(serve(receiver, githubRequest(`{"ref":"refs/heads/main"}`, "push", "1"))).To(Equal(http.StatusAccepted))
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0].Provider).To(Equal("github"))
+	g.Expect(events[0].Type).To(Equal("push"))
+	g.Expect(events[0].DeliveryID).To(Equal("1"))
+	g.Expect(events[0].ReceivedAt).To(Equal(now))
+	payload, ok := PayloadAs[pushPayload](events[0])
+	g.Expect(ok).To(BeTrue())
+	g.Expect(payload.Ref).To(Equal("refs/heads/main"))
+
+	// events without schema keep the raw payload only
+	g.Expect(serve(receiver, githubRequest(`{}`, "ping", "2"))).To(Equal(http.StatusAccepted))
+	g.Expect(events[1].Payload).To(BeNil())
+	g.Expect(string(events[1].Raw)).To(Equal(`{}`))
+
+	// replays are acknowledged without being handled until the replay window ends
+	g.Expect(serve(receiver, githubRequest(`{"ref":"refs/heads/main"}`, "push", "1"))).To(Equal(http.StatusOK))
+	g.Expect(events).To(HaveLen(2))
+	fakeClock.Advance(2 * time.Minute)
+	g.Expect(serve(receiver, githubRequest(`{"ref":"refs/heads/main"}`, "push", "1"))).To(Equal(http.StatusAccepted))
+	g.Expect(events).To(HaveLen(3))
+
+	forged := githubRequest(`{"ref":"refs/heads/main"}`, "push", "3")
+	forged.Header.Set(GitHubSignatureHeader, "sha256=00")
+	g.Expect(serve(receiver, forged)).To(Equal(http.StatusUnauthorized))
+	g.Expect(serve(receiver, githubRequest(`{"ref":1}`, "push", "4"))).To(Equal(http.StatusBadRequest))
+	g.Expect(serve(receiver, githubRequest(`{}`, "", "5"))).To(Equal(http.StatusBadRequest))
+	g.Expect(serve(receiver, httptest.NewRequest(http.MethodGet, "/github", nil))).To(Equal(http.StatusMethodNotAllowed))
+	g.Expect(serve(receiver, httptest.NewRequest(http.MethodPost, "/gitlab", nil))).To(Equal(http.StatusNotFound))
+	g.Expect(events).To(HaveLen(3))
+}
+
+func TestReceiver_handlerErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	var err error
+	handled := 0
+	receiver := New(HandlerFunc(func(context.Context, *Event) error {
+		handled++
+		return err
+	}), WithMaxPayloadSize(10))
+	receiver.Handle("/github", &GitHub{Secret: secret})
+
+	// failed deliveries are handled again when they are sent again
+	err = ErrBusy
+	g.Expect(serve(receiver, githubRequest(`{}`, "push", "1"))).To(Equal(http.StatusServiceUnavailable))
+	err = errors.New("failed")
+	g.Expect(serve(receiver, githubRequest(`{}`, "push", "1"))).To(Equal(http.StatusInternalServerError))
+	err = nil
+	g.Expect(serve(receiver, githubRequest(`{}`, "push", "1"))).To(Equal(http.StatusAccepted))
+	g.Expect(handled).To(Equal(3))
+
+	g.Expect(serve(receiver, githubRequest(`{"ref":"refs/heads/main"}`, "push", "2"))).To(Equal(http.StatusRequestEntityTooLarge))
+	g.Expect(handled).To(Equal(3))
+}
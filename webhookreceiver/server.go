@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// DefaultShutdownTimeout is the time given to in flight deliveries when the server stops
+const DefaultShutdownTimeout = 10 * time.Second
+
+// Server is a manager.Runnable serving a Receiver over http.
+// It runs on every replica, not only on the leader
+type Server struct {
+	// Addr is the address listened, e.g. :8090
+	Addr string
+	// Handler serves the deliveries, usually a Receiver
+	Handler http.Handler
+	// ShutdownTimeout defaults to DefaultShutdownTimeout
+	ShutdownTimeout time.Duration
+}
+
+var (
+	_ manager.Runnable               = &Server{}
+	_ manager.LeaderElectionRunnable = &Server{}
+)
+
+// NewServer returns a Server serving handler at addr
+func NewServer(addr string, handler http.Handler) *Server {
+	return &Server{Addr: addr, Handler: handler}
+}
+
+// NeedLeaderElection returns false, webhooks are received by all replicas
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until ctx is done, then waits for in flight deliveries
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves on listener until ctx is done, then waits for in flight deliveries
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	logger := logging.FromContext(ctx)
+	server := &http.Server{
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		// in flight deliveries are not cancelled when ctx is done, but during shutdown
+		BaseContext: func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+	errs := make(chan error, 1)
+	go func() {
+		logger.Infow("serving webhook receiver", "addr", listener.Addr().String())
+		errs <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	timeout := s.ShutdownTimeout
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
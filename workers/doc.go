@@ -15,4 +15,5 @@ limitations under the License.
 */
 
 // Package workers for job worker managed by controller-runtime manager
+// and a generic Pool running items with bounded concurrency, serialized per key
 package workers
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/AlaudaDevops/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// ErrPoolClosed is returned when submitting tasks to a pool after Wait was called
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// PoolFunc handles an item submitted to a Pool
+type PoolFunc[T any] func(ctx context.Context, item T) error
+
+// PoolHooks are called by a Pool when tasks change state, e.g. to record metrics.
+// Hooks are called concurrently and must not block
+type PoolHooks struct {
+	// OnDepth is called with the number of submitted tasks not yet done whenever it changes
+	OnDepth func(depth int)
+	// OnStart is called when the task of key starts running, after waiting for wait since submitted
+	OnStart func(key string, wait time.Duration)
+	// OnDone is called when the task of key is done, with its duration and error
+	OnDone func(key string, duration time.Duration, err error)
+}
+
+// MetricsHooks returns hooks reporting the pool depth in the metrics.QueueDepth gauge under name
+func MetricsHooks(name string) PoolHooks {
+	return PoolHooks{
+		OnDepth: func(depth int) {
+			metrics.SetQueueDepth(name, depth)
+		},
+	}
+}
+
+// PoolOption configures a Pool
+type PoolOption func(*poolOptions)
+
+type poolOptions struct {
+	hooks  PoolHooks
+	logger *zap.SugaredLogger
+}
+
+// WithPoolHooks sets the hooks called by the pool
+func WithPoolHooks(hooks PoolHooks) PoolOption {
+	return func(o *poolOptions) {
+		o.hooks = hooks
+	}
+}
+
+// WithPoolLogger sets the logger used to report recovered panics
+func WithPoolLogger(logger *zap.SugaredLogger) PoolOption {
+	return func(o *poolOptions) {
+		o.logger = logger
+	}
+}
+
+// Pool runs submitted items with bounded concurrency. Items submitted with the same key
+// never run concurrently and run in the order they were submitted.
+// Panics are recovered into errors. Once the context of the pool is done pending items
+// are not run anymore and fail with the context error
+type Pool[T any] struct {
+	ctx     context.Context
+	fn      PoolFunc[T]
+	slots   chan struct{}
+	options poolOptions
+
+	lock    sync.Mutex
+	pending map[string][]poolTask[T]
+	depth   int
+	closed  bool
+	errs    []error
+	wg      sync.WaitGroup
+}
+
+type poolTask[T any] struct {
+	item      T
+	submitted time.Time
+}
+
+// NewPool returns a pool running fn for at most concurrency items at the same time
+// until ctx is done, concurrency below 1 is treated as 1
+func NewPool[T any](ctx context.Context, concurrency int, fn PoolFunc[T], opts ...PoolOption) *Pool[T] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	p := &Pool[T]{
+		ctx:     ctx,
+		fn:      fn,
+		slots:   make(chan struct{}, concurrency),
+		pending: map[string][]poolTask[T]{},
+		options: poolOptions{logger: zap.NewNop().Sugar()},
+	}
+	for _, opt := range opts {
+		opt(&p.options)
+	}
+	return p
+}
+
+// Submit queues item to run under key without blocking.
+// Returns ErrPoolClosed if Wait was already called
+func (p *Pool[T]) Submit(key string, item T) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	p.wg.Add(1)
+	p.setDepth(p.depth + 1)
+
+	task := poolTask[T]{item: item, submitted: time.Now()}
+	if queue, running := p.pending[key]; running {
+		// the goroutine running key picks it up when the previous items are done
+		p.pending[key] = append(queue, task)
+		return nil
+	}
+	p.pending[key] = nil
+	go p.runKey(key, task)
+	return nil
+}
+
+// Wait closes the pool for new items, waits for all submitted items to be done
+// and returns their errors joined
+func (p *Pool[T]) Wait() error {
+	p.lock.Lock()
+	p.closed = true
+	p.lock.Unlock()
+
+	p.wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return errors.Join(p.errs...)
+}
+
+// Depth returns the number of submitted items not yet done
+func (p *Pool[T]) Depth() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.depth
+}
+
+// runKey runs task and then the items queued for the same key until none is left
+func (p *Pool[T]) runKey(key string, task poolTask[T]) {
+	for {
+		p.run(key, task)
+
+		p.lock.Lock()
+		queue := p.pending[key]
+		if len(queue) == 0 {
+			delete(p.pending, key)
+			p.lock.Unlock()
+			return
+		}
+		task = queue[0]
+		p.pending[key] = queue[1:]
+		p.lock.Unlock()
+	}
+}
+
+// run waits for a free slot and runs the task
+func (p *Pool[T]) run(key string, task poolTask[T]) {
+	var err error
+	defer func() {
+		p.lock.Lock()
+		if err != nil {
+			p.errs = append(p.errs, fmt.Errorf("%s: %w", key, err))
+		}
+		p.setDepth(p.depth - 1)
+		p.lock.Unlock()
+		p.wg.Done()
+	}()
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-p.ctx.Done():
+		err = p.ctx.Err()
+		p.done(key, 0, err)
+		return
+	}
+	defer func() { <-p.slots }()
+
+	// a slot may be acquired together with the cancellation
+	if err = p.ctx.Err(); err != nil {
+		p.done(key, 0, err)
+		return
+	}
+
+	if hook := p.options.hooks.OnStart; hook != nil {
+		hook(key, time.Since(task.submitted))
+	}
+	start := time.Now()
+	err = p.call(key, task.item)
+	p.done(key, time.Since(start), err)
+}
+
+// call runs the function converting panics into errors
+func (p *Pool[T]) call(key string, item T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.options.logger.Errorw("recovered panic in pool task", "key", key, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic in pool task %s: %v", key, r)
+		}
+	}()
+	return p.fn(p.ctx, item)
+}
+
+func (p *Pool[T]) done(key string, duration time.Duration, err error) {
+	if hook := p.options.hooks.OnDone; hook != nil {
+		hook(key, duration, err)
+	}
+}
+
+// setDepth must be called holding the lock
+func (p *Pool[T]) setDepth(depth int) {
+	p.depth = depth
+	if hook := p.options.hooks.OnDepth; hook != nil {
+		hook(depth)
+	}
+}
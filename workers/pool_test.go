@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pool", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+	})
+
+	It("bounds the number of concurrent items", func() {
+		var running, maxRunning int32
+		pool := NewPool(ctx, 2, func(ctx context.Context, item int) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				observed := atomic.LoadInt32(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+		for i := 0; i < 8; i++ {
+			Expect(pool.Submit(string(rune('a'+i)), i)).To(Succeed())
+		}
+		Expect(pool.Wait()).To(Succeed())
+		Expect(maxRunning).To(BeEquivalentTo(2))
+		Expect(pool.Depth()).To(BeZero())
+	})
+
+	It("runs items of the same key one at a time in order", func() {
+		lock := sync.Mutex{}
+		order := []int{}
+		var running int32
+		pool := NewPool(ctx, 4, func(ctx context.Context, item int) error {
+			Expect(atomic.AddInt32(&running, 1)).To(BeEquivalentTo(1))
+			time.Sleep(time.Millisecond)
+			lock.Lock()
+			order = append(order, item)
+			lock.Unlock()
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+		for i := 0; i < 5; i++ {
+			Expect(pool.Submit("same", i)).To(Succeed())
+		}
+		Expect(pool.Wait()).To(Succeed())
+		Expect(order).To(Equal([]int{0, 1, 2, 3, 4}))
+	})
+
+	It("returns errors and recovered panics", func() {
+		pool := NewPool(ctx, 2, func(ctx context.Context, item string) error {
+			switch item {
+			case "fail":
+				return errors.New("failed")
+			case "panic":
+				panic("boom")
+			}
+			return nil
+		})
+		Expect(pool.Submit("a", "ok")).To(Succeed())
+		Expect(pool.Submit("b", "fail")).To(Succeed())
+		Expect(pool.Submit("c", "panic")).To(Succeed())
+		err := pool.Wait()
+		Expect(err).To(MatchError(ContainSubstring("b: failed")))
+		Expect(err).To(MatchError(ContainSubstring("c: panic in pool task c: boom")))
+		Expect(pool.Submit("d", "ok")).To(MatchError(ErrPoolClosed))
+	})
+
+	It("does not run pending items once the context is canceled", func() {
+		started := make(chan struct{})
+		var calls int32
+		pool := NewPool(ctx, 1, func(ctx context.Context, item int) error {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		Expect(pool.Submit("a", 1)).To(Succeed())
+		<-started
+		Expect(pool.Submit("b", 2)).To(Succeed())
+		Expect(pool.Submit("a", 3)).To(Succeed())
+		cancel()
+		err := pool.Wait()
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		Expect(calls).To(BeEquivalentTo(1))
+	})
+
+	It("calls the hooks", func() {
+		lock := sync.Mutex{}
+		depths := []int{}
+		done := map[string]error{}
+		started := map[string]bool{}
+		pool := NewPool(ctx, 1, func(ctx context.Context, item string) error {
+			if item == "fail" {
+				return errors.New("failed")
+			}
+			return nil
+		}, WithPoolHooks(PoolHooks{
+			OnDepth: func(depth int) {
+				lock.Lock()
+				defer lock.Unlock()
+				depths = append(depths, depth)
+			},
+			OnStart: func(key string, _ time.Duration) {
+				lock.Lock()
+				defer lock.Unlock()
+				started[key] = true
+			},
+			OnDone: func(key string, _ time.Duration, err error) {
+				lock.Lock()
+				defer lock.Unlock()
+				done[key] = err
+			},
+		}))
+		Expect(pool.Submit("a", "ok")).To(Succeed())
+		Expect(pool.Submit("b", "fail")).To(Succeed())
+		Expect(pool.Wait()).NotTo(Succeed())
+
+		Expect(depths).To(HaveLen(4))
+		Expect(depths[len(depths)-1]).To(BeZero())
+		Expect(started).To(Equal(map[string]bool{"a": true, "b": true}))
+		Expect(done).To(HaveKeyWithValue("a", BeNil()))
+		Expect(done).To(HaveKeyWithValue("b", MatchError("failed")))
+	})
+})
@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package yamlutil decodes yaml documents into go types through their json tags, like
+// sigs.k8s.io/yaml, with a strict mode selectable per call and correct handling of
+// anchors and merge keys.
+//
+// In strict mode unknown fields and duplicate keys are errors. Keys overriding the ones
+// brought by a merge key are not duplicates, as defined by the yaml merge key spec:
+//
+//	defaults: &defaults
+//	  replicas: 1
+//	app:
+//	  <<: *defaults
+//	  replicas: 3 # overrides the merged value
+//
+// Multi-document streams are decoded one document at a time, anchors are scoped to the
+// document defining them and documents whose aliases expand to far more nodes than they
+// contain are rejected.
+//
+//	var deployments []appsv1.Deployment
+//	deployments, err := yamlutil.UnmarshalAll[appsv1.Deployment](data, yamlutil.Strict())
+package yamlutil
@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	mergeTag = "!!merge"
+	// aliasBudget is the number of nodes aliases can expand to in addition to
+	// aliasRatio times the number of nodes of a document
+	aliasBudget = 10000
+	aliasRatio  = 10
+)
+
+type options struct {
+	strict bool
+}
+
+// Option configures decoding
+type Option func(*options)
+
+// Strict makes unknown fields and duplicate keys errors
+func Strict() Option {
+	return func(opts *options) {
+		opts.strict = true
+	}
+}
+
+// Decoder decodes the documents of a yaml stream one at a time
+type Decoder struct {
+	decoder *yaml.Decoder
+	options options
+	index   int
+}
+
+// NewDecoder returns a Decoder reading documents from r
+func NewDecoder(r io.Reader, opts ...Option) *Decoder {
+	d := &Decoder{decoder: yaml.NewDecoder(r)}
+	for _, opt := range opts {
+		opt(&d.options)
+	}
+	return d
+}
+
+// Decode decodes the next non empty document into obj. It returns io.EOF when there are no more documents
+func (d *Decoder) Decode(obj any) error {
+	data, err := d.DecodeJSON()
+	if err != nil {
+		return err
+	}
+	if err = unmarshalJSON(data, obj, d.options.strict); err != nil {
+		return fmt.Errorf("document %d: %w", d.index, err)
+	}
+	return nil
+}
+
+// DecodeJSON converts the next non empty document into json, resolving anchors and merge keys.
+// It returns io.EOF when there are no more documents
+func (d *Decoder) DecodeJSON() ([]byte, error) {
+	for {
+		node := &yaml.Node{}
+		if err := d.decoder.Decode(node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("document %d: %w", d.index+1, err)
+		}
+		d.index++
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+		c := &converter{strict: d.options.strict, nodes: map[*yaml.Node]bool{}}
+		collectNodes(node, c.nodes)
+		c.budget = aliasBudget + aliasRatio*len(c.nodes)
+		value, err := c.convert(node.Content[0])
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", d.index, err)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", d.index, err)
+		}
+		return data, nil
+	}
+}
+
+// Unmarshal decodes the first document of data into obj
+func Unmarshal(data []byte, obj any, opts ...Option) error {
+	err := NewDecoder(bytes.NewReader(data), opts...).Decode(obj)
+	if errors.Is(err, io.EOF) {
+		// like sigs.k8s.io/yaml an empty document leaves obj as is
+		return nil
+	}
+	return err
+}
+
+// UnmarshalAll decodes all the non empty documents of data
+func UnmarshalAll[T any](data []byte, opts ...Option) (list []T, err error) {
+	decoder := NewDecoder(bytes.NewReader(data), opts...)
+	for {
+		obj := new(T)
+		if err = decoder.Decode(obj); errors.Is(err, io.EOF) {
+			return list, nil
+		} else if err != nil {
+			return nil, err
+		}
+		list = append(list, *obj)
+	}
+}
+
+// ToJSON converts the first document of data into json, resolving anchors and merge keys.
+// An empty document is converted to null
+func ToJSON(data []byte, opts ...Option) ([]byte, error) {
+	result, err := NewDecoder(bytes.NewReader(data), opts...).DecodeJSON()
+	if errors.Is(err, io.EOF) {
+		return []byte("null"), nil
+	}
+	return result, err
+}
+
+func unmarshalJSON(data []byte, obj any, strict bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(obj)
+}
+
+func collectNodes(node *yaml.Node, nodes map[*yaml.Node]bool) {
+	nodes[node] = true
+	for _, child := range node.Content {
+		collectNodes(child, nodes)
+	}
+}
+
+// converter converts yaml nodes into values marshalled as json
+type converter struct {
+	strict bool
+	// nodes of the document, aliases to anchors of other documents are errors
+	nodes map[*yaml.Node]bool
+	// budget is the number of nodes left to convert
+	budget int
+}
+
+// resolve returns the node referenced by an alias
+func (c *converter) resolve(node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind != yaml.AliasNode {
+		return node, nil
+	}
+	if !c.nodes[node.Alias] {
+		return nil, fmt.Errorf("line %d: unknown anchor %q referenced", node.Line, node.Value)
+	}
+	return node.Alias, nil
+}
+
+func (c *converter) convert(node *yaml.Node) (any, error) {
+	if c.budget--; c.budget < 0 {
+		return nil, errors.New("document aliases expand to too many nodes")
+	}
+	switch node.Kind {
+	case yaml.AliasNode:
+		alias, err := c.resolve(node)
+		if err != nil {
+			return nil, err
+		}
+		return c.convert(alias)
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!timestamp" {
+			// like sigs.k8s.io/yaml timestamps are kept as written
+			return node.Value, nil
+		}
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case yaml.SequenceNode:
+		list := make([]any, 0, len(node.Content))
+		for _, child := range node.Content {
+			value, err := c.convert(child)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case yaml.MappingNode:
+		return c.convertMapping(node)
+	}
+	return nil, fmt.Errorf("line %d: unsupported yaml node", node.Line)
+}
+
+// convertMapping applies merge keys first so the keys of the mapping override them
+func (c *converter) convertMapping(node *yaml.Node) (map[string]any, error) {
+	result := map[string]any{}
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; key.Kind == yaml.ScalarNode && key.Tag == mergeTag {
+			merges = append(merges, node.Content[i+1])
+		}
+	}
+	for _, merge := range merges {
+		if err := c.merge(result, merge); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, valueNode := node.Content[i], node.Content[i+1]
+		if key.Kind == yaml.ScalarNode && key.Tag == mergeTag {
+			continue
+		}
+		key, err := c.resolve(key)
+		if err != nil {
+			return nil, err
+		}
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+		}
+		if seen[key.Value] && c.strict {
+			return nil, fmt.Errorf("line %d: key %q already set in map", key.Line, key.Value)
+		}
+		seen[key.Value] = true
+		value, err := c.convert(valueNode)
+		if err != nil {
+			return nil, err
+		}
+		result[key.Value] = value
+	}
+	return result, nil
+}
+
+// merge sets the keys of the mappings referenced by a merge key which are not set yet,
+// earlier mappings of a sequence take precedence over later ones
+func (c *converter) merge(result map[string]any, node *yaml.Node) error {
+	node, err := c.resolve(node)
+	if err != nil {
+		return err
+	}
+	var mappings []*yaml.Node
+	switch node.Kind {
+	case yaml.MappingNode:
+		mappings = []*yaml.Node{node}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			child, err := c.resolve(child)
+			if err != nil {
+				return err
+			}
+			if child.Kind != yaml.MappingNode {
+				return fmt.Errorf("line %d: merge key sequences must contain mappings", child.Line)
+			}
+			mappings = append(mappings, child)
+		}
+	default:
+		return fmt.Errorf("line %d: merge key values must be mappings or sequences of mappings", node.Line)
+	}
+	for _, mapping := range mappings {
+		merged, err := c.convertMapping(mapping)
+		if err != nil {
+			return err
+		}
+		for key, value := range merged {
+			if _, ok := result[key]; !ok {
+				result[key] = value
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The AlaudaDevops Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlutil
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type app struct {
+	Name     string            `json:"name"`
+	Replicas int               `json:"replicas"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+func TestUnmarshal_mergeKeys(t *testing.T) {
+	g := NewGomegaWithT(t)
+	data := []byte(`
+defaults: &defaults
+  replicas: 1
+  labels: &labels
+    team: devops
+app:
+  <<: *defaults
+  name: web
+  replicas: 3
+`)
+	obj := map[string]app{}
+	g.Expect(Unmarshal(data, &obj, Strict())).To(Succeed())
+	g.Expect(obj["app"]).To(Equal(app{Name: "web", Replicas: 3, Labels: map[string]string{"team": "devops"}}))
+	g.Expect(obj["defaults"]).To(Equal(app{Replicas: 1, Labels: map[string]string{"team": "devops"}}))
+
+	// earlier mappings of a merge sequence win
+	data = []byte("app:\n  <<: [{replicas: 1}, {replicas: 2, name: web}]\n")
+	g.Expect(Unmarshal(data, &obj, Strict())).To(Succeed())
+	g.Expect(obj["app"]).To(Equal(app{Name: "web", Replicas: 1}))
+
+	_, err := ToJSON([]byte("app:\n  <<: 1\n"))
+	g.Expect(err).To(MatchError(ContainSubstring("merge key values must be mappings")))
+}
+
+func TestUnmarshal_strict(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj := app{}
+	g.Expect(Unmarshal([]byte("name: web\nreplica: 3\n"), &obj)).To(Succeed())
+	g.Expect(obj.Name).To(Equal("web"))
+	err := Unmarshal([]byte("name: web\nreplica: 3\n"), &obj, Strict())
+	g.Expect(err).To(MatchError(ContainSubstring(`unknown field "replica"`)))
+
+	obj = app{}
+	g.Expect(Unmarshal([]byte("name: web\nname: api\n"), &obj)).To(Succeed())
+	g.Expect(obj.Name).To(Equal("api"))
+	err = Unmarshal([]byte("name: web\nname: api\n"), &obj, Strict())
+	g.Expect(err).To(MatchError(`document 1: line 2: key "name" already set in map`))
+}
+
+func TestUnmarshalAll(t *testing.T) {
+	g := NewGomegaWithT(t)
+	data := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: &name first
+data:
+  name: *name
+---
+# empty documents are skipped
+---
+{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "second"}}
+`)
+	cms, err := UnmarshalAll[corev1.ConfigMap](data, Strict())
+	g.Expect(err).To(BeNil())
+	g.Expect(cms).To(HaveLen(2))
+	g.Expect(cms[0].Data).To(Equal(map[string]string{"name": "first"}))
+	g.Expect(cms[1].Name).To(Equal("second"))
+
+	// anchors are scoped to their document
+	_, err = UnmarshalAll[map[string]any]([]byte("a: &a 1\n---\nb: *a\n"))
+	g.Expect(err).To(MatchError(ContainSubstring("document 2")))
+
+	_, err = UnmarshalAll[corev1.ConfigMap]([]byte("metadata: {}\n---\nmetadata: {nam: x}\n"), Strict())
+	g.Expect(err).To(MatchError(ContainSubstring("document 2: json: unknown field \"nam\"")))
+}
+
+func TestToJSON(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	data, err := ToJSON([]byte("a: 1\nb: [true, null, 1.5, \"2024-01-01\", 2024-01-01]\n1: x\n"))
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(Equal(`{"1":"x","a":1,"b":[true,null,1.5,"2024-01-01","2024-01-01"]}`))
+
+	data, err = ToJSON(nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(data)).To(Equal("null"))
+}
+
+func TestToJSON_aliasExpansion(t *testing.T) {
+	g := NewGomegaWithT(t)
+	// each level references the previous one ten times
+	doc := &strings.Builder{}
+	doc.WriteString("l0: &l0 [x, x, x, x, x, x, x, x, x, x]\n")
+	for i := 1; i < 8; i++ {
+		prev := "*l" + string(rune('0'+i-1))
+		doc.WriteString("l" + string(rune('0'+i)) + ": &l" + string(rune('0'+i)) + " [")
+		for j := 0; j < 10; j++ {
+			if j > 0 {
+				doc.WriteString(", ")
+			}
+			doc.WriteString(prev)
+		}
+		doc.WriteString("]\n")
+	}
+
+	_, err := ToJSON([]byte(doc.String()))
+	g.Expect(err).To(MatchError(ContainSubstring("aliases expand to too many nodes")))
+}